@@ -0,0 +1,159 @@
+// Package card renders a team's season as an image card that can be shared after an event.
+package card
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+const (
+	cardWidth  = 960
+	cardHeight = 540
+	margin     = 40
+	maxAwards  = 4 // Awards listed on the card before the rest are counted
+)
+
+var (
+	background = color.RGBA{0x1B, 0x2A, 0x49, 0xFF} // Dark blue
+	banner     = color.RGBA{0xF5, 0x7E, 0x25, 0xFF} // FTC orange
+	tile       = color.RGBA{0x26, 0x3A, 0x63, 0xFF} // Lighter blue behind each statistic
+	white      = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	muted      = color.RGBA{0xAF, 0xBC, 0xD4, 0xFF} // Gray-blue for labels
+	gold       = color.RGBA{0xFF, 0xD1, 0x66, 0xFF} // Awards
+)
+
+// stat is a labeled value shown in a tile on the card.
+type stat struct {
+	label string
+	value string
+}
+
+// Render draws the team's card: its record, latest OPR and npOPR, awards, and next event for the season. Events
+// starting on or after now's date that have no results yet are treated as upcoming, followed by the events the team
+// is registered for but hasn't played in.
+func Render(comparison *query.TeamEventComparison, year int, now time.Time) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	fill(img, img.Bounds(), background)
+
+	// Banner with the team number and name
+	team := comparison.Team
+	fill(img, image.Rect(0, 0, cardWidth, 160), banner)
+	drawText(img, fmt.Sprintf("#%d", team.TeamID), margin, 28, 8, white)
+	drawText(img, fitText(team.Name, 4, cardWidth-2*margin), margin, 104, 4, background)
+
+	// Location and rookie year
+	var location []string
+	for _, part := range []string{team.City, team.StateProv, team.Country} {
+		if part != "" {
+			location = append(location, part)
+		}
+	}
+	details := strings.Join(location, ", ")
+	if team.RookieYear > 0 {
+		details += fmt.Sprintf("  |  Rookie year %d", team.RookieYear)
+	}
+	drawText(img, fitText(details, 2, cardWidth-2*margin), margin, 180, 2, muted)
+
+	// Statistics
+	opr, npOPR := "-", "-"
+	if comparison.Latest != nil {
+		opr = locale.Number("%.2f", comparison.Latest.OPR)
+		npOPR = locale.Number("%.2f", comparison.Latest.NpOPR)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	played := 0
+	var next *query.EventDetails
+	for i := range comparison.Events {
+		event := &comparison.Events[i]
+		if event.HasMetrics || event.DateStart.Before(today) {
+			played++
+		} else if next == nil {
+			next = &event.EventDetails
+		}
+	}
+	if next == nil && len(team.Upcoming) > 0 {
+		upcoming := team.Upcoming[0]
+		next = &query.EventDetails{
+			EventID:   upcoming.EventID,
+			EventCode: upcoming.EventCode,
+			EventName: upcoming.EventName,
+			DateStart: upcoming.DateStart,
+		}
+	}
+	record := team.TotalRecord
+	stats := []stat{
+		{"RECORD", fmt.Sprintf("%d-%d-%d", record.Wins, record.Losses, record.Ties)},
+		{"OPR", opr},
+		{"NP OPR", npOPR},
+		{"EVENTS", fmt.Sprintf("%d", played)},
+	}
+	const gap = 20
+	tileWidth := (cardWidth - 2*margin - (len(stats)-1)*gap) / len(stats)
+	for i, s := range stats {
+		x := margin + i*(tileWidth+gap)
+		fill(img, image.Rect(x, 220, x+tileWidth, 330), tile)
+		drawText(img, s.label, x+16, 236, 2, muted)
+		scale := 5
+		for scale > 2 && textWidth(s.value, scale) > tileWidth-32 {
+			scale--
+		}
+		drawText(img, fitText(s.value, scale, tileWidth-32), x+16, 270, scale, white)
+	}
+
+	// Awards on the left, and the next event on the right
+	columnWidth := (cardWidth - 2*margin - gap) / 2
+	drawText(img, "AWARDS", margin, 360, 2, muted)
+	var awards []string
+	for _, event := range comparison.Events {
+		for _, award := range event.Awards {
+			awards = append(awards, fmt.Sprintf("%s (%s)", award, event.EventCode))
+		}
+	}
+	if len(awards) == 0 {
+		drawText(img, "None yet", margin, 390, 2, white)
+	}
+	for i, award := range awards {
+		y := 390 + i*26
+		if i == maxAwards-1 && len(awards) > maxAwards {
+			drawText(img, fmt.Sprintf("+%d more", len(awards)-i), margin, y, 2, gold)
+			break
+		}
+		drawText(img, fitText(award, 2, columnWidth), margin, y, 2, gold)
+	}
+
+	x := margin + columnWidth + gap
+	drawText(img, "NEXT EVENT", x, 360, 2, muted)
+	if next == nil {
+		drawText(img, "None scheduled", x, 390, 2, white)
+	} else {
+		drawText(img, fitText(next.EventName, 2, columnWidth), x, 390, 2, white)
+		drawText(img, fmt.Sprintf("%s  |  %s", next.EventCode, locale.Date(next.DateStart)), x, 416, 2, muted)
+	}
+
+	// Footer
+	fill(img, image.Rect(0, cardHeight-44, cardWidth, cardHeight), tile)
+	drawText(img, fmt.Sprintf("FTC Standing  |  %d season", year), margin, cardHeight-30, 2, muted)
+	generated := "As of " + locale.Date(now)
+	drawText(img, generated, cardWidth-margin-textWidth(generated, 2), cardHeight-30, 2, muted)
+
+	return img
+}
+
+// WritePNG renders the team's card and writes it to w as a PNG image.
+func WritePNG(w io.Writer, comparison *query.TeamEventComparison, year int, now time.Time) error {
+	return png.Encode(w, Render(comparison, year, now))
+}
+
+// fill paints the rectangle of the image with the color.
+func fill(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// autoLeaderboardCmd ranks teams by the share of their scoring that comes from the autonomous period.
+var autoLeaderboardCmd = &cobra.Command{
+	Use:   "auto-leaderboard [region]",
+	Short: "Rank teams by their share of scoring in the autonomous period",
+	Long: `Rank teams by the share of their non-penalty scoring that comes from the autonomous period, the percentage of
+their npOPR made up by their auto OPR. Reliable autonomous scoring is worth the most in the playoffs, where every
+alliance is strong in teleop. Teams whose npOPR isn't positive are left out. The same metrics can be shown in the
+team rankings with 'ftc team-rankings --sort autopct'.`,
+	Example: `  # Show the teams in a region by their share of scoring in autonomous
+  ftc auto-leaderboard USNC
+
+  # Show the top 10 teams at an event
+  ftc auto-leaderboard --event USNCRAQ --limit 10`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := ""
+		if len(args) > 0 {
+			region = args[0]
+		}
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		eventCodes, _ := cmd.Flags().GetStringSlice("event")
+		country, _ := cmd.Flags().GetString("country")
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", limit)
+		}
+		includeUnofficial, _ := cmd.Flags().GetBool("include-unofficial")
+		if region != "" {
+			var err error
+			if region, err = resolveRegion(region); err != nil {
+				return err
+			}
+		}
+		if err := requireEvents(eventCodes, year); err != nil {
+			return err
+		}
+
+		performances, err := query.AutoLeaderboardQuery(region, country, eventCodes, year, includeUnofficial)
+		if err != nil {
+			return err
+		}
+		output := terminal.RenderAutoLeaderboard(performances, region, eventsLabel(eventCodes), year, limit)
+		fmt.Println(output)
+		printDataAsOf(rankingsScope(region, eventCodes, year))
+		return nil
+	},
+}
+
+func init() {
+	autoLeaderboardCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	autoLeaderboardCmd.Flags().StringSliceP("event", "e", nil, "Event code to filter matches (may be repeated or comma-separated)")
+	autoLeaderboardCmd.Flags().StringP("country", "c", "", "Country to filter teams")
+	autoLeaderboardCmd.Flags().IntP("limit", "l", 0, "Limit number of teams displayed (0 = no limit)")
+	autoLeaderboardCmd.Flags().Bool("include-unofficial", false, "Include unofficial events, such as scrimmages and off-season events")
+	rootCmd.AddCommand(autoLeaderboardCmd)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// awardsListCmd lists the awards given during a season, with what each is given for and whether it carries
+// advancement.
+var awardsListCmd = &cobra.Command{
+	Use:   "awards-list",
+	Short: "List the season's awards and what each is given for",
+	Long: `List the awards given at the season's events, with the description of what each award is given for, whether
+it is given to a team or a person, and whether it carries advancement. The Inspire Award and the other judged awards
+carry advancement, and are shown with the advancement points for 1st, 2nd, and 3rd place under the season's rules.
+The alliance awards earn their points in the playoff bracket instead.`,
+	Example: `  # List the season's awards
+  ftc awards-list
+
+  # List the awards of a prior season
+  ftc awards-list --year 2024`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		definitions, err := query.AwardDefinitionsQuery(year)
+		if err != nil {
+			return err
+		}
+		fmt.Println(terminal.RenderAwardDefinitions(definitions, year))
+		return nil
+	},
+}
+
+func init() {
+	awardsListCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	rootCmd.AddCommand(awardsListCmd)
+}
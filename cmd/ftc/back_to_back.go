@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// backToBackCmd compares how a region's teams performed at events on consecutive weekends with spaced events.
+var backToBackCmd = &cobra.Command{
+	Use:   "back-to-back [region]",
+	Short: "Compare teams' npOPR at back-to-back events with spaced events",
+	Long: `Compare how a region's teams performed at events played on consecutive weekends with how they performed at
+events with more time between them. Each team's official events of the season, in and out of the region, are put in
+order by date, and the change in the team's npOPR from each event to its next one is measured. Events that start
+within --days of the team's previous event are back-to-back, and the rest are spaced.
+
+The summary compares the two groups: the number of event pairs and teams, the average days between the events, the
+average and median change in npOPR, and how many of the teams improved. A team's npOPR usually rises through the
+season, so a smaller gain between back-to-back events points to fatigue or too little time to improve the robot.
+Below the summary, the back-to-back events are listed from the largest gain in npOPR to the largest loss.`,
+	Example: `  # Compare back-to-back and spaced events for the teams in a region
+  ftc back-to-back USNC
+
+  # Count events within 14 days of each other as back-to-back, and list the first 20
+  ftc back-to-back USNC --days 14 --limit 20`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		days, _ := cmd.Flags().GetInt("days")
+		if days < 1 {
+			return fmt.Errorf("--days must be at least 1")
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", limit)
+		}
+		region, err := resolveRegion(args[0])
+		if err != nil {
+			return err
+		}
+
+		result, err := query.BackToBackQuery(region, year, days)
+		if err != nil {
+			return err
+		}
+		output := terminal.RenderBackToBack(result, limit)
+		fmt.Println(output)
+		printDataAsOf(rankingsScope(region, nil, year))
+		return nil
+	},
+}
+
+func init() {
+	backToBackCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	backToBackCmd.Flags().Int("days", query.DefaultBackToBackDays, "Most days apart two events can start and still be back-to-back")
+	backToBackCmd.Flags().IntP("limit", "l", 0, "Limit number of back-to-back events displayed (0 = no limit)")
+	rootCmd.AddCommand(backToBackCmd)
+}
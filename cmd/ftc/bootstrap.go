@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/ftcmock"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/spf13/cobra"
+)
+
+var autoSyncFlag bool
+
+// seasonSync is the sync that loads the data a command reports on into a database that has none for the season. It
+// syncs the events or region the command is for, or the whole season if the command is for neither.
+type seasonSync struct {
+	season     string
+	eventCodes []string
+	regionCode string
+}
+
+// String returns the ftcdata command that performs the sync.
+func (s seasonSync) String() string {
+	switch {
+	case len(s.eventCodes) > 0:
+		return fmt.Sprintf("ftcdata --season %s --event %s", s.season, strings.Join(s.eventCodes, ","))
+	case s.regionCode != "":
+		return fmt.Sprintf("ftcdata --season %s --region %s --refresh", s.season, s.regionCode)
+	default:
+		return fmt.Sprintf("ftcdata --season %s --all", s.season)
+	}
+}
+
+// run performs the sync, using the mock FTC Events API if FTC_MOCK is set to true, as ftcdata does. The advancement
+// cutoffs of the synced events are saved once the sync has finished.
+func (s seasonSync) run() error {
+	if enabled, _ := strconv.ParseBool(os.Getenv("FTC_MOCK")); enabled {
+		mock, err := ftcmock.NewServer(os.Getenv("FTC_MOCK_FIXTURES"))
+		if err != nil {
+			return fmt.Errorf("failed to start mock FTC server: %w", err)
+		}
+		defer mock.Close()
+		request.SetFTCServer(mock.URL, ftcmock.Username, ftcmock.AuthKey)
+	}
+
+	switch {
+	case len(s.eventCodes) > 0:
+		if _, err := request.SyncEvents(s.season, s.eventCodes, ""); err != nil {
+			return err
+		}
+	case s.regionCode != "":
+		request.SyncRegion(s.season, s.regionCode, true, 0)
+	default:
+		request.RequestAndSaveAll(s.season, false, false)
+	}
+	if empty, err := request.SeasonIsEmpty(s.season); err != nil || empty {
+		if err == nil {
+			err = fmt.Errorf("no events were returned for the %s season; check the FTC Events API credentials", s.season)
+		}
+		return err
+	}
+
+	if count, err := query.SaveAdvancementCutoffs(defaultYear, false); err != nil {
+		slog.Warn("failed to save advancement cutoffs", "season", s.season, "error", err)
+	} else {
+		slog.Info("Saved advancement cutoffs", "season", s.season, "count", count)
+	}
+	return nil
+}
+
+// commandSync returns the sync that loads the data the command reports on: the event given by its first argument or
+// the events given by its --event flag, or the region given by its first argument or --region flag.
+func commandSync(cmd *cobra.Command, args []string) seasonSync {
+	sync := seasonSync{season: strconv.Itoa(defaultYear)}
+	if cmd.Flags().Lookup("event") != nil {
+		if eventCodes, _ := cmd.Flags().GetStringSlice("event"); len(eventCodes) > 0 {
+			sync.eventCodes = database.NormalizeCodes(eventCodes)
+			return sync
+		}
+	}
+	if flag := cmd.Flags().Lookup("region"); flag != nil && flag.Value.String() != "" {
+		sync.regionCode = syncRegionCode(flag.Value.String())
+		return sync
+	}
+	if len(args) == 0 {
+		return sync
+	}
+	switch cmd {
+	case eventTeamsCmd, previewCmd, eventStatsCmd, eventFlowCmd, rankingsCmd, awardsCmd, advancementCmd, matchesCmd, queueCmd, kioskCmd, pickListCmd, rerankCmd, diagnosticsCmd, exportSheetPickListCmd:
+		sync.eventCodes = []string{database.NormalizeCode(args[0])}
+	case teamsCmd, eventsCmd, regionAdvancementCmd, eventAdvancementCmd, awardPerformanceCmd, regionTrendCmd, champsProjectionCmd, teamRankingsCmd, teamEventRankingsCmd, autoLeaderboardCmd, foulsCmd, backToBackCmd, exportSheetRankingsCmd:
+		sync.regionCode = syncRegionCode(args[0])
+	}
+	return sync
+}
+
+// syncRegionCode returns the region code of a region given as a code or an alias. Aliases can only be resolved if
+// they have been added to the database, so anything else is taken to be a region code.
+func syncRegionCode(region string) string {
+	if regionCode, err := query.ResolveRegion(region); err == nil {
+		return regionCode
+	}
+	return database.NormalizeCode(region)
+}
+
+// requireSeasonData checks that the database has data for the season before a command reports on it, so an empty
+// database isn't shown as empty tables. If it has none, an error gives the ftcdata command that syncs the data the
+// command needs; with --auto-sync, that sync is run instead and the command continues.
+func requireSeasonData(cmd *cobra.Command, args []string) error {
+	// These commands don't report on synced data, or sync the data themselves
+	switch cmd.Name() {
+	case "serve", "enter-matches", "qp-table", "help":
+		return nil
+	}
+
+	sync := commandSync(cmd, args)
+	empty, err := request.SeasonIsEmpty(sync.season)
+	if err != nil || !empty {
+		return err
+	}
+	if !autoSyncFlag {
+		// The command was used correctly, so the usage would only bury the command that syncs the season
+		cmd.SilenceUsage = true
+		return fmt.Errorf("no data has been synced for the %s season. Sync it with:\n\n  %s\n\nor run the command again with --auto-sync to sync it now", sync.season, sync)
+	}
+
+	fmt.Fprintf(os.Stderr, "No data has been synced for the %s season; running the equivalent of '%s'\n", sync.season, sync)
+	if err := sync.run(); err != nil {
+		return fmt.Errorf("failed to sync the %s season: %w", sync.season, err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// codeIndexMaxAge is how long the cached region and event codes are used before they are reloaded from the database.
+const codeIndexMaxAge = 24 * time.Hour
+
+// completionCmd generates the shell completion script for the CLI application.
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the shell completion script",
+	Long: `Generate the shell completion script for ftc.
+
+Region codes and event codes are completed from the database for the selected season. The codes are cached
+for 24 hours so completion stays fast; the cache is stored in the user's cache directory under ftcstanding.`,
+	Example: `  # Load completions for the current bash session
+  source <(ftc completion bash)
+
+  # Load completions for every new zsh session
+  ftc completion zsh > "${fpath[1]}/_ftc"
+
+  # Load completions for every new fish session
+  ftc completion fish > ~/.config/fish/completions/ftc.fish`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Generating the script does not need the database
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return fmt.Errorf("unsupported shell %q", args[0])
+	},
+}
+
+// codeIndexFile returns the path of the cached region and event codes for a season.
+func codeIndexFile(season string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "ftcstanding", "codes-"+season+".json"), nil
+}
+
+// loadCodeIndex returns the region and event codes for the season selected on the command line. The cached
+// codes are used if they are recent enough; otherwise the database is initialized and the cache is rebuilt. The
+// cache is only read and written once the season is known, so codes of different seasons are never mixed up.
+func loadCodeIndex(cmd *cobra.Command) *query.CodeIndex {
+	// Logging would be mixed in with the completion results, so discard it
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	season := seasonFlag
+	if yearFlag := cmd.Flags().Lookup("year"); yearFlag != nil && yearFlag.Changed {
+		season = yearFlag.Value.String()
+	}
+	if season == "" {
+		season = os.Getenv("FTC_SEASON")
+	}
+
+	if season != "" {
+		if file, err := codeIndexFile(season); err == nil {
+			if data, err := os.ReadFile(file); err == nil {
+				var index query.CodeIndex
+				if err := json.Unmarshal(data, &index); err == nil && time.Since(index.Updated) < codeIndexMaxAge {
+					return &index
+				}
+			}
+		}
+	}
+
+	// Initializing the app resolves the season, failing if none is selected
+	if err := initializeApp(cmd); err != nil {
+		return nil
+	}
+	season = strconv.Itoa(defaultYear)
+	index, err := query.CodeIndexQuery(season)
+	if err != nil {
+		return nil
+	}
+	if file, err := codeIndexFile(season); err == nil {
+		if data, err := json.Marshal(index); err == nil {
+			if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err == nil {
+				os.WriteFile(file, data, 0644)
+			}
+		}
+	}
+	return index
+}
+
+// filterPrefix returns the codes that start with the text being completed, ignoring case.
+func filterPrefix(codes []string, toComplete string) []string {
+	var matches []string
+	for _, code := range codes {
+		if strings.HasPrefix(strings.ToUpper(code), strings.ToUpper(toComplete)) {
+			matches = append(matches, code)
+		}
+	}
+	return matches
+}
+
+// completeRegionCodes completes region codes from the database.
+func completeRegionCodes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	index := loadCodeIndex(cmd)
+	if index == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix(index.RegionCodes(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEventCodes completes event codes from the database, limited to the region given by the --region flag
+// if the command has one.
+func completeEventCodes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	index := loadCodeIndex(cmd)
+	if index == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	region, _ := cmd.Flags().GetString("region")
+	if region == "" && cmd.Flags().Lookup("event") != nil && len(args) > 0 {
+		// Commands with an --event flag take the region as their argument
+		region = args[0]
+	}
+	return filterPrefix(index.EventCodes(region), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// firstArg limits a completion function to the first positional argument.
+func firstArg(complete cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// registerCompletions registers the dynamic completion of region codes, event codes, and flag values.
+func registerCompletions() {
+	for _, cmd := range []*cobra.Command{teamsCmd, eventsCmd, regionAdvancementCmd, eventAdvancementCmd, awardPerformanceCmd, regionTrendCmd, champsProjectionCmd, teamRankingsCmd, teamEventRankingsCmd, autoLeaderboardCmd, foulsCmd, backToBackCmd, exportSheetRankingsCmd} {
+		cmd.ValidArgsFunction = firstArg(completeRegionCodes)
+	}
+	for _, cmd := range []*cobra.Command{eventTeamsCmd, previewCmd, eventStatsCmd, eventFlowCmd, rankingsCmd, awardsCmd, advancementCmd, matchesCmd, queueCmd, enterMatchesCmd, kioskCmd, pickListCmd, rerankCmd, diagnosticsCmd, exportSheetPickListCmd} {
+		cmd.ValidArgsFunction = firstArg(completeEventCodes)
+	}
+	for _, cmd := range []*cobra.Command{teamCmd, teamEventsCmd, pathCmd, teamCardCmd, whatIfCmd, cutoffsCmd, qpTableCmd} {
+		cmd.ValidArgsFunction = cobra.NoFileCompletions
+	}
+
+	whatIfCmd.RegisterFlagCompletionFunc("region", completeRegionCodes)
+	awardPerformanceCmd.RegisterFlagCompletionFunc("event", completeEventCodes)
+	autoLeaderboardCmd.RegisterFlagCompletionFunc("event", completeEventCodes)
+	foulsCmd.RegisterFlagCompletionFunc("event", completeEventCodes)
+	exportSheetRankingsCmd.RegisterFlagCompletionFunc("event", completeEventCodes)
+	rootCmd.RegisterFlagCompletionFunc("lang", cobra.FixedCompletions(terminal.Languages, cobra.ShellCompDirectiveNoFileComp))
+
+	sortValues := cobra.FixedCompletions([]string{"opr", "npopr", "ccwm", "dpr", "npdpr", "npavg", "autoopr", "autopct", "matches", "team"}, cobra.ShellCompDirectiveNoFileComp)
+	for _, cmd := range []*cobra.Command{teamRankingsCmd, teamEventRankingsCmd} {
+		cmd.RegisterFlagCompletionFunc("region", completeRegionCodes)
+		cmd.RegisterFlagCompletionFunc("event", completeEventCodes)
+		cmd.RegisterFlagCompletionFunc("sort", sortValues)
+	}
+	exportSheetRankingsCmd.RegisterFlagCompletionFunc("sort", sortValues)
+	for _, cmd := range []*cobra.Command{teamsCmd, teamRankingsCmd} {
+		cmd.RegisterFlagCompletionFunc("group-by", cobra.FixedCompletions([]string{"country"}, cobra.ShellCompDirectiveNoFileComp))
+	}
+}
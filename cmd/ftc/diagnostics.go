@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/performance"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/spf13/cobra"
+)
+
+// diagnosticsCmd writes the inputs and results of an event's performance calculation for offline analysis.
+var diagnosticsCmd = &cobra.Command{
+	Use:   "diagnostics [eventCode]",
+	Short: "Write the design matrix and residuals of an event's performance calculation",
+	Long: `Solve a performance metric for the teams at an event the same way the team rankings are calculated, and write
+the design matrix, the scores it was fit to, the solved ratings, and each alliance's predicted and actual score
+along with the residual, so the metrics can be audited and extended offline. The metric is one of opr, npopr,
+ccwm, dpr, npdpr, or autoopr.
+
+As JSON, the matrix has a row for each alliance in each match and a column for each team in "teams", and "fits"
+describes each row. As CSV, each row is an alliance in a match, with a column for each team, followed by the
+actual and predicted scores and the residual; the final row holds the rating of each team.`,
+	Example: `  # Write the OPR calculation of an event as JSON
+  ftc diagnostics USNCRAQ
+
+  # Write the npOPR calculation of an event to a CSV file
+  ftc diagnostics USNCRAQ --metric npopr --format csv --output USNCRAQ-npopr.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := database.NormalizeCode(args[0])
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		metric, _ := cmd.Flags().GetString("metric")
+		metric = strings.ToLower(metric)
+		format, _ := cmd.Flags().GetString("format")
+		format = strings.ToLower(format)
+		if format != "json" && format != "csv" {
+			return fmt.Errorf("invalid format %q, expected json or csv", format)
+		}
+		output, _ := cmd.Flags().GetString("output")
+
+		events, err := query.EventsQuery(database.EventFilter{EventCodes: []string{eventCode}, Year: year})
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return query.EventNotFound(eventCode, year)
+		}
+		diagnostics, err := request.CalculateEventDiagnostics(events[0], metric)
+		if err != nil {
+			return err
+		}
+		if diagnostics == nil {
+			return fmt.Errorf("no scored matches found for event %s", eventCode)
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := writeDiagnostics(w, diagnostics, format); err != nil {
+			return fmt.Errorf("failed to write diagnostics: %w", err)
+		}
+		if output != "" {
+			fmt.Printf("Wrote the %s diagnostics for %s to %s\n", metric, eventCode, output)
+		}
+		return nil
+	},
+}
+
+// writeDiagnostics writes the diagnostics of a performance calculation as JSON or CSV.
+func writeDiagnostics(w io.Writer, diagnostics *performance.Diagnostics, format string) error {
+	if format == "csv" {
+		return diagnostics.WriteCSV(w)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diagnostics)
+}
+
+func init() {
+	diagnosticsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	diagnosticsCmd.Flags().String("metric", "opr", "Metric to diagnose: "+strings.Join(performance.DiagnosticMetrics, ", "))
+	diagnosticsCmd.Flags().String("format", "json", "Output format: json or csv")
+	diagnosticsCmd.Flags().String("output", "", "File to write the diagnostics to (defaults to standard output)")
+	diagnosticsCmd.RegisterFlagCompletionFunc("metric", cobra.FixedCompletions(performance.DiagnosticMetrics, cobra.ShellCompDirectiveNoFileComp))
+	diagnosticsCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"json", "csv"}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(diagnosticsCmd)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// enterMatchesCmd enters the match results of an event by hand.
+var enterMatchesCmd = &cobra.Command{
+	Use:   "enter-matches [eventCode]",
+	Short: "Enter the match results of an event by hand",
+	Long: `Enter the match results of an event whose scores aren't posted to the FTC Events API, such as a league that
+only keeps its scores on paper. Each match's teams, scores, autonomous points, and foul points are prompted for,
+and the entered matches can be listed, edited, and deleted before they are saved. The matches are saved to the
+same tables as synced matches, so every report includes them, and the event's team rankings are recalculated.
+
+The event must already be in the database, either synced from the FTC Events API or registered with 'ftcdata
+event add'. Matches that were synced from a data source can't be replaced. If no event code is given, it is
+prompted for.`,
+	Example: `  # Enter the matches of a league meet
+  ftc enter-matches USNCLM1
+
+  # Prompt for the event code
+  ftc enter-matches`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+
+		in := bufio.NewReader(os.Stdin)
+		eventCode := ""
+		if len(args) > 0 {
+			eventCode = args[0]
+		} else {
+			fmt.Print("Event code: ")
+			line, err := in.ReadString('\n')
+			if err != nil && line == "" {
+				return fmt.Errorf("no event code entered")
+			}
+			eventCode = strings.TrimSpace(line)
+		}
+
+		events, err := query.EventsQuery(database.EventFilter{EventCodes: []string{eventCode}, Year: year})
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return query.EventNotFound(eventCode, year)
+		}
+		event := events[0]
+
+		matches, save := terminal.EnterMatches(in, os.Stdout, event)
+		if !save {
+			fmt.Println("Matches not saved")
+			return nil
+		}
+		if len(matches) == 0 {
+			fmt.Println("No matches entered")
+			return nil
+		}
+		saved, err := request.SaveManualMatches(event, matches)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Saved %d matches for %s\n", len(saved), event.EventCode)
+		return nil
+	},
+}
+
+func init() {
+	enterMatchesCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	rootCmd.AddCommand(enterMatchesCmd)
+}
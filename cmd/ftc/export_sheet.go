@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/sheets"
+	"github.com/spf13/cobra"
+)
+
+// exportSheetCmd groups the commands that export reports to a Google Sheet.
+var exportSheetCmd = &cobra.Command{
+	Use:   "export-sheet",
+	Short: "Export rankings and pick lists to a Google Sheet",
+	Long: `Export the team rankings or a pick list to a sheet of a shared Google Sheet, so they can be used by a scouting
+workflow kept in the spreadsheet. The sheet is replaced by the report each time it is exported, and is added to the
+spreadsheet if it doesn't have it.
+
+The reports are written with a Google Cloud service account that has the Google Sheets API enabled. Its JSON key
+file is given by the GOOGLE_SHEETS_CREDENTIALS environment variable, which can be set in the .env file along with
+GOOGLE_SHEETS_SPREADSHEET_ID, the spreadsheet exported to when --spreadsheet isn't given. The spreadsheet must be
+shared with the service account's email address as an editor.`,
+}
+
+// exportSheetRankingsCmd exports the team rankings to a Google Sheet.
+var exportSheetRankingsCmd = &cobra.Command{
+	Use:   "rankings [region]",
+	Short: "Export the team rankings to a Google Sheet",
+	Long: `Export the team rankings, as shown by 'ftc team-rankings', to a Google Sheet. The rankings are of a region's
+teams, the teams at the events given by --event, or every team in the season if neither is given. The sheet is named
+for the region or events unless --sheet is given.`,
+	Example: `  # Export the region's team rankings to the spreadsheet in GOOGLE_SHEETS_SPREADSHEET_ID
+  ftc export-sheet rankings USNC
+
+  # Export the rankings of two events, sorted by npOPR, to a named sheet
+  ftc export-sheet rankings --event USNCRAQ,USNCCOQ --sort npopr --sheet "Qualifiers"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := ""
+		if len(args) > 0 {
+			var err error
+			if region, err = resolveRegion(args[0]); err != nil {
+				return err
+			}
+		}
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		eventCodes, _ := cmd.Flags().GetStringSlice("event")
+		country, _ := cmd.Flags().GetString("country")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", limit)
+		}
+		includeUnofficial, _ := cmd.Flags().GetBool("include-unofficial")
+		keys, err := query.ParseSortKeys(sortBy, "")
+		if err != nil {
+			return err
+		}
+		if err := requireEvents(eventCodes, year); err != nil {
+			return err
+		}
+
+		performances, err := query.TeamRankingsQuery(region, country, eventCodes, year, includeUnofficial, false)
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			query.SortTeamPerformances(performances, keys)
+		}
+		if limit > 0 && limit < len(performances) {
+			performances = performances[:limit]
+		}
+
+		sheet := "Rankings"
+		switch {
+		case len(eventCodes) > 0:
+			sheet += " " + eventsLabel(eventCodes)
+		case region != "":
+			sheet += " " + region
+		}
+		rows := [][]any{{"Rank", "Team", "Name", "Region", "Country", "OPR", "npOPR", "CCWM", "DPR", "npDPR", "npAVG", "Auto OPR", "Auto %", "Matches"}}
+		for i, p := range performances {
+			rows = append(rows, []any{i + 1, p.TeamID, p.TeamName, p.Region, p.Country, round2(p.OPR), round2(p.NpOPR),
+				round2(p.CCWM), round2(p.DPR), round2(p.NpDPR), round2(p.NpAVG), round2(p.AutoOPR), round2(p.AutoShare()),
+				p.Matches})
+		}
+		return exportSheet(cmd, sheet, rows)
+	},
+}
+
+// exportSheetPickListCmd exports the pick list of an event to a Google Sheet.
+var exportSheetPickListCmd = &cobra.Command{
+	Use:   "pick-list [eventCode]",
+	Short: "Export an event's pick list to a Google Sheet",
+	Long: `Export the pick list of an event, as shown by 'ftc pick-list', to a Google Sheet: the teams at the event ranked
+by a formula, with the value of each of the formula's metrics. --formula takes a name from the formulas file or a
+formula, in the same way as 'ftc pick-list', and --tag narrows the list to the teams with the scouting tags given.
+The tags and notes imported with 'ftcdata import-scouting' are exported with the teams. The sheet is named for the
+event unless --sheet is given.`,
+	Example: `  # Export the pick list of an event, ranked by the default formula
+  ftc export-sheet pick-list USNCRAQ
+
+  # Export the pick list ranked by the formula named "coach", leaving out the teams already picked
+  ftc export-sheet pick-list USNCRAQ --formula coach --exclude 12345,23456`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := database.NormalizeCode(args[0])
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", limit)
+		}
+		exclude, _ := cmd.Flags().GetIntSlice("exclude")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		formulaFlag, _ := cmd.Flags().GetString("formula")
+		formula, err := resolveFormula(formulaFlag)
+		if err != nil {
+			return err
+		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
+
+		rankings, err := query.FormulaRankingsQuery(formula, "", "", []string{eventCode}, year, false)
+		if err != nil {
+			return err
+		}
+		notes, err := query.TeamNotesQuery(year)
+		if err != nil {
+			return err
+		}
+		rankings = query.FilterRankingsByTags(rankings, notes, tags)
+		rankings = slices.DeleteFunc(rankings, func(r query.FormulaRanking) bool {
+			return slices.Contains(exclude, r.TeamID)
+		})
+		if limit > 0 && limit < len(rankings) {
+			rankings = rankings[:limit]
+		}
+
+		metrics := formula.Metrics()
+		header := []any{"Rank", "Team", "Name", "Score"}
+		for _, metric := range metrics {
+			header = append(header, metric)
+		}
+		header = append(header, "Tags", "Notes")
+		rows := [][]any{header}
+		for i, r := range rankings {
+			row := []any{i + 1, r.TeamID, r.TeamName, round2(r.Score)}
+			for _, metric := range metrics {
+				row = append(row, round2(r.Value(metric)))
+			}
+			if note, ok := notes[r.TeamID]; ok {
+				row = append(row, strings.Join(note.Tags, ", "), note.Notes)
+			} else {
+				row = append(row, "", "")
+			}
+			rows = append(rows, row)
+		}
+		return exportSheet(cmd, "Pick List "+eventCode, rows)
+	},
+}
+
+// exportSheet writes the rows to the sheet of the spreadsheet given by --spreadsheet, or the one in
+// GOOGLE_SHEETS_SPREADSHEET_ID. The sheet given by --sheet is used instead of the report's, if there is one.
+func exportSheet(cmd *cobra.Command, sheet string, rows [][]any) error {
+	config, err := sheets.ConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	spreadsheetID, _ := cmd.Flags().GetString("spreadsheet")
+	if spreadsheetID == "" {
+		spreadsheetID = config.SpreadsheetID
+	}
+	if spreadsheetID == "" {
+		return fmt.Errorf("a spreadsheet is required; use --spreadsheet or set GOOGLE_SHEETS_SPREADSHEET_ID")
+	}
+	if name, _ := cmd.Flags().GetString("sheet"); strings.TrimSpace(name) != "" {
+		sheet = strings.TrimSpace(name)
+	}
+
+	client, err := sheets.NewClient(config)
+	if err != nil {
+		return err
+	}
+	if err := client.WriteSheet(spreadsheetID, sheet, rows); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d teams to sheet %q\n", len(rows)-1, sheet)
+	return nil
+}
+
+// round2 rounds a metric to two decimal places, as the reports show them.
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func init() {
+	exportSheetCmd.PersistentFlags().String("spreadsheet", "", "ID of the spreadsheet, from its URL (defaults to GOOGLE_SHEETS_SPREADSHEET_ID environment variable)")
+	exportSheetCmd.PersistentFlags().String("sheet", "", "Name of the sheet to replace (defaults to a name for the report)")
+	exportSheetCmd.PersistentFlags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	exportSheetCmd.PersistentFlags().IntP("limit", "l", 0, "Number of teams to export (0 exports every team)")
+
+	exportSheetRankingsCmd.Flags().StringSliceP("event", "e", nil, "Event code to filter matches (may be repeated or comma-separated)")
+	exportSheetRankingsCmd.Flags().StringP("country", "c", "", "Country to filter teams")
+	exportSheetRankingsCmd.Flags().String("sort", "", "Comma-separated fields to sort by, such as npopr or ccwm,-matches (defaults to npavg)")
+	exportSheetRankingsCmd.Flags().Bool("include-unofficial", false, "Include unofficial events, such as scrimmages and off-season events")
+
+	exportSheetPickListCmd.Flags().StringP("formula", "f", "", "Name of a formula in the formulas file, or a formula such as \"0.5*npopr + 0.5*ccwm\"")
+	exportSheetPickListCmd.Flags().IntSlice("exclude", nil, "Teams already picked, which are left out of the list (may be repeated)")
+	exportSheetPickListCmd.Flags().StringSliceP("tag", "t", nil, "Scouting tag the teams must have (may be repeated or comma-separated)")
+
+	exportSheetCmd.AddCommand(exportSheetRankingsCmd)
+	exportSheetCmd.AddCommand(exportSheetPickListCmd)
+	rootCmd.AddCommand(exportSheetCmd)
+}
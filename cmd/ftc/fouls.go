@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// foulsCmd shows how many points each team's alliances gained and lost through fouls.
+var foulsCmd = &cobra.Command{
+	Use:   "fouls [region]",
+	Short: "Show the points each team's alliances gained and lost through fouls",
+	Long: `Show the foul points each team's alliances received for their opponents' fouls and the foul points they
+committed, across every scored match of the season. Teams are ranked by their net foul points, from the team that
+gained the most to the team that lost the most, along with the matches that fouls turned into a win or a loss.
+Below the table are the teams that won the most matches on their opponents' fouls and the teams that committed the
+fewest foul points per match.`,
+	Example: `  # Show the foul impact for the teams in a region
+  ftc fouls USNC
+
+  # Show the foul impact for the teams at an event
+  ftc fouls --event USNCRAQ`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := ""
+		if len(args) > 0 {
+			region = args[0]
+		}
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		eventCodes, _ := cmd.Flags().GetStringSlice("event")
+		country, _ := cmd.Flags().GetString("country")
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", limit)
+		}
+		includeUnofficial, _ := cmd.Flags().GetBool("include-unofficial")
+		if region != "" {
+			var err error
+			if region, err = resolveRegion(region); err != nil {
+				return err
+			}
+		}
+		if err := requireEvents(eventCodes, year); err != nil {
+			return err
+		}
+
+		impacts, err := query.FoulImpactQuery(region, country, eventCodes, year, includeUnofficial)
+		if err != nil {
+			return err
+		}
+		output := terminal.RenderFoulImpact(impacts, region, eventsLabel(eventCodes), year, limit)
+		fmt.Println(output)
+		printDataAsOf(rankingsScope(region, eventCodes, year))
+		return nil
+	},
+}
+
+func init() {
+	foulsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	foulsCmd.Flags().StringSliceP("event", "e", nil, "Event code to filter matches (may be repeated or comma-separated)")
+	foulsCmd.Flags().StringP("country", "c", "", "Country to filter teams")
+	foulsCmd.Flags().IntP("limit", "l", 0, "Limit number of teams displayed (0 = no limit)")
+	foulsCmd.Flags().Bool("include-unofficial", false, "Include unofficial events, such as scrimmages and off-season events")
+	rootCmd.AddCommand(foulsCmd)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// clearScreen moves the cursor to the top left of the terminal and clears the screen.
+const clearScreen = "\033[H\033[2J"
+
+// kioskPage is a page cycled through by the pit display. Render loads the page's data and renders its body.
+type kioskPage struct {
+	title  string
+	render func() (string, error)
+}
+
+// kioskCmd cycles an event's standings on a pit display.
+var kioskCmd = &cobra.Command{
+	Use:   "kiosk [eventCode]",
+	Short: "Cycle an event's standings full screen on a pit display",
+	Long: `Cycle an event's standings full screen, for a TV in the pits driven by a Raspberry Pi or other small computer.
+The display shows the qualification rankings, the most recent matches, the upcoming matches, and the OPR
+leaderboard in turn, each for --interval. The data of each page is loaded again each time it is shown, so the
+display keeps up with the event as its results are synced. With --sync-interval, the event is also synced from
+the FTC Events API while it is shown. Pages are cut short to fit the height of the screen. Press Ctrl-C to stop.`,
+	Example: `  # Show each page for 15 seconds
+  ftc kiosk USNCRAQ
+
+  # Show each page for 30 seconds with 10 recent and upcoming matches, syncing the event every minute
+  ftc kiosk USNCRAQ --interval 30s --matches 10 --sync-interval 1m`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := database.NormalizeCode(args[0])
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		interval, _ := cmd.Flags().GetDuration("interval")
+		matches, _ := cmd.Flags().GetInt("matches")
+		limit, _ := cmd.Flags().GetInt("limit")
+		syncInterval, _ := cmd.Flags().GetDuration("sync-interval")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
+		events, err := query.EventsQuery(database.EventFilter{EventCodes: []string{eventCode}, Year: year})
+		if err != nil {
+			return err
+		}
+		event := events[0]
+
+		pages := []kioskPage{
+			{"Rankings", func() (string, error) {
+				teams, err := query.OverlayLeaderboardQuery(eventCode, year, 0)
+				return terminal.RenderKioskRankings(teams), err
+			}},
+			{"Recent Matches", func() (string, error) {
+				recent, err := query.RecentMatchesQuery(eventCode, year, matches)
+				return terminal.RenderRecentMatches(recent), err
+			}},
+			{"Upcoming Matches", func() (string, error) {
+				upcoming, err := query.UpcomingMatchesQuery(eventCode, year, matches)
+				return terminal.RenderUpcomingMatches(upcoming), err
+			}},
+			{"OPR Leaderboard", func() (string, error) {
+				teams, err := query.OPRLeaderboardQuery(eventCode, year, limit)
+				return terminal.RenderKioskLeaderboard(teams), err
+			}},
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		if syncInterval > 0 {
+			go syncEvents(ctx, []string{eventCode}, syncInterval)
+		}
+
+		for i := 0; ; i = (i + 1) % len(pages) {
+			page := pages[i]
+			body, err := page.render()
+			if err != nil {
+				// Keep the display running through a failed load; the page is loaded again on the next cycle
+				body = fmt.Sprintf("Failed to load %s: %v\n", page.title, err)
+			}
+			_, height, err := term.GetSize(int(os.Stdout.Fd()))
+			if err != nil {
+				height = 0
+			}
+			fmt.Print(clearScreen)
+			fmt.Print(terminal.RenderKioskPage(event, page.title, body, i+1, len(pages), time.Now(), height))
+
+			select {
+			case <-ctx.Done():
+				fmt.Println()
+				return nil
+			case <-time.After(interval):
+			}
+		}
+	},
+}
+
+func init() {
+	kioskCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	kioskCmd.Flags().Duration("interval", 15*time.Second, "How long each page is shown")
+	kioskCmd.Flags().Int("matches", 8, "Number of recent and upcoming matches to show")
+	kioskCmd.Flags().IntP("limit", "l", 10, "Number of teams on the OPR leaderboard (0 shows every team)")
+	kioskCmd.Flags().Duration("sync-interval", 0, "How often to sync the event from the FTC Events API while it is shown (0 doesn't sync)")
+	rootCmd.AddCommand(kioskCmd)
+}
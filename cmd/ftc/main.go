@@ -4,11 +4,21 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/rbrabson/ftcstanding/card"
 	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/geocode"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/internal/version"
+	"github.com/rbrabson/ftcstanding/pdf"
 	"github.com/rbrabson/ftcstanding/query"
 	"github.com/rbrabson/ftcstanding/request"
 	"github.com/rbrabson/ftcstanding/terminal"
@@ -18,6 +28,10 @@ import (
 var (
 	defaultYear int
 	seasonFlag  string
+	langFlag    string
+	localeFlag  string
+	columnsFlag string
+	appDB       database.DB
 )
 
 // setLogLevelFromEnv sets the log level from the LOG_LEVEL environment variable.
@@ -40,10 +54,44 @@ func setLogLevelFromEnv() slog.Level {
 	return logLevel
 }
 
-// initializeApp sets up database and initializes subsystems
-func initializeApp() error {
-	// Use --season flag if provided, otherwise fall back to FTC_SEASON environment variable
+// initializeApp sets up database and initializes subsystems for the season selected by the --season flag, the
+// command's --year flag, or the FTC_SEASON environment variable, in that order. Any previously opened database
+// is closed so the data for the selected season is loaded.
+func initializeApp(cmd *cobra.Command) error {
+	// Use --lang flag if provided, otherwise the language of the user's locale if it is supported
+	if langFlag != "" {
+		if err := terminal.SetLanguage(langFlag); err != nil {
+			return err
+		}
+	} else {
+		terminal.SetLanguage(terminal.LanguageFromEnv())
+	}
+
+	// Use --locale flag if provided, otherwise the user's locale, to format numbers and dates
+	if localeFlag != "" {
+		if err := locale.Set(localeFlag); err != nil {
+			return err
+		}
+	} else {
+		locale.Set(locale.FromEnv())
+	}
+
+	// Rename and hide table columns as configured
+	columns, err := loadColumnConfig()
+	if err != nil {
+		return err
+	}
+	terminal.SetColumnConfig(columns)
+
+	// Use --season flag if provided, then the command's --year flag, otherwise fall back to FTC_SEASON environment variable
 	season := seasonFlag
+	if yearFlag := cmd.Flags().Lookup("year"); yearFlag != nil && yearFlag.Changed {
+		year := yearFlag.Value.String()
+		if season != "" && season != year {
+			return fmt.Errorf("--season %s and --year %s refer to different seasons", season, year)
+		}
+		season = year
+	}
 	if season == "" {
 		season = os.Getenv("FTC_SEASON")
 		if season == "" {
@@ -51,16 +99,20 @@ func initializeApp() error {
 		}
 	}
 
-	var err error
 	defaultYear, err = strconv.Atoi(season)
 	if err != nil {
 		return fmt.Errorf("invalid season value: %s", season)
 	}
 
-	db, err := database.Init(season)
+	if appDB != nil {
+		appDB.Close()
+		appDB = nil
+	}
+	db, err := database.InitPrimary(season)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %v", err)
 	}
+	appDB = db
 
 	request.Init(db)
 	query.Init(db)
@@ -69,13 +121,49 @@ func initializeApp() error {
 	return nil
 }
 
+// loadColumnConfig loads the column configuration from the file given by --columns. If no file is given, the
+// columns.json file in the user's ftcstanding configuration directory is loaded if it exists.
+func loadColumnConfig() (*terminal.ColumnConfig, error) {
+	file := columnsFlag
+	if file == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, nil
+		}
+		file = filepath.Join(configDir, "ftcstanding", "columns.json")
+		if _, err := os.Stat(file); err != nil {
+			return nil, nil
+		}
+	}
+	config, err := terminal.LoadColumnConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load column configuration: %w", err)
+	}
+	return config, nil
+}
+
 // rootCmd is the base command for the CLI application.
 var rootCmd = &cobra.Command{
 	Use:   "ftc",
 	Short: "FTC Standing - A CLI tool for FTC competition data",
 	Long:  `A command-line interface for querying and displaying FTC (FIRST Tech Challenge) competition data including teams, events, matches, rankings, and advancement information.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return initializeApp()
+		// Shell completion loads the database only when the cached region and event codes are out of date
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+			return nil
+		}
+		// The version is reported without loading the database, so it works when the database is unavailable
+		if cmd.Name() == "version" {
+			return nil
+		}
+		// Profile the whole command, including loading the database
+		if err := startProfiling(); err != nil {
+			return err
+		}
+		if err := initializeApp(cmd); err != nil {
+			return err
+		}
+		return requireSeasonData(cmd, args)
 	},
 }
 
@@ -83,13 +171,15 @@ var rootCmd = &cobra.Command{
 var teamCmd = &cobra.Command{
 	Use:   "team [teamID]",
 	Short: "Show detailed information about a team",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Show details for a team
+  ftc team 12345`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		teamID, err := strconv.Atoi(args[0])
 		if err != nil {
 			return fmt.Errorf("invalid teamID '%s', must be a number", args[0])
 		}
-		details, err := query.TeamDetailsQuery(teamID)
+		details, err := query.TeamDetailsQuery(teamID, defaultYear)
 		if err != nil {
 			return err
 		}
@@ -102,43 +192,421 @@ var teamCmd = &cobra.Command{
 	},
 }
 
-// teamsCmd lists all teams in a specified region, showing their team ID, name, and home region.
+// teamEventsCmd compares a team's metrics, rank, record, and awards across each event they attended.
+var teamEventsCmd = &cobra.Command{
+	Use:   "team-events [teamID]",
+	Short: "Compare a team's performance across their events",
+	Example: `  # Compare a team's performance at each event they attended
+  ftc team-events 12345`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teamID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid teamID '%s', must be a number", args[0])
+		}
+		comparison, err := query.TeamEventComparisonQuery(teamID, defaultYear)
+		if err != nil {
+			return err
+		}
+		if comparison == nil {
+			return fmt.Errorf("team %d not found", teamID)
+		}
+		output := terminal.RenderTeamEventComparison(comparison)
+		fmt.Println(output)
+		return nil
+	},
+}
+
+// pathCmd shows a team's progression through the tiers of the advancement chain, from league meets to Worlds.
+var pathCmd = &cobra.Command{
+	Use:   "path [teamID]",
+	Short: "Show a team's advancement path",
+	Long: `Show a team's progression through the advancement chain: league meets, league tournaments, qualifiers, the
+regional championship, and Worlds. Each tier lists the events the team attended and whether it advanced from them.`,
+	Example: `  # Show the advancement path of a team
+  ftc path 12345`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teamID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid teamID '%s', must be a number", args[0])
+		}
+		path, err := query.AdvancementPathQuery(teamID)
+		if err != nil {
+			return err
+		}
+		if path == nil {
+			return fmt.Errorf("team %d not found", teamID)
+		}
+		output := terminal.RenderAdvancementPath(path)
+		fmt.Println(output)
+		return nil
+	},
+}
+
+// teamCardCmd renders a shareable image card of a team's season.
+var teamCardCmd = &cobra.Command{
+	Use:   "team-card [teamID]",
+	Short: "Create a shareable image card of a team's season",
+	Long: `Create a PNG image card of a team's season, showing its record, latest OPR and npOPR, awards, and next event,
+for posting on social media after an event.`,
+	Example: `  # Create a card for a team
+  ftc team-card 12345 --png 12345.png`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teamID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid teamID '%s', must be a number", args[0])
+		}
+		pngFile, _ := cmd.Flags().GetString("png")
+		comparison, err := query.TeamEventComparisonQuery(teamID, defaultYear)
+		if err != nil {
+			return err
+		}
+		if comparison == nil {
+			return fmt.Errorf("team %d not found", teamID)
+		}
+
+		f, err := os.Create(pngFile)
+		if err != nil {
+			return err
+		}
+		if err := card.WritePNG(f, comparison, defaultYear, time.Now()); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", pngFile, err)
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote the card for team %d to %s\n", teamID, pngFile)
+		return nil
+	},
+}
+
+// teamsCmd lists all teams in a specified region, or every team, showing their team ID, name, and home region.
 var teamsCmd = &cobra.Command{
 	Use:   "teams [region]",
 	Short: "List teams in a region",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # List the teams in a region
+  ftc teams USNC
+
+  # List the teams in a region for a prior season
+  ftc --season 2024 teams USNC
+
+  # List every team, grouped by country
+  ftc teams --group-by country`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		region := args[0]
-		teamsFilter := database.TeamFilter{
-			HomeRegions: []string{region},
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		if err := checkGroupBy(groupBy); err != nil {
+			return err
+		}
+		var teamsFilter database.TeamFilter
+		if len(args) > 0 {
+			region, err := resolveRegion(args[0])
+			if err != nil {
+				return err
+			}
+			teamsFilter.HomeRegions = []string{region}
 		}
 		teams, err := query.TeamsQuery(teamsFilter)
 		if err != nil {
 			return err
 		}
+		if groupBy != "" {
+			fmt.Println(terminal.RenderTeamsByCountry(teams))
+			return nil
+		}
 		teamsOutput := terminal.RenderTeams(teams)
 		fmt.Println(teamsOutput)
 		return nil
 	},
 }
 
+// checkGroupBy returns an error if the value of a --group-by flag isn't one the teams can be grouped by. The teams can
+// only be grouped by country.
+func checkGroupBy(groupBy string) error {
+	if groupBy != "" && groupBy != "country" {
+		return fmt.Errorf("invalid --group-by %q, must be country", groupBy)
+	}
+	return nil
+}
+
+// eventsCmd lists the events for a season, optionally limited to a region or to events near a location.
+var eventsCmd = &cobra.Command{
+	Use:   "events [region]",
+	Short: "List events, optionally near a location",
+	Example: `  # List all events in a region
+  ftc events USNC
+
+  # List events within 150 miles of a city
+  ftc events --near "Raleigh, NC" --within 150mi
+
+  # List events within 200 km of a latitude and longitude
+  ftc events --near 35.78,-78.64 --within 200km`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		country, _ := cmd.Flags().GetString("country")
+		near, _ := cmd.Flags().GetString("near")
+		withinStr, _ := cmd.Flags().GetString("within")
+
+		filter := database.EventFilter{Year: year}
+		if len(args) > 0 {
+			region, err := resolveRegion(args[0])
+			if err != nil {
+				return err
+			}
+			filter.RegionCodes = []string{region}
+		}
+		if country != "" {
+			filter.Countries = []string{country}
+		}
+
+		if near == "" {
+			events, err := query.EventsQuery(filter)
+			if err != nil {
+				return err
+			}
+			fmt.Println(terminal.RenderEvents(events))
+			return nil
+		}
+
+		within, err := geocode.ParseDistance(withinStr)
+		if err != nil {
+			return err
+		}
+		origin, err := resolveLocation(near)
+		if err != nil {
+			return err
+		}
+		events, err := query.EventsNearQuery(filter, *origin, within)
+		if err != nil {
+			return err
+		}
+		fmt.Println(terminal.RenderEventsNear(events, near, within))
+		return nil
+	},
+}
+
+// resolveLocation returns the location for a "latitude,longitude" pair, or geocodes it as an address
+// using the geocoder selected by the GEOCODER environment variable.
+func resolveLocation(near string) (*geocode.Location, error) {
+	if location, err := geocode.ParseLocation(near); err == nil {
+		return location, nil
+	}
+
+	geocoder, err := geocode.New()
+	if err != nil {
+		return nil, err
+	}
+	if geocoder == nil {
+		return nil, fmt.Errorf("set GEOCODER to look up %q, or specify --near as latitude,longitude", near)
+	}
+	location, err := geocoder.Geocode(near)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %q: %w", near, err)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("location %q not found", near)
+	}
+	return location, nil
+}
+
+// requireEvent returns an error if no event has the event code in the year, suggesting the event codes that are
+// closest to it. Event codes are matched without regard to case.
+func requireEvent(eventCode string, year int) error {
+	events, err := query.EventsQuery(database.EventFilter{EventCodes: []string{eventCode}, Year: year})
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return query.EventNotFound(eventCode, year)
+	}
+	return nil
+}
+
+// requireEvents returns an error for the first of the events that isn't in the year, in the same way as
+// requireEvent.
+func requireEvents(eventCodes []string, year int) error {
+	for _, eventCode := range eventCodes {
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventsLabel returns the codes of the events given by --event as they are shown in the heading of a report.
+func eventsLabel(eventCodes []string) string {
+	return strings.Join(database.NormalizeCodes(eventCodes), ", ")
+}
+
+// printDataAsOf prints the footer of a report, with the last time the data in the scope was synced from the FTC
+// Events API. Nothing is printed if the time can't be found.
+func printDataAsOf(scope query.DataScope) {
+	dataAsOf, err := query.DataAsOfQuery(scope)
+	if err != nil {
+		slog.Warn("Failed to find when the data was last synced", "error", err)
+		return
+	}
+	fmt.Println(terminal.RenderDataAsOf(dataAsOf, time.Now()))
+}
+
+// rankingsScope returns the scope of the team rankings: the event if one is given, otherwise the region, or the whole
+// season if neither is given.
+func rankingsScope(region string, eventCodes []string, year int) query.DataScope {
+	if len(eventCodes) > 0 {
+		return query.DataScope{Year: year, EventCodes: eventCodes}
+	}
+	return query.DataScope{Year: year, RegionCode: region}
+}
+
+// resolveRegion returns the region code for a region given as a region code or an alias, such as "North Carolina"
+// for USNC. It returns an error suggesting the closest region codes if the region isn't found. Region codes and
+// aliases are matched without regard to case.
+func resolveRegion(region string) (string, error) {
+	regionCode, err := query.ResolveRegion(region)
+	if err != nil {
+		return "", err
+	}
+	regionCodes, err := query.RegionCodesQuery()
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(regionCodes, regionCode) {
+		return "", query.RegionNotFound(region)
+	}
+	return regionCode, nil
+}
+
 // eventTeamsCmd lists all teams that participated in a specific event, showing their team ID, name, and home region.
 var eventTeamsCmd = &cobra.Command{
 	Use:   "event-teams [eventCode]",
 	Short: "List teams at an event",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # List the teams at an event
+  ftc event-teams USNCRAQ
+
+  # List the teams at an event in a prior season
+  ftc event-teams USNCRAQ --year 2024`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		eventCode := args[0]
 		year, _ := cmd.Flags().GetInt("year")
 		if year == 0 {
 			year = defaultYear
 		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
 		eventTeams, err := query.TeamsByEventQuery(eventCode, year)
 		if err != nil {
 			return err
 		}
 		eventTeamsOutput := terminal.RenderTeamsByEvent(eventTeams)
 		fmt.Println(eventTeamsOutput)
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
+		return nil
+	},
+}
+
+// previewCmd previews the field of an event before it is played.
+var previewCmd = &cobra.Command{
+	Use:   "preview [eventCode]",
+	Short: "Preview the teams registered for an event",
+	Long: `Preview the field of an event before it is played, for media previews and scouting prep: the registered teams
+with the best npOPR this season, the predicted top seeds, the teams that have already advanced, and the rookies.
+Each team's season is taken from the official events that ended before the event started. The predicted top seeds
+are the teams with the best OPR this season, one for each playoff alliance.`,
+	Example: `  # Preview the teams registered for an event
+  ftc preview USNCRAQ
+
+  # Show the 20 top teams in the field
+  ftc preview USNCRAQ --limit 20`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := args[0]
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", limit)
+		}
+		preview, err := query.EventPreviewQuery(eventCode, year, limit)
+		if err != nil {
+			return err
+		}
+		if preview == nil {
+			return query.EventNotFound(eventCode, year)
+		}
+		output := terminal.RenderEventPreview(preview)
+		fmt.Println(output)
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
+		return nil
+	},
+}
+
+// eventStatsCmd renders summary statistics for a specific event, including any registered teams that did not play
+// and any teams that played without being registered.
+var eventStatsCmd = &cobra.Command{
+	Use:   "event-stats [eventCode]",
+	Short: "Show statistics and attendance anomalies for an event",
+	Example: `  # Show statistics, no-shows, and walk-ons for an event
+  ftc event-stats USNCRAQ`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := args[0]
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
+		stats, err := query.EventStatsQuery(eventCode, year)
+		if err != nil {
+			return err
+		}
+		eventStatsOutput := terminal.RenderEventStats(stats)
+		fmt.Println(eventStatsOutput)
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
+		return nil
+	},
+}
+
+// eventFlowCmd renders the flow of the matches at a specific event, for FTAs tracking how far the matches have slipped
+// from the schedule.
+var eventFlowCmd = &cobra.Command{
+	Use:   "event-flow [eventCode]",
+	Short: "Show the match cycle time, breaks, and schedule slip at an event",
+	Long: `Show the flow of the matches at an event, measured from their actual start times: the average time between
+matches, the breaks between them, how far the latest match started behind or ahead of its scheduled time, and when
+the scheduled matches are projected to finish. Gaps of more than 20 minutes between matches are counted as breaks
+rather than match cycles. The schedule is requested from the FTC Events API; if it can't be fetched, the slip and
+projected finish aren't shown.`,
+	Example: `  # Show the flow of the qualification and playoff matches at an event
+  ftc event-flow USNCRAQ`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := args[0]
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
+		flow, err := query.EventFlowQuery(eventCode, year)
+		if err != nil {
+			return err
+		}
+		fmt.Println(terminal.RenderEventFlow(flow))
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
 		return nil
 	},
 }
@@ -148,19 +616,49 @@ var eventTeamsCmd = &cobra.Command{
 var rankingsCmd = &cobra.Command{
 	Use:   "rankings [eventCode]",
 	Short: "List team rankings at an event",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Show the qualification rankings at an event
+  ftc rankings USNCRAQ
+
+  # Compare each team's OPR and npAVG at the event with its season values
+  ftc rankings USNCRAQ --vs-season
+
+  # Show the top 10 teams as Markdown to post in Slack or Discord
+  ftc rankings USNCRAQ --markdown`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		eventCode := args[0]
 		year, _ := cmd.Flags().GetInt("year")
 		if year == 0 {
 			year = defaultYear
 		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
 		rankings, err := query.EventTeamRankingQuery(eventCode, year)
 		if err != nil {
 			return err
 		}
+		markdown, _ := cmd.Flags().GetBool("markdown")
+		vsSeason, _ := cmd.Flags().GetBool("vs-season")
+		if markdown && vsSeason {
+			return fmt.Errorf("--vs-season can't be used with --markdown")
+		}
+		if markdown {
+			fmt.Print(terminal.RenderTeamRankingsMarkdown(rankings, terminal.MarkdownTopTeams))
+			return nil
+		}
+		if vsSeason && rankings != nil {
+			comparisons, err := query.EventSeasonComparisonQuery(rankings.Event)
+			if err != nil {
+				return err
+			}
+			fmt.Println(terminal.RenderTeamRankingsVsSeason(rankings, comparisons))
+			printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
+			return nil
+		}
 		teamRankingsOutput := terminal.RenderTeamRankings(rankings)
 		fmt.Println(teamRankingsOutput)
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
 		return nil
 	},
 }
@@ -170,19 +668,25 @@ var rankingsCmd = &cobra.Command{
 var awardsCmd = &cobra.Command{
 	Use:   "awards [eventCode]",
 	Short: "List award winners at an event",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Show the awards given at an event
+  ftc awards USNCRAQ`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		eventCode := args[0]
 		year, _ := cmd.Flags().GetInt("year")
 		if year == 0 {
 			year = defaultYear
 		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
 		awardsResults, err := query.AwardsByEventQuery(eventCode, year)
 		if err != nil {
 			return err
 		}
 		awardResultsOutput := terminal.RenderAwardsByEvent(awardsResults)
 		fmt.Println(awardResultsOutput)
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
 		return nil
 	},
 }
@@ -192,19 +696,55 @@ var awardsCmd = &cobra.Command{
 var advancementCmd = &cobra.Command{
 	Use:   "advancement [eventCode]",
 	Short: "Show advancement report for an event",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Show the teams that advanced from an event
+  ftc advancement USNCRAQ
+
+  # List the advancing teams as Markdown to post in Slack or Discord
+  ftc advancement USNCRAQ --markdown
+
+  # Write the report as a PDF to print or post with the event's announcements
+  ftc advancement USNCRAQ --pdf USNCRAQ.pdf`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		eventCode := args[0]
 		year, _ := cmd.Flags().GetInt("year")
 		if year == 0 {
 			year = defaultYear
 		}
+		markdown, _ := cmd.Flags().GetBool("markdown")
+		pdfFile, _ := cmd.Flags().GetString("pdf")
+		if pdfFile != "" && markdown {
+			return fmt.Errorf("--pdf can't be used with --markdown")
+		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
 		advancementReport, err := query.AdvancementReportQuery(eventCode, year)
 		if err != nil {
 			return err
 		}
+		if pdfFile != "" {
+			f, err := os.Create(pdfFile)
+			if err != nil {
+				return err
+			}
+			if err := pdf.WriteAdvancementReport(f, advancementReport, time.Now()); err != nil {
+				f.Close()
+				return fmt.Errorf("failed to write %s: %w", pdfFile, err)
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			fmt.Printf("Wrote the advancement report for %s to %s\n", advancementReport.Event.EventCode, pdfFile)
+			return nil
+		}
+		if markdown {
+			fmt.Print(terminal.RenderAdvancementReportMarkdown(advancementReport))
+			return nil
+		}
 		advancementReportOutput := terminal.RenderAdvancementReport(advancementReport)
 		fmt.Println(advancementReportOutput)
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
 		return nil
 	},
 }
@@ -214,13 +754,21 @@ var advancementCmd = &cobra.Command{
 var matchesCmd = &cobra.Command{
 	Use:   "matches [eventCode]",
 	Short: "Show match results at an event",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Show all matches at an event
+  ftc matches USNCRAQ
+
+  # Show the matches for a single team at an event
+  ftc matches USNCRAQ --team 12345`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		eventCode := args[0]
 		year, _ := cmd.Flags().GetInt("year")
 		if year == 0 {
 			year = defaultYear
 		}
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
 		teamID, _ := cmd.Flags().GetInt("team")
 
 		if teamID != 0 {
@@ -240,28 +788,343 @@ var matchesCmd = &cobra.Command{
 			matchResultsOutput := terminal.RenderMatchDetails(matchResults)
 			fmt.Println(matchResultsOutput)
 		}
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
 		return nil
 	},
 }
 
+// queueCmd renders the next matches to be played at an event for the volunteers who queue the teams, with the
+// names of the teams in each match and the estimated wait until it starts. With --watch, the queue is shown again
+// at the interval until the command is stopped.
+var queueCmd = &cobra.Command{
+	Use:   "queue [eventCode]",
+	Short: "Show the next matches to queue at an event",
+	Long: `Show the next matches to be played at an event, with the teams to queue for each match and the estimated
+wait until it starts. The matches are the unplayed matches in the event's schedule, which is requested from the
+FTC Events API. The wait is estimated from the average cycle time of the matches played so far, measured from their
+actual start times; gaps of more than 20 minutes, such as lunch, aren't counted.`,
+	Example: `  # Show the next 5 matches at an event
+  ftc queue USNCRAQ
+
+  # Show the next 8 matches, refreshing every 30 seconds
+  ftc queue USNCRAQ --limit 8 --watch 30s`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := args[0]
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		watch, _ := cmd.Flags().GetDuration("watch")
+		if err := requireEvent(eventCode, year); err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		for {
+			report, err := query.QueueQuery(eventCode, year, limit)
+			if err != nil {
+				return err
+			}
+			if watch > 0 {
+				fmt.Print(clearScreen)
+			}
+			fmt.Println(terminal.RenderQueue(report))
+			printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
+			if watch <= 0 {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(watch):
+			}
+		}
+	},
+}
+
 // renderAdvancementReport renders the advancement report for a specific event, showing which teams advanced
 // and their points breakdown.
 var regionAdvancementCmd = &cobra.Command{
 	Use:   "region-advancement [region]",
 	Short: "Show all advancing teams in a region",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Show all advancing teams in a region
+  ftc region-advancement USNC
+
+  # Leave out the teams from other regions that advanced from the region's events
+  ftc region-advancement USNC --exclude-visitors
+
+  # List the advancing teams by event as Markdown to post in Slack or Discord
+  ftc region-advancement USNC --markdown`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		region := args[0]
 		year, _ := cmd.Flags().GetInt("year")
 		if year == 0 {
 			year = defaultYear
 		}
-		report, err := query.RegionAdvancementQuery(region, year)
+		region, err := resolveRegion(region)
+		if err != nil {
+			return err
+		}
+		excludeVisitors, _ := cmd.Flags().GetBool("exclude-visitors")
+		report, err := query.RegionAdvancementQuery(region, year, excludeVisitors)
 		if err != nil {
 			return err
 		}
+		if markdown, _ := cmd.Flags().GetBool("markdown"); markdown {
+			fmt.Print(terminal.RenderRegionAdvancementMarkdown(report))
+			return nil
+		}
 		output := terminal.RenderRegionAdvancementReport(report)
 		fmt.Println(output)
+		printDataAsOf(query.DataScope{Year: year, RegionCode: region})
+		return nil
+	},
+}
+
+// awardPerformanceCmd compares the judged awards won at a region's events, or at one event, with how the winners
+// performed on the field.
+var awardPerformanceCmd = &cobra.Command{
+	Use:   "award-performance [region]",
+	Short: "Compare judged award winners with their ranks and OPR",
+	Long: `Show the Inspire Award and other judged awards won at a region's events, or at one event with --event, along
+with each winner's qualification rank and OPR at the event, such as an Inspire Award winner that ranked 14th of 28
+with the 3rd highest OPR. A summary shows the average rank and OPR rank of each award's winners and how many ranked
+in the top quarter of their event, to compare judging with performance on the field. Winners at a multi-division
+event are ranked within their division.`,
+	Example: `  # Compare the judged award winners in a region with their performance
+  ftc award-performance USNC
+
+  # Compare the judged award winners at one event
+  ftc award-performance --event USNCRAQ`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		eventCodes, _ := cmd.Flags().GetStringSlice("event")
+		var region string
+		if len(eventCodes) == 0 {
+			if len(args) == 0 {
+				return fmt.Errorf("a region or --event is required")
+			}
+			var err error
+			region, err = resolveRegion(args[0])
+			if err != nil {
+				return err
+			}
+		}
+		if err := requireEvents(eventCodes, year); err != nil {
+			return err
+		}
+		report, err := query.JudgingPerformanceQuery(region, eventCodes, year)
+		if err != nil {
+			return err
+		}
+		if report == nil {
+			return query.EventNotFound(eventCodes[0], year)
+		}
+		output := terminal.RenderJudgingReport(report)
+		fmt.Println(output)
+		printDataAsOf(rankingsScope(region, eventCodes, year))
+		return nil
+	},
+}
+
+// regionTrendCmd compares a region's recent seasons.
+var regionTrendCmd = &cobra.Command{
+	Use:   "region-trend [region]",
+	Short: "Compare a region's competitiveness across seasons",
+	Long: `Compare a region's recent seasons: the number of events played and teams that competed, the average and high
+alliance scores, the average npOPR of the teams, and how many teams advanced to the championship and to the FIRST
+Championship. The change in teams and advancements from the season before is shown in parentheses. Each season is
+read from its own database, as selected by --season, so earlier seasons must have been synced with ftcdata. Scores
+depend on each season's game, so they only show how a region compares within a season.`,
+	Example: `  # Compare a region's last 4 seasons
+  ftc region-trend USNC
+
+  # Compare a region's last 6 seasons, up to 2024
+  ftc region-trend USNC --seasons 6 --year 2024`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		count, _ := cmd.Flags().GetInt("seasons")
+		if count < 1 {
+			return fmt.Errorf("--seasons must be at least 1")
+		}
+		region, err := resolveRegion(args[0])
+		if err != nil {
+			return err
+		}
+
+		seasons := make(map[int]database.DB, count)
+		for y := year - count + 1; y <= year; y++ {
+			if y == defaultYear {
+				seasons[y] = appDB
+				continue
+			}
+			seasonDB, err := database.Init(strconv.Itoa(y))
+			if err != nil {
+				return fmt.Errorf("failed to open the %d season's database: %w", y, err)
+			}
+			defer seasonDB.Close()
+			seasons[y] = seasonDB
+		}
+
+		trend, err := query.RegionTrendQuery(region, seasons)
+		if err != nil {
+			return err
+		}
+		output := terminal.RenderRegionTrend(trend)
+		fmt.Println(output)
+		return nil
+	},
+}
+
+// champsProjectionCmd projects the field of a region's championship from the current advancements and the teams
+// registered for the remaining events.
+var champsProjectionCmd = &cobra.Command{
+	Use:   "champs-projection [region]",
+	Short: "Project the championship field for a region",
+	Long: `Project the field of a region's championship. Teams that have already advanced are locked. At each remaining
+league tournament and qualifier, the registered teams that haven't advanced are ranked by their best npOPR this
+season; the teams that would take the event's advancement slots are likely, and the teams behind them are on the
+bubble. By default each remaining event is given the average number of slots of the region's completed events.`,
+	Example: `  # Project the championship field for a region
+  ftc champs-projection USNC
+
+  # Project the field assuming 6 advancement slots at each remaining event
+  ftc champs-projection USNC --slots 6`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		slots, _ := cmd.Flags().GetInt("slots")
+		if slots < 0 {
+			return fmt.Errorf("invalid slots %d, must not be negative", slots)
+		}
+		region, err := resolveRegion(args[0])
+		if err != nil {
+			return err
+		}
+		projection, err := query.ChampionshipProjectionQuery(region, year, slots)
+		if err != nil {
+			return err
+		}
+		if projection.SlotsPerEvent == 0 && len(projection.RemainingEvents) > 0 {
+			return fmt.Errorf("no events in %s have advanced teams yet; use --slots to give the slots at each remaining event", region)
+		}
+		output := terminal.RenderChampionshipProjection(projection)
+		fmt.Println(output)
+		return nil
+	},
+}
+
+// whatIfCmd shows the finishes a team needs at each remaining event in a region to advance.
+var whatIfCmd = &cobra.Command{
+	Use:   "what-if [teamID]",
+	Short: "Show the finishes a team needs to advance",
+	Long: `Show the finishes a team needs at each remaining league tournament and qualifier in a region to earn an
+advancement slot. The points needed are the median of the cutoffs at the region's completed events, where an
+event's cutoff is the lowest total advancement points of a team that advanced from it. For each playoff finish and
+judged award, the lowest qualification rank that still reaches the cutoff is shown, followed by the qualification
+points earned at each rank.`,
+	Example: `  # Show what a team needs at the remaining events in its home region
+  ftc what-if 12345
+
+  # Assume 70 points are needed to advance from an event in another region
+  ftc what-if 12345 --region USSC --cutoff 70`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teamID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid teamID '%s', must be a number", args[0])
+		}
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		cutoff, _ := cmd.Flags().GetInt("cutoff")
+		if cutoff < 0 {
+			return fmt.Errorf("invalid cutoff %d, must not be negative", cutoff)
+		}
+		region, _ := cmd.Flags().GetString("region")
+		if region != "" {
+			if region, err = resolveRegion(region); err != nil {
+				return err
+			}
+		}
+		report, err := query.WhatIfQuery(teamID, region, year, cutoff)
+		if err != nil {
+			return err
+		}
+		if report == nil {
+			return fmt.Errorf("team %d not found", teamID)
+		}
+		output := terminal.RenderWhatIf(report)
+		fmt.Println(output)
+		return nil
+	},
+}
+
+// cutoffsCmd shows the typical advancement cutoffs at the season's completed events.
+var cutoffsCmd = &cobra.Command{
+	Use:   "cutoffs",
+	Short: "Show the typical advancement cutoffs by event size",
+	Long: `Show the advancement cutoffs at the season's completed league tournaments and qualifiers. An event's cutoff
+is the lowest total advancement points of a team that advanced from it, not counting teams that had already
+advanced. The events are grouped by the number of teams ranked at them, and the median, lowest, and highest
+cutoff of each group is shown. Cutoffs are saved by ftcdata as events are synced.`,
+	Example: `  # Show the advancement cutoffs for the current season
+  ftc cutoffs
+
+  # Show the advancement cutoffs for an earlier season
+  ftc cutoffs --year 2024`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		analysis, err := query.AdvancementCutoffAnalysisQuery(year)
+		if err != nil {
+			return err
+		}
+		output := terminal.RenderCutoffAnalysis(analysis)
+		fmt.Println(output)
+		return nil
+	},
+}
+
+// qpTableCmd shows the qualification points earned at each rank at an event of a given size.
+var qpTableCmd = &cobra.Command{
+	Use:   "qp-table",
+	Short: "Show the qualification points earned at each rank",
+	Long: `Show the qualification points earned at each qualification rank at an event with the given number of teams,
+using the same points as the advancement report. The alliance selection points of a team selected for an alliance
+and the total of the two are shown beside them. The top-ranked teams are assumed to captain the alliance of the same
+number, and a lower-ranked team is assumed to be picked by the last alliance.`,
+	Example: `  # Show the qualification points at an event with 36 teams
+  ftc qp-table --teams 36`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teams, _ := cmd.Flags().GetInt("teams")
+		if teams <= 0 {
+			return fmt.Errorf("invalid number of teams %d, must be positive", teams)
+		}
+		table := query.QualificationPointsQuery(teams)
+		output := terminal.RenderQualificationPoints(table)
+		fmt.Println(output)
 		return nil
 	},
 }
@@ -271,13 +1134,19 @@ var regionAdvancementCmd = &cobra.Command{
 var eventAdvancementCmd = &cobra.Command{
 	Use:   "event-advancement [region]",
 	Short: "Show qualified teams organized by qualifying events",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Show the qualified teams in a region organized by qualifying event
+  ftc event-advancement USNC`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		region := args[0]
 		year, _ := cmd.Flags().GetInt("year")
 		if year == 0 {
 			year = defaultYear
 		}
+		region, err := resolveRegion(region)
+		if err != nil {
+			return err
+		}
 		summary, err := query.EventAdvancementSummaryQuery(region, year)
 		if err != nil {
 			return err
@@ -288,11 +1157,39 @@ var eventAdvancementCmd = &cobra.Command{
 	},
 }
 
-// teamRankingsCmd shows performance rankings for teams.
+// teamRankingsCmd shows performance rankings for teams. It can also be run as region-rankings, which is
+// equivalent to team-rankings --region.
 var teamRankingsCmd = &cobra.Command{
-	Use:   "team-rankings [region]",
-	Short: "Show performance rankings for teams",
-	Args:  cobra.MaximumNArgs(1),
+	Use:     "team-rankings [region]",
+	Aliases: []string{"region-rankings"},
+	Short:   "Show performance rankings for teams",
+	Example: `  # Show the top 20 teams in a region by OPR
+  ftc team-rankings USNC --sort opr --limit 20
+
+  # Show the rankings for a region using the region-rankings alias
+  ftc region-rankings USNC
+
+  # Show the rankings for the teams at an event
+  ftc team-rankings --event USNCRAQ
+
+  # Show the rankings as of a date, with movement since the week before
+  ftc team-rankings USNC --as-of 2025-01-15 --since 2025-01-08
+
+  # Show which teams win more matches than their alliances are expected to
+  ftc team-rankings USNC --wpa
+
+  # Include the teams from other regions that competed at the region's events
+  ftc team-rankings USNC --visitors
+
+  # Rank the teams at every event, with a table for each country
+  ftc team-rankings --group-by country --limit 10
+
+  # Rank the teams in a region by a custom formula
+  ftc team-rankings USNC --formula "0.5*npopr + 0.3*ccwm + 0.2*consistency"
+
+  # Show the top 10 teams and their movement this week as Markdown to post in Slack or Discord
+  ftc team-rankings USNC --since 2025-01-08 --markdown`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		region := ""
 		if len(args) > 0 {
@@ -308,14 +1205,65 @@ var teamRankingsCmd = &cobra.Command{
 			year = defaultYear
 		}
 		sortBy, _ := cmd.Flags().GetString("sort")
-		eventCode, _ := cmd.Flags().GetString("event")
+		eventCodes, _ := cmd.Flags().GetStringSlice("event")
 		country, _ := cmd.Flags().GetString("country")
 		limit, _ := cmd.Flags().GetInt("limit")
-
-		performances, err := query.TeamRankingsQuery(region, country, eventCode, year)
-		if err != nil {
+		includeUnofficial, _ := cmd.Flags().GetBool("include-unofficial")
+		includeVisitors, _ := cmd.Flags().GetBool("visitors")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		if err := checkGroupBy(groupBy); err != nil {
 			return err
 		}
+		if region != "" {
+			var err error
+			if region, err = resolveRegion(region); err != nil {
+				return err
+			}
+		}
+		if err := requireEvents(eventCodes, year); err != nil {
+			return err
+		}
+		asOfStr, _ := cmd.Flags().GetString("as-of")
+		sinceStr, _ := cmd.Flags().GetString("since")
+
+		if cmd.Flags().Changed("formula") {
+			for _, flag := range []string{"sort", "as-of", "since", "wpa", "markdown", "visitors", "group-by"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--formula can't be used with --%s", flag)
+				}
+			}
+			formulaFlag, _ := cmd.Flags().GetString("formula")
+			formula, err := resolveFormula(formulaFlag)
+			if err != nil {
+				return err
+			}
+			rankings, err := query.FormulaRankingsQuery(formula, region, country, eventCodes, year, includeUnofficial)
+			if err != nil {
+				return err
+			}
+			output := terminal.RenderFormulaRankings(rankings, formula, region, eventsLabel(eventCodes), year, limit)
+			fmt.Println(output)
+			printDataAsOf(rankingsScope(region, eventCodes, year))
+			return nil
+		}
+
+		var performances []query.TeamPerformance
+		var err error
+		if asOfStr != "" {
+			asOf, err := time.Parse(database.SnapshotDateFormat, asOfStr)
+			if err != nil {
+				return fmt.Errorf("invalid --as-of date %q, expected YYYY-MM-DD", asOfStr)
+			}
+			performances, err = query.TeamRankingsAsOfQuery(region, country, eventCodes, year, asOf, includeUnofficial, includeVisitors)
+			if err != nil {
+				return err
+			}
+		} else {
+			performances, err = query.TeamRankingsQuery(region, country, eventCodes, year, includeUnofficial, includeVisitors)
+			if err != nil {
+				return err
+			}
+		}
 
 		// Convert sortBy string to SortBy type
 		var sort terminal.SortBy
@@ -332,6 +1280,10 @@ var teamRankingsCmd = &cobra.Command{
 			sort = terminal.SortByNpDPR
 		case "npavg":
 			sort = terminal.SortByNpAVG
+		case "autoopr":
+			sort = terminal.SortByAutoOPR
+		case "autopct":
+			sort = terminal.SortByAutoPct
 		case "matches":
 			sort = terminal.SortByMatches
 		case "team":
@@ -340,8 +1292,65 @@ var teamRankingsCmd = &cobra.Command{
 			sort = terminal.SortByOPR
 		}
 
-		output := terminal.RenderTeamPerformance(performances, eventCode, sort, region, year, limit)
+		markdown, _ := cmd.Flags().GetBool("markdown")
+		showWPA, _ := cmd.Flags().GetBool("wpa")
+		if markdown && showWPA {
+			return fmt.Errorf("--wpa can't be used with --markdown")
+		}
+		if groupBy != "" {
+			for _, flag := range []string{"since", "wpa", "markdown"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--group-by can't be used with --%s", flag)
+				}
+			}
+			fmt.Println(terminal.RenderTeamPerformanceByCountry(performances, eventsLabel(eventCodes), sort, region, year, limit))
+			printDataAsOf(rankingsScope(region, eventCodes, year))
+			return nil
+		}
+		var wpa map[int]query.TeamWPA
+		if showWPA {
+			report, err := query.WinProbabilityQuery(region, eventCodes, year, includeUnofficial)
+			if err != nil {
+				return err
+			}
+			wpa = report.Teams
+		}
+		if sinceStr != "" {
+			since, err := time.Parse(database.SnapshotDateFormat, sinceStr)
+			if err != nil {
+				return fmt.Errorf("invalid --since date %q, expected YYYY-MM-DD", sinceStr)
+			}
+			previous, err := query.TeamRankingsAsOfQuery(region, country, eventCodes, year, since, includeUnofficial, includeVisitors)
+			if err != nil {
+				return err
+			}
+			if showWPA {
+				fmt.Println(terminal.RenderTeamPerformanceWPA(performances, previous, wpa, eventsLabel(eventCodes), sort, region, year, limit, since))
+				printDataAsOf(rankingsScope(region, eventCodes, year))
+				return nil
+			}
+			if markdown {
+				fmt.Print(terminal.RenderTeamPerformanceMarkdown(performances, previous, eventsLabel(eventCodes), sort, region, year, limit, since))
+				return nil
+			}
+			output := terminal.RenderTeamPerformanceMovement(performances, previous, eventsLabel(eventCodes), sort, region, year, limit, since)
+			fmt.Println(output)
+			printDataAsOf(rankingsScope(region, eventCodes, year))
+			return nil
+		}
+
+		if markdown {
+			fmt.Print(terminal.RenderTeamPerformanceMarkdown(performances, nil, eventsLabel(eventCodes), sort, region, year, limit, time.Time{}))
+			return nil
+		}
+		if showWPA {
+			fmt.Println(terminal.RenderTeamPerformanceWPA(performances, nil, wpa, eventsLabel(eventCodes), sort, region, year, limit, time.Time{}))
+			printDataAsOf(rankingsScope(region, eventCodes, year))
+			return nil
+		}
+		output := terminal.RenderTeamPerformance(performances, eventsLabel(eventCodes), sort, region, year, limit, region == "")
 		fmt.Println(output)
+		printDataAsOf(rankingsScope(region, eventCodes, year))
 		return nil
 	},
 }
@@ -351,7 +1360,12 @@ var teamEventRankingsCmd = &cobra.Command{
 	Use:   "team-event-rankings [region]",
 	Short: "Show performance rankings for teams by event",
 	Long:  "Show performance rankings for teams at individual events (not consolidated across events)",
-	Args:  cobra.MaximumNArgs(1),
+	Example: `  # Show each team's performance at each event in a region
+  ftc team-event-rankings USNC
+
+  # Show the performance of the teams at a single event by npOPR
+  ftc team-event-rankings --event USNCRAQ --sort npopr`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		region := ""
 		if len(args) > 0 {
@@ -367,11 +1381,21 @@ var teamEventRankingsCmd = &cobra.Command{
 			year = defaultYear
 		}
 		sortBy, _ := cmd.Flags().GetString("sort")
-		eventCode, _ := cmd.Flags().GetString("event")
+		eventCodes, _ := cmd.Flags().GetStringSlice("event")
 		country, _ := cmd.Flags().GetString("country")
 		limit, _ := cmd.Flags().GetInt("limit")
+		includeUnofficial, _ := cmd.Flags().GetBool("include-unofficial")
+		if region != "" {
+			var err error
+			if region, err = resolveRegion(region); err != nil {
+				return err
+			}
+		}
+		if err := requireEvents(eventCodes, year); err != nil {
+			return err
+		}
 
-		performances, err := query.TeamEventRankingsQuery(region, country, eventCode, year)
+		performances, err := query.TeamEventRankingsQuery(region, country, eventCodes, year, includeUnofficial)
 		if err != nil {
 			return err
 		}
@@ -391,6 +1415,10 @@ var teamEventRankingsCmd = &cobra.Command{
 			sort = terminal.SortByNpDPR
 		case "npavg":
 			sort = terminal.SortByNpAVG
+		case "autoopr":
+			sort = terminal.SortByAutoOPR
+		case "autopct":
+			sort = terminal.SortByAutoPct
 		case "matches":
 			sort = terminal.SortByMatches
 		case "team":
@@ -399,8 +1427,9 @@ var teamEventRankingsCmd = &cobra.Command{
 			sort = terminal.SortByOPR
 		}
 
-		output := terminal.RenderTeamEventPerformance(performances, eventCode, sort, region, year, limit)
+		output := terminal.RenderTeamEventPerformance(performances, eventsLabel(eventCodes), sort, region, year, limit)
 		fmt.Println(output)
+		printDataAsOf(rankingsScope(region, eventCodes, year))
 		return nil
 	},
 }
@@ -408,49 +1437,128 @@ var teamEventRankingsCmd = &cobra.Command{
 // init initializes the CLI commands and flags, and adds them to the root command.
 func init() {
 	// Add persistent season flag that applies to all commands
-	rootCmd.PersistentFlags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	rootCmd.PersistentFlags().StringVarP(&seasonFlag, "season", "s", "", "Season year used to select the database (defaults to FTC_SEASON environment variable)")
+
+	// Add persistent flag to sync an empty season instead of reporting on it
+	rootCmd.PersistentFlags().BoolVar(&autoSyncFlag, "auto-sync", false, "If no data has been synced for the season, sync the data the command needs from the FTC Events API before running it")
+
+	// Add persistent profiling flags, so a slow command can be profiled
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language of table headers and metric definitions: en, es, or fr (defaults to the LANG environment variable)")
+	rootCmd.PersistentFlags().StringVar(&localeFlag, "locale", "", "Locale numbers and dates are formatted for, such as en-GB, fr, or de_DE (defaults to the LANG environment variable)")
+	rootCmd.PersistentFlags().StringVar(&columnsFlag, "columns", os.Getenv("FTC_COLUMNS"), "JSON file that renames and hides table columns (defaults to FTC_COLUMNS environment variable, then columns.json in the user's ftcstanding config directory)")
+	rootCmd.PersistentFlags().StringVar(&cpuProfileFlag, "cpuprofile", "", "Write a CPU profile of the command to a file")
+	rootCmd.PersistentFlags().StringVar(&memProfileFlag, "memprofile", "", "Write a memory profile to a file when the command finishes")
+	cobra.OnFinalize(stopProfiling)
 
 	// Add year flag to all commands that need it
-	eventTeamsCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	rankingsCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	awardsCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	advancementCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	matchesCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	regionAdvancementCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	eventAdvancementCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	teamRankingsCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
+	eventsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	eventTeamsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	eventStatsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	previewCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	previewCmd.Flags().IntP("limit", "l", 10, "Number of top teams to show (0 shows every team that has played this season)")
+	eventFlowCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	rankingsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	rankingsCmd.Flags().Bool("vs-season", false, "Compare each team's OPR and npAVG at the event with its season-wide values")
+	awardsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	advancementCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	advancementCmd.Flags().String("pdf", "", "PDF file to write the report to, laid out for printing")
+	matchesCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	regionAdvancementCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	regionAdvancementCmd.Flags().Bool("exclude-visitors", false, "Leave out the teams from other regions that advanced from the region's events")
+	eventAdvancementCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	awardPerformanceCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	awardPerformanceCmd.Flags().StringSliceP("event", "e", nil, "Event code to show instead of a region (may be repeated or comma-separated)")
+	regionTrendCmd.Flags().IntP("year", "y", 0, "Latest season to compare (defaults to --season or FTC_SEASON environment variable)")
+	regionTrendCmd.Flags().Int("seasons", 4, "Number of seasons to compare, ending with --year")
+	champsProjectionCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	champsProjectionCmd.Flags().Int("slots", 0, "Advancement slots at each remaining event (defaults to the average of the completed events)")
+	whatIfCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	whatIfCmd.Flags().StringP("region", "r", "", "Region whose remaining events are shown (defaults to the team's home region)")
+	whatIfCmd.Flags().Int("cutoff", 0, "Points needed to advance (defaults to the median cutoff of the completed events)")
+	cutoffsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	qpTableCmd.Flags().IntP("teams", "t", 0, "Number of teams ranked at the event")
+	qpTableCmd.MarkFlagRequired("teams")
+	teamRankingsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+
+	// Add Markdown output to the reports that are commonly posted in chat
+	for _, cmd := range []*cobra.Command{rankingsCmd, advancementCmd, regionAdvancementCmd, teamRankingsCmd} {
+		cmd.Flags().Bool("markdown", false, "Render as Markdown without colors, to paste or post in Slack or Discord")
+	}
+
+	// Add events specific flags
+	eventsCmd.Flags().StringP("country", "c", "", "Country to filter events")
+	eventsCmd.Flags().String("near", "", "Only show events near a location (address or latitude,longitude)")
+	eventsCmd.Flags().String("within", "100mi", "Distance from --near to search (e.g. 150mi or 200km)")
 
 	// Add matches specific flags
 	matchesCmd.Flags().IntP("team", "t", 0, "Show matches for specific team only")
 
+	// Add queue specific flags
+	queueCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	queueCmd.Flags().IntP("limit", "l", 5, "Number of matches to show (0 shows every unplayed match)")
+	queueCmd.Flags().Duration("watch", 0, "Show the queue again at this interval until stopped (0 shows it once)")
+
 	// Add team-rankings specific flags
-	teamRankingsCmd.Flags().StringP("sort", "o", "npavg", "Sort by: opr, npopr, ccwm, dpr, npdpr, npavg, matches, team")
-	teamRankingsCmd.Flags().StringP("event", "e", "", "Event code to filter matches")
+	teamRankingsCmd.Flags().StringP("sort", "o", "npavg", "Sort by: opr, npopr, ccwm, dpr, npdpr, npavg, autoopr, autopct, matches, team")
+	teamRankingsCmd.Flags().StringSliceP("event", "e", nil, "Event code to filter matches (may be repeated or comma-separated)")
 	teamRankingsCmd.Flags().StringP("region", "r", "", "Region code to filter teams")
 	teamRankingsCmd.Flags().StringP("country", "c", "", "Country to filter teams")
 	teamRankingsCmd.Flags().IntP("limit", "l", 0, "Limit number of teams displayed (0 = no limit)")
+	teamRankingsCmd.Flags().Bool("include-unofficial", false, "Include unofficial events, such as scrimmages and off-season events")
+	teamRankingsCmd.Flags().Bool("visitors", false, "Include the teams from other regions that competed at the region's events, marked with *")
+	teamRankingsCmd.Flags().String("group-by", "", "Group the teams by country, ranking each country's teams in its own table")
+	teamsCmd.Flags().String("group-by", "", "Group the teams by country")
+	teamRankingsCmd.Flags().String("as-of", "", "Show rankings from the latest snapshot on or before this date (YYYY-MM-DD)")
+	teamRankingsCmd.Flags().String("since", "", "Show ranking movement since the snapshot on or before this date (YYYY-MM-DD)")
+	teamRankingsCmd.Flags().Bool("wpa", false, "Show each team's win probability added, the matches won above or below what was expected")
+	teamRankingsCmd.Flags().StringP("formula", "f", "", "Rank by a formula, such as \"0.5*npopr + 0.3*ccwm + 0.2*consistency\", or the name of one in the formulas file")
 
 	// Add team-event-rankings specific flags
-	teamEventRankingsCmd.Flags().IntP("year", "y", 0, "Year (defaults to FTC_SEASON environment variable)")
-	teamEventRankingsCmd.Flags().StringP("sort", "o", "npavg", "Sort by: opr, npopr, ccwm, dpr, npdpr, npavg, matches, team")
-	teamEventRankingsCmd.Flags().StringP("event", "e", "", "Event code to filter matches")
+	teamEventRankingsCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	teamEventRankingsCmd.Flags().StringP("sort", "o", "npavg", "Sort by: opr, npopr, ccwm, dpr, npdpr, npavg, autoopr, autopct, matches, team")
+	teamEventRankingsCmd.Flags().StringSliceP("event", "e", nil, "Event code to filter matches (may be repeated or comma-separated)")
 	teamEventRankingsCmd.Flags().StringP("region", "r", "", "Region code to filter teams")
 	teamEventRankingsCmd.Flags().StringP("country", "c", "", "Country to filter teams")
 	teamEventRankingsCmd.Flags().IntP("limit", "l", 0, "Limit number of entries displayed (0 = no limit)")
+	teamEventRankingsCmd.Flags().Bool("include-unofficial", false, "Include unofficial events, such as scrimmages and off-season events")
+
+	// Add team-card specific flags
+	teamCardCmd.Flags().String("png", "", "PNG file to write the card to")
+	teamCardCmd.MarkFlagRequired("png")
+
+	// Add shell completion for region codes, event codes, and flag values
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	registerCompletions()
 
 	// Add all commands to root
 	rootCmd.AddCommand(
 		teamCmd,
+		teamEventsCmd,
+		pathCmd,
+		teamCardCmd,
 		teamsCmd,
+		eventsCmd,
 		eventTeamsCmd,
+		previewCmd,
+		eventStatsCmd,
+		eventFlowCmd,
 		rankingsCmd,
 		awardsCmd,
 		advancementCmd,
 		matchesCmd,
+		queueCmd,
 		regionAdvancementCmd,
 		eventAdvancementCmd,
+		awardPerformanceCmd,
+		regionTrendCmd,
+		champsProjectionCmd,
+		whatIfCmd,
+		cutoffsCmd,
+		qpTableCmd,
 		teamRankingsCmd,
 		teamEventRankingsCmd,
+		completionCmd,
+		version.NewCommand("ftc"),
 	)
 }
 
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/ftcmock"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// TestMain syncs the canned season of the mock FTC Events API into a file database in a temporary directory, which
+// the commands are run against.
+func TestMain(m *testing.M) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	dir, err := os.MkdirTemp("", "ftc-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code, err := runWithMockSeason(m, dir)
+	os.RemoveAll(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+// runWithMockSeason syncs the mock season into a file database in dir, then runs the tests.
+func runWithMockSeason(m *testing.M, dir string) (int, error) {
+	os.Setenv("DB_TYPE", "file")
+	os.Setenv("FILEDB_DATA_DIR", filepath.Join(dir, "data"))
+	os.Setenv("FTC_SEASON", "2025")
+	os.Setenv("FTC_MOCK", "true")
+	os.Setenv("LANG", "en_US.UTF-8")
+	os.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	os.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+	os.Unsetenv("GOOGLE_SHEETS_CREDENTIALS")
+
+	db, err := database.InitPrimary("2025")
+	if err != nil {
+		return 0, err
+	}
+	request.Init(db)
+	query.Init(db)
+	mock, err := ftcmock.NewServer("")
+	if err != nil {
+		return 0, err
+	}
+	request.SetFTCServer(mock.URL, ftcmock.Username, ftcmock.AuthKey)
+	request.RequestAndSaveAll("2025", false, false)
+	mock.Close()
+	if _, err := query.SaveAdvancementCutoffs(2025, false); err != nil {
+		return 0, err
+	}
+	db.Close()
+
+	return m.Run(), nil
+}
+
+// commandTest is a run of ftc with the arguments, whose output must contain want. If interrupt is set, the command
+// runs until it is stopped, so it is stopped once its output contains interrupt.
+type commandTest struct {
+	args      []string
+	want      string
+	wantErr   string
+	interrupt string
+}
+
+// commandTests run every command of ftc, and its aliases, against the mock season.
+var commandTests = []commandTest{
+	{args: []string{"team", "90002"}, want: "Gear Grinders"},
+	{args: []string{"team-events", "90002"}, want: "USMOCKQ1"},
+	{args: []string{"path", "90002"}, want: "USMOCKCMP"},
+	{args: []string{"team-card", "90002", "--png", "{dir}/90002.png"}, want: "Wrote the card"},
+	{args: []string{"teams", "USMOCK"}, want: "Gear Grinders"},
+	{args: []string{"events", "USMOCK"}, want: "USMOCKQ1"},
+	{args: []string{"event-teams", "USMOCKQ1"}, want: "Gear Grinders"},
+	{args: []string{"preview", "USMOCKCMP"}, want: "Gear Grinders"},
+	{args: []string{"event-stats", "USMOCKQ1"}, want: "USMOCKQ1"},
+	{args: []string{"event-flow", "USMOCKQ1"}, want: "USMOCKQ1"},
+	{args: []string{"rankings", "USMOCKQ1"}, want: "90007"},
+	{args: []string{"awards", "USMOCKQ1"}, want: "USMOCKQ1"},
+	{args: []string{"advancement", "USMOCKQ1"}, want: "USMOCKQ1"},
+	{args: []string{"matches", "USMOCKQ1"}, want: "90007"},
+	{args: []string{"queue", "USMOCKQ1"}, want: "Match Queue"},
+	{args: []string{"region-advancement", "USMOCK"}, want: "USMOCK"},
+	{args: []string{"event-advancement", "USMOCK"}, want: "Mock Qualifier One"},
+	{args: []string{"award-performance", "USMOCK"}, want: "Inspire Award"},
+	{args: []string{"region-trend", "USMOCK", "--seasons", "1"}, want: "2025"},
+	{args: []string{"champs-projection", "USMOCK"}, want: "Projected Field"},
+	{args: []string{"what-if", "90002"}, want: "already advanced"},
+	{args: []string{"cutoffs"}, want: "Advancement Cutoffs"},
+	{args: []string{"qp-table", "--teams", "12"}, want: "Qualification Points"},
+	{args: []string{"team-rankings", "USMOCK"}, want: "Gear Grinders"},
+	{args: []string{"region-rankings", "USMOCK", "--sort", "opr"}, want: "Gear Grinders"},
+	{args: []string{"team-event-rankings", "USMOCK"}, want: "Gear Grinders"},
+	{args: []string{"auto-leaderboard", "USMOCK"}, want: "Gear Grinders"},
+	{args: []string{"fouls", "USMOCK"}, want: "Gear Grinders"},
+	{args: []string{"back-to-back", "USMOCK"}, want: "Back-to-Back"},
+	{args: []string{"awards-list"}, want: "Winning Alliance Award"},
+	{args: []string{"pick-list", "USMOCKCMP"}, want: "Gear Grinders"},
+	{args: []string{"rerank", "USMOCKQ1"}, want: "90007"},
+	{args: []string{"diagnostics", "USMOCKQ1"}, want: "90007"},
+	{args: []string{"enter-matches", "USMOCKQ1"}, want: "Matches not saved"},
+	{args: []string{"kiosk", "USMOCKQ1", "--interval", "1h"}, want: "Torque Titans", interrupt: "Torque Titans"},
+	{args: []string{"serve", "--port", "{port}"}, want: "Standings are available", interrupt: "Standings are available"},
+	// Exporting needs the credentials of a Google service account, which the tests don't have
+	{args: []string{"export-sheet", "rankings", "USMOCK", "--spreadsheet", "test"}, wantErr: "GOOGLE_SHEETS_CREDENTIALS"},
+	{args: []string{"export-sheet", "pick-list", "USMOCKCMP", "--spreadsheet", "test"}, wantErr: "GOOGLE_SHEETS_CREDENTIALS"},
+	{args: []string{"completion", "bash"}, want: "ftc"},
+	{args: []string{"version"}, want: "ftc"},
+	{args: []string{"help", "team-rankings"}, want: "team-rankings"},
+}
+
+func TestCommands(t *testing.T) {
+	for _, test := range commandTests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			output, err := runCommand(t, test)
+			switch {
+			case test.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("error = %v, want an error containing %q", err, test.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("error = %v\noutput:\n%s", err, output)
+			case !strings.Contains(output, test.want):
+				t.Errorf("output doesn't contain %q:\n%s", test.want, output)
+			}
+		})
+	}
+}
+
+// TestCommandsCovered checks that every command of ftc, and each of their aliases, is run by TestCommands, so a new
+// command can't be added without a test.
+func TestCommandsCovered(t *testing.T) {
+	tested := make(map[string]bool)
+	for _, test := range commandTests {
+		cmd, _, err := rootCmd.Find(test.args)
+		if err != nil {
+			t.Fatalf("%v: %v", test.args, err)
+		}
+		tested[cmd.CommandPath()] = true
+		if test.args[0] != cmd.Name() {
+			tested[cmd.Parent().CommandPath()+" "+test.args[0]] = true
+		}
+	}
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if cmd.Runnable() && cmd != rootCmd {
+			if !tested[cmd.CommandPath()] {
+				t.Errorf("%s isn't tested", cmd.CommandPath())
+			}
+			for _, alias := range cmd.Aliases {
+				if path := cmd.Parent().CommandPath() + " " + alias; !tested[path] {
+					t.Errorf("%s, an alias of %s, isn't tested", path, cmd.CommandPath())
+				}
+			}
+		}
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+}
+
+// runCommand runs ftc with the test's arguments and returns what it wrote to standard output. The flags are reset to
+// their defaults first, as they keep the values of the previous run.
+func runCommand(t *testing.T, test commandTest) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	args := make([]string, len(test.args))
+	for i, arg := range test.args {
+		arg = strings.ReplaceAll(arg, "{dir}", dir)
+		if strings.Contains(arg, "{port}") {
+			arg = strings.ReplaceAll(arg, "{port}", freePort(t))
+		}
+		args[i] = arg
+	}
+	resetFlags(rootCmd)
+
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	savedStdout, savedStdin := os.Stdout, os.Stdin
+	os.Stdout, os.Stdin = stdout, stdin
+	defer func() { os.Stdout, os.Stdin = savedStdout, savedStdin }()
+
+	// Commands that run until they are interrupted stop when their context is canceled, the same as on an interrupt
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if test.interrupt != "" {
+		go cancelOnOutput(ctx, cancel, stdout.Name(), test.interrupt)
+	}
+
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(io.Discard)
+	runErr := rootCmd.ExecuteContext(ctx)
+
+	output, err := os.ReadFile(stdout.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output), runErr
+}
+
+// cancelOnOutput cancels the context of a command that runs until it is interrupted once the output file contains
+// want.
+func cancelOnOutput(ctx context.Context, cancel context.CancelFunc, file, want string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+		if output, _ := os.ReadFile(file); strings.Contains(string(output), want) {
+			cancel()
+			return
+		}
+	}
+}
+
+// freePort returns a port that nothing is listening on.
+func freePort(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return fmt.Sprint(l.Addr().(*net.TCPAddr).Port)
+}
+
+// resetFlags sets the flags of the command and its subcommands back to their defaults.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// TestCompletionCache checks that event codes are completed from the mock season, and that completing without a
+// season doesn't cache the codes under an empty season.
+func TestCompletionCache(t *testing.T) {
+	cacheDir := filepath.Join(os.Getenv("XDG_CACHE_HOME"), "ftcstanding")
+
+	output, err := runCommand(t, commandTest{args: []string{cobra.ShellCompRequestCmd, "rankings", "USMOCKQ"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "USMOCKQ1") {
+		t.Errorf("completions don't contain USMOCKQ1:\n%s", output)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "codes-2025.json")); err != nil {
+		t.Errorf("codes of the season aren't cached: %v", err)
+	}
+
+	t.Setenv("FTC_SEASON", "")
+	if _, err := runCommand(t, commandTest{args: []string{cobra.ShellCompRequestCmd, "rankings", "USMOCKQ"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "codes-.json")); err == nil {
+		t.Error("codes are cached without a season")
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// defaultFormula is the formula used when none is given and the formulas file doesn't name a default.
+const defaultFormula = "npopr"
+
+// loadFormulas loads the named formulas from the file given by the FTC_FORMULAS environment variable. If it isn't
+// set, the formulas.json file in the user's ftcstanding configuration directory is loaded if it exists.
+func loadFormulas() (map[string]query.Formula, error) {
+	file := os.Getenv("FTC_FORMULAS")
+	if file == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, nil
+		}
+		file = filepath.Join(configDir, "ftcstanding", "formulas.json")
+		if _, err := os.Stat(file); err != nil {
+			return nil, nil
+		}
+	}
+	formulas, err := query.LoadFormulas(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load formulas: %w", err)
+	}
+	return formulas, nil
+}
+
+// resolveFormula returns the formula given by the --formula flag, which is either the name of a formula in the
+// formulas file or a formula such as "0.5*npopr + 0.5*ccwm". If the flag is empty, the formula named "default" in
+// the formulas file is used, or npOPR alone if there isn't one.
+func resolveFormula(value string) (query.Formula, error) {
+	formulas, err := loadFormulas()
+	if err != nil {
+		return nil, err
+	}
+	name := strings.ToLower(strings.TrimSpace(value))
+	if name == "" {
+		name = "default"
+	}
+	if formula, ok := formulas[name]; ok {
+		return formula, nil
+	}
+	if value == "" {
+		value = defaultFormula
+	}
+	return query.ParseFormula(value)
+}
+
+// pickListCmd ranks the teams at an event by a formula for alliance selection.
+var pickListCmd = &cobra.Command{
+	Use:   "pick-list [eventCode]",
+	Short: "Rank the teams at an event by a custom formula for alliance selection",
+	Long: `Rank the teams at an event by a formula that weighs the metrics by your strategy, for alliance selection. A
+formula is the weighted sum of any of opr, npopr, ccwm, dpr, npdpr, npavg, autoopr, autopct, matches, and
+consistency, such as "0.5*npopr + 0.3*ccwm + 0.2*consistency". Autopct is the percentage of a team's npOPR scored
+in the autonomous period. Consistency is 100 less the spread of a team's alliance scores as a percentage of their
+average, so steadier teams score closer to 100.
+
+Named formulas can be kept in a JSON file that maps each name to a formula, given by the FTC_FORMULAS environment
+variable or saved as formulas.json in the user's ftcstanding config directory. --formula takes a name from the file
+or a formula; without it, the formula named "default" is used, or npOPR alone. Teams already picked can be left out
+with --exclude as alliance selection goes on. The same formulas rank a region's teams with
+'ftc team-rankings --formula'.
+
+The tags and notes imported from a scouting spreadsheet with 'ftcdata import-scouting' are shown with the teams,
+and --tag narrows the list to the teams tagged with each of the tags given.`,
+	Example: `  # Rank the teams at an event by a custom formula
+  ftc pick-list USNCRAQ --formula "0.5*npopr + 0.3*ccwm + 0.2*consistency"
+
+  # Use the formula named "coach" in the formulas file, leaving out the teams already picked
+  ftc pick-list USNCRAQ --formula coach --exclude 12345,23456
+
+  # Only list the teams scouted as able to hang
+  ftc pick-list USNCRAQ --tag hang`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := database.NormalizeCode(args[0])
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", limit)
+		}
+		exclude, _ := cmd.Flags().GetIntSlice("exclude")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		formulaFlag, _ := cmd.Flags().GetString("formula")
+		formula, err := resolveFormula(formulaFlag)
+		if err != nil {
+			return err
+		}
+
+		events, err := query.EventsQuery(database.EventFilter{EventCodes: []string{eventCode}, Year: year})
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return query.EventNotFound(eventCode, year)
+		}
+		rankings, err := query.FormulaRankingsQuery(formula, "", "", []string{eventCode}, year, false)
+		if err != nil {
+			return err
+		}
+		notes, err := query.TeamNotesQuery(year)
+		if err != nil {
+			return err
+		}
+		rankings = query.FilterRankingsByTags(rankings, notes, tags)
+		output := terminal.RenderPickList(rankings, formula, events[0], exclude, notes, limit)
+		fmt.Println(output)
+		printDataAsOf(query.DataScope{Year: year, EventCode: eventCode})
+		return nil
+	},
+}
+
+func init() {
+	pickListCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	pickListCmd.Flags().StringP("formula", "f", "", "Name of a formula in the formulas file, or a formula such as \"0.5*npopr + 0.5*ccwm\"")
+	pickListCmd.Flags().IntSlice("exclude", nil, "Teams already picked, which are left out of the list (may be repeated)")
+	pickListCmd.Flags().StringSliceP("tag", "t", nil, "Scouting tag the teams must have (may be repeated or comma-separated)")
+	pickListCmd.Flags().IntP("limit", "l", 0, "Number of teams to show (0 shows every team)")
+	rootCmd.AddCommand(pickListCmd)
+}
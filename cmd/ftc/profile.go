@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+var (
+	cpuProfileFlag string
+	memProfileFlag string
+	cpuProfile     *os.File // CPU profile being written, or nil if CPU profiling isn't running
+)
+
+// startProfiling starts writing a CPU profile to the file given by --cpuprofile, if any.
+func startProfiling() error {
+	if cpuProfileFlag == "" || cpuProfile != nil {
+		return nil
+	}
+	f, err := os.Create(cpuProfileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to create CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+	cpuProfile = f
+	return nil
+}
+
+// stopProfiling stops the CPU profile and writes a heap profile to the file given by --memprofile, if any. It is
+// called once the command finishes, whether or not it succeeded, so the profiles of a failing command are kept.
+func stopProfiling() {
+	if cpuProfile != nil {
+		pprof.StopCPUProfile()
+		if err := cpuProfile.Close(); err != nil {
+			slog.Error("failed to write CPU profile", "file", cpuProfileFlag, "error", err)
+		}
+		cpuProfile = nil
+	}
+
+	if memProfileFlag == "" {
+		return
+	}
+	f, err := os.Create(memProfileFlag)
+	if err != nil {
+		slog.Error("failed to create memory profile", "file", memProfileFlag, "error", err)
+		return
+	}
+	defer f.Close()
+	// Collect garbage first so the profile shows the memory still in use
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		slog.Error("failed to write memory profile", "file", memProfileFlag, "error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// rerankCmd recomputes an event's qualification rankings under another ranking scheme.
+var rerankCmd = &cobra.Command{
+	Use:   "rerank [eventCode]",
+	Short: "Recompute an event's qualification rankings under another ranking scheme",
+	Long: `Recompute an event's qualification rankings under another ranking scheme from its qualification matches, and
+compare them with the official rankings, to see how the season's ranking rules change who seeds where. The schemes
+are:
+
+  official  the ranking score under the season's rules, as ranked by FIRST
+  record    2 points for a win and 1 for a tie, averaged over the matches played
+  avg       the average alliance score, including foul points
+  npavg     the average alliance score before fouls
+  opr       the team's OPR at the event
+
+Teams that tie under a scheme are ordered by their average score, and then by their official rank. A disqualified
+team is counted as losing the match with no score. Below the table are the teams that move into and out of the top
+seeds, who captain the playoff alliances.`,
+	Example: `  # Rank the teams at an event by their average score
+  ftc rerank USNCRAQ --scheme avg
+
+  # Rank the teams by their win-loss record alone
+  ftc rerank USNCRAQ --scheme record`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventCode := args[0]
+		year, _ := cmd.Flags().GetInt("year")
+		if year == 0 {
+			year = defaultYear
+		}
+		scheme, _ := cmd.Flags().GetString("scheme")
+
+		rerank, err := query.RerankQuery(eventCode, year, scheme)
+		if err != nil {
+			return err
+		}
+		if rerank == nil {
+			return query.EventNotFound(eventCode, year)
+		}
+		fmt.Println(terminal.RenderRerank(rerank))
+		printDataAsOf(query.DataScope{Year: year, EventCode: rerank.Event.EventCode})
+		return nil
+	},
+}
+
+func init() {
+	rerankCmd.Flags().IntP("year", "y", 0, "Year (defaults to --season or FTC_SEASON environment variable)")
+	rerankCmd.Flags().String("scheme", "avg", "Ranking scheme: "+strings.Join(query.RerankSchemes, ", "))
+	rerankCmd.RegisterFlagCompletionFunc("scheme", cobra.FixedCompletions(query.RerankSchemes, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(rerankCmd)
+}
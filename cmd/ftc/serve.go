@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/internal/ftcmock"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/rbrabson/ftcstanding/server"
+	"github.com/rbrabson/ftcstanding/web"
+	"github.com/spf13/cobra"
+)
+
+// serveCmd serves the HTTP API and a web UI for standings from a single process, optionally keeping events synced
+// with the FTC Events API. With --standalone, it uses a file-based database in a local directory, so nothing else
+// needs to be installed or configured to run standings at an event venue.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the API and a web UI for standings, syncing events as they are played",
+	Long: `Serve the HTTP API and a web UI for event rankings, matches, and advancement on one port, and keep the
+events given by --event synced with the FTC Events API while they are being played.
+
+With --standalone, the data is kept in a file-based database in --data-dir instead of the database configured by
+DB_TYPE, and the season's teams, awards, and events are requested the first time it is run. Only the FTC Events API
+credentials are needed, which makes it suitable for running standings from a laptop at an event venue. The
+file-based database is used rather than an embedded SQLite database so ftc needs no cgo and builds as a single static
+binary for every platform.`,
+	Example: `  # Run standings for an event from a laptop, with the data kept in ./ftcstanding-data
+  ftc serve --standalone --event USTXCMP
+
+  # Sync two events every 30 seconds on port 3000
+  ftc serve --standalone --event USTXHOQ1 --event USTXHOQ2 --sync-interval 30s --port 3000
+
+  # Serve the configured database without syncing
+  ftc serve`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// The database is chosen from the environment, so the standalone database must be set up before it is opened
+		if standalone, _ := cmd.Flags().GetBool("standalone"); standalone {
+			dataDir, _ := cmd.Flags().GetString("data-dir")
+			os.Setenv("DB_TYPE", "file")
+			os.Setenv("FILEDB_DATA_DIR", dataDir)
+		}
+		return rootCmd.PersistentPreRunE(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		port, _ := cmd.Flags().GetInt("port")
+		standalone, _ := cmd.Flags().GetBool("standalone")
+		eventCodes, _ := cmd.Flags().GetStringSlice("event")
+		interval, _ := cmd.Flags().GetDuration("sync-interval")
+		mock, _ := cmd.Flags().GetBool("mock")
+		for i, code := range eventCodes {
+			eventCodes[i] = strings.ToUpper(code)
+		}
+		season := strconv.Itoa(defaultYear)
+
+		// Send requests to a mock of the FTC Events API, to try out the standings before an event
+		if mock {
+			mockServer, err := ftcmock.NewServer(os.Getenv("FTC_MOCK_FIXTURES"))
+			if err != nil {
+				return fmt.Errorf("failed to start mock FTC server: %w", err)
+			}
+			defer mockServer.Close()
+			request.SetFTCServer(mockServer.URL, ftcmock.Username, ftcmock.AuthKey)
+			slog.Info("Using mock FTC server", "url", mockServer.URL)
+		}
+
+		if standalone {
+			if err := request.BootstrapSeason(season); err != nil {
+				return err
+			}
+		}
+
+		mux := http.NewServeMux()
+		api := server.NewServer(appDB)
+		mux.Handle("/v1/", api)
+		mux.Handle("/health", api)
+		mux.Handle("/", web.Handler(season, eventCodes))
+
+		addr := fmt.Sprintf(":%d", port)
+		srv := &http.Server{
+			Addr:         addr,
+			Handler:      mux,
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if len(eventCodes) > 0 {
+			go syncEvents(ctx, eventCodes, interval)
+		}
+
+		errs := make(chan error, 1)
+		go func() {
+			slog.Info("Serving standings", "address", addr, "season", season, "events", eventCodes)
+			fmt.Printf("Standings are available at http://localhost:%d/\n", port)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- err
+			}
+		}()
+
+		select {
+		case err := <-errs:
+			return fmt.Errorf("server failed to start: %w", err)
+		case <-ctx.Done():
+		}
+		slog.Info("Shutting down server...")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("server forced to shutdown: %w", err)
+		}
+		return nil
+	},
+}
+
+// syncEvents syncs the events' results and recalculates their team rankings right away, then again at each interval
+// until the context is done. If the interval isn't positive, the events are only synced once.
+func syncEvents(ctx context.Context, eventCodes []string, interval time.Duration) {
+	for {
+		for _, code := range eventCodes {
+			syncEvent(code)
+		}
+		if interval <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// syncEvent requests the event's results from the FTC Events API, saves them, and recalculates the event's team
+// rankings. Each sync is recorded as a sync run.
+func syncEvent(eventCode string) {
+	season := strconv.Itoa(defaultYear)
+	run := request.StartSyncRun(season, "event "+eventCode)
+	_, err := request.SyncEvent(season, eventCode)
+	request.FinishSyncRun(run, err)
+	if err != nil {
+		slog.Warn("failed to sync event", "event", eventCode, "error", err)
+		return
+	}
+	slog.Info("Synced event", "event", eventCode)
+}
+
+func init() {
+	serveCmd.Flags().IntP("port", "p", 8080, "Port to listen on")
+	serveCmd.Flags().Bool("standalone", false, "Keep the data in a file-based database in --data-dir, requesting the season the first time it is run")
+	serveCmd.Flags().String("data-dir", "ftcstanding-data", "Directory of the standalone database")
+	serveCmd.Flags().StringSliceP("event", "e", nil, "Event code to keep synced while it is being played (may be repeated)")
+	serveCmd.Flags().Duration("sync-interval", time.Minute, "How often to sync the events (0 syncs them once at startup)")
+	serveCmd.Flags().Bool("mock", false, "Sync from a mock FTC Events API with canned data")
+	serveCmd.RegisterFlagCompletionFunc("event", completeEventCodes)
+	serveCmd.ValidArgsFunction = cobra.NoFileCompletions
+	rootCmd.AddCommand(serveCmd)
+}
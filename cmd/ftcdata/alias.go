@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/spf13/cobra"
+)
+
+var overwriteFlag bool
+
+// regionAliasCmd groups the commands that manage region aliases.
+var regionAliasCmd = &cobra.Command{
+	Use:   "region-alias",
+	Short: "Manage the friendly names of regions",
+	Long: `Manage the aliases that map friendly names, such as "North Carolina" or "NorCal", to region codes like USNC.
+The ftc CLI and the API server accept an alias anywhere a region code is expected. Aliases are matched without
+regard to case or spacing.`,
+}
+
+// regionAliasListCmd lists the region aliases.
+var regionAliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the region aliases",
+	Example: `  # List the region aliases
+  ftcdata region-alias list --season 2025`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := openSeason(seasonFlag); err != nil {
+			return err
+		}
+		defer db.Close()
+
+		aliases, err := db.GetRegionAliases()
+		if err != nil {
+			return err
+		}
+		if len(aliases) == 0 {
+			fmt.Println("No region aliases; add the default aliases with 'ftcdata region-alias seed'")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Alias\tRegion\t")
+		for _, alias := range aliases {
+			fmt.Fprintf(w, "%s\t%s\t\n", alias.Alias, alias.RegionCode)
+		}
+		return w.Flush()
+	},
+}
+
+// regionAliasAddCmd adds or replaces a region alias.
+var regionAliasAddCmd = &cobra.Command{
+	Use:   "add <alias> <regionCode>",
+	Short: "Add a region alias",
+	Long: `Add an alias for a region. Adding an alias that already exists, without regard to case or spacing, changes
+the region it maps to.`,
+	Example: `  # Add an alias for the North Carolina region
+  ftcdata region-alias add "Tar Heel State" USNC --season 2025`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		alias := database.NormalizeAlias(args[0])
+		if alias == "" {
+			return fmt.Errorf("the alias can't be empty")
+		}
+		regionCode := database.NormalizeCode(args[1])
+
+		if _, err := openSeason(seasonFlag); err != nil {
+			return err
+		}
+		defer db.Close()
+
+		regionCodes, err := db.GetRegionCodes()
+		if err != nil {
+			return err
+		}
+		if slices.Contains(regionCodes, database.NormalizeCode(alias)) {
+			return fmt.Errorf("%s is a region code and can't be used as an alias", alias)
+		}
+		if err := db.SaveRegionAlias(&database.RegionAlias{Alias: alias, RegionCode: regionCode}); err != nil {
+			return fmt.Errorf("failed to save region alias: %w", err)
+		}
+		fmt.Printf("%s is now an alias for %s\n", alias, regionCode)
+		if !slices.Contains(regionCodes, regionCode) {
+			fmt.Printf("No events have been synced for region %s yet\n", regionCode)
+		}
+		return nil
+	},
+}
+
+// regionAliasRemoveCmd removes a region alias.
+var regionAliasRemoveCmd = &cobra.Command{
+	Use:   "remove <alias>",
+	Short: "Remove a region alias",
+	Example: `  # Remove an alias
+  ftcdata region-alias remove "Tar Heel State" --season 2025`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := openSeason(seasonFlag); err != nil {
+			return err
+		}
+		defer db.Close()
+
+		if err := db.DeleteRegionAlias(args[0]); err != nil {
+			return fmt.Errorf("failed to remove region alias: %w", err)
+		}
+		fmt.Printf("Removed region alias %s\n", database.NormalizeAlias(args[0]))
+		return nil
+	},
+}
+
+// regionAliasSeedCmd saves the default region aliases.
+var regionAliasSeedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Add the default region aliases",
+	Long: `Add the default region aliases: the name and postal abbreviation of each state with a single region, and the
+common names of the regions within California, New York, and Texas. Aliases that already exist are left as they
+are unless --overwrite is given.`,
+	Example: `  # Add the default aliases, keeping any that have been changed
+  ftcdata region-alias seed --season 2025
+
+  # Reset the default aliases to the regions they map to by default
+  ftcdata region-alias seed --season 2025 --overwrite`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := openSeason(seasonFlag); err != nil {
+			return err
+		}
+		defer db.Close()
+
+		existing, err := db.GetRegionAliases()
+		if err != nil {
+			return err
+		}
+		saved := 0
+		for _, alias := range database.DefaultRegionAliases() {
+			exists := slices.ContainsFunc(existing, func(a *database.RegionAlias) bool {
+				return strings.EqualFold(a.Alias, alias.Alias)
+			})
+			if exists && !overwriteFlag {
+				continue
+			}
+			if err := db.SaveRegionAlias(alias); err != nil {
+				return fmt.Errorf("failed to save region alias %s: %w", alias.Alias, err)
+			}
+			saved++
+		}
+		fmt.Printf("Saved %d region aliases\n", saved)
+		return nil
+	},
+}
+
+func init() {
+	regionAliasCmd.PersistentFlags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+
+	regionAliasSeedCmd.Flags().BoolVar(&overwriteFlag, "overwrite", false, "Replace aliases that already exist with their default regions")
+
+	regionAliasCmd.AddCommand(regionAliasListCmd, regionAliasAddCmd, regionAliasRemoveCmd, regionAliasSeedCmd)
+	rootCmd.AddCommand(regionAliasCmd)
+}
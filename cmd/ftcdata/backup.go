@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupOutFlag string
+	restoreInFlag string
+)
+
+// keySources are the data sources that event keys are saved for, whose keys are included in a backup.
+var keySources = []string{"toa"}
+
+// backupCmd writes a season's records to a backup file.
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up a season's data to a file",
+	Long: `Write every record of a season to a JSON file, compressed with gzip if the file name ends in ".gz". The
+records are read through the same interface for every database backend, so a backup of a file-based database can be
+restored to MySQL and the other way around. Event summaries are recalculated when the backup is restored, and only
+the latest snapshot of each team ranking is included.`,
+	Example: `  # Back up the season mid-event
+  ftcdata backup --season 2025 --out ftc-2025.json.gz`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		backup, err := database.NewBackup(db, season, keySources...)
+		if err != nil {
+			return fmt.Errorf("failed to back up season %s: %w", season, err)
+		}
+
+		f, err := os.Create(backupOutFlag)
+		if err != nil {
+			return err
+		}
+		if err := database.WriteBackup(f, backupOutFlag, backup); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", backupOutFlag, err)
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Printf("Backed up %d records of %d events to %s\n", backup.Count(), len(backup.Events), backupOutFlag)
+		return nil
+	},
+}
+
+// restoreCmd restores a season's records from a backup file.
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a season's data from a backup file",
+	Long: `Save every record in a backup written by 'ftcdata backup' to the database configured by DB_TYPE, then
+recalculate the summaries of the restored events. Records already in the database are replaced by the backed up
+records with the same keys, and other records are kept. The backup is restored to the season it was taken from.`,
+	Example: `  # Restore a backup on another laptop
+  ftcdata restore --in ftc-2025.json.gz`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(restoreInFlag)
+		if err != nil {
+			return err
+		}
+		backup, err := database.ReadBackup(f, restoreInFlag)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", restoreInFlag, err)
+		}
+		if seasonFlag != "" && seasonFlag != backup.Season {
+			return fmt.Errorf("the backup is of season %s, not %s", backup.Season, seasonFlag)
+		}
+
+		if _, err := openSeason(backup.Season); err != nil {
+			return err
+		}
+		defer db.Close()
+
+		if err := backup.Restore(db); err != nil {
+			return fmt.Errorf("failed to restore season %s: %w", backup.Season, err)
+		}
+		fmt.Printf("Restored %d records of %d events to season %s from the backup taken %s\n",
+			backup.Count(), len(backup.Events), backup.Season, backup.CreatedAt.Local().Format("2006-01-02 15:04"))
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	backupCmd.Flags().StringVarP(&backupOutFlag, "out", "o", "", "File to write the backup to")
+	backupCmd.MarkFlagRequired("out")
+
+	restoreCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season the backup is expected to be of (defaults to the backup's season)")
+	restoreCmd.Flags().StringVarP(&restoreInFlag, "in", "i", "", "Backup file to restore")
+	restoreCmd.MarkFlagRequired("in")
+
+	rootCmd.AddCommand(backupCmd, restoreCmd)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+// unofficialEventTypes maps the event types that may be given to an unofficial event to the event type codes used
+// by the FTC Events API.
+var unofficialEventTypes = map[string]string{
+	"scrimmage": "0",
+	"offseason": "5",
+}
+
+var (
+	eventNameFlag    string
+	eventDateFlag    string
+	eventEndFlag     string
+	eventTypeFlag    string
+	eventRegionFlag  string
+	eventVenueFlag   string
+	eventCityFlag    string
+	eventStateFlag   string
+	eventCountryFlag string
+	eventCSVFlag     string
+)
+
+// eventCmd groups the commands that manage unofficial events.
+var eventCmd = &cobra.Command{
+	Use:   "event",
+	Short: "Register unofficial events and enter their match results",
+	Long: `Register events that aren't in the FTC Events API, such as scrimmages and off-season events, and enter their
+match results. These events are flagged as unofficial, so they are left out of team rankings unless
+--include-unofficial is given.`,
+}
+
+// eventAddCmd registers an unofficial event.
+var eventAddCmd = &cobra.Command{
+	Use:   "add <eventCode>",
+	Short: "Register an unofficial event",
+	Long: `Register a scrimmage or off-season event that isn't in the FTC Events API. The event is saved in the season's
+database and flagged as unofficial. Registering an event again updates it.`,
+	Example: `  # Register a scrimmage
+  ftcdata event add NCSCRIM1 --season 2025 --name "Raleigh Scrimmage" --date 2025-10-04 --region USNC
+
+  # Register a two-day off-season event
+  ftcdata event add NCOFF --season 2025 --name "Summer Showdown" --date 2026-06-13 --end 2026-06-14 --type offseason`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eventType, ok := unofficialEventTypes[strings.ToLower(eventTypeFlag)]
+		if !ok {
+			return fmt.Errorf("invalid event type %q; use scrimmage or offseason", eventTypeFlag)
+		}
+		dateStart, err := time.Parse(time.DateOnly, eventDateFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --date %q, expected YYYY-MM-DD", eventDateFlag)
+		}
+		dateEnd := dateStart
+		if eventEndFlag != "" {
+			if dateEnd, err = time.Parse(time.DateOnly, eventEndFlag); err != nil {
+				return fmt.Errorf("invalid --end %q, expected YYYY-MM-DD", eventEndFlag)
+			}
+			if dateEnd.Before(dateStart) {
+				return fmt.Errorf("--end can't be before --date")
+			}
+		}
+
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		year, err := strconv.Atoi(season)
+		if err != nil {
+			return fmt.Errorf("invalid season %q", season)
+		}
+		event := &database.Event{
+			EventCode:  args[0],
+			Year:       year,
+			Name:       eventNameFlag,
+			Type:       eventType,
+			RegionCode: strings.ToUpper(eventRegionFlag),
+			Venue:      eventVenueFlag,
+			City:       eventCityFlag,
+			StateProv:  eventStateFlag,
+			Country:    eventCountryFlag,
+			DateStart:  dateStart,
+			DateEnd:    dateEnd,
+		}
+		if err := request.RegisterUnofficialEvent(event); err != nil {
+			return fmt.Errorf("failed to register event: %w", err)
+		}
+		fmt.Printf("Registered unofficial event %s (%s)\n", event.EventCode, event.EventID)
+		return nil
+	},
+}
+
+// eventMatchesCmd enters the match results of an unofficial event.
+var eventMatchesCmd = &cobra.Command{
+	Use:   "matches <eventCode>",
+	Short: "Enter the match results of an unofficial event",
+	Long: `Enter the match results of an unofficial event, either from a CSV file or by answering prompts for each match.
+The teams at the event and their team rankings are calculated once the matches are saved. Entering a match again
+replaces it.
+
+Interactively, each match is prompted for and the entered matches can be listed, edited, and deleted before they
+are saved.
+
+The CSV file starts with a header row naming its columns, as described by 'ftcdata import-matches':
+
+  level,match,red1,red2,blue1,blue2,red_score,blue_score,red_auto,blue_auto,red_fouls,blue_fouls
+
+The level is Q (qualification) or P (playoff), and the match is the match number, or the series for playoff
+matches. The scores include foul points. The red_auto, blue_auto, red_fouls, and blue_fouls columns are optional,
+and the fouls are the foul points awarded to the alliance.`,
+	Example: `  # Enter matches from a CSV file
+  ftcdata event matches NCSCRIM1 --season 2025 --csv scrimmage.csv
+
+  # Enter matches interactively
+  ftcdata event matches NCSCRIM1 --season 2025`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		event, err := findUnofficialEvent(season, args[0])
+		if err != nil {
+			return err
+		}
+
+		var matches []*request.ManualMatch
+		if eventCSVFlag != "" {
+			f, err := os.Open(eventCSVFlag)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if matches, err = request.ReadManualMatchesCSV(f); err != nil {
+				return fmt.Errorf("failed to read %s: %w", eventCSVFlag, err)
+			}
+			for _, match := range matches {
+				if match.EventCode != "" && match.EventCode != event.EventCode {
+					return fmt.Errorf("%s match %d is for event %s, not %s", match.TournamentLevel, match.MatchNumber, match.EventCode, event.EventCode)
+				}
+			}
+		} else {
+			var save bool
+			if matches, save = terminal.EnterMatches(os.Stdin, os.Stdout, event); !save {
+				fmt.Println("Matches not saved")
+				return nil
+			}
+		}
+		if len(matches) == 0 {
+			fmt.Println("No matches entered")
+			return nil
+		}
+
+		saved, err := request.SaveManualMatches(event, matches)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Saved %d matches for %s\n", len(saved), event.EventCode)
+		return nil
+	},
+}
+
+// findUnofficialEvent returns the unofficial event with the event code in the season.
+func findUnofficialEvent(season, eventCode string) (*database.Event, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season %q", season)
+	}
+	events, err := db.GetAllEvents(database.EventFilter{EventCodes: []string{strings.ToUpper(eventCode)}, Year: year})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("event %s not found; register it with 'ftcdata event add'", eventCode)
+	}
+	if !events[0].Unofficial {
+		return nil, fmt.Errorf("event %s is an official event", eventCode)
+	}
+	return events[0], nil
+}
+
+func init() {
+	eventCmd.PersistentFlags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+
+	eventAddCmd.Flags().StringVar(&eventNameFlag, "name", "", "Name of the event")
+	eventAddCmd.Flags().StringVar(&eventDateFlag, "date", "", "Date the event starts (YYYY-MM-DD)")
+	eventAddCmd.Flags().StringVar(&eventEndFlag, "end", "", "Date the event ends (YYYY-MM-DD, defaults to --date)")
+	eventAddCmd.Flags().StringVar(&eventTypeFlag, "type", "scrimmage", "Type of event: scrimmage or offseason")
+	eventAddCmd.Flags().StringVarP(&eventRegionFlag, "region", "r", "", "Region code of the event (e.g., USNC)")
+	eventAddCmd.Flags().StringVar(&eventVenueFlag, "venue", "", "Venue of the event")
+	eventAddCmd.Flags().StringVar(&eventCityFlag, "city", "", "City of the event")
+	eventAddCmd.Flags().StringVar(&eventStateFlag, "state", "", "State or province of the event")
+	eventAddCmd.Flags().StringVar(&eventCountryFlag, "country", "", "Country of the event")
+	eventAddCmd.MarkFlagRequired("name")
+	eventAddCmd.MarkFlagRequired("date")
+
+	eventMatchesCmd.Flags().StringVar(&eventCSVFlag, "csv", "", "CSV file of match results (prompts for each match if not given)")
+
+	eventCmd.AddCommand(eventAddCmd, eventMatchesCmd)
+	rootCmd.AddCommand(eventCmd)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/spf13/cobra"
+)
+
+// syncEventCodes returns the codes of the events given by --event and --events-file, normalized and in the order
+// they were given, with any event given more than once only returned the first time.
+func syncEventCodes() ([]string, error) {
+	eventCodes := slices.Clone(eventFlags)
+	if eventsFileFlag != "" {
+		fileCodes, err := readEventsFile(eventsFileFlag)
+		if err != nil {
+			return nil, err
+		}
+		eventCodes = append(eventCodes, fileCodes...)
+	}
+
+	var unique []string
+	for _, eventCode := range database.NormalizeCodes(eventCodes) {
+		if eventCode != "" && !slices.Contains(unique, eventCode) {
+			unique = append(unique, eventCode)
+		}
+	}
+	return unique, nil
+}
+
+// readEventsFile reads the event codes listed in a file. The codes are separated by commas or white space, and
+// anything on a line after a # is a comment.
+func readEventsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open events file: %w", err)
+	}
+	defer f.Close()
+
+	var eventCodes []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		eventCodes = append(eventCodes, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\r'
+		})...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events file: %w", err)
+	}
+	if len(eventCodes) == 0 {
+		return nil, fmt.Errorf("events file %s doesn't list any events", path)
+	}
+	return eventCodes, nil
+}
+
+// validateSyncFlags checks that the flags that choose what to sync are combined in a way that has a meaning, rather
+// than letting one of them silently take priority over the others. --region may be given with --event or
+// --events-file to restrict the events to those in the region, and with --registrations to restrict the
+// registrations synced to the region's events.
+func validateSyncFlags(cmd *cobra.Command, eventCodes []string) error {
+	events := len(eventCodes) > 0
+	switch {
+	case allFlag && (events || regionFlag != ""):
+		return fmt.Errorf("--all syncs every event, so it can't be combined with --region, --event, or --events-file")
+	case registerFlag && (allFlag || events || retryFlag):
+		return fmt.Errorf("--registrations can't be combined with --all, --event, --events-file, or --retry; use --region to restrict it to a region's events")
+	case retryFlag && events:
+		return fmt.Errorf("--retry can't be combined with --event or --events-file, which sync the events themselves")
+	case resumeFlag && !allFlag:
+		return fmt.Errorf("--resume can only be used with --all")
+	case cmd.Flags().Changed("workers") && (events || regionFlag == "" || registerFlag):
+		return fmt.Errorf("--workers can only be used with --region")
+	}
+	return nil
+}
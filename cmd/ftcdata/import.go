@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/ftcscout"
+	"github.com/rbrabson/ftcstanding/geocode"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/rbrabson/ftcstanding/toa"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importEventsFlag  []string
+	importRefreshFlag bool
+	ftcScoutURLFlag   string
+	toaURLFlag        string
+	toaAPIKeyFlag     string
+	toaMapFlag        []string
+)
+
+// importCmd groups the commands that import data from sources other than the FTC Events API.
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Backfill data from sources other than the FTC Events API",
+	Long: `Backfill the results of events that are missing from the FTC Events API using another data source. Each
+match and event ranking that is imported records the source it was imported from.`,
+}
+
+// importFTCScoutCmd backfills event results from FTC Scout.
+var importFTCScoutCmd = &cobra.Command{
+	Use:   "ftcscout",
+	Short: "Backfill event results from ftcscout.org",
+	Long: `Backfill the matches and rankings of events from the FTC Scout API (ftcscout.org), then calculate the team
+rankings for each backfilled event. By default every event in the season that has ended but has no matches is
+backfilled. Use --event to backfill specific events; events that aren't in the database are imported from FTC
+Scout as well. Events that already have matches are skipped unless --refresh is given.`,
+	Example: `  # Backfill every event in the season that has no matches
+  ftcdata import ftcscout --season 2025
+
+  # Backfill specific events, replacing any matches already saved
+  ftcdata import ftcscout --season 2025 --event USNCRAQ --event USNCCMP --refresh`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		source := ftcscout.NewSource(ftcscout.New(ftcScoutURLFlag))
+		events := request.BackfillEvents(source, season, upperAll(importEventsFlag), importRefreshFlag)
+		fmt.Printf("Backfilled %d events from %s\n", len(events), source.Name())
+		return nil
+	},
+}
+
+// importTOACmd imports seasons from The Orange Alliance.
+var importTOACmd = &cobra.Command{
+	Use:   "toa",
+	Short: "Import a season from theorangealliance.org",
+	Long: `Import the awards, teams, and events of a season from The Orange Alliance (theorangealliance.org), then
+backfill the matches, rankings, and awards of its events. The Orange Alliance retains seasons that the FTC Events
+API no longer provides, so it is used for team history before the 2019 season.
+
+The Orange Alliance identifies events by keys such as "1819-NC-RAQ". Each event is given the FIRST event code if
+The Orange Alliance has one, or else the event key without the season and dashes, such as "NCRAQ". The event that
+each key maps to is saved, so later imports keep the same event IDs. Use --map to map an event key to a different
+event code. An API key is required, and is read from --api-key or the TOA_API_KEY environment variable.`,
+	Example: `  # Import the 2018 season
+  ftcdata import toa --season 2018
+
+  # Import the 2018 season, mapping an event key to a specific event code
+  ftcdata import toa --season 2018 --map 1819-CMP-HOU1=HOUSTON1`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := toaAPIKeyFlag
+		if apiKey == "" {
+			apiKey = os.Getenv("TOA_API_KEY")
+			if apiKey == "" {
+				return fmt.Errorf("API key not specified. Use --api-key flag or set TOA_API_KEY environment variable")
+			}
+		}
+		mappings := make(map[string]string, len(toaMapFlag))
+		for _, mapping := range toaMapFlag {
+			key, code, ok := strings.Cut(mapping, "=")
+			if !ok || key == "" || code == "" {
+				return fmt.Errorf("invalid mapping %q; use EVENTKEY=EVENTCODE", mapping)
+			}
+			mappings[key] = code
+		}
+
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		source := toa.NewSource(toa.New(toaURLFlag, apiKey))
+		keys, err := db.GetEventSourceKeys(source.Name())
+		if err != nil {
+			return fmt.Errorf("failed to load event keys: %w", err)
+		}
+		for _, key := range keys {
+			if event, err := db.GetEvent(key.EventID); err == nil && event != nil {
+				source.SetEventCode(key.SourceKey, event.EventCode)
+			}
+		}
+		for key, code := range mappings {
+			source.SetEventCode(key, code)
+		}
+
+		for _, event := range request.ImportSeason(source, season) {
+			key, ok := source.EventKey(event.EventCode)
+			if !ok {
+				continue
+			}
+			eventKey := &database.EventSourceKey{Source: source.Name(), SourceKey: key, EventID: event.EventID}
+			if err := db.SaveEventSourceKey(eventKey); err != nil {
+				return fmt.Errorf("failed to save event key %s: %w", key, err)
+			}
+		}
+		events := request.BackfillEvents(source, season, upperAll(importEventsFlag), importRefreshFlag)
+		fmt.Printf("Backfilled %d events from %s\n", len(events), source.Name())
+		return nil
+	},
+}
+
+// openSeason determines the season from the flag or the FTC_SEASON environment variable, and initializes the
+// database and geocoder for it.
+func openSeason(seasonFlag string) (string, error) {
+	season := seasonFlag
+	if season == "" {
+		season = os.Getenv("FTC_SEASON")
+		if season == "" {
+			return "", fmt.Errorf("season not specified. Use --season flag or set FTC_SEASON environment variable")
+		}
+	}
+
+	var err error
+	db, err = database.InitPrimary(season)
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize database: %w", err)
+	}
+	request.Init(db)
+
+	geocoder, err := geocode.New()
+	if err != nil {
+		db.Close()
+		return "", fmt.Errorf("failed to initialize geocoder: %w", err)
+	}
+	request.SetGeocoder(geocoder)
+	return season, nil
+}
+
+// upperAll returns the codes in upper case, as event codes are stored.
+func upperAll(codes []string) []string {
+	upper := make([]string, 0, len(codes))
+	for _, code := range codes {
+		upper = append(upper, strings.ToUpper(code))
+	}
+	return upper
+}
+
+func init() {
+	importCmd.PersistentFlags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	importCmd.PersistentFlags().StringSliceVarP(&importEventsFlag, "event", "e", nil, "Event code to backfill; may be repeated (defaults to every event without matches)")
+	importCmd.PersistentFlags().BoolVar(&importRefreshFlag, "refresh", false, "Backfill events that already have matches")
+	importFTCScoutCmd.Flags().StringVar(&ftcScoutURLFlag, "url", "", "Base URL of the FTC Scout REST API (defaults to https://api.ftcscout.org/rest/v1)")
+
+	importTOACmd.Flags().StringVar(&toaURLFlag, "url", "", "Base URL of The Orange Alliance API (defaults to https://theorangealliance.org/api)")
+	importTOACmd.Flags().StringVar(&toaAPIKeyFlag, "api-key", "", "The Orange Alliance API key (defaults to TOA_API_KEY environment variable)")
+	importTOACmd.Flags().StringSliceVar(&toaMapFlag, "map", nil, "Map an event key to an event code, as EVENTKEY=EVENTCODE; may be repeated")
+
+	importCmd.AddCommand(importFTCScoutCmd)
+	importCmd.AddCommand(importTOACmd)
+	rootCmd.AddCommand(importCmd)
+}
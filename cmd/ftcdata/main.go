@@ -4,23 +4,36 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/geocode"
+	"github.com/rbrabson/ftcstanding/internal/ftcmock"
+	"github.com/rbrabson/ftcstanding/internal/version"
 	"github.com/rbrabson/ftcstanding/query"
 	"github.com/rbrabson/ftcstanding/request"
 	"github.com/spf13/cobra"
 )
 
 var (
-	db          database.DB
-	allFlag     bool
-	regionFlag  string
-	eventFlag   string
-	seasonFlag  string
-	refreshFlag bool
+	db             database.DB
+	allFlag        bool
+	regionFlag     string
+	eventFlags     []string
+	eventsFileFlag string
+	seasonFlag     string
+	refreshFlag    bool
+	resumeFlag     bool
+	snapshotFlag   bool
+	workersFlag    int
+	mockFlag       bool
+	movementFlag   bool
+	webhookFlag    string
+	registerFlag   bool
+	retryFlag      bool
 )
 
 // setLogLevelFromEnv sets the log level from the LOG_LEVEL environment variable.
@@ -54,17 +67,53 @@ var rootCmd = &cobra.Command{
   # Sync data for a specific region
   ftcdata --season 2025 --region USNC
 
+  # Sync a region, calculating team rankings with 4 workers
+  ftcdata --season 2025 --region USNC --refresh --workers 4
+
   # Sync data for a specific event
   ftcdata --season 2025 --event USNCRAQ
 
+  # Sync the events listed in a file, one or more event codes to a line
+  ftcdata --season 2025 --events-file events.txt
+
+  # Sync the listed events that are in a region, skipping the others
+  ftcdata --season 2025 --events-file events.txt --region USNC
+
   # Force refresh all data
-  ftcdata --season 2025 --all --refresh`,
+  ftcdata --season 2025 --all --refresh
+
+  # Resume an interrupted sync from the last completed event
+  ftcdata --season 2025 --all --resume
+
+  # Retry only the events whose results failed to sync in earlier runs
+  ftcdata --season 2025 --retry
+
+  # Sync the teams registered for a region's upcoming events
+  ftcdata --season 2025 --registrations --region USNC
+
+  # Record today's team rankings as a snapshot
+  ftcdata --season 2025 --snapshot
+
+  # Sync the canned data from the mock FTC Events API
+  ftcdata --season 2025 --all --mock
+
+  # Sync a region and post the biggest rank risers and fallers of each finished event to a webhook
+  ftcdata --season 2025 --region USNC --movement-webhook https://hooks.slack.com/services/...`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no action flags are specified, show help
-		if !allFlag && eventFlag == "" && regionFlag == "" {
+		if !allFlag && len(eventFlags) == 0 && eventsFileFlag == "" && regionFlag == "" && !snapshotFlag && !registerFlag && !retryFlag {
 			return cmd.Help()
 		}
 
+		eventCodes, err := syncEventCodes()
+		if err != nil {
+			return err
+		}
+		if err := validateSyncFlags(cmd, eventCodes); err != nil {
+			return err
+		}
+		regionFlag = database.NormalizeCode(regionFlag)
+
 		// Determine season
 		season := seasonFlag
 		if season == "" {
@@ -74,8 +123,7 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
-		var err error
-		db, err = database.Init(season)
+		db, err = database.InitPrimary(season)
 		if err != nil {
 			return fmt.Errorf("failed to initialize database: %w", err)
 		}
@@ -84,147 +132,156 @@ var rootCmd = &cobra.Command{
 		request.Init(db)
 		query.Init(db)
 
-		// Handle different modes based on flags
-		switch {
-		case eventFlag != "":
-			// Process single event
-			processEvent(season, eventFlag)
-		case regionFlag != "":
-			// Process region
-			processRegion(season, regionFlag, refreshFlag)
-		case allFlag:
-			// Process all data
-			request.RequestAndSaveAll(season, refreshFlag)
+		// Send requests to a mock of the FTC Events API instead of the real one
+		if useMock() {
+			mock, err := ftcmock.NewServer(os.Getenv("FTC_MOCK_FIXTURES"))
+			if err != nil {
+				return fmt.Errorf("failed to start mock FTC server: %w", err)
+			}
+			defer mock.Close()
+			request.SetFTCServer(mock.URL, ftcmock.Username, ftcmock.AuthKey)
+			slog.Info("Using mock FTC server", "url", mock.URL)
 		}
 
-		return nil
-	},
-}
-
-func init() {
-	// Load environment variables
-	godotenv.Load()
-	setLogLevelFromEnv()
+		geocoder, err := geocode.New()
+		if err != nil {
+			return fmt.Errorf("failed to initialize geocoder: %w", err)
+		}
+		request.SetGeocoder(geocoder)
 
-	// Define flags
-	rootCmd.Flags().BoolVarP(&allFlag, "all", "a", false, "Sync all data for the season")
-	rootCmd.Flags().StringVarP(&regionFlag, "region", "r", "", "Region code to filter events (e.g., USCHS)")
-	rootCmd.Flags().StringVarP(&eventFlag, "event", "e", "", "Event code to process (e.g., USNCCOQ)")
-	rootCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
-	rootCmd.Flags().BoolVar(&refreshFlag, "refresh", false, "Force refresh of all data")
+		// Record the run, so 'ftcdata status' and the API server can report when the data was last refreshed
+		scope := syncScope(eventCodes)
+		if scope == "" {
+			return runSync(season, eventCodes)
+		}
+		run := request.StartSyncRun(season, scope)
+		err = runSync(season, eventCodes)
+		request.FinishSyncRun(run, err)
+		return err
+	},
 }
 
-func main() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// syncScope describes what the flags sync, such as "all", "region USNC", "event USNCRAQ", or "events USNCRAQ,
+// USNCCOQ", or returns an empty string if they only save a snapshot. A long list of events is described by their
+// number alone.
+func syncScope(eventCodes []string) string {
+	switch {
+	case registerFlag && regionFlag != "":
+		return "registrations " + regionFlag
+	case registerFlag:
+		return "registrations"
+	case len(eventCodes) == 1:
+		return "event " + eventCodes[0]
+	case len(eventCodes) > maxScopeEvents:
+		return fmt.Sprintf("%d events", len(eventCodes))
+	case len(eventCodes) > 1:
+		return "events " + strings.Join(eventCodes, ", ")
+	case regionFlag != "":
+		return "region " + regionFlag
+	case allFlag:
+		return "all"
+	case retryFlag:
+		return "retry"
 	}
+	return ""
 }
 
-// processEvent processes a single event
-func processEvent(season, eventCode string) {
-	slog.Info("Processing single event", "eventCode", eventCode, "season", season)
-
-	// Get the event
-	filter := database.EventFilter{
-		EventCodes: []string{eventCode},
-	}
-	events, err := db.GetAllEvents(filter)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to load events: %v\n", err)
-		os.Exit(1)
-	}
-
-	var event *database.Event
-	for _, e := range events {
-		if e.EventCode == eventCode {
-			event = e
-			break
+// maxScopeEvents is the most events a sync run's scope lists by their codes.
+const maxScopeEvents = 3
+
+// runSync syncs the season's data as the flags direct, with the events given by --event and --events-file synced
+// rather than the whole region.
+func runSync(season string, eventCodes []string) error {
+	// Handle different modes based on flags
+	var synced []*database.Event
+	switch {
+	case registerFlag:
+		// Process the registrations of upcoming events, limited to a region if one is given
+		upcoming := request.SyncRegistrations(season, regionFlag)
+		slog.Info("Synced registrations for upcoming events", "season", season, "region", regionFlag, "events", len(upcoming))
+	case len(eventCodes) > 0:
+		// Process the events, restricted to the region if one is given
+		events, err := request.SyncEvents(season, eventCodes, regionFlag)
+		if err != nil {
+			return err
 		}
+		synced = events
+	case regionFlag != "":
+		// Process region
+		synced = request.SyncRegion(season, regionFlag, refreshFlag, workersFlag)
+	case allFlag:
+		// Process all data
+		synced = request.RequestAndSaveAll(season, refreshFlag, resumeFlag)
 	}
 
-	if event == nil {
-		fmt.Fprintf(os.Stderr, "Error: Event %s not found\n", eventCode)
-		os.Exit(1)
-	}
-
-	// Process event details
-	request.RequestAndSaveEventAwards(event)
-	request.RequestAndSaveEventRankings(event)
-	request.RequestAndSaveEventAdvancements(event)
-	request.RequestAndSaveMatches(event)
-	request.RequestAndSaveTeamsInEvent(event)
-
-	slog.Info("Finished processing event", "eventCode", eventCode)
-}
-
-// processRegion processes all events in a region
-func processRegion(season, regionCode string, refresh bool) {
-	slog.Info("Processing region", "regionCode", regionCode, "season", season)
-
-	// Get or refresh teams and awards
-	teams, err := db.GetAllTeams()
-	if err != nil {
-		slog.Warn("failed to load teams", "error", err)
-	}
-	if refresh || len(teams) == 0 {
-		teams = request.RequestAndSaveTeams(season)
-	}
-
-	awards, err := db.GetAllAwards()
-	if err != nil {
-		slog.Warn("failed to load awards", "error", err)
-	}
-	if refresh || len(awards) == 0 {
-		awards = request.RequestAndSaveAwards(season)
+	// Retry the events whose results failed to sync in earlier runs, other than those this run already synced
+	if !registerFlag && (allFlag || regionFlag != "" || retryFlag) && len(eventCodes) == 0 {
+		synced = append(synced, request.RetryFailedEvents(season, synced)...)
 	}
 
-	// Get events for the region
-	filter := database.EventFilter{
-		RegionCodes: []string{regionCode},
-	}
-	events, err := db.GetAllEvents(filter)
-	if err != nil {
-		slog.Warn("failed to load region events", "regionCode", regionCode, "error", err)
+	// Summarize how the synced events moved their teams in the region's team rankings
+	if movementFlag || webhookFlag != "" {
+		reportRankMovement(synced, webhookFlag)
 	}
 
-	if refresh || len(events) == 0 {
-		// Refresh all events and filter
-		allEvents := request.RequestAndSaveEvents(season)
-		events = nil
-		for _, e := range allEvents {
-			if e.RegionCode == regionCode {
-				events = append(events, e)
+	// Save the advancement cutoffs of the events teams have advanced from since the last sync
+	if !registerFlag && (allFlag || len(eventCodes) > 0 || regionFlag != "" || retryFlag) {
+		if year, err := strconv.Atoi(season); err == nil {
+			if count, err := query.SaveAdvancementCutoffs(year, refreshFlag); err != nil {
+				slog.Warn("failed to save advancement cutoffs", "season", season, "error", err)
+			} else {
+				slog.Info("Saved advancement cutoffs", "season", season, "count", count)
 			}
 		}
 	}
 
-	slog.Info("Found events in region", "regionCode", regionCode, "eventCount", len(events))
-
-	// If not refresh, filter events to only those in the past 24 hours
-	filteredEvents := events
-	if !refresh {
-		now := time.Now()
-		var recentEvents []*database.Event
-		for _, event := range events {
-			if event.DateStart.Before(now) && event.DateStart.After(now.Add(-24*time.Hour)) {
-				recentEvents = append(recentEvents, event)
-			}
+	// Record a snapshot of the team rankings once any sync has completed
+	if snapshotFlag {
+		if err := request.SaveTeamRankingSnapshot(time.Now()); err != nil {
+			return fmt.Errorf("failed to save team ranking snapshot: %w", err)
 		}
-		filteredEvents = recentEvents
 	}
 
-	for i, event := range filteredEvents {
-		slog.Info("Processing event", "eventNumber", i+1, "totalEvents", len(filteredEvents), "event", event.EventCode)
+	return nil
+}
 
-		request.RequestAndSaveEventAwards(event)
-		request.RequestAndSaveEventRankings(event)
-		request.RequestAndSaveEventAdvancements(event)
-		request.RequestAndSaveMatches(event)
-		request.RequestAndSaveTeamsInEvent(event)
+func init() {
+	// Load environment variables
+	godotenv.Load()
+	setLogLevelFromEnv()
 
-		slog.Info("Finished processing event", "eventCode", event.EventCode)
+	// Define flags
+	rootCmd.Flags().BoolVarP(&allFlag, "all", "a", false, "Sync all data for the season")
+	rootCmd.Flags().StringVarP(&regionFlag, "region", "r", "", "Region code to filter events (e.g., USCHS); restricts --event, --events-file, and --registrations to the region's events")
+	rootCmd.Flags().StringSliceVarP(&eventFlags, "event", "e", nil, "Event code to process (e.g., USNCCOQ); may be repeated")
+	rootCmd.Flags().StringVar(&eventsFileFlag, "events-file", "", "File listing the event codes to process, separated by commas or white space, with # starting a comment")
+	rootCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	rootCmd.Flags().BoolVar(&refreshFlag, "refresh", false, "Force refresh of all data")
+	rootCmd.Flags().BoolVar(&resumeFlag, "resume", false, "Resume an interrupted --all sync from the last completed event")
+	rootCmd.Flags().BoolVar(&registerFlag, "registrations", false, "Sync the teams registered for the season's upcoming events, limited to the events in --region if given (events must already be synced)")
+	rootCmd.Flags().BoolVar(&retryFlag, "retry", false, "Retry the events whose results failed to sync in earlier runs (also done after --all and --region)")
+	rootCmd.Flags().BoolVar(&snapshotFlag, "snapshot", false, "Save a dated snapshot of the current team rankings")
+	rootCmd.Flags().BoolVar(&mockFlag, "mock", false, "Sync from a mock FTC Events API with canned data (also enabled by FTC_MOCK=true)")
+	rootCmd.Flags().BoolVar(&movementFlag, "movement", false, "Log the teams that moved up and down their region's rankings the most at each finished event that was synced")
+	rootCmd.Flags().StringVar(&webhookFlag, "movement-webhook", os.Getenv("MOVEMENT_WEBHOOK_URL"), "Webhook URL to post the rank movement of each finished event to (defaults to MOVEMENT_WEBHOOK_URL environment variable)")
+	rootCmd.Flags().IntVar(&workersFlag, "workers", 0, "Number of events to calculate team rankings for in parallel (defaults to the number of CPUs)")
+
+	rootCmd.AddCommand(version.NewCommand("ftcdata"))
+}
+
+// useMock returns true if data should be synced from the mock FTC Events API, either because the --mock flag was
+// given or because the FTC_MOCK environment variable is set to true.
+func useMock() bool {
+	if mockFlag {
+		return true
 	}
+	enabled, _ := strconv.ParseBool(os.Getenv("FTC_MOCK"))
+	return enabled
+}
 
-	slog.Info("Finished processing region", "regionCode", regionCode)
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/rbrabson/ftcstanding/terminal"
+	"github.com/spf13/cobra"
+)
+
+var importMatchesEventFlag string
+
+// importMatchesCmd imports match results kept in a spreadsheet.
+var importMatchesCmd = &cobra.Command{
+	Use:   "import-matches <file.csv>",
+	Short: "Import match results from a CSV file",
+	Long: `Import match results from a CSV file, such as a spreadsheet kept by a league, into the season's database.
+Each match is saved to an event that is already in the database, either an event synced from the FTC Events API
+or an unofficial event registered with 'ftcdata event add'. The teams at each event and their team rankings are
+calculated once its matches are saved.
+
+The CSV file starts with a header row naming its columns, which may be in any order:
+
+  event,level,match,red1,red2,blue1,blue2,red_score,blue_score,red_auto,blue_auto,red_fouls,blue_fouls
+
+  event       Event code of the event the match was played at
+  level       Q (qualification) or P (playoff)
+  match       Match number, or the series for playoff matches
+  red1, red2  Team numbers of the red alliance (red2 may be empty)
+  blue1, blue2
+              Team numbers of the blue alliance (blue2 may be empty)
+  red_score, blue_score
+              Final scores, including foul points
+  red_auto, blue_auto
+              Autonomous points
+  red_fouls, blue_fouls
+              Foul points awarded to the alliance
+
+The event column may be left out if --event is given, and the auto and foul columns are optional. Every row is
+validated before anything is saved, and the file is rejected if any row is invalid, names an event that isn't in
+the database, or replaces a match that was requested from a data source. Importing a match again replaces it.`,
+	Example: `  # Import a league's matches, with the event named on each row
+  ftcdata import-matches league.csv --season 2025
+
+  # Import the matches of a single event
+  ftcdata import-matches scrimmage.csv --season 2025 --event NCSCRIM1
+
+  # Check a file without saving it
+  ftcdata import-matches league.csv --season 2025 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		matches, err := request.ReadManualMatchesCSV(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %s:\n%w", args[0], err)
+		}
+		if len(matches) == 0 {
+			fmt.Println("No matches to import")
+			return nil
+		}
+
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		events, matchesByEvent, err := linkMatchesToEvents(season, matches, importMatchesEventFlag)
+		if err != nil {
+			return err
+		}
+		warnUnknownTeams(matches)
+		for _, event := range events {
+			if err := request.CheckManualMatches(event, matchesByEvent[event.EventCode]); err != nil {
+				return fmt.Errorf("invalid matches for %s: %w", event.EventCode, err)
+			}
+		}
+
+		if dryRunFlag {
+			for _, event := range events {
+				fmt.Printf("%s - %s\n", event.EventCode, event.Name)
+				fmt.Print(terminal.RenderManualMatches(matchesByEvent[event.EventCode]))
+			}
+			fmt.Printf("Would import %d matches for %d events\n", len(matches), len(events))
+			return nil
+		}
+
+		for _, event := range events {
+			saved, err := request.SaveManualMatches(event, matchesByEvent[event.EventCode])
+			if err != nil {
+				return fmt.Errorf("failed to import matches for %s: %w", event.EventCode, err)
+			}
+			fmt.Printf("Imported %d matches for %s\n", len(saved), event.EventCode)
+		}
+		return nil
+	},
+}
+
+// linkMatchesToEvents groups the matches by the event they were played at. Matches that don't name an event are
+// linked to the default event code. The events are returned in the order they first appear in the matches, and
+// an error is returned for every event code that isn't in the season's database.
+func linkMatchesToEvents(season string, matches []*request.ManualMatch, defaultEventCode string) ([]*database.Event, map[string][]*request.ManualMatch, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid season %q", season)
+	}
+
+	var codes []string
+	matchesByEvent := make(map[string][]*request.ManualMatch)
+	for _, match := range matches {
+		if match.EventCode == "" {
+			match.EventCode = strings.ToUpper(defaultEventCode)
+		}
+		if match.EventCode == "" {
+			return nil, nil, fmt.Errorf("%s match %d doesn't name an event; add an event column or use --event", match.TournamentLevel, match.MatchNumber)
+		}
+		if !slices.Contains(codes, match.EventCode) {
+			codes = append(codes, match.EventCode)
+		}
+		matchesByEvent[match.EventCode] = append(matchesByEvent[match.EventCode], match)
+	}
+
+	found, err := db.GetAllEvents(database.EventFilter{EventCodes: codes, Year: year})
+	if err != nil {
+		return nil, nil, err
+	}
+	eventsByCode := make(map[string]*database.Event, len(found))
+	for _, event := range found {
+		eventsByCode[event.EventCode] = event
+	}
+
+	events := make([]*database.Event, 0, len(codes))
+	var errs []error
+	for _, code := range codes {
+		event, ok := eventsByCode[code]
+		if !ok {
+			errs = append(errs, fmt.Errorf("event %s not found in the %s season; sync it or register it with 'ftcdata event add'", code, season))
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	return events, matchesByEvent, nil
+}
+
+// warnUnknownTeams prints a warning for each team in the matches that isn't in the database, as these are often
+// mistyped team numbers.
+func warnUnknownTeams(matches []*request.ManualMatch) {
+	checked := make(map[int]bool)
+	for _, match := range matches {
+		for _, team := range slices.Concat(match.RedTeams, match.BlueTeams) {
+			if checked[team] {
+				continue
+			}
+			checked[team] = true
+			if t, err := db.GetTeam(team); err == nil && t == nil {
+				fmt.Printf("Warning: team %d is not in the database\n", team)
+			}
+		}
+	}
+}
+
+func init() {
+	importMatchesCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	importMatchesCmd.Flags().StringVarP(&importMatchesEventFlag, "event", "e", "", "Event code for rows that don't name an event")
+	importMatchesCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Validate the file and show the matches without saving them")
+
+	rootCmd.AddCommand(importMatchesCmd)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// movementLimit is the number of risers, fallers, and newly ranked teams summarized for each event.
+const movementLimit = 5
+
+// movementTeam is a team's change in region rank in a movement summary.
+type movementTeam struct {
+	TeamID int    `json:"team"`
+	Name   string `json:"name"`
+	Before int    `json:"before,omitempty"`
+	After  int    `json:"after"`
+	Places int    `json:"places"`
+}
+
+// movementSummary is the JSON posted to the movement webhook. The summary is in both Text and Content, so it is
+// shown by Slack and Discord webhooks, and the teams are included for other consumers.
+type movementSummary struct {
+	Text      string         `json:"text"`
+	Content   string         `json:"content"`
+	EventCode string         `json:"event_code"`
+	EventName string         `json:"event_name"`
+	Region    string         `json:"region"`
+	Ranked    int            `json:"ranked"`
+	Risers    []movementTeam `json:"risers"`
+	Fallers   []movementTeam `json:"fallers"`
+	New       []movementTeam `json:"new"`
+}
+
+// reportRankMovement logs the teams that moved up and down their region's team rankings the most at each of the
+// synced events that has finished, and posts the summaries to the webhook if one is given.
+func reportRankMovement(events []*database.Event, webhookURL string) {
+	now := time.Now()
+	for _, event := range events {
+		if event == nil || event.Unofficial || event.DateEnd.After(now) {
+			continue
+		}
+		movement, err := query.EventRankMovementQuery(event, movementLimit)
+		if err != nil {
+			slog.Warn("failed to calculate region rank movement", "event", event.EventCode, "error", err)
+			continue
+		}
+		if movement == nil {
+			continue
+		}
+
+		slog.Info("Region rank movement", "event", event.EventCode, "region", movement.Region,
+			"risers", formatRankChanges(movement.Risers), "fallers", formatRankChanges(movement.Fallers),
+			"new", formatRankChanges(movement.New))
+
+		if webhookURL != "" {
+			if err := postRankMovement(webhookURL, movement); err != nil {
+				slog.Warn("failed to post region rank movement", "event", event.EventCode, "error", err)
+			}
+		}
+	}
+}
+
+// formatRankChanges formats the rank changes for a log message, such as "12345 (+4 to 3), 23456 (new at 9)".
+func formatRankChanges(changes []query.RankChange) string {
+	parts := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.Before == 0 {
+			parts = append(parts, fmt.Sprintf("%d (new at %d)", change.TeamID, change.After))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d (%+d to %d)", change.TeamID, change.Places(), change.After))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// postRankMovement posts the event's rank movement summary to the webhook.
+func postRankMovement(webhookURL string, movement *query.EventRankMovement) error {
+	text := movementText(movement)
+	summary := movementSummary{
+		Text:      text,
+		Content:   text,
+		EventCode: movement.Event.EventCode,
+		EventName: movement.Event.Name,
+		Region:    movement.Region,
+		Ranked:    movement.Ranked,
+		Risers:    toMovementTeams(movement.Risers),
+		Fallers:   toMovementTeams(movement.Fallers),
+		New:       toMovementTeams(movement.New),
+	}
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// toMovementTeams converts the rank changes to the teams in a movement summary.
+func toMovementTeams(changes []query.RankChange) []movementTeam {
+	teams := make([]movementTeam, 0, len(changes))
+	for _, change := range changes {
+		team := movementTeam{
+			TeamID: change.TeamID,
+			Name:   change.TeamName,
+			Before: change.Before,
+			After:  change.After,
+		}
+		if change.Before != 0 {
+			team.Places = change.Places()
+		}
+		teams = append(teams, team)
+	}
+	return teams
+}
+
+// movementText formats the movement summary as Markdown for a chat message.
+func movementText(movement *query.EventRankMovement) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s rankings after %s** (%s)\n", movement.Region, movement.Event.Name, movement.Event.EventCode))
+	section := func(title string, changes []query.RankChange) {
+		if len(changes) == 0 {
+			return
+		}
+		sb.WriteString(title + "\n")
+		for _, change := range changes {
+			if change.Before == 0 {
+				sb.WriteString(fmt.Sprintf("- **%d** %s: new at #%d\n", change.TeamID, change.TeamName, change.After))
+			} else {
+				sb.WriteString(fmt.Sprintf("- **%d** %s: %+d, #%d to #%d\n", change.TeamID, change.TeamName, change.Places(), change.Before, change.After))
+			}
+		}
+	}
+	section("Biggest risers", movement.Risers)
+	section("Biggest fallers", movement.Fallers)
+	section("Newly ranked", movement.New)
+	if len(movement.Risers) == 0 && len(movement.Fallers) == 0 && len(movement.New) == 0 {
+		sb.WriteString("No teams changed places.\n")
+	}
+	sb.WriteString(fmt.Sprintf("%d teams ranked in %s\n", movement.Ranked, movement.Region))
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keepFlag   int
+	dryRunFlag bool
+)
+
+// usageCmd reports the data stored for each season.
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Report the data stored for each season",
+	Long: `Report the number of events and matches stored for each season, from the most recent season to the oldest.
+For file-based databases the size of each season's directory is also reported.`,
+	Example: `  # Report storage usage for every season
+  ftcdata usage`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		usage, err := database.GetSeasonUsage()
+		if err != nil {
+			return fmt.Errorf("failed to get season usage: %w", err)
+		}
+		if len(usage) == 0 {
+			fmt.Println("No seasons found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "Season\tEvents\tMatches\tSize\t")
+		var totalBytes int64
+		for _, su := range usage {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t\n", su.Season, su.Events, su.Matches, formatBytes(su.Bytes))
+			totalBytes += su.Bytes
+		}
+		if usage[0].Bytes >= 0 {
+			fmt.Fprintf(w, "Total\t\t\t%s\t\n", formatBytes(totalBytes))
+		}
+		return w.Flush()
+	},
+}
+
+// pruneCmd deletes the data for old seasons.
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete the data for old seasons",
+	Long: `Delete the data for all but the most recent seasons. For file-based databases each old season's directory is
+removed. For SQL databases the events of each old season, and the matches, rankings, awards, and metrics that
+belong to them, are deleted from the season's DATA_SOURCE_NAME_<season> database if it is set, and from the
+DATA_SOURCE_NAME database otherwise; teams and award definitions are shared by all seasons and are kept.`,
+	Example: `  # Keep the two most recent seasons
+  ftcdata prune --keep 2
+
+  # Show which seasons would be deleted without deleting them
+  ftcdata prune --keep 2 --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seasons, err := database.PruneSeasons(keepFlag, dryRunFlag)
+		if err != nil {
+			return fmt.Errorf("failed to prune seasons: %w", err)
+		}
+		switch {
+		case len(seasons) == 0:
+			fmt.Printf("Nothing to prune; %d or fewer seasons are stored\n", keepFlag)
+		case dryRunFlag:
+			fmt.Printf("Would delete seasons: %v\n", seasons)
+		default:
+			fmt.Printf("Deleted seasons: %v\n", seasons)
+		}
+		return nil
+	},
+}
+
+// formatBytes formats a size in bytes for display, or "-" if the size is not known.
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < 0 {
+		return "-"
+	}
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	pruneCmd.Flags().IntVar(&keepFlag, "keep", 2, "Number of most recent seasons to keep")
+	pruneCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show the seasons that would be deleted without deleting them")
+
+	rootCmd.AddCommand(usageCmd, pruneCmd)
+}
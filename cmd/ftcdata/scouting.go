@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/request"
+	"github.com/rbrabson/ftcstanding/sheets"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scoutingSpreadsheetFlag string
+	scoutingSheetFlag       string
+	scoutingColumnsFlag     string
+)
+
+// importScoutingCmd imports the notes and tags on teams kept in a scouting spreadsheet.
+var importScoutingCmd = &cobra.Command{
+	Use:   "import-scouting [file.csv]",
+	Short: "Import scouting notes and tags from a Google Sheet or CSV file",
+	Long: `Import the notes and tags on teams from a scouting spreadsheet, such as one filled in during pit scouting, into
+the season's database. They are shown with the teams in 'ftc pick-list', which can be narrowed to the teams with
+the tags given by --tag, and exported with them by 'ftc export-sheet pick-list'.
+
+The spreadsheet is read from a CSV file if one is given, or from a sheet of a shared Google Sheet otherwise, using
+the service account in GOOGLE_SHEETS_CREDENTIALS as 'ftc export-sheet' does. The spreadsheet is given by
+--spreadsheet or GOOGLE_SHEETS_SPREADSHEET_ID, and only needs to be shared with the service account as a viewer.
+
+The first row is a header naming the columns. By default, the team number is read from the "team" column, the notes
+from the "notes" column, and the tags, separated by commas or semicolons, from the "tags" column. A spreadsheet
+laid out differently is read with a JSON file mapping its columns, given by --columns, the FTC_SCOUTING_COLUMNS
+environment variable, or saved as scouting.json in the user's ftcstanding config directory:
+
+  {
+    "team": "Team #",
+    "notes": ["Drivetrain", "Pit Notes"],
+    "tags": ["Tags"],
+    "flags": {"Can Hang?": "hang"}
+  }
+
+Each notes column is labelled with its header when there are several, and each flag column adds its tag to the
+teams marked yes, true, x, or 1. Columns are matched without regard to case or spacing, and any others are
+ignored. Rows without a team number are skipped, and the rows of a team listed more than once are merged. Every row
+is validated before anything is saved, and the spreadsheet is rejected if any team isn't in the database.
+Importing a team's notes again replaces them.`,
+	Example: `  # Import the "Scouting" sheet of the spreadsheet in GOOGLE_SHEETS_SPREADSHEET_ID
+  ftcdata import-scouting --season 2025
+
+  # Import a named sheet, with its columns mapped by a file
+  ftcdata import-scouting --season 2025 --sheet "Pit Scouting" --columns pit.json
+
+  # Check a CSV file without saving it
+  ftcdata import-scouting scouting.csv --season 2025 --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		columns, err := loadScoutingColumns(scoutingColumnsFlag)
+		if err != nil {
+			return err
+		}
+
+		var notes []*database.TeamNote
+		var source string
+		if len(args) > 0 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if notes, err = request.ReadTeamNotesCSV(f, columns); err != nil {
+				return fmt.Errorf("failed to read %s:\n%w", args[0], err)
+			}
+			source = filepath.Base(args[0])
+		} else {
+			rows, err := readScoutingSheet(scoutingSpreadsheetFlag, scoutingSheetFlag)
+			if err != nil {
+				return err
+			}
+			if notes, err = request.ReadTeamNotes(rows, columns); err != nil {
+				return fmt.Errorf("failed to read sheet %q:\n%w", scoutingSheetFlag, err)
+			}
+			source = "sheet " + scoutingSheetFlag
+		}
+		if len(notes) == 0 {
+			fmt.Println("No teams to import")
+			return nil
+		}
+
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		year, err := strconv.Atoi(season)
+		if err != nil {
+			return fmt.Errorf("invalid season %q", season)
+		}
+
+		if dryRunFlag {
+			if err := request.CheckTeamNotes(notes); err != nil {
+				return err
+			}
+			for _, note := range notes {
+				fmt.Printf("%-6d [%s] %s\n", note.TeamID, strings.Join(note.Tags, ", "), note.Notes)
+			}
+			fmt.Printf("Would import scouting data for %d teams\n", len(notes))
+			return nil
+		}
+
+		if err := request.SaveTeamNotes(notes, year, source); err != nil {
+			return err
+		}
+		fmt.Printf("Imported scouting data for %d teams from %s\n", len(notes), source)
+		return nil
+	},
+}
+
+// loadScoutingColumns loads the mapping of the scouting spreadsheet's columns from the file given by --columns, or
+// the FTC_SCOUTING_COLUMNS environment variable. If neither is given, the scouting.json file in the user's
+// ftcstanding configuration directory is loaded if it exists, and the default columns are used if it doesn't.
+func loadScoutingColumns(file string) (*request.ScoutingColumns, error) {
+	if file == "" {
+		file = os.Getenv("FTC_SCOUTING_COLUMNS")
+	}
+	if file == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, nil
+		}
+		file = filepath.Join(configDir, "ftcstanding", "scouting.json")
+		if _, err := os.Stat(file); err != nil {
+			return nil, nil
+		}
+	}
+	columns, err := request.LoadScoutingColumns(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scouting columns: %w", err)
+	}
+	return columns, nil
+}
+
+// readScoutingSheet reads the rows of a sheet of the spreadsheet, or of the one in GOOGLE_SHEETS_SPREADSHEET_ID if
+// none is given.
+func readScoutingSheet(spreadsheetID, sheet string) ([][]string, error) {
+	config, err := sheets.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if spreadsheetID == "" {
+		spreadsheetID = config.SpreadsheetID
+	}
+	if spreadsheetID == "" {
+		return nil, fmt.Errorf("a CSV file or spreadsheet is required; use --spreadsheet or set GOOGLE_SHEETS_SPREADSHEET_ID")
+	}
+	client, err := sheets.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return client.ReadSheet(spreadsheetID, sheet)
+}
+
+func init() {
+	importScoutingCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	importScoutingCmd.Flags().StringVar(&scoutingSpreadsheetFlag, "spreadsheet", "", "ID of the spreadsheet, from its URL (defaults to GOOGLE_SHEETS_SPREADSHEET_ID environment variable)")
+	importScoutingCmd.Flags().StringVar(&scoutingSheetFlag, "sheet", "Scouting", "Name of the sheet to read")
+	importScoutingCmd.Flags().StringVar(&scoutingColumnsFlag, "columns", "", "JSON file mapping the spreadsheet's columns (defaults to FTC_SCOUTING_COLUMNS environment variable)")
+	importScoutingCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Validate the spreadsheet and show the teams' notes without saving them")
+
+	rootCmd.AddCommand(importScoutingCmd)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/spf13/cobra"
+)
+
+var statusLimitFlag int
+
+// statusCmd reports the recent runs that synced a season's data.
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report when the season's data was last synced",
+	Long: `Report the most recent runs that synced the season's data, from the most recent to the oldest, with what each
+run synced, whether it completed or failed, how many events it synced and how many of them changed, and how many
+data source endpoints were updated or skipped because they hadn't changed. A run that is still going, or that was
+interrupted, is reported as running along with how many of its events it has synced. The errors of the most recent
+run are listed below the runs, followed by the events whose results failed to sync and are waiting to be retried.
+An event that failed to sync too many times in a row is quarantined, and is only synced again by a run that syncs
+the event itself.`,
+	Example: `  # Report the last 10 sync runs
+  ftcdata status --season 2025
+
+  # Report the last 50 sync runs
+  ftcdata status --season 2025 --limit 50`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if statusLimitFlag < 0 {
+			return fmt.Errorf("invalid limit %d, must not be negative", statusLimitFlag)
+		}
+		season, err := openSeason(seasonFlag)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		runs, err := db.GetSyncRuns(database.SyncRunFilter{Season: season, Limit: statusLimitFlag})
+		if err != nil {
+			return fmt.Errorf("failed to load sync runs: %w", err)
+		}
+		if len(runs) == 0 {
+			fmt.Printf("No sync runs recorded for season %s\n", season)
+			return printSyncRetries(season)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Started\tScope\tStatus\tDuration\tEvents\tChanged\tUpdated\tSkipped\tRemoved\tErrors\t")
+		for _, run := range runs {
+			duration := "-"
+			if !run.FinishedAt.IsZero() {
+				duration = run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\t%d\t%d\t%d\t%d\t%d\t\n",
+				run.StartedAt.Local().Format("2006-01-02 15:04:05"), run.Scope, run.Status, duration,
+				run.Events, run.TotalEvents, run.ChangedEvents, run.UpdatedEndpoints, run.SkippedEndpoints,
+				run.Removed, run.ErrorCount)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if last := runs[0]; last.ErrorCount > 0 {
+			fmt.Printf("\nErrors in the most recent run:\n")
+			for _, e := range last.Errors {
+				fmt.Printf("  %s\n", e)
+			}
+			if more := last.ErrorCount - len(last.Errors); more > 0 {
+				fmt.Printf("  ...and %d more\n", more)
+			}
+		}
+		return printSyncRetries(season)
+	},
+}
+
+// printSyncRetries prints the season's events whose results failed to sync and are waiting to be retried.
+func printSyncRetries(season string) error {
+	retries, err := db.GetSyncRetries()
+	if err != nil {
+		return fmt.Errorf("failed to load sync retries: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printed := false
+	for _, retry := range retries {
+		event, err := db.GetEvent(retry.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to load event %s: %w", retry.EventID, err)
+		}
+		if event == nil || strconv.Itoa(event.Year) != season {
+			continue
+		}
+		if !printed {
+			fmt.Printf("\nEvents waiting to be retried:\n")
+			fmt.Fprintln(w, "Event\tStatus\tAttempts\tFirst Failed\tLast Failed\tLast Error\t")
+			printed = true
+		}
+		status := "retrying"
+		if retry.Quarantined() {
+			status = "quarantined"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t\n", event.EventCode, status, retry.Attempts,
+			retry.FirstFailedAt.Local().Format("2006-01-02 15:04:05"), retry.LastFailedAt.Local().Format("2006-01-02 15:04:05"),
+			retry.LastError)
+	}
+	return w.Flush()
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	statusCmd.Flags().IntVarP(&statusLimitFlag, "limit", "l", 10, "Number of most recent runs to report (0 reports every run)")
+
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/internal/version"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configFlag  string
+	seasonFlag  string
+	reportFlag  string
+	sendNowFlag bool
+	previewFlag bool
+	localeFlag  string
+)
+
+// setLogLevelFromEnv sets the log level from the LOG_LEVEL environment variable.
+func setLogLevelFromEnv() slog.Level {
+	levelStr := os.Getenv("LOG_LEVEL")
+
+	var logLevel slog.Level
+	switch strings.ToLower(levelStr) {
+	case "debug":
+		logLevel = slog.LevelDebug
+	case "warn":
+		logLevel = slog.LevelWarn
+	case "error":
+		logLevel = slog.LevelError
+	default:
+		logLevel = slog.LevelInfo
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})))
+	return logLevel
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "ftcreport",
+	Short: "FTC Standing scheduled report emails",
+	Long: `Render FTC Standing reports, such as a region's advancing teams and leaderboard, as HTML and email them on a
+cron schedule. The reports are defined in a JSON configuration file, and the mail server is read from the
+SMTP_HOST, SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD, and SMTP_FROM environment variables.`,
+	Example: `  # Send the reports in reports.json on their schedules
+  ftcreport --config reports.json
+
+  # Send every report once, right away
+  ftcreport --config reports.json --send-now
+
+  # Write one report's HTML to a file instead of sending it
+  ftcreport --config reports.json --report "USNC Weekly Digest" --preview > digest.html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Determine season
+		season := seasonFlag
+		if season == "" {
+			season = os.Getenv("FTC_SEASON")
+			if season == "" {
+				return fmt.Errorf("season not specified. Use --season flag or set FTC_SEASON environment variable")
+			}
+		}
+		year, err := strconv.Atoi(season)
+		if err != nil {
+			return fmt.Errorf("invalid season %q", season)
+		}
+
+		// Use --locale flag if provided, otherwise the user's locale, to format numbers and dates
+		if localeFlag != "" {
+			if err := locale.Set(localeFlag); err != nil {
+				return err
+			}
+		} else {
+			locale.Set(locale.FromEnv())
+		}
+
+		config, err := report.LoadConfig(configFlag)
+		if err != nil {
+			return fmt.Errorf("failed to load report configuration: %w", err)
+		}
+		reports := config.Reports
+		if reportFlag != "" {
+			r := config.Find(reportFlag)
+			if r == nil {
+				return fmt.Errorf("report %q not found in %s", reportFlag, configFlag)
+			}
+			reports = []*report.Report{r}
+		}
+
+		db, err := database.Init(season)
+		if err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+		defer db.Close()
+
+		query.Init(db)
+
+		if previewFlag {
+			for _, r := range reports {
+				html, err := report.Render(r, year, time.Now())
+				if err != nil {
+					return fmt.Errorf("failed to render report %q: %w", r.Name, err)
+				}
+				fmt.Println(html)
+			}
+			return nil
+		}
+
+		mailer, err := report.SMTPConfigFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to configure mail server: %w", err)
+		}
+
+		if sendNowFlag {
+			for _, r := range reports {
+				if err := report.Send(r, year, mailer); err != nil {
+					return fmt.Errorf("failed to send report %q: %w", r.Name, err)
+				}
+				slog.Info("Sent report", "report", r.Name, "recipients", len(r.To))
+			}
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		report.Run(ctx, &report.Config{Reports: reports}, year, mailer)
+		slog.Info("Report scheduler exited")
+		return nil
+	},
+}
+
+func init() {
+	defaultConfig := os.Getenv("REPORT_CONFIG")
+	if defaultConfig == "" {
+		defaultConfig = "reports.json"
+	}
+
+	rootCmd.Flags().StringVarP(&configFlag, "config", "c", defaultConfig, "Report configuration file (defaults to REPORT_CONFIG environment variable, or reports.json)")
+	rootCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Season year (defaults to FTC_SEASON environment variable)")
+	rootCmd.Flags().StringVarP(&reportFlag, "report", "r", "", "Name of a single report to send or preview")
+	rootCmd.Flags().BoolVar(&sendNowFlag, "send-now", false, "Send the reports once, right away, instead of on their schedules")
+	rootCmd.Flags().BoolVar(&previewFlag, "preview", false, "Write the reports' HTML to standard output instead of sending them")
+	rootCmd.Flags().StringVar(&localeFlag, "locale", "", "Locale numbers and dates are formatted for, such as en-GB, fr, or de_DE (defaults to the LANG environment variable)")
+
+	rootCmd.AddCommand(version.NewCommand("ftcreport"))
+}
+
+func main() {
+	godotenv.Load()
+	setLogLevelFromEnv()
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
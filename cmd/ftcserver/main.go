@@ -13,6 +13,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/version"
 	"github.com/rbrabson/ftcstanding/query"
 	"github.com/rbrabson/ftcstanding/server"
 	"github.com/spf13/cobra"
@@ -21,6 +22,13 @@ import (
 var (
 	port       int
 	seasonFlag string
+	debugAddr  string
+	apiKeys    string
+
+	privateTeamFields string
+	privacyMode       string
+
+	watchInterval time.Duration
 )
 
 // setLogLevelFromEnv sets the log level from the LOG_LEVEL environment variable.
@@ -43,11 +51,28 @@ func setLogLevelFromEnv() slog.Level {
 	return logLevel
 }
 
+// envOrDefault returns the value of the environment variable, or the default if it isn't set.
+func envOrDefault(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// envDuration returns the duration in the environment variable, or 0 if it isn't set or isn't a valid duration.
+func envDuration(key string) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
 var rootCmd = &cobra.Command{
 	Use:     "ftcserver",
 	Short:   "FTC Standing HTTP API server",
 	Long:    "HTTP REST API server for FTC (FIRST Tech Challenge) standing data including teams, events, matches, awards, and rankings.",
-	Example: "  # Start the server on default port 8080\n  ftcserver\n\n  # Start the server on a custom port\n  ftcserver --port 3000\n\n  # Specify a season (optional, can still be provided in API paths)\n  ftcserver --season 2024",
+	Example: "  # Start the server on default port 8080\n  ftcserver\n\n  # Start the server on a custom port\n  ftcserver --port 3000\n\n  # Specify a season (optional, can still be provided in API paths)\n  ftcserver --season 2024\n\n  # Serve pprof profiles and runtime metrics on localhost only\n  ftcserver --debug-addr localhost:6060\n\n  # Accept the API keys in keys.json\n  ftcserver --api-keys keys.json\n\n  # Leave teams' full names and websites out of responses to requests without an API key\n  ftcserver --private-team-fields full_name,website --privacy-mode omit\n\n  # Pick up data files copied into FILEDB_DATA_DIR while the server is running\n  ftcserver --watch-interval 5s",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Determine season if provided
 		season := seasonFlag
@@ -64,7 +89,32 @@ var rootCmd = &cobra.Command{
 
 		query.Init(db)
 
+		watchCtx, stopWatching := context.WithCancel(context.Background())
+		defer stopWatching()
+		if watchInterval > 0 {
+			if err := database.Watch(watchCtx, db, watchInterval); err != nil {
+				return err
+			}
+		}
+
 		httpServer := server.NewServer(db)
+		if apiKeys != "" {
+			keys, err := server.LoadAPIKeys(apiKeys)
+			if err != nil {
+				return err
+			}
+			if err := httpServer.SetAPIKeys(keys); err != nil {
+				return fmt.Errorf("invalid API keys in %s: %w", apiKeys, err)
+			}
+			slog.Info("Loaded API keys", "file", apiKeys, "count", len(keys))
+		}
+		if privateTeamFields != "" {
+			fields := strings.Split(privateTeamFields, ",")
+			if err := httpServer.SetPrivateTeamFields(fields, server.PrivacyMode(strings.ToLower(privacyMode))); err != nil {
+				return err
+			}
+			slog.Info("Hiding private team fields from requests without an API key", "fields", privateTeamFields, "mode", privacyMode)
+		}
 
 		addr := fmt.Sprintf(":%d", port)
 		srv := &http.Server{
@@ -76,7 +126,7 @@ var rootCmd = &cobra.Command{
 		}
 
 		go func() {
-			slog.Info("Starting FTC API server", "address", addr)
+			slog.Info("Starting FTC API server", "address", addr, "version", version.Get().Version)
 			slog.Info("API documentation available at http://localhost:" + fmt.Sprint(port) + "/v1/{season}/{resource}")
 			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				slog.Error("Server failed to start", "error", err)
@@ -84,6 +134,23 @@ var rootCmd = &cobra.Command{
 			}
 		}()
 
+		// Profiles and runtime metrics are served on their own address so they aren't exposed on the API's port
+		var debugSrv *http.Server
+		if debugAddr != "" {
+			debugSrv = &http.Server{
+				Addr:        debugAddr,
+				Handler:     server.NewDebugHandler(),
+				ReadTimeout: 15 * time.Second,
+				IdleTimeout: 60 * time.Second,
+			}
+			go func() {
+				slog.Info("Serving pprof profiles and runtime metrics", "address", debugAddr)
+				if err := debugSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					slog.Error("Debug server failed to start", "error", err)
+				}
+			}()
+		}
+
 		quit := make(chan os.Signal, 1)
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 		<-quit
@@ -91,6 +158,9 @@ var rootCmd = &cobra.Command{
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
+		if debugSrv != nil {
+			debugSrv.Shutdown(ctx)
+		}
 		if err := srv.Shutdown(ctx); err != nil {
 			return fmt.Errorf("server forced to shutdown: %w", err)
 		}
@@ -103,6 +173,13 @@ var rootCmd = &cobra.Command{
 func init() {
 	rootCmd.Flags().IntVarP(&port, "port", "p", 8080, "Port to listen on")
 	rootCmd.Flags().StringVarP(&seasonFlag, "season", "s", "", "Default season year (defaults to FTC_SEASON environment variable)")
+	rootCmd.Flags().StringVar(&apiKeys, "api-keys", os.Getenv("API_KEYS_FILE"), "JSON file of the API keys that grant the scout and admin roles (defaults to API_KEYS_FILE environment variable)")
+	rootCmd.Flags().StringVar(&privateTeamFields, "private-team-fields", os.Getenv("PRIVATE_TEAM_FIELDS"), "Comma-separated team fields hidden from requests without an API key: full_name, city, state_prov, country, country_code, website, robot_name (defaults to PRIVATE_TEAM_FIELDS environment variable)")
+	rootCmd.Flags().StringVar(&privacyMode, "privacy-mode", envOrDefault("PRIVACY_MODE", string(server.PrivacyRedact)), "How private team fields are hidden: redact to give them as empty strings, or omit to leave them out (defaults to PRIVACY_MODE environment variable)")
+	rootCmd.Flags().DurationVar(&watchInterval, "watch-interval", envDuration("FILEDB_WATCH_INTERVAL"), "How often a file-based database is checked for data files changed outside the server, such as 5s (disabled if 0; defaults to FILEDB_WATCH_INTERVAL environment variable)")
+	rootCmd.Flags().StringVar(&debugAddr, "debug-addr", "", "Address to serve pprof profiles and runtime metrics on, such as localhost:6060 (disabled if empty)")
+
+	rootCmd.AddCommand(version.NewCommand("ftcserver"))
 }
 
 func main() {
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EventAttendance compares the teams registered for an event with the teams that actually played in its matches.
+type EventAttendance struct {
+	EventID    string `json:"event_id"`
+	Registered []int  `json:"registered"` // Teams registered for the event
+	Played     []int  `json:"played"`     // Teams that were on the field for at least one match
+	NoShows    []int  `json:"no_shows"`   // Teams registered for the event that never played a match
+	WalkOns    []int  `json:"walk_ons"`   // Teams that played a match without being registered for the event
+}
+
+// GetEventAttendance compares the registered teams for an event with the teams that were on the field for the
+// given match teams. Teams that were scheduled but never took the field are not counted as having played. The
+// registered teams come from the event registration sync, so the report is empty for an event whose registrations
+// haven't been synced.
+func GetEventAttendance(eventID string, registered []int, matchTeams []*MatchTeam) *EventAttendance {
+	registeredMap := make(map[int]bool, len(registered))
+	for _, teamID := range registered {
+		registeredMap[teamID] = true
+	}
+	playedMap := make(map[int]bool)
+	for _, mt := range matchTeams {
+		if mt.OnField {
+			playedMap[mt.TeamID] = true
+		}
+	}
+
+	attendance := &EventAttendance{
+		EventID:    eventID,
+		Registered: make([]int, 0, len(registeredMap)),
+		Played:     make([]int, 0, len(playedMap)),
+		NoShows:    []int{},
+		WalkOns:    []int{},
+	}
+	for teamID := range registeredMap {
+		attendance.Registered = append(attendance.Registered, teamID)
+		if !playedMap[teamID] {
+			attendance.NoShows = append(attendance.NoShows, teamID)
+		}
+	}
+	for teamID := range playedMap {
+		attendance.Played = append(attendance.Played, teamID)
+		if !registeredMap[teamID] {
+			attendance.WalkOns = append(attendance.WalkOns, teamID)
+		}
+	}
+	slices.Sort(attendance.Registered)
+	slices.Sort(attendance.Played)
+	slices.Sort(attendance.NoShows)
+	slices.Sort(attendance.WalkOns)
+
+	return attendance
+}
+
+// HasAnomalies returns true if any registered team did not play or any team played without being registered.
+func (ea *EventAttendance) HasAnomalies() bool {
+	return len(ea.NoShows) > 0 || len(ea.WalkOns) > 0
+}
+
+// String returns a string representation of the EventAttendance.
+func (ea *EventAttendance) String() string {
+	return fmt.Sprintf("EventAttendance{EventID: %s, Registered: %d, Played: %d, NoShows: %v, WalkOns: %v}",
+		ea.EventID, len(ea.Registered), len(ea.Played), ea.NoShows, ea.WalkOns)
+}
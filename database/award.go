@@ -1,13 +1,17 @@
 package database
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Award is an award that is given in a given season
 type Award struct {
-	AwardID     int    `json:"award_id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ForPerson   bool   `json:"for_person"`
+	AwardID     int       `json:"award_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ForPerson   bool      `json:"for_person"`
+	UpdatedAt   time.Time `json:"updated_at"` // Time the record was last created or changed
 }
 
 // String returns a string representation of the Award.
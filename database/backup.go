@@ -0,0 +1,320 @@
+package database
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BackupVersion is the version of the backup format written by WriteBackup.
+const BackupVersion = 1
+
+// Backup is a logical copy of the records in a database, read and restored through the DB interface so a backup of
+// one backend can be restored to any other. Event summaries aren't included, since they are recalculated from the
+// restored records, and only the latest snapshot of each team ranking is included, since earlier snapshots can't be
+// listed through the DB interface.
+type Backup struct {
+	Version              int                    `json:"version"`
+	Season               string                 `json:"season"`
+	CreatedAt            time.Time              `json:"created_at"`
+	Awards               []*Award               `json:"awards"`
+	Teams                []*Team                `json:"teams"`
+	Events               []*Event               `json:"events"`
+	EventAwards          []*EventAward          `json:"event_awards"`
+	EventRankings        []*EventRanking        `json:"event_rankings"`
+	EventAdvancements    []*EventAdvancement    `json:"event_advancements"`
+	EventTeams           []*EventTeam           `json:"event_teams"`
+	Matches              []*Match               `json:"matches"`
+	MatchAllianceScores  []*MatchAllianceScore  `json:"match_alliance_scores"`
+	MatchTeams           []*MatchTeam           `json:"match_teams"`
+	TeamRankings         []*TeamRanking         `json:"team_rankings"`
+	TeamRankingSnapshots []*TeamRankingSnapshot `json:"team_ranking_snapshots"`
+	TeamHistory          []*TeamHistory         `json:"team_history"`
+	AdvancementCutoffs   []*AdvancementCutoff   `json:"advancement_cutoffs"`
+	EventSourceKeys      []*EventSourceKey      `json:"event_source_keys"`
+	SyncCheckpoints      []*SyncCheckpoint      `json:"sync_checkpoints"`
+	EventSyncs           []*EventSync           `json:"event_syncs"`
+	EndpointHashes       []*EndpointHash        `json:"endpoint_hashes"`
+	RegionAliases        []*RegionAlias         `json:"region_aliases"`
+	TeamNotes            []*TeamNote            `json:"team_notes,omitempty"`
+}
+
+// NewBackup reads every record of the season from the database into a backup. The event source keys of the given
+// data sources are included, as the keys can only be listed by source.
+func NewBackup(db DB, season string, sources ...string) (*Backup, error) {
+	b := &Backup{Version: BackupVersion, Season: season, CreatedAt: time.Now().UTC()}
+
+	var err error
+	if b.Awards, err = db.GetAllAwards(); err != nil {
+		return nil, fmt.Errorf("failed to read awards: %w", err)
+	}
+	if b.Teams, err = db.GetAllTeams(); err != nil {
+		return nil, fmt.Errorf("failed to read teams: %w", err)
+	}
+	if b.TeamHistory, err = db.GetTeamHistory(); err != nil {
+		return nil, fmt.Errorf("failed to read team history: %w", err)
+	}
+	if b.Events, err = db.GetAllEvents(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	for _, event := range b.Events {
+		awards, err := db.GetEventAwards(event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read awards of event %s: %w", event.EventCode, err)
+		}
+		b.EventAwards = append(b.EventAwards, awards...)
+
+		rankings, err := db.GetEventRankings(event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rankings of event %s: %w", event.EventCode, err)
+		}
+		b.EventRankings = append(b.EventRankings, rankings...)
+
+		advancements, err := db.GetEventAdvancements(event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read advancements of event %s: %w", event.EventCode, err)
+		}
+		b.EventAdvancements = append(b.EventAdvancements, advancements...)
+
+		teams, err := db.GetEventTeams(event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read teams of event %s: %w", event.EventCode, err)
+		}
+		b.EventTeams = append(b.EventTeams, teams...)
+
+		matches, err := db.GetMatchesByEvent(event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read matches of event %s: %w", event.EventCode, err)
+		}
+		b.Matches = append(b.Matches, matches...)
+		for _, match := range matches {
+			for _, alliance := range []string{AllianceRed, AllianceBlue} {
+				score, err := db.GetMatchAllianceScore(match.MatchID, alliance)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read %s score of match %s: %w", alliance, match.MatchID, err)
+				}
+				if score != nil {
+					b.MatchAllianceScores = append(b.MatchAllianceScores, score)
+				}
+			}
+			matchTeams, err := db.GetMatchTeams(match.MatchID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read teams of match %s: %w", match.MatchID, err)
+			}
+			b.MatchTeams = append(b.MatchTeams, matchTeams...)
+		}
+
+		hashes, err := db.GetEndpointHashes(event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read endpoint hashes of event %s: %w", event.EventCode, err)
+		}
+		b.EndpointHashes = append(b.EndpointHashes, hashes...)
+	}
+
+	if b.TeamRankings, err = db.GetTeamRankings(); err != nil {
+		return nil, fmt.Errorf("failed to read team rankings: %w", err)
+	}
+	if b.TeamRankingSnapshots, err = db.GetTeamRankingSnapshots(); err != nil {
+		return nil, fmt.Errorf("failed to read team ranking snapshots: %w", err)
+	}
+	if b.AdvancementCutoffs, err = db.GetAdvancementCutoffs(); err != nil {
+		return nil, fmt.Errorf("failed to read advancement cutoffs: %w", err)
+	}
+	for _, source := range sources {
+		keys, err := db.GetEventSourceKeys(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s event keys: %w", source, err)
+		}
+		b.EventSourceKeys = append(b.EventSourceKeys, keys...)
+	}
+	if b.SyncCheckpoints, err = db.GetSyncCheckpoints(season); err != nil {
+		return nil, fmt.Errorf("failed to read sync checkpoints: %w", err)
+	}
+	if b.EventSyncs, err = db.GetEventSyncs(); err != nil {
+		return nil, fmt.Errorf("failed to read event syncs: %w", err)
+	}
+	if b.RegionAliases, err = db.GetRegionAliases(); err != nil {
+		return nil, fmt.Errorf("failed to read region aliases: %w", err)
+	}
+	if year, err := strconv.Atoi(season); err == nil {
+		if b.TeamNotes, err = db.GetTeamNotes(TeamNoteFilter{Year: year}); err != nil {
+			return nil, fmt.Errorf("failed to read team notes: %w", err)
+		}
+	}
+
+	return b, nil
+}
+
+// Restore saves every record in the backup to the database, then recalculates the summaries of the restored events.
+// Records already in the database are replaced by the backed up records with the same keys, and other records are
+// kept.
+func (b *Backup) Restore(db DB) error {
+	for _, award := range b.Awards {
+		if err := db.SaveAward(award); err != nil {
+			return fmt.Errorf("failed to restore award %d: %w", award.AwardID, err)
+		}
+	}
+	for _, team := range b.Teams {
+		if err := db.SaveTeam(team); err != nil {
+			return fmt.Errorf("failed to restore team %d: %w", team.TeamID, err)
+		}
+	}
+	for _, history := range b.TeamHistory {
+		if err := db.SaveTeamHistory(history); err != nil {
+			return fmt.Errorf("failed to restore history of team %d: %w", history.TeamID, err)
+		}
+	}
+	for _, event := range b.Events {
+		if err := db.SaveEvent(event); err != nil {
+			return fmt.Errorf("failed to restore event %s: %w", event.EventCode, err)
+		}
+	}
+	for _, ea := range b.EventAwards {
+		if err := db.SaveEventAward(ea); err != nil {
+			return fmt.Errorf("failed to restore award of event %s: %w", ea.EventID, err)
+		}
+	}
+	for _, er := range b.EventRankings {
+		if err := db.SaveEventRanking(er); err != nil {
+			return fmt.Errorf("failed to restore ranking of event %s: %w", er.EventID, err)
+		}
+	}
+	for _, ea := range b.EventAdvancements {
+		if err := db.SaveEventAdvancement(ea); err != nil {
+			return fmt.Errorf("failed to restore advancement of event %s: %w", ea.EventID, err)
+		}
+	}
+	for _, et := range b.EventTeams {
+		if err := db.SaveEventTeam(et); err != nil {
+			return fmt.Errorf("failed to restore team of event %s: %w", et.EventID, err)
+		}
+	}
+	locations := make(map[string]*time.Location, len(b.Events))
+	for _, event := range b.Events {
+		locations[event.EventID] = event.Location()
+	}
+	for _, match := range b.Matches {
+		// Backups written before start times had a time zone hold them in the event's local time
+		if loc, ok := locations[match.EventID]; ok {
+			match.localizeStartTime(loc)
+		}
+		if err := db.SaveMatch(match); err != nil {
+			return fmt.Errorf("failed to restore match %s: %w", match.MatchID, err)
+		}
+	}
+	for _, score := range b.MatchAllianceScores {
+		if err := db.SaveMatchAllianceScore(score); err != nil {
+			return fmt.Errorf("failed to restore %s score of match %s: %w", score.Alliance, score.MatchID, err)
+		}
+	}
+	for _, mt := range b.MatchTeams {
+		if err := db.SaveMatchTeam(mt); err != nil {
+			return fmt.Errorf("failed to restore team of match %s: %w", mt.MatchID, err)
+		}
+	}
+	for _, ranking := range b.TeamRankings {
+		if err := db.SaveTeamRanking(ranking); err != nil {
+			return fmt.Errorf("failed to restore team ranking of event %s: %w", ranking.EventID, err)
+		}
+	}
+	for _, snapshot := range b.TeamRankingSnapshots {
+		if err := db.SaveTeamRankingSnapshot(snapshot); err != nil {
+			return fmt.Errorf("failed to restore team ranking snapshot of event %s: %w", snapshot.EventID, err)
+		}
+	}
+	for _, cutoff := range b.AdvancementCutoffs {
+		if err := db.SaveAdvancementCutoff(cutoff); err != nil {
+			return fmt.Errorf("failed to restore advancement cutoff of event %s: %w", cutoff.EventID, err)
+		}
+	}
+	for _, key := range b.EventSourceKeys {
+		if err := db.SaveEventSourceKey(key); err != nil {
+			return fmt.Errorf("failed to restore %s event key %s: %w", key.Source, key.SourceKey, err)
+		}
+	}
+	for _, checkpoint := range b.SyncCheckpoints {
+		if err := db.SaveSyncCheckpoint(checkpoint); err != nil {
+			return fmt.Errorf("failed to restore sync checkpoint of event %s: %w", checkpoint.EventID, err)
+		}
+	}
+	for _, sync := range b.EventSyncs {
+		if err := db.SaveEventSync(sync); err != nil {
+			return fmt.Errorf("failed to restore sync of event %s: %w", sync.EventID, err)
+		}
+	}
+	for _, hash := range b.EndpointHashes {
+		if err := db.SaveEndpointHash(hash); err != nil {
+			return fmt.Errorf("failed to restore %s endpoint hash of event %s: %w", hash.Endpoint, hash.EventID, err)
+		}
+	}
+	for _, alias := range b.RegionAliases {
+		if err := db.SaveRegionAlias(alias); err != nil {
+			return fmt.Errorf("failed to restore region alias %q: %w", alias.Alias, err)
+		}
+	}
+	for _, note := range b.TeamNotes {
+		if err := db.SaveTeamNote(note); err != nil {
+			return fmt.Errorf("failed to restore notes on team %d: %w", note.TeamID, err)
+		}
+	}
+
+	for _, event := range b.Events {
+		if err := db.RefreshEventSummary(event.EventID); err != nil {
+			return fmt.Errorf("failed to refresh summary of event %s: %w", event.EventCode, err)
+		}
+	}
+	return nil
+}
+
+// Count returns the total number of records in the backup.
+func (b *Backup) Count() int {
+	return len(b.Awards) + len(b.Teams) + len(b.Events) + len(b.EventAwards) + len(b.EventRankings) +
+		len(b.EventAdvancements) + len(b.EventTeams) + len(b.Matches) + len(b.MatchAllianceScores) +
+		len(b.MatchTeams) + len(b.TeamRankings) + len(b.TeamRankingSnapshots) + len(b.AdvancementCutoffs) +
+		len(b.EventSourceKeys) + len(b.SyncCheckpoints) + len(b.EventSyncs) + len(b.EndpointHashes) +
+		len(b.RegionAliases) + len(b.TeamHistory) + len(b.TeamNotes)
+}
+
+// String returns a string representation of the Backup.
+func (b *Backup) String() string {
+	return fmt.Sprintf("Backup{Season: %s, CreatedAt: %s, Events: %d, Records: %d}",
+		b.Season, b.CreatedAt.Format(time.RFC3339), len(b.Events), b.Count())
+}
+
+// WriteBackup writes the backup as JSON, compressed with gzip if the file name ends in ".gz".
+func WriteBackup(w io.Writer, name string, b *Backup) error {
+	if strings.HasSuffix(name, ".gz") {
+		zw := gzip.NewWriter(w)
+		if err := json.NewEncoder(zw).Encode(b); err != nil {
+			return err
+		}
+		return zw.Close()
+	}
+	return json.NewEncoder(w).Encode(b)
+}
+
+// ReadBackup reads a backup written by WriteBackup, which is decompressed if the file name ends in ".gz".
+func ReadBackup(r io.Reader, name string) (*Backup, error) {
+	if strings.HasSuffix(name, ".gz") {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	var b Backup
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, err
+	}
+	if b.Version < 1 || b.Version > BackupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", b.Version)
+	}
+	return &b, nil
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// ChangeSet contains all records that were created or changed after a point in time.
+type ChangeSet struct {
+	Since               time.Time             `json:"since"`
+	Awards              []*Award              `json:"awards"`
+	Teams               []*Team               `json:"teams"`
+	TeamRankings        []*TeamRanking        `json:"team_rankings"`
+	Events              []*Event              `json:"events"`
+	EventAwards         []*EventAward         `json:"event_awards"`
+	EventRankings       []*EventRanking       `json:"event_rankings"`
+	EventAdvancements   []*EventAdvancement   `json:"event_advancements"`
+	EventTeams          []*EventTeam          `json:"event_teams"`
+	Matches             []*Match              `json:"matches"`
+	MatchAllianceScores []*MatchAllianceScore `json:"match_alliance_scores"`
+	MatchTeams          []*MatchTeam          `json:"match_teams"`
+}
+
+// LastUpdatedFilter limits the records considered when finding the time data was last created or changed.
+type LastUpdatedFilter struct {
+	EventIDs []string // Only the records of these events, along with the teams and award definitions
+}
+
+// Count returns the total number of changed records in the ChangeSet.
+func (cs *ChangeSet) Count() int {
+	return len(cs.Awards) + len(cs.Teams) + len(cs.TeamRankings) + len(cs.Events) + len(cs.EventAwards) +
+		len(cs.EventRankings) + len(cs.EventAdvancements) + len(cs.EventTeams) + len(cs.Matches) +
+		len(cs.MatchAllianceScores) + len(cs.MatchTeams)
+}
+
+// String returns a string representation of the ChangeSet.
+func (cs *ChangeSet) String() string {
+	return fmt.Sprintf("ChangeSet{Since: %s, Changes: %d}", cs.Since.Format(time.RFC3339), cs.Count())
+}
@@ -0,0 +1,27 @@
+package database
+
+import "strings"
+
+// NormalizeCode returns an event or region code in the form it is saved in the database, with any surrounding
+// whitespace removed and upper-cased, so a code typed as "usncraq" finds the event saved as "USNCRAQ".
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// NormalizeCodes returns a copy of the event or region codes, with each code normalized by NormalizeCode.
+func NormalizeCodes(codes []string) []string {
+	if codes == nil {
+		return nil
+	}
+	normalized := make([]string, len(codes))
+	for i, code := range codes {
+		normalized[i] = NormalizeCode(code)
+	}
+	return normalized
+}
+
+// NormalizeAlliance returns an alliance name in the form it is saved in the database, with any surrounding
+// whitespace removed and lower-cased, so "Red" and "red" both refer to AllianceRed.
+func NormalizeAlliance(alliance string) string {
+	return strings.ToLower(strings.TrimSpace(alliance))
+}
@@ -1,15 +1,50 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
 )
 
 // DB defines the interface for database operations.
+//
+// Every implementation returns lists in the same order, so output doesn't change with the backend:
+//   - Awards and teams are ordered by ID.
+//   - Events are ordered by start date, event code, then event ID.
+//   - Event awards are ordered by event ID, award ID, series, then team ID.
+//   - Event rankings are ordered by event ID, rank, then team ID.
+//   - Event advancements, event teams, team rankings, and snapshots are ordered by event ID, then team ID.
+//   - Matches are ordered by event ID, qualification matches before playoff matches, then match number.
+//   - Match teams are ordered by match ID, alliance, then team ID.
+//   - Event summaries, advancement cutoffs, event syncs, and sync retries are ordered by event ID, and event source
+//     keys by source key.
+//   - Event IDs, team IDs, region codes, and event codes are sorted in ascending order.
+//   - Sync checkpoints are ordered by completion time.
+//   - Endpoint hashes are ordered by endpoint.
+//   - Sync runs are ordered by start time, most recent first.
+//   - Team history is ordered by team ID, then by when it was replaced, most recent first.
+//   - Region aliases are ordered by alias, without regard to case.
+//   - Team notes are ordered by year, then team ID.
+//
+// Deleting a record that doesn't exist is not an error. Deleting a match also deletes its alliance scores and teams.
+//
+// MoveEvent moves every record of an event to a new event ID, such as when an event's start date moves to another
+// year, and deletes the event saved under the old ID. The event must already be saved under the new ID. Match IDs
+// embed the event ID, so matches are given new IDs as well. Records already saved for the new event ID are kept in
+// place of the moved records they would replace. The event's endpoint hashes are deleted rather than moved, so the
+// event's results are saved in full when it is next synced.
+//
+// Region aliases are looked up, replaced, and deleted by alias without regard to case or spacing, and are saved
+// with their alias normalized by NormalizeAlias and their region code by NormalizeCode.
 type DB interface {
 	Close()
 
@@ -20,44 +55,103 @@ type DB interface {
 	GetEvent(eventID string) (*Event, error)
 	GetAllEvents(filters ...EventFilter) ([]*Event, error)
 	SaveEvent(event *Event) error
+	MoveEvent(fromEventID, toEventID string) error
 	GetEventAwards(eventID string) ([]*EventAward, error)
 	SaveEventAward(ea *EventAward) error
+	DeleteEventAward(ea *EventAward) error
 	GetTeamAwardsByEvent(eventID string, teamID int) ([]*EventAward, error)
 	GetAllTeamAwards(teamID int) ([]*EventAward, error)
 	GetEventRankings(eventID string) ([]*EventRanking, error)
 	SaveEventRanking(er *EventRanking) error
+	DeleteEventRanking(eventID string, teamID int) error
 	GetEventAdvancements(eventID string) ([]*EventAdvancement, error)
 	SaveEventAdvancement(ea *EventAdvancement) error
+	DeleteEventAdvancement(eventID string, teamID int) error
 	GetEventTeams(eventID string) ([]*EventTeam, error)
 	SaveEventTeam(et *EventTeam) error
+	DeleteEventTeam(eventID string, teamID int) error
 	GetEventsByTeam(teamID int) ([]string, error)
 	GetRegionCodes() ([]string, error)
 	GetEventCodesByRegion(regionCode string) ([]string, error)
 	GetAdvancementsByRegion(regionCode string) ([]*EventAdvancement, error)
 	GetAllAdvancements(filters ...AdvancementFilter) ([]*EventAdvancement, error)
+	GetEventSummaries(filters ...EventSummaryFilter) ([]*EventSummary, error)
+	RefreshEventSummary(eventID string) error
+	GetAdvancementCutoffs(filters ...AdvancementCutoffFilter) ([]*AdvancementCutoff, error)
+	SaveAdvancementCutoff(cutoff *AdvancementCutoff) error
+	GetEventSourceKeys(source string) ([]*EventSourceKey, error)
+	SaveEventSourceKey(key *EventSourceKey) error
 
 	GetMatch(matchID string) (*Match, error)
 	GetAllMatches(filters ...MatchFilter) ([]*Match, error)
 	GetMatchesByEvent(eventID string) ([]*Match, error)
 	SaveMatch(match *Match) error
+	DeleteMatch(matchID string) error
 	GetMatchAllianceScore(matchID, alliance string) (*MatchAllianceScore, error)
 	SaveMatchAllianceScore(score *MatchAllianceScore) error
 	GetMatchTeams(matchID string) ([]*MatchTeam, error)
 	SaveMatchTeam(team *MatchTeam) error
+	DeleteMatchTeam(matchID string, teamID int) error
 	GetTeamsByEvent(eventID string) ([]int, error)
 
 	GetTeam(teamID int) (*Team, error)
 	GetAllTeams(filters ...TeamFilter) ([]*Team, error)
 	SaveTeam(team *Team) error
 	GetTeamsByRegion(region string) ([]*Team, error)
+	GetTeamHistory(filters ...TeamHistoryFilter) ([]*TeamHistory, error)
+	SaveTeamHistory(history *TeamHistory) error
 	GetTeamRankings(filters ...TeamRankingFilter) ([]*TeamRanking, error)
 	SaveTeamRanking(ranking *TeamRanking) error
+	DeleteTeamRanking(eventID string, teamID int) error
+	GetTeamRankingSnapshots(filters ...TeamRankingSnapshotFilter) ([]*TeamRankingSnapshot, error)
+	SaveTeamRankingSnapshot(snapshot *TeamRankingSnapshot) error
+
+	GetChanges(since time.Time) (*ChangeSet, error)
+	GetLastUpdated(filters ...LastUpdatedFilter) (time.Time, error)
+
+	GetSyncCheckpoints(season string) ([]*SyncCheckpoint, error)
+	SaveSyncCheckpoint(checkpoint *SyncCheckpoint) error
+	DeleteSyncCheckpoints(season string) error
+	GetEventSyncs(filters ...EventSyncFilter) ([]*EventSync, error)
+	SaveEventSync(sync *EventSync) error
+	GetEndpointHashes(eventID string) ([]*EndpointHash, error)
+	SaveEndpointHash(hash *EndpointHash) error
+	GetSyncRuns(filters ...SyncRunFilter) ([]*SyncRun, error)
+	SaveSyncRun(run *SyncRun) error
+	GetSyncRetries() ([]*SyncRetry, error)
+	SaveSyncRetry(retry *SyncRetry) error
+	DeleteSyncRetry(eventID string) error
+
+	GetRegionAliases() ([]*RegionAlias, error)
+	SaveRegionAlias(alias *RegionAlias) error
+	DeleteRegionAlias(alias string) error
+
+	GetTeamNotes(filters ...TeamNoteFilter) ([]*TeamNote, error)
+	SaveTeamNote(note *TeamNote) error
 }
 
 // InitDB initializes the database connection.
-// season is an optional parameter. If provided, it will be used for file-based databases.
-// If not provided, the FTC_SEASON environment variable will be used.
+// season is an optional parameter. If provided, it selects the data directory for file-based databases and
+// the DATA_SOURCE_NAME_<season> connection string, if set, for SQL databases. If not provided, the FTC_SEASON
+// environment variable will be used for file-based databases.
+//
+// If a read replica is configured for a SQL database, with the READ_DATA_SOURCE_NAME_<season> or
+// READ_DATA_SOURCE_NAME connection string, reads are made on the replica and writes on the primary. Reads move to
+// the primary while the replica can't be connected to.
 func Init(season ...string) (DB, error) {
+	return initDB(true, season...)
+}
+
+// InitPrimary initializes the database like Init, but makes every read on the primary SQL database even if a read
+// replica is configured. Commands that read back the data they write, such as ftcdata while it syncs, use it so
+// they don't read data the replica hasn't received yet.
+func InitPrimary(season ...string) (DB, error) {
+	return initDB(false, season...)
+}
+
+// initDB initializes the database selected by the DB_TYPE environment variable, reading from the SQL database's
+// read replica if one is configured and useReplica is true.
+func initDB(useReplica bool, season ...string) (DB, error) {
 	godotenv.Load()
 	dbType := os.Getenv("DB_TYPE")
 	if dbType == "" {
@@ -66,10 +160,46 @@ func Init(season ...string) (DB, error) {
 	switch dbType {
 	case "sql":
 		slog.Info("Initializing SQL database")
-		return initSQLDB()
+		return initSQLDB(useReplica, season...)
 	case "file":
 		slog.Info("Initializing file database")
 		return initFileDB(season...)
 	}
 	return nil, fmt.Errorf("unsupported DB_TYPE: %s", dbType)
 }
+
+// Ping checks that the database can be reached, returning the error if it can't. A SQL database, and its read
+// replica if one is configured, are pinged; a file-based database checks that its data directory can be read.
+func Ping(ctx context.Context, db DB) error {
+	switch db := db.(type) {
+	case *sqldb:
+		return db.ping(ctx)
+	case *filedb:
+		_, err := os.Stat(db.dataDir)
+		return err
+	}
+	return nil
+}
+
+// Backend returns the database backend selected by the DB_TYPE environment variable, "sql" or "file", or an empty
+// string if it isn't set. The .env file is loaded first, as it is by Init.
+func Backend() string {
+	godotenv.Load()
+	return os.Getenv("DB_TYPE")
+}
+
+// IsUnavailable returns true if the error was caused by the database being unreachable, rather than by the
+// request or the data. Callers may retry the operation once the database is available again.
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,969 @@
+// Package dbtest checks that implementations of database.DB behave the way the rest of the module expects.
+package dbtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// TestDB checks that db behaves as the DB interface documents. Records must be returned as they were saved, saving
+// a record again must replace it, filters must select the documented records, lists must be returned in the
+// documented order, and records passed to and returned by the database must be copies.
+//
+// The database must be empty when TestDB is called, and the records TestDB saves are left in it. Times are compared
+// to the second, so a SQL database must be opened with parseTime=true and loc=UTC. Update times are not compared.
+//
+// TestDB returns an error describing every problem found, or nil if there are none. It can be called from a test of
+// a new implementation:
+//
+//	if err := dbtest.TestDB(db); err != nil {
+//		t.Fatal(err)
+//	}
+func TestDB(db database.DB) error {
+	c := &checker{db: db, start: time.Now().Add(-time.Second)}
+	c.checkAwards()
+	c.checkTeams()
+	c.checkTeamHistory()
+	c.checkEvents()
+	c.checkEventAwards()
+	c.checkEventRankings()
+	c.checkEventAdvancements()
+	c.checkEventTeams()
+	c.checkMatches()
+	c.checkTeamRankings()
+	c.checkTeamRankingSnapshots()
+	c.checkEventSummaries()
+	c.checkAdvancementCutoffs()
+	c.checkEventSyncs()
+	c.checkEndpointHashes()
+	c.checkSyncRuns()
+	c.checkSyncRetries()
+	c.checkEventSourceKeys()
+	c.checkSyncCheckpoints()
+	c.checkRegionAliases()
+	c.checkTeamNotes()
+	c.checkChanges()
+	c.checkLastUpdated()
+	c.checkDeletes()
+	c.checkMoveEvent()
+	return errors.Join(c.errs...)
+}
+
+// checker runs the checks against a database and collects the problems found.
+type checker struct {
+	db    database.DB
+	start time.Time
+	errs  []error
+}
+
+// errorf records a problem.
+func (c *checker) errorf(format string, args ...any) {
+	c.errs = append(c.errs, fmt.Errorf(format, args...))
+}
+
+// ok records err as a problem with the named operation, and returns whether err is nil.
+func (c *checker) ok(op string, err error) bool {
+	if err != nil {
+		c.errorf("%s: %w", op, err)
+		return false
+	}
+	return true
+}
+
+// expect records a problem with the named operation if got isn't the same as want. Update times are ignored, and
+// other times are compared to the second.
+func expect[T any](c *checker, op string, got, want T) {
+	if !reflect.DeepEqual(normalize(reflect.ValueOf(got)).Interface(), normalize(reflect.ValueOf(want)).Interface()) {
+		c.errorf("%s: got %v, want %v", op, got, want)
+	}
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// normalize returns a copy of v with the UpdatedAt fields cleared and every other time truncated to the second and
+// converted to UTC, so values read back from a database can be compared with the values that were saved.
+func normalize(v reflect.Value) reflect.Value {
+	switch {
+	case v.Type() == timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return reflect.ValueOf(time.Time{})
+		}
+		return reflect.ValueOf(t.Truncate(time.Second).UTC())
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(normalize(v.Elem()))
+		return p
+	case v.Kind() == reflect.Slice:
+		if v.Len() == 0 {
+			// A nil and an empty slice are both "no records"
+			return reflect.Zero(v.Type())
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			s.Index(i).Set(normalize(v.Index(i)))
+		}
+		return s
+	case v.Kind() == reflect.Struct:
+		s := reflect.New(v.Type()).Elem()
+		for i := range v.NumField() {
+			if v.Type().Field(i).Name == "UpdatedAt" {
+				continue
+			}
+			s.Field(i).Set(normalize(v.Field(i)))
+		}
+		return s
+	}
+	return v
+}
+
+// Records saved by the checks. The events are given in the order GetAllEvents must return them.
+var (
+	day = time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	eventB = &database.Event{EventID: "DBTB : 2025", EventCode: "DBTB", Year: 2025, Name: "Event B", Type: "2", RegionCode: "USVA", Country: "USA", DateStart: day.AddDate(0, 0, -7), DateEnd: day.AddDate(0, 0, -7)}
+	eventA = &database.Event{EventID: "DBTA : 2025", EventCode: "DBTA", Year: 2025, Name: "Event A", Type: "2", RegionCode: "USNC", Country: "USA", DateStart: day, DateEnd: day.AddDate(0, 0, 1), Latitude: 35.5, Longitude: -78.5}
+	eventC = &database.Event{EventID: "DBTC : 2025", EventCode: "DBTC", Year: 2025, Name: "Event C", Type: "0", RegionCode: "USNC", Country: "USA", DateStart: day, DateEnd: day, Unofficial: true}
+)
+
+// checkAwards checks saving, replacing, and listing awards, and that records are copied.
+func (c *checker) checkAwards() {
+	award2 := &database.Award{AwardID: 2, Name: "Think Award", Description: "Engineering", ForPerson: false}
+	award1 := &database.Award{AwardID: 1, Name: "Inspire Award", Description: "Best overall"}
+	for _, award := range []*database.Award{award2, award1} {
+		c.ok("SaveAward", c.db.SaveAward(award))
+	}
+
+	got, err := c.db.GetAward(1)
+	if c.ok("GetAward", err) {
+		expect(c, "GetAward", got, award1)
+		if got != nil {
+			got.Name = "changed"
+			again, err := c.db.GetAward(1)
+			if c.ok("GetAward", err) {
+				expect(c, "GetAward after changing the returned award", again, award1)
+			}
+		}
+	}
+	missing, err := c.db.GetAward(99)
+	if c.ok("GetAward", err) && missing != nil {
+		c.errorf("GetAward of a missing award: got %v, want nil", missing)
+	}
+
+	// Changing the saved value must not change the stored award
+	saved := *award2
+	award2.Name = "changed"
+	got, err = c.db.GetAward(2)
+	if c.ok("GetAward", err) {
+		expect(c, "GetAward after changing the saved award", got, &saved)
+	}
+	award2.Name = "Think Award"
+
+	replaced := &database.Award{AwardID: 2, Name: "Think Award", Description: "Engineering portfolio", ForPerson: true}
+	c.ok("SaveAward", c.db.SaveAward(replaced))
+	all, err := c.db.GetAllAwards()
+	if c.ok("GetAllAwards", err) {
+		expect(c, "GetAllAwards", all, []*database.Award{award1, replaced})
+	}
+}
+
+// checkTeams checks saving, filtering, and listing teams.
+func (c *checker) checkTeams() {
+	team30 := &database.Team{TeamID: 30, Name: "Thirty", FullName: "Team Thirty", City: "Raleigh", StateProv: "NC", Country: "USA", RookieYear: 2020, HomeRegion: "USNC"}
+	team10 := &database.Team{TeamID: 10, Name: "Ten", City: "Richmond", StateProv: "VA", Country: "USA", RookieYear: 2010, HomeRegion: "USVA", Website: "https://example.com", RobotName: "Bot"}
+	team20 := &database.Team{TeamID: 20, Name: "Twenty", City: "Durham", StateProv: "NC", Country: "USA", RookieYear: 2015, HomeRegion: "USNC"}
+	for _, team := range []*database.Team{team30, team10, team20} {
+		c.ok("SaveTeam", c.db.SaveTeam(team))
+	}
+
+	got, err := c.db.GetTeam(10)
+	if c.ok("GetTeam", err) {
+		expect(c, "GetTeam", got, team10)
+	}
+	missing, err := c.db.GetTeam(99)
+	if c.ok("GetTeam", err) && missing != nil {
+		c.errorf("GetTeam of a missing team: got %v, want nil", missing)
+	}
+
+	all, err := c.db.GetAllTeams()
+	if c.ok("GetAllTeams", err) {
+		expect(c, "GetAllTeams", all, []*database.Team{team10, team20, team30})
+	}
+	byRegion, err := c.db.GetAllTeams(database.TeamFilter{HomeRegions: []string{"USNC"}})
+	if c.ok("GetAllTeams", err) {
+		expect(c, "GetAllTeams filtered by home region", byRegion, []*database.Team{team20, team30})
+	}
+	byID, err := c.db.GetAllTeams(database.TeamFilter{TeamIDs: []int{30, 10}})
+	if c.ok("GetAllTeams", err) {
+		expect(c, "GetAllTeams filtered by team ID", byID, []*database.Team{team10, team30})
+	}
+	paged, err := c.db.GetAllTeams(database.TeamFilter{Limit: 1, Offset: 1})
+	if c.ok("GetAllTeams", err) {
+		expect(c, "GetAllTeams with a limit and offset", paged, []*database.Team{team20})
+	}
+	inRegion, err := c.db.GetTeamsByRegion("USNC")
+	if c.ok("GetTeamsByRegion", err) {
+		expect(c, "GetTeamsByRegion", inRegion, []*database.Team{team20, team30})
+	}
+}
+
+// checkTeamHistory checks saving, replacing, filtering, and listing the earlier names and home regions of teams.
+func (c *checker) checkTeamHistory() {
+	first := &database.TeamHistory{TeamID: 20, Name: "First Name", HomeRegion: "USVA", ValidTo: day}
+	second := &database.TeamHistory{TeamID: 20, Name: "Second Name", HomeRegion: "USVA", ValidFrom: day, ValidTo: day.Add(24 * time.Hour)}
+	other := &database.TeamHistory{TeamID: 10, Name: "Old Name", HomeRegion: "USNC", ValidTo: day}
+	for _, history := range []*database.TeamHistory{first, second, other} {
+		c.ok("SaveTeamHistory", c.db.SaveTeamHistory(history))
+	}
+	replaced := &database.TeamHistory{TeamID: 20, Name: "Second Name", HomeRegion: "USNC", ValidFrom: day, ValidTo: second.ValidTo}
+	c.ok("SaveTeamHistory", c.db.SaveTeamHistory(replaced))
+
+	history, err := c.db.GetTeamHistory()
+	if c.ok("GetTeamHistory", err) {
+		expect(c, "GetTeamHistory", history, []*database.TeamHistory{other, replaced, first})
+	}
+	history, err = c.db.GetTeamHistory(database.TeamHistoryFilter{TeamIDs: []int{20}})
+	if c.ok("GetTeamHistory", err) {
+		expect(c, "GetTeamHistory filtered by team ID", history, []*database.TeamHistory{replaced, first})
+	}
+}
+
+// checkEvents checks saving, filtering, and listing events, and the region and event code lists.
+func (c *checker) checkEvents() {
+	for _, event := range []*database.Event{eventC, eventA, eventB} {
+		c.ok("SaveEvent", c.db.SaveEvent(event))
+	}
+
+	got, err := c.db.GetEvent(eventA.EventID)
+	if c.ok("GetEvent", err) {
+		expect(c, "GetEvent", got, eventA)
+	}
+	missing, err := c.db.GetEvent("MISSING : 2025")
+	if c.ok("GetEvent", err) && missing != nil {
+		c.errorf("GetEvent of a missing event: got %v, want nil", missing)
+	}
+
+	official, unofficial := false, true
+	filters := []struct {
+		name   string
+		filter []database.EventFilter
+		want   []*database.Event
+	}{
+		{"", nil, []*database.Event{eventB, eventA, eventC}},
+		{"event code", []database.EventFilter{{EventCodes: []string{"DBTC"}}}, []*database.Event{eventC}},
+		{"region code", []database.EventFilter{{RegionCodes: []string{"USNC"}}}, []*database.Event{eventA, eventC}},
+		{"type", []database.EventFilter{{Types: []string{"2"}}}, []*database.Event{eventB, eventA}},
+		{"year", []database.EventFilter{{Year: 2025, RegionCodes: []string{"USVA"}}}, []*database.Event{eventB}},
+		{"official", []database.EventFilter{{Unofficial: &official}}, []*database.Event{eventB, eventA}},
+		{"unofficial", []database.EventFilter{{Unofficial: &unofficial}}, []*database.Event{eventC}},
+		{"limit", []database.EventFilter{{Limit: 2}}, []*database.Event{eventB, eventA}},
+		{"offset", []database.EventFilter{{Offset: 1}}, []*database.Event{eventA, eventC}},
+		{"limit and region code", []database.EventFilter{{RegionCodes: []string{"USNC"}, Limit: 1, Offset: 1}}, []*database.Event{eventC}},
+	}
+	for _, f := range filters {
+		op := "GetAllEvents"
+		if f.name != "" {
+			op += " filtered by " + f.name
+		}
+		events, err := c.db.GetAllEvents(f.filter...)
+		if c.ok("GetAllEvents", err) {
+			expect(c, op, events, f.want)
+		}
+	}
+
+	regions, err := c.db.GetRegionCodes()
+	if c.ok("GetRegionCodes", err) {
+		expect(c, "GetRegionCodes", regions, []string{"USNC", "USVA"})
+	}
+	codes, err := c.db.GetEventCodesByRegion("USNC")
+	if c.ok("GetEventCodesByRegion", err) {
+		expect(c, "GetEventCodesByRegion", codes, []string{"DBTA", "DBTC"})
+	}
+}
+
+// checkEventAwards checks saving, replacing, and listing the awards given at events.
+func (c *checker) checkEventAwards() {
+	award20 := &database.EventAward{EventID: eventA.EventID, TeamID: 20, AwardID: 2, Name: "Think Award", Series: 1}
+	award10 := &database.EventAward{EventID: eventA.EventID, TeamID: 10, AwardID: 1, Name: "Inspire Award", Series: 2}
+	award30 := &database.EventAward{EventID: eventA.EventID, TeamID: 30, AwardID: 1, Name: "Inspire Award", Series: 1}
+	awardB := &database.EventAward{EventID: eventB.EventID, TeamID: 10, AwardID: 1, Name: "Inspire Award", Series: 1}
+	personB := &database.EventAward{EventID: eventB.EventID, TeamID: 20, AwardID: 3, Name: "Dean's List Finalist", Series: 1, Person: "Avery Chen"}
+	for _, award := range []*database.EventAward{award20, award10, award30, awardB, personB} {
+		c.ok("SaveEventAward", c.db.SaveEventAward(award))
+	}
+	replaced := *award20
+	replaced.Name = "Think Award Winner"
+	c.ok("SaveEventAward", c.db.SaveEventAward(&replaced))
+
+	awards, err := c.db.GetEventAwards(eventA.EventID)
+	if c.ok("GetEventAwards", err) {
+		expect(c, "GetEventAwards", awards, []*database.EventAward{award30, award10, &replaced})
+	}
+	teamAwards, err := c.db.GetTeamAwardsByEvent(eventA.EventID, 10)
+	if c.ok("GetTeamAwardsByEvent", err) {
+		expect(c, "GetTeamAwardsByEvent", teamAwards, []*database.EventAward{award10})
+	}
+	allTeamAwards, err := c.db.GetAllTeamAwards(10)
+	if c.ok("GetAllTeamAwards", err) {
+		expect(c, "GetAllTeamAwards", allTeamAwards, []*database.EventAward{award10, awardB})
+	}
+	personAwards, err := c.db.GetTeamAwardsByEvent(eventB.EventID, 20)
+	if c.ok("GetTeamAwardsByEvent of an award given to a person", err) {
+		expect(c, "GetTeamAwardsByEvent of an award given to a person", personAwards, []*database.EventAward{personB})
+	}
+}
+
+// checkEventRankings checks saving, replacing, and listing the qualification rankings at an event.
+func (c *checker) checkEventRankings() {
+	ranking30 := &database.EventRanking{EventID: eventA.EventID, TeamID: 30, Rank: 1, SortOrder1: 2, SortOrder2: 150.5, Wins: 5, MatchesPlayed: 5, MatchesCounted: 5, Source: "test"}
+	ranking10 := &database.EventRanking{EventID: eventA.EventID, TeamID: 10, Rank: 3, Wins: 1, Losses: 4, MatchesPlayed: 5, MatchesCounted: 5}
+	ranking20 := &database.EventRanking{EventID: eventA.EventID, TeamID: 20, Rank: 2, Wins: 3, Losses: 1, Ties: 1, Dq: 1, MatchesPlayed: 5, MatchesCounted: 5}
+	for _, ranking := range []*database.EventRanking{ranking30, ranking10, ranking20} {
+		c.ok("SaveEventRanking", c.db.SaveEventRanking(ranking))
+	}
+	replaced := *ranking10
+	replaced.Wins, replaced.Losses = 2, 3
+	c.ok("SaveEventRanking", c.db.SaveEventRanking(&replaced))
+
+	rankings, err := c.db.GetEventRankings(eventA.EventID)
+	if c.ok("GetEventRankings", err) {
+		expect(c, "GetEventRankings", rankings, []*database.EventRanking{ranking30, ranking20, &replaced})
+	}
+}
+
+// checkEventAdvancements checks saving and listing the teams that advanced from events.
+func (c *checker) checkEventAdvancements() {
+	adv20 := &database.EventAdvancement{EventID: eventA.EventID, TeamID: 20, Status: "Advanced"}
+	adv10 := &database.EventAdvancement{EventID: eventA.EventID, TeamID: 10, Status: "Advanced"}
+	advB := &database.EventAdvancement{EventID: eventB.EventID, TeamID: 30, Status: "Advanced"}
+	for _, adv := range []*database.EventAdvancement{advB, adv20, adv10} {
+		c.ok("SaveEventAdvancement", c.db.SaveEventAdvancement(adv))
+	}
+
+	advancements, err := c.db.GetEventAdvancements(eventA.EventID)
+	if c.ok("GetEventAdvancements", err) {
+		expect(c, "GetEventAdvancements", advancements, []*database.EventAdvancement{adv10, adv20})
+	}
+	byRegion, err := c.db.GetAdvancementsByRegion("USNC")
+	if c.ok("GetAdvancementsByRegion", err) {
+		expect(c, "GetAdvancementsByRegion", byRegion, []*database.EventAdvancement{adv10, adv20})
+	}
+	all, err := c.db.GetAllAdvancements()
+	if c.ok("GetAllAdvancements", err) {
+		expect(c, "GetAllAdvancements", all, []*database.EventAdvancement{adv10, adv20, advB})
+	}
+	filtered, err := c.db.GetAllAdvancements(database.AdvancementFilter{RegionCodes: []string{"USVA"}})
+	if c.ok("GetAllAdvancements", err) {
+		expect(c, "GetAllAdvancements filtered by region code", filtered, []*database.EventAdvancement{advB})
+	}
+	filtered, err = c.db.GetAllAdvancements(database.AdvancementFilter{EventCodes: []string{"DBTA"}})
+	if c.ok("GetAllAdvancements", err) {
+		expect(c, "GetAllAdvancements filtered by event code", filtered, []*database.EventAdvancement{adv10, adv20})
+	}
+}
+
+// checkEventTeams checks saving and listing the teams at events.
+func (c *checker) checkEventTeams() {
+	teams := []*database.EventTeam{
+		{EventID: eventA.EventID, TeamID: 30, Registered: true},
+		{EventID: eventA.EventID, TeamID: 10, Registered: true, Played: true},
+		{EventID: eventA.EventID, TeamID: 20, Played: true},
+		{EventID: eventB.EventID, TeamID: 10, Registered: true},
+	}
+	for _, team := range teams {
+		c.ok("SaveEventTeam", c.db.SaveEventTeam(team))
+	}
+	teams[0].Played = true
+	c.ok("SaveEventTeam", c.db.SaveEventTeam(teams[0]))
+
+	got, err := c.db.GetEventTeams(eventA.EventID)
+	if c.ok("GetEventTeams", err) {
+		expect(c, "GetEventTeams", got, []*database.EventTeam{teams[1], teams[2], teams[0]})
+	}
+	events, err := c.db.GetEventsByTeam(10)
+	if c.ok("GetEventsByTeam", err) {
+		expect(c, "GetEventsByTeam", events, []string{eventA.EventID, eventB.EventID})
+	}
+}
+
+// checkMatches checks saving and listing matches, their alliance scores, and their teams.
+func (c *checker) checkMatches() {
+	match := func(event *database.Event, level string, number int) *database.Match {
+		return &database.Match{
+			MatchID:         database.GetMatchID(event, level, number),
+			EventID:         event.EventID,
+			MatchType:       level,
+			MatchNumber:     number,
+			Description:     fmt.Sprintf("%s %d", level, number),
+			TournamentLevel: level,
+		}
+	}
+	playoff1 := match(eventA, "PLAYOFF", 1)
+	qual2 := match(eventA, "QUALIFICATION", 2)
+	qual1 := match(eventA, "QUALIFICATION", 1)
+	qual1.Source = "manual"
+	qual1.ActualStartTime = day.Add(10*time.Hour + 42*time.Minute).In(time.FixedZone("EST", -5*60*60))
+	qualB := match(eventB, "QUALIFICATION", 1)
+	for _, m := range []*database.Match{playoff1, qual2, qual1, qualB} {
+		c.ok("SaveMatch", c.db.SaveMatch(m))
+	}
+
+	got, err := c.db.GetMatch(qual1.MatchID)
+	if c.ok("GetMatch", err) {
+		expect(c, "GetMatch", got, qual1)
+	}
+	missing, err := c.db.GetMatch("MISSING")
+	if c.ok("GetMatch", err) && missing != nil {
+		c.errorf("GetMatch of a missing match: got %v, want nil", missing)
+	}
+	byEvent, err := c.db.GetMatchesByEvent(eventA.EventID)
+	if c.ok("GetMatchesByEvent", err) {
+		expect(c, "GetMatchesByEvent", byEvent, []*database.Match{qual1, qual2, playoff1})
+	}
+	all, err := c.db.GetAllMatches()
+	if c.ok("GetAllMatches", err) {
+		expect(c, "GetAllMatches", all, []*database.Match{qual1, qual2, playoff1, qualB})
+	}
+	filtered, err := c.db.GetAllMatches(database.MatchFilter{EventIDs: []string{eventB.EventID}})
+	if c.ok("GetAllMatches", err) {
+		expect(c, "GetAllMatches filtered by event ID", filtered, []*database.Match{qualB})
+	}
+	paged, err := c.db.GetAllMatches(database.MatchFilter{Limit: 2, Offset: 1})
+	if c.ok("GetAllMatches", err) {
+		expect(c, "GetAllMatches with a limit and offset", paged, []*database.Match{qual2, playoff1})
+	}
+
+	scores := []*database.MatchAllianceScore{
+		{MatchID: qual1.MatchID, Alliance: database.AllianceRed, AutoPoints: 20, TeleopPoints: 60, FoulPointsCommitted: 10, PreFoulTotal: 80, TotalPoints: 90, MinorFouls: 1},
+		{MatchID: qual1.MatchID, Alliance: database.AllianceBlue, AutoPoints: 15, TeleopPoints: 45, PreFoulTotal: 60, TotalPoints: 60, MajorFouls: 1},
+		{MatchID: qual2.MatchID, Alliance: database.AllianceRed, TotalPoints: 100},
+		{MatchID: playoff1.MatchID, Alliance: database.AllianceRed, TotalPoints: 120},
+	}
+	for _, score := range scores {
+		c.ok("SaveMatchAllianceScore", c.db.SaveMatchAllianceScore(score))
+	}
+	score, err := c.db.GetMatchAllianceScore(qual1.MatchID, database.AllianceBlue)
+	if c.ok("GetMatchAllianceScore", err) {
+		expect(c, "GetMatchAllianceScore", score, scores[1])
+	}
+	missingScore, err := c.db.GetMatchAllianceScore(qual2.MatchID, database.AllianceBlue)
+	if c.ok("GetMatchAllianceScore", err) && missingScore != nil {
+		c.errorf("GetMatchAllianceScore of a missing score: got %v, want nil", missingScore)
+	}
+
+	red30 := &database.MatchTeam{MatchID: qual1.MatchID, TeamID: 30, Alliance: database.AllianceRed, OnField: true}
+	red10 := &database.MatchTeam{MatchID: qual1.MatchID, TeamID: 10, Alliance: database.AllianceRed, OnField: true}
+	blue20 := &database.MatchTeam{MatchID: qual1.MatchID, TeamID: 20, Alliance: database.AllianceBlue, OnField: true}
+	qual2Team := &database.MatchTeam{MatchID: qual2.MatchID, TeamID: 40, Alliance: database.AllianceBlue}
+	for _, team := range []*database.MatchTeam{red30, red10, blue20, qual2Team} {
+		c.ok("SaveMatchTeam", c.db.SaveMatchTeam(team))
+	}
+	dq := *red30
+	dq.Dq = true
+	c.ok("SaveMatchTeam", c.db.SaveMatchTeam(&dq))
+
+	matchTeams, err := c.db.GetMatchTeams(qual1.MatchID)
+	if c.ok("GetMatchTeams", err) {
+		expect(c, "GetMatchTeams", matchTeams, []*database.MatchTeam{blue20, red10, &dq})
+	}
+	teamIDs, err := c.db.GetTeamsByEvent(eventA.EventID)
+	if c.ok("GetTeamsByEvent", err) {
+		expect(c, "GetTeamsByEvent", teamIDs, []int{10, 20, 30, 40})
+	}
+}
+
+// checkTeamRankings checks saving, filtering, and listing the performance metrics of teams at events.
+func (c *checker) checkTeamRankings() {
+	rankingB10 := &database.TeamRanking{TeamID: 10, EventID: eventB.EventID, NumMatches: 5, OPR: 40.5, NpOPR: 35.25}
+	rankingA20 := &database.TeamRanking{TeamID: 20, EventID: eventA.EventID, NumMatches: 5, CCWM: 10, OPR: 55, NpOPR: 50, DPR: 45, NpDPR: 40, NpAvg: 90}
+	rankingA10 := &database.TeamRanking{TeamID: 10, EventID: eventA.EventID, NumMatches: 5, CCWM: -5, OPR: 30, NpOPR: 28, DPR: 35, NpDPR: 33, NpAvg: 60}
+	for _, ranking := range []*database.TeamRanking{rankingB10, rankingA20, rankingA10} {
+		c.ok("SaveTeamRanking", c.db.SaveTeamRanking(ranking))
+	}
+
+	all, err := c.db.GetTeamRankings()
+	if c.ok("GetTeamRankings", err) {
+		expect(c, "GetTeamRankings", all, []*database.TeamRanking{rankingA10, rankingA20, rankingB10})
+	}
+	byTeam, err := c.db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: []int{10}})
+	if c.ok("GetTeamRankings", err) {
+		expect(c, "GetTeamRankings filtered by team ID", byTeam, []*database.TeamRanking{rankingA10, rankingB10})
+	}
+	byEvent, err := c.db.GetTeamRankings(database.TeamRankingFilter{EventIDs: []string{eventB.EventID}})
+	if c.ok("GetTeamRankings", err) {
+		expect(c, "GetTeamRankings filtered by event ID", byEvent, []*database.TeamRanking{rankingB10})
+	}
+	paged, err := c.db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: []int{10}, Offset: 1})
+	if c.ok("GetTeamRankings", err) {
+		expect(c, "GetTeamRankings with an offset", paged, []*database.TeamRanking{rankingB10})
+	}
+}
+
+// checkTeamRankingSnapshots checks that the latest snapshot on or before a date is returned.
+func (c *checker) checkTeamRankingSnapshots() {
+	first := &database.TeamRankingSnapshot{SnapshotDate: day, TeamID: 20, EventID: eventA.EventID, NumMatches: 3, OPR: 50}
+	firstOther := &database.TeamRankingSnapshot{SnapshotDate: day, TeamID: 10, EventID: eventA.EventID, NumMatches: 3, OPR: 25}
+	second := &database.TeamRankingSnapshot{SnapshotDate: day.AddDate(0, 0, 7), TeamID: 10, EventID: eventA.EventID, NumMatches: 5, OPR: 30}
+	for _, snapshot := range []*database.TeamRankingSnapshot{second, first, firstOther} {
+		c.ok("SaveTeamRankingSnapshot", c.db.SaveTeamRankingSnapshot(snapshot))
+	}
+
+	latest, err := c.db.GetTeamRankingSnapshots()
+	if c.ok("GetTeamRankingSnapshots", err) {
+		expect(c, "GetTeamRankingSnapshots", latest, []*database.TeamRankingSnapshot{second})
+	}
+	asOf, err := c.db.GetTeamRankingSnapshots(database.TeamRankingSnapshotFilter{AsOf: day.AddDate(0, 0, 3)})
+	if c.ok("GetTeamRankingSnapshots", err) {
+		expect(c, "GetTeamRankingSnapshots as of a date", asOf, []*database.TeamRankingSnapshot{firstOther, first})
+	}
+	byTeam, err := c.db.GetTeamRankingSnapshots(database.TeamRankingSnapshotFilter{AsOf: day, TeamIDs: []int{20}})
+	if c.ok("GetTeamRankingSnapshots", err) {
+		expect(c, "GetTeamRankingSnapshots filtered by team ID", byTeam, []*database.TeamRankingSnapshot{first})
+	}
+}
+
+// checkEventSummaries checks that an event's summary is calculated from its matches and team rankings. It depends
+// on the records saved by checkMatches and checkTeamRankings.
+func (c *checker) checkEventSummaries() {
+	if !c.ok("RefreshEventSummary", c.db.RefreshEventSummary(eventA.EventID)) {
+		return
+	}
+	summaries, err := c.db.GetEventSummaries(database.EventSummaryFilter{RegionCodes: []string{"USNC"}})
+	if !c.ok("GetEventSummaries", err) {
+		return
+	}
+	if len(summaries) != 1 {
+		c.errorf("GetEventSummaries filtered by region code: got %d summaries, want 1", len(summaries))
+		return
+	}
+	summary := summaries[0]
+	got := []int{summary.QualMatches, summary.PlayoffMatches, summary.NumTeams, summary.HighScore, summary.TopOPRTeamID}
+	expect(c, "GetEventSummaries matches, playoff matches, teams, high score, and top OPR team", got, []int{2, 1, 2, 120, 20})
+}
+
+// checkAdvancementCutoffs checks saving, replacing, and listing the advancement cutoffs of events.
+func (c *checker) checkAdvancementCutoffs() {
+	cutoffB := &database.AdvancementCutoff{EventID: eventB.EventID, Teams: 24, Advancing: 3, Cutoff: 71}
+	cutoffA := &database.AdvancementCutoff{EventID: eventA.EventID, Teams: 16, Advancing: 2, Cutoff: 58}
+	cutoffC := &database.AdvancementCutoff{EventID: eventC.EventID, Teams: 12, Advancing: 1, Cutoff: 40}
+	for _, cutoff := range []*database.AdvancementCutoff{cutoffB, cutoffA, cutoffC} {
+		c.ok("SaveAdvancementCutoff", c.db.SaveAdvancementCutoff(cutoff))
+	}
+	replaced := &database.AdvancementCutoff{EventID: eventA.EventID, Teams: 18, Advancing: 2, Cutoff: 62}
+	c.ok("SaveAdvancementCutoff", c.db.SaveAdvancementCutoff(replaced))
+
+	cutoffs, err := c.db.GetAdvancementCutoffs()
+	if c.ok("GetAdvancementCutoffs", err) {
+		expect(c, "GetAdvancementCutoffs", cutoffs, []*database.AdvancementCutoff{replaced, cutoffB, cutoffC})
+	}
+	cutoffs, err = c.db.GetAdvancementCutoffs(database.AdvancementCutoffFilter{EventIDs: []string{eventB.EventID}})
+	if c.ok("GetAdvancementCutoffs", err) {
+		expect(c, "GetAdvancementCutoffs filtered by event ID", cutoffs, []*database.AdvancementCutoff{cutoffB})
+	}
+}
+
+// checkEventSyncs checks saving, replacing, and listing the times events were last synced.
+func (c *checker) checkEventSyncs() {
+	syncB := &database.EventSync{EventID: eventB.EventID, SyncedAt: day.Add(9 * time.Hour)}
+	syncA := &database.EventSync{EventID: eventA.EventID, SyncedAt: day.Add(10 * time.Hour)}
+	syncC := &database.EventSync{EventID: eventC.EventID, SyncedAt: day.Add(11 * time.Hour)}
+	for _, sync := range []*database.EventSync{syncB, syncA, syncC} {
+		c.ok("SaveEventSync", c.db.SaveEventSync(sync))
+	}
+	replaced := &database.EventSync{EventID: eventA.EventID, SyncedAt: day.Add(12 * time.Hour)}
+	c.ok("SaveEventSync", c.db.SaveEventSync(replaced))
+
+	syncs, err := c.db.GetEventSyncs()
+	if c.ok("GetEventSyncs", err) {
+		expect(c, "GetEventSyncs", syncs, []*database.EventSync{replaced, syncB, syncC})
+	}
+	syncs, err = c.db.GetEventSyncs(database.EventSyncFilter{EventIDs: []string{eventB.EventID}})
+	if c.ok("GetEventSyncs", err) {
+		expect(c, "GetEventSyncs filtered by event ID", syncs, []*database.EventSync{syncB})
+	}
+}
+
+// checkEndpointHashes checks saving, replacing, and listing the hashes of the responses from the data source's
+// endpoints for an event.
+func (c *checker) checkEndpointHashes() {
+	rankings := &database.EndpointHash{EventID: eventA.EventID, Endpoint: "rankings", Hash: "r1", HashedAt: day.Add(9 * time.Hour)}
+	awards := &database.EndpointHash{EventID: eventA.EventID, Endpoint: "awards", Hash: "a1", HashedAt: day.Add(9 * time.Hour)}
+	other := &database.EndpointHash{EventID: eventC.EventID, Endpoint: "awards", Hash: "c1", HashedAt: day.Add(9 * time.Hour)}
+	for _, hash := range []*database.EndpointHash{rankings, awards, other} {
+		c.ok("SaveEndpointHash", c.db.SaveEndpointHash(hash))
+	}
+	replaced := &database.EndpointHash{EventID: eventA.EventID, Endpoint: "rankings", Hash: "r2", HashedAt: day.Add(10 * time.Hour)}
+	c.ok("SaveEndpointHash", c.db.SaveEndpointHash(replaced))
+
+	hashes, err := c.db.GetEndpointHashes(eventA.EventID)
+	if c.ok("GetEndpointHashes", err) {
+		expect(c, "GetEndpointHashes", hashes, []*database.EndpointHash{awards, replaced})
+	}
+}
+
+// checkSyncRuns checks saving, replacing, filtering, and limiting the runs that synced a season's data.
+func (c *checker) checkSyncRuns() {
+	started := time.Date(2025, time.November, 2, 12, 0, 0, 0, time.UTC)
+	first := &database.SyncRun{RunID: "run-1", Season: "2025", Scope: "all", Status: database.SyncRunFailed, StartedAt: started, FinishedAt: started.Add(time.Minute), TotalEvents: 3, Events: 3, ErrorCount: 1, Errors: []string{"DBTA: timeout"}}
+	second := &database.SyncRun{RunID: "run-2", Season: "2025", Scope: "region USNC", Status: database.SyncRunRunning, StartedAt: started.Add(time.Hour)}
+	other := &database.SyncRun{RunID: "run-3", Season: "2024", Scope: "all", Status: database.SyncRunCompleted, StartedAt: started, FinishedAt: started.Add(time.Minute)}
+	for _, run := range []*database.SyncRun{first, second, other} {
+		c.ok("SaveSyncRun", c.db.SaveSyncRun(run))
+	}
+	finished := *second
+	finished.Status = database.SyncRunCompleted
+	finished.FinishedAt = second.StartedAt.Add(time.Minute)
+	finished.TotalEvents, finished.Events, finished.ChangedEvents, finished.UpdatedEndpoints = 2, 2, 1, 5
+	c.ok("SaveSyncRun", c.db.SaveSyncRun(&finished))
+
+	runs, err := c.db.GetSyncRuns(database.SyncRunFilter{Season: "2025"})
+	if c.ok("GetSyncRuns", err) {
+		expect(c, "GetSyncRuns", runs, []*database.SyncRun{&finished, first})
+	}
+	runs, err = c.db.GetSyncRuns(database.SyncRunFilter{Season: "2025", Status: database.SyncRunFailed})
+	if c.ok("GetSyncRuns", err) {
+		expect(c, "GetSyncRuns filtered by status", runs, []*database.SyncRun{first})
+	}
+	runs, err = c.db.GetSyncRuns(database.SyncRunFilter{Season: "2025", Limit: 1})
+	if c.ok("GetSyncRuns", err) {
+		expect(c, "GetSyncRuns with a limit", runs, []*database.SyncRun{&finished})
+	}
+}
+
+// checkSyncRetries checks saving, replacing, listing, and deleting the events whose results failed to sync.
+func (c *checker) checkSyncRetries() {
+	failed := time.Date(2025, time.November, 2, 12, 0, 0, 0, time.UTC)
+	retryC := &database.SyncRetry{EventID: eventC.EventID, Attempts: 1, LastError: "timeout", FirstFailedAt: failed, LastFailedAt: failed}
+	retryB := &database.SyncRetry{EventID: eventB.EventID, Attempts: 1, LastError: "timeout", FirstFailedAt: failed, LastFailedAt: failed}
+	retryA := &database.SyncRetry{EventID: eventA.EventID, Attempts: 1, LastError: "timeout", FirstFailedAt: failed, LastFailedAt: failed}
+	for _, retry := range []*database.SyncRetry{retryC, retryB, retryA} {
+		c.ok("SaveSyncRetry", c.db.SaveSyncRetry(retry))
+	}
+	replaced := &database.SyncRetry{EventID: eventA.EventID, Attempts: 2, LastError: "bad gateway", FirstFailedAt: failed, LastFailedAt: failed.Add(time.Hour)}
+	c.ok("SaveSyncRetry", c.db.SaveSyncRetry(replaced))
+	c.ok("DeleteSyncRetry", c.db.DeleteSyncRetry(eventB.EventID))
+	c.ok("DeleteSyncRetry of a missing retry", c.db.DeleteSyncRetry(eventB.EventID))
+
+	retries, err := c.db.GetSyncRetries()
+	if c.ok("GetSyncRetries", err) {
+		expect(c, "GetSyncRetries", retries, []*database.SyncRetry{replaced, retryC})
+	}
+}
+
+// checkEventSourceKeys checks saving, replacing, and listing the keys other data sources use for events.
+func (c *checker) checkEventSourceKeys() {
+	key2 := &database.EventSourceKey{Source: "dbtest", SourceKey: "key-2", EventID: eventB.EventID}
+	key1 := &database.EventSourceKey{Source: "dbtest", SourceKey: "key-1", EventID: eventA.EventID}
+	other := &database.EventSourceKey{Source: "other", SourceKey: "key-1", EventID: eventA.EventID}
+	for _, key := range []*database.EventSourceKey{key2, key1, other} {
+		c.ok("SaveEventSourceKey", c.db.SaveEventSourceKey(key))
+	}
+	replaced := &database.EventSourceKey{Source: "dbtest", SourceKey: "key-1", EventID: eventC.EventID}
+	c.ok("SaveEventSourceKey", c.db.SaveEventSourceKey(replaced))
+
+	keys, err := c.db.GetEventSourceKeys("dbtest")
+	if c.ok("GetEventSourceKeys", err) {
+		expect(c, "GetEventSourceKeys", keys, []*database.EventSourceKey{replaced, key2})
+	}
+}
+
+// checkSyncCheckpoints checks saving, listing, and deleting the checkpoints of a season's sync.
+func (c *checker) checkSyncCheckpoints() {
+	completed := time.Date(2025, time.November, 2, 12, 0, 0, 0, time.UTC)
+	later := &database.SyncCheckpoint{Season: "2025", EventID: eventB.EventID, CompletedAt: completed.Add(time.Hour)}
+	earlier := &database.SyncCheckpoint{Season: "2025", EventID: eventA.EventID, CompletedAt: completed}
+	other := &database.SyncCheckpoint{Season: "2024", EventID: eventA.EventID, CompletedAt: completed}
+	for _, checkpoint := range []*database.SyncCheckpoint{later, earlier, other} {
+		c.ok("SaveSyncCheckpoint", c.db.SaveSyncCheckpoint(checkpoint))
+	}
+
+	checkpoints, err := c.db.GetSyncCheckpoints("2025")
+	if c.ok("GetSyncCheckpoints", err) {
+		expect(c, "GetSyncCheckpoints", checkpoints, []*database.SyncCheckpoint{earlier, later})
+	}
+	if c.ok("DeleteSyncCheckpoints", c.db.DeleteSyncCheckpoints("2025")) {
+		checkpoints, err = c.db.GetSyncCheckpoints("2025")
+		if c.ok("GetSyncCheckpoints", err) && len(checkpoints) != 0 {
+			c.errorf("GetSyncCheckpoints after DeleteSyncCheckpoints: got %d checkpoints, want 0", len(checkpoints))
+		}
+	}
+	checkpoints, err = c.db.GetSyncCheckpoints("2024")
+	if c.ok("GetSyncCheckpoints", err) {
+		expect(c, "GetSyncCheckpoints of another season", checkpoints, []*database.SyncCheckpoint{other})
+	}
+	c.ok("DeleteSyncCheckpoints", c.db.DeleteSyncCheckpoints("2024"))
+}
+
+// checkRegionAliases checks saving, replacing, listing, and deleting region aliases, and that aliases are matched
+// without regard to case or spacing.
+func (c *checker) checkRegionAliases() {
+	for _, alias := range []*database.RegionAlias{
+		{Alias: "NorCal", RegionCode: "USCANO"},
+		{Alias: " north  carolina ", RegionCode: "usnc"},
+		{Alias: "Virginia", RegionCode: "USVA"},
+		{Alias: "North Carolina", RegionCode: "USNC"},
+	} {
+		c.ok("SaveRegionAlias", c.db.SaveRegionAlias(alias))
+	}
+
+	aliases, err := c.db.GetRegionAliases()
+	if c.ok("GetRegionAliases", err) {
+		expect(c, "GetRegionAliases", aliases, []*database.RegionAlias{
+			{Alias: "NorCal", RegionCode: "USCANO"},
+			{Alias: "North Carolina", RegionCode: "USNC"},
+			{Alias: "Virginia", RegionCode: "USVA"},
+		})
+	}
+	if c.ok("DeleteRegionAlias", c.db.DeleteRegionAlias("NORCAL")) {
+		aliases, err = c.db.GetRegionAliases()
+		if c.ok("GetRegionAliases", err) {
+			expect(c, "GetRegionAliases after DeleteRegionAlias", aliases, []*database.RegionAlias{
+				{Alias: "North Carolina", RegionCode: "USNC"},
+				{Alias: "Virginia", RegionCode: "USVA"},
+			})
+		}
+	}
+	c.ok("DeleteRegionAlias", c.db.DeleteRegionAlias("North Carolina"))
+	c.ok("DeleteRegionAlias", c.db.DeleteRegionAlias("Virginia"))
+}
+
+// checkTeamNotes checks saving, replacing, and listing the scouting notes on teams, and that tags are normalized.
+func (c *checker) checkTeamNotes() {
+	for _, note := range []*database.TeamNote{
+		{Year: 2025, TeamID: 20, Notes: "Slow intake", Tags: []string{"Defense"}},
+		{Year: 2025, TeamID: 10, Notes: "Mecanum drive", Tags: []string{" Strong  Auto ", "climber", "CLIMBER"}, Source: "pit.csv"},
+		{Year: 2024, TeamID: 10, Notes: "Tank drive"},
+		{Year: 2025, TeamID: 20, Notes: "Fixed intake", Tags: []string{"defense", "cycler"}, Source: "pit.csv"},
+	} {
+		c.ok("SaveTeamNote", c.db.SaveTeamNote(note))
+	}
+
+	notes, err := c.db.GetTeamNotes()
+	if c.ok("GetTeamNotes", err) {
+		expect(c, "GetTeamNotes", notes, []*database.TeamNote{
+			{Year: 2024, TeamID: 10, Notes: "Tank drive"},
+			{Year: 2025, TeamID: 10, Notes: "Mecanum drive", Tags: []string{"strong auto", "climber"}, Source: "pit.csv"},
+			{Year: 2025, TeamID: 20, Notes: "Fixed intake", Tags: []string{"defense", "cycler"}, Source: "pit.csv"},
+		})
+	}
+	notes, err = c.db.GetTeamNotes(database.TeamNoteFilter{Year: 2025, TeamIDs: []int{20}})
+	if c.ok("GetTeamNotes with filter", err) {
+		expect(c, "GetTeamNotes with filter", notes, []*database.TeamNote{
+			{Year: 2025, TeamID: 20, Notes: "Fixed intake", Tags: []string{"defense", "cycler"}, Source: "pit.csv"},
+		})
+	}
+}
+
+// checkChanges checks that the records saved by the other checks are returned as changes, and that nothing has
+// changed since now.
+func (c *checker) checkChanges() {
+	changes, err := c.db.GetChanges(c.start)
+	if !c.ok("GetChanges", err) {
+		return
+	}
+	got := []int{len(changes.Awards), len(changes.Teams), len(changes.Events), len(changes.Matches)}
+	expect(c, "GetChanges awards, teams, events, and matches", got, []int{2, 3, 3, 4})
+	if len(changes.Awards) == 2 && changes.Awards[0].AwardID > changes.Awards[1].AwardID {
+		c.errorf("GetChanges: awards are not ordered by award ID")
+	}
+
+	changes, err = c.db.GetChanges(time.Now().Add(time.Minute))
+	if c.ok("GetChanges", err) && changes.Count() != 0 {
+		c.errorf("GetChanges since a future time: got changes, want none")
+	}
+}
+
+// checkLastUpdated checks that the time data was last changed, for all records and for the records of an event,
+// is no earlier than the records saved by the earlier checks.
+func (c *checker) checkLastUpdated() {
+	latest, err := c.db.GetLastUpdated()
+	if c.ok("GetLastUpdated", err) && latest.Before(c.start) {
+		c.errorf("GetLastUpdated: got %s, want a time after the checks started", latest)
+	}
+	latest, err = c.db.GetLastUpdated(database.LastUpdatedFilter{EventIDs: []string{eventA.EventID}})
+	if c.ok("GetLastUpdated", err) && latest.Before(c.start) {
+		c.errorf("GetLastUpdated filtered by event ID: got %s, want a time after the checks started", latest)
+	}
+}
+
+// checkDeletes checks deleting the records of an event, and that deleting a match also deletes its alliance scores
+// and teams. It saves its records to the unofficial event, which the other checks don't use, and runs after
+// checkChanges so the records it saves aren't counted as changes.
+func (c *checker) checkDeletes() {
+	award10 := &database.EventAward{EventID: eventC.EventID, TeamID: 10, AwardID: 1, Name: "Inspire Award", Series: 1}
+	award20 := &database.EventAward{EventID: eventC.EventID, TeamID: 20, AwardID: 1, Name: "Inspire Award", Series: 2}
+	for _, award := range []*database.EventAward{award10, award20} {
+		c.ok("SaveEventAward", c.db.SaveEventAward(award))
+	}
+	c.ok("DeleteEventAward", c.db.DeleteEventAward(award10))
+	c.ok("DeleteEventAward of a missing award", c.db.DeleteEventAward(award10))
+	awards, err := c.db.GetEventAwards(eventC.EventID)
+	if c.ok("GetEventAwards", err) {
+		expect(c, "GetEventAwards after DeleteEventAward", awards, []*database.EventAward{award20})
+	}
+
+	ranking10 := &database.EventRanking{EventID: eventC.EventID, TeamID: 10, Rank: 1}
+	ranking20 := &database.EventRanking{EventID: eventC.EventID, TeamID: 20, Rank: 2}
+	for _, ranking := range []*database.EventRanking{ranking10, ranking20} {
+		c.ok("SaveEventRanking", c.db.SaveEventRanking(ranking))
+	}
+	c.ok("DeleteEventRanking", c.db.DeleteEventRanking(eventC.EventID, 10))
+	rankings, err := c.db.GetEventRankings(eventC.EventID)
+	if c.ok("GetEventRankings", err) {
+		expect(c, "GetEventRankings after DeleteEventRanking", rankings, []*database.EventRanking{ranking20})
+	}
+
+	adv10 := &database.EventAdvancement{EventID: eventC.EventID, TeamID: 10, Status: "Advanced"}
+	adv20 := &database.EventAdvancement{EventID: eventC.EventID, TeamID: 20, Status: "Advanced"}
+	for _, adv := range []*database.EventAdvancement{adv10, adv20} {
+		c.ok("SaveEventAdvancement", c.db.SaveEventAdvancement(adv))
+	}
+	c.ok("DeleteEventAdvancement", c.db.DeleteEventAdvancement(eventC.EventID, 20))
+	advancements, err := c.db.GetEventAdvancements(eventC.EventID)
+	if c.ok("GetEventAdvancements", err) {
+		expect(c, "GetEventAdvancements after DeleteEventAdvancement", advancements, []*database.EventAdvancement{adv10})
+	}
+
+	team10 := &database.EventTeam{EventID: eventC.EventID, TeamID: 10}
+	team20 := &database.EventTeam{EventID: eventC.EventID, TeamID: 20}
+	for _, team := range []*database.EventTeam{team10, team20} {
+		c.ok("SaveEventTeam", c.db.SaveEventTeam(team))
+	}
+	c.ok("DeleteEventTeam", c.db.DeleteEventTeam(eventC.EventID, 10))
+	eventTeams, err := c.db.GetEventTeams(eventC.EventID)
+	if c.ok("GetEventTeams", err) {
+		expect(c, "GetEventTeams after DeleteEventTeam", eventTeams, []*database.EventTeam{team20})
+	}
+
+	teamRanking10 := &database.TeamRanking{TeamID: 10, EventID: eventC.EventID, NumMatches: 2, OPR: 20}
+	teamRanking20 := &database.TeamRanking{TeamID: 20, EventID: eventC.EventID, NumMatches: 2, OPR: 30}
+	for _, ranking := range []*database.TeamRanking{teamRanking10, teamRanking20} {
+		c.ok("SaveTeamRanking", c.db.SaveTeamRanking(ranking))
+	}
+	c.ok("DeleteTeamRanking", c.db.DeleteTeamRanking(eventC.EventID, 20))
+	teamRankings, err := c.db.GetTeamRankings(database.TeamRankingFilter{EventIDs: []string{eventC.EventID}})
+	if c.ok("GetTeamRankings", err) {
+		expect(c, "GetTeamRankings after DeleteTeamRanking", teamRankings, []*database.TeamRanking{teamRanking10})
+	}
+
+	qual1 := &database.Match{MatchID: database.GetMatchID(eventC, "QUALIFICATION", 1), EventID: eventC.EventID, MatchType: "QUALIFICATION", MatchNumber: 1, TournamentLevel: "QUALIFICATION"}
+	qual2 := &database.Match{MatchID: database.GetMatchID(eventC, "QUALIFICATION", 2), EventID: eventC.EventID, MatchType: "QUALIFICATION", MatchNumber: 2, TournamentLevel: "QUALIFICATION"}
+	for _, m := range []*database.Match{qual1, qual2} {
+		c.ok("SaveMatch", c.db.SaveMatch(m))
+		c.ok("SaveMatchAllianceScore", c.db.SaveMatchAllianceScore(&database.MatchAllianceScore{MatchID: m.MatchID, Alliance: database.AllianceRed, TotalPoints: 50}))
+		c.ok("SaveMatchTeam", c.db.SaveMatchTeam(&database.MatchTeam{MatchID: m.MatchID, TeamID: 10, Alliance: database.AllianceRed}))
+	}
+	red20 := &database.MatchTeam{MatchID: qual2.MatchID, TeamID: 20, Alliance: database.AllianceRed}
+	c.ok("SaveMatchTeam", c.db.SaveMatchTeam(red20))
+
+	c.ok("DeleteMatch", c.db.DeleteMatch(qual1.MatchID))
+	c.ok("DeleteMatch of a missing match", c.db.DeleteMatch(qual1.MatchID))
+	matches, err := c.db.GetMatchesByEvent(eventC.EventID)
+	if c.ok("GetMatchesByEvent", err) {
+		expect(c, "GetMatchesByEvent after DeleteMatch", matches, []*database.Match{qual2})
+	}
+	score, err := c.db.GetMatchAllianceScore(qual1.MatchID, database.AllianceRed)
+	if c.ok("GetMatchAllianceScore", err) && score != nil {
+		c.errorf("GetMatchAllianceScore after DeleteMatch: got %v, want nil", score)
+	}
+	matchTeams, err := c.db.GetMatchTeams(qual1.MatchID)
+	if c.ok("GetMatchTeams", err) && len(matchTeams) != 0 {
+		c.errorf("GetMatchTeams after DeleteMatch: got %d teams, want 0", len(matchTeams))
+	}
+
+	c.ok("DeleteMatchTeam", c.db.DeleteMatchTeam(qual2.MatchID, 10))
+	matchTeams, err = c.db.GetMatchTeams(qual2.MatchID)
+	if c.ok("GetMatchTeams", err) {
+		expect(c, "GetMatchTeams after DeleteMatchTeam", matchTeams, []*database.MatchTeam{red20})
+	}
+}
+
+// checkMoveEvent checks moving the unofficial event to a new event ID, as happens when an event is rescheduled into
+// another year. It depends on the records saved by checkDeletes, checkAdvancementCutoffs, checkEventSyncs,
+// checkEndpointHashes, checkSyncRetries, and checkEventSourceKeys.
+func (c *checker) checkMoveEvent() {
+	moved := *eventC
+	moved.EventID = "DBTC : 2026"
+	moved.DateStart = time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+	moved.DateEnd = moved.DateStart
+	c.ok("SaveEvent", c.db.SaveEvent(&moved))
+	kept := &database.EventTeam{EventID: moved.EventID, TeamID: 20}
+	c.ok("SaveEventTeam", c.db.SaveEventTeam(kept))
+	if !c.ok("MoveEvent", c.db.MoveEvent(eventC.EventID, moved.EventID)) {
+		return
+	}
+
+	old, err := c.db.GetEvent(eventC.EventID)
+	if c.ok("GetEvent", err) && old != nil {
+		c.errorf("GetEvent after MoveEvent: got %v, want nil", old)
+	}
+	awards, err := c.db.GetEventAwards(moved.EventID)
+	if c.ok("GetEventAwards", err) {
+		expect(c, "GetEventAwards after MoveEvent", awards, []*database.EventAward{
+			{EventID: moved.EventID, TeamID: 20, AwardID: 1, Name: "Inspire Award", Series: 2},
+		})
+	}
+	oldAwards, err := c.db.GetEventAwards(eventC.EventID)
+	if c.ok("GetEventAwards", err) && len(oldAwards) != 0 {
+		c.errorf("GetEventAwards of the old event ID after MoveEvent: got %d awards, want 0", len(oldAwards))
+	}
+	teams, err := c.db.GetEventTeams(moved.EventID)
+	if c.ok("GetEventTeams", err) {
+		expect(c, "GetEventTeams after MoveEvent", teams, []*database.EventTeam{kept})
+	}
+	rankings, err := c.db.GetTeamRankings(database.TeamRankingFilter{EventIDs: []string{moved.EventID}})
+	if c.ok("GetTeamRankings", err) {
+		expect(c, "GetTeamRankings after MoveEvent", rankings, []*database.TeamRanking{
+			{TeamID: 10, EventID: moved.EventID, NumMatches: 2, OPR: 20},
+		})
+	}
+
+	matchID := database.GetMatchID(&moved, "QUALIFICATION", 2)
+	matches, err := c.db.GetMatchesByEvent(moved.EventID)
+	if c.ok("GetMatchesByEvent", err) {
+		expect(c, "GetMatchesByEvent after MoveEvent", matches, []*database.Match{
+			{MatchID: matchID, EventID: moved.EventID, MatchType: "QUALIFICATION", MatchNumber: 2, TournamentLevel: "QUALIFICATION"},
+		})
+	}
+	score, err := c.db.GetMatchAllianceScore(matchID, database.AllianceRed)
+	if c.ok("GetMatchAllianceScore", err) {
+		expect(c, "GetMatchAllianceScore after MoveEvent", score, &database.MatchAllianceScore{MatchID: matchID, Alliance: database.AllianceRed, TotalPoints: 50})
+	}
+	matchTeams, err := c.db.GetMatchTeams(matchID)
+	if c.ok("GetMatchTeams", err) {
+		expect(c, "GetMatchTeams after MoveEvent", matchTeams, []*database.MatchTeam{{MatchID: matchID, TeamID: 20, Alliance: database.AllianceRed}})
+	}
+	cutoffs, err := c.db.GetAdvancementCutoffs(database.AdvancementCutoffFilter{EventIDs: []string{eventC.EventID, moved.EventID}})
+	if c.ok("GetAdvancementCutoffs", err) && len(cutoffs) != 0 {
+		c.errorf("GetAdvancementCutoffs after MoveEvent: got %d cutoffs, want 0", len(cutoffs))
+	}
+	syncs, err := c.db.GetEventSyncs(database.EventSyncFilter{EventIDs: []string{eventC.EventID, moved.EventID}})
+	if c.ok("GetEventSyncs", err) {
+		expect(c, "GetEventSyncs after MoveEvent", syncs, []*database.EventSync{{EventID: moved.EventID, SyncedAt: day.Add(11 * time.Hour)}})
+	}
+	retries, err := c.db.GetSyncRetries()
+	if c.ok("GetSyncRetries", err) {
+		var eventIDs []string
+		for _, retry := range retries {
+			eventIDs = append(eventIDs, retry.EventID)
+		}
+		expect(c, "GetSyncRetries after MoveEvent", eventIDs, []string{eventA.EventID, moved.EventID})
+	}
+	for _, eventID := range []string{eventC.EventID, moved.EventID} {
+		hashes, err := c.db.GetEndpointHashes(eventID)
+		if c.ok("GetEndpointHashes", err) && len(hashes) != 0 {
+			c.errorf("GetEndpointHashes after MoveEvent: got %d hashes for %s, want 0", len(hashes), eventID)
+		}
+	}
+	keys, err := c.db.GetEventSourceKeys("dbtest")
+	if c.ok("GetEventSourceKeys", err) && len(keys) > 0 {
+		expect(c, "GetEventSourceKeys after MoveEvent", keys[0].EventID, moved.EventID)
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"time"
+	_ "time/tzdata" // Time zones of the events, for systems without a time zone database
 
 	"github.com/rbrabson/ftc"
 )
@@ -25,47 +26,110 @@ type Event struct {
 	Timezone     string    `json:"timezone"`
 	DateStart    time.Time `json:"date_start"`
 	DateEnd      time.Time `json:"date_end"`
+	Latitude     float64   `json:"latitude"`             // Geocoded venue latitude, or 0 if the venue has not been geocoded
+	Longitude    float64   `json:"longitude"`            // Geocoded venue longitude, or 0 if the venue has not been geocoded
+	Unofficial   bool      `json:"unofficial,omitempty"` // Whether the event was registered manually, such as a scrimmage or off-season event
+	UpdatedAt    time.Time `json:"updated_at"`           // Time the record was last created or changed
 }
 
 // EventAward represents an award given to a team at an event. EventID, TeamID, AwardID, and Series together form the primary key.
 type EventAward struct {
-	EventID string `json:"event_id"`
-	TeamID  int    `json:"team_id"`
-	AwardID int    `json:"award_id"`
-	Name    string `json:"name"`   // Award name
-	Series  int    `json:"series"` // Award series number
+	EventID   string    `json:"event_id"`
+	TeamID    int       `json:"team_id"`
+	AwardID   int       `json:"award_id"`
+	Name      string    `json:"name"`             // Award name
+	Series    int       `json:"series"`           // Award series number
+	Person    string    `json:"person,omitempty"` // Name of the person the award was given to, for awards given to a person, such as the Dean's List awards
+	UpdatedAt time.Time `json:"updated_at"`       // Time the record was last created or changed
 }
 
 // EventRanking represents a team's ranking in an event. EventID and TeamID together form the primary key.
 type EventRanking struct {
-	EventID        string  `json:"event_id"`
-	TeamID         int     `json:"team_id"`
-	Rank           int     `json:"rank"`
-	SortOrder1     float64 `json:"sort_order1"`
-	SortOrder2     float64 `json:"sort_order2"`
-	SortOrder3     float64 `json:"sort_order3"`
-	SortOrder4     float64 `json:"sort_order4"`
-	SortOrder5     float64 `json:"sort_order5"`
-	SortOrder6     float64 `json:"sort_order6"`
-	Wins           int     `json:"wins"`
-	Losses         int     `json:"losses"`
-	Ties           int     `json:"ties"`
-	Dq             int     `json:"dq"`
-	MatchesPlayed  int     `json:"matches_played"`
-	MatchesCounted int     `json:"matches_counted"`
+	EventID        string    `json:"event_id"`
+	TeamID         int       `json:"team_id"`
+	Rank           int       `json:"rank"`
+	SortOrder1     float64   `json:"sort_order1"`
+	SortOrder2     float64   `json:"sort_order2"`
+	SortOrder3     float64   `json:"sort_order3"`
+	SortOrder4     float64   `json:"sort_order4"`
+	SortOrder5     float64   `json:"sort_order5"`
+	SortOrder6     float64   `json:"sort_order6"`
+	Wins           int       `json:"wins"`
+	Losses         int       `json:"losses"`
+	Ties           int       `json:"ties"`
+	Dq             int       `json:"dq"`
+	MatchesPlayed  int       `json:"matches_played"`
+	MatchesCounted int       `json:"matches_counted"`
+	Source         string    `json:"source,omitempty"` // Data source the ranking was requested from
+	UpdatedAt      time.Time `json:"updated_at"`       // Time the record was last created or changed
 }
 
 // EventAdvancement represents a team advancing from an event. EventID and TeamID together form the primary key.
 type EventAdvancement struct {
-	EventID string `json:"event_id"`
-	TeamID  int    `json:"team_id"`
-	Status  string `json:"status"`
+	EventID   string    `json:"event_id"`
+	TeamID    int       `json:"team_id"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"` // Time the record was last created or changed
 }
 
-// EventTeam represents a team participating in an event. EventID and TeamID together form the primary key.
+// EventTeam represents a team participating in an event. A team is registered when the FTC API lists it as
+// registered for the event, which is known before the event starts, and has played once it is in one of the event's
+// matches. EventID and TeamID together form the primary key.
 type EventTeam struct {
-	EventID string `json:"event_id"`
-	TeamID  int    `json:"team_id"`
+	EventID    string    `json:"event_id"`
+	TeamID     int       `json:"team_id"`
+	Registered bool      `json:"registered"` // Registered for the event
+	Played     bool      `json:"played"`     // Played in at least one of the event's matches
+	UpdatedAt  time.Time `json:"updated_at"` // Time the record was last created or changed
+}
+
+// EventSummary is a materialized summary of an event's matches and calculated team performance metrics. It is
+// refreshed whenever the team rankings for the event are calculated, so event leaderboards can be built without
+// loading every match. EventID is the primary key.
+type EventSummary struct {
+	EventID        string    `json:"event_id"`
+	QualMatches    int       `json:"qual_matches"`
+	PlayoffMatches int       `json:"playoff_matches"`
+	NumTeams       int       `json:"num_teams"`          // Teams with calculated performance metrics
+	HighScore      int       `json:"high_score"`         // Highest alliance score in any match
+	AverageScore   float64   `json:"average_score"`      // Average alliance score
+	AverageNpScore float64   `json:"average_np_score"`   // Average alliance score without penalty points
+	AverageOPR     float64   `json:"average_opr"`        // Average OPR of the teams at the event
+	AverageNpOPR   float64   `json:"average_np_opr"`     // Average NpOPR of the teams at the event
+	TopOPRTeamID   int       `json:"top_opr_team_id"`    // Team with the highest OPR
+	TopOPR         float64   `json:"top_opr"`            // Highest OPR at the event
+	TopNpOPRTeamID int       `json:"top_np_opr_team_id"` // Team with the highest NpOPR
+	TopNpOPR       float64   `json:"top_np_opr"`         // Highest NpOPR at the event
+	UpdatedAt      time.Time `json:"updated_at"`         // Time the record was last created or changed
+}
+
+// AdvancementCutoff records the advancement points needed to advance from an event: the lowest total points of a
+// team that advanced from it, not counting teams that had already advanced from an earlier event. Cutoffs are
+// calculated once an event's advancements are known, so the cutoffs of past events can show what typically
+// advances. EventID is the primary key.
+type AdvancementCutoff struct {
+	EventID   string `json:"event_id"`
+	Teams     int    `json:"teams"`     // Teams ranked at the event
+	Advancing int    `json:"advancing"` // Teams that advanced, not counting teams that had already advanced
+	Cutoff    int    `json:"cutoff"`    // Lowest total advancement points of a team that advanced
+}
+
+// HasLocation returns true if the event's venue has been geocoded.
+func (e *Event) HasLocation() bool {
+	return e.Latitude != 0 || e.Longitude != 0
+}
+
+// Location returns the event's time zone, which match times are shown in. Events without a time zone, or with
+// one that isn't known, use UTC.
+func (e *Event) Location() *time.Location {
+	if e.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
 
 // String returns a string representation of the Event.
@@ -76,8 +140,8 @@ func (e *Event) String() string {
 
 // String returns a string representation of the EventAward.
 func (ea *EventAward) String() string {
-	return fmt.Sprintf("EventAward{EventID: %q, TeamID: %d, AwardID: %d, Name: %q, Series: %d}",
-		ea.EventID, ea.TeamID, ea.AwardID, ea.Name, ea.Series)
+	return fmt.Sprintf("EventAward{EventID: %q, TeamID: %d, AwardID: %d, Name: %q, Series: %d, Person: %q}",
+		ea.EventID, ea.TeamID, ea.AwardID, ea.Name, ea.Series, ea.Person)
 }
 
 // String returns a string representation of the EventRanking.
@@ -94,8 +158,20 @@ func (ea *EventAdvancement) String() string {
 
 // String returns a string representation of the EventTeam.
 func (et *EventTeam) String() string {
-	return fmt.Sprintf("EventTeam{EventID: %q, TeamID: %d}",
-		et.EventID, et.TeamID)
+	return fmt.Sprintf("EventTeam{EventID: %q, TeamID: %d, Registered: %t, Played: %t}",
+		et.EventID, et.TeamID, et.Registered, et.Played)
+}
+
+// String returns a string representation of the EventSummary.
+func (es *EventSummary) String() string {
+	return fmt.Sprintf("EventSummary{EventID: %q, Matches: %d/%d, Teams: %d, HighScore: %d, AverageNpOPR: %.2f, TopNpOPR: %d (%.2f)}",
+		es.EventID, es.QualMatches, es.PlayoffMatches, es.NumTeams, es.HighScore, es.AverageNpOPR, es.TopNpOPRTeamID, es.TopNpOPR)
+}
+
+// String returns a string representation of the AdvancementCutoff.
+func (ac *AdvancementCutoff) String() string {
+	return fmt.Sprintf("AdvancementCutoff{EventID: %q, Teams: %d, Advancing: %d, Cutoff: %d}",
+		ac.EventID, ac.Teams, ac.Advancing, ac.Cutoff)
 }
 
 // EventFilter defines criteria for filtering events.
@@ -105,6 +181,20 @@ type EventFilter struct {
 	Countries   []string
 	Types       []string
 	Year        int
+	Unofficial  *bool // If set, only unofficial (true) or official (false) events are included
+	Limit       int   // If set, at most this many events are returned
+	Offset      int   // If set, this many events are skipped before any are returned
+}
+
+// EventSummaryFilter defines criteria for filtering event summaries.
+type EventSummaryFilter struct {
+	EventIDs    []string
+	RegionCodes []string
+}
+
+// AdvancementCutoffFilter defines criteria for filtering advancement cutoffs.
+type AdvancementCutoffFilter struct {
+	EventIDs []string
 }
 
 // AdvancementFilter defines criteria for filtering event advancements.
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -47,6 +48,8 @@ type filedb struct {
 	awardsMu            sync.RWMutex
 	teamsMu             sync.RWMutex
 	teamRankingsMu      sync.RWMutex
+	teamSnapshotsMu     sync.RWMutex
+	teamHistoryMu       sync.RWMutex
 	eventsMu            sync.RWMutex
 	eventAwardsMu       sync.RWMutex
 	eventRankingsMu     sync.RWMutex
@@ -55,10 +58,22 @@ type filedb struct {
 	matchesMu           sync.RWMutex
 	matchScoresMu       sync.RWMutex
 	matchTeamsMu        sync.RWMutex
+	syncCheckpointsMu   sync.RWMutex
+	eventSummariesMu    sync.RWMutex
+	eventSourceKeysMu   sync.RWMutex
+	regionAliasesMu     sync.RWMutex
+	cutoffsMu           sync.RWMutex
+	eventSyncsMu        sync.RWMutex
+	endpointHashesMu    sync.RWMutex
+	syncRunsMu          sync.RWMutex
+	syncRetriesMu       sync.RWMutex
+	teamNotesMu         sync.RWMutex
 
 	awards            map[int]*Award
 	teams             map[int]*Team
-	teamRankings      map[string]map[int]*TeamRanking // eventID -> teamID -> ranking
+	teamRankings      map[string]map[int]*TeamRanking   // eventID -> teamID -> ranking
+	teamSnapshots     map[string][]*TeamRankingSnapshot // keyed by snapshot date (YYYY-MM-DD)
+	teamHistory       map[int][]*TeamHistory            // keyed by teamID
 	events            map[string]*Event
 	eventAwards       map[string][]*EventAward       // keyed by eventID
 	eventRankings     map[string][]*EventRanking     // keyed by eventID
@@ -67,12 +82,23 @@ type filedb struct {
 	matches           map[string]*Match
 	matchScores       map[string]map[string]*MatchAllianceScore // matchID -> alliance -> score
 	matchTeams        map[string][]*MatchTeam                   // keyed by matchID
+	syncCheckpoints   map[string]map[string]*SyncCheckpoint     // season -> eventID -> checkpoint
+	eventSummaries    map[string]*EventSummary                  // keyed by eventID
+	eventSourceKeys   map[string]map[string]*EventSourceKey     // source -> source key -> mapping
+	regionAliases     map[string]*RegionAlias                   // keyed by lower-cased alias
+	cutoffs           map[string]*AdvancementCutoff             // keyed by eventID
+	eventSyncs        map[string]*EventSync                     // keyed by eventID
+	endpointHashes    map[string]map[string]*EndpointHash       // eventID -> endpoint -> hash
+	syncRuns          map[string]*SyncRun                       // keyed by runID
+	syncRetries       map[string]*SyncRetry                     // keyed by eventID
+	teamNotes         map[int]map[int]*TeamNote                 // year -> teamID -> note
 }
 
 type fileState struct {
 	exists  bool
 	modTime time.Time
 	size    int64
+	invalid bool // The file wasn't valid JSON when it was reloaded, so its data wasn't loaded
 }
 
 // InitFileDB initializes a file-based database.
@@ -111,6 +137,8 @@ func initFileDB(season ...string) (*filedb, error) {
 		awards:            make(map[int]*Award),
 		teams:             make(map[int]*Team),
 		teamRankings:      make(map[string]map[int]*TeamRanking),
+		teamSnapshots:     make(map[string][]*TeamRankingSnapshot),
+		teamHistory:       make(map[int][]*TeamHistory),
 		events:            make(map[string]*Event),
 		eventAwards:       make(map[string][]*EventAward),
 		eventRankings:     make(map[string][]*EventRanking),
@@ -119,6 +147,16 @@ func initFileDB(season ...string) (*filedb, error) {
 		matches:           make(map[string]*Match),
 		matchScores:       make(map[string]map[string]*MatchAllianceScore),
 		matchTeams:        make(map[string][]*MatchTeam),
+		syncCheckpoints:   make(map[string]map[string]*SyncCheckpoint),
+		eventSummaries:    make(map[string]*EventSummary),
+		eventSourceKeys:   make(map[string]map[string]*EventSourceKey),
+		regionAliases:     make(map[string]*RegionAlias),
+		cutoffs:           make(map[string]*AdvancementCutoff),
+		eventSyncs:        make(map[string]*EventSync),
+		endpointHashes:    make(map[string]map[string]*EndpointHash),
+		syncRuns:          make(map[string]*SyncRun),
+		syncRetries:       make(map[string]*SyncRetry),
+		teamNotes:         make(map[int]map[int]*TeamNote),
 	}
 
 	// Load existing data
@@ -139,6 +177,12 @@ func (db *filedb) refreshAllIfChanged() error {
 	if err := db.refreshTeamRankingsIfChanged(); err != nil {
 		return err
 	}
+	if err := db.refreshTeamSnapshotsIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshTeamHistoryIfChanged(); err != nil {
+		return err
+	}
 	if err := db.refreshEventsIfChanged(); err != nil {
 		return err
 	}
@@ -163,6 +207,36 @@ func (db *filedb) refreshAllIfChanged() error {
 	if err := db.refreshMatchTeamsIfChanged(); err != nil {
 		return err
 	}
+	if err := db.refreshSyncCheckpointsIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshEventSummariesIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshEventSourceKeysIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshRegionAliasesIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshCutoffsIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshEventSyncsIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshEndpointHashesIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshSyncRunsIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshSyncRetriesIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshTeamNotesIfChanged(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -181,6 +255,10 @@ func (db *filedb) loadAll() error {
 	defer db.teamsMu.Unlock()
 	db.teamRankingsMu.Lock()
 	defer db.teamRankingsMu.Unlock()
+	db.teamSnapshotsMu.Lock()
+	defer db.teamSnapshotsMu.Unlock()
+	db.teamHistoryMu.Lock()
+	defer db.teamHistoryMu.Unlock()
 	db.eventsMu.Lock()
 	defer db.eventsMu.Unlock()
 	db.eventAwardsMu.Lock()
@@ -197,6 +275,26 @@ func (db *filedb) loadAll() error {
 	defer db.matchScoresMu.Unlock()
 	db.matchTeamsMu.Lock()
 	defer db.matchTeamsMu.Unlock()
+	db.syncCheckpointsMu.Lock()
+	defer db.syncCheckpointsMu.Unlock()
+	db.eventSummariesMu.Lock()
+	defer db.eventSummariesMu.Unlock()
+	db.eventSourceKeysMu.Lock()
+	defer db.eventSourceKeysMu.Unlock()
+	db.regionAliasesMu.Lock()
+	defer db.regionAliasesMu.Unlock()
+	db.cutoffsMu.Lock()
+	defer db.cutoffsMu.Unlock()
+	db.eventSyncsMu.Lock()
+	defer db.eventSyncsMu.Unlock()
+	db.endpointHashesMu.Lock()
+	defer db.endpointHashesMu.Unlock()
+	db.syncRunsMu.Lock()
+	defer db.syncRunsMu.Unlock()
+	db.syncRetriesMu.Lock()
+	defer db.syncRetriesMu.Unlock()
+	db.teamNotesMu.Lock()
+	defer db.teamNotesMu.Unlock()
 
 	// Load awards
 	if err := db.loadJSONFile("awards.json", &db.awards); err != nil && !os.IsNotExist(err) {
@@ -213,6 +311,16 @@ func (db *filedb) loadAll() error {
 		return err
 	}
 
+	// Load team ranking snapshots
+	if err := db.loadJSONFile("team_ranking_snapshots.json", &db.teamSnapshots); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load team history
+	if err := db.loadJSONFile("team_history.json", &db.teamHistory); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	// Load events
 	if err := db.loadJSONFile("events.json", &db.events); err != nil && !os.IsNotExist(err) {
 		return err
@@ -253,6 +361,70 @@ func (db *filedb) loadAll() error {
 		return err
 	}
 
+	// Load sync checkpoints
+	if err := db.loadJSONFile("sync_checkpoints.json", &db.syncCheckpoints); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load event summaries
+	if err := db.loadJSONFile("event_summary.json", &db.eventSummaries); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load event source keys
+	if err := db.loadJSONFile("event_source_keys.json", &db.eventSourceKeys); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load region aliases
+	if err := db.loadJSONFile("region_aliases.json", &db.regionAliases); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load advancement cutoffs
+	if err := db.loadJSONFile("advancement_cutoffs.json", &db.cutoffs); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load event syncs
+	if err := db.loadJSONFile("event_syncs.json", &db.eventSyncs); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load endpoint hashes
+	if err := db.loadJSONFile("endpoint_hashes.json", &db.endpointHashes); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load sync runs
+	if err := db.loadJSONFile("sync_runs.json", &db.syncRuns); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load sync retries
+	if err := db.loadJSONFile("sync_retries.json", &db.syncRetries); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Load team notes
+	if err := db.loadJSONFile("team_notes.json", &db.teamNotes); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Matches saved before start times had a time zone are moved to their event's time zone and saved again
+	if db.localizeMatchStartTimes() > 0 {
+		if err := db.saveJSONFile("matches.json", db.matches); err != nil {
+			return err
+		}
+	}
+
+	// Event teams saved before registrations were synced were all teams that played at the event
+	if db.markEventTeamsPlayed() > 0 {
+		if err := db.saveJSONFile("event_teams.json", db.eventTeams); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -269,6 +441,10 @@ func (db *filedb) saveAll() error {
 	defer db.teamsMu.RUnlock()
 	db.teamRankingsMu.RLock()
 	defer db.teamRankingsMu.RUnlock()
+	db.teamSnapshotsMu.RLock()
+	defer db.teamSnapshotsMu.RUnlock()
+	db.teamHistoryMu.RLock()
+	defer db.teamHistoryMu.RUnlock()
 	db.eventsMu.RLock()
 	defer db.eventsMu.RUnlock()
 	db.eventAwardsMu.RLock()
@@ -285,6 +461,26 @@ func (db *filedb) saveAll() error {
 	defer db.matchScoresMu.RUnlock()
 	db.matchTeamsMu.RLock()
 	defer db.matchTeamsMu.RUnlock()
+	db.syncCheckpointsMu.RLock()
+	defer db.syncCheckpointsMu.RUnlock()
+	db.eventSummariesMu.RLock()
+	defer db.eventSummariesMu.RUnlock()
+	db.eventSourceKeysMu.RLock()
+	defer db.eventSourceKeysMu.RUnlock()
+	db.regionAliasesMu.RLock()
+	defer db.regionAliasesMu.RUnlock()
+	db.cutoffsMu.RLock()
+	defer db.cutoffsMu.RUnlock()
+	db.eventSyncsMu.RLock()
+	defer db.eventSyncsMu.RUnlock()
+	db.endpointHashesMu.RLock()
+	defer db.endpointHashesMu.RUnlock()
+	db.syncRunsMu.RLock()
+	defer db.syncRunsMu.RUnlock()
+	db.syncRetriesMu.RLock()
+	defer db.syncRetriesMu.RUnlock()
+	db.teamNotesMu.RLock()
+	defer db.teamNotesMu.RUnlock()
 
 	if err := db.saveJSONFile("awards.json", db.awards); err != nil {
 		return err
@@ -298,6 +494,14 @@ func (db *filedb) saveAll() error {
 		return err
 	}
 
+	if err := db.saveJSONFile("team_ranking_snapshots.json", db.teamSnapshots); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("team_history.json", db.teamHistory); err != nil {
+		return err
+	}
+
 	if err := db.saveJSONFile("events.json", db.events); err != nil {
 		return err
 	}
@@ -330,6 +534,46 @@ func (db *filedb) saveAll() error {
 		return err
 	}
 
+	if err := db.saveJSONFile("sync_checkpoints.json", db.syncCheckpoints); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("event_summary.json", db.eventSummaries); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("event_source_keys.json", db.eventSourceKeys); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("region_aliases.json", db.regionAliases); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("advancement_cutoffs.json", db.cutoffs); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("event_syncs.json", db.eventSyncs); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("endpoint_hashes.json", db.endpointHashes); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("sync_runs.json", db.syncRuns); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("sync_retries.json", db.syncRetries); err != nil {
+		return err
+	}
+
+	if err := db.saveJSONFile("team_notes.json", db.teamNotes); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -345,6 +589,14 @@ func (db *filedb) refreshTeamRankingsIfChanged() error {
 	return db.refreshJSONFileIfChanged("team_rankings.json", &db.teamRankingsMu, &db.teamRankings)
 }
 
+func (db *filedb) refreshTeamSnapshotsIfChanged() error {
+	return db.refreshJSONFileIfChanged("team_ranking_snapshots.json", &db.teamSnapshotsMu, &db.teamSnapshots)
+}
+
+func (db *filedb) refreshTeamHistoryIfChanged() error {
+	return db.refreshJSONFileIfChanged("team_history.json", &db.teamHistoryMu, &db.teamHistory)
+}
+
 func (db *filedb) refreshEventsIfChanged() error {
 	return db.refreshJSONFileIfChanged("events.json", &db.eventsMu, &db.events)
 }
@@ -377,6 +629,18 @@ func (db *filedb) refreshMatchTeamsIfChanged() error {
 	return db.refreshJSONFileIfChanged("match_teams.json", &db.matchTeamsMu, &db.matchTeams)
 }
 
+func (db *filedb) refreshSyncCheckpointsIfChanged() error {
+	return db.refreshJSONFileIfChanged("sync_checkpoints.json", &db.syncCheckpointsMu, &db.syncCheckpoints)
+}
+
+func (db *filedb) refreshEventSummariesIfChanged() error {
+	return db.refreshJSONFileIfChanged("event_summary.json", &db.eventSummariesMu, &db.eventSummaries)
+}
+
+func (db *filedb) refreshEventSourceKeysIfChanged() error {
+	return db.refreshJSONFileIfChanged("event_source_keys.json", &db.eventSourceKeysMu, &db.eventSourceKeys)
+}
+
 func (db *filedb) refreshJSONFileIfChanged(filename string, mu *sync.RWMutex, target interface{}) error {
 	changed, err := db.hasFileChanged(filename)
 	if err != nil || !changed {
@@ -391,7 +655,7 @@ func (db *filedb) refreshJSONFileIfChanged(filename string, mu *sync.RWMutex, ta
 		return err
 	}
 
-	if err := db.loadJSONFile(filename, target); err != nil {
+	if err := db.reloadJSONFile(filename, target); err != nil {
 		if !os.IsNotExist(err) {
 			return err
 		}
@@ -404,6 +668,38 @@ func (db *filedb) refreshJSONFileIfChanged(filename string, mu *sync.RWMutex, ta
 	return nil
 }
 
+// reloadJSONFile replaces the target with the data in a JSON file that changed after it was loaded, such as when
+// data synced on another computer is copied into the data directory. The file is decoded into a new value, so
+// records removed from the file are removed from the target as well. If the file isn't valid JSON, such as while it
+// is still being copied, the target keeps its data and the file is reloaded the next time it changes.
+func (db *filedb) reloadJSONFile(filename string, target interface{}) error {
+	// The state is taken before the file is read, so a change made while it is read is reloaded the next time
+	state, err := db.currentFileState(filename)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(db.dataDir, filename)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			db.setKnownFileState(filename, fileState{exists: false})
+		}
+		return err
+	}
+
+	v := reflect.New(reflect.TypeOf(target).Elem())
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		slog.Warn("Keeping data from before the file changed, since it isn't valid JSON", "file", path, "error", err)
+		state.invalid = true
+		db.setKnownFileState(filename, state)
+		return nil
+	}
+	reflect.ValueOf(target).Elem().Set(v.Elem())
+	db.setKnownFileState(filename, state)
+
+	return nil
+}
+
 func (db *filedb) hasFileChanged(filename string) (bool, error) {
 	known, ok := db.getKnownFileState(filename)
 	if !ok {
@@ -524,3 +820,31 @@ func (db *filedb) saveJSONFile(filename string, v interface{}) error {
 
 	return nil
 }
+
+func (db *filedb) refreshRegionAliasesIfChanged() error {
+	return db.refreshJSONFileIfChanged("region_aliases.json", &db.regionAliasesMu, &db.regionAliases)
+}
+
+func (db *filedb) refreshCutoffsIfChanged() error {
+	return db.refreshJSONFileIfChanged("advancement_cutoffs.json", &db.cutoffsMu, &db.cutoffs)
+}
+
+func (db *filedb) refreshEventSyncsIfChanged() error {
+	return db.refreshJSONFileIfChanged("event_syncs.json", &db.eventSyncsMu, &db.eventSyncs)
+}
+
+func (db *filedb) refreshEndpointHashesIfChanged() error {
+	return db.refreshJSONFileIfChanged("endpoint_hashes.json", &db.endpointHashesMu, &db.endpointHashes)
+}
+
+func (db *filedb) refreshSyncRunsIfChanged() error {
+	return db.refreshJSONFileIfChanged("sync_runs.json", &db.syncRunsMu, &db.syncRuns)
+}
+
+func (db *filedb) refreshSyncRetriesIfChanged() error {
+	return db.refreshJSONFileIfChanged("sync_retries.json", &db.syncRetriesMu, &db.syncRetries)
+}
+
+func (db *filedb) refreshTeamNotesIfChanged() error {
+	return db.refreshJSONFileIfChanged("team_notes.json", &db.teamNotesMu, &db.teamNotes)
+}
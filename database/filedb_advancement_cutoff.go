@@ -0,0 +1,53 @@
+package database
+
+import (
+	"slices"
+	"sort"
+)
+
+// GetAdvancementCutoffs retrieves advancement cutoffs with optional filters.
+// If no filters are provided, returns all advancement cutoffs.
+func (db *filedb) GetAdvancementCutoffs(filters ...AdvancementCutoffFilter) ([]*AdvancementCutoff, error) {
+	if err := db.refreshCutoffsIfChanged(); err != nil {
+		return nil, err
+	}
+
+	var filter AdvancementCutoffFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+
+	db.cutoffsMu.RLock()
+	defer db.cutoffsMu.RUnlock()
+
+	var cutoffs []*AdvancementCutoff
+	for eventID, cutoff := range db.cutoffs {
+		if len(filter.EventIDs) > 0 && !slices.Contains(filter.EventIDs, eventID) {
+			continue
+		}
+		cutoffCopy := *cutoff
+		cutoffs = append(cutoffs, &cutoffCopy)
+	}
+
+	// Sort by EventID
+	sort.Slice(cutoffs, func(i, j int) bool {
+		return cutoffs[i].EventID < cutoffs[j].EventID
+	})
+
+	return cutoffs, nil
+}
+
+// SaveAdvancementCutoff saves the advancement cutoff of an event, replacing any earlier cutoff for the event.
+func (db *filedb) SaveAdvancementCutoff(cutoff *AdvancementCutoff) error {
+	if err := db.refreshCutoffsIfChanged(); err != nil {
+		return err
+	}
+
+	db.cutoffsMu.Lock()
+	defer db.cutoffsMu.Unlock()
+
+	cutoffCopy := *cutoff
+	db.cutoffs[cutoff.EventID] = &cutoffCopy
+
+	return db.saveJSONFile("advancement_cutoffs.json", db.cutoffs)
+}
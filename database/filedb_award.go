@@ -1,5 +1,7 @@
 package database
 
+import "time"
+
 // GetAward retrieves an award from the file database by its ID.
 func (db *filedb) GetAward(awardID int) (*Award, error) {
 	if err := db.refreshAwardsIfChanged(); err != nil {
@@ -32,6 +34,7 @@ func (db *filedb) GetAllAwards() ([]*Award, error) {
 		awardCopy := *award
 		awards = append(awards, &awardCopy)
 	}
+	sortAwards(awards)
 	return awards, nil
 }
 
@@ -46,6 +49,7 @@ func (db *filedb) SaveAward(award *Award) error {
 
 	// Make a copy to avoid external modifications
 	awardCopy := *award
+	setUpdatedAt(&awardCopy, db.awards[award.AwardID], func(a *Award) *time.Time { return &a.UpdatedAt })
 	db.awards[award.AwardID] = &awardCopy
 
 	// Persist to disk
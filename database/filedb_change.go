@@ -0,0 +1,351 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// setUpdatedAt sets the UpdatedAt time on a record that is being saved. If the record is unchanged from
+// the existing record, the existing time is kept so unchanged records are not reported by GetChanges.
+// Records are compared using their JSON encoding, which is how they are persisted, so time values that
+// were reloaded from disk compare equal to the values they were saved from.
+func setUpdatedAt[T any](record *T, existing *T, updatedAt func(*T) *time.Time) {
+	if existing != nil {
+		unchanged := *existing
+		*updatedAt(&unchanged) = *updatedAt(record)
+		existingJSON, err1 := json.Marshal(&unchanged)
+		recordJSON, err2 := json.Marshal(record)
+		if err1 == nil && err2 == nil && bytes.Equal(existingJSON, recordJSON) {
+			*updatedAt(record) = *updatedAt(existing)
+			return
+		}
+	}
+	*updatedAt(record) = time.Now().UTC()
+}
+
+// GetChanges retrieves all records that were created or changed after the given time.
+func (db *filedb) GetChanges(since time.Time) (*ChangeSet, error) {
+	if err := db.refreshAllIfChanged(); err != nil {
+		return nil, err
+	}
+
+	changes := &ChangeSet{Since: since}
+
+	db.awardsMu.RLock()
+	for _, award := range db.awards {
+		if award.UpdatedAt.After(since) {
+			awardCopy := *award
+			changes.Awards = append(changes.Awards, &awardCopy)
+		}
+	}
+	db.awardsMu.RUnlock()
+
+	db.teamsMu.RLock()
+	for _, team := range db.teams {
+		if team.UpdatedAt.After(since) {
+			teamCopy := *team
+			changes.Teams = append(changes.Teams, &teamCopy)
+		}
+	}
+	db.teamsMu.RUnlock()
+
+	db.teamRankingsMu.RLock()
+	for _, eventRankings := range db.teamRankings {
+		for _, ranking := range eventRankings {
+			if ranking.UpdatedAt.After(since) {
+				rankingCopy := *ranking
+				changes.TeamRankings = append(changes.TeamRankings, &rankingCopy)
+			}
+		}
+	}
+	db.teamRankingsMu.RUnlock()
+
+	db.eventsMu.RLock()
+	for _, event := range db.events {
+		if event.UpdatedAt.After(since) {
+			eventCopy := *event
+			changes.Events = append(changes.Events, &eventCopy)
+		}
+	}
+	db.eventsMu.RUnlock()
+
+	db.eventAwardsMu.RLock()
+	for _, awards := range db.eventAwards {
+		for _, award := range awards {
+			if award.UpdatedAt.After(since) {
+				awardCopy := *award
+				changes.EventAwards = append(changes.EventAwards, &awardCopy)
+			}
+		}
+	}
+	db.eventAwardsMu.RUnlock()
+
+	db.eventRankingsMu.RLock()
+	for _, rankings := range db.eventRankings {
+		for _, ranking := range rankings {
+			if ranking.UpdatedAt.After(since) {
+				rankingCopy := *ranking
+				changes.EventRankings = append(changes.EventRankings, &rankingCopy)
+			}
+		}
+	}
+	db.eventRankingsMu.RUnlock()
+
+	db.eventAdvancementsMu.RLock()
+	for _, advancements := range db.eventAdvancements {
+		for _, advancement := range advancements {
+			if advancement.UpdatedAt.After(since) {
+				advancementCopy := *advancement
+				changes.EventAdvancements = append(changes.EventAdvancements, &advancementCopy)
+			}
+		}
+	}
+	db.eventAdvancementsMu.RUnlock()
+
+	db.eventTeamsMu.RLock()
+	for _, teams := range db.eventTeams {
+		for _, team := range teams {
+			if team.UpdatedAt.After(since) {
+				teamCopy := *team
+				changes.EventTeams = append(changes.EventTeams, &teamCopy)
+			}
+		}
+	}
+	db.eventTeamsMu.RUnlock()
+
+	db.matchesMu.RLock()
+	for _, match := range db.matches {
+		if match.UpdatedAt.After(since) {
+			matchCopy := *match
+			changes.Matches = append(changes.Matches, &matchCopy)
+		}
+	}
+	db.matchesMu.RUnlock()
+
+	db.matchScoresMu.RLock()
+	for _, scores := range db.matchScores {
+		for _, score := range scores {
+			if score.UpdatedAt.After(since) {
+				scoreCopy := *score
+				changes.MatchAllianceScores = append(changes.MatchAllianceScores, &scoreCopy)
+			}
+		}
+	}
+	db.matchScoresMu.RUnlock()
+
+	db.matchTeamsMu.RLock()
+	for _, teams := range db.matchTeams {
+		for _, team := range teams {
+			if team.UpdatedAt.After(since) {
+				teamCopy := *team
+				changes.MatchTeams = append(changes.MatchTeams, &teamCopy)
+			}
+		}
+	}
+	db.matchTeamsMu.RUnlock()
+
+	// Sort each set of changes so the order matches the SQL database
+	sort.Slice(changes.Awards, func(i, j int) bool {
+		return changes.Awards[i].AwardID < changes.Awards[j].AwardID
+	})
+	sort.Slice(changes.Teams, func(i, j int) bool {
+		return changes.Teams[i].TeamID < changes.Teams[j].TeamID
+	})
+	sort.Slice(changes.TeamRankings, func(i, j int) bool {
+		if changes.TeamRankings[i].EventID != changes.TeamRankings[j].EventID {
+			return changes.TeamRankings[i].EventID < changes.TeamRankings[j].EventID
+		}
+		return changes.TeamRankings[i].TeamID < changes.TeamRankings[j].TeamID
+	})
+	sort.Slice(changes.Events, func(i, j int) bool {
+		return changes.Events[i].EventID < changes.Events[j].EventID
+	})
+	sort.Slice(changes.EventAwards, func(i, j int) bool {
+		if changes.EventAwards[i].EventID != changes.EventAwards[j].EventID {
+			return changes.EventAwards[i].EventID < changes.EventAwards[j].EventID
+		}
+		return changes.EventAwards[i].TeamID < changes.EventAwards[j].TeamID
+	})
+	sort.Slice(changes.EventRankings, func(i, j int) bool {
+		if changes.EventRankings[i].EventID != changes.EventRankings[j].EventID {
+			return changes.EventRankings[i].EventID < changes.EventRankings[j].EventID
+		}
+		return changes.EventRankings[i].TeamID < changes.EventRankings[j].TeamID
+	})
+	sort.Slice(changes.EventAdvancements, func(i, j int) bool {
+		if changes.EventAdvancements[i].EventID != changes.EventAdvancements[j].EventID {
+			return changes.EventAdvancements[i].EventID < changes.EventAdvancements[j].EventID
+		}
+		return changes.EventAdvancements[i].TeamID < changes.EventAdvancements[j].TeamID
+	})
+	sort.Slice(changes.EventTeams, func(i, j int) bool {
+		if changes.EventTeams[i].EventID != changes.EventTeams[j].EventID {
+			return changes.EventTeams[i].EventID < changes.EventTeams[j].EventID
+		}
+		return changes.EventTeams[i].TeamID < changes.EventTeams[j].TeamID
+	})
+	sort.Slice(changes.Matches, func(i, j int) bool {
+		return changes.Matches[i].MatchID < changes.Matches[j].MatchID
+	})
+	sort.Slice(changes.MatchAllianceScores, func(i, j int) bool {
+		if changes.MatchAllianceScores[i].MatchID != changes.MatchAllianceScores[j].MatchID {
+			return changes.MatchAllianceScores[i].MatchID < changes.MatchAllianceScores[j].MatchID
+		}
+		return changes.MatchAllianceScores[i].Alliance < changes.MatchAllianceScores[j].Alliance
+	})
+	sort.Slice(changes.MatchTeams, func(i, j int) bool {
+		if changes.MatchTeams[i].MatchID != changes.MatchTeams[j].MatchID {
+			return changes.MatchTeams[i].MatchID < changes.MatchTeams[j].MatchID
+		}
+		return changes.MatchTeams[i].TeamID < changes.MatchTeams[j].TeamID
+	})
+
+	return changes, nil
+}
+
+// GetLastUpdated returns the latest time any record was created or changed, or the zero time if there are no
+// records. If the filter lists event IDs, only the records of those events are considered, along with the teams
+// and award definitions.
+func (db *filedb) GetLastUpdated(filters ...LastUpdatedFilter) (time.Time, error) {
+	if err := db.refreshAllIfChanged(); err != nil {
+		return time.Time{}, err
+	}
+
+	var eventIDs map[string]bool
+	if len(filters) > 0 && len(filters[0].EventIDs) > 0 {
+		eventIDs = make(map[string]bool, len(filters[0].EventIDs))
+		for _, eventID := range filters[0].EventIDs {
+			eventIDs[eventID] = true
+		}
+	}
+	included := func(eventID string) bool {
+		return eventIDs == nil || eventIDs[eventID]
+	}
+
+	var latest time.Time
+	update := func(t time.Time) {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	db.awardsMu.RLock()
+	for _, award := range db.awards {
+		update(award.UpdatedAt)
+	}
+	db.awardsMu.RUnlock()
+
+	db.teamsMu.RLock()
+	for _, team := range db.teams {
+		update(team.UpdatedAt)
+	}
+	db.teamsMu.RUnlock()
+
+	db.teamRankingsMu.RLock()
+	for eventID, eventRankings := range db.teamRankings {
+		if !included(eventID) {
+			continue
+		}
+		for _, ranking := range eventRankings {
+			update(ranking.UpdatedAt)
+		}
+	}
+	db.teamRankingsMu.RUnlock()
+
+	db.eventsMu.RLock()
+	for eventID, event := range db.events {
+		if included(eventID) {
+			update(event.UpdatedAt)
+		}
+	}
+	db.eventsMu.RUnlock()
+
+	db.eventAwardsMu.RLock()
+	for eventID, awards := range db.eventAwards {
+		if !included(eventID) {
+			continue
+		}
+		for _, award := range awards {
+			update(award.UpdatedAt)
+		}
+	}
+	db.eventAwardsMu.RUnlock()
+
+	db.eventRankingsMu.RLock()
+	for eventID, rankings := range db.eventRankings {
+		if !included(eventID) {
+			continue
+		}
+		for _, ranking := range rankings {
+			update(ranking.UpdatedAt)
+		}
+	}
+	db.eventRankingsMu.RUnlock()
+
+	db.eventAdvancementsMu.RLock()
+	for eventID, advancements := range db.eventAdvancements {
+		if !included(eventID) {
+			continue
+		}
+		for _, advancement := range advancements {
+			update(advancement.UpdatedAt)
+		}
+	}
+	db.eventAdvancementsMu.RUnlock()
+
+	db.eventTeamsMu.RLock()
+	for eventID, teams := range db.eventTeams {
+		if !included(eventID) {
+			continue
+		}
+		for _, team := range teams {
+			update(team.UpdatedAt)
+		}
+	}
+	db.eventTeamsMu.RUnlock()
+
+	db.eventSummariesMu.RLock()
+	for eventID, summary := range db.eventSummaries {
+		if included(eventID) {
+			update(summary.UpdatedAt)
+		}
+	}
+	db.eventSummariesMu.RUnlock()
+
+	// Alliance scores and match teams are keyed by match, so the matches give their events
+	matchIDs := make(map[string]bool)
+	db.matchesMu.RLock()
+	for matchID, match := range db.matches {
+		if included(match.EventID) {
+			matchIDs[matchID] = true
+			update(match.UpdatedAt)
+		}
+	}
+	db.matchesMu.RUnlock()
+
+	db.matchScoresMu.RLock()
+	for matchID, scores := range db.matchScores {
+		if !matchIDs[matchID] {
+			continue
+		}
+		for _, score := range scores {
+			update(score.UpdatedAt)
+		}
+	}
+	db.matchScoresMu.RUnlock()
+
+	db.matchTeamsMu.RLock()
+	for matchID, teams := range db.matchTeams {
+		if !matchIDs[matchID] {
+			continue
+		}
+		for _, team := range teams {
+			update(team.UpdatedAt)
+		}
+	}
+	db.matchTeamsMu.RUnlock()
+
+	return latest, nil
+}
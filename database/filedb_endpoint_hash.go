@@ -0,0 +1,44 @@
+package database
+
+import "sort"
+
+// GetEndpointHashes retrieves the hashes of the last responses from the data source's endpoints for an event.
+func (db *filedb) GetEndpointHashes(eventID string) ([]*EndpointHash, error) {
+	if err := db.refreshEndpointHashesIfChanged(); err != nil {
+		return nil, err
+	}
+
+	db.endpointHashesMu.RLock()
+	defer db.endpointHashesMu.RUnlock()
+
+	hashes := make([]*EndpointHash, 0, len(db.endpointHashes[eventID]))
+	for _, hash := range db.endpointHashes[eventID] {
+		hashCopy := *hash
+		hashes = append(hashes, &hashCopy)
+	}
+
+	// Sort by Endpoint
+	sort.Slice(hashes, func(i, j int) bool {
+		return hashes[i].Endpoint < hashes[j].Endpoint
+	})
+
+	return hashes, nil
+}
+
+// SaveEndpointHash records the hash of the last response from an endpoint for an event, replacing any earlier hash.
+func (db *filedb) SaveEndpointHash(hash *EndpointHash) error {
+	if err := db.refreshEndpointHashesIfChanged(); err != nil {
+		return err
+	}
+
+	db.endpointHashesMu.Lock()
+	defer db.endpointHashesMu.Unlock()
+
+	if db.endpointHashes[hash.EventID] == nil {
+		db.endpointHashes[hash.EventID] = make(map[string]*EndpointHash)
+	}
+	hashCopy := *hash
+	db.endpointHashes[hash.EventID][hash.Endpoint] = &hashCopy
+
+	return db.saveJSONFile("endpoint_hashes.json", db.endpointHashes)
+}
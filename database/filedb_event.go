@@ -3,6 +3,7 @@ package database
 import (
 	"slices"
 	"sort"
+	"time"
 )
 
 // GetEvent retrieves an event from the file database by its ID.
@@ -41,6 +42,7 @@ func (db *filedb) GetAllEvents(filters ...EventFilter) ([]*Event, error) {
 			eventCopy := *event
 			events = append(events, &eventCopy)
 		}
+		sortEvents(events)
 		return events, nil
 	}
 
@@ -79,6 +81,13 @@ func (db *filedb) GetAllEvents(filters ...EventFilter) ([]*Event, error) {
 			}
 		}
 
+		// Check Unofficial filter
+		if matchesFilter && filter.Unofficial != nil {
+			if event.Unofficial != *filter.Unofficial {
+				matchesFilter = false
+			}
+		}
+
 		// Check Type filter (OR within field)
 		if matchesFilter && len(filter.Types) > 0 {
 			if !slices.Contains(filter.Types, event.Type) {
@@ -92,7 +101,8 @@ func (db *filedb) GetAllEvents(filters ...EventFilter) ([]*Event, error) {
 		}
 	}
 
-	return events, nil
+	sortEvents(events)
+	return page(events, filter.Limit, filter.Offset), nil
 }
 
 // SaveEvent saves or updates an event in the file database.
@@ -106,6 +116,7 @@ func (db *filedb) SaveEvent(event *Event) error {
 
 	// Make a copy to avoid external modifications
 	eventCopy := *event
+	setUpdatedAt(&eventCopy, db.events[event.EventID], func(e *Event) *time.Time { return &e.UpdatedAt })
 	db.events[event.EventID] = &eventCopy
 
 	// Persist to disk
@@ -132,6 +143,7 @@ func (db *filedb) GetEventAwards(eventID string) ([]*EventAward, error) {
 		awardCopy := *award
 		result[i] = &awardCopy
 	}
+	sortEventAwards(result)
 	return result, nil
 }
 
@@ -151,6 +163,7 @@ func (db *filedb) SaveEventAward(ea *EventAward) error {
 		if existing.TeamID == ea.TeamID && existing.AwardID == ea.AwardID && existing.Series == ea.Series {
 			// Update existing
 			eaCopy := *ea
+			setUpdatedAt(&eaCopy, existing, func(a *EventAward) *time.Time { return &a.UpdatedAt })
 			awards[i] = &eaCopy
 			found = true
 			break
@@ -160,6 +173,7 @@ func (db *filedb) SaveEventAward(ea *EventAward) error {
 	if !found {
 		// Add new
 		eaCopy := *ea
+		setUpdatedAt(&eaCopy, nil, func(a *EventAward) *time.Time { return &a.UpdatedAt })
 		db.eventAwards[ea.EventID] = append(awards, &eaCopy)
 	}
 
@@ -167,6 +181,27 @@ func (db *filedb) SaveEventAward(ea *EventAward) error {
 	return db.saveJSONFile("event_awards.json", db.eventAwards)
 }
 
+// DeleteEventAward deletes an award given at an event from the file database.
+func (db *filedb) DeleteEventAward(ea *EventAward) error {
+	eventID := ea.EventID
+	if err := db.refreshEventAwardsIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventAwardsMu.Lock()
+	defer db.eventAwardsMu.Unlock()
+
+	db.eventAwards[eventID] = slices.DeleteFunc(db.eventAwards[eventID], func(r *EventAward) bool {
+		return r.TeamID == ea.TeamID && r.AwardID == ea.AwardID && r.Series == ea.Series
+	})
+	if len(db.eventAwards[eventID]) == 0 {
+		delete(db.eventAwards, eventID)
+	}
+
+	// Persist to disk
+	return db.saveJSONFile("event_awards.json", db.eventAwards)
+}
+
 // GetTeamAwardsByEvent retrieves all awards for a specific team at a specific event.
 func (db *filedb) GetTeamAwardsByEvent(eventID string, teamID int) ([]*EventAward, error) {
 	if err := db.refreshEventAwardsIfChanged(); err != nil {
@@ -188,6 +223,7 @@ func (db *filedb) GetTeamAwardsByEvent(eventID string, teamID int) ([]*EventAwar
 			result = append(result, &awardCopy)
 		}
 	}
+	sortEventAwards(result)
 	return result, nil
 }
 
@@ -209,6 +245,7 @@ func (db *filedb) GetAllTeamAwards(teamID int) ([]*EventAward, error) {
 			}
 		}
 	}
+	sortEventAwards(result)
 	return result, nil
 }
 
@@ -232,6 +269,7 @@ func (db *filedb) GetEventRankings(eventID string) ([]*EventRanking, error) {
 		rankingCopy := *ranking
 		result[i] = &rankingCopy
 	}
+	sortEventRankings(result)
 	return result, nil
 }
 
@@ -251,6 +289,7 @@ func (db *filedb) SaveEventRanking(er *EventRanking) error {
 		if existing.TeamID == er.TeamID {
 			// Update existing
 			erCopy := *er
+			setUpdatedAt(&erCopy, existing, func(r *EventRanking) *time.Time { return &r.UpdatedAt })
 			rankings[i] = &erCopy
 			found = true
 			break
@@ -260,6 +299,7 @@ func (db *filedb) SaveEventRanking(er *EventRanking) error {
 	if !found {
 		// Add new
 		erCopy := *er
+		setUpdatedAt(&erCopy, nil, func(r *EventRanking) *time.Time { return &r.UpdatedAt })
 		db.eventRankings[er.EventID] = append(rankings, &erCopy)
 	}
 
@@ -267,6 +307,26 @@ func (db *filedb) SaveEventRanking(er *EventRanking) error {
 	return db.saveJSONFile("event_rankings.json", db.eventRankings)
 }
 
+// DeleteEventRanking deletes the ranking of a team at an event from the file database.
+func (db *filedb) DeleteEventRanking(eventID string, teamID int) error {
+	if err := db.refreshEventRankingsIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventRankingsMu.Lock()
+	defer db.eventRankingsMu.Unlock()
+
+	db.eventRankings[eventID] = slices.DeleteFunc(db.eventRankings[eventID], func(r *EventRanking) bool {
+		return r.TeamID == teamID
+	})
+	if len(db.eventRankings[eventID]) == 0 {
+		delete(db.eventRankings, eventID)
+	}
+
+	// Persist to disk
+	return db.saveJSONFile("event_rankings.json", db.eventRankings)
+}
+
 // GetEventAdvancements retrieves all team advancements for a specific event.
 func (db *filedb) GetEventAdvancements(eventID string) ([]*EventAdvancement, error) {
 	if err := db.refreshEventAdvancementsIfChanged(); err != nil {
@@ -287,6 +347,7 @@ func (db *filedb) GetEventAdvancements(eventID string) ([]*EventAdvancement, err
 		advancementCopy := *advancement
 		result[i] = &advancementCopy
 	}
+	sortEventAdvancements(result)
 	return result, nil
 }
 
@@ -306,6 +367,7 @@ func (db *filedb) SaveEventAdvancement(ea *EventAdvancement) error {
 		if existing.TeamID == ea.TeamID {
 			// Update existing
 			eaCopy := *ea
+			setUpdatedAt(&eaCopy, existing, func(a *EventAdvancement) *time.Time { return &a.UpdatedAt })
 			advancements[i] = &eaCopy
 			found = true
 			break
@@ -315,6 +377,7 @@ func (db *filedb) SaveEventAdvancement(ea *EventAdvancement) error {
 	if !found {
 		// Add new
 		eaCopy := *ea
+		setUpdatedAt(&eaCopy, nil, func(a *EventAdvancement) *time.Time { return &a.UpdatedAt })
 		db.eventAdvancements[ea.EventID] = append(advancements, &eaCopy)
 	}
 
@@ -322,6 +385,26 @@ func (db *filedb) SaveEventAdvancement(ea *EventAdvancement) error {
 	return db.saveJSONFile("event_advancements.json", db.eventAdvancements)
 }
 
+// DeleteEventAdvancement deletes the advancement of a team from an event from the file database.
+func (db *filedb) DeleteEventAdvancement(eventID string, teamID int) error {
+	if err := db.refreshEventAdvancementsIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventAdvancementsMu.Lock()
+	defer db.eventAdvancementsMu.Unlock()
+
+	db.eventAdvancements[eventID] = slices.DeleteFunc(db.eventAdvancements[eventID], func(r *EventAdvancement) bool {
+		return r.TeamID == teamID
+	})
+	if len(db.eventAdvancements[eventID]) == 0 {
+		delete(db.eventAdvancements, eventID)
+	}
+
+	// Persist to disk
+	return db.saveJSONFile("event_advancements.json", db.eventAdvancements)
+}
+
 // GetRegionCodes retrieves all unique region codes from events.
 func (db *filedb) GetRegionCodes() ([]string, error) {
 	if err := db.refreshEventsIfChanged(); err != nil {
@@ -395,6 +478,7 @@ func (db *filedb) GetAdvancementsByRegion(regionCode string) ([]*EventAdvancemen
 			}
 		}
 	}
+	sortEventAdvancements(result)
 	return result, nil
 }
 
@@ -424,6 +508,7 @@ func (db *filedb) GetAllAdvancements(filters ...AdvancementFilter) ([]*EventAdva
 				result = append(result, &advancementCopy)
 			}
 		}
+		sortEventAdvancements(result)
 		return result, nil
 	}
 
@@ -493,6 +578,7 @@ func (db *filedb) GetAllAdvancements(filters ...AdvancementFilter) ([]*EventAdva
 		}
 	}
 
+	sortEventAdvancements(result)
 	return result, nil
 }
 
@@ -516,6 +602,7 @@ func (db *filedb) GetEventTeams(eventID string) ([]*EventTeam, error) {
 		teamCopy := *team
 		result[i] = &teamCopy
 	}
+	sortEventTeams(result)
 	return result, nil
 }
 
@@ -535,6 +622,7 @@ func (db *filedb) SaveEventTeam(et *EventTeam) error {
 		if existing.TeamID == et.TeamID {
 			// Update existing
 			etCopy := *et
+			setUpdatedAt(&etCopy, existing, func(t *EventTeam) *time.Time { return &t.UpdatedAt })
 			teams[i] = &etCopy
 			found = true
 			break
@@ -544,6 +632,7 @@ func (db *filedb) SaveEventTeam(et *EventTeam) error {
 	if !found {
 		// Add new
 		etCopy := *et
+		setUpdatedAt(&etCopy, nil, func(t *EventTeam) *time.Time { return &t.UpdatedAt })
 		db.eventTeams[et.EventID] = append(teams, &etCopy)
 	}
 
@@ -551,6 +640,42 @@ func (db *filedb) SaveEventTeam(et *EventTeam) error {
 	return db.saveJSONFile("event_teams.json", db.eventTeams)
 }
 
+// DeleteEventTeam deletes a team from an event in the file database.
+func (db *filedb) DeleteEventTeam(eventID string, teamID int) error {
+	if err := db.refreshEventTeamsIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventTeamsMu.Lock()
+	defer db.eventTeamsMu.Unlock()
+
+	db.eventTeams[eventID] = slices.DeleteFunc(db.eventTeams[eventID], func(r *EventTeam) bool {
+		return r.TeamID == teamID
+	})
+	if len(db.eventTeams[eventID]) == 0 {
+		delete(db.eventTeams, eventID)
+	}
+
+	// Persist to disk
+	return db.saveJSONFile("event_teams.json", db.eventTeams)
+}
+
+// markEventTeamsPlayed marks the event teams that are neither registered nor played, which were saved before
+// registrations were synced, as having played, and returns the number of event teams that were changed. The caller
+// must hold the event teams lock.
+func (db *filedb) markEventTeamsPlayed() int {
+	var changed int
+	for _, teams := range db.eventTeams {
+		for _, team := range teams {
+			if !team.Registered && !team.Played {
+				team.Played = true
+				changed++
+			}
+		}
+	}
+	return changed
+}
+
 // GetEventsByTeam retrieves all event IDs that a team has or will participate in.
 func (db *filedb) GetEventsByTeam(teamID int) ([]string, error) {
 	if err := db.refreshEventTeamsIfChanged(); err != nil {
@@ -569,5 +694,6 @@ func (db *filedb) GetEventsByTeam(teamID int) ([]string, error) {
 			}
 		}
 	}
+	sort.Strings(eventIDs)
 	return eventIDs, nil
 }
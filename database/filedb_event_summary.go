@@ -0,0 +1,131 @@
+package database
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GetEventSummaries retrieves event summaries with optional filters.
+// Filters support filtering by EventID and/or RegionCode.
+// If no filters are provided, returns all event summaries.
+func (db *filedb) GetEventSummaries(filters ...EventSummaryFilter) ([]*EventSummary, error) {
+	if err := db.refreshEventSummariesIfChanged(); err != nil {
+		return nil, err
+	}
+
+	// Look up the region of each event if filtering by region
+	var filter EventSummaryFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+	var regionCodes map[string]string
+	if len(filter.RegionCodes) > 0 {
+		if err := db.refreshEventsIfChanged(); err != nil {
+			return nil, err
+		}
+		db.eventsMu.RLock()
+		regionCodes = make(map[string]string, len(db.events))
+		for eventID, event := range db.events {
+			regionCodes[eventID] = event.RegionCode
+		}
+		db.eventsMu.RUnlock()
+	}
+
+	db.eventSummariesMu.RLock()
+	defer db.eventSummariesMu.RUnlock()
+
+	var summaries []*EventSummary
+	for eventID, summary := range db.eventSummaries {
+		if len(filter.EventIDs) > 0 && !slices.Contains(filter.EventIDs, eventID) {
+			continue
+		}
+		if regionCodes != nil && !slices.Contains(filter.RegionCodes, regionCodes[eventID]) {
+			continue
+		}
+		summaryCopy := *summary
+		summaries = append(summaries, &summaryCopy)
+	}
+
+	// Sort by EventID
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].EventID < summaries[j].EventID
+	})
+
+	return summaries, nil
+}
+
+// RefreshEventSummary recalculates the summary for an event from its matches and team rankings.
+func (db *filedb) RefreshEventSummary(eventID string) error {
+	summary := &EventSummary{EventID: eventID}
+
+	// Summarize the matches and their alliance scores
+	matches, err := db.GetMatchesByEvent(eventID)
+	if err != nil {
+		return err
+	}
+	var totalScore, totalNpScore, scoreCount int
+	for _, match := range matches {
+		if strings.EqualFold(match.TournamentLevel, "playoff") {
+			summary.PlayoffMatches++
+		} else {
+			summary.QualMatches++
+		}
+
+		for _, alliance := range []string{AllianceRed, AllianceBlue} {
+			score, err := db.GetMatchAllianceScore(match.MatchID, alliance)
+			if err != nil {
+				return err
+			}
+			if score == nil {
+				continue
+			}
+			totalScore += score.TotalPoints
+			totalNpScore += score.TotalPoints - score.FoulPointsCommitted
+			scoreCount++
+			summary.HighScore = max(summary.HighScore, score.TotalPoints)
+		}
+	}
+	if scoreCount > 0 {
+		summary.AverageScore = float64(totalScore) / float64(scoreCount)
+		summary.AverageNpScore = float64(totalNpScore) / float64(scoreCount)
+	}
+
+	// Summarize the team performance metrics. Rankings are sorted by team, so ties go to the lowest team number.
+	rankings, err := db.GetTeamRankings(TeamRankingFilter{EventIDs: []string{eventID}})
+	if err != nil {
+		return err
+	}
+	var totalOPR, totalNpOPR float64
+	for i, ranking := range rankings {
+		totalOPR += ranking.OPR
+		totalNpOPR += ranking.NpOPR
+		if i == 0 || ranking.OPR > summary.TopOPR {
+			summary.TopOPRTeamID = ranking.TeamID
+			summary.TopOPR = ranking.OPR
+		}
+		if i == 0 || ranking.NpOPR > summary.TopNpOPR {
+			summary.TopNpOPRTeamID = ranking.TeamID
+			summary.TopNpOPR = ranking.NpOPR
+		}
+	}
+	summary.NumTeams = len(rankings)
+	if len(rankings) > 0 {
+		summary.AverageOPR = totalOPR / float64(len(rankings))
+		summary.AverageNpOPR = totalNpOPR / float64(len(rankings))
+	}
+
+	if err := db.refreshEventSummariesIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventSummariesMu.Lock()
+	defer db.eventSummariesMu.Unlock()
+
+	setUpdatedAt(summary, db.eventSummaries[eventID], func(es *EventSummary) *time.Time { return &es.UpdatedAt })
+	db.eventSummaries[eventID] = summary
+
+	// Persist to disk
+	return db.saveJSONFile("event_summary.json", db.eventSummaries)
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"slices"
+	"sort"
+)
+
+// GetEventSyncs retrieves the times events were last synced, with optional filters.
+// If no filters are provided, returns the syncs of all events.
+func (db *filedb) GetEventSyncs(filters ...EventSyncFilter) ([]*EventSync, error) {
+	if err := db.refreshEventSyncsIfChanged(); err != nil {
+		return nil, err
+	}
+
+	var filter EventSyncFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+
+	db.eventSyncsMu.RLock()
+	defer db.eventSyncsMu.RUnlock()
+
+	var syncs []*EventSync
+	for eventID, sync := range db.eventSyncs {
+		if len(filter.EventIDs) > 0 && !slices.Contains(filter.EventIDs, eventID) {
+			continue
+		}
+		syncCopy := *sync
+		syncs = append(syncs, &syncCopy)
+	}
+
+	// Sort by EventID
+	sort.Slice(syncs, func(i, j int) bool {
+		return syncs[i].EventID < syncs[j].EventID
+	})
+
+	return syncs, nil
+}
+
+// SaveEventSync records the time an event was synced, replacing any earlier time for the event.
+func (db *filedb) SaveEventSync(sync *EventSync) error {
+	if err := db.refreshEventSyncsIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventSyncsMu.Lock()
+	defer db.eventSyncsMu.Unlock()
+
+	syncCopy := *sync
+	db.eventSyncs[sync.EventID] = &syncCopy
+
+	return db.saveJSONFile("event_syncs.json", db.eventSyncs)
+}
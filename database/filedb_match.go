@@ -1,5 +1,11 @@
 package database
 
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
 // GetMatch retrieves a match from the file database by its ID.
 func (db *filedb) GetMatch(matchID string) (*Match, error) {
 	if err := db.refreshMatchesIfChanged(); err != nil {
@@ -36,6 +42,7 @@ func (db *filedb) GetAllMatches(filters ...MatchFilter) ([]*Match, error) {
 			matchCopy := *match
 			matches = append(matches, &matchCopy)
 		}
+		sortMatches(matches)
 		return matches, nil
 	}
 
@@ -65,7 +72,8 @@ func (db *filedb) GetAllMatches(filters ...MatchFilter) ([]*Match, error) {
 		}
 	}
 
-	return matches, nil
+	sortMatches(matches)
+	return page(matches, filter.Limit, filter.Offset), nil
 }
 
 // GetMatchesByEvent retrieves all matches for a specific event.
@@ -84,6 +92,7 @@ func (db *filedb) GetMatchesByEvent(eventID string) ([]*Match, error) {
 			matches = append(matches, &matchCopy)
 		}
 	}
+	sortMatches(matches)
 	return matches, nil
 }
 
@@ -98,12 +107,48 @@ func (db *filedb) SaveMatch(match *Match) error {
 
 	// Make a copy to avoid external modifications
 	matchCopy := *match
+	setUpdatedAt(&matchCopy, db.matches[match.MatchID], func(m *Match) *time.Time { return &m.UpdatedAt })
 	db.matches[match.MatchID] = &matchCopy
 
 	// Persist to disk
 	return db.saveJSONFile("matches.json", db.matches)
 }
 
+// DeleteMatch deletes a match along with its alliance scores and teams from the file database.
+func (db *filedb) DeleteMatch(matchID string) error {
+	if err := db.refreshMatchesIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshMatchScoresIfChanged(); err != nil {
+		return err
+	}
+	if err := db.refreshMatchTeamsIfChanged(); err != nil {
+		return err
+	}
+
+	// Delete the rows that depend on the match before the match itself
+	db.matchTeamsMu.Lock()
+	delete(db.matchTeams, matchID)
+	err := db.saveJSONFile("match_teams.json", db.matchTeams)
+	db.matchTeamsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.matchScoresMu.Lock()
+	delete(db.matchScores, matchID)
+	err = db.saveJSONFile("match_scores.json", db.matchScores)
+	db.matchScoresMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.matchesMu.Lock()
+	defer db.matchesMu.Unlock()
+	delete(db.matches, matchID)
+	return db.saveJSONFile("matches.json", db.matches)
+}
+
 // GetMatchAllianceScore retrieves the score for a specific alliance in a match.
 func (db *filedb) GetMatchAllianceScore(matchID, alliance string) (*MatchAllianceScore, error) {
 	if err := db.refreshMatchScoresIfChanged(); err != nil {
@@ -118,7 +163,7 @@ func (db *filedb) GetMatchAllianceScore(matchID, alliance string) (*MatchAllianc
 		return nil, nil
 	}
 
-	score, ok := matchScores[alliance]
+	score, ok := matchScores[NormalizeAlliance(alliance)]
 	if !ok {
 		return nil, nil
 	}
@@ -144,7 +189,9 @@ func (db *filedb) SaveMatchAllianceScore(score *MatchAllianceScore) error {
 
 	// Make a copy to avoid external modifications
 	scoreCopy := *score
-	db.matchScores[score.MatchID][score.Alliance] = &scoreCopy
+	scoreCopy.Alliance = NormalizeAlliance(score.Alliance)
+	setUpdatedAt(&scoreCopy, db.matchScores[score.MatchID][scoreCopy.Alliance], func(mas *MatchAllianceScore) *time.Time { return &mas.UpdatedAt })
+	db.matchScores[score.MatchID][scoreCopy.Alliance] = &scoreCopy
 
 	// Persist to disk
 	return db.saveJSONFile("match_scores.json", db.matchScores)
@@ -170,6 +217,7 @@ func (db *filedb) GetMatchTeams(matchID string) ([]*MatchTeam, error) {
 		teamCopy := *team
 		result[i] = &teamCopy
 	}
+	sortMatchTeams(result)
 	return result, nil
 }
 
@@ -189,6 +237,8 @@ func (db *filedb) SaveMatchTeam(team *MatchTeam) error {
 		if existing.TeamID == team.TeamID {
 			// Update existing
 			teamCopy := *team
+			teamCopy.Alliance = NormalizeAlliance(team.Alliance)
+			setUpdatedAt(&teamCopy, existing, func(mt *MatchTeam) *time.Time { return &mt.UpdatedAt })
 			teams[i] = &teamCopy
 			found = true
 			break
@@ -198,6 +248,8 @@ func (db *filedb) SaveMatchTeam(team *MatchTeam) error {
 	if !found {
 		// Add new
 		teamCopy := *team
+		teamCopy.Alliance = NormalizeAlliance(team.Alliance)
+		setUpdatedAt(&teamCopy, nil, func(mt *MatchTeam) *time.Time { return &mt.UpdatedAt })
 		db.matchTeams[team.MatchID] = append(teams, &teamCopy)
 	}
 
@@ -205,6 +257,26 @@ func (db *filedb) SaveMatchTeam(team *MatchTeam) error {
 	return db.saveJSONFile("match_teams.json", db.matchTeams)
 }
 
+// DeleteMatchTeam deletes a team from a match in the file database.
+func (db *filedb) DeleteMatchTeam(matchID string, teamID int) error {
+	if err := db.refreshMatchTeamsIfChanged(); err != nil {
+		return err
+	}
+
+	db.matchTeamsMu.Lock()
+	defer db.matchTeamsMu.Unlock()
+
+	db.matchTeams[matchID] = slices.DeleteFunc(db.matchTeams[matchID], func(r *MatchTeam) bool {
+		return r.TeamID == teamID
+	})
+	if len(db.matchTeams[matchID]) == 0 {
+		delete(db.matchTeams, matchID)
+	}
+
+	// Persist to disk
+	return db.saveJSONFile("match_teams.json", db.matchTeams)
+}
+
 // GetTeamsByEvent retrieves all unique team IDs that participated at a specific event.
 func (db *filedb) GetTeamsByEvent(eventID string) ([]int, error) {
 	if err := db.refreshMatchesIfChanged(); err != nil {
@@ -240,5 +312,22 @@ func (db *filedb) GetTeamsByEvent(eventID string) ([]int, error) {
 	for teamID := range teamIDMap {
 		teamIDs = append(teamIDs, teamID)
 	}
+	sort.Ints(teamIDs)
 	return teamIDs, nil
 }
+
+// localizeMatchStartTimes moves the start times of the matches read without a time zone to the time zone of their
+// event, and returns the number of matches that were changed. The caller must hold the matches and events locks.
+func (db *filedb) localizeMatchStartTimes() int {
+	var changed int
+	for _, match := range db.matches {
+		loc := time.UTC
+		if event, ok := db.events[match.EventID]; ok {
+			loc = event.Location()
+		}
+		if match.localizeStartTime(loc) {
+			changed++
+		}
+	}
+	return changed
+}
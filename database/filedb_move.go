@@ -0,0 +1,334 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MoveEvent moves every record of an event to a new event ID and deletes the event saved under the old ID. Each
+// table is saved as it is updated, so a failure part way through leaves the remaining records under the old ID,
+// and moving the event again finishes the move.
+func (db *filedb) MoveEvent(fromEventID, toEventID string) error {
+	if fromEventID == toEventID {
+		return nil
+	}
+	if err := db.refreshAllIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventsMu.RLock()
+	_, ok := db.events[toEventID]
+	db.eventsMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("event %s not found", toEventID)
+	}
+
+	if err := db.moveMatches(fromEventID, toEventID); err != nil {
+		return err
+	}
+
+	db.eventAwardsMu.Lock()
+	moved := moveEventRecords(db.eventAwards, fromEventID, toEventID, func(ea *EventAward) { ea.EventID = toEventID }, func(a, b *EventAward) bool {
+		return a.TeamID == b.TeamID && a.AwardID == b.AwardID && a.Series == b.Series
+	})
+	err := db.saveIfMoved(moved, "event_awards.json", db.eventAwards)
+	db.eventAwardsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.eventRankingsMu.Lock()
+	moved = moveEventRecords(db.eventRankings, fromEventID, toEventID, func(er *EventRanking) { er.EventID = toEventID }, func(a, b *EventRanking) bool {
+		return a.TeamID == b.TeamID
+	})
+	err = db.saveIfMoved(moved, "event_rankings.json", db.eventRankings)
+	db.eventRankingsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.eventAdvancementsMu.Lock()
+	moved = moveEventRecords(db.eventAdvancements, fromEventID, toEventID, func(ea *EventAdvancement) { ea.EventID = toEventID }, func(a, b *EventAdvancement) bool {
+		return a.TeamID == b.TeamID
+	})
+	err = db.saveIfMoved(moved, "event_advancements.json", db.eventAdvancements)
+	db.eventAdvancementsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.eventTeamsMu.Lock()
+	moved = moveEventRecords(db.eventTeams, fromEventID, toEventID, func(et *EventTeam) { et.EventID = toEventID }, func(a, b *EventTeam) bool {
+		return a.TeamID == b.TeamID
+	})
+	err = db.saveIfMoved(moved, "event_teams.json", db.eventTeams)
+	db.eventTeamsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.teamRankingsMu.Lock()
+	rankings, moved := db.teamRankings[fromEventID]
+	if moved {
+		if db.teamRankings[toEventID] == nil {
+			db.teamRankings[toEventID] = make(map[int]*TeamRanking)
+		}
+		for teamID, ranking := range rankings {
+			if _, ok := db.teamRankings[toEventID][teamID]; ok {
+				continue
+			}
+			rankingCopy := *ranking
+			rankingCopy.EventID = toEventID
+			db.teamRankings[toEventID][teamID] = &rankingCopy
+		}
+		delete(db.teamRankings, fromEventID)
+	}
+	err = db.saveIfMoved(moved, "team_rankings.json", db.teamRankings)
+	db.teamRankingsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.teamSnapshotsMu.Lock()
+	moved = false
+	for date, snapshots := range db.teamSnapshots {
+		if !slices.ContainsFunc(snapshots, func(s *TeamRankingSnapshot) bool { return s.EventID == fromEventID }) {
+			continue
+		}
+		updated := make([]*TeamRankingSnapshot, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			if snapshot.EventID != fromEventID {
+				updated = append(updated, snapshot)
+				continue
+			}
+			if slices.ContainsFunc(snapshots, func(s *TeamRankingSnapshot) bool {
+				return s.EventID == toEventID && s.TeamID == snapshot.TeamID
+			}) {
+				continue
+			}
+			snapshotCopy := *snapshot
+			snapshotCopy.EventID = toEventID
+			updated = append(updated, &snapshotCopy)
+		}
+		db.teamSnapshots[date] = updated
+		moved = true
+	}
+	err = db.saveIfMoved(moved, "team_ranking_snapshots.json", db.teamSnapshots)
+	db.teamSnapshotsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.syncCheckpointsMu.Lock()
+	moved = false
+	for _, checkpoints := range db.syncCheckpoints {
+		checkpoint, ok := checkpoints[fromEventID]
+		if !ok {
+			continue
+		}
+		if _, ok := checkpoints[toEventID]; !ok {
+			checkpointCopy := *checkpoint
+			checkpointCopy.EventID = toEventID
+			checkpoints[toEventID] = &checkpointCopy
+		}
+		delete(checkpoints, fromEventID)
+		moved = true
+	}
+	err = db.saveIfMoved(moved, "sync_checkpoints.json", db.syncCheckpoints)
+	db.syncCheckpointsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.eventSourceKeysMu.Lock()
+	moved = false
+	for _, keys := range db.eventSourceKeys {
+		for sourceKey, key := range keys {
+			if key.EventID != fromEventID {
+				continue
+			}
+			keyCopy := *key
+			keyCopy.EventID = toEventID
+			keys[sourceKey] = &keyCopy
+			moved = true
+		}
+	}
+	err = db.saveIfMoved(moved, "event_source_keys.json", db.eventSourceKeys)
+	db.eventSourceKeysMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.eventSummariesMu.Lock()
+	_, moved = db.eventSummaries[fromEventID]
+	delete(db.eventSummaries, fromEventID)
+	err = db.saveIfMoved(moved, "event_summary.json", db.eventSummaries)
+	db.eventSummariesMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// The cutoff is recalculated when the event's advancements are next synced
+	db.cutoffsMu.Lock()
+	_, moved = db.cutoffs[fromEventID]
+	delete(db.cutoffs, fromEventID)
+	err = db.saveIfMoved(moved, "advancement_cutoffs.json", db.cutoffs)
+	db.cutoffsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.eventSyncsMu.Lock()
+	sync, moved := db.eventSyncs[fromEventID]
+	if moved {
+		if _, ok := db.eventSyncs[toEventID]; !ok {
+			syncCopy := *sync
+			syncCopy.EventID = toEventID
+			db.eventSyncs[toEventID] = &syncCopy
+		}
+		delete(db.eventSyncs, fromEventID)
+	}
+	err = db.saveIfMoved(moved, "event_syncs.json", db.eventSyncs)
+	db.eventSyncsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.syncRetriesMu.Lock()
+	retry, moved := db.syncRetries[fromEventID]
+	if moved {
+		if _, ok := db.syncRetries[toEventID]; !ok {
+			retryCopy := *retry
+			retryCopy.EventID = toEventID
+			db.syncRetries[toEventID] = &retryCopy
+		}
+		delete(db.syncRetries, fromEventID)
+	}
+	err = db.saveIfMoved(moved, "sync_retries.json", db.syncRetries)
+	db.syncRetriesMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// The hashes are dropped, so the event's results are saved in full when it is next synced
+	db.endpointHashesMu.Lock()
+	_, moved = db.endpointHashes[fromEventID]
+	delete(db.endpointHashes, fromEventID)
+	err = db.saveIfMoved(moved, "endpoint_hashes.json", db.endpointHashes)
+	db.endpointHashesMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// The event is deleted last, so it can still be found if the move needs to be finished
+	db.eventsMu.Lock()
+	_, moved = db.events[fromEventID]
+	delete(db.events, fromEventID)
+	err = db.saveIfMoved(moved, "events.json", db.events)
+	db.eventsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return db.RefreshEventSummary(toEventID)
+}
+
+// moveMatches moves the matches of an event, along with their alliance scores and teams, to a new event ID. The
+// matches are given new match IDs, as match IDs embed the event ID.
+func (db *filedb) moveMatches(fromEventID, toEventID string) error {
+	db.matchesMu.RLock()
+	matchIDs := make(map[string]string)
+	for matchID, match := range db.matches {
+		if match.EventID == fromEventID {
+			matchIDs[matchID] = movedMatchID(matchID, fromEventID, toEventID)
+		}
+	}
+	db.matchesMu.RUnlock()
+	if len(matchIDs) == 0 {
+		return nil
+	}
+
+	// Move the rows that depend on the matches before the matches themselves
+	db.matchTeamsMu.Lock()
+	moved := false
+	for matchID, newMatchID := range matchIDs {
+		moved = moveEventRecords(db.matchTeams, matchID, newMatchID, func(mt *MatchTeam) { mt.MatchID = newMatchID }, func(a, b *MatchTeam) bool {
+			return a.TeamID == b.TeamID
+		}) || moved
+	}
+	err := db.saveIfMoved(moved, "match_teams.json", db.matchTeams)
+	db.matchTeamsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.matchScoresMu.Lock()
+	moved = false
+	for matchID, newMatchID := range matchIDs {
+		scores, ok := db.matchScores[matchID]
+		if !ok {
+			continue
+		}
+		if _, ok := db.matchScores[newMatchID]; !ok {
+			newScores := make(map[string]*MatchAllianceScore, len(scores))
+			for alliance, score := range scores {
+				scoreCopy := *score
+				scoreCopy.MatchID = newMatchID
+				newScores[alliance] = &scoreCopy
+			}
+			db.matchScores[newMatchID] = newScores
+		}
+		delete(db.matchScores, matchID)
+		moved = true
+	}
+	err = db.saveIfMoved(moved, "match_scores.json", db.matchScores)
+	db.matchScoresMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	db.matchesMu.Lock()
+	defer db.matchesMu.Unlock()
+	for matchID, newMatchID := range matchIDs {
+		match, ok := db.matches[matchID]
+		if !ok {
+			continue
+		}
+		if _, ok := db.matches[newMatchID]; !ok {
+			matchCopy := *match
+			matchCopy.MatchID = newMatchID
+			matchCopy.EventID = toEventID
+			db.matches[newMatchID] = &matchCopy
+		}
+		delete(db.matches, matchID)
+	}
+	return db.saveJSONFile("matches.json", db.matches)
+}
+
+// moveEventRecords moves the records kept under one key of a table to another key, using setKey to update each
+// record. Records already kept under the new key are kept in place of the moved records that are the same. It
+// returns true if any records were moved.
+func moveEventRecords[T any](records map[string][]*T, from, to string, setKey func(*T), same func(a, b *T) bool) bool {
+	moved, ok := records[from]
+	if !ok {
+		return false
+	}
+	for _, record := range moved {
+		recordCopy := *record
+		setKey(&recordCopy)
+		if slices.ContainsFunc(records[to], func(r *T) bool { return same(r, &recordCopy) }) {
+			continue
+		}
+		records[to] = append(records[to], &recordCopy)
+	}
+	delete(records, from)
+	return true
+}
+
+// saveIfMoved saves a table to its JSON file if any of its records were moved.
+func (db *filedb) saveIfMoved(moved bool, filename string, data any) error {
+	if !moved {
+		return nil
+	}
+	return db.saveJSONFile(filename, data)
+}
@@ -0,0 +1,51 @@
+package database
+
+// GetRegionAliases retrieves all region aliases.
+func (db *filedb) GetRegionAliases() ([]*RegionAlias, error) {
+	if err := db.refreshRegionAliasesIfChanged(); err != nil {
+		return nil, err
+	}
+
+	db.regionAliasesMu.RLock()
+	defer db.regionAliasesMu.RUnlock()
+
+	aliases := make([]*RegionAlias, 0, len(db.regionAliases))
+	for _, alias := range db.regionAliases {
+		aliasCopy := *alias
+		aliases = append(aliases, &aliasCopy)
+	}
+	sortRegionAliases(aliases)
+	return aliases, nil
+}
+
+// SaveRegionAlias saves a region alias, replacing any alias that is the same without regard to case or spacing.
+func (db *filedb) SaveRegionAlias(alias *RegionAlias) error {
+	if err := db.refreshRegionAliasesIfChanged(); err != nil {
+		return err
+	}
+
+	db.regionAliasesMu.Lock()
+	defer db.regionAliasesMu.Unlock()
+
+	aliasCopy := RegionAlias{
+		Alias:      NormalizeAlias(alias.Alias),
+		RegionCode: NormalizeCode(alias.RegionCode),
+	}
+	db.regionAliases[aliasKey(alias.Alias)] = &aliasCopy
+
+	return db.saveJSONFile("region_aliases.json", db.regionAliases)
+}
+
+// DeleteRegionAlias deletes a region alias, matching the alias without regard to case or spacing.
+func (db *filedb) DeleteRegionAlias(alias string) error {
+	if err := db.refreshRegionAliasesIfChanged(); err != nil {
+		return err
+	}
+
+	db.regionAliasesMu.Lock()
+	defer db.regionAliasesMu.Unlock()
+
+	delete(db.regionAliases, aliasKey(alias))
+
+	return db.saveJSONFile("region_aliases.json", db.regionAliases)
+}
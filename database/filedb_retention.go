@@ -0,0 +1,91 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// fileDataDir returns the directory that holds a directory for each season.
+func fileDataDir() (string, error) {
+	baseDir := os.Getenv("FILEDB_DATA_DIR")
+	if baseDir == "" {
+		return "", errors.New("FILEDB_DATA_DIR environment variable not set")
+	}
+	return baseDir, nil
+}
+
+// getFileSeasonUsage reports the size of each season directory and the number of events and matches it holds.
+// Directories whose names are not years are ignored.
+func getFileSeasonUsage() ([]*SeasonUsage, error) {
+	baseDir, err := fileDataDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var usage []*SeasonUsage
+	for _, entry := range entries {
+		if !entry.IsDir() || seasonYear(entry.Name()) == 0 {
+			continue
+		}
+		dataDir := filepath.Join(baseDir, entry.Name())
+		su := &SeasonUsage{
+			Season:  entry.Name(),
+			Events:  countJSONRecords(filepath.Join(dataDir, "events.json")),
+			Matches: countJSONRecords(filepath.Join(dataDir, "matches.json")),
+		}
+		err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			su.Bytes += info.Size()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		usage = append(usage, su)
+	}
+	return usage, nil
+}
+
+// countJSONRecords returns the number of records in a JSON file holding a map of records, or 0 if the file
+// does not exist or cannot be read.
+func countJSONRecords(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	var records map[string]json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return 0
+	}
+	return len(records)
+}
+
+// pruneFileSeasons removes the directories for the seasons.
+func pruneFileSeasons(seasons []string) error {
+	baseDir, err := fileDataDir()
+	if err != nil {
+		return err
+	}
+	for _, season := range seasons {
+		if err := os.RemoveAll(filepath.Join(baseDir, season)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
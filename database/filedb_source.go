@@ -0,0 +1,43 @@
+package database
+
+import "sort"
+
+// GetEventSourceKeys retrieves the keys that a data source uses for events, along with the events they map to.
+func (db *filedb) GetEventSourceKeys(source string) ([]*EventSourceKey, error) {
+	if err := db.refreshEventSourceKeysIfChanged(); err != nil {
+		return nil, err
+	}
+
+	db.eventSourceKeysMu.RLock()
+	defer db.eventSourceKeysMu.RUnlock()
+
+	keys := make([]*EventSourceKey, 0, len(db.eventSourceKeys[source]))
+	for _, key := range db.eventSourceKeys[source] {
+		keyCopy := *key
+		keys = append(keys, &keyCopy)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].SourceKey < keys[j].SourceKey
+	})
+	return keys, nil
+}
+
+// SaveEventSourceKey saves or updates the event that a data source's key maps to.
+func (db *filedb) SaveEventSourceKey(key *EventSourceKey) error {
+	if err := db.refreshEventSourceKeysIfChanged(); err != nil {
+		return err
+	}
+
+	db.eventSourceKeysMu.Lock()
+	defer db.eventSourceKeysMu.Unlock()
+
+	if db.eventSourceKeys[key.Source] == nil {
+		db.eventSourceKeys[key.Source] = make(map[string]*EventSourceKey)
+	}
+	// Make a copy to avoid external modifications
+	keyCopy := *key
+	db.eventSourceKeys[key.Source][key.SourceKey] = &keyCopy
+
+	// Persist to disk
+	return db.saveJSONFile("event_source_keys.json", db.eventSourceKeys)
+}
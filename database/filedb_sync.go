@@ -0,0 +1,58 @@
+package database
+
+import "sort"
+
+// GetSyncCheckpoints retrieves the events that were completed by an interrupted sync of a season.
+func (db *filedb) GetSyncCheckpoints(season string) ([]*SyncCheckpoint, error) {
+	if err := db.refreshSyncCheckpointsIfChanged(); err != nil {
+		return nil, err
+	}
+
+	db.syncCheckpointsMu.RLock()
+	defer db.syncCheckpointsMu.RUnlock()
+
+	checkpoints := make([]*SyncCheckpoint, 0, len(db.syncCheckpoints[season]))
+	for _, checkpoint := range db.syncCheckpoints[season] {
+		checkpointCopy := *checkpoint
+		checkpoints = append(checkpoints, &checkpointCopy)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].CompletedAt.Before(checkpoints[j].CompletedAt)
+	})
+	return checkpoints, nil
+}
+
+// SaveSyncCheckpoint records that an event was completed during a sync of a season.
+func (db *filedb) SaveSyncCheckpoint(checkpoint *SyncCheckpoint) error {
+	if err := db.refreshSyncCheckpointsIfChanged(); err != nil {
+		return err
+	}
+
+	db.syncCheckpointsMu.Lock()
+	defer db.syncCheckpointsMu.Unlock()
+
+	if db.syncCheckpoints[checkpoint.Season] == nil {
+		db.syncCheckpoints[checkpoint.Season] = make(map[string]*SyncCheckpoint)
+	}
+	// Make a copy to avoid external modifications
+	checkpointCopy := *checkpoint
+	db.syncCheckpoints[checkpoint.Season][checkpoint.EventID] = &checkpointCopy
+
+	// Persist to disk
+	return db.saveJSONFile("sync_checkpoints.json", db.syncCheckpoints)
+}
+
+// DeleteSyncCheckpoints removes all checkpoints for a season, so the next sync starts from the beginning.
+func (db *filedb) DeleteSyncCheckpoints(season string) error {
+	if err := db.refreshSyncCheckpointsIfChanged(); err != nil {
+		return err
+	}
+
+	db.syncCheckpointsMu.Lock()
+	defer db.syncCheckpointsMu.Unlock()
+
+	delete(db.syncCheckpoints, season)
+
+	// Persist to disk
+	return db.saveJSONFile("sync_checkpoints.json", db.syncCheckpoints)
+}
@@ -0,0 +1,58 @@
+package database
+
+import "sort"
+
+// GetSyncRetries retrieves the events whose results failed to sync and are to be retried.
+func (db *filedb) GetSyncRetries() ([]*SyncRetry, error) {
+	if err := db.refreshSyncRetriesIfChanged(); err != nil {
+		return nil, err
+	}
+
+	db.syncRetriesMu.RLock()
+	defer db.syncRetriesMu.RUnlock()
+
+	retries := make([]*SyncRetry, 0, len(db.syncRetries))
+	for _, retry := range db.syncRetries {
+		retryCopy := *retry
+		retries = append(retries, &retryCopy)
+	}
+
+	// Sort by EventID
+	sort.Slice(retries, func(i, j int) bool {
+		return retries[i].EventID < retries[j].EventID
+	})
+
+	return retries, nil
+}
+
+// SaveSyncRetry records an event whose results failed to sync, replacing any earlier retry for the event.
+func (db *filedb) SaveSyncRetry(retry *SyncRetry) error {
+	if err := db.refreshSyncRetriesIfChanged(); err != nil {
+		return err
+	}
+
+	db.syncRetriesMu.Lock()
+	defer db.syncRetriesMu.Unlock()
+
+	retryCopy := *retry
+	db.syncRetries[retry.EventID] = &retryCopy
+
+	return db.saveJSONFile("sync_retries.json", db.syncRetries)
+}
+
+// DeleteSyncRetry removes the retry of an event, if there is one.
+func (db *filedb) DeleteSyncRetry(eventID string) error {
+	if err := db.refreshSyncRetriesIfChanged(); err != nil {
+		return err
+	}
+
+	db.syncRetriesMu.Lock()
+	defer db.syncRetriesMu.Unlock()
+
+	if _, ok := db.syncRetries[eventID]; !ok {
+		return nil
+	}
+	delete(db.syncRetries, eventID)
+
+	return db.saveJSONFile("sync_retries.json", db.syncRetries)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"slices"
+	"sort"
+)
+
+// GetSyncRuns retrieves the runs that synced the season's data, with optional filters.
+// If no filters are provided, returns every run.
+func (db *filedb) GetSyncRuns(filters ...SyncRunFilter) ([]*SyncRun, error) {
+	if err := db.refreshSyncRunsIfChanged(); err != nil {
+		return nil, err
+	}
+
+	var filter SyncRunFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+
+	db.syncRunsMu.RLock()
+	defer db.syncRunsMu.RUnlock()
+
+	var runs []*SyncRun
+	for _, run := range db.syncRuns {
+		if filter.Season != "" && run.Season != filter.Season {
+			continue
+		}
+		if filter.Status != "" && run.Status != filter.Status {
+			continue
+		}
+		runCopy := *run
+		runCopy.Errors = slices.Clone(run.Errors)
+		runs = append(runs, &runCopy)
+	}
+
+	// Sort by StartedAt, most recent first
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+
+	if filter.Limit > 0 && len(runs) > filter.Limit {
+		runs = runs[:filter.Limit]
+	}
+	return runs, nil
+}
+
+// SaveSyncRun saves a sync run, replacing the run with the same ID, then deletes the season's oldest runs beyond
+// MaxSyncRuns.
+func (db *filedb) SaveSyncRun(run *SyncRun) error {
+	if err := db.refreshSyncRunsIfChanged(); err != nil {
+		return err
+	}
+
+	db.syncRunsMu.Lock()
+	defer db.syncRunsMu.Unlock()
+
+	runCopy := *run
+	runCopy.Errors = slices.Clone(run.Errors)
+	db.syncRuns[run.RunID] = &runCopy
+
+	var seasonRuns []*SyncRun
+	for _, r := range db.syncRuns {
+		if r.Season == run.Season {
+			seasonRuns = append(seasonRuns, r)
+		}
+	}
+	if len(seasonRuns) > MaxSyncRuns {
+		sort.Slice(seasonRuns, func(i, j int) bool {
+			return seasonRuns[i].StartedAt.After(seasonRuns[j].StartedAt)
+		})
+		for _, r := range seasonRuns[MaxSyncRuns:] {
+			delete(db.syncRuns, r.RunID)
+		}
+	}
+
+	return db.saveJSONFile("sync_runs.json", db.syncRuns)
+}
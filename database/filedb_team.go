@@ -3,6 +3,7 @@ package database
 import (
 	"slices"
 	"sort"
+	"time"
 )
 
 // GetTeam retrieves a team from the file database by its ID.
@@ -117,7 +118,7 @@ func (db *filedb) GetAllTeams(filters ...TeamFilter) ([]*Team, error) {
 	sort.Slice(teams, func(i, j int) bool {
 		return teams[i].TeamID < teams[j].TeamID
 	})
-	return teams, nil
+	return page(teams, filter.Limit, filter.Offset), nil
 }
 
 // SaveTeam saves or updates a team in the file database.
@@ -131,6 +132,7 @@ func (db *filedb) SaveTeam(team *Team) error {
 
 	// Make a copy to avoid external modifications
 	teamCopy := *team
+	setUpdatedAt(&teamCopy, db.teams[team.TeamID], func(t *Team) *time.Time { return &t.UpdatedAt })
 	db.teams[team.TeamID] = &teamCopy
 
 	// Persist to disk
@@ -222,6 +224,9 @@ func (db *filedb) GetTeamRankings(filters ...TeamRankingFilter) ([]*TeamRanking,
 		return rankings[i].TeamID < rankings[j].TeamID
 	})
 
+	if len(filters) > 0 {
+		rankings = page(rankings, filters[0].Limit, filters[0].Offset)
+	}
 	return rankings, nil
 }
 
@@ -241,8 +246,119 @@ func (db *filedb) SaveTeamRanking(ranking *TeamRanking) error {
 
 	// Make a copy and save it
 	rankingCopy := *ranking
+	setUpdatedAt(&rankingCopy, db.teamRankings[ranking.EventID][ranking.TeamID], func(tr *TeamRanking) *time.Time { return &tr.UpdatedAt })
 	db.teamRankings[ranking.EventID][ranking.TeamID] = &rankingCopy
 
 	// Persist to disk
 	return db.saveJSONFile("team_rankings.json", db.teamRankings)
 }
+
+// DeleteTeamRanking deletes the performance metrics of a team at an event from the file database.
+func (db *filedb) DeleteTeamRanking(eventID string, teamID int) error {
+	if err := db.refreshTeamRankingsIfChanged(); err != nil {
+		return err
+	}
+
+	db.teamRankingsMu.Lock()
+	defer db.teamRankingsMu.Unlock()
+
+	delete(db.teamRankings[eventID], teamID)
+	if len(db.teamRankings[eventID]) == 0 {
+		delete(db.teamRankings, eventID)
+	}
+
+	// Persist to disk
+	return db.saveJSONFile("team_rankings.json", db.teamRankings)
+}
+
+// GetTeamRankingSnapshots retrieves the most recent team ranking snapshot taken on or before the
+// filter's AsOf date. If AsOf is not set, the latest snapshot is returned.
+// Filters support filtering by TeamID and/or EventID.
+func (db *filedb) GetTeamRankingSnapshots(filters ...TeamRankingSnapshotFilter) ([]*TeamRankingSnapshot, error) {
+	if err := db.refreshTeamSnapshotsIfChanged(); err != nil {
+		return nil, err
+	}
+
+	db.teamSnapshotsMu.RLock()
+	defer db.teamSnapshotsMu.RUnlock()
+
+	var filter TeamRankingSnapshotFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+
+	// Find the latest snapshot date on or before AsOf. Dates are keyed as YYYY-MM-DD, so
+	// they sort lexically in chronological order.
+	var asOf string
+	if !filter.AsOf.IsZero() {
+		asOf = GetSnapshotDate(filter.AsOf).Format(SnapshotDateFormat)
+	}
+	var snapshotDate string
+	for date := range db.teamSnapshots {
+		if asOf != "" && date > asOf {
+			continue
+		}
+		if date > snapshotDate {
+			snapshotDate = date
+		}
+	}
+	if snapshotDate == "" {
+		return nil, nil
+	}
+
+	var snapshots []*TeamRankingSnapshot
+	for _, snapshot := range db.teamSnapshots[snapshotDate] {
+		// Check TeamID filter
+		if len(filter.TeamIDs) > 0 && !slices.Contains(filter.TeamIDs, snapshot.TeamID) {
+			continue
+		}
+
+		// Check EventID filter
+		if len(filter.EventIDs) > 0 && !slices.Contains(filter.EventIDs, snapshot.EventID) {
+			continue
+		}
+
+		snapshotCopy := *snapshot
+		snapshots = append(snapshots, &snapshotCopy)
+	}
+
+	// Sort by EventID then TeamID
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].EventID != snapshots[j].EventID {
+			return snapshots[i].EventID < snapshots[j].EventID
+		}
+		return snapshots[i].TeamID < snapshots[j].TeamID
+	})
+
+	return snapshots, nil
+}
+
+// SaveTeamRankingSnapshot saves or updates a team ranking snapshot in the file database.
+func (db *filedb) SaveTeamRankingSnapshot(snapshot *TeamRankingSnapshot) error {
+	if err := db.refreshTeamSnapshotsIfChanged(); err != nil {
+		return err
+	}
+
+	db.teamSnapshotsMu.Lock()
+	defer db.teamSnapshotsMu.Unlock()
+
+	// Make a copy, normalizing the date to the day of the snapshot
+	snapshotCopy := *snapshot
+	snapshotCopy.SnapshotDate = GetSnapshotDate(snapshot.SnapshotDate)
+	date := snapshotCopy.SnapshotDate.Format(SnapshotDateFormat)
+
+	// Replace an existing entry for the same team and event, otherwise append
+	snapshots := db.teamSnapshots[date]
+	idx := slices.IndexFunc(snapshots, func(s *TeamRankingSnapshot) bool {
+		return s.TeamID == snapshot.TeamID && s.EventID == snapshot.EventID
+	})
+	if idx >= 0 {
+		snapshots[idx] = &snapshotCopy
+	} else {
+		snapshots = append(snapshots, &snapshotCopy)
+	}
+	db.teamSnapshots[date] = snapshots
+
+	// Persist to disk
+	return db.saveJSONFile("team_ranking_snapshots.json", db.teamSnapshots)
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"slices"
+	"sort"
+)
+
+// GetTeamHistory retrieves the earlier names and home regions of teams, with optional filters.
+// If no filters are provided, returns the history of all teams.
+func (db *filedb) GetTeamHistory(filters ...TeamHistoryFilter) ([]*TeamHistory, error) {
+	if err := db.refreshTeamHistoryIfChanged(); err != nil {
+		return nil, err
+	}
+
+	var filter TeamHistoryFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+
+	db.teamHistoryMu.RLock()
+	defer db.teamHistoryMu.RUnlock()
+
+	var history []*TeamHistory
+	for teamID, entries := range db.teamHistory {
+		if len(filter.TeamIDs) > 0 && !slices.Contains(filter.TeamIDs, teamID) {
+			continue
+		}
+		for _, entry := range entries {
+			entryCopy := *entry
+			history = append(history, &entryCopy)
+		}
+	}
+
+	// Sort by TeamID, then by ValidTo, most recent first
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].TeamID != history[j].TeamID {
+			return history[i].TeamID < history[j].TeamID
+		}
+		return history[i].ValidTo.After(history[j].ValidTo)
+	})
+
+	return history, nil
+}
+
+// SaveTeamHistory records an earlier name and home region of a team, replacing any entry for the team that was
+// replaced at the same time.
+func (db *filedb) SaveTeamHistory(history *TeamHistory) error {
+	if err := db.refreshTeamHistoryIfChanged(); err != nil {
+		return err
+	}
+
+	db.teamHistoryMu.Lock()
+	defer db.teamHistoryMu.Unlock()
+
+	historyCopy := *history
+	entries := db.teamHistory[history.TeamID]
+	idx := slices.IndexFunc(entries, func(th *TeamHistory) bool {
+		return th.ValidTo.Equal(history.ValidTo)
+	})
+	if idx >= 0 {
+		entries[idx] = &historyCopy
+	} else {
+		entries = append(entries, &historyCopy)
+	}
+	db.teamHistory[history.TeamID] = entries
+
+	return db.saveJSONFile("team_history.json", db.teamHistory)
+}
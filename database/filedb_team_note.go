@@ -0,0 +1,69 @@
+package database
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// GetTeamNotes retrieves the scouting notes on teams, with optional filters.
+// If no filters are provided, returns the notes on all teams.
+func (db *filedb) GetTeamNotes(filters ...TeamNoteFilter) ([]*TeamNote, error) {
+	if err := db.refreshTeamNotesIfChanged(); err != nil {
+		return nil, err
+	}
+
+	var filter TeamNoteFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+
+	db.teamNotesMu.RLock()
+	defer db.teamNotesMu.RUnlock()
+
+	var notes []*TeamNote
+	for year, teamNotes := range db.teamNotes {
+		if filter.Year != 0 && year != filter.Year {
+			continue
+		}
+		for teamID, note := range teamNotes {
+			if len(filter.TeamIDs) > 0 && !slices.Contains(filter.TeamIDs, teamID) {
+				continue
+			}
+			noteCopy := *note
+			noteCopy.Tags = slices.Clone(note.Tags)
+			notes = append(notes, &noteCopy)
+		}
+	}
+
+	// Sort by Year, then by TeamID
+	sort.Slice(notes, func(i, j int) bool {
+		if notes[i].Year != notes[j].Year {
+			return notes[i].Year < notes[j].Year
+		}
+		return notes[i].TeamID < notes[j].TeamID
+	})
+
+	return notes, nil
+}
+
+// SaveTeamNote saves the scouting notes on a team, replacing any notes already saved on the team for the year. The
+// time the notes were updated is kept if they haven't changed.
+func (db *filedb) SaveTeamNote(note *TeamNote) error {
+	if err := db.refreshTeamNotesIfChanged(); err != nil {
+		return err
+	}
+
+	db.teamNotesMu.Lock()
+	defer db.teamNotesMu.Unlock()
+
+	noteCopy := *note
+	noteCopy.Tags = NormalizeTags(note.Tags)
+	if db.teamNotes[note.Year] == nil {
+		db.teamNotes[note.Year] = make(map[int]*TeamNote)
+	}
+	setUpdatedAt(&noteCopy, db.teamNotes[note.Year][note.TeamID], func(tn *TeamNote) *time.Time { return &tn.UpdatedAt })
+	db.teamNotes[note.Year][note.TeamID] = &noteCopy
+
+	return db.saveJSONFile("team_notes.json", db.teamNotes)
+}
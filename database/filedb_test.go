@@ -0,0 +1,22 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/database/dbtest"
+)
+
+func TestFileDB(t *testing.T) {
+	t.Setenv("DB_TYPE", "file")
+	t.Setenv("FILEDB_DATA_DIR", t.TempDir())
+	db, err := database.Init("2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := dbtest.TestDB(db); err != nil {
+		t.Fatal(err)
+	}
+}
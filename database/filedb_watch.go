@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"time"
+)
+
+// Watch reloads the data files of a file-based database as they change, such as when data synced on another
+// computer is copied into the data directory with rsync, so a long-running server serves the new data without being
+// restarted. The data directory is checked for changed files at each interval until the context is done. Reads
+// also reload changed files, but watching loads them as soon as they are copied rather than on the first request
+// that reads them. A file that isn't valid JSON, such as one that is still being copied, is left unloaded until it
+// changes again. An error is returned if the database isn't file-based or the interval isn't positive.
+//
+// The directory is polled rather than watched with file system notifications such as inotify, as notifications
+// aren't delivered for files changed by another computer on a network share or through some container bind mounts,
+// which is how data is commonly copied to a server. Each check stats every data file of the season, a few dozen
+// files, so even a short interval costs little. Files that change more than once between checks are reloaded once,
+// with their content at the time of the check; no change is lost, as only the latest data is served, but it is
+// served up to one interval after it is copied. An interval of a few seconds suits copying data from an event.
+func Watch(ctx context.Context, db DB, interval time.Duration) error {
+	fdb, ok := db.(*filedb)
+	if !ok {
+		return errors.New("only a file-based database can be watched for changes")
+	}
+	if interval <= 0 {
+		return errors.New("the watch interval must be positive")
+	}
+
+	go fdb.watch(ctx, interval)
+	return nil
+}
+
+// watch reloads the files in the data directory that have changed at each interval until the context is done.
+func (db *filedb) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slog.Info("Watching the data directory for changes", "dir", db.dataDir, "interval", interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		before := db.knownFileStates()
+		if err := db.refreshAllIfChanged(); err != nil {
+			slog.Error("Failed to reload changed data files", "dir", db.dataDir, "error", err)
+			continue
+		}
+		for filename, state := range db.knownFileStates() {
+			if previous, ok := before[filename]; (!ok || !sameFileState(previous, state)) && !state.invalid {
+				slog.Info("Reloaded changed data file", "dir", db.dataDir, "file", filename)
+			}
+		}
+	}
+}
+
+// knownFileStates returns a copy of the states of the data files when they were last loaded or saved.
+func (db *filedb) knownFileStates() map[string]fileState {
+	db.fileStateMu.Lock()
+	defer db.fileStateMu.Unlock()
+
+	return maps.Clone(db.fileStates)
+}
@@ -1,7 +1,10 @@
 package database
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 )
 
 const (
@@ -11,35 +14,90 @@ const (
 
 // Match represents a match in an event.
 type Match struct {
-	MatchID         string `json:"matchID"`
-	EventID         string `json:"event_id"`
-	MatchType       string `json:"matchType"`
-	MatchNumber     int    `json:"matchNumber"`
-	ActualStartTime string `json:"actualStartTime"`
-	Description     string `json:"description"`
-	TournamentLevel string `json:"tournamentLevel"`
+	MatchID         string    `json:"matchID"`
+	EventID         string    `json:"event_id"`
+	MatchType       string    `json:"matchType"`
+	MatchNumber     int       `json:"matchNumber"`
+	ActualStartTime time.Time `json:"actualStartTime,omitzero"` // Time the match started, or the zero time if it isn't known
+	Description     string    `json:"description"`
+	TournamentLevel string    `json:"tournamentLevel"`
+	Source          string    `json:"source,omitempty"` // Data source the match was requested from
+	UpdatedAt       time.Time `json:"updated_at"`       // Time the record was last created or changed
+}
+
+// startTimeLayout is the layout of the match start times reported by the FTC API, which are in the event's local time
+// and have no time zone.
+const startTimeLayout = "2006-01-02T15:04:05"
+
+// floatingStartTime is the location of start times read from records written before start times had a time zone,
+// until localizeStartTime moves them to their event's time zone.
+var floatingStartTime = time.FixedZone("", 0)
+
+// ParseStartTime parses the start time of a match as reported by a data source. Times with a time zone, such as
+// those from FTC Scout, are kept as they are, while times without one, such as those from the FTC API, are taken to
+// be in the event's time zone, loc. It returns the zero time if the start time is empty or can't be parsed.
+func ParseStartTime(s string, loc *time.Location) time.Time {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc)
+	}
+	if t, err := time.ParseInLocation(startTimeLayout, s, loc); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// UnmarshalJSON decodes a match, accepting the start times of records written before start times had a time zone.
+// Those are read in floatingStartTime until localizeStartTime moves them to the event's time zone.
+func (m *Match) UnmarshalJSON(data []byte) error {
+	type match Match
+	aux := struct {
+		*match
+		ActualStartTime string `json:"actualStartTime"`
+	}{match: (*match)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if t, err := time.Parse(time.RFC3339, aux.ActualStartTime); err == nil {
+		m.ActualStartTime = t
+	} else {
+		m.ActualStartTime = ParseStartTime(aux.ActualStartTime, floatingStartTime)
+	}
+	return nil
+}
+
+// localizeStartTime moves a start time read without a time zone to the event's time zone, loc, keeping its date
+// and clock time. It returns true if the start time was changed.
+func (m *Match) localizeStartTime(loc *time.Location) bool {
+	t := m.ActualStartTime
+	if t.Location() != floatingStartTime {
+		return false
+	}
+	m.ActualStartTime = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	return true
 }
 
 // MatchAllianceScore represents the score of an alliance in a match. MatchID and Alliance form a composite primary key.
 type MatchAllianceScore struct {
-	MatchID             string `json:"match_id"`
-	Alliance            string `json:"alliance"`
-	AutoPoints          int    `json:"auto_points"`
-	TeleopPoints        int    `json:"teleop_points"`
-	FoulPointsCommitted int    `json:"foul_points_committed"`
-	PreFoulTotal        int    `json:"pre_foul_total"`
-	TotalPoints         int    `json:"total_points"`
-	MajorFouls          int    `json:"major_fouls"`
-	MinorFouls          int    `json:"minor_fouls"`
+	MatchID             string    `json:"match_id"`
+	Alliance            string    `json:"alliance"`
+	AutoPoints          int       `json:"auto_points"`
+	TeleopPoints        int       `json:"teleop_points"`
+	FoulPointsCommitted int       `json:"foul_points_committed"`
+	PreFoulTotal        int       `json:"pre_foul_total"`
+	TotalPoints         int       `json:"total_points"`
+	MajorFouls          int       `json:"major_fouls"`
+	MinorFouls          int       `json:"minor_fouls"`
+	UpdatedAt           time.Time `json:"updated_at"` // Time the record was last created or changed
 }
 
 // MatchTeam represents an alliance team member participating in a match. MatchID and TeamID form a composite primary key.
 type MatchTeam struct {
-	MatchID  string `json:"match_id"`
-	TeamID   int    `json:"team_id"`
-	Alliance string `json:"alliance"`
-	Dq       bool   `json:"dq"`
-	OnField  bool   `json:"on_field"`
+	MatchID   string    `json:"match_id"`
+	TeamID    int       `json:"team_id"`
+	Alliance  string    `json:"alliance"`
+	Dq        bool      `json:"dq"`
+	OnField   bool      `json:"on_field"`
+	UpdatedAt time.Time `json:"updated_at"` // Time the record was last created or changed
 }
 
 // String returns a string representation of the Match.
@@ -69,9 +127,16 @@ func (mt *MatchTeam) String() string {
 // MatchFilter defines criteria for filtering matches.
 type MatchFilter struct {
 	EventIDs []string
+	Limit    int // If set, at most this many matches are returned
+	Offset   int // If set, this many matches are skipped before any are returned
 }
 
 // GetMatchID generates a unique ID for a match based on its event ID and match number.
 func GetMatchID(event *Event, matchType string, matchNumber int) string {
 	return fmt.Sprintf("%s : %s : %d", event.EventID, matchType, matchNumber)
 }
+
+// movedMatchID returns the ID a match is given when its event is moved from one event ID to another.
+func movedMatchID(matchID, fromEventID, toEventID string) string {
+	return toEventID + strings.TrimPrefix(matchID, fromEventID)
+}
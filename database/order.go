@@ -0,0 +1,113 @@
+package database
+
+import (
+	"sort"
+	"strings"
+)
+
+// The functions in this file sort the results returned by the file database into the order documented on the DB
+// interface, which is the order the SQL database returns them in. Both databases must return the same order so
+// reports don't change when the backend does.
+
+// sortAwards sorts awards by award ID.
+func sortAwards(awards []*Award) {
+	sort.Slice(awards, func(i, j int) bool {
+		return awards[i].AwardID < awards[j].AwardID
+	})
+}
+
+// sortEvents sorts events by start date, then event code, then event ID.
+func sortEvents(events []*Event) {
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].DateStart.Equal(events[j].DateStart) {
+			return events[i].DateStart.Before(events[j].DateStart)
+		}
+		if events[i].EventCode != events[j].EventCode {
+			return events[i].EventCode < events[j].EventCode
+		}
+		return events[i].EventID < events[j].EventID
+	})
+}
+
+// sortEventAwards sorts event awards by event ID, award ID, series, then team ID.
+func sortEventAwards(awards []*EventAward) {
+	sort.Slice(awards, func(i, j int) bool {
+		if awards[i].EventID != awards[j].EventID {
+			return awards[i].EventID < awards[j].EventID
+		}
+		if awards[i].AwardID != awards[j].AwardID {
+			return awards[i].AwardID < awards[j].AwardID
+		}
+		if awards[i].Series != awards[j].Series {
+			return awards[i].Series < awards[j].Series
+		}
+		return awards[i].TeamID < awards[j].TeamID
+	})
+}
+
+// sortEventRankings sorts event rankings by event ID, rank, then team ID.
+func sortEventRankings(rankings []*EventRanking) {
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].EventID != rankings[j].EventID {
+			return rankings[i].EventID < rankings[j].EventID
+		}
+		if rankings[i].Rank != rankings[j].Rank {
+			return rankings[i].Rank < rankings[j].Rank
+		}
+		return rankings[i].TeamID < rankings[j].TeamID
+	})
+}
+
+// sortEventAdvancements sorts event advancements by event ID, then team ID.
+func sortEventAdvancements(advancements []*EventAdvancement) {
+	sort.Slice(advancements, func(i, j int) bool {
+		if advancements[i].EventID != advancements[j].EventID {
+			return advancements[i].EventID < advancements[j].EventID
+		}
+		return advancements[i].TeamID < advancements[j].TeamID
+	})
+}
+
+// sortEventTeams sorts event teams by event ID, then team ID.
+func sortEventTeams(teams []*EventTeam) {
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].EventID != teams[j].EventID {
+			return teams[i].EventID < teams[j].EventID
+		}
+		return teams[i].TeamID < teams[j].TeamID
+	})
+}
+
+// sortMatches sorts matches by event ID, tournament level in descending order so qualification matches come before
+// playoff matches, then match number.
+func sortMatches(matches []*Match) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].EventID != matches[j].EventID {
+			return matches[i].EventID < matches[j].EventID
+		}
+		if c := strings.Compare(matches[i].TournamentLevel, matches[j].TournamentLevel); c != 0 {
+			return c > 0
+		}
+		return matches[i].MatchNumber < matches[j].MatchNumber
+	})
+}
+
+// sortMatchTeams sorts match teams by match ID, alliance, then team ID.
+func sortMatchTeams(teams []*MatchTeam) {
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].MatchID != teams[j].MatchID {
+			return teams[i].MatchID < teams[j].MatchID
+		}
+		if teams[i].Alliance != teams[j].Alliance {
+			return teams[i].Alliance < teams[j].Alliance
+		}
+		return teams[i].TeamID < teams[j].TeamID
+	})
+}
+
+// sortRegionAliases sorts region aliases by alias, without regard to case.
+func sortRegionAliases(aliases []*RegionAlias) {
+	sort.Slice(aliases, func(i, j int) bool {
+		return strings.ToLower(aliases[i].Alias) < strings.ToLower(aliases[j].Alias)
+	})
+}
@@ -0,0 +1,34 @@
+package database
+
+// The Limit and Offset fields of a filter select a page of the records that match it, in the order the records are
+// documented to be returned. A Limit of 0 returns every record after the offset, and an Offset of 0 starts with the
+// first record. Negative values are treated as 0.
+
+// page returns the records on the page selected by limit and offset.
+func page[T any](records []T, limit, offset int) []T {
+	if offset > 0 {
+		if offset >= len(records) {
+			return records[:0]
+		}
+		records = records[offset:]
+	}
+	if limit > 0 && limit < len(records) {
+		records = records[:limit]
+	}
+	return records
+}
+
+// sqlPage returns the LIMIT clause that selects the page of rows, and its arguments, to be appended to a query
+// after its ORDER BY clause. MySQL doesn't allow an offset without a limit, so skipping rows without limiting the
+// rest uses the largest limit MySQL accepts.
+func sqlPage(limit, offset int) (string, []any) {
+	switch {
+	case limit > 0 && offset > 0:
+		return " LIMIT ? OFFSET ?", []any{limit, offset}
+	case limit > 0:
+		return " LIMIT ?", []any{limit}
+	case offset > 0:
+		return " LIMIT 18446744073709551615 OFFSET ?", []any{offset}
+	}
+	return "", nil
+}
@@ -0,0 +1,40 @@
+package database
+
+import "fmt"
+
+// EventReconciliation lists the records of an event that were removed because the data source no longer returns
+// them, such as a match that was deleted or a team that was removed from an event after it was first synced. It also
+// lists the data source's endpoints whose responses were saved because they changed since the last sync, and those
+// that were skipped because they hadn't.
+type EventReconciliation struct {
+	EventID      string              `json:"event_id"`
+	Awards       []*EventAward       `json:"awards"`
+	Rankings     []*EventRanking     `json:"rankings"`
+	Advancements []*EventAdvancement `json:"advancements"`
+	Matches      []*Match            `json:"matches"`
+	MatchTeams   []*MatchTeam        `json:"match_teams"` // Teams removed from matches that are still returned
+	EventTeams   []*EventTeam        `json:"event_teams"`
+	TeamRankings []*TeamRanking      `json:"team_rankings"`
+
+	UpdatedEndpoints []string `json:"updated_endpoints"`
+	SkippedEndpoints []string `json:"skipped_endpoints"`
+}
+
+// Count returns the total number of records removed from the event.
+func (er *EventReconciliation) Count() int {
+	return len(er.Awards) + len(er.Rankings) + len(er.Advancements) + len(er.Matches) + len(er.MatchTeams) +
+		len(er.EventTeams) + len(er.TeamRankings)
+}
+
+// Changed returns true if any of the event's records were saved or removed, so the results calculated from them,
+// such as the team rankings, need to be calculated again.
+func (er *EventReconciliation) Changed() bool {
+	return len(er.UpdatedEndpoints) > 0 || er.Count() > 0
+}
+
+// String returns a string representation of the EventReconciliation.
+func (er *EventReconciliation) String() string {
+	return fmt.Sprintf("EventReconciliation{EventID: %s, Awards: %d, Rankings: %d, Advancements: %d, Matches: %d, MatchTeams: %d, EventTeams: %d, TeamRankings: %d}",
+		er.EventID, len(er.Awards), len(er.Rankings), len(er.Advancements), len(er.Matches), len(er.MatchTeams),
+		len(er.EventTeams), len(er.TeamRankings))
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegionAlias maps a friendly name for a region, such as "North Carolina" or "NorCal", to the region's code, so
+// users don't need to know codes like USNC. Aliases are matched without regard to case or spacing, so each alias
+// may only map to a single region.
+type RegionAlias struct {
+	Alias      string `json:"alias"`
+	RegionCode string `json:"region_code"`
+}
+
+// String returns a string representation of the RegionAlias.
+func (ra *RegionAlias) String() string {
+	return fmt.Sprintf("RegionAlias{Alias: %q, RegionCode: %s}", ra.Alias, ra.RegionCode)
+}
+
+// NormalizeAlias returns an alias with surrounding whitespace removed and each run of whitespace within it
+// replaced by a single space, so "North  Carolina " is saved as "North Carolina".
+func NormalizeAlias(alias string) string {
+	return strings.Join(strings.Fields(alias), " ")
+}
+
+// aliasKey returns the key an alias is matched by, which ignores case and spacing.
+func aliasKey(alias string) string {
+	return strings.ToLower(NormalizeAlias(alias))
+}
+
+// DefaultRegionAliases returns the aliases that are saved when the region aliases are seeded. Each state with a
+// single region is known by its name and postal abbreviation, and the states split into several regions are known
+// by the common names of those regions.
+func DefaultRegionAliases() []*RegionAlias {
+	var aliases []*RegionAlias
+	for _, state := range singleRegionStates {
+		regionCode := "US" + state.abbreviation
+		aliases = append(aliases,
+			&RegionAlias{Alias: state.name, RegionCode: regionCode},
+			&RegionAlias{Alias: state.abbreviation, RegionCode: regionCode},
+		)
+	}
+	for alias, regionCode := range splitStateRegions {
+		aliases = append(aliases, &RegionAlias{Alias: alias, RegionCode: regionCode})
+	}
+	sortRegionAliases(aliases)
+	return aliases
+}
+
+// singleRegionStates are the states whose region code is US followed by the state's postal abbreviation.
+var singleRegionStates = []struct {
+	name         string
+	abbreviation string
+}{
+	{"Alabama", "AL"},
+	{"Alaska", "AK"},
+	{"Arizona", "AZ"},
+	{"Arkansas", "AR"},
+	{"Colorado", "CO"},
+	{"Connecticut", "CT"},
+	{"Delaware", "DE"},
+	{"Florida", "FL"},
+	{"Georgia", "GA"},
+	{"Hawaii", "HI"},
+	{"Idaho", "ID"},
+	{"Illinois", "IL"},
+	{"Indiana", "IN"},
+	{"Iowa", "IA"},
+	{"Kentucky", "KY"},
+	{"Louisiana", "LA"},
+	{"Maine", "ME"},
+	{"Massachusetts", "MA"},
+	{"Michigan", "MI"},
+	{"Minnesota", "MN"},
+	{"Mississippi", "MS"},
+	{"Montana", "MT"},
+	{"Nebraska", "NE"},
+	{"Nevada", "NV"},
+	{"New Hampshire", "NH"},
+	{"New Jersey", "NJ"},
+	{"New Mexico", "NM"},
+	{"North Carolina", "NC"},
+	{"North Dakota", "ND"},
+	{"Ohio", "OH"},
+	{"Oklahoma", "OK"},
+	{"Oregon", "OR"},
+	{"Pennsylvania", "PA"},
+	{"Rhode Island", "RI"},
+	{"South Carolina", "SC"},
+	{"South Dakota", "SD"},
+	{"Tennessee", "TN"},
+	{"Utah", "UT"},
+	{"Vermont", "VT"},
+	{"Virginia", "VA"},
+	{"Washington", "WA"},
+	{"West Virginia", "WV"},
+	{"Wisconsin", "WI"},
+	{"Wyoming", "WY"},
+}
+
+// splitStateRegions maps the common names of the regions within states that are split into several regions to
+// their region codes.
+var splitStateRegions = map[string]string{
+	"NorCal":                 "USCANO",
+	"Northern California":    "USCANO",
+	"Los Angeles":            "USCALA",
+	"San Diego":              "USCASD",
+	"New York City":          "USNYNY",
+	"NYC":                    "USNYNY",
+	"Long Island":            "USNYLI",
+	"Excelsior":              "USNYEX",
+	"Upstate New York":       "USNYEX",
+	"Central Texas":          "USTXCE",
+	"Houston":                "USTXHO",
+	"North Texas":            "USTXNO",
+	"South Texas":            "USTXSO",
+	"West Texas":             "USTXWP",
+	"West Texas & Panhandle": "USTXWP",
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/joho/godotenv"
+)
+
+// SeasonUsage reports how much data is stored for a season.
+type SeasonUsage struct {
+	Season  string `json:"season"`
+	Events  int    `json:"events"`
+	Matches int    `json:"matches"`
+	Bytes   int64  `json:"bytes"` // Size on disk, or -1 if the database does not report it per season
+}
+
+// String returns a string representation of the SeasonUsage.
+func (su *SeasonUsage) String() string {
+	return fmt.Sprintf("SeasonUsage{Season: %s, Events: %d, Matches: %d, Bytes: %d}",
+		su.Season, su.Events, su.Matches, su.Bytes)
+}
+
+// GetSeasonUsage reports the data stored for every season, ordered from the most recent season to the oldest.
+// For file-based databases each season is a directory under FILEDB_DATA_DIR. For SQL databases the seasons are
+// the event years in the DATA_SOURCE_NAME database, along with the seasons kept in their own
+// DATA_SOURCE_NAME_<season> database.
+func GetSeasonUsage() ([]*SeasonUsage, error) {
+	godotenv.Load()
+	var usage []*SeasonUsage
+	var err error
+	switch dbType := os.Getenv("DB_TYPE"); dbType {
+	case "sql":
+		usage, err = getSQLSeasonUsage()
+	case "file":
+		usage, err = getFileSeasonUsage()
+	case "":
+		return nil, errors.New("DB_TYPE environment variable not set")
+	default:
+		return nil, fmt.Errorf("unsupported DB_TYPE: %s", dbType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(usage, func(a, b *SeasonUsage) int {
+		return seasonYear(b.Season) - seasonYear(a.Season)
+	})
+	return usage, nil
+}
+
+// PruneSeasons deletes the data for all but the most recent keep seasons and returns the seasons that were
+// deleted. If dryRun is true, nothing is deleted and the seasons that would have been deleted are returned.
+func PruneSeasons(keep int, dryRun bool) ([]string, error) {
+	if keep < 1 {
+		return nil, errors.New("at least one season must be kept")
+	}
+
+	usage, err := GetSeasonUsage()
+	if err != nil {
+		return nil, err
+	}
+	if len(usage) <= keep {
+		return nil, nil
+	}
+
+	var seasons []string
+	for _, su := range usage[keep:] {
+		seasons = append(seasons, su.Season)
+	}
+	if dryRun {
+		return seasons, nil
+	}
+
+	switch os.Getenv("DB_TYPE") {
+	case "sql":
+		err = pruneSQLSeasons(seasons)
+	default:
+		err = pruneFileSeasons(seasons)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+// seasonYear returns the year of a season, or 0 if the season is not a year.
+func seasonYear(season string) int {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return 0
+	}
+	return year
+}
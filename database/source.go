@@ -0,0 +1,16 @@
+package database
+
+import "fmt"
+
+// EventSourceKey maps the key that an external data source uses for an event to the ID of the event, so the event
+// can be found again when data is next imported from the source. Source and SourceKey together form the primary key.
+type EventSourceKey struct {
+	Source    string `json:"source"`
+	SourceKey string `json:"source_key"`
+	EventID   string `json:"event_id"`
+}
+
+// String returns a string representation of the EventSourceKey.
+func (k *EventSourceKey) String() string {
+	return fmt.Sprintf("EventSourceKey{Source: %s, SourceKey: %s, EventID: %s}", k.Source, k.SourceKey, k.EventID)
+}
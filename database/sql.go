@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -14,26 +17,66 @@ import (
 
 // sqldb wraps a sql.DB and provides prepared statements for common operations.
 type sqldb struct {
-	ctx   context.Context
-	sqldb *sql.DB
-	stmts map[string]*sql.Stmt
+	ctx       context.Context
+	cancel    context.CancelFunc // Stops the health checks when the database is closed
+	sqldb     *sql.DB
+	stmts     map[string]*sql.Stmt
+	readDB    *sql.DB              // Reads are made on the read replica, if one is configured, or else on sqldb
+	readStmts map[string]*sql.Stmt // The statements that read data, prepared on readDB
+
+	queryTimeout       time.Duration // Queries are canceled once they take this long; 0 if they aren't
+	slowQueryThreshold time.Duration // Queries that take at least this long are logged; 0 if none are
 }
 
+const (
+	// defaultQueryTimeout is how long a query may take before it is canceled, if SQL_QUERY_TIMEOUT isn't set.
+	defaultQueryTimeout = 30 * time.Second
+
+	// defaultSlowQueryThreshold is how long a query may take before it is logged as slow, if
+	// SQL_SLOW_QUERY_THRESHOLD isn't set.
+	defaultSlowQueryThreshold = time.Second
+)
+
 // initDB initializes the database connection.
-func initSQLDB() (*sqldb, error) {
+// season is an optional parameter. If provided and the DATA_SOURCE_NAME_<season> environment variable is set,
+// it is used as the connection string so each season can be kept in its own database. Otherwise the
+// DATA_SOURCE_NAME environment variable is used. If useReplica is true and a read replica is configured for the
+// database, reads are made on the replica.
+func initSQLDB(useReplica bool, season ...string) (*sqldb, error) {
 	godotenv.Load()
-	dsn := os.Getenv("DATA_SOURCE_NAME")
+	var dsn, replicaDSN string
+	if len(season) > 0 && season[0] != "" {
+		dsn = os.Getenv("DATA_SOURCE_NAME_" + season[0])
+		replicaDSN = os.Getenv("READ_DATA_SOURCE_NAME_" + season[0])
+	}
+	if dsn == "" {
+		dsn = os.Getenv("DATA_SOURCE_NAME")
+		// The replica of DATA_SOURCE_NAME isn't used for a season kept in its own database
+		if replicaDSN == "" {
+			replicaDSN = os.Getenv("READ_DATA_SOURCE_NAME")
+		}
+	}
 	if dsn == "" {
 		return nil, errors.New("DATA_SOURCE_NAME environment variable not set")
 	}
+	queryTimeout, err := durationFromEnv("SQL_QUERY_TIMEOUT", defaultQueryTimeout)
+	if err != nil {
+		return nil, err
+	}
+	slowQueryThreshold, err := durationFromEnv("SQL_SLOW_QUERY_THRESHOLD", defaultSlowQueryThreshold)
+	if err != nil {
+		return nil, err
+	}
 
-	ctx := context.Background()
-	var err error
+	ctx, cancel := context.WithCancel(context.Background())
 	sqlDB, err := sql.Open("mysql", dsn)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 	if err := sqlDB.PingContext(ctx); err != nil {
+		cancel()
+		sqlDB.Close()
 		return nil, err
 	}
 	// Set database connection pool settings
@@ -42,22 +85,66 @@ func initSQLDB() (*sqldb, error) {
 	sqlDB.SetMaxIdleConns(10) // Make it the same as MaxOpenConns
 
 	db := &sqldb{
-		ctx:   ctx,
-		sqldb: sqlDB,
-		stmts: make(map[string]*sql.Stmt),
+		ctx:       ctx,
+		cancel:    cancel,
+		sqldb:     sqlDB,
+		stmts:     make(map[string]*sql.Stmt),
+		readDB:    sqlDB,
+		readStmts: make(map[string]*sql.Stmt),
+
+		queryTimeout:       queryTimeout,
+		slowQueryThreshold: slowQueryThreshold,
 	}
-	db.initStatements()
+	if err := db.migrateSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate the database schema: %w", err)
+	}
+	if useReplica && replicaDSN != "" {
+		readDB, err := openReadReplica(dsn, replicaDSN)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to open the read replica: %w", err)
+		}
+		db.readDB = readDB
+	}
+	if err := db.initStatements(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	go db.monitor()
 
 	return db, nil
 
 }
 
-// CloseDB closes all prepared statements.
+// durationFromEnv returns the duration in the environment variable, such as 30s, or the default if it isn't set.
+func durationFromEnv(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a duration such as 30s, or 0 to disable", key, value)
+	}
+	return d, nil
+}
+
+// CloseDB closes all prepared statements and the database connection.
 func (db *sqldb) Close() {
+	db.cancel()
 	for _, stmt := range db.stmts {
 		stmt.Close()
 	}
 	db.stmts = make(map[string]*sql.Stmt)
+	if db.readDB != db.sqldb {
+		for _, stmt := range db.readStmts {
+			stmt.Close()
+		}
+		db.readDB.Close()
+	}
+	db.readStmts = make(map[string]*sql.Stmt)
+	db.sqldb.Close()
 }
 
 // InitStatements initializes all prepared statements for the dbmodel package.
@@ -74,17 +161,61 @@ func (db *sqldb) initStatements() error {
 	if err := db.initTeamStatements(); err != nil {
 		return err
 	}
+	if err := db.initChangeStatements(); err != nil {
+		return err
+	}
+	if err := db.initSyncStatements(); err != nil {
+		return err
+	}
+	if err := db.initEventSummaryStatements(); err != nil {
+		return err
+	}
+	if err := db.initEventSourceKeyStatements(); err != nil {
+		return err
+	}
+	if err := db.initRegionAliasStatements(); err != nil {
+		return err
+	}
+	if err := db.initAdvancementCutoffStatements(); err != nil {
+		return err
+	}
+	if err := db.initEventSyncStatements(); err != nil {
+		return err
+	}
+	if err := db.initEndpointHashStatements(); err != nil {
+		return err
+	}
+	if err := db.initSyncRunStatements(); err != nil {
+		return err
+	}
+	if err := db.initSyncRetryStatements(); err != nil {
+		return err
+	}
+	if err := db.initTeamHistoryStatements(); err != nil {
+		return err
+	}
+	if err := db.initTeamNoteStatements(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-// PrepareStatement prepares and caches a SQL statement.
+// PrepareStatement prepares and caches a SQL statement. Statements that read data, whose names start with "get",
+// are also prepared on the read replica.
 func (db *sqldb) prepareStatement(name, query string) error {
 	stmt, err := db.sqldb.Prepare(query)
 	if err != nil {
 		return err
 	}
 	db.stmts[name] = stmt
+	if db.readDB != db.sqldb && strings.HasPrefix(name, "get") {
+		stmt, err := db.readDB.Prepare(query)
+		if err != nil {
+			return err
+		}
+		db.readStmts[name] = stmt
+	}
 	return nil
 }
 
@@ -92,3 +223,31 @@ func (db *sqldb) prepareStatement(name, query string) error {
 func (db *sqldb) getStatement(name string) *sql.Stmt {
 	return db.stmts[name]
 }
+
+// readStatement retrieves a prepared statement that reads data by name, prepared on the read replica if one is
+// configured.
+func (db *sqldb) readStatement(name string) *sql.Stmt {
+	if stmt, ok := db.readStmts[name]; ok {
+		return stmt
+	}
+	return db.stmts[name]
+}
+
+// startQuery returns the context a query is made with, which is canceled once the query timeout has passed so a
+// hung query doesn't hold its connection and the caller indefinitely, and the function to call when the query is
+// done. The function logs the query if it took at least the slow query threshold. The name identifies the query in
+// the log.
+func (db *sqldb) startQuery(name string) (context.Context, func()) {
+	start := time.Now()
+	ctx, cancel := db.ctx, context.CancelFunc(func() {})
+	if db.queryTimeout > 0 {
+		ctx, cancel = context.WithTimeout(db.ctx, db.queryTimeout)
+	}
+	return ctx, func() {
+		cancel()
+		elapsed := time.Since(start)
+		if db.slowQueryThreshold > 0 && elapsed >= db.slowQueryThreshold {
+			slog.Warn("Slow query", "query", name, "duration", elapsed, "threshold", db.slowQueryThreshold, "timedOut", errors.Is(ctx.Err(), context.DeadlineExceeded))
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import "fmt"
+
+// initAdvancementCutoffStatements prepares all SQL statements for advancement cutoff operations.
+func (db *sqldb) initAdvancementCutoffStatements() error {
+	queries := map[string]string{
+		"saveAdvancementCutoff": "INSERT INTO advancement_cutoffs (event_id, teams, advancing, cutoff) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE teams = VALUES(teams), advancing = VALUES(advancing), cutoff = VALUES(cutoff)",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetAdvancementCutoffs retrieves advancement cutoffs with optional filters.
+// If no filters are provided, returns all advancement cutoffs.
+func (db *sqldb) GetAdvancementCutoffs(filters ...AdvancementCutoffFilter) ([]*AdvancementCutoff, error) {
+	ctx, done := db.startQuery("GetAdvancementCutoffs")
+	defer done()
+
+	// Build dynamic query
+	query := "SELECT event_id, teams, advancing, cutoff FROM advancement_cutoffs WHERE 1=1"
+	args := []interface{}{}
+
+	if len(filters) > 0 {
+		filter := filters[0]
+
+		// Add EventID filter
+		if len(filter.EventIDs) > 0 {
+			query += " AND event_id IN ("
+			for i, id := range filter.EventIDs {
+				if i > 0 {
+					query += ","
+				}
+				query += "?"
+				args = append(args, id)
+			}
+			query += ")"
+		}
+	}
+
+	query += " ORDER BY event_id"
+
+	// Execute query
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cutoffs []*AdvancementCutoff
+	for rows.Next() {
+		var cutoff AdvancementCutoff
+		err := rows.Scan(
+			&cutoff.EventID,
+			&cutoff.Teams,
+			&cutoff.Advancing,
+			&cutoff.Cutoff,
+		)
+		if err != nil {
+			continue
+		}
+		cutoffs = append(cutoffs, &cutoff)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return cutoffs, nil
+}
+
+// SaveAdvancementCutoff saves the advancement cutoff of an event, replacing any earlier cutoff for the event.
+func (db *sqldb) SaveAdvancementCutoff(cutoff *AdvancementCutoff) error {
+	ctx, done := db.startQuery("SaveAdvancementCutoff")
+	defer done()
+
+	stmt := db.getStatement("saveAdvancementCutoff")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		cutoff.EventID,
+		cutoff.Teams,
+		cutoff.Advancing,
+		cutoff.Cutoff,
+	)
+	return err
+}
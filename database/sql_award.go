@@ -1,12 +1,16 @@
 package database
 
-import "fmt"
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
 
 // InitAwardStatements prepares all SQL statements for award operations.
 func (db *sqldb) initAwardStatements() error {
 	queries := map[string]string{
 		"getAward":     "SELECT award_id, name, description, for_person FROM awards WHERE award_id = ?",
-		"getAllAwards": "SELECT award_id, name, description, for_person FROM awards",
+		"getAllAwards": "SELECT award_id, name, description, for_person FROM awards ORDER BY award_id",
 		"saveAward":    "INSERT INTO awards (award_id, name, description, for_person) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), description = VALUES(description), for_person = VALUES(for_person)",
 	}
 
@@ -20,30 +24,39 @@ func (db *sqldb) initAwardStatements() error {
 
 // GetAward retrieves an award from a database by its ID.
 func (db *sqldb) GetAward(awardID int) (*Award, error) {
+	ctx, done := db.startQuery("GetAward")
+	defer done()
+
 	var award Award
-	stmt := db.getStatement("getAward")
+	stmt := db.readStatement("getAward")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	err := stmt.QueryRow(awardID).Scan(
+	err := stmt.QueryRowContext(ctx, awardID).Scan(
 		&award.AwardID,
 		&award.Name,
 		&award.Description,
 		&award.ForPerson,
 	)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	return &award, nil
 }
 
 // GetAllAwards retrieves all awards from the
 func (db *sqldb) GetAllAwards() ([]*Award, error) {
-	stmt := db.getStatement("getAllAwards")
+	ctx, done := db.startQuery("GetAllAwards")
+	defer done()
+
+	stmt := db.readStatement("getAllAwards")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query()
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -63,15 +76,21 @@ func (db *sqldb) GetAllAwards() ([]*Award, error) {
 		}
 		awards = append(awards, &award)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return awards, nil
 }
 
 // SaveAward saves or updates an award in the
 func (db *sqldb) SaveAward(award *Award) error {
+	ctx, done := db.startQuery("SaveAward")
+	defer done()
+
 	stmt := db.getStatement("saveAward")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(award.AwardID, award.Name, award.Description, award.ForPerson)
+	_, err := stmt.ExecContext(ctx, award.AwardID, award.Name, award.Description, award.ForPerson)
 	return err
 }
@@ -0,0 +1,403 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InitChangeStatements prepares all SQL statements for change feed operations.
+// Each table maintains an updated_at column that MySQL sets whenever a row is inserted or one of its values changes.
+func (db *sqldb) initChangeStatements() error {
+	queries := map[string]string{
+		"getChangedAwards":              "SELECT award_id, name, description, for_person, updated_at FROM awards WHERE updated_at > ? ORDER BY award_id",
+		"getChangedTeams":               "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name, updated_at FROM teams WHERE updated_at > ? ORDER BY team_id",
+		"getChangedTeamRankings":        "SELECT team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg, auto_opr, updated_at FROM team_rankings WHERE updated_at > ? ORDER BY event_id, team_id",
+		"getChangedEvents":              "SELECT event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end, latitude, longitude, unofficial, updated_at FROM events WHERE updated_at > ? ORDER BY event_id",
+		"getChangedEventAwards":         "SELECT event_id, team_id, award_id, name, series, person, updated_at FROM event_awards WHERE updated_at > ? ORDER BY event_id, team_id",
+		"getChangedEventRankings":       "SELECT event_id, team_id, rank, sort_order1, sort_order2, sort_order3, sort_order4, sort_order5, sort_order6, wins, losses, ties, dq, matches_played, matches_counted, source, updated_at FROM event_rankings WHERE updated_at > ? ORDER BY event_id, team_id",
+		"getChangedEventAdvancements":   "SELECT event_id, team_id, status, updated_at FROM event_advancements WHERE updated_at > ? ORDER BY event_id, team_id",
+		"getChangedEventTeams":          "SELECT event_id, team_id, registered, played, updated_at FROM event_teams WHERE updated_at > ? ORDER BY event_id, team_id",
+		"getChangedMatches":             "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source, updated_at FROM matches WHERE updated_at > ? ORDER BY match_id",
+		"getChangedMatchAllianceScores": "SELECT match_id, alliance, auto_points, teleop_points, foul_points_committed, pre_foul_total, total_points, major_fouls, minor_fouls, updated_at FROM match_alliance_scores WHERE updated_at > ? ORDER BY match_id, alliance",
+		"getChangedMatchTeams":          "SELECT match_id, team_id, alliance, dq, on_field, updated_at FROM match_teams WHERE updated_at > ? ORDER BY match_id, team_id",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetChanges retrieves all records that were created or changed after the given time.
+func (db *sqldb) GetChanges(since time.Time) (*ChangeSet, error) {
+	changes := &ChangeSet{Since: since}
+
+	// Awards
+	rows, err := db.queryChanges("getChangedAwards", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var award Award
+		if err := rows.Scan(&award.AwardID, &award.Name, &award.Description, &award.ForPerson, &award.UpdatedAt); err != nil {
+			continue
+		}
+		changes.Awards = append(changes.Awards, &award)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Teams
+	rows, err = db.queryChanges("getChangedTeams", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var team Team
+		err := rows.Scan(
+			&team.TeamID,
+			&team.Name,
+			&team.FullName,
+			&team.City,
+			&team.StateProv,
+			&team.Country,
+			&team.Website,
+			&team.RookieYear,
+			&team.HomeRegion,
+			&team.RobotName,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		changes.Teams = append(changes.Teams, &team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Team rankings
+	rows, err = db.queryChanges("getChangedTeamRankings", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var ranking TeamRanking
+		err := rows.Scan(
+			&ranking.TeamID,
+			&ranking.EventID,
+			&ranking.NumMatches,
+			&ranking.CCWM,
+			&ranking.OPR,
+			&ranking.NpOPR,
+			&ranking.DPR,
+			&ranking.NpDPR,
+			&ranking.NpAvg,
+			&ranking.AutoOPR,
+			&ranking.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		changes.TeamRankings = append(changes.TeamRankings, &ranking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Events
+	rows, err = db.queryChanges("getChangedEvents", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var event Event
+		err := rows.Scan(
+			&event.EventID,
+			&event.EventCode,
+			&event.Year,
+			&event.Name,
+			&event.Type,
+			&event.DivisionCode,
+			&event.RegionCode,
+			&event.LeagueCode,
+			&event.Venue,
+			&event.Address,
+			&event.City,
+			&event.StateProv,
+			&event.Country,
+			&event.Timezone,
+			&event.DateStart,
+			&event.DateEnd,
+			&event.Latitude,
+			&event.Longitude,
+			&event.Unofficial,
+			&event.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		changes.Events = append(changes.Events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Event awards
+	rows, err = db.queryChanges("getChangedEventAwards", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var ea EventAward
+		if err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.AwardID, &ea.Name, &ea.Series, &ea.Person, &ea.UpdatedAt); err != nil {
+			continue
+		}
+		changes.EventAwards = append(changes.EventAwards, &ea)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Event rankings
+	rows, err = db.queryChanges("getChangedEventRankings", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var er EventRanking
+		err := rows.Scan(
+			&er.EventID,
+			&er.TeamID,
+			&er.Rank,
+			&er.SortOrder1,
+			&er.SortOrder2,
+			&er.SortOrder3,
+			&er.SortOrder4,
+			&er.SortOrder5,
+			&er.SortOrder6,
+			&er.Wins,
+			&er.Losses,
+			&er.Ties,
+			&er.Dq,
+			&er.MatchesPlayed,
+			&er.MatchesCounted,
+			&er.Source,
+			&er.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		changes.EventRankings = append(changes.EventRankings, &er)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Event advancements
+	rows, err = db.queryChanges("getChangedEventAdvancements", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var ea EventAdvancement
+		if err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.Status, &ea.UpdatedAt); err != nil {
+			continue
+		}
+		changes.EventAdvancements = append(changes.EventAdvancements, &ea)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Event teams
+	rows, err = db.queryChanges("getChangedEventTeams", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var et EventTeam
+		if err := rows.Scan(&et.EventID, &et.TeamID, &et.Registered, &et.Played, &et.UpdatedAt); err != nil {
+			continue
+		}
+		changes.EventTeams = append(changes.EventTeams, &et)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Matches
+	rows, err = db.queryChanges("getChangedMatches", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var match Match
+		err := rows.Scan(
+			&match.MatchID,
+			&match.EventID,
+			&match.MatchType,
+			&match.MatchNumber,
+			(*nullTime)(&match.ActualStartTime),
+			&match.Description,
+			&match.TournamentLevel,
+			&match.Source,
+			&match.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		changes.Matches = append(changes.Matches, &match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Match alliance scores
+	rows, err = db.queryChanges("getChangedMatchAllianceScores", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var score MatchAllianceScore
+		err := rows.Scan(
+			&score.MatchID,
+			&score.Alliance,
+			&score.AutoPoints,
+			&score.TeleopPoints,
+			&score.FoulPointsCommitted,
+			&score.PreFoulTotal,
+			&score.TotalPoints,
+			&score.MajorFouls,
+			&score.MinorFouls,
+			&score.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		changes.MatchAllianceScores = append(changes.MatchAllianceScores, &score)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Match teams
+	rows, err = db.queryChanges("getChangedMatchTeams", since)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var mt MatchTeam
+		if err := rows.Scan(&mt.MatchID, &mt.TeamID, &mt.Alliance, &mt.Dq, &mt.OnField, &mt.UpdatedAt); err != nil {
+			continue
+		}
+		changes.MatchTeams = append(changes.MatchTeams, &mt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	return changes, nil
+}
+
+// queryChanges runs the named change feed statement for records updated after since.
+func (db *sqldb) queryChanges(name string, since time.Time) (*sql.Rows, error) {
+	ctx, done := db.startQuery("queryChanges")
+	defer done()
+
+	stmt := db.getStatement(name)
+	if stmt == nil {
+		return nil, fmt.Errorf("prepared statement not found")
+	}
+	return stmt.QueryContext(ctx, since)
+}
+
+// lastUpdatedEventTables are the tables whose rows belong to an event, which are limited to the filtered events
+// when finding the time data was last changed.
+var lastUpdatedEventTables = []string{
+	"events",
+	"event_awards",
+	"event_rankings",
+	"event_advancements",
+	"event_teams",
+	"team_rankings",
+	"matches",
+	"event_summary",
+}
+
+// lastUpdatedMatchTables are the tables whose rows belong to a match.
+var lastUpdatedMatchTables = []string{
+	"match_alliance_scores",
+	"match_teams",
+}
+
+// GetLastUpdated returns the latest time any record was created or changed, or the zero time if there are no
+// records. If the filter lists event IDs, only the records of those events are considered, along with the teams
+// and award definitions.
+func (db *sqldb) GetLastUpdated(filters ...LastUpdatedFilter) (time.Time, error) {
+	ctx, done := db.startQuery("GetLastUpdated")
+	defer done()
+
+	// Build the condition that limits the rows to the filtered events
+	var eventIDs []string
+	if len(filters) > 0 {
+		eventIDs = filters[0].EventIDs
+	}
+	condition := ""
+	if len(eventIDs) > 0 {
+		condition = " WHERE %s IN (" + strings.TrimSuffix(strings.Repeat("?,", len(eventIDs)), ",") + ")"
+	}
+
+	selects := []string{
+		"SELECT MAX(updated_at) AS updated_at FROM awards",
+		"SELECT MAX(updated_at) FROM teams",
+	}
+	args := []interface{}{}
+	addEventArgs := func() {
+		for _, id := range eventIDs {
+			args = append(args, id)
+		}
+	}
+	for _, table := range lastUpdatedEventTables {
+		query := "SELECT MAX(updated_at) FROM " + table
+		if condition != "" {
+			query += fmt.Sprintf(condition, "event_id")
+			addEventArgs()
+		}
+		selects = append(selects, query)
+	}
+	for _, table := range lastUpdatedMatchTables {
+		query := fmt.Sprintf("SELECT MAX(c.updated_at) FROM %s c INNER JOIN matches m ON c.match_id = m.match_id", table)
+		if condition != "" {
+			query += fmt.Sprintf(condition, "m.event_id")
+			addEventArgs()
+		}
+		selects = append(selects, query)
+	}
+	query := "SELECT MAX(updated_at) FROM (" + strings.Join(selects, " UNION ALL ") + ") u"
+
+	var latest sql.NullTime
+	if err := db.readDB.QueryRowContext(ctx, query, args...).Scan(&latest); err != nil {
+		return time.Time{}, err
+	}
+	if !latest.Valid {
+		return time.Time{}, nil
+	}
+	return latest.Time, nil
+}
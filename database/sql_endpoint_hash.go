@@ -0,0 +1,71 @@
+package database
+
+import "fmt"
+
+// initEndpointHashStatements prepares all SQL statements for endpoint hash operations.
+func (db *sqldb) initEndpointHashStatements() error {
+	queries := map[string]string{
+		"getEndpointHashes": "SELECT event_id, endpoint, hash, hashed_at FROM endpoint_hashes WHERE event_id = ? ORDER BY endpoint",
+		"saveEndpointHash":  "INSERT INTO endpoint_hashes (event_id, endpoint, hash, hashed_at) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE hash = VALUES(hash), hashed_at = VALUES(hashed_at)",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetEndpointHashes retrieves the hashes of the last responses from the data source's endpoints for an event.
+func (db *sqldb) GetEndpointHashes(eventID string) ([]*EndpointHash, error) {
+	ctx, done := db.startQuery("GetEndpointHashes")
+	defer done()
+
+	stmt := db.readStatement("getEndpointHashes")
+	if stmt == nil {
+		return nil, fmt.Errorf("prepared statement not found")
+	}
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []*EndpointHash
+	for rows.Next() {
+		var hash EndpointHash
+		err := rows.Scan(
+			&hash.EventID,
+			&hash.Endpoint,
+			&hash.Hash,
+			&hash.HashedAt,
+		)
+		if err != nil {
+			continue
+		}
+		hashes = append(hashes, &hash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// SaveEndpointHash records the hash of the last response from an endpoint for an event, replacing any earlier hash.
+func (db *sqldb) SaveEndpointHash(hash *EndpointHash) error {
+	ctx, done := db.startQuery("SaveEndpointHash")
+	defer done()
+
+	stmt := db.getStatement("saveEndpointHash")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		hash.EventID,
+		hash.Endpoint,
+		hash.Hash,
+		hash.HashedAt,
+	)
+	return err
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -8,18 +10,22 @@ import (
 // InitEventStatements prepares all SQL statements for event operations.
 func (db *sqldb) initEventStatements() error {
 	queries := map[string]string{
-		"getEvent":                "SELECT event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end FROM events WHERE event_id = ?",
-		"saveEvent":               "INSERT INTO events (event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE event_code = VALUES(event_code), year = VALUES(year), name = VALUES(name), type = VALUES(type), division_code = VALUES(division_code), region_code = VALUES(region_code), league_code = VALUES(league_code), venue = VALUES(venue), address = VALUES(address), city = VALUES(city), state_prov = VALUES(state_prov), country = VALUES(country), timezone = VALUES(timezone), date_start = VALUES(date_start), date_end = VALUES(date_end)",
-		"getEventAwards":          "SELECT event_id, team_id, award_id, name, series FROM event_awards WHERE event_id = ?",
-		"saveEventAward":          "INSERT INTO event_awards (event_id, team_id, award_id, name, series) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), series = VALUES(series)",
-		"getTeamAwardsByEvent":    "SELECT event_id, team_id, award_id, name, series FROM event_awards WHERE event_id = ? AND team_id = ?",
-		"getAllTeamAwards":        "SELECT event_id, team_id, award_id, name, series FROM event_awards WHERE team_id = ? ORDER BY event_id",
-		"getEventRankings":        "SELECT event_id, team_id, rank, sort_order1, sort_order2, sort_order3, sort_order4, sort_order5, sort_order6, wins, losses, ties, dq, matches_played, matches_counted FROM event_rankings WHERE event_id = ?",
-		"saveEventRanking":        "INSERT INTO event_rankings (event_id, team_id, rank, sort_order1, sort_order2, sort_order3, sort_order4, sort_order5, sort_order6, wins, losses, ties, dq, matches_played, matches_counted) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE rank = VALUES(rank), sort_order1 = VALUES(sort_order1), sort_order2 = VALUES(sort_order2), sort_order3 = VALUES(sort_order3), sort_order4 = VALUES(sort_order4), sort_order5 = VALUES(sort_order5), sort_order6 = VALUES(sort_order6), wins = VALUES(wins), losses = VALUES(losses), ties = VALUES(ties), dq = VALUES(dq), matches_played = VALUES(matches_played), matches_counted = VALUES(matches_counted)",
-		"getEventAdvancements":    "SELECT event_id, team_id, status FROM event_advancements WHERE event_id = ?",
+		"getEvent":                "SELECT event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end, latitude, longitude, unofficial FROM events WHERE event_id = ?",
+		"saveEvent":               "INSERT INTO events (event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end, latitude, longitude, unofficial) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE event_code = VALUES(event_code), year = VALUES(year), name = VALUES(name), type = VALUES(type), division_code = VALUES(division_code), region_code = VALUES(region_code), league_code = VALUES(league_code), venue = VALUES(venue), address = VALUES(address), city = VALUES(city), state_prov = VALUES(state_prov), country = VALUES(country), timezone = VALUES(timezone), date_start = VALUES(date_start), date_end = VALUES(date_end), latitude = VALUES(latitude), longitude = VALUES(longitude), unofficial = VALUES(unofficial)",
+		"getEventAwards":          "SELECT event_id, team_id, award_id, name, series, person FROM event_awards WHERE event_id = ? ORDER BY award_id, series, team_id",
+		"saveEventAward":          "INSERT INTO event_awards (event_id, team_id, award_id, name, series, person) VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), series = VALUES(series), person = VALUES(person)",
+		"deleteEventAward":        "DELETE FROM event_awards WHERE event_id = ? AND team_id = ? AND award_id = ? AND series = ?",
+		"getTeamAwardsByEvent":    "SELECT event_id, team_id, award_id, name, series, person FROM event_awards WHERE event_id = ? AND team_id = ? ORDER BY award_id, series",
+		"getAllTeamAwards":        "SELECT event_id, team_id, award_id, name, series, person FROM event_awards WHERE team_id = ? ORDER BY event_id, award_id, series",
+		"getEventRankings":        "SELECT event_id, team_id, rank, sort_order1, sort_order2, sort_order3, sort_order4, sort_order5, sort_order6, wins, losses, ties, dq, matches_played, matches_counted, source FROM event_rankings WHERE event_id = ? ORDER BY rank, team_id",
+		"saveEventRanking":        "INSERT INTO event_rankings (event_id, team_id, rank, sort_order1, sort_order2, sort_order3, sort_order4, sort_order5, sort_order6, wins, losses, ties, dq, matches_played, matches_counted, source) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE rank = VALUES(rank), sort_order1 = VALUES(sort_order1), sort_order2 = VALUES(sort_order2), sort_order3 = VALUES(sort_order3), sort_order4 = VALUES(sort_order4), sort_order5 = VALUES(sort_order5), sort_order6 = VALUES(sort_order6), wins = VALUES(wins), losses = VALUES(losses), ties = VALUES(ties), dq = VALUES(dq), matches_played = VALUES(matches_played), matches_counted = VALUES(matches_counted), source = VALUES(source)",
+		"deleteEventRanking":      "DELETE FROM event_rankings WHERE event_id = ? AND team_id = ?",
+		"getEventAdvancements":    "SELECT event_id, team_id, status FROM event_advancements WHERE event_id = ? ORDER BY team_id",
 		"saveEventAdvancement":    "INSERT INTO event_advancements (event_id, team_id, status) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE status = VALUES(status)",
-		"getEventTeams":           "SELECT event_id, team_id FROM event_teams WHERE event_id = ?",
-		"saveEventTeam":           "INSERT INTO event_teams (event_id, team_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE event_id = event_id",
+		"deleteEventAdvancement":  "DELETE FROM event_advancements WHERE event_id = ? AND team_id = ?",
+		"getEventTeams":           "SELECT event_id, team_id, registered, played FROM event_teams WHERE event_id = ? ORDER BY team_id",
+		"saveEventTeam":           "INSERT INTO event_teams (event_id, team_id, registered, played) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE registered = VALUES(registered), played = VALUES(played)",
+		"deleteEventTeam":         "DELETE FROM event_teams WHERE event_id = ? AND team_id = ?",
 		"getEventsByTeam":         "SELECT DISTINCT event_id FROM event_teams WHERE team_id = ? ORDER BY event_id",
 		"getAllAdvancements":      "SELECT event_id, team_id, status FROM event_advancements ORDER BY event_id, team_id",
 		"getRegionCodes":          "SELECT DISTINCT region_code FROM events WHERE region_code IS NOT NULL AND region_code != '' ORDER BY region_code",
@@ -42,12 +48,15 @@ func (db *sqldb) GetEventID(eventCode string, dateStart time.Time) string {
 
 // GetEvent retrieves an event from the database by its ID.
 func (db *sqldb) GetEvent(eventID string) (*Event, error) {
+	ctx, done := db.startQuery("GetEvent")
+	defer done()
+
 	var event Event
-	stmt := db.getStatement("getEvent")
+	stmt := db.readStatement("getEvent")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	err := stmt.QueryRow(eventID).Scan(
+	err := stmt.QueryRowContext(ctx, eventID).Scan(
 		&event.EventID,
 		&event.EventCode,
 		&event.Year,
@@ -64,10 +73,16 @@ func (db *sqldb) GetEvent(eventID string) (*Event, error) {
 		&event.Timezone,
 		&event.DateStart,
 		&event.DateEnd,
+		&event.Latitude,
+		&event.Longitude,
+		&event.Unofficial,
 	)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	return &event, nil
 }
 
@@ -75,8 +90,11 @@ func (db *sqldb) GetEvent(eventID string) (*Event, error) {
 // If no filters are provided, returns all events.
 // Filters are combined with OR logic within each field and AND logic between fields.
 func (db *sqldb) GetAllEvents(filters ...EventFilter) ([]*Event, error) {
+	ctx, done := db.startQuery("GetAllEvents")
+	defer done()
+
 	// Build dynamic query
-	query := "SELECT event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end FROM events"
+	query := "SELECT event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end, latitude, longitude, unofficial FROM events"
 	args := []interface{}{}
 
 	if len(filters) > 0 {
@@ -128,6 +146,12 @@ func (db *sqldb) GetAllEvents(filters ...EventFilter) ([]*Event, error) {
 			args = append(args, filter.Year)
 		}
 
+		// Add Unofficial filter
+		if filter.Unofficial != nil {
+			query += " AND unofficial = ?"
+			args = append(args, *filter.Unofficial)
+		}
+
 		// Add Type filter
 		if len(filter.Types) > 0 {
 			query += " AND type IN ("
@@ -142,10 +166,17 @@ func (db *sqldb) GetAllEvents(filters ...EventFilter) ([]*Event, error) {
 		}
 	}
 
-	query += " ORDER BY date_start, event_code"
+	query += " ORDER BY date_start, event_code, event_id"
+
+	// Add the page of events to return
+	if len(filters) > 0 {
+		limit, limitArgs := sqlPage(filters[0].Limit, filters[0].Offset)
+		query += limit
+		args = append(args, limitArgs...)
+	}
 
 	// Execute query
-	rows, err := db.sqldb.Query(query, args...)
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -171,22 +202,31 @@ func (db *sqldb) GetAllEvents(filters ...EventFilter) ([]*Event, error) {
 			&event.Timezone,
 			&event.DateStart,
 			&event.DateEnd,
+			&event.Latitude,
+			&event.Longitude,
+			&event.Unofficial,
 		)
 		if err != nil {
 			continue
 		}
 		events = append(events, &event)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return events, nil
 }
 
 // SaveEvent saves or updates an event in the
 func (db *sqldb) SaveEvent(event *Event) error {
+	ctx, done := db.startQuery("SaveEvent")
+	defer done()
+
 	stmt := db.getStatement("saveEvent")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(
+	_, err := stmt.ExecContext(ctx,
 		event.EventID,
 		event.EventCode,
 		event.Year,
@@ -203,17 +243,23 @@ func (db *sqldb) SaveEvent(event *Event) error {
 		event.Timezone,
 		event.DateStart,
 		event.DateEnd,
+		event.Latitude,
+		event.Longitude,
+		event.Unofficial,
 	)
 	return err
 }
 
 // GetEventAwards retrieves all awards given at a specific event.
 func (db *sqldb) GetEventAwards(eventID string) ([]*EventAward, error) {
-	stmt := db.getStatement("getEventAwards")
+	ctx, done := db.startQuery("GetEventAwards")
+	defer done()
+
+	stmt := db.readStatement("getEventAwards")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(eventID)
+	rows, err := stmt.QueryContext(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -222,32 +268,54 @@ func (db *sqldb) GetEventAwards(eventID string) ([]*EventAward, error) {
 	var awards []*EventAward
 	for rows.Next() {
 		var ea EventAward
-		err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.AwardID, &ea.Name, &ea.Series)
+		err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.AwardID, &ea.Name, &ea.Series, &ea.Person)
 		if err != nil {
 			continue
 		}
 		awards = append(awards, &ea)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return awards, nil
 }
 
 // SaveEventAward saves or updates an event award in the
 func (db *sqldb) SaveEventAward(ea *EventAward) error {
+	ctx, done := db.startQuery("SaveEventAward")
+	defer done()
+
 	stmt := db.getStatement("saveEventAward")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(ea.EventID, ea.TeamID, ea.AwardID, ea.Name, ea.Series)
+	_, err := stmt.ExecContext(ctx, ea.EventID, ea.TeamID, ea.AwardID, ea.Name, ea.Series, ea.Person)
+	return err
+}
+
+// DeleteEventAward deletes an award given at an event.
+func (db *sqldb) DeleteEventAward(ea *EventAward) error {
+	ctx, done := db.startQuery("DeleteEventAward")
+	defer done()
+
+	stmt := db.getStatement("deleteEventAward")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, ea.EventID, ea.TeamID, ea.AwardID, ea.Series)
 	return err
 }
 
 // GetTeamAwardsByEvent retrieves all awards for a specific team at a specific event.
 func (db *sqldb) GetTeamAwardsByEvent(eventID string, teamID int) ([]*EventAward, error) {
-	stmt := db.getStatement("getTeamAwardsByEvent")
+	ctx, done := db.startQuery("GetTeamAwardsByEvent")
+	defer done()
+
+	stmt := db.readStatement("getTeamAwardsByEvent")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(eventID, teamID)
+	rows, err := stmt.QueryContext(ctx, eventID, teamID)
 	if err != nil {
 		return nil, err
 	}
@@ -256,22 +324,28 @@ func (db *sqldb) GetTeamAwardsByEvent(eventID string, teamID int) ([]*EventAward
 	var awards []*EventAward
 	for rows.Next() {
 		var ea EventAward
-		err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.AwardID, &ea.Name, &ea.Series)
+		err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.AwardID, &ea.Name, &ea.Series, &ea.Person)
 		if err != nil {
 			continue
 		}
 		awards = append(awards, &ea)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return awards, nil
 }
 
 // GetAllTeamAwards retrieves all awards for a specific team across all events, ordered by event ID.
 func (db *sqldb) GetAllTeamAwards(teamID int) ([]*EventAward, error) {
-	stmt := db.getStatement("getAllTeamAwards")
+	ctx, done := db.startQuery("GetAllTeamAwards")
+	defer done()
+
+	stmt := db.readStatement("getAllTeamAwards")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(teamID)
+	rows, err := stmt.QueryContext(ctx, teamID)
 	if err != nil {
 		return nil, err
 	}
@@ -280,22 +354,28 @@ func (db *sqldb) GetAllTeamAwards(teamID int) ([]*EventAward, error) {
 	var awards []*EventAward
 	for rows.Next() {
 		var ea EventAward
-		err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.AwardID, &ea.Name, &ea.Series)
+		err := rows.Scan(&ea.EventID, &ea.TeamID, &ea.AwardID, &ea.Name, &ea.Series, &ea.Person)
 		if err != nil {
 			continue
 		}
 		awards = append(awards, &ea)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return awards, nil
 }
 
 // GetEventRankings retrieves all rankings for a specific event.
 func (db *sqldb) GetEventRankings(eventID string) ([]*EventRanking, error) {
-	stmt := db.getStatement("getEventRankings")
+	ctx, done := db.startQuery("GetEventRankings")
+	defer done()
+
+	stmt := db.readStatement("getEventRankings")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(eventID)
+	rows, err := stmt.QueryContext(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -320,32 +400,55 @@ func (db *sqldb) GetEventRankings(eventID string) ([]*EventRanking, error) {
 			&er.Dq,
 			&er.MatchesPlayed,
 			&er.MatchesCounted,
+			&er.Source,
 		)
 		if err != nil {
 			continue
 		}
 		rankings = append(rankings, &er)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return rankings, nil
 }
 
 // SaveEventRanking saves or updates an event ranking in the
 func (db *sqldb) SaveEventRanking(er *EventRanking) error {
+	ctx, done := db.startQuery("SaveEventRanking")
+	defer done()
+
 	stmt := db.getStatement("saveEventRanking")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(er.EventID, er.TeamID, er.Rank, er.SortOrder1, er.SortOrder2, er.SortOrder3, er.SortOrder4, er.SortOrder5, er.SortOrder6, er.Wins, er.Losses, er.Ties, er.Dq, er.MatchesPlayed, er.MatchesCounted)
+	_, err := stmt.ExecContext(ctx, er.EventID, er.TeamID, er.Rank, er.SortOrder1, er.SortOrder2, er.SortOrder3, er.SortOrder4, er.SortOrder5, er.SortOrder6, er.Wins, er.Losses, er.Ties, er.Dq, er.MatchesPlayed, er.MatchesCounted, er.Source)
+	return err
+}
+
+// DeleteEventRanking deletes the ranking of a team at an event.
+func (db *sqldb) DeleteEventRanking(eventID string, teamID int) error {
+	ctx, done := db.startQuery("DeleteEventRanking")
+	defer done()
+
+	stmt := db.getStatement("deleteEventRanking")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, eventID, teamID)
 	return err
 }
 
 // GetEventAdvancements retrieves all team advancements for a specific event.
 func (db *sqldb) GetEventAdvancements(eventID string) ([]*EventAdvancement, error) {
-	stmt := db.getStatement("getEventAdvancements")
+	ctx, done := db.startQuery("GetEventAdvancements")
+	defer done()
+
+	stmt := db.readStatement("getEventAdvancements")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(eventID)
+	rows, err := stmt.QueryContext(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -360,26 +463,48 @@ func (db *sqldb) GetEventAdvancements(eventID string) ([]*EventAdvancement, erro
 		}
 		advancements = append(advancements, &ea)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return advancements, nil
 }
 
 // SaveEventAdvancement saves or updates an event advancement in the
 func (db *sqldb) SaveEventAdvancement(ea *EventAdvancement) error {
+	ctx, done := db.startQuery("SaveEventAdvancement")
+	defer done()
+
 	stmt := db.getStatement("saveEventAdvancement")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(ea.EventID, ea.TeamID, ea.Status)
+	_, err := stmt.ExecContext(ctx, ea.EventID, ea.TeamID, ea.Status)
+	return err
+}
+
+// DeleteEventAdvancement deletes the advancement of a team from an event.
+func (db *sqldb) DeleteEventAdvancement(eventID string, teamID int) error {
+	ctx, done := db.startQuery("DeleteEventAdvancement")
+	defer done()
+
+	stmt := db.getStatement("deleteEventAdvancement")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, eventID, teamID)
 	return err
 }
 
 // GetEventTeams retrieves all teams for a specific event.
 func (db *sqldb) GetEventTeams(eventID string) ([]*EventTeam, error) {
-	stmt := db.getStatement("getEventTeams")
+	ctx, done := db.startQuery("GetEventTeams")
+	defer done()
+
+	stmt := db.readStatement("getEventTeams")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(eventID)
+	rows, err := stmt.QueryContext(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -388,32 +513,54 @@ func (db *sqldb) GetEventTeams(eventID string) ([]*EventTeam, error) {
 	var teams []*EventTeam
 	for rows.Next() {
 		var et EventTeam
-		err := rows.Scan(&et.EventID, &et.TeamID)
+		err := rows.Scan(&et.EventID, &et.TeamID, &et.Registered, &et.Played)
 		if err != nil {
 			continue
 		}
 		teams = append(teams, &et)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return teams, nil
 }
 
 // SaveEventTeam saves or updates an event team in the database.
 func (db *sqldb) SaveEventTeam(et *EventTeam) error {
+	ctx, done := db.startQuery("SaveEventTeam")
+	defer done()
+
 	stmt := db.getStatement("saveEventTeam")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(et.EventID, et.TeamID)
+	_, err := stmt.ExecContext(ctx, et.EventID, et.TeamID, et.Registered, et.Played)
+	return err
+}
+
+// DeleteEventTeam deletes a team from an event.
+func (db *sqldb) DeleteEventTeam(eventID string, teamID int) error {
+	ctx, done := db.startQuery("DeleteEventTeam")
+	defer done()
+
+	stmt := db.getStatement("deleteEventTeam")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, eventID, teamID)
 	return err
 }
 
 // GetEventsByTeam retrieves all event IDs that a team has or will participate in, sorted alphabetically.
 func (db *sqldb) GetEventsByTeam(teamID int) ([]string, error) {
-	stmt := db.getStatement("getEventsByTeam")
+	ctx, done := db.startQuery("GetEventsByTeam")
+	defer done()
+
+	stmt := db.readStatement("getEventsByTeam")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(teamID)
+	rows, err := stmt.QueryContext(ctx, teamID)
 	if err != nil {
 		return nil, err
 	}
@@ -428,16 +575,22 @@ func (db *sqldb) GetEventsByTeam(teamID int) ([]string, error) {
 		}
 		eventIDs = append(eventIDs, eventID)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return eventIDs, nil
 }
 
 // GetRegionCodes retrieves all unique region codes from events, sorted alphabetically.
 func (db *sqldb) GetRegionCodes() ([]string, error) {
-	stmt := db.getStatement("getRegionCodes")
+	ctx, done := db.startQuery("GetRegionCodes")
+	defer done()
+
+	stmt := db.readStatement("getRegionCodes")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query()
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -452,16 +605,22 @@ func (db *sqldb) GetRegionCodes() ([]string, error) {
 		}
 		regionCodes = append(regionCodes, regionCode)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return regionCodes, nil
 }
 
 // GetEventCodesByRegion retrieves all unique event codes for a given region, sorted alphabetically.
 func (db *sqldb) GetEventCodesByRegion(regionCode string) ([]string, error) {
-	stmt := db.getStatement("getEventCodesByRegion")
+	ctx, done := db.startQuery("GetEventCodesByRegion")
+	defer done()
+
+	stmt := db.readStatement("getEventCodesByRegion")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(regionCode)
+	rows, err := stmt.QueryContext(ctx, regionCode)
 	if err != nil {
 		return nil, err
 	}
@@ -476,16 +635,22 @@ func (db *sqldb) GetEventCodesByRegion(regionCode string) ([]string, error) {
 		}
 		eventCodes = append(eventCodes, eventCode)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return eventCodes, nil
 }
 
 // GetAdvancementsByRegion retrieves all event advancements for events in a given region, ordered by event ID and team ID.
 func (db *sqldb) GetAdvancementsByRegion(regionCode string) ([]*EventAdvancement, error) {
-	stmt := db.getStatement("getAdvancementsByRegion")
+	ctx, done := db.startQuery("GetAdvancementsByRegion")
+	defer done()
+
+	stmt := db.readStatement("getAdvancementsByRegion")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(regionCode)
+	rows, err := stmt.QueryContext(ctx, regionCode)
 	if err != nil {
 		return nil, err
 	}
@@ -500,6 +665,9 @@ func (db *sqldb) GetAdvancementsByRegion(regionCode string) ([]*EventAdvancement
 		}
 		advancements = append(advancements, &ea)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return advancements, nil
 }
 
@@ -507,6 +675,9 @@ func (db *sqldb) GetAdvancementsByRegion(regionCode string) ([]*EventAdvancement
 // If no filters are provided, returns all advancements.
 // Filters are combined with OR logic within each field and AND logic between fields.
 func (db *sqldb) GetAllAdvancements(filters ...AdvancementFilter) ([]*EventAdvancement, error) {
+	ctx, done := db.startQuery("GetAllAdvancements")
+	defer done()
+
 	// Build dynamic query
 	query := "SELECT ea.event_id, ea.team_id, ea.status FROM event_advancements ea"
 	args := []interface{}{}
@@ -561,7 +732,7 @@ func (db *sqldb) GetAllAdvancements(filters ...AdvancementFilter) ([]*EventAdvan
 	query += " ORDER BY ea.event_id, ea.team_id"
 
 	// Execute query
-	rows, err := db.sqldb.Query(query, args...)
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -576,5 +747,8 @@ func (db *sqldb) GetAllAdvancements(filters ...AdvancementFilter) ([]*EventAdvan
 		}
 		advancements = append(advancements, &ea)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return advancements, nil
 }
@@ -0,0 +1,126 @@
+package database
+
+import "fmt"
+
+// initEventSummaryStatements prepares all SQL statements for event summary operations.
+func (db *sqldb) initEventSummaryStatements() error {
+	queries := map[string]string{
+		// The summary is calculated by the database from the event's matches and team rankings, so none of the
+		// match data needs to be loaded to refresh it.
+		"refreshEventSummary": `INSERT INTO event_summary (event_id, qual_matches, playoff_matches, num_teams, high_score, average_score, average_np_score, average_opr, average_np_opr, top_opr_team_id, top_opr, top_np_opr_team_id, top_np_opr)
+			SELECT e.event_id,
+				(SELECT COUNT(*) FROM matches m WHERE m.event_id = e.event_id AND m.tournament_level <> 'Playoff'),
+				(SELECT COUNT(*) FROM matches m WHERE m.event_id = e.event_id AND m.tournament_level = 'Playoff'),
+				(SELECT COUNT(*) FROM team_rankings tr WHERE tr.event_id = e.event_id),
+				(SELECT COALESCE(MAX(s.total_points), 0) FROM match_alliance_scores s INNER JOIN matches m ON s.match_id = m.match_id WHERE m.event_id = e.event_id),
+				(SELECT COALESCE(AVG(s.total_points), 0) FROM match_alliance_scores s INNER JOIN matches m ON s.match_id = m.match_id WHERE m.event_id = e.event_id),
+				(SELECT COALESCE(AVG(s.total_points - s.foul_points_committed), 0) FROM match_alliance_scores s INNER JOIN matches m ON s.match_id = m.match_id WHERE m.event_id = e.event_id),
+				(SELECT COALESCE(AVG(tr.opr), 0) FROM team_rankings tr WHERE tr.event_id = e.event_id),
+				(SELECT COALESCE(AVG(tr.np_opr), 0) FROM team_rankings tr WHERE tr.event_id = e.event_id),
+				COALESCE((SELECT tr.team_id FROM team_rankings tr WHERE tr.event_id = e.event_id ORDER BY tr.opr DESC, tr.team_id LIMIT 1), 0),
+				(SELECT COALESCE(MAX(tr.opr), 0) FROM team_rankings tr WHERE tr.event_id = e.event_id),
+				COALESCE((SELECT tr.team_id FROM team_rankings tr WHERE tr.event_id = e.event_id ORDER BY tr.np_opr DESC, tr.team_id LIMIT 1), 0),
+				(SELECT COALESCE(MAX(tr.np_opr), 0) FROM team_rankings tr WHERE tr.event_id = e.event_id)
+			FROM events e WHERE e.event_id = ?
+			ON DUPLICATE KEY UPDATE qual_matches = VALUES(qual_matches), playoff_matches = VALUES(playoff_matches), num_teams = VALUES(num_teams), high_score = VALUES(high_score), average_score = VALUES(average_score), average_np_score = VALUES(average_np_score), average_opr = VALUES(average_opr), average_np_opr = VALUES(average_np_opr), top_opr_team_id = VALUES(top_opr_team_id), top_opr = VALUES(top_opr), top_np_opr_team_id = VALUES(top_np_opr_team_id), top_np_opr = VALUES(top_np_opr)`,
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetEventSummaries retrieves event summaries with optional filters.
+// Filters support filtering by EventID and/or RegionCode.
+// If no filters are provided, returns all event summaries.
+func (db *sqldb) GetEventSummaries(filters ...EventSummaryFilter) ([]*EventSummary, error) {
+	ctx, done := db.startQuery("GetEventSummaries")
+	defer done()
+
+	// Build dynamic query
+	query := "SELECT s.event_id, s.qual_matches, s.playoff_matches, s.num_teams, s.high_score, s.average_score, s.average_np_score, s.average_opr, s.average_np_opr, s.top_opr_team_id, s.top_opr, s.top_np_opr_team_id, s.top_np_opr FROM event_summary s INNER JOIN events e ON s.event_id = e.event_id WHERE 1=1"
+	args := []interface{}{}
+
+	if len(filters) > 0 {
+		filter := filters[0]
+
+		// Add EventID filter
+		if len(filter.EventIDs) > 0 {
+			query += " AND s.event_id IN ("
+			for i, id := range filter.EventIDs {
+				if i > 0 {
+					query += ","
+				}
+				query += "?"
+				args = append(args, id)
+			}
+			query += ")"
+		}
+
+		// Add RegionCode filter
+		if len(filter.RegionCodes) > 0 {
+			query += " AND e.region_code IN ("
+			for i, code := range filter.RegionCodes {
+				if i > 0 {
+					query += ","
+				}
+				query += "?"
+				args = append(args, code)
+			}
+			query += ")"
+		}
+	}
+
+	query += " ORDER BY s.event_id"
+
+	// Execute query
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []*EventSummary
+	for rows.Next() {
+		var summary EventSummary
+		err := rows.Scan(
+			&summary.EventID,
+			&summary.QualMatches,
+			&summary.PlayoffMatches,
+			&summary.NumTeams,
+			&summary.HighScore,
+			&summary.AverageScore,
+			&summary.AverageNpScore,
+			&summary.AverageOPR,
+			&summary.AverageNpOPR,
+			&summary.TopOPRTeamID,
+			&summary.TopOPR,
+			&summary.TopNpOPRTeamID,
+			&summary.TopNpOPR,
+		)
+		if err != nil {
+			continue
+		}
+		summaries = append(summaries, &summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// RefreshEventSummary recalculates the summary for an event from its matches and team rankings.
+func (db *sqldb) RefreshEventSummary(eventID string) error {
+	ctx, done := db.startQuery("RefreshEventSummary")
+	defer done()
+
+	stmt := db.getStatement("refreshEventSummary")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, eventID)
+	return err
+}
@@ -0,0 +1,87 @@
+package database
+
+import "fmt"
+
+// initEventSyncStatements prepares all SQL statements for event sync operations.
+func (db *sqldb) initEventSyncStatements() error {
+	queries := map[string]string{
+		"saveEventSync": "INSERT INTO event_syncs (event_id, synced_at) VALUES (?, ?) ON DUPLICATE KEY UPDATE synced_at = VALUES(synced_at)",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetEventSyncs retrieves the times events were last synced, with optional filters.
+// If no filters are provided, returns the syncs of all events.
+func (db *sqldb) GetEventSyncs(filters ...EventSyncFilter) ([]*EventSync, error) {
+	ctx, done := db.startQuery("GetEventSyncs")
+	defer done()
+
+	// Build dynamic query
+	query := "SELECT event_id, synced_at FROM event_syncs WHERE 1=1"
+	args := []interface{}{}
+
+	if len(filters) > 0 {
+		filter := filters[0]
+
+		// Add EventID filter
+		if len(filter.EventIDs) > 0 {
+			query += " AND event_id IN ("
+			for i, id := range filter.EventIDs {
+				if i > 0 {
+					query += ","
+				}
+				query += "?"
+				args = append(args, id)
+			}
+			query += ")"
+		}
+	}
+
+	query += " ORDER BY event_id"
+
+	// Execute query
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var syncs []*EventSync
+	for rows.Next() {
+		var sync EventSync
+		err := rows.Scan(
+			&sync.EventID,
+			&sync.SyncedAt,
+		)
+		if err != nil {
+			continue
+		}
+		syncs = append(syncs, &sync)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return syncs, nil
+}
+
+// SaveEventSync records the time an event was synced, replacing any earlier time for the event.
+func (db *sqldb) SaveEventSync(sync *EventSync) error {
+	ctx, done := db.startQuery("SaveEventSync")
+	defer done()
+
+	stmt := db.getStatement("saveEventSync")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		sync.EventID,
+		sync.SyncedAt,
+	)
+	return err
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// healthCheckInterval is how often the connection to a SQL database is checked.
+	healthCheckInterval = 30 * time.Second
+
+	// healthCheckTimeout is how long a SQL database is given to answer a health check.
+	healthCheckTimeout = 5 * time.Second
+)
+
+// ping checks that the primary database, and the read replica if one is configured, can be reached.
+func (db *sqldb) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	if err := db.sqldb.PingContext(ctx); err != nil {
+		return err
+	}
+	if db.readDB != db.sqldb {
+		return db.readDB.PingContext(ctx)
+	}
+	return nil
+}
+
+// monitor checks the connection to the database at each interval until the database is closed, logging when the
+// connection is lost and when it is restored. Connections broken while the database was down, such as when MySQL is
+// restarted, are replaced as they are used, and the prepared statements are prepared again on the new connections,
+// so queries succeed again without restarting the command or server.
+func (db *sqldb) monitor() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	healthy := true
+	for {
+		select {
+		case <-db.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := db.ping(db.ctx)
+		switch {
+		case db.ctx.Err() != nil:
+			return
+		case err != nil && healthy:
+			slog.Error("Lost the connection to the database", "error", err)
+			healthy = false
+		case err == nil && !healthy:
+			slog.Info("Reconnected to the database")
+			healthy = true
+		}
+	}
+}
@@ -1,19 +1,28 @@
 package database
 
-import "fmt"
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
 
 // InitMatchStatements prepares all SQL statements for match operations.
 func (db *sqldb) initMatchStatements() error {
 	queries := map[string]string{
-		"getMatch":               "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level FROM matches WHERE match_id = ?",
-		"getAllMatches":          "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level FROM matches",
-		"getMatchesByEvent":      "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level FROM matches WHERE event_id = ? ORDER BY match_number",
-		"saveMatch":              "INSERT INTO matches (match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level) VALUES (?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE event_id = VALUES(event_id), match_type = VALUES(match_type), match_number = VALUES(match_number), actual_start_time = VALUES(actual_start_time), description = VALUES(description), tournament_level = VALUES(tournament_level)",
-		"getMatchAllianceScore":  "SELECT match_id, alliance, auto_points, teleop_points, foul_points_committed, pre_foul_total, total_points, major_fouls, minor_fouls FROM match_alliance_scores WHERE match_id = ? AND alliance = ?",
-		"saveMatchAllianceScore": "INSERT INTO match_alliance_scores (match_id, alliance, auto_points, teleop_points, foul_points_committed, pre_foul_total, total_points, major_fouls, minor_fouls) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE auto_points = VALUES(auto_points), teleop_points = VALUES(teleop_points), foul_points_committed = VALUES(foul_points_committed), pre_foul_total = VALUES(pre_foul_total), total_points = VALUES(total_points), major_fouls = VALUES(major_fouls), minor_fouls = VALUES(minor_fouls)",
-		"getMatchTeams":          "SELECT match_id, team_id, alliance, dq, on_field FROM match_teams WHERE match_id = ?",
-		"getTeamsByEvent":        "SELECT DISTINCT mt.team_id FROM match_teams mt INNER JOIN matches m ON mt.match_id = m.match_id WHERE m.event_id = ? ORDER BY mt.team_id",
-		"saveMatchTeam":          "INSERT INTO match_teams (match_id, team_id, alliance, dq, on_field) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE alliance = VALUES(alliance), dq = VALUES(dq), on_field = VALUES(on_field)",
+		"getMatch":                  "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source FROM matches WHERE match_id = ?",
+		"getAllMatches":             "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source FROM matches ORDER BY event_id, tournament_level DESC, match_number",
+		"getMatchesByEvent":         "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source FROM matches WHERE event_id = ? ORDER BY tournament_level DESC, match_number",
+		"saveMatch":                 "INSERT INTO matches (match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source) VALUES (?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE event_id = VALUES(event_id), match_type = VALUES(match_type), match_number = VALUES(match_number), actual_start_time = VALUES(actual_start_time), description = VALUES(description), tournament_level = VALUES(tournament_level), source = VALUES(source)",
+		"deleteMatch":               "DELETE FROM matches WHERE match_id = ?",
+		"deleteMatchAllianceScores": "DELETE FROM match_alliance_scores WHERE match_id = ?",
+		"deleteMatchTeams":          "DELETE FROM match_teams WHERE match_id = ?",
+		"getMatchAllianceScore":     "SELECT match_id, alliance, auto_points, teleop_points, foul_points_committed, pre_foul_total, total_points, major_fouls, minor_fouls FROM match_alliance_scores WHERE match_id = ? AND alliance = ?",
+		"saveMatchAllianceScore":    "INSERT INTO match_alliance_scores (match_id, alliance, auto_points, teleop_points, foul_points_committed, pre_foul_total, total_points, major_fouls, minor_fouls) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE auto_points = VALUES(auto_points), teleop_points = VALUES(teleop_points), foul_points_committed = VALUES(foul_points_committed), pre_foul_total = VALUES(pre_foul_total), total_points = VALUES(total_points), major_fouls = VALUES(major_fouls), minor_fouls = VALUES(minor_fouls)",
+		"getMatchTeams":             "SELECT match_id, team_id, alliance, dq, on_field FROM match_teams WHERE match_id = ? ORDER BY alliance, team_id",
+		"getTeamsByEvent":           "SELECT DISTINCT mt.team_id FROM match_teams mt INNER JOIN matches m ON mt.match_id = m.match_id WHERE m.event_id = ? ORDER BY mt.team_id",
+		"saveMatchTeam":             "INSERT INTO match_teams (match_id, team_id, alliance, dq, on_field) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE alliance = VALUES(alliance), dq = VALUES(dq), on_field = VALUES(on_field)",
+		"deleteMatchTeam":           "DELETE FROM match_teams WHERE match_id = ? AND team_id = ?",
 	}
 
 	for name, query := range queries {
@@ -31,23 +40,30 @@ func (db *sqldb) GetMatchID(eventID string, matchNumber int) string {
 
 // GetMatch retrieves a match from the database by its ID.
 func (db *sqldb) GetMatch(matchID string) (*Match, error) {
+	ctx, done := db.startQuery("GetMatch")
+	defer done()
+
 	var match Match
-	stmt := db.getStatement("getMatch")
+	stmt := db.readStatement("getMatch")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	err := stmt.QueryRow(matchID).Scan(
+	err := stmt.QueryRowContext(ctx, matchID).Scan(
 		&match.MatchID,
 		&match.EventID,
 		&match.MatchType,
 		&match.MatchNumber,
-		&match.ActualStartTime,
+		(*nullTime)(&match.ActualStartTime),
 		&match.Description,
 		&match.TournamentLevel,
+		&match.Source,
 	)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	return &match, nil
 }
 
@@ -55,13 +71,16 @@ func (db *sqldb) GetMatch(matchID string) (*Match, error) {
 // If no filters are provided, returns all matches.
 // Filters are combined with OR logic within each field.
 func (db *sqldb) GetAllMatches(filters ...MatchFilter) ([]*Match, error) {
+	ctx, done := db.startQuery("GetAllMatches")
+	defer done()
+
 	// If no filters, use the prepared statement
 	if len(filters) == 0 {
-		stmt := db.getStatement("getAllMatches")
+		stmt := db.readStatement("getAllMatches")
 		if stmt == nil {
 			return nil, fmt.Errorf("prepared statement not found")
 		}
-		rows, err := stmt.Query()
+		rows, err := stmt.QueryContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -75,22 +94,26 @@ func (db *sqldb) GetAllMatches(filters ...MatchFilter) ([]*Match, error) {
 				&match.EventID,
 				&match.MatchType,
 				&match.MatchNumber,
-				&match.ActualStartTime,
+				(*nullTime)(&match.ActualStartTime),
 				&match.Description,
 				&match.TournamentLevel,
+				&match.Source,
 			)
 			if err != nil {
 				continue
 			}
 			matches = append(matches, &match)
 		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
 		return matches, nil
 	}
 
 	filter := filters[0]
 
 	// Build dynamic query
-	query := "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level FROM matches"
+	query := "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source FROM matches"
 	args := []interface{}{}
 
 	if len(filter.EventIDs) > 0 {
@@ -105,10 +128,15 @@ func (db *sqldb) GetAllMatches(filters ...MatchFilter) ([]*Match, error) {
 		query += ")"
 	}
 
-	query += " ORDER BY event_id, match_number"
+	query += " ORDER BY event_id, tournament_level DESC, match_number"
+
+	// Add the page of matches to return
+	limit, limitArgs := sqlPage(filter.Limit, filter.Offset)
+	query += limit
+	args = append(args, limitArgs...)
 
 	// Execute query
-	rows, err := db.sqldb.Query(query, args...)
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -122,25 +150,32 @@ func (db *sqldb) GetAllMatches(filters ...MatchFilter) ([]*Match, error) {
 			&match.EventID,
 			&match.MatchType,
 			&match.MatchNumber,
-			&match.ActualStartTime,
+			(*nullTime)(&match.ActualStartTime),
 			&match.Description,
 			&match.TournamentLevel,
+			&match.Source,
 		)
 		if err != nil {
 			continue
 		}
 		matches = append(matches, &match)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return matches, nil
 }
 
 // GetMatchesByEvent retrieves all matches for a specific event, ordered by match number.
 func (db *sqldb) GetMatchesByEvent(eventID string) ([]*Match, error) {
-	stmt := db.getStatement("getMatchesByEvent")
+	ctx, done := db.startQuery("GetMatchesByEvent")
+	defer done()
+
+	stmt := db.readStatement("getMatchesByEvent")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(eventID)
+	rows, err := stmt.QueryContext(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -154,44 +189,82 @@ func (db *sqldb) GetMatchesByEvent(eventID string) ([]*Match, error) {
 			&match.EventID,
 			&match.MatchType,
 			&match.MatchNumber,
-			&match.ActualStartTime,
+			(*nullTime)(&match.ActualStartTime),
 			&match.Description,
 			&match.TournamentLevel,
+			&match.Source,
 		)
 		if err != nil {
 			continue
 		}
 		matches = append(matches, &match)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return matches, nil
 }
 
 // SaveMatch saves or updates a match in the
 func (db *sqldb) SaveMatch(match *Match) error {
+	ctx, done := db.startQuery("SaveMatch")
+	defer done()
+
 	stmt := db.getStatement("saveMatch")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(
+	_, err := stmt.ExecContext(ctx,
 		match.MatchID,
 		match.EventID,
 		match.MatchType,
 		match.MatchNumber,
-		match.ActualStartTime,
+		nullTimeValue(match.ActualStartTime),
 		match.Description,
 		match.TournamentLevel,
+		match.Source,
 	)
 	return err
 }
 
+// DeleteMatch deletes a match along with its alliance scores and teams. All are deleted in a single transaction.
+func (db *sqldb) DeleteMatch(matchID string) error {
+	ctx, done := db.startQuery("DeleteMatch")
+	defer done()
+
+	var stmts []*sql.Stmt
+	for _, name := range []string{"deleteMatchTeams", "deleteMatchAllianceScores", "deleteMatch"} {
+		stmt := db.getStatement(name)
+		if stmt == nil {
+			return fmt.Errorf("prepared statement not found")
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	tx, err := db.sqldb.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Stmt(stmt).ExecContext(ctx, matchID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // GetMatchAllianceScore retrieves the score for a specific alliance in a match.
 func (db *sqldb) GetMatchAllianceScore(matchID, alliance string) (*MatchAllianceScore, error) {
+	ctx, done := db.startQuery("GetMatchAllianceScore")
+	defer done()
+
 	var score MatchAllianceScore
-	stmt := db.getStatement("getMatchAllianceScore")
+	stmt := db.readStatement("getMatchAllianceScore")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	err := stmt.QueryRow(matchID, alliance).Scan(
+	err := stmt.QueryRowContext(ctx, matchID, NormalizeAlliance(alliance)).Scan(
 		&score.MatchID,
 		&score.Alliance,
 		&score.AutoPoints,
@@ -202,21 +275,27 @@ func (db *sqldb) GetMatchAllianceScore(matchID, alliance string) (*MatchAlliance
 		&score.MajorFouls,
 		&score.MinorFouls,
 	)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	return &score, nil
 }
 
 // SaveMatchAllianceScore saves or updates the score for a specific alliance in a match.
 func (db *sqldb) SaveMatchAllianceScore(score *MatchAllianceScore) error {
+	ctx, done := db.startQuery("SaveMatchAllianceScore")
+	defer done()
+
 	stmt := db.getStatement("saveMatchAllianceScore")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(
+	_, err := stmt.ExecContext(ctx,
 		score.MatchID,
-		score.Alliance,
+		NormalizeAlliance(score.Alliance),
 		score.AutoPoints,
 		score.TeleopPoints,
 		score.FoulPointsCommitted,
@@ -230,11 +309,14 @@ func (db *sqldb) SaveMatchAllianceScore(score *MatchAllianceScore) error {
 
 // GetMatchTeams retrieves all teams participating in a specific match.
 func (db *sqldb) GetMatchTeams(matchID string) ([]*MatchTeam, error) {
-	stmt := db.getStatement("getMatchTeams")
+	ctx, done := db.startQuery("GetMatchTeams")
+	defer done()
+
+	stmt := db.readStatement("getMatchTeams")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(matchID)
+	rows, err := stmt.QueryContext(ctx, matchID)
 	if err != nil {
 		return nil, err
 	}
@@ -254,32 +336,54 @@ func (db *sqldb) GetMatchTeams(matchID string) ([]*MatchTeam, error) {
 		}
 		teams = append(teams, &team)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return teams, nil
 }
 
 // SaveMatchTeam saves or updates a match team in the
 func (db *sqldb) SaveMatchTeam(team *MatchTeam) error {
+	ctx, done := db.startQuery("SaveMatchTeam")
+	defer done()
+
 	stmt := db.getStatement("saveMatchTeam")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(
+	_, err := stmt.ExecContext(ctx,
 		team.MatchID,
 		team.TeamID,
-		team.Alliance,
+		NormalizeAlliance(team.Alliance),
 		team.Dq,
 		team.OnField,
 	)
 	return err
 }
 
+// DeleteMatchTeam deletes a team from a match.
+func (db *sqldb) DeleteMatchTeam(matchID string, teamID int) error {
+	ctx, done := db.startQuery("DeleteMatchTeam")
+	defer done()
+
+	stmt := db.getStatement("deleteMatchTeam")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, matchID, teamID)
+	return err
+}
+
 // GetTeamsByEvent retrieves all unique team IDs that participated at a specific event, ordered by team ID.
 func (db *sqldb) GetTeamsByEvent(eventID string) ([]int, error) {
-	stmt := db.getStatement("getTeamsByEvent")
+	ctx, done := db.startQuery("GetTeamsByEvent")
+	defer done()
+
+	stmt := db.readStatement("getTeamsByEvent")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(eventID)
+	rows, err := stmt.QueryContext(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -294,5 +398,30 @@ func (db *sqldb) GetTeamsByEvent(eventID string) ([]int, error) {
 		}
 		teamIDs = append(teamIDs, teamID)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return teamIDs, nil
 }
+
+// nullTime scans a nullable DATETIME column into a time.Time, which is left as the zero time if the column is NULL.
+type nullTime time.Time
+
+// Scan implements the sql.Scanner interface.
+func (nt *nullTime) Scan(value any) error {
+	var t sql.NullTime
+	if err := t.Scan(value); err != nil {
+		return err
+	}
+	*nt = nullTime(t.Time)
+	return nil
+}
+
+// nullTimeValue returns the value saved in a nullable DATETIME column for the time: NULL for the zero time, and the
+// time in UTC otherwise.
+func nullTimeValue(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
@@ -0,0 +1,81 @@
+package database
+
+import "fmt"
+
+// moveEventQueries move the records of an event to a new event ID. Each query is paired with the arguments it
+// takes, where "from" is replaced by the old event ID and "to" by the new one. UPDATE IGNORE skips the records that
+// would replace a record already saved for the new event ID, and those records are then deleted along with the
+// event. Match IDs embed the event ID, so each match is copied to its new ID before the rows that belong to it are
+// moved, which keeps the rows from being orphaned when the foreign keys are in place.
+var moveEventQueries = []struct {
+	query string
+	args  []string
+}{
+	{"INSERT IGNORE INTO matches (match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source) SELECT CONCAT(?, SUBSTRING(match_id, CHAR_LENGTH(?) + 1)), ?, match_type, match_number, actual_start_time, description, tournament_level, source FROM matches WHERE event_id = ?", []string{"to", "from", "to", "from"}},
+	{"UPDATE IGNORE match_teams mt INNER JOIN matches m ON mt.match_id = m.match_id SET mt.match_id = CONCAT(?, SUBSTRING(mt.match_id, CHAR_LENGTH(?) + 1)) WHERE m.event_id = ?", []string{"to", "from", "from"}},
+	{"DELETE mt FROM match_teams mt INNER JOIN matches m ON mt.match_id = m.match_id WHERE m.event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE match_alliance_scores s INNER JOIN matches m ON s.match_id = m.match_id SET s.match_id = CONCAT(?, SUBSTRING(s.match_id, CHAR_LENGTH(?) + 1)) WHERE m.event_id = ?", []string{"to", "from", "from"}},
+	{"DELETE s FROM match_alliance_scores s INNER JOIN matches m ON s.match_id = m.match_id WHERE m.event_id = ?", []string{"from"}},
+	{"DELETE FROM matches WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE event_awards SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM event_awards WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE event_rankings SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM event_rankings WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE event_advancements SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM event_advancements WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE event_teams SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM event_teams WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE team_rankings SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM team_rankings WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE team_ranking_snapshots SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM team_ranking_snapshots WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE sync_checkpoints SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM sync_checkpoints WHERE event_id = ?", []string{"from"}},
+	{"UPDATE event_source_keys SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM event_summary WHERE event_id = ?", []string{"from"}},
+	{"DELETE FROM advancement_cutoffs WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE event_syncs SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM event_syncs WHERE event_id = ?", []string{"from"}},
+	{"DELETE FROM endpoint_hashes WHERE event_id = ?", []string{"from"}},
+	{"UPDATE IGNORE sync_retries SET event_id = ? WHERE event_id = ?", []string{"to", "from"}},
+	{"DELETE FROM sync_retries WHERE event_id = ?", []string{"from"}},
+	{"DELETE FROM events WHERE event_id = ?", []string{"from"}},
+}
+
+// MoveEvent moves every record of an event to a new event ID and deletes the event saved under the old ID. The
+// records are moved in a single transaction, so a failure leaves the event where it was.
+func (db *sqldb) MoveEvent(fromEventID, toEventID string) error {
+	ctx, done := db.startQuery("MoveEvent")
+	defer done()
+
+	if fromEventID == toEventID {
+		return nil
+	}
+	event, err := db.GetEvent(toEventID)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return fmt.Errorf("event %s not found", toEventID)
+	}
+
+	tx, err := db.sqldb.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	ids := map[string]string{"from": fromEventID, "to": toEventID}
+	for _, q := range moveEventQueries {
+		args := make([]any, 0, len(q.args))
+		for _, arg := range q.args {
+			args = append(args, ids[arg])
+		}
+		if _, err := tx.ExecContext(ctx, q.query, args...); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to move event %s: %w", fromEventID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return db.RefreshEventSummary(toEventID)
+}
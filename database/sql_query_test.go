@@ -0,0 +1,275 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recorder is a database/sql driver that records the statements it is given, so the SQL built by sqldb can be
+// checked without a MySQL server. A query returns the row given for it in rows, or no rows if it isn't given one.
+type recorder struct {
+	mu         sync.Mutex
+	statements []recorded
+	rows       map[string][]driver.Value
+}
+
+// recorded is a statement given to the recorder, and its arguments.
+type recorded struct {
+	query string
+	args  []driver.Value
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return r, nil }
+func (r *recorder) Driver() driver.Driver                        { return nil }
+func (r *recorder) Prepare(string) (driver.Stmt, error)          { return nil, driver.ErrSkip }
+func (r *recorder) Close() error                                 { return nil }
+func (r *recorder) Begin() (driver.Tx, error)                    { return nil, driver.ErrSkip }
+
+func (r *recorder) record(query string, args []driver.NamedValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var values []driver.Value
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+	r.statements = append(r.statements, recorded{query, values})
+}
+
+func (r *recorder) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	r.record(query, args)
+	return &recordedRows{row: r.rows[query]}, nil
+}
+
+func (r *recorder) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	r.record(query, args)
+	return driver.RowsAffected(0), nil
+}
+
+// queries returns the statements given to the recorder, without their arguments.
+func (r *recorder) queries() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var queries []string
+	for _, statement := range r.statements {
+		queries = append(queries, statement.query)
+	}
+	return queries
+}
+
+// recordedRows returns a single row, if it has one.
+type recordedRows struct {
+	row  []driver.Value
+	done bool
+}
+
+func (rows *recordedRows) Columns() []string {
+	columns := make([]string, len(rows.row))
+	for i := range columns {
+		columns[i] = fmt.Sprintf("c%d", i)
+	}
+	return columns
+}
+
+func (rows *recordedRows) Close() error { return nil }
+
+func (rows *recordedRows) Next(dest []driver.Value) error {
+	if rows.done || rows.row == nil {
+		return io.EOF
+	}
+	rows.done = true
+	copy(dest, rows.row)
+	return nil
+}
+
+// newRecordedDB returns a SQL database whose statements are made on the recorder.
+func newRecordedDB(t *testing.T, r *recorder) *sqldb {
+	t.Helper()
+	conn := sql.OpenDB(r)
+	t.Cleanup(func() { conn.Close() })
+	return &sqldb{ctx: context.Background(), sqldb: conn, readDB: conn}
+}
+
+func TestSQLPage(t *testing.T) {
+	tests := []struct {
+		limit, offset int
+		clause        string
+		args          []any
+	}{
+		{0, 0, "", nil},
+		{-1, -5, "", nil},
+		{10, 0, " LIMIT ?", []any{10}},
+		{10, 20, " LIMIT ? OFFSET ?", []any{10, 20}},
+		{0, 20, " LIMIT 18446744073709551615 OFFSET ?", []any{20}},
+		{-1, 20, " LIMIT 18446744073709551615 OFFSET ?", []any{20}},
+	}
+	for _, test := range tests {
+		clause, args := sqlPage(test.limit, test.offset)
+		if clause != test.clause || !reflect.DeepEqual(args, test.args) {
+			t.Errorf("sqlPage(%d, %d) = %q, %v, want %q, %v", test.limit, test.offset, clause, args, test.clause, test.args)
+		}
+	}
+}
+
+func TestSQLFilterQueries(t *testing.T) {
+	const (
+		selectTeams    = "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name FROM teams WHERE 1=1"
+		selectEvents   = "SELECT event_id, event_code, year, name, type, division_code, region_code, league_code, venue, address, city, state_prov, country, timezone, date_start, date_end, latitude, longitude, unofficial FROM events"
+		selectMatches  = "SELECT match_id, event_id, match_type, match_number, actual_start_time, description, tournament_level, source FROM matches"
+		selectRankings = "SELECT team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg, auto_opr FROM team_rankings WHERE 1=1"
+	)
+	official := false
+	tests := []struct {
+		name  string
+		get   func(db *sqldb) error
+		query string
+		args  []driver.Value
+	}{
+		{
+			name: "teams",
+			get: func(db *sqldb) error {
+				_, err := db.GetAllTeams(TeamFilter{TeamIDs: []int{1, 2}, Countries: []string{"USA"}, HomeRegions: []string{"USCA", "USTX"}, Limit: 10, Offset: 20})
+				return err
+			},
+			query: selectTeams + " AND team_id IN (?,?) AND country IN (?) AND home_region IN (?,?) ORDER BY team_id LIMIT ? OFFSET ?",
+			args:  []driver.Value{int64(1), int64(2), "USA", "USCA", "USTX", int64(10), int64(20)},
+		},
+		{
+			name: "events",
+			get: func(db *sqldb) error {
+				_, err := db.GetAllEvents(EventFilter{RegionCodes: []string{"USCA"}, Year: 2025, Unofficial: &official, Offset: 50})
+				return err
+			},
+			query: selectEvents + " WHERE 1=1 AND region_code IN (?) AND year = ? AND unofficial = ? ORDER BY date_start, event_code, event_id LIMIT 18446744073709551615 OFFSET ?",
+			args:  []driver.Value{"USCA", int64(2025), false, int64(50)},
+		},
+		{
+			name: "events without a filter",
+			get: func(db *sqldb) error {
+				_, err := db.GetAllEvents()
+				return err
+			},
+			query: selectEvents + " ORDER BY date_start, event_code, event_id",
+		},
+		{
+			name: "matches",
+			get: func(db *sqldb) error {
+				_, err := db.GetAllMatches(MatchFilter{EventIDs: []string{"2025 : USCAQ1"}, Limit: 5})
+				return err
+			},
+			query: selectMatches + " WHERE event_id IN (?) ORDER BY event_id, tournament_level DESC, match_number LIMIT ?",
+			args:  []driver.Value{"2025 : USCAQ1", int64(5)},
+		},
+		{
+			name: "team rankings",
+			get: func(db *sqldb) error {
+				_, err := db.GetTeamRankings(TeamRankingFilter{EventIDs: []string{"a", "b"}})
+				return err
+			},
+			query: selectRankings + " AND event_id IN (?,?) ORDER BY event_id, team_id",
+			args:  []driver.Value{"a", "b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &recorder{}
+			if err := test.get(newRecordedDB(t, r)); err != nil {
+				t.Fatal(err)
+			}
+			if len(r.statements) != 1 {
+				t.Fatalf("got %d statements, want 1: %q", len(r.statements), r.queries())
+			}
+			got := r.statements[0]
+			if got.query != test.query {
+				t.Errorf("query = %q, want %q", got.query, test.query)
+			}
+			if !reflect.DeepEqual(got.args, test.args) {
+				t.Errorf("args = %v, want %v", got.args, test.args)
+			}
+		})
+	}
+}
+
+func TestMigrateSchema(t *testing.T) {
+	const selectVersion = "SELECT COALESCE(MAX(version), 0) FROM schema_migrations"
+	latest := schemaMigrations[len(schemaMigrations)-1].version
+	newRecorder := func(version int64, orphans map[string]int64) *recorder {
+		r := &recorder{rows: map[string][]driver.Value{
+			selectVersion:            {version},
+			"SELECT GET_LOCK(?, 60)": {int64(1)},
+		}}
+		for _, table := range orphanTables {
+			r.rows[table.countOrphansQuery()] = []driver.Value{orphans[table.table]}
+		}
+		return r
+	}
+
+	// The statements of every migration are applied in order, and each migration is recorded once it is applied
+	r := newRecorder(0, nil)
+	if err := newRecordedDB(t, r).migrateSchema(); err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, migration := range schemaMigrations {
+		if migration.check != nil {
+			for _, table := range orphanTables {
+				want = append(want, table.countOrphansQuery())
+			}
+		}
+		want = append(want, migration.statements...)
+		want = append(want, "INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, UTC_TIMESTAMP(6))")
+	}
+	queries := r.queries()
+	start := slices.Index(queries, want[0])
+	if start < 0 || len(queries) < start+len(want) || !slices.Equal(queries[start:start+len(want)], want) {
+		t.Errorf("migrating an empty database made %q, want %q", queries, want)
+	}
+
+	// Nothing is applied to a database that is up to date
+	r = newRecorder(int64(latest), nil)
+	if err := newRecordedDB(t, r).migrateSchema(); err != nil {
+		t.Fatal(err)
+	}
+	if queries := r.queries(); !slices.Equal(queries, []string{selectVersion}) {
+		t.Errorf("migrating an up to date database made %q, want only %q", queries, selectVersion)
+	}
+
+	// Orphaned rows stop the foreign keys from being added, and are counted in the error
+	r = newRecorder(2, map[string]int64{"match_teams": 3, "team_rankings": 1})
+	err := newRecordedDB(t, r).migrateSchema()
+	if err == nil {
+		t.Fatal("migrating a database with orphaned rows succeeded")
+	}
+	for _, s := range []string{"match_teams: 3", "team_rankings: 1", deleteOrphansEnv} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("error %q doesn't include %q", err, s)
+		}
+	}
+	for _, query := range r.queries() {
+		if strings.HasPrefix(query, "DELETE") || slices.Contains(foreignKeyStatements, query) {
+			t.Errorf("migrating a database with orphaned rows made %q", query)
+		}
+	}
+
+	// Orphaned rows are deleted before the foreign keys are added when permission is given
+	t.Setenv(deleteOrphansEnv, "true")
+	r = newRecorder(2, map[string]int64{"match_teams": 3})
+	if err := newRecordedDB(t, r).migrateSchema(); err != nil {
+		t.Fatal(err)
+	}
+	queries = r.queries()
+	deleted := slices.Index(queries, orphanTable{"match_teams", "matches", "match_id"}.deleteOrphansStatement())
+	if deleted < 0 || deleted > slices.Index(queries, foreignKeyStatements[0]) {
+		t.Errorf("orphaned match teams weren't deleted before the foreign keys were added: %q", queries)
+	}
+	if slices.Contains(queries, orphanTable{"team_rankings", "events", "event_id"}.deleteOrphansStatement()) {
+		t.Error("team rankings were deleted without any being orphaned")
+	}
+}
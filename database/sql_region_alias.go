@@ -0,0 +1,82 @@
+package database
+
+import "fmt"
+
+// initRegionAliasStatements prepares all SQL statements for region alias operations.
+func (db *sqldb) initRegionAliasStatements() error {
+	queries := map[string]string{
+		"getRegionAliases":  "SELECT alias, region_code FROM region_aliases ORDER BY alias_key",
+		"saveRegionAlias":   "INSERT INTO region_aliases (alias_key, alias, region_code) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE alias = VALUES(alias), region_code = VALUES(region_code)",
+		"deleteRegionAlias": "DELETE FROM region_aliases WHERE alias_key = ?",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetRegionAliases retrieves all region aliases.
+func (db *sqldb) GetRegionAliases() ([]*RegionAlias, error) {
+	ctx, done := db.startQuery("GetRegionAliases")
+	defer done()
+
+	stmt := db.readStatement("getRegionAliases")
+	if stmt == nil {
+		return nil, fmt.Errorf("prepared statement not found")
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []*RegionAlias
+	for rows.Next() {
+		var alias RegionAlias
+		err := rows.Scan(
+			&alias.Alias,
+			&alias.RegionCode,
+		)
+		if err != nil {
+			continue
+		}
+		aliases = append(aliases, &alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// SaveRegionAlias saves a region alias, replacing any alias that is the same without regard to case or spacing.
+func (db *sqldb) SaveRegionAlias(alias *RegionAlias) error {
+	ctx, done := db.startQuery("SaveRegionAlias")
+	defer done()
+
+	stmt := db.getStatement("saveRegionAlias")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		aliasKey(alias.Alias),
+		NormalizeAlias(alias.Alias),
+		NormalizeCode(alias.RegionCode),
+	)
+	return err
+}
+
+// DeleteRegionAlias deletes a region alias, matching the alias without regard to case or spacing.
+func (db *sqldb) DeleteRegionAlias(alias string) error {
+	ctx, done := db.startQuery("DeleteRegionAlias")
+	defer done()
+
+	stmt := db.getStatement("deleteRegionAlias")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, aliasKey(alias))
+	return err
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// replicaRetryInterval is how long reads are made on the primary after the read replica can't be connected to,
+	// before connecting to the replica is tried again.
+	replicaRetryInterval = 30 * time.Second
+
+	// replicaDialTimeout is how long connecting to the read replica is tried before reading from the primary, if
+	// the replica's connection string doesn't set a timeout.
+	replicaDialTimeout = 5 * time.Second
+)
+
+// failoverConnector connects to the read replica, or to the primary while the replica can't be connected to. The
+// pool of connections that reads are made on is opened with it, so reads move to the primary when the replica goes
+// down, and back to the replica as the pool's connections are replaced once it is up again.
+type failoverConnector struct {
+	replica driver.Connector
+	primary driver.Connector
+
+	mu        sync.Mutex
+	downUntil time.Time // Connections are made to the primary until this time
+}
+
+// openReadReplica opens the pool of connections that reads are made on, connecting to the read replica and falling
+// back to the primary while the replica is unavailable.
+func openReadReplica(dsn string, replicaDSN string) (*sql.DB, error) {
+	primaryCfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	replicaCfg, err := mysql.ParseDSN(replicaDSN)
+	if err != nil {
+		return nil, err
+	}
+	if replicaCfg.Timeout == 0 {
+		replicaCfg.Timeout = replicaDialTimeout
+	}
+	primary, err := mysql.NewConnector(primaryCfg)
+	if err != nil {
+		return nil, err
+	}
+	replica, err := mysql.NewConnector(replicaCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	readDB := sql.OpenDB(&failoverConnector{replica: replica, primary: primary})
+	readDB.SetConnMaxLifetime(time.Minute * 3) // Connections made to the primary are moved back to the replica when they are replaced
+	readDB.SetMaxOpenConns(10)
+	readDB.SetMaxIdleConns(10)
+	if err := readDB.Ping(); err != nil {
+		readDB.Close()
+		return nil, err
+	}
+	slog.Info("Reading from the read replica", "replica", replicaCfg.Addr)
+	return readDB, nil
+}
+
+// Connect implements driver.Connector. It connects to the read replica, unless connecting to it recently failed,
+// and to the primary if the replica can't be connected to.
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	useReplica := time.Now().After(c.downUntil)
+	c.mu.Unlock()
+
+	if useReplica {
+		conn, err := c.replica.Connect(ctx)
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		slog.Warn("Read replica is unavailable; reading from the primary database", "error", err, "retryIn", replicaRetryInterval)
+		c.mu.Lock()
+		c.downUntil = time.Now().Add(replicaRetryInterval)
+		c.mu.Unlock()
+	}
+	return c.primary.Connect(ctx)
+}
+
+// Driver implements driver.Connector.
+func (c *failoverConnector) Driver() driver.Driver {
+	return c.primary.Driver()
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// pruneSeasonQueries delete the data for a season, with the season's year as the only parameter. Rows that
+// depend on an event are deleted before the event itself. Teams and award definitions are shared by all
+// seasons and are not deleted.
+var pruneSeasonQueries = []string{
+	"DELETE mt FROM match_teams mt INNER JOIN matches m ON mt.match_id = m.match_id INNER JOIN events e ON m.event_id = e.event_id WHERE e.year = ?",
+	"DELETE s FROM match_alliance_scores s INNER JOIN matches m ON s.match_id = m.match_id INNER JOIN events e ON m.event_id = e.event_id WHERE e.year = ?",
+	"DELETE m FROM matches m INNER JOIN events e ON m.event_id = e.event_id WHERE e.year = ?",
+	"DELETE ea FROM event_awards ea INNER JOIN events e ON ea.event_id = e.event_id WHERE e.year = ?",
+	"DELETE er FROM event_rankings er INNER JOIN events e ON er.event_id = e.event_id WHERE e.year = ?",
+	"DELETE ea FROM event_advancements ea INNER JOIN events e ON ea.event_id = e.event_id WHERE e.year = ?",
+	"DELETE et FROM event_teams et INNER JOIN events e ON et.event_id = e.event_id WHERE e.year = ?",
+	"DELETE tr FROM team_rankings tr INNER JOIN events e ON tr.event_id = e.event_id WHERE e.year = ?",
+	"DELETE ts FROM team_ranking_snapshots ts INNER JOIN events e ON ts.event_id = e.event_id WHERE e.year = ?",
+	"DELETE s FROM event_summary s INNER JOIN events e ON s.event_id = e.event_id WHERE e.year = ?",
+	"DELETE c FROM advancement_cutoffs c INNER JOIN events e ON c.event_id = e.event_id WHERE e.year = ?",
+	"DELETE es FROM event_syncs es INNER JOIN events e ON es.event_id = e.event_id WHERE e.year = ?",
+	"DELETE h FROM endpoint_hashes h INNER JOIN events e ON h.event_id = e.event_id WHERE e.year = ?",
+	"DELETE r FROM sync_retries r INNER JOIN events e ON r.event_id = e.event_id WHERE e.year = ?",
+	"DELETE k FROM event_source_keys k INNER JOIN events e ON k.event_id = e.event_id WHERE e.year = ?",
+	"DELETE FROM sync_checkpoints WHERE season = ?",
+	"DELETE FROM sync_runs WHERE season = ?",
+	"DELETE FROM team_notes WHERE year = ?",
+	"DELETE FROM events WHERE year = ?",
+}
+
+// sqlDataSourceName returns the connection string of the season's database, the same one initSQLDB opens for the
+// season: DATA_SOURCE_NAME_<season> if it is set, and DATA_SOURCE_NAME otherwise.
+func sqlDataSourceName(season string) string {
+	if season != "" {
+		if dsn := os.Getenv("DATA_SOURCE_NAME_" + season); dsn != "" {
+			return dsn
+		}
+	}
+	return os.Getenv("DATA_SOURCE_NAME")
+}
+
+// ownSQLSeasons returns the seasons kept in their own database, given by a DATA_SOURCE_NAME_<season> environment
+// variable.
+func ownSQLSeasons() []string {
+	var seasons []string
+	for _, env := range os.Environ() {
+		name, dsn, _ := strings.Cut(env, "=")
+		if season, ok := strings.CutPrefix(name, "DATA_SOURCE_NAME_"); ok && dsn != "" && seasonYear(season) > 0 {
+			seasons = append(seasons, season)
+		}
+	}
+	return seasons
+}
+
+// openSeasonSQLDB opens a connection to the season's database, or to the DATA_SOURCE_NAME database if season is
+// empty.
+func openSeasonSQLDB(season string) (*sql.DB, error) {
+	dsn := sqlDataSourceName(season)
+	if dsn == "" {
+		return nil, errors.New("DATA_SOURCE_NAME environment variable not set")
+	}
+	return sql.Open("mysql", dsn)
+}
+
+// getSQLSeasonUsage reports the number of events and matches for each event year. The seasons kept in their own
+// DATA_SOURCE_NAME_<season> database are counted in that database, and the others in the DATA_SOURCE_NAME database.
+// MySQL does not report the storage used by a subset of a table's rows, so the size of each season is not known.
+func getSQLSeasonUsage() ([]*SeasonUsage, error) {
+	own := ownSQLSeasons()
+	var usage []*SeasonUsage
+	if os.Getenv("DATA_SOURCE_NAME") != "" || len(own) == 0 {
+		shared, err := querySQLSeasonUsage("")
+		if err != nil {
+			return nil, err
+		}
+		for _, su := range shared {
+			// Any rows for the season in the shared database aren't the ones the season is read from
+			if !slices.Contains(own, su.Season) {
+				usage = append(usage, su)
+			}
+		}
+	}
+	for _, season := range own {
+		seasonUsage, err := querySQLSeasonUsage(season)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the usage of season %s: %w", season, err)
+		}
+		if len(seasonUsage) == 0 {
+			seasonUsage = []*SeasonUsage{{Season: season, Bytes: -1}}
+		}
+		usage = append(usage, seasonUsage...)
+	}
+	return usage, nil
+}
+
+// querySQLSeasonUsage reports the number of events and matches for each event year in the season's database. If
+// season is empty, every event year in the DATA_SOURCE_NAME database is reported; otherwise only the season's year.
+func querySQLSeasonUsage(season string) ([]*SeasonUsage, error) {
+	db, err := openSeasonSQLDB(season)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	query := "SELECT e.year, COUNT(DISTINCT e.event_id), COUNT(m.match_id) FROM events e LEFT JOIN matches m ON m.event_id = e.event_id"
+	var args []any
+	if season != "" {
+		query += " WHERE e.year = ?"
+		args = append(args, season)
+	}
+	rows, err := db.Query(query+" GROUP BY e.year", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []*SeasonUsage
+	for rows.Next() {
+		var year int
+		su := &SeasonUsage{Bytes: -1}
+		if err := rows.Scan(&year, &su.Events, &su.Matches); err != nil {
+			return nil, err
+		}
+		su.Season = fmt.Sprintf("%d", year)
+		usage = append(usage, su)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// pruneSQLSeasons deletes the data for the seasons, each from the database it is kept in. Each season is deleted in
+// its own transaction, so a failure leaves every season either fully present or fully deleted.
+func pruneSQLSeasons(seasons []string) error {
+	for _, season := range seasons {
+		if err := pruneSQLSeason(season); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneSQLSeason deletes the data for the season from its database in a single transaction.
+func pruneSQLSeason(season string) error {
+	db, err := openSeasonSQLDB(season)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, query := range pruneSeasonQueries {
+		if _, err := tx.Exec(query, season); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to prune season %s: %w", season, err)
+		}
+	}
+	return tx.Commit()
+}
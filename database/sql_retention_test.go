@@ -0,0 +1,31 @@
+package database
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSQLDataSourceName(t *testing.T) {
+	t.Setenv("DATA_SOURCE_NAME", "user@tcp(shared)/ftc")
+	t.Setenv("DATA_SOURCE_NAME_2023", "user@tcp(archive)/ftc2023")
+	t.Setenv("READ_DATA_SOURCE_NAME_2022", "user@tcp(replica)/ftc2022")
+
+	tests := []struct {
+		season string
+		want   string
+	}{
+		{"2023", "user@tcp(archive)/ftc2023"},
+		{"2024", "user@tcp(shared)/ftc"},
+		{"2022", "user@tcp(shared)/ftc"}, // A replica alone doesn't move the season to its own database
+		{"", "user@tcp(shared)/ftc"},
+	}
+	for _, test := range tests {
+		if got := sqlDataSourceName(test.season); got != test.want {
+			t.Errorf("sqlDataSourceName(%q) = %q, want %q", test.season, got, test.want)
+		}
+	}
+
+	if got := ownSQLSeasons(); !slices.Equal(got, []string{"2023"}) {
+		t.Errorf("ownSQLSeasons() = %v, want [2023]", got)
+	}
+}
@@ -0,0 +1,628 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// schemaMigration is a change to the SQL schema. Migrations are applied in order of version, and each applied
+// migration is recorded in the schema_migrations table so it is only applied once. If the migration has a check, it
+// is run before any of the statements, and an error from it stops the migration before anything is changed.
+type schemaMigration struct {
+	version     int
+	description string
+	statements  []string
+	check       func(db *sqldb, conn *sql.Conn) error
+}
+
+// schemaMigrations are the changes that build the SQL schema. New migrations must be appended with the next
+// version; a migration that has been released must never be changed.
+var schemaMigrations = []schemaMigration{
+	{version: 1, description: "create tables", statements: createTableStatements},
+	{version: 2, description: "add surrogate keys", statements: surrogateKeyStatements},
+	{version: 3, description: "add foreign keys", statements: foreignKeyStatements, check: (*sqldb).checkOrphans},
+	{version: 4, description: "add region aliases", statements: regionAliasStatements},
+	{version: 5, description: "add advancement cutoffs", statements: advancementCutoffStatements},
+	{version: 6, description: "store match start times as times", statements: matchStartTimeStatements},
+	{version: 7, description: "add event syncs", statements: eventSyncStatements},
+	{version: 8, description: "add event team registrations", statements: eventTeamRegistrationStatements},
+	{version: 9, description: "add team ranking auto OPR", statements: teamRankingAutoOPRStatements},
+	{version: 10, description: "add endpoint hashes", statements: endpointHashStatements},
+	{version: 11, description: "add sync runs", statements: syncRunStatements},
+	{version: 12, description: "add sync retries", statements: syncRetryStatements},
+	{version: 13, description: "add team history", statements: teamHistoryStatements},
+	{version: 14, description: "add event award recipients", statements: eventAwardPersonStatements},
+	{version: 15, description: "add team notes", statements: teamNoteStatements},
+	{version: 16, description: "add surrogate keys to team history and notes", statements: teamSurrogateKeyStatements},
+}
+
+// updatedAtColumn is maintained by MySQL and used by the change feed.
+const updatedAtColumn = "updated_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6)"
+
+// createTableStatements create the tables, keyed by their natural keys, if they don't already exist. Databases
+// created before migrations were added already have these tables and are left as they are.
+var createTableStatements = []string{
+	`CREATE TABLE IF NOT EXISTS awards (
+		award_id INT NOT NULL,
+		name VARCHAR(128) NOT NULL DEFAULT '',
+		description TEXT,
+		for_person BOOLEAN NOT NULL DEFAULT FALSE,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (award_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS teams (
+		team_id INT NOT NULL,
+		name VARCHAR(255) NOT NULL DEFAULT '',
+		full_name TEXT,
+		city VARCHAR(128) NOT NULL DEFAULT '',
+		state_prov VARCHAR(64) NOT NULL DEFAULT '',
+		country VARCHAR(64) NOT NULL DEFAULT '',
+		website VARCHAR(255) NOT NULL DEFAULT '',
+		rookie_year INT NOT NULL DEFAULT 0,
+		home_region VARCHAR(16) NOT NULL DEFAULT '',
+		robot_name VARCHAR(128) NOT NULL DEFAULT '',
+		` + updatedAtColumn + `,
+		PRIMARY KEY (team_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS events (
+		event_id VARCHAR(64) NOT NULL,
+		event_code VARCHAR(32) NOT NULL,
+		year INT NOT NULL,
+		name VARCHAR(255) NOT NULL DEFAULT '',
+		type VARCHAR(16) NOT NULL DEFAULT '',
+		division_code VARCHAR(32) NOT NULL DEFAULT '',
+		region_code VARCHAR(16) NOT NULL DEFAULT '',
+		league_code VARCHAR(32) NOT NULL DEFAULT '',
+		venue VARCHAR(255) NOT NULL DEFAULT '',
+		address VARCHAR(255) NOT NULL DEFAULT '',
+		city VARCHAR(128) NOT NULL DEFAULT '',
+		state_prov VARCHAR(64) NOT NULL DEFAULT '',
+		country VARCHAR(64) NOT NULL DEFAULT '',
+		timezone VARCHAR(64) NOT NULL DEFAULT '',
+		date_start DATETIME NOT NULL,
+		date_end DATETIME NOT NULL,
+		latitude DOUBLE NOT NULL DEFAULT 0,
+		longitude DOUBLE NOT NULL DEFAULT 0,
+		unofficial BOOLEAN NOT NULL DEFAULT FALSE,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (event_id),
+		KEY events_year (year),
+		KEY events_region_code (region_code)
+	)`,
+	`CREATE TABLE IF NOT EXISTS matches (
+		match_id VARCHAR(128) NOT NULL,
+		event_id VARCHAR(64) NOT NULL,
+		match_type VARCHAR(32) NOT NULL DEFAULT '',
+		match_number INT NOT NULL,
+		actual_start_time VARCHAR(32) NOT NULL DEFAULT '',
+		description VARCHAR(128) NOT NULL DEFAULT '',
+		tournament_level VARCHAR(32) NOT NULL DEFAULT '',
+		source VARCHAR(32) NOT NULL DEFAULT '',
+		` + updatedAtColumn + `,
+		PRIMARY KEY (match_id),
+		KEY matches_event_id (event_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS match_alliance_scores (
+		match_id VARCHAR(128) NOT NULL,
+		alliance VARCHAR(8) NOT NULL,
+		auto_points INT NOT NULL DEFAULT 0,
+		teleop_points INT NOT NULL DEFAULT 0,
+		foul_points_committed INT NOT NULL DEFAULT 0,
+		pre_foul_total INT NOT NULL DEFAULT 0,
+		total_points INT NOT NULL DEFAULT 0,
+		major_fouls INT NOT NULL DEFAULT 0,
+		minor_fouls INT NOT NULL DEFAULT 0,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (match_id, alliance)
+	)`,
+	`CREATE TABLE IF NOT EXISTS match_teams (
+		match_id VARCHAR(128) NOT NULL,
+		team_id INT NOT NULL,
+		alliance VARCHAR(8) NOT NULL,
+		dq BOOLEAN NOT NULL DEFAULT FALSE,
+		on_field BOOLEAN NOT NULL DEFAULT TRUE,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (match_id, team_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS event_awards (
+		event_id VARCHAR(64) NOT NULL,
+		team_id INT NOT NULL,
+		award_id INT NOT NULL,
+		name VARCHAR(128) NOT NULL DEFAULT '',
+		series INT NOT NULL DEFAULT 0,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (event_id, team_id, award_id, series)
+	)`,
+	`CREATE TABLE IF NOT EXISTS event_rankings (
+		event_id VARCHAR(64) NOT NULL,
+		team_id INT NOT NULL,
+		` + "`rank`" + ` INT NOT NULL DEFAULT 0,
+		sort_order1 DOUBLE NOT NULL DEFAULT 0,
+		sort_order2 DOUBLE NOT NULL DEFAULT 0,
+		sort_order3 DOUBLE NOT NULL DEFAULT 0,
+		sort_order4 DOUBLE NOT NULL DEFAULT 0,
+		sort_order5 DOUBLE NOT NULL DEFAULT 0,
+		sort_order6 DOUBLE NOT NULL DEFAULT 0,
+		wins INT NOT NULL DEFAULT 0,
+		losses INT NOT NULL DEFAULT 0,
+		ties INT NOT NULL DEFAULT 0,
+		dq INT NOT NULL DEFAULT 0,
+		matches_played INT NOT NULL DEFAULT 0,
+		matches_counted INT NOT NULL DEFAULT 0,
+		source VARCHAR(32) NOT NULL DEFAULT '',
+		` + updatedAtColumn + `,
+		PRIMARY KEY (event_id, team_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS event_advancements (
+		event_id VARCHAR(64) NOT NULL,
+		team_id INT NOT NULL,
+		status VARCHAR(32) NOT NULL DEFAULT '',
+		` + updatedAtColumn + `,
+		PRIMARY KEY (event_id, team_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS event_teams (
+		event_id VARCHAR(64) NOT NULL,
+		team_id INT NOT NULL,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (event_id, team_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS team_rankings (
+		team_id INT NOT NULL,
+		event_id VARCHAR(64) NOT NULL,
+		num_matches INT NOT NULL DEFAULT 0,
+		ccwm DOUBLE NOT NULL DEFAULT 0,
+		opr DOUBLE NOT NULL DEFAULT 0,
+		np_opr DOUBLE NOT NULL DEFAULT 0,
+		dpr DOUBLE NOT NULL DEFAULT 0,
+		np_dpr DOUBLE NOT NULL DEFAULT 0,
+		np_avg DOUBLE NOT NULL DEFAULT 0,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (team_id, event_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS team_ranking_snapshots (
+		snapshot_date DATE NOT NULL,
+		team_id INT NOT NULL,
+		event_id VARCHAR(64) NOT NULL,
+		num_matches INT NOT NULL DEFAULT 0,
+		ccwm DOUBLE NOT NULL DEFAULT 0,
+		opr DOUBLE NOT NULL DEFAULT 0,
+		np_opr DOUBLE NOT NULL DEFAULT 0,
+		dpr DOUBLE NOT NULL DEFAULT 0,
+		np_dpr DOUBLE NOT NULL DEFAULT 0,
+		np_avg DOUBLE NOT NULL DEFAULT 0,
+		PRIMARY KEY (snapshot_date, team_id, event_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS sync_checkpoints (
+		season VARCHAR(8) NOT NULL,
+		event_id VARCHAR(64) NOT NULL,
+		completed_at DATETIME(6) NOT NULL,
+		PRIMARY KEY (season, event_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS event_source_keys (
+		source VARCHAR(32) NOT NULL,
+		source_key VARCHAR(64) NOT NULL,
+		event_id VARCHAR(64) NOT NULL,
+		PRIMARY KEY (source, source_key)
+	)`,
+	`CREATE TABLE IF NOT EXISTS event_summary (
+		event_id VARCHAR(64) NOT NULL,
+		qual_matches INT NOT NULL DEFAULT 0,
+		playoff_matches INT NOT NULL DEFAULT 0,
+		num_teams INT NOT NULL DEFAULT 0,
+		high_score INT NOT NULL DEFAULT 0,
+		average_score DOUBLE NOT NULL DEFAULT 0,
+		average_np_score DOUBLE NOT NULL DEFAULT 0,
+		average_opr DOUBLE NOT NULL DEFAULT 0,
+		average_np_opr DOUBLE NOT NULL DEFAULT 0,
+		top_opr_team_id INT NOT NULL DEFAULT 0,
+		top_opr DOUBLE NOT NULL DEFAULT 0,
+		top_np_opr_team_id INT NOT NULL DEFAULT 0,
+		top_np_opr DOUBLE NOT NULL DEFAULT 0,
+		` + updatedAtColumn + `,
+		PRIMARY KEY (event_id)
+	)`,
+}
+
+// naturalKey is the columns that identify a row of a table.
+type naturalKey struct {
+	table   string
+	columns string
+}
+
+// naturalKeys are the columns that identify a row of each table. Queries continue to look up and replace rows by
+// their natural key, which is kept unique once the tables are given surrogate keys.
+var naturalKeys = []naturalKey{
+	{"awards", "award_id"},
+	{"teams", "team_id"},
+	{"events", "event_id"},
+	{"matches", "match_id"},
+	{"match_alliance_scores", "match_id, alliance"},
+	{"match_teams", "match_id, team_id"},
+	{"event_awards", "event_id, team_id, award_id, series"},
+	{"event_rankings", "event_id, team_id"},
+	{"event_advancements", "event_id, team_id"},
+	{"event_teams", "event_id, team_id"},
+	{"team_rankings", "team_id, event_id"},
+	{"team_ranking_snapshots", "snapshot_date, team_id, event_id"},
+	{"sync_checkpoints", "season, event_id"},
+	{"event_source_keys", "source, source_key"},
+	{"event_summary", "event_id"},
+}
+
+// surrogateKeyStatements replace the primary key of each table with an auto-incremented integer, keeping the
+// natural key as a unique key.
+var surrogateKeyStatements = addSurrogateKeys(naturalKeys)
+
+// addSurrogateKeys returns the statements that replace the primary key of each table with an auto-incremented
+// integer, keeping the natural key as a unique key.
+func addSurrogateKeys(keys []naturalKey) []string {
+	statements := make([]string, 0, len(keys))
+	for _, key := range keys {
+		statements = append(statements, fmt.Sprintf(
+			"ALTER TABLE %s DROP PRIMARY KEY, ADD COLUMN id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY FIRST, ADD UNIQUE KEY %s_natural_key (%s)",
+			key.table, key.table, key.columns))
+	}
+	return statements
+}
+
+// eventChildTables are the tables whose rows belong to an event.
+var eventChildTables = []string{
+	"matches",
+	"event_awards",
+	"event_rankings",
+	"event_advancements",
+	"event_teams",
+	"team_rankings",
+	"team_ranking_snapshots",
+	"sync_checkpoints",
+	"event_source_keys",
+	"event_summary",
+}
+
+// matchChildTables are the tables whose rows belong to a match.
+var matchChildTables = []string{
+	"match_alliance_scores",
+	"match_teams",
+}
+
+// orphanTable is a table whose rows belong to a row of its parent table, referenced by the column.
+type orphanTable struct {
+	table  string
+	parent string
+	column string
+}
+
+// orphanTables are the tables that get foreign keys, in the order their orphaned rows are deleted. A match's rows
+// are deleted before the matches of missing events, so they are counted as orphans of their match.
+var orphanTables = func() []orphanTable {
+	var tables []orphanTable
+	for _, table := range matchChildTables {
+		tables = append(tables, orphanTable{table, "matches", "match_id"})
+	}
+	for _, table := range eventChildTables {
+		tables = append(tables, orphanTable{table, "events", "event_id"})
+	}
+	return tables
+}()
+
+// countOrphansQuery returns the query counting the rows of the table whose parent is missing.
+func (t orphanTable) countOrphansQuery() string {
+	return fmt.Sprintf("SELECT COUNT(*) FROM %s c LEFT JOIN %s p ON c.%s = p.%s WHERE p.%s IS NULL", t.table, t.parent, t.column, t.column, t.column)
+}
+
+// deleteOrphansStatement returns the statement deleting the rows of the table whose parent is missing.
+func (t orphanTable) deleteOrphansStatement() string {
+	return fmt.Sprintf("DELETE c FROM %s c LEFT JOIN %s p ON c.%s = p.%s WHERE p.%s IS NULL", t.table, t.parent, t.column, t.column, t.column)
+}
+
+// deleteOrphansEnv is the environment variable that allows the foreign key migration to delete the rows that belong
+// to a missing event or match.
+const deleteOrphansEnv = "SQL_DELETE_ORPHANS"
+
+// checkOrphans counts the rows that belong to a missing event or match, which would stop the foreign keys from being
+// added. If there are any, an error reporting how many are in each table is returned, so they can be repaired or
+// removed by hand; the rows are only deleted if SQL_DELETE_ORPHANS is set to true.
+func (db *sqldb) checkOrphans(conn *sql.Conn) error {
+	deleteOrphans := false
+	if value := os.Getenv(deleteOrphansEnv); value != "" {
+		var err error
+		if deleteOrphans, err = strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid %s %q: must be true or false", deleteOrphansEnv, value)
+		}
+	}
+
+	var orphans []string
+	for _, t := range orphanTables {
+		var count int64
+		if err := conn.QueryRowContext(db.ctx, t.countOrphansQuery()).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count the orphaned rows of %s: %w", t.table, err)
+		}
+		if count == 0 {
+			continue
+		}
+		if !deleteOrphans {
+			orphans = append(orphans, fmt.Sprintf("%s: %d", t.table, count))
+			continue
+		}
+		result, err := conn.ExecContext(db.ctx, t.deleteOrphansStatement())
+		if err != nil {
+			return fmt.Errorf("failed to delete the orphaned rows of %s: %w", t.table, err)
+		}
+		rows, _ := result.RowsAffected()
+		slog.Warn("Deleted rows that belong to a missing record", "table", t.table, "parent", t.parent, "rows", rows)
+	}
+	if len(orphans) > 0 {
+		return fmt.Errorf("rows belong to a missing event or match (%s); repair or delete them, or set %s=true to delete them, before the foreign keys can be added",
+			strings.Join(orphans, ", "), deleteOrphansEnv)
+	}
+	return nil
+}
+
+// foreignKeyStatements add foreign keys so that rows can't be orphaned. Deleting an event or match deletes the rows
+// that belong to it, and changing its ID changes theirs. Teams and awards aren't referenced, as events include teams
+// and awards that aren't in the season's team and award lists. Rows that are already orphaned are found by
+// checkOrphans before the keys are added.
+var foreignKeyStatements = func() []string {
+	var statements []string
+	for _, table := range eventChildTables {
+		statements = append(statements, fmt.Sprintf(
+			"ALTER TABLE %s ADD CONSTRAINT %s_event_fk FOREIGN KEY (event_id) REFERENCES events (event_id) ON UPDATE CASCADE ON DELETE CASCADE",
+			table, table))
+	}
+	for _, table := range matchChildTables {
+		statements = append(statements, fmt.Sprintf(
+			"ALTER TABLE %s ADD CONSTRAINT %s_match_fk FOREIGN KEY (match_id) REFERENCES matches (match_id) ON UPDATE CASCADE ON DELETE CASCADE",
+			table, table))
+	}
+	return statements
+}()
+
+// regionAliasStatements create the table of region aliases. The alias key is the alias lower-cased, which aliases
+// are looked up by so that they match without regard to case.
+var regionAliasStatements = []string{
+	`CREATE TABLE IF NOT EXISTS region_aliases (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		alias_key VARCHAR(64) NOT NULL,
+		alias VARCHAR(64) NOT NULL,
+		region_code VARCHAR(16) NOT NULL,
+		PRIMARY KEY (id),
+		UNIQUE KEY region_aliases_natural_key (alias_key)
+	)`,
+}
+
+// advancementCutoffStatements create the table of advancement cutoffs. Each cutoff belongs to an event, so it is
+// deleted along with the event.
+var advancementCutoffStatements = []string{
+	`CREATE TABLE IF NOT EXISTS advancement_cutoffs (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		event_id VARCHAR(64) NOT NULL,
+		teams INT NOT NULL DEFAULT 0,
+		advancing INT NOT NULL DEFAULT 0,
+		cutoff INT NOT NULL DEFAULT 0,
+		PRIMARY KEY (id),
+		UNIQUE KEY advancement_cutoffs_natural_key (event_id),
+		CONSTRAINT advancement_cutoffs_event_fk FOREIGN KEY (event_id) REFERENCES events (event_id) ON UPDATE CASCADE ON DELETE CASCADE
+	)`,
+}
+
+// eventSyncStatements create the table of the times events were last synced. Each sync belongs to an event, so it
+// is deleted along with the event.
+var eventSyncStatements = []string{
+	`CREATE TABLE IF NOT EXISTS event_syncs (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		event_id VARCHAR(64) NOT NULL,
+		synced_at DATETIME(6) NOT NULL,
+		PRIMARY KEY (id),
+		UNIQUE KEY event_syncs_natural_key (event_id),
+		CONSTRAINT event_syncs_event_fk FOREIGN KEY (event_id) REFERENCES events (event_id) ON UPDATE CASCADE ON DELETE CASCADE
+	)`,
+}
+
+// eventTeamRegistrationStatements add whether each event team is registered for the event and whether it has played
+// in the event's matches. The event teams saved before registrations were synced are the teams that played. The
+// update keeps the event teams' updated_at, so the change feed doesn't report every event team as changed.
+var eventTeamRegistrationStatements = []string{
+	"ALTER TABLE event_teams ADD COLUMN registered BOOLEAN NOT NULL DEFAULT FALSE AFTER team_id, ADD COLUMN played BOOLEAN NOT NULL DEFAULT FALSE AFTER registered",
+	"UPDATE event_teams SET played = TRUE, updated_at = updated_at",
+}
+
+// teamRankingAutoOPRStatements add the OPR from the points scored in the autonomous period to the team rankings and
+// their snapshots. The rankings saved before it was added have an auto OPR of 0 until they are calculated again.
+var teamRankingAutoOPRStatements = []string{
+	"ALTER TABLE team_rankings ADD COLUMN auto_opr DOUBLE NOT NULL DEFAULT 0 AFTER np_avg",
+	"ALTER TABLE team_ranking_snapshots ADD COLUMN auto_opr DOUBLE NOT NULL DEFAULT 0 AFTER np_avg",
+}
+
+// endpointHashStatements create the table of the hashes of the last responses from the data source's endpoints.
+// Each hash belongs to an event, so it is deleted along with the event.
+var endpointHashStatements = []string{
+	`CREATE TABLE IF NOT EXISTS endpoint_hashes (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		event_id VARCHAR(64) NOT NULL,
+		endpoint VARCHAR(64) NOT NULL,
+		hash CHAR(64) NOT NULL,
+		hashed_at DATETIME(6) NOT NULL,
+		PRIMARY KEY (id),
+		UNIQUE KEY endpoint_hashes_natural_key (event_id, endpoint),
+		CONSTRAINT endpoint_hashes_event_fk FOREIGN KEY (event_id) REFERENCES events (event_id) ON UPDATE CASCADE ON DELETE CASCADE
+	)`,
+}
+
+// syncRunStatements create the table of the runs that synced a season's data. The errors recorded for a run are
+// saved as a JSON array.
+var syncRunStatements = []string{
+	`CREATE TABLE IF NOT EXISTS sync_runs (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		run_id VARCHAR(64) NOT NULL,
+		season VARCHAR(8) NOT NULL,
+		scope VARCHAR(64) NOT NULL,
+		status VARCHAR(16) NOT NULL,
+		started_at DATETIME(6) NOT NULL,
+		finished_at DATETIME(6) NULL,
+		total_events INT NOT NULL DEFAULT 0,
+		events INT NOT NULL DEFAULT 0,
+		changed_events INT NOT NULL DEFAULT 0,
+		updated_endpoints INT NOT NULL DEFAULT 0,
+		skipped_endpoints INT NOT NULL DEFAULT 0,
+		removed INT NOT NULL DEFAULT 0,
+		error_count INT NOT NULL DEFAULT 0,
+		errors TEXT NOT NULL,
+		PRIMARY KEY (id),
+		UNIQUE KEY sync_runs_natural_key (run_id),
+		KEY sync_runs_season_started (season, started_at)
+	)`,
+}
+
+// syncRetryStatements create the table of the events whose results failed to sync. Each retry belongs to an event, so
+// it is deleted along with the event.
+var syncRetryStatements = []string{
+	`CREATE TABLE IF NOT EXISTS sync_retries (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		event_id VARCHAR(64) NOT NULL,
+		attempts INT NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL,
+		first_failed_at DATETIME(6) NOT NULL,
+		last_failed_at DATETIME(6) NOT NULL,
+		PRIMARY KEY (id),
+		UNIQUE KEY sync_retries_natural_key (event_id),
+		CONSTRAINT sync_retries_event_fk FOREIGN KEY (event_id) REFERENCES events (event_id) ON UPDATE CASCADE ON DELETE CASCADE
+	)`,
+}
+
+// teamHistoryStatements create the table of the names and home regions teams had before they were changed. Teams are
+// shared by every season, so the history is too.
+var teamHistoryStatements = []string{
+	`CREATE TABLE IF NOT EXISTS team_history (
+		team_id INT NOT NULL,
+		name VARCHAR(255) NOT NULL DEFAULT '',
+		home_region VARCHAR(16) NOT NULL DEFAULT '',
+		valid_from DATETIME(6) NULL,
+		valid_to DATETIME(6) NOT NULL,
+		PRIMARY KEY (team_id, valid_to)
+	)`,
+}
+
+// eventAwardPersonStatements add the name of the person an award was given to, for awards given to a person rather
+// than a team. The awards saved before it was added have no name until their event is synced again.
+var eventAwardPersonStatements = []string{
+	"ALTER TABLE event_awards ADD COLUMN person VARCHAR(128) NOT NULL DEFAULT '' AFTER series",
+}
+
+// teamSurrogateKeyStatements give the team history and team notes, which were created with their natural keys as
+// their primary keys, the auto-incremented primary key every other table has.
+var teamSurrogateKeyStatements = addSurrogateKeys([]naturalKey{
+	{"team_history", "team_id, valid_to"},
+	{"team_notes", "year, team_id"},
+})
+
+// teamNoteStatements create the table of the scouting notes and tags on teams. Teams are shared by every season, so
+// the notes are kept by year. The tags are saved as a comma-separated list.
+var teamNoteStatements = []string{
+	`CREATE TABLE IF NOT EXISTS team_notes (
+		year INT NOT NULL,
+		team_id INT NOT NULL,
+		notes TEXT NOT NULL,
+		tags TEXT NOT NULL,
+		source VARCHAR(255) NOT NULL DEFAULT '',
+		` + updatedAtColumn + `,
+		PRIMARY KEY (year, team_id)
+	)`,
+}
+
+// matchStartTimeStatements change the start times of matches from the text reported by the data sources to times
+// in UTC. Start times ending in Z are already in UTC, while the others are in the event's local time and are
+// converted with the event's time zone. If MySQL's time zone tables haven't been loaded, the local times can't be
+// converted and are kept as they are. The update keeps the matches' updated_at, so the change feed doesn't report
+// every match as changed.
+var matchStartTimeStatements = []string{
+	"ALTER TABLE matches ADD COLUMN actual_start DATETIME(6) NULL AFTER actual_start_time",
+	`UPDATE matches m LEFT JOIN events e ON m.event_id = e.event_id
+		SET m.actual_start = CASE
+			WHEN m.actual_start_time LIKE '%Z' THEN STR_TO_DATE(LEFT(m.actual_start_time, 19), '%Y-%m-%dT%H:%i:%s')
+			ELSE COALESCE(
+				CONVERT_TZ(STR_TO_DATE(LEFT(m.actual_start_time, 19), '%Y-%m-%dT%H:%i:%s'), e.timezone, '+00:00'),
+				STR_TO_DATE(LEFT(m.actual_start_time, 19), '%Y-%m-%dT%H:%i:%s'))
+			END,
+			m.updated_at = m.updated_at
+		WHERE m.actual_start_time REGEXP '^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}'`,
+	"ALTER TABLE matches DROP COLUMN actual_start_time, RENAME COLUMN actual_start TO actual_start_time",
+}
+
+// schemaLock is the name of the lock held while migrating the schema.
+const schemaLock = "ftcstanding_schema"
+
+// migrateSchema applies the schema migrations that haven't been applied to the database. A named lock is held
+// while migrating, so programs started at the same time don't apply the same migration twice.
+//
+// MySQL commits each schema change as it is made, so a migration that fails part way through is left partly
+// applied. The error names the statement that failed; once the problem is fixed, the rest of the migration must
+// be applied by hand and its version added to schema_migrations.
+func (db *sqldb) migrateSchema() error {
+	latest := schemaMigrations[len(schemaMigrations)-1].version
+	if version, err := db.schemaVersion(db.sqldb); err == nil && version >= latest {
+		return nil
+	}
+
+	conn, err := db.sqldb.Conn(db.ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(db.ctx, "SELECT GET_LOCK(?, 60)", schemaLock).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return errors.New("timed out waiting for another program to migrate the schema")
+	}
+	defer conn.ExecContext(db.ctx, "DO RELEASE_LOCK(?)", schemaLock)
+
+	if _, err := conn.ExecContext(db.ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version INT NOT NULL PRIMARY KEY, description VARCHAR(128) NOT NULL, applied_at DATETIME(6) NOT NULL)"); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	// Another program may have migrated the schema while waiting for the lock
+	version, err := db.schemaVersion(conn)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range schemaMigrations {
+		if migration.version <= version {
+			continue
+		}
+		slog.Info("Applying schema migration", "version", migration.version, "description", migration.description)
+		if migration.check != nil {
+			if err := migration.check(db, conn); err != nil {
+				return fmt.Errorf("schema migration %d (%s) can't be applied: %w", migration.version, migration.description, err)
+			}
+		}
+		for _, statement := range migration.statements {
+			if _, err := conn.ExecContext(db.ctx, statement); err != nil {
+				return fmt.Errorf("schema migration %d (%s) failed: %s: %w", migration.version, migration.description, statement, err)
+			}
+		}
+		if _, err := conn.ExecContext(db.ctx, "INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, UTC_TIMESTAMP(6))", migration.version, migration.description); err != nil {
+			return fmt.Errorf("failed to record schema migration %d: %w", migration.version, err)
+		}
+	}
+	return nil
+}
+
+// queryRower is implemented by *sql.DB and *sql.Conn.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// schemaVersion returns the version of the last schema migration applied to the database.
+func (db *sqldb) schemaVersion(q queryRower) (int, error) {
+	var version int
+	err := q.QueryRowContext(db.ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
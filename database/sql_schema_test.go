@@ -0,0 +1,79 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// createTable matches the statement creating a table, capturing the table's name.
+var createTable = regexp.MustCompile(`^CREATE TABLE IF NOT EXISTS (\w+) \(`)
+
+func TestSchemaMigrations(t *testing.T) {
+	surrogateKey := make(map[string]bool)
+	for i, migration := range schemaMigrations {
+		if migration.version != i+1 {
+			t.Errorf("migration %d (%s) has version %d, want %d", i+1, migration.description, migration.version, i+1)
+		}
+		for _, statement := range migration.statements {
+			// Rows are only deleted by checks that are given permission to
+			if strings.HasPrefix(statement, "DELETE") {
+				t.Errorf("migration %d (%s) deletes rows: %s", migration.version, migration.description, statement)
+			}
+			if strings.Contains(statement, "id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT") {
+				if match := createTable.FindStringSubmatch(statement); match != nil {
+					surrogateKey[match[1]] = true
+				} else if table, ok := strings.CutPrefix(statement, "ALTER TABLE "); ok {
+					surrogateKey[strings.Fields(table)[0]] = true
+				}
+			}
+		}
+	}
+
+	// Every table is given an auto-incremented primary key, whether it is created with one or gets it later
+	for _, migration := range schemaMigrations {
+		for _, statement := range migration.statements {
+			if match := createTable.FindStringSubmatch(statement); match != nil && !surrogateKey[match[1]] {
+				t.Errorf("%s, created by migration %d (%s), has no surrogate key", match[1], migration.version, migration.description)
+			}
+		}
+	}
+}
+
+func TestOrphanStatements(t *testing.T) {
+	tests := []struct {
+		table orphanTable
+		count string
+		del   string
+	}{
+		{
+			table: orphanTable{"match_teams", "matches", "match_id"},
+			count: "SELECT COUNT(*) FROM match_teams c LEFT JOIN matches p ON c.match_id = p.match_id WHERE p.match_id IS NULL",
+			del:   "DELETE c FROM match_teams c LEFT JOIN matches p ON c.match_id = p.match_id WHERE p.match_id IS NULL",
+		},
+		{
+			table: orphanTable{"team_rankings", "events", "event_id"},
+			count: "SELECT COUNT(*) FROM team_rankings c LEFT JOIN events p ON c.event_id = p.event_id WHERE p.event_id IS NULL",
+			del:   "DELETE c FROM team_rankings c LEFT JOIN events p ON c.event_id = p.event_id WHERE p.event_id IS NULL",
+		},
+	}
+	for _, test := range tests {
+		if got := test.table.countOrphansQuery(); got != test.count {
+			t.Errorf("countOrphansQuery() = %q, want %q", got, test.count)
+		}
+		if got := test.table.deleteOrphansStatement(); got != test.del {
+			t.Errorf("deleteOrphansStatement() = %q, want %q", got, test.del)
+		}
+	}
+
+	// Every table given a foreign key is checked for orphans first
+	checked := make(map[string]bool)
+	for _, table := range orphanTables {
+		checked[table.table] = true
+	}
+	for _, table := range append(eventChildTables, matchChildTables...) {
+		if !checked[table] {
+			t.Errorf("%s isn't checked for orphaned rows", table)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package database
+
+import "fmt"
+
+// initEventSourceKeyStatements prepares all SQL statements for event source key operations.
+func (db *sqldb) initEventSourceKeyStatements() error {
+	queries := map[string]string{
+		"getEventSourceKeys": "SELECT source, source_key, event_id FROM event_source_keys WHERE source = ? ORDER BY source_key",
+		"saveEventSourceKey": "INSERT INTO event_source_keys (source, source_key, event_id) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE event_id = VALUES(event_id)",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetEventSourceKeys retrieves the keys that a data source uses for events, along with the events they map to.
+func (db *sqldb) GetEventSourceKeys(source string) ([]*EventSourceKey, error) {
+	ctx, done := db.startQuery("GetEventSourceKeys")
+	defer done()
+
+	stmt := db.readStatement("getEventSourceKeys")
+	if stmt == nil {
+		return nil, fmt.Errorf("prepared statement not found")
+	}
+	rows, err := stmt.QueryContext(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*EventSourceKey
+	for rows.Next() {
+		var key EventSourceKey
+		err := rows.Scan(
+			&key.Source,
+			&key.SourceKey,
+			&key.EventID,
+		)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, &key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// SaveEventSourceKey saves or updates the event that a data source's key maps to.
+func (db *sqldb) SaveEventSourceKey(key *EventSourceKey) error {
+	ctx, done := db.startQuery("SaveEventSourceKey")
+	defer done()
+
+	stmt := db.getStatement("saveEventSourceKey")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		key.Source,
+		key.SourceKey,
+		key.EventID,
+	)
+	return err
+}
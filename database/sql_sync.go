@@ -0,0 +1,83 @@
+package database
+
+import "fmt"
+
+// initSyncStatements prepares all SQL statements for sync checkpoint operations.
+func (db *sqldb) initSyncStatements() error {
+	queries := map[string]string{
+		"getSyncCheckpoints":    "SELECT season, event_id, completed_at FROM sync_checkpoints WHERE season = ? ORDER BY completed_at",
+		"saveSyncCheckpoint":    "INSERT INTO sync_checkpoints (season, event_id, completed_at) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE completed_at = VALUES(completed_at)",
+		"deleteSyncCheckpoints": "DELETE FROM sync_checkpoints WHERE season = ?",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetSyncCheckpoints retrieves the events that were completed by an interrupted sync of a season.
+func (db *sqldb) GetSyncCheckpoints(season string) ([]*SyncCheckpoint, error) {
+	ctx, done := db.startQuery("GetSyncCheckpoints")
+	defer done()
+
+	stmt := db.readStatement("getSyncCheckpoints")
+	if stmt == nil {
+		return nil, fmt.Errorf("prepared statement not found")
+	}
+	rows, err := stmt.QueryContext(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checkpoints []*SyncCheckpoint
+	for rows.Next() {
+		var checkpoint SyncCheckpoint
+		err := rows.Scan(
+			&checkpoint.Season,
+			&checkpoint.EventID,
+			&checkpoint.CompletedAt,
+		)
+		if err != nil {
+			continue
+		}
+		checkpoints = append(checkpoints, &checkpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// SaveSyncCheckpoint records that an event was completed during a sync of a season.
+func (db *sqldb) SaveSyncCheckpoint(checkpoint *SyncCheckpoint) error {
+	ctx, done := db.startQuery("SaveSyncCheckpoint")
+	defer done()
+
+	stmt := db.getStatement("saveSyncCheckpoint")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		checkpoint.Season,
+		checkpoint.EventID,
+		checkpoint.CompletedAt,
+	)
+	return err
+}
+
+// DeleteSyncCheckpoints removes all checkpoints for a season, so the next sync starts from the beginning.
+func (db *sqldb) DeleteSyncCheckpoints(season string) error {
+	ctx, done := db.startQuery("DeleteSyncCheckpoints")
+	defer done()
+
+	stmt := db.getStatement("deleteSyncCheckpoints")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, season)
+	return err
+}
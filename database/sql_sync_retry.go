@@ -0,0 +1,87 @@
+package database
+
+import "fmt"
+
+// initSyncRetryStatements prepares all SQL statements for sync retry operations.
+func (db *sqldb) initSyncRetryStatements() error {
+	queries := map[string]string{
+		"getSyncRetries":  "SELECT event_id, attempts, last_error, first_failed_at, last_failed_at FROM sync_retries ORDER BY event_id",
+		"saveSyncRetry":   "INSERT INTO sync_retries (event_id, attempts, last_error, first_failed_at, last_failed_at) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE attempts = VALUES(attempts), last_error = VALUES(last_error), first_failed_at = VALUES(first_failed_at), last_failed_at = VALUES(last_failed_at)",
+		"deleteSyncRetry": "DELETE FROM sync_retries WHERE event_id = ?",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetSyncRetries retrieves the events whose results failed to sync and are to be retried.
+func (db *sqldb) GetSyncRetries() ([]*SyncRetry, error) {
+	ctx, done := db.startQuery("GetSyncRetries")
+	defer done()
+
+	stmt := db.readStatement("getSyncRetries")
+	if stmt == nil {
+		return nil, fmt.Errorf("prepared statement not found")
+	}
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var retries []*SyncRetry
+	for rows.Next() {
+		var retry SyncRetry
+		err := rows.Scan(
+			&retry.EventID,
+			&retry.Attempts,
+			&retry.LastError,
+			&retry.FirstFailedAt,
+			&retry.LastFailedAt,
+		)
+		if err != nil {
+			continue
+		}
+		retries = append(retries, &retry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return retries, nil
+}
+
+// SaveSyncRetry records an event whose results failed to sync, replacing any earlier retry for the event.
+func (db *sqldb) SaveSyncRetry(retry *SyncRetry) error {
+	ctx, done := db.startQuery("SaveSyncRetry")
+	defer done()
+
+	stmt := db.getStatement("saveSyncRetry")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		retry.EventID,
+		retry.Attempts,
+		retry.LastError,
+		retry.FirstFailedAt,
+		retry.LastFailedAt,
+	)
+	return err
+}
+
+// DeleteSyncRetry removes the retry of an event, if there is one.
+func (db *sqldb) DeleteSyncRetry(eventID string) error {
+	ctx, done := db.startQuery("DeleteSyncRetry")
+	defer done()
+
+	stmt := db.getStatement("deleteSyncRetry")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, eventID)
+	return err
+}
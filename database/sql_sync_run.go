@@ -0,0 +1,139 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// initSyncRunStatements prepares all SQL statements for sync run operations.
+func (db *sqldb) initSyncRunStatements() error {
+	queries := map[string]string{
+		"saveSyncRun":   "INSERT INTO sync_runs (run_id, season, scope, status, started_at, finished_at, total_events, events, changed_events, updated_endpoints, skipped_endpoints, removed, error_count, errors) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE season = VALUES(season), scope = VALUES(scope), status = VALUES(status), started_at = VALUES(started_at), finished_at = VALUES(finished_at), total_events = VALUES(total_events), events = VALUES(events), changed_events = VALUES(changed_events), updated_endpoints = VALUES(updated_endpoints), skipped_endpoints = VALUES(skipped_endpoints), removed = VALUES(removed), error_count = VALUES(error_count), errors = VALUES(errors)",
+		"pruneSyncRuns": "DELETE FROM sync_runs WHERE season = ? AND id NOT IN (SELECT id FROM (SELECT id FROM sync_runs WHERE season = ? ORDER BY started_at DESC LIMIT ?) AS recent)",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetSyncRuns retrieves the runs that synced the season's data, with optional filters.
+// If no filters are provided, returns every run.
+func (db *sqldb) GetSyncRuns(filters ...SyncRunFilter) ([]*SyncRun, error) {
+	ctx, done := db.startQuery("GetSyncRuns")
+	defer done()
+
+	// Build dynamic query
+	query := "SELECT run_id, season, scope, status, started_at, finished_at, total_events, events, changed_events, updated_endpoints, skipped_endpoints, removed, error_count, errors FROM sync_runs WHERE 1=1"
+	args := []interface{}{}
+
+	var filter SyncRunFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+	if filter.Season != "" {
+		query += " AND season = ?"
+		args = append(args, filter.Season)
+	}
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+
+	query += " ORDER BY started_at DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// Execute query
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*SyncRun
+	for rows.Next() {
+		var run SyncRun
+		var errs string
+		err := rows.Scan(
+			&run.RunID,
+			&run.Season,
+			&run.Scope,
+			&run.Status,
+			&run.StartedAt,
+			(*nullTime)(&run.FinishedAt),
+			&run.TotalEvents,
+			&run.Events,
+			&run.ChangedEvents,
+			&run.UpdatedEndpoints,
+			&run.SkippedEndpoints,
+			&run.Removed,
+			&run.ErrorCount,
+			&errs,
+		)
+		if err != nil {
+			continue
+		}
+		if errs != "" {
+			if err := json.Unmarshal([]byte(errs), &run.Errors); err != nil {
+				run.Errors = []string{errs}
+			}
+		}
+		runs = append(runs, &run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// SaveSyncRun saves a sync run, replacing the run with the same ID, then deletes the season's oldest runs beyond
+// MaxSyncRuns.
+func (db *sqldb) SaveSyncRun(run *SyncRun) error {
+	ctx, done := db.startQuery("SaveSyncRun")
+	defer done()
+
+	stmt := db.getStatement("saveSyncRun")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	var errs string
+	if len(run.Errors) > 0 {
+		data, err := json.Marshal(run.Errors)
+		if err != nil {
+			return err
+		}
+		errs = string(data)
+	}
+	_, err := stmt.ExecContext(ctx,
+		run.RunID,
+		run.Season,
+		run.Scope,
+		run.Status,
+		run.StartedAt.UTC(),
+		nullTimeValue(run.FinishedAt),
+		run.TotalEvents,
+		run.Events,
+		run.ChangedEvents,
+		run.UpdatedEndpoints,
+		run.SkippedEndpoints,
+		run.Removed,
+		run.ErrorCount,
+		errs,
+	)
+	if err != nil {
+		return err
+	}
+
+	stmt = db.getStatement("pruneSyncRuns")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err = stmt.ExecContext(ctx, run.Season, run.Season, MaxSyncRuns)
+	return err
+}
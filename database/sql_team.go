@@ -1,15 +1,21 @@
 package database
 
-import "fmt"
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
 
 // InitTeamStatements prepares all SQL statements for team operations.
 func (db *sqldb) initTeamStatements() error {
 	queries := map[string]string{
-		"getTeam":          "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name FROM teams WHERE team_id = ?",
-		"getAllTeams":      "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name FROM teams ORDER BY team_id",
-		"getTeamsByRegion": "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name FROM teams WHERE home_region = ? ORDER BY team_id",
-		"saveTeam":         "INSERT INTO teams (team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), full_name = VALUES(full_name), city = VALUES(city), state_prov = VALUES(state_prov), country = VALUES(country), website = VALUES(website), rookie_year = VALUES(rookie_year), home_region = VALUES(home_region), robot_name = VALUES(robot_name)",
-		"saveTeamRanking":  "INSERT INTO team_rankings (team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE num_matches = VALUES(num_matches), ccwm = VALUES(ccwm), opr = VALUES(opr), np_opr = VALUES(np_opr), dpr = VALUES(dpr), np_dpr = VALUES(np_dpr), np_avg = VALUES(np_avg)",
+		"getTeam":                 "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name FROM teams WHERE team_id = ?",
+		"getAllTeams":             "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name FROM teams ORDER BY team_id",
+		"getTeamsByRegion":        "SELECT team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name FROM teams WHERE home_region = ? ORDER BY team_id",
+		"saveTeam":                "INSERT INTO teams (team_id, name, full_name, city, state_prov, country, website, rookie_year, home_region, robot_name) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), full_name = VALUES(full_name), city = VALUES(city), state_prov = VALUES(state_prov), country = VALUES(country), website = VALUES(website), rookie_year = VALUES(rookie_year), home_region = VALUES(home_region), robot_name = VALUES(robot_name)",
+		"saveTeamRanking":         "INSERT INTO team_rankings (team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg, auto_opr) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE num_matches = VALUES(num_matches), ccwm = VALUES(ccwm), opr = VALUES(opr), np_opr = VALUES(np_opr), dpr = VALUES(dpr), np_dpr = VALUES(np_dpr), np_avg = VALUES(np_avg), auto_opr = VALUES(auto_opr)",
+		"deleteTeamRanking":       "DELETE FROM team_rankings WHERE event_id = ? AND team_id = ?",
+		"saveTeamRankingSnapshot": "INSERT INTO team_ranking_snapshots (snapshot_date, team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg, auto_opr) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE num_matches = VALUES(num_matches), ccwm = VALUES(ccwm), opr = VALUES(opr), np_opr = VALUES(np_opr), dpr = VALUES(dpr), np_dpr = VALUES(np_dpr), np_avg = VALUES(np_avg), auto_opr = VALUES(auto_opr)",
 	}
 
 	for name, query := range queries {
@@ -22,12 +28,15 @@ func (db *sqldb) initTeamStatements() error {
 
 // GetTeam retrieves a team from a database by its ID.
 func (db *sqldb) GetTeam(teamID int) (*Team, error) {
+	ctx, done := db.startQuery("GetTeam")
+	defer done()
+
 	var team Team
-	stmt := db.getStatement("getTeam")
+	stmt := db.readStatement("getTeam")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	err := stmt.QueryRow(teamID).Scan(
+	err := stmt.QueryRowContext(ctx, teamID).Scan(
 		&team.TeamID,
 		&team.Name,
 		&team.FullName,
@@ -39,9 +48,12 @@ func (db *sqldb) GetTeam(teamID int) (*Team, error) {
 		&team.HomeRegion,
 		&team.RobotName,
 	)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	return &team, nil
 }
 
@@ -49,13 +61,16 @@ func (db *sqldb) GetTeam(teamID int) (*Team, error) {
 // If no filters are provided, returns all teams.
 // Filters are combined with OR logic within each field and AND logic between fields.
 func (db *sqldb) GetAllTeams(filters ...TeamFilter) ([]*Team, error) {
+	ctx, done := db.startQuery("GetAllTeams")
+	defer done()
+
 	// If no filters, use the prepared statement
 	if len(filters) == 0 {
-		stmt := db.getStatement("getAllTeams")
+		stmt := db.readStatement("getAllTeams")
 		if stmt == nil {
 			return nil, fmt.Errorf("prepared statement not found")
 		}
-		rows, err := stmt.Query()
+		rows, err := stmt.QueryContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -81,6 +96,9 @@ func (db *sqldb) GetAllTeams(filters ...TeamFilter) ([]*Team, error) {
 			}
 			teams = append(teams, &team)
 		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
 		return teams, nil
 	}
 
@@ -171,8 +189,13 @@ func (db *sqldb) GetAllTeams(filters ...TeamFilter) ([]*Team, error) {
 
 	query += " ORDER BY team_id"
 
+	// Add the page of teams to return
+	limit, limitArgs := sqlPage(filter.Limit, filter.Offset)
+	query += limit
+	args = append(args, limitArgs...)
+
 	// Execute query
-	rows, err := db.sqldb.Query(query, args...)
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -198,16 +221,22 @@ func (db *sqldb) GetAllTeams(filters ...TeamFilter) ([]*Team, error) {
 		}
 		teams = append(teams, &team)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return teams, nil
 }
 
 // SaveTeam saves or updates a team in the
 func (db *sqldb) SaveTeam(team *Team) error {
+	ctx, done := db.startQuery("SaveTeam")
+	defer done()
+
 	stmt := db.getStatement("saveTeam")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(
+	_, err := stmt.ExecContext(ctx,
 		team.TeamID,
 		team.Name,
 		team.FullName,
@@ -224,11 +253,14 @@ func (db *sqldb) SaveTeam(team *Team) error {
 
 // GetTeamsByRegion retrieves all teams in a given home region, ordered by team ID.
 func (db *sqldb) GetTeamsByRegion(region string) ([]*Team, error) {
-	stmt := db.getStatement("getTeamsByRegion")
+	ctx, done := db.startQuery("GetTeamsByRegion")
+	defer done()
+
+	stmt := db.readStatement("getTeamsByRegion")
 	if stmt == nil {
 		return nil, fmt.Errorf("prepared statement not found")
 	}
-	rows, err := stmt.Query(region)
+	rows, err := stmt.QueryContext(ctx, region)
 	if err != nil {
 		return nil, err
 	}
@@ -254,6 +286,9 @@ func (db *sqldb) GetTeamsByRegion(region string) ([]*Team, error) {
 		}
 		teams = append(teams, &team)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return teams, nil
 }
 
@@ -261,8 +296,11 @@ func (db *sqldb) GetTeamsByRegion(region string) ([]*Team, error) {
 // Filters support filtering by TeamID and/or EventID.
 // If no filters are provided, returns all team rankings.
 func (db *sqldb) GetTeamRankings(filters ...TeamRankingFilter) ([]*TeamRanking, error) {
+	ctx, done := db.startQuery("GetTeamRankings")
+	defer done()
+
 	// Build dynamic query
-	query := "SELECT team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg FROM team_rankings WHERE 1=1"
+	query := "SELECT team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg, auto_opr FROM team_rankings WHERE 1=1"
 	args := []interface{}{}
 
 	if len(filters) > 0 {
@@ -297,8 +335,15 @@ func (db *sqldb) GetTeamRankings(filters ...TeamRankingFilter) ([]*TeamRanking,
 
 	query += " ORDER BY event_id, team_id"
 
+	// Add the page of rankings to return
+	if len(filters) > 0 {
+		limit, limitArgs := sqlPage(filters[0].Limit, filters[0].Offset)
+		query += limit
+		args = append(args, limitArgs...)
+	}
+
 	// Execute query
-	rows, err := db.sqldb.Query(query, args...)
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -317,22 +362,29 @@ func (db *sqldb) GetTeamRankings(filters ...TeamRankingFilter) ([]*TeamRanking,
 			&ranking.DPR,
 			&ranking.NpDPR,
 			&ranking.NpAvg,
+			&ranking.AutoOPR,
 		)
 		if err != nil {
 			continue
 		}
 		rankings = append(rankings, &ranking)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return rankings, nil
 }
 
 // SaveTeamRanking saves or updates a team ranking in the database.
 func (db *sqldb) SaveTeamRanking(ranking *TeamRanking) error {
+	ctx, done := db.startQuery("SaveTeamRanking")
+	defer done()
+
 	stmt := db.getStatement("saveTeamRanking")
 	if stmt == nil {
 		return fmt.Errorf("prepared statement not found")
 	}
-	_, err := stmt.Exec(
+	_, err := stmt.ExecContext(ctx,
 		ranking.TeamID,
 		ranking.EventID,
 		ranking.NumMatches,
@@ -342,6 +394,128 @@ func (db *sqldb) SaveTeamRanking(ranking *TeamRanking) error {
 		ranking.DPR,
 		ranking.NpDPR,
 		ranking.NpAvg,
+		ranking.AutoOPR,
+	)
+	return err
+}
+
+// DeleteTeamRanking deletes the performance metrics of a team at an event.
+func (db *sqldb) DeleteTeamRanking(eventID string, teamID int) error {
+	ctx, done := db.startQuery("DeleteTeamRanking")
+	defer done()
+
+	stmt := db.getStatement("deleteTeamRanking")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx, eventID, teamID)
+	return err
+}
+
+// GetTeamRankingSnapshots retrieves the most recent team ranking snapshot taken on or before the
+// filter's AsOf date. If AsOf is not set, the latest snapshot is returned.
+// Filters support filtering by TeamID and/or EventID.
+func (db *sqldb) GetTeamRankingSnapshots(filters ...TeamRankingSnapshotFilter) ([]*TeamRankingSnapshot, error) {
+	ctx, done := db.startQuery("GetTeamRankingSnapshots")
+	defer done()
+
+	var filter TeamRankingSnapshotFilter
+	if len(filters) > 0 {
+		filter = filters[0]
+	}
+
+	// Build dynamic query, selecting the latest snapshot date on or before AsOf
+	query := "SELECT snapshot_date, team_id, event_id, num_matches, ccwm, opr, np_opr, dpr, np_dpr, np_avg, auto_opr FROM team_ranking_snapshots WHERE snapshot_date = (SELECT MAX(snapshot_date) FROM team_ranking_snapshots"
+	args := []interface{}{}
+	if !filter.AsOf.IsZero() {
+		query += " WHERE snapshot_date <= ?"
+		args = append(args, GetSnapshotDate(filter.AsOf))
+	}
+	query += ")"
+
+	// Add TeamID filter
+	if len(filter.TeamIDs) > 0 {
+		query += " AND team_id IN ("
+		for i, id := range filter.TeamIDs {
+			if i > 0 {
+				query += ","
+			}
+			query += "?"
+			args = append(args, id)
+		}
+		query += ")"
+	}
+
+	// Add EventID filter
+	if len(filter.EventIDs) > 0 {
+		query += " AND event_id IN ("
+		for i, id := range filter.EventIDs {
+			if i > 0 {
+				query += ","
+			}
+			query += "?"
+			args = append(args, id)
+		}
+		query += ")"
+	}
+
+	query += " ORDER BY event_id, team_id"
+
+	// Execute query
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []*TeamRankingSnapshot
+	for rows.Next() {
+		var snapshot TeamRankingSnapshot
+		err := rows.Scan(
+			&snapshot.SnapshotDate,
+			&snapshot.TeamID,
+			&snapshot.EventID,
+			&snapshot.NumMatches,
+			&snapshot.CCWM,
+			&snapshot.OPR,
+			&snapshot.NpOPR,
+			&snapshot.DPR,
+			&snapshot.NpDPR,
+			&snapshot.NpAvg,
+			&snapshot.AutoOPR,
+		)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// SaveTeamRankingSnapshot saves or updates a team ranking snapshot in the database.
+func (db *sqldb) SaveTeamRankingSnapshot(snapshot *TeamRankingSnapshot) error {
+	ctx, done := db.startQuery("SaveTeamRankingSnapshot")
+	defer done()
+
+	stmt := db.getStatement("saveTeamRankingSnapshot")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		GetSnapshotDate(snapshot.SnapshotDate),
+		snapshot.TeamID,
+		snapshot.EventID,
+		snapshot.NumMatches,
+		snapshot.CCWM,
+		snapshot.OPR,
+		snapshot.NpOPR,
+		snapshot.DPR,
+		snapshot.NpDPR,
+		snapshot.NpAvg,
+		snapshot.AutoOPR,
 	)
 	return err
 }
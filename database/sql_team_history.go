@@ -0,0 +1,94 @@
+package database
+
+import "fmt"
+
+// initTeamHistoryStatements prepares all SQL statements for team history operations.
+func (db *sqldb) initTeamHistoryStatements() error {
+	queries := map[string]string{
+		"saveTeamHistory": "INSERT INTO team_history (team_id, name, home_region, valid_from, valid_to) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), home_region = VALUES(home_region), valid_from = VALUES(valid_from)",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetTeamHistory retrieves the earlier names and home regions of teams, with optional filters.
+// If no filters are provided, returns the history of all teams.
+func (db *sqldb) GetTeamHistory(filters ...TeamHistoryFilter) ([]*TeamHistory, error) {
+	ctx, done := db.startQuery("GetTeamHistory")
+	defer done()
+
+	// Build dynamic query
+	query := "SELECT team_id, name, home_region, valid_from, valid_to FROM team_history WHERE 1=1"
+	args := []interface{}{}
+
+	if len(filters) > 0 {
+		filter := filters[0]
+
+		// Add TeamID filter
+		if len(filter.TeamIDs) > 0 {
+			query += " AND team_id IN ("
+			for i, id := range filter.TeamIDs {
+				if i > 0 {
+					query += ","
+				}
+				query += "?"
+				args = append(args, id)
+			}
+			query += ")"
+		}
+	}
+
+	query += " ORDER BY team_id, valid_to DESC"
+
+	// Execute query
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*TeamHistory
+	for rows.Next() {
+		var entry TeamHistory
+		err := rows.Scan(
+			&entry.TeamID,
+			&entry.Name,
+			&entry.HomeRegion,
+			(*nullTime)(&entry.ValidFrom),
+			&entry.ValidTo,
+		)
+		if err != nil {
+			continue
+		}
+		history = append(history, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// SaveTeamHistory records an earlier name and home region of a team, replacing any entry for the team that was
+// replaced at the same time.
+func (db *sqldb) SaveTeamHistory(history *TeamHistory) error {
+	ctx, done := db.startQuery("SaveTeamHistory")
+	defer done()
+
+	stmt := db.getStatement("saveTeamHistory")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	_, err := stmt.ExecContext(ctx,
+		history.TeamID,
+		history.Name,
+		history.HomeRegion,
+		nullTimeValue(history.ValidFrom),
+		history.ValidTo.UTC(),
+	)
+	return err
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// initTeamNoteStatements prepares all SQL statements for team note operations.
+func (db *sqldb) initTeamNoteStatements() error {
+	queries := map[string]string{
+		"saveTeamNote": "INSERT INTO team_notes (year, team_id, notes, tags, source) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE notes = VALUES(notes), tags = VALUES(tags), source = VALUES(source)",
+	}
+
+	for name, query := range queries {
+		if err := db.prepareStatement(name, query); err != nil {
+			return fmt.Errorf("failed to prepare statement %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GetTeamNotes retrieves the scouting notes on teams, with optional filters.
+// If no filters are provided, returns the notes on all teams.
+func (db *sqldb) GetTeamNotes(filters ...TeamNoteFilter) ([]*TeamNote, error) {
+	ctx, done := db.startQuery("GetTeamNotes")
+	defer done()
+
+	// Build dynamic query
+	query := "SELECT year, team_id, notes, tags, source, updated_at FROM team_notes WHERE 1=1"
+	args := []interface{}{}
+
+	if len(filters) > 0 {
+		filter := filters[0]
+
+		// Add Year filter
+		if filter.Year != 0 {
+			query += " AND year = ?"
+			args = append(args, filter.Year)
+		}
+
+		// Add TeamID filter
+		if len(filter.TeamIDs) > 0 {
+			query += " AND team_id IN ("
+			for i, id := range filter.TeamIDs {
+				if i > 0 {
+					query += ","
+				}
+				query += "?"
+				args = append(args, id)
+			}
+			query += ")"
+		}
+	}
+
+	query += " ORDER BY year, team_id"
+
+	// Execute query
+	rows, err := db.readDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []*TeamNote
+	for rows.Next() {
+		var note TeamNote
+		var tags string
+		err := rows.Scan(
+			&note.Year,
+			&note.TeamID,
+			&note.Notes,
+			&tags,
+			&note.Source,
+			&note.UpdatedAt,
+		)
+		if err != nil {
+			continue
+		}
+		if tags != "" {
+			note.Tags = strings.Split(tags, ",")
+		}
+		notes = append(notes, &note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// SaveTeamNote saves the scouting notes on a team, replacing any notes already saved on the team for the year. The
+// time the notes were updated is kept if they haven't changed.
+func (db *sqldb) SaveTeamNote(note *TeamNote) error {
+	ctx, done := db.startQuery("SaveTeamNote")
+	defer done()
+
+	stmt := db.getStatement("saveTeamNote")
+	if stmt == nil {
+		return fmt.Errorf("prepared statement not found")
+	}
+	// Tags are saved as a comma-separated list, so a comma within a tag is replaced
+	tags := NormalizeTags(note.Tags)
+	for i, tag := range tags {
+		tags[i] = strings.ReplaceAll(tag, ",", " ")
+	}
+	_, err := stmt.ExecContext(ctx,
+		note.Year,
+		note.TeamID,
+		note.Notes,
+		strings.Join(tags, ","),
+		note.Source,
+	)
+	return err
+}
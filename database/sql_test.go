@@ -0,0 +1,30 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/database/dbtest"
+)
+
+// TestSQLDB runs against the empty MySQL database given by the FTCSTANDING_TEST_DSN environment variable, such as
+// "user:password@tcp(localhost:3306)/ftctest?parseTime=true&loc=UTC". It is skipped if the variable isn't set.
+func TestSQLDB(t *testing.T) {
+	dsn := os.Getenv("FTCSTANDING_TEST_DSN")
+	if dsn == "" {
+		t.Skip("FTCSTANDING_TEST_DSN not set")
+	}
+	t.Setenv("DB_TYPE", "sql")
+	t.Setenv("DATA_SOURCE_NAME", dsn)
+	t.Setenv("READ_DATA_SOURCE_NAME", "")
+	db, err := database.Init()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := dbtest.TestDB(db); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// SyncCheckpoint records that an event was completely processed during a full-season sync, so an interrupted
+// sync can resume from where it left off. Season and EventID together form the primary key.
+type SyncCheckpoint struct {
+	Season      string    `json:"season"`
+	EventID     string    `json:"event_id"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// EventSync records the last time an event's results were successfully synced from the data source. EventID is the
+// primary key.
+type EventSync struct {
+	EventID  string    `json:"event_id"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// EndpointHash records a hash of the last response from one of the data source's endpoints for an event, so a sync
+// can skip saving the response when it hasn't changed. EventID and Endpoint together form the primary key.
+type EndpointHash struct {
+	EventID  string    `json:"event_id"`
+	Endpoint string    `json:"endpoint"`
+	Hash     string    `json:"hash"`
+	HashedAt time.Time `json:"hashed_at"`
+}
+
+// The statuses of a sync run.
+const (
+	SyncRunRunning   = "running"
+	SyncRunCompleted = "completed"
+	SyncRunFailed    = "failed"
+)
+
+// MaxSyncRuns is the number of sync runs kept for each season. Saving a run deletes the oldest runs beyond it.
+const MaxSyncRuns = 500
+
+// SyncRun records a run of ftcdata or another program that syncs a season's data from the data source, so operators
+// can see when the data was last refreshed and whether the run ran into errors. The run is saved as it starts and
+// after each event, so a run in progress reports how far along it is, and a run that was interrupted is left
+// running. RunID is the primary key.
+type SyncRun struct {
+	RunID            string    `json:"run_id"`
+	Season           string    `json:"season"`
+	Scope            string    `json:"scope"` // What was synced, such as "all", "region USNC", or "event USNCRAQ"
+	Status           string    `json:"status"`
+	StartedAt        time.Time `json:"started_at"`
+	FinishedAt       time.Time `json:"finished_at,omitzero"` // The zero time until the run finishes
+	TotalEvents      int       `json:"total_events"`
+	Events           int       `json:"events"` // Events whose results have been synced so far
+	ChangedEvents    int       `json:"changed_events"`
+	UpdatedEndpoints int       `json:"updated_endpoints"`
+	SkippedEndpoints int       `json:"skipped_endpoints"`
+	Removed          int       `json:"removed"` // Records removed because the data source no longer returns them
+	ErrorCount       int       `json:"error_count"`
+	Errors           []string  `json:"errors"` // The first errors the run ran into
+}
+
+// SyncRunFilter defines criteria for filtering sync runs.
+type SyncRunFilter struct {
+	Season string
+	Status string
+	Limit  int // The number of most recent runs to return, or 0 for every run
+}
+
+// MaxSyncAttempts is the number of syncs of an event in a row that can fail before the event is quarantined, and no
+// longer retried until it is synced on its own.
+const MaxSyncAttempts = 5
+
+// SyncRetry records an event whose results failed to sync, such as when the data source had a hiccup, so later syncs
+// retry it. The retry is removed once the event syncs. EventID is the primary key.
+type SyncRetry struct {
+	EventID       string    `json:"event_id"`
+	Attempts      int       `json:"attempts"` // Syncs of the event in a row that failed
+	LastError     string    `json:"last_error"`
+	FirstFailedAt time.Time `json:"first_failed_at"`
+	LastFailedAt  time.Time `json:"last_failed_at"`
+}
+
+// EventSyncFilter defines criteria for filtering event syncs.
+type EventSyncFilter struct {
+	EventIDs []string
+}
+
+// String returns a string representation of the SyncCheckpoint.
+func (sc *SyncCheckpoint) String() string {
+	return fmt.Sprintf("SyncCheckpoint{Season: %s, EventID: %s, CompletedAt: %s}",
+		sc.Season, sc.EventID, sc.CompletedAt.Format(time.RFC3339))
+}
+
+// String returns a string representation of the EventSync.
+func (es *EventSync) String() string {
+	return fmt.Sprintf("EventSync{EventID: %s, SyncedAt: %s}", es.EventID, es.SyncedAt.Format(time.RFC3339))
+}
+
+// String returns a string representation of the EndpointHash.
+func (eh *EndpointHash) String() string {
+	return fmt.Sprintf("EndpointHash{EventID: %s, Endpoint: %s, Hash: %s, HashedAt: %s}",
+		eh.EventID, eh.Endpoint, eh.Hash, eh.HashedAt.Format(time.RFC3339))
+}
+
+// String returns a string representation of the SyncRun.
+func (sr *SyncRun) String() string {
+	return fmt.Sprintf("SyncRun{RunID: %s, Season: %s, Scope: %s, Status: %s, StartedAt: %s, Events: %d/%d, Errors: %d}",
+		sr.RunID, sr.Season, sr.Scope, sr.Status, sr.StartedAt.Format(time.RFC3339), sr.Events, sr.TotalEvents, sr.ErrorCount)
+}
+
+// Quarantined returns true if the event has failed to sync too many times in a row to be retried automatically.
+func (sr *SyncRetry) Quarantined() bool {
+	return sr.Attempts >= MaxSyncAttempts
+}
+
+// String returns a string representation of the SyncRetry.
+func (sr *SyncRetry) String() string {
+	return fmt.Sprintf("SyncRetry{EventID: %s, Attempts: %d, LastError: %s, LastFailedAt: %s}",
+		sr.EventID, sr.Attempts, sr.LastError, sr.LastFailedAt.Format(time.RFC3339))
+}
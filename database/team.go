@@ -1,32 +1,66 @@
 package database
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Team represents a team that participates in competitions.
 type Team struct {
-	TeamID     int    `json:"team_id"`
-	Name       string `json:"name"`
-	FullName   string `json:"full_name"`
-	City       string `json:"city"`
-	StateProv  string `json:"state_prov"`
-	Country    string `json:"country"`
-	Website    string `json:"website"`
-	RookieYear int    `json:"rookie_year"`
-	HomeRegion string `json:"home_region"`
-	RobotName  string `json:"robot_name"`
+	TeamID     int       `json:"team_id"`
+	Name       string    `json:"name"`
+	FullName   string    `json:"full_name"`
+	City       string    `json:"city"`
+	StateProv  string    `json:"state_prov"`
+	Country    string    `json:"country"`
+	Website    string    `json:"website"`
+	RookieYear int       `json:"rookie_year"`
+	HomeRegion string    `json:"home_region"`
+	RobotName  string    `json:"robot_name"`
+	UpdatedAt  time.Time `json:"updated_at"` // Time the record was last created or changed
+}
+
+// TeamHistory records the name and home region a team had before a sync found them changed, such as when a team
+// renames itself during the season. ValidFrom is when the team took the name and region, or the zero time if they
+// were in place before the team's history was kept, and ValidTo is when they were replaced. TeamID and ValidTo
+// together form the primary key.
+type TeamHistory struct {
+	TeamID     int       `json:"team_id"`
+	Name       string    `json:"name"`
+	HomeRegion string    `json:"home_region"`
+	ValidFrom  time.Time `json:"valid_from,omitzero"`
+	ValidTo    time.Time `json:"valid_to"`
 }
 
 // TeamRanking represents the ranking information for a team based on their performance in matches at a specific event.
 type TeamRanking struct {
-	TeamID     int     `json:"team_id"`
-	EventID    string  `json:"event_id"`
-	NumMatches int     `json:"num_matches"`
-	CCWM       float64 `json:"ccwm"`
-	OPR        float64 `json:"opr"`
-	NpOPR      float64 `json:"np_opr"`
-	DPR        float64 `json:"dpr"`
-	NpDPR      float64 `json:"np_dpr"`
-	NpAvg      float64 `json:"np_avg"`
+	TeamID     int       `json:"team_id"`
+	EventID    string    `json:"event_id"`
+	NumMatches int       `json:"num_matches"`
+	CCWM       float64   `json:"ccwm"`
+	OPR        float64   `json:"opr"`
+	NpOPR      float64   `json:"np_opr"`
+	DPR        float64   `json:"dpr"`
+	NpDPR      float64   `json:"np_dpr"`
+	NpAvg      float64   `json:"np_avg"`
+	AutoOPR    float64   `json:"auto_opr"`   // OPR from the points scored in the autonomous period
+	UpdatedAt  time.Time `json:"updated_at"` // Time the record was last created or changed
+}
+
+// TeamRankingSnapshot is a dated copy of a TeamRanking, used to report how rankings change over time.
+// SnapshotDate, TeamID, and EventID together form the primary key.
+type TeamRankingSnapshot struct {
+	SnapshotDate time.Time `json:"snapshot_date"`
+	TeamID       int       `json:"team_id"`
+	EventID      string    `json:"event_id"`
+	NumMatches   int       `json:"num_matches"`
+	CCWM         float64   `json:"ccwm"`
+	OPR          float64   `json:"opr"`
+	NpOPR        float64   `json:"np_opr"`
+	DPR          float64   `json:"dpr"`
+	NpDPR        float64   `json:"np_dpr"`
+	NpAvg        float64   `json:"np_avg"`
+	AutoOPR      float64   `json:"auto_opr"`
 }
 
 // String returns a string representation of the Team.
@@ -35,10 +69,22 @@ func (t *Team) String() string {
 		t.TeamID, t.Name, t.City, t.StateProv, t.HomeRegion)
 }
 
+// String returns a string representation of the TeamHistory.
+func (th *TeamHistory) String() string {
+	return fmt.Sprintf("TeamHistory{TeamID: %d, Name: %q, Region: %s, ValidTo: %s}",
+		th.TeamID, th.Name, th.HomeRegion, th.ValidTo.Format(time.RFC3339))
+}
+
 // String returns a string representation of the TeamRanking.
 func (tr *TeamRanking) String() string {
-	return fmt.Sprintf("TeamRanking{TeamID: %d, EventID: %q, NumMatches: %d, CCWM: %.2f, OPR: %.2f, NpOPR: %.2f, DPR: %.2f, NpDPR: %.2f, NpAvg: %.2f}",
-		tr.TeamID, tr.EventID, tr.NumMatches, tr.CCWM, tr.OPR, tr.NpOPR, tr.DPR, tr.NpDPR, tr.NpAvg)
+	return fmt.Sprintf("TeamRanking{TeamID: %d, EventID: %q, NumMatches: %d, CCWM: %.2f, OPR: %.2f, NpOPR: %.2f, DPR: %.2f, NpDPR: %.2f, NpAvg: %.2f, AutoOPR: %.2f}",
+		tr.TeamID, tr.EventID, tr.NumMatches, tr.CCWM, tr.OPR, tr.NpOPR, tr.DPR, tr.NpDPR, tr.NpAvg, tr.AutoOPR)
+}
+
+// String returns a string representation of the TeamRankingSnapshot.
+func (trs *TeamRankingSnapshot) String() string {
+	return fmt.Sprintf("TeamRankingSnapshot{Date: %s, TeamID: %d, EventID: %q, NumMatches: %d, OPR: %.2f, NpAvg: %.2f}",
+		trs.SnapshotDate.Format(SnapshotDateFormat), trs.TeamID, trs.EventID, trs.NumMatches, trs.OPR, trs.NpAvg)
 }
 
 // TeamFilter defines criteria for filtering teams.
@@ -47,10 +93,36 @@ type TeamFilter struct {
 	Countries   []string
 	HomeRegions []string
 	EventCodes  []string
+	Limit       int // If set, at most this many teams are returned
+	Offset      int // If set, this many teams are skipped before any are returned
+}
+
+// TeamHistoryFilter defines criteria for filtering the history of teams.
+type TeamHistoryFilter struct {
+	TeamIDs []int
 }
 
 // TeamRankingFilter defines criteria for filtering team rankings.
 type TeamRankingFilter struct {
 	TeamIDs  []int
 	EventIDs []string
+	Limit    int // If set, at most this many rankings are returned
+	Offset   int // If set, this many rankings are skipped before any are returned
+}
+
+// TeamRankingSnapshotFilter defines criteria for filtering team ranking snapshots.
+// AsOf selects the most recent snapshot taken on or before that date; if it is
+// the zero time, the most recent snapshot is used.
+type TeamRankingSnapshotFilter struct {
+	TeamIDs  []int
+	EventIDs []string
+	AsOf     time.Time
+}
+
+// SnapshotDateFormat is the layout used to key and display team ranking snapshot dates.
+const SnapshotDateFormat = "2006-01-02"
+
+// GetSnapshotDate normalizes a time to the calendar day used for a team ranking snapshot.
+func GetSnapshotDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 }
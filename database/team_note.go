@@ -0,0 +1,54 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TeamNote is the scouting data kept on a team during a season, such as the notes and tags from pit scouting, so it
+// can be read alongside the metrics calculated from the team's matches. Year and TeamID together form the primary
+// key.
+type TeamNote struct {
+	Year      int       `json:"year"`
+	TeamID    int       `json:"team_id"`
+	Notes     string    `json:"notes,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`   // Short labels, such as "climber", saved by NormalizeTags
+	Source    string    `json:"source,omitempty"` // Where the note was imported from, such as a file or spreadsheet
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TeamNoteFilter defines criteria for filtering the scouting notes on teams.
+type TeamNoteFilter struct {
+	Year    int
+	TeamIDs []int
+}
+
+// String returns a string representation of the TeamNote.
+func (tn *TeamNote) String() string {
+	return fmt.Sprintf("TeamNote{Year: %d, TeamID: %d, Tags: %v, Notes: %q, Source: %s}",
+		tn.Year, tn.TeamID, tn.Tags, tn.Notes, tn.Source)
+}
+
+// HasTag returns true if the note has the tag, matched without regard to case or spacing.
+func (tn *TeamNote) HasTag(tag string) bool {
+	return slices.Contains(tn.Tags, normalizeTag(tag))
+}
+
+// NormalizeTags returns the tags in lower case with surrounding whitespace removed and each run of whitespace within
+// them replaced by a single space, in the order given, leaving out empty tags and tags given more than once.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		if tag = normalizeTag(tag); tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// normalizeTag returns a tag as it is saved.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
@@ -0,0 +1,173 @@
+// Package ftcscout is a client for the public REST API of FTC Scout (https://ftcscout.org). FTC Scout publishes
+// results for events whose results are missing from the FTC Events API, and is used to backfill them.
+package ftcscout
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultURL       = "https://api.ftcscout.org/rest/v1"
+	defaultUserAgent = "ftcstanding (https://github.com/rbrabson/ftcstanding)"
+)
+
+// Client requests data from the FTC Scout REST API.
+type Client struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+}
+
+// Event is an event returned by the FTC Scout API.
+type Event struct {
+	Season       int     `json:"season"`
+	Code         string  `json:"code"`
+	DivisionCode *string `json:"divisionCode"`
+	Name         string  `json:"name"`
+	Remote       bool    `json:"remote"`
+	Hybrid       bool    `json:"hybrid"`
+	FieldCount   int     `json:"fieldCount"`
+	Published    bool    `json:"published"`
+	Type         string  `json:"type"`
+	RegionCode   string  `json:"regionCode"`
+	LeagueCode   *string `json:"leagueCode"`
+	DistrictCode *string `json:"districtCode"`
+	Venue        string  `json:"venue"`
+	Address      string  `json:"address"`
+	Country      string  `json:"country"`
+	StateProv    string  `json:"stateProv"`
+	City         string  `json:"city"`
+	Website      *string `json:"website"`
+	Timezone     string  `json:"timezone"`
+	Start        string  `json:"start"`
+	End          string  `json:"end"`
+}
+
+// Match is a match played at an event, as returned by the FTC Scout API.
+type Match struct {
+	Season          int          `json:"season"`
+	EventCode       string       `json:"eventCode"`
+	ID              int          `json:"id"`
+	HasBeenPlayed   bool         `json:"hasBeenPlayed"`
+	ActualStartTime *string      `json:"actualStartTime"`
+	PostResultTime  *string      `json:"postResultTime"`
+	TournamentLevel string       `json:"tournamentLevel"`
+	Series          int          `json:"series"`
+	MatchNum        int          `json:"matchNum"`
+	Description     string       `json:"description"`
+	Scores          *MatchScores `json:"scores"`
+	Teams           []*MatchTeam `json:"teams"`
+}
+
+// MatchScores is the score of each alliance in a match. Remote matches have no alliances, so they have no scores.
+type MatchScores struct {
+	Red  *AllianceScore `json:"red"`
+	Blue *AllianceScore `json:"blue"`
+}
+
+// AllianceScore is the score of an alliance in a match. Only the fields reported for every season are included.
+type AllianceScore struct {
+	AutoPoints             int `json:"autoPoints"`
+	DcPoints               int `json:"dcPoints"`
+	PenaltyPointsCommitted int `json:"penaltyPointsCommitted"`
+	TotalPoints            int `json:"totalPoints"`
+	TotalPointsNp          int `json:"totalPointsNp"`
+	MajorsCommitted        int `json:"majorsCommitted"`
+	MinorsCommitted        int `json:"minorsCommitted"`
+}
+
+// MatchTeam is a team that played in a match.
+type MatchTeam struct {
+	Alliance   string `json:"alliance"`
+	Station    string `json:"station"`
+	TeamNumber int    `json:"teamNumber"`
+	Surrogate  bool   `json:"surrogate"`
+	NoShow     bool   `json:"noShow"`
+	DQ         bool   `json:"dq"`
+	OnField    bool   `json:"onField"`
+}
+
+// EventTeam is a team that attended an event, along with its results at the event.
+type EventTeam struct {
+	Season     int             `json:"season"`
+	EventCode  string          `json:"eventCode"`
+	TeamNumber int             `json:"teamNumber"`
+	Stats      *EventTeamStats `json:"stats"`
+}
+
+// EventTeamStats is a team's qualification ranking at an event.
+type EventTeamStats struct {
+	Rank              int     `json:"rank"`
+	RP                float64 `json:"rp"`
+	TB1               float64 `json:"tb1"`
+	TB2               float64 `json:"tb2"`
+	Wins              int     `json:"wins"`
+	Losses            int     `json:"losses"`
+	Ties              int     `json:"ties"`
+	DQs               int     `json:"dqs"`
+	QualMatchesPlayed int     `json:"qualMatchesPlayed"`
+}
+
+// New returns a client for the FTC Scout API. If baseURL is empty, the public FTC Scout API is used.
+func New(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+	return &Client{
+		baseURL:   baseURL,
+		userAgent: defaultUserAgent,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// GetEvents returns the events held in the season.
+func (c *Client) GetEvents(season int) ([]*Event, error) {
+	var events []*Event
+	if err := c.get(fmt.Sprintf("/events/search/%d", season), &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetEventMatches returns the matches played at an event.
+func (c *Client) GetEventMatches(season int, eventCode string) ([]*Match, error) {
+	var matches []*Match
+	if err := c.get(fmt.Sprintf("/events/%d/%s/matches", season, url.PathEscape(eventCode)), &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// GetEventTeams returns the teams that attended an event.
+func (c *Client) GetEventTeams(season int, eventCode string) ([]*EventTeam, error) {
+	var teams []*EventTeam
+	if err := c.get(fmt.Sprintf("/events/%d/%s/teams", season, url.PathEscape(eventCode)), &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// get sends a GET request to the FTC Scout API and decodes the JSON response into v.
+func (c *Client) get(path string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to FTC Scout %s failed with status %d", path, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
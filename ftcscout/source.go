@@ -0,0 +1,328 @@
+package ftcscout
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rbrabson/ftc"
+)
+
+const (
+	qualificationLevel = "QUALIFICATION"
+	playoffLevel       = "PLAYOFF"
+)
+
+// eventTypes maps the event types used by FTC Scout to the event type codes used by the FTC Events API. Other
+// event types are reported as "Other".
+var eventTypes = map[string]string{
+	"Scrimmage":         "0",
+	"LeagueMeet":        "1",
+	"Qualifier":         "2",
+	"LeagueTournament":  "3",
+	"Championship":      "4",
+	"Other":             "5",
+	"FIRSTChampionship": "6",
+	"SuperQualifier":    "7",
+}
+
+// Source provides FTC Scout data in the form returned by the FTC Events API, so it can be used as a data source
+// by the request package. FTC Scout provides events, matches, and rankings; requests for other data fail with an
+// error that wraps errors.ErrUnsupported.
+type Source struct {
+	client *Client
+
+	mu      sync.Mutex
+	matches map[string][]*Match
+}
+
+// NewSource returns a data source that requests data from FTC Scout using the client.
+func NewSource(client *Client) *Source {
+	return &Source{
+		client:  client,
+		matches: make(map[string][]*Match),
+	}
+}
+
+// Name returns the name of FTC Scout.
+func (s *Source) Name() string {
+	return "ftcscout"
+}
+
+// GetAwardListing is not supported by FTC Scout.
+func (s *Source) GetAwardListing(season string) ([]*ftc.Award, error) {
+	return nil, unsupported("award listings")
+}
+
+// GetTeams is not supported by FTC Scout.
+func (s *Source) GetTeams(season string) ([]*ftc.Team, error) {
+	return nil, unsupported("season teams")
+}
+
+// GetEventAwards is not supported by FTC Scout.
+func (s *Source) GetEventAwards(season, eventCode string) ([]*ftc.TeamAward, error) {
+	return nil, unsupported("event awards")
+}
+
+// GetAdvancementsTo is not supported by FTC Scout.
+func (s *Source) GetAdvancementsTo(season, eventCode string) (*ftc.AdvancementsTo, error) {
+	return nil, unsupported("event advancements")
+}
+
+// GetEventTeams is not supported by FTC Scout.
+func (s *Source) GetEventTeams(season, eventCode string) ([]*ftc.Team, error) {
+	return nil, unsupported("event registrations")
+}
+
+// GetEvents returns the events held in the season.
+func (s *Source) GetEvents(season string) ([]*ftc.Event, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season %q", season)
+	}
+	events, err := s.client.GetEvents(year)
+	if err != nil {
+		return nil, err
+	}
+
+	ftcEvents := make([]*ftc.Event, 0, len(events))
+	for _, event := range events {
+		eventType, ok := eventTypes[event.Type]
+		if !ok {
+			eventType = eventTypes["Other"]
+		}
+		ftcEvent := &ftc.Event{
+			Code:         event.Code,
+			DivisionCode: event.DivisionCode,
+			Name:         event.Name,
+			Remote:       event.Remote,
+			Hybrid:       event.Hybrid,
+			FieldCount:   event.FieldCount,
+			Published:    event.Published,
+			Type:         eventType,
+			TypeName:     event.Type,
+			RegionCode:   event.RegionCode,
+			LeagueCode:   event.LeagueCode,
+			Venue:        event.Venue,
+			Address:      event.Address,
+			City:         event.City,
+			Stateprov:    event.StateProv,
+			Country:      event.Country,
+			Timezone:     event.Timezone,
+			DateStart:    parseDate(event.Start),
+			DateEnd:      parseDate(event.End),
+		}
+		if event.DistrictCode != nil {
+			ftcEvent.DistrictCode = *event.DistrictCode
+		}
+		if event.Website != nil {
+			ftcEvent.Website = *event.Website
+		}
+		ftcEvents = append(ftcEvents, ftcEvent)
+	}
+	return ftcEvents, nil
+}
+
+// GetRankings returns the qualification rankings of an event, ordered by rank.
+func (s *Source) GetRankings(season, eventCode string) ([]*ftc.Ranking, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season %q", season)
+	}
+	teams, err := s.client.GetEventTeams(year, eventCode)
+	if err != nil {
+		return nil, err
+	}
+
+	rankings := make([]*ftc.Ranking, 0, len(teams))
+	for _, team := range teams {
+		if team.Stats == nil || team.Stats.Rank == 0 {
+			continue
+		}
+		rankings = append(rankings, &ftc.Ranking{
+			Rank:              team.Stats.Rank,
+			TeamNumber:        team.TeamNumber,
+			DisplayTeamNumber: strconv.Itoa(team.TeamNumber),
+			SortOrder1:        team.Stats.RP,
+			SortOrder2:        team.Stats.TB1,
+			SortOrder3:        team.Stats.TB2,
+			Wins:              team.Stats.Wins,
+			Losses:            team.Stats.Losses,
+			Ties:              team.Stats.Ties,
+			DQ:                team.Stats.DQs,
+			MatchesPlayed:     team.Stats.QualMatchesPlayed,
+			MatchesCounted:    team.Stats.QualMatchesPlayed,
+		})
+	}
+	slices.SortFunc(rankings, func(a, b *ftc.Ranking) int {
+		return a.Rank - b.Rank
+	})
+	return rankings, nil
+}
+
+// GetMatchResults returns the results of an event's matches at the tournament level. Matches that have not been
+// played, or that have no alliance scores, are not included.
+func (s *Source) GetMatchResults(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.Match, error) {
+	matches, err := s.eventMatches(season, eventCode, tournamentLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	ftcMatches := make([]*ftc.Match, 0, len(matches))
+	for _, match := range matches {
+		red, blue := match.Scores.Red, match.Scores.Blue
+		ftcMatch := &ftc.Match{
+			ActualStartTime: deref(match.ActualStartTime),
+			Description:     match.Description,
+			TournamentLevel: matchLevel(match.TournamentLevel),
+			Series:          match.Series,
+			MatchNumber:     match.MatchNum,
+			ScoreRedFinal:   red.TotalPoints,
+			ScoreRedFoul:    blue.PenaltyPointsCommitted,
+			ScoreRedAuto:    red.AutoPoints,
+			ScoreBlueFinal:  blue.TotalPoints,
+			ScoreBlueFoul:   red.PenaltyPointsCommitted,
+			ScoreBlueAuto:   blue.AutoPoints,
+			PostResultTime:  deref(match.PostResultTime),
+			Teams:           make([]*ftc.MatchTeam, 0, len(match.Teams)),
+		}
+		for _, team := range match.Teams {
+			ftcMatch.Teams = append(ftcMatch.Teams, &ftc.MatchTeam{
+				TeamNumber: team.TeamNumber,
+				Station:    team.Alliance + stationNumber(team.Station),
+				DQ:         team.DQ,
+				OnField:    team.OnField,
+			})
+		}
+		ftcMatches = append(ftcMatches, ftcMatch)
+	}
+	return ftcMatches, nil
+}
+
+// GetEventScores returns the scores of an event's matches at the tournament level. Playoff scores are numbered
+// by series, as they are in the FTC Events API.
+func (s *Source) GetEventScores(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.MatchScores, error) {
+	matches, err := s.eventMatches(season, eventCode, tournamentLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]*ftc.MatchScores, 0, len(matches))
+	for _, match := range matches {
+		level := matchLevel(match.TournamentLevel)
+		matchNumber := match.MatchNum
+		if level == playoffLevel {
+			matchNumber = match.Series
+		}
+		scores = append(scores, &ftc.MatchScores{
+			MatchLevel:  level,
+			MatchSeries: match.Series,
+			MatchNumber: matchNumber,
+			Alliances: []*ftc.MatchAlliance{
+				allianceScore("Red", match.Scores.Red, match.Scores.Blue),
+				allianceScore("Blue", match.Scores.Blue, match.Scores.Red),
+			},
+		})
+	}
+	return scores, nil
+}
+
+// eventMatches returns the played matches of an event at the tournament level. The matches of an event are
+// requested once and reused, since the match results and scores are both built from them.
+func (s *Source) eventMatches(season, eventCode string, tournamentLevel ftc.MatchType) ([]*Match, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season %q", season)
+	}
+
+	key := season + "/" + strings.ToUpper(eventCode)
+	s.mu.Lock()
+	matches, ok := s.matches[key]
+	s.mu.Unlock()
+	if !ok {
+		matches, err = s.client.GetEventMatches(year, eventCode)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.matches[key] = matches
+		s.mu.Unlock()
+	}
+
+	played := make([]*Match, 0, len(matches))
+	for _, match := range matches {
+		if !match.HasBeenPlayed || match.Scores == nil || match.Scores.Red == nil || match.Scores.Blue == nil {
+			continue
+		}
+		if !strings.EqualFold(matchLevel(match.TournamentLevel), string(tournamentLevel)) {
+			continue
+		}
+		played = append(played, match)
+	}
+	return played, nil
+}
+
+// allianceScore converts the score of an alliance to the form returned by the FTC Events API. The foul points of an
+// alliance are the penalty points committed by the opposing alliance, which are included in its total.
+func allianceScore(alliance string, score, opponent *AllianceScore) *ftc.MatchAlliance {
+	return &ftc.MatchAlliance{
+		Alliance:            alliance,
+		AutoPoints:          score.AutoPoints,
+		TeleopPoints:        score.DcPoints,
+		FoulPointsCommitted: opponent.PenaltyPointsCommitted,
+		PreFoulTotal:        score.TotalPointsNp,
+		TotalPoints:         score.TotalPoints,
+		MajorFouls:          score.MajorsCommitted,
+		MinorFouls:          score.MinorsCommitted,
+	}
+}
+
+// matchLevel returns the FTC Events API tournament level of an FTC Scout tournament level. FTC Scout reports each
+// playoff round separately, while the FTC Events API reports them all as playoffs.
+func matchLevel(tournamentLevel string) string {
+	if strings.EqualFold(tournamentLevel, "Quals") {
+		return qualificationLevel
+	}
+	return playoffLevel
+}
+
+// stationNumber returns the number of an FTC Scout station, such as "One", as used in FTC Events API stations
+// such as "Red1".
+func stationNumber(station string) string {
+	switch strings.ToLower(station) {
+	case "one":
+		return "1"
+	case "two":
+		return "2"
+	case "three":
+		return "3"
+	}
+	return station
+}
+
+// parseDate parses a date reported by FTC Scout, which may or may not include the time.
+func parseDate(s string) ftc.Time {
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return ftc.Time(t)
+		}
+	}
+	return ftc.Time{}
+}
+
+// deref returns the value of the string, or an empty string if it is nil.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// unsupported returns an error reporting that FTC Scout does not provide the data.
+func unsupported(data string) error {
+	return fmt.Errorf("FTC Scout does not provide %s: %w", data, errors.ErrUnsupported)
+}
@@ -0,0 +1,91 @@
+package geocode
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	earthRadiusMiles  = 3958.8
+	kilometersPerMile = 1.609344
+)
+
+// Location is a point on the earth, in decimal degrees.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Geocoder converts an address into a location.
+type Geocoder interface {
+	// Geocode returns the location of the address, or nil if the address could not be found.
+	Geocode(address string) (*Location, error)
+}
+
+// New returns the geocoder selected by the GEOCODER environment variable.
+// If GEOCODER is not set or is "none", nil is returned and geocoding is disabled.
+func New() (Geocoder, error) {
+	geocoder := strings.ToLower(os.Getenv("GEOCODER"))
+	switch geocoder {
+	case "", "none":
+		return nil, nil
+	case "nominatim":
+		return NewNominatim(os.Getenv("GEOCODER_URL"), os.Getenv("GEOCODER_USER_AGENT")), nil
+	}
+	return nil, fmt.Errorf("unsupported GEOCODER: %s", geocoder)
+}
+
+// String returns a string representation of the Location.
+func (l *Location) String() string {
+	return fmt.Sprintf("%.5f,%.5f", l.Latitude, l.Longitude)
+}
+
+// Distance returns the great-circle distance between two locations in miles.
+func Distance(from, to Location) float64 {
+	lat1 := from.Latitude * math.Pi / 180
+	lat2 := to.Latitude * math.Pi / 180
+	dLat := (to.Latitude - from.Latitude) * math.Pi / 180
+	dLon := (to.Longitude - from.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Sqrt(a))
+}
+
+// ParseLocation parses a location given as "latitude,longitude" in decimal degrees.
+func ParseLocation(s string) (*Location, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid location: %s", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("invalid latitude: %s", parts[0])
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return nil, fmt.Errorf("invalid longitude: %s", parts[1])
+	}
+	return &Location{Latitude: lat, Longitude: lon}, nil
+}
+
+// ParseDistance parses a distance such as "150mi", "200km", or "150" and returns it in miles.
+// A distance without a unit is in miles.
+func ParseDistance(s string) (float64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(value, "mi"):
+		value = strings.TrimSuffix(value, "mi")
+	case strings.HasSuffix(value, "km"):
+		value = strings.TrimSuffix(value, "km")
+		multiplier = 1 / kilometersPerMile
+	}
+	distance, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || distance < 0 {
+		return 0, fmt.Errorf("invalid distance: %s", s)
+	}
+	return distance * multiplier, nil
+}
@@ -0,0 +1,104 @@
+package geocode
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNominatimURL       = "https://nominatim.openstreetmap.org"
+	defaultNominatimUserAgent = "ftcstanding (https://github.com/rbrabson/ftcstanding)"
+)
+
+// Nominatim is a Geocoder that uses the OpenStreetMap Nominatim search API.
+// Requests are limited to one per second, as required by the public Nominatim usage policy.
+type Nominatim struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+// nominatimResult is a single result returned by the Nominatim search API.
+type nominatimResult struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+// NewNominatim returns a Nominatim geocoder. If baseURL or userAgent are empty, the public Nominatim
+// server and a default user agent are used.
+func NewNominatim(baseURL, userAgent string) *Nominatim {
+	if baseURL == "" {
+		baseURL = defaultNominatimURL
+	}
+	if userAgent == "" {
+		userAgent = defaultNominatimUserAgent
+	}
+	return &Nominatim{
+		baseURL:   baseURL,
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Geocode returns the location of the address, or nil if the address could not be found.
+func (n *Nominatim) Geocode(address string) (*Location, error) {
+	n.throttle()
+
+	params := url.Values{}
+	params.Set("q", address)
+	params.Set("format", "json")
+	params.Set("limit", "1")
+
+	req, err := http.NewRequest(http.MethodGet, n.baseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", n.userAgent)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geocoding %q failed with status %d", address, resp.StatusCode)
+	}
+
+	var results []nominatimResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude %q returned for %q", results[0].Lat, address)
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude %q returned for %q", results[0].Lon, address)
+	}
+	return &Location{Latitude: lat, Longitude: lon}, nil
+}
+
+// throttle waits until at least one second has passed since the previous request.
+func (n *Nominatim) throttle() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if wait := time.Second - time.Since(n.lastRequest); wait > 0 {
+		time.Sleep(wait)
+	}
+	n.lastRequest = time.Now()
+}
@@ -0,0 +1,334 @@
+// Package ftcmock provides a mock of the FTC Events API that serves canned fixtures. It allows data to be synced
+// without access to the FTC Events API, and gives the same results every time it is run.
+package ftcmock
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftc"
+)
+
+// Username and AuthKey are the credentials used to authenticate with the mock server. The mock server accepts any
+// credentials, but the FTC Events API requires them to be present.
+const (
+	Username = "ftcmock"
+	AuthKey  = "ftcmock"
+)
+
+// teamsPageSize is the number of teams returned in each page of teams. It is kept small so that retrieving the
+// fixtures requires more than one page, as it does with the FTC Events API.
+const teamsPageSize = 5
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Fixtures returns the canned fixtures served by the mock server. There is a directory for each season, which
+// contains:
+//   - teams.json, events.json, and awards.json - the season's teams, events, and award definitions
+//   - <eventCode>/matches.json and <eventCode>/scores.json - the qualification and playoff matches and scores; the
+//     event's schedule lists the same matches
+//   - <eventCode>/rankings.json, awards.json, advancement.json, and alliances.json - the event's results
+func Fixtures() fs.FS {
+	sub, _ := fs.Sub(fixtures, "fixtures")
+	return sub
+}
+
+// mock serves the FTC Events API from a set of fixtures.
+type mock struct {
+	fixtures fs.FS
+}
+
+// NewHandler returns a handler that serves the FTC Events API from the fixtures. The handler serves the API from
+// the root of the server, so the URL of the server is used as the FTC server URL.
+func NewHandler(fixtures fs.FS) http.Handler {
+	m := &mock{fixtures: fixtures}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{season}/teams", m.handleTeams)
+	mux.HandleFunc("GET /{season}/events", m.handleEvents)
+	mux.HandleFunc("GET /{season}/awards/list", m.handleAwardListing)
+	mux.HandleFunc("GET /{season}/awards/{eventCode}", m.handleEventAwards)
+	mux.HandleFunc("GET /{season}/matches/{eventCode}", m.handleMatches)
+	mux.HandleFunc("GET /{season}/scores/{eventCode}/{tournamentLevel}", m.handleScores)
+	mux.HandleFunc("GET /{season}/schedule/{eventCode}", m.handleSchedule)
+	mux.HandleFunc("GET /{season}/rankings/{eventCode}", m.handleRankings)
+	mux.HandleFunc("GET /{season}/advancement/{eventCode}", m.handleAdvancements)
+	mux.HandleFunc("GET /{season}/alliances/{eventCode}", m.handleAlliances)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The FTC Events API rejects requests without credentials
+		if _, _, ok := r.BasicAuth(); !ok {
+			http.Error(w, "authorization required", http.StatusUnauthorized)
+			return
+		}
+		slog.Debug("mock FTC request", "method", r.Method, "url", r.URL.String())
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// NewServer starts a mock FTC Events API server. If dir is not empty, the fixtures are read from that directory
+// instead of the canned fixtures, using the same layout. The caller must close the server once it is done.
+func NewServer(dir string) (*httptest.Server, error) {
+	fixtures := Fixtures()
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mock fixtures: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("mock fixtures %s is not a directory", dir)
+		}
+		fixtures = os.DirFS(dir)
+	}
+	return httptest.NewServer(NewHandler(fixtures)), nil
+}
+
+// handleTeams returns a page of the season's teams. The teams may be limited to a single team with the
+// teamNumber query parameter, or to the teams registered for an event with the eventCode query parameter. The
+// teams registered for an event are the teams in its matches.
+func (m *mock) handleTeams(w http.ResponseWriter, r *http.Request) {
+	var teams []*ftc.Team
+	if !m.readFixture(w, r, &teams, "teams.json") {
+		return
+	}
+	if teamNumber := r.URL.Query().Get("teamNumber"); teamNumber != "" {
+		teams = slices.DeleteFunc(teams, func(t *ftc.Team) bool {
+			return strconv.Itoa(t.TeamNumber) != teamNumber
+		})
+	}
+	if eventCode := r.URL.Query().Get("eventCode"); eventCode != "" {
+		teams = slices.DeleteFunc(teams, func(t *ftc.Team) bool {
+			return !m.eventHasTeam(r, eventCode, strconv.Itoa(t.TeamNumber))
+		})
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		var err error
+		page, err = strconv.Atoi(p)
+		if err != nil || page < 1 {
+			http.Error(w, "invalid page", http.StatusBadRequest)
+			return
+		}
+	}
+	pageTotal := max((len(teams)+teamsPageSize-1)/teamsPageSize, 1)
+	start := min((page-1)*teamsPageSize, len(teams))
+	end := min(start+teamsPageSize, len(teams))
+
+	writeJSON(w, ftc.Teams{
+		Teams:          teams[start:end],
+		TeamCountTotal: len(teams),
+		TeamCountPage:  end - start,
+		PageCurrent:    page,
+		PageTotal:      pageTotal,
+	})
+}
+
+// handleEvents returns the season's events. The events may be limited with the eventCode or teamNumber query
+// parameters.
+func (m *mock) handleEvents(w http.ResponseWriter, r *http.Request) {
+	var events []*ftc.Event
+	if !m.readFixture(w, r, &events, "events.json") {
+		return
+	}
+	if eventCode := r.URL.Query().Get("eventCode"); eventCode != "" {
+		events = slices.DeleteFunc(events, func(e *ftc.Event) bool {
+			return !strings.EqualFold(e.Code, eventCode)
+		})
+	}
+	if teamNumber := r.URL.Query().Get("teamNumber"); teamNumber != "" {
+		events = slices.DeleteFunc(events, func(e *ftc.Event) bool {
+			return !m.eventHasTeam(r, e.Code, teamNumber)
+		})
+	}
+
+	writeJSON(w, ftc.Events{
+		Events:     events,
+		EventCount: len(events),
+	})
+}
+
+// eventHasTeam returns true if the team played in any of the event's matches.
+func (m *mock) eventHasTeam(r *http.Request, eventCode, teamNumber string) bool {
+	var matches []*ftc.Match
+	if err := m.loadFixture(r.PathValue("season"), &matches, eventCode, "matches.json"); err != nil {
+		return false
+	}
+	return slices.ContainsFunc(matches, func(match *ftc.Match) bool {
+		return matchHasTeam(match, teamNumber)
+	})
+}
+
+// handleAwardListing returns the awards that may be given in the season.
+func (m *mock) handleAwardListing(w http.ResponseWriter, r *http.Request) {
+	var awards []*ftc.Award
+	if !m.readFixture(w, r, &awards, "awards.json") {
+		return
+	}
+	writeJSON(w, ftc.Awards{Awards: awards})
+}
+
+// handleEventAwards returns the awards given at an event.
+func (m *mock) handleEventAwards(w http.ResponseWriter, r *http.Request) {
+	var awards []*ftc.TeamAward
+	if !m.readFixture(w, r, &awards, r.PathValue("eventCode"), "awards.json") {
+		return
+	}
+	writeJSON(w, ftc.TeamAwards{Awards: awards})
+}
+
+// handleMatches returns the results of an event's matches at the level given by the tournamentLevel query
+// parameter. The matches may be limited to those played by a team with the teamNumber query parameter.
+func (m *mock) handleMatches(w http.ResponseWriter, r *http.Request) {
+	var matches []*ftc.Match
+	if !m.readFixture(w, r, &matches, r.PathValue("eventCode"), "matches.json") {
+		return
+	}
+	// The fixtures use levels such as "QUALIFICATION", while requests use levels such as "Qualification"
+	if level := r.URL.Query().Get("tournamentLevel"); level != "" {
+		matches = slices.DeleteFunc(matches, func(match *ftc.Match) bool {
+			return !strings.EqualFold(match.TournamentLevel, level)
+		})
+	}
+	if teamNumber := r.URL.Query().Get("teamNumber"); teamNumber != "" {
+		matches = slices.DeleteFunc(matches, func(match *ftc.Match) bool {
+			return !matchHasTeam(match, teamNumber)
+		})
+	}
+	writeJSON(w, ftc.Matches{Matches: matches})
+}
+
+// handleSchedule returns the schedule of an event's matches at the given tournament level. The schedule is built
+// from the event's matches, each scheduled to start when it was played.
+func (m *mock) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	var matches []*ftc.Match
+	if !m.readFixture(w, r, &matches, r.PathValue("eventCode"), "matches.json") {
+		return
+	}
+	level := r.URL.Query().Get("tournamentLevel")
+	schedule := []*ftc.EventSchedule{}
+	for _, match := range matches {
+		if level != "" && !strings.EqualFold(match.TournamentLevel, level) {
+			continue
+		}
+		scheduled := &ftc.EventSchedule{
+			Description:     match.Description,
+			TournamentLevel: match.TournamentLevel,
+			StartTime:       match.ActualStartTime,
+			Series:          match.Series,
+			MatchNumber:     match.MatchNumber,
+			ModifiedOn:      match.ModifiedOn,
+		}
+		for _, team := range match.Teams {
+			scheduled.Teams = append(scheduled.Teams, ftc.ScheduledTeam{TeamNumber: team.TeamNumber, Station: team.Station})
+		}
+		schedule = append(schedule, scheduled)
+	}
+	writeJSON(w, ftc.EventSchedules{Schedule: schedule})
+}
+
+// handleScores returns the detailed scores of an event's matches at the given tournament level.
+func (m *mock) handleScores(w http.ResponseWriter, r *http.Request) {
+	var scores []*ftc.MatchScores
+	if !m.readFixture(w, r, &scores, r.PathValue("eventCode"), "scores.json") {
+		return
+	}
+	level := r.PathValue("tournamentLevel")
+	scores = slices.DeleteFunc(scores, func(score *ftc.MatchScores) bool {
+		return !strings.EqualFold(score.MatchLevel, level)
+	})
+	writeJSON(w, ftc.Scores{MatchScores: scores})
+}
+
+// handleRankings returns the qualification rankings of an event.
+func (m *mock) handleRankings(w http.ResponseWriter, r *http.Request) {
+	var rankings []*ftc.Ranking
+	if !m.readFixture(w, r, &rankings, r.PathValue("eventCode"), "rankings.json") {
+		return
+	}
+	writeJSON(w, ftc.Rankings{Rankings: rankings})
+}
+
+// handleAdvancements returns the teams advancing from an event.
+func (m *mock) handleAdvancements(w http.ResponseWriter, r *http.Request) {
+	var advancements ftc.AdvancementsTo
+	if !m.readFixture(w, r, &advancements, r.PathValue("eventCode"), "advancement.json") {
+		return
+	}
+	writeJSON(w, advancements)
+}
+
+// handleAlliances returns the playoff alliances of an event.
+func (m *mock) handleAlliances(w http.ResponseWriter, r *http.Request) {
+	var alliances []*ftc.Alliance
+	if !m.readFixture(w, r, &alliances, r.PathValue("eventCode"), "alliances.json") {
+		return
+	}
+	writeJSON(w, ftc.Alliances{
+		Alliances: alliances,
+		Count:     len(alliances),
+	})
+}
+
+// readFixture reads a fixture for the request's season into v. If the fixture can't be read, an error is written
+// to the response and false is returned. Unknown seasons and events are reported as not found.
+func (m *mock) readFixture(w http.ResponseWriter, r *http.Request, v any, elem ...string) bool {
+	err := m.loadFixture(r.PathValue("season"), v, elem...)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, fs.ErrNotExist):
+		http.Error(w, "not found", http.StatusNotFound)
+	default:
+		slog.Error("failed to read mock fixture", "url", r.URL.String(), "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return false
+}
+
+// loadFixture reads a fixture for the season into v.
+func (m *mock) loadFixture(season string, v any, elem ...string) error {
+	if len(elem) > 1 {
+		// Event codes are not case-sensitive in the FTC Events API
+		elem[0] = strings.ToUpper(elem[0])
+	}
+	name := path.Join(append([]string{season}, elem...)...)
+	if !fs.ValidPath(name) {
+		return fs.ErrNotExist
+	}
+	data, err := fs.ReadFile(m.fixtures, name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// matchHasTeam returns true if the team played in the match.
+func matchHasTeam(match *ftc.Match, teamNumber string) bool {
+	return slices.ContainsFunc(match.Teams, func(team *ftc.MatchTeam) bool {
+		return strconv.Itoa(team.TeamNumber) == teamNumber
+	})
+}
+
+// writeJSON writes the response as JSON.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write mock response", "error", err)
+	}
+}
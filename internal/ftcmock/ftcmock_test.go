@@ -0,0 +1,72 @@
+package ftcmock_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/ftcmock"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/request"
+)
+
+// TestSyncAndQuery syncs the canned season from the mock server into an empty file database and checks the results
+// of queries on it, as ftcdata --mock followed by ftc would give them.
+func TestSyncAndQuery(t *testing.T) {
+	t.Setenv("DB_TYPE", "file")
+	t.Setenv("FILEDB_DATA_DIR", t.TempDir())
+	db, err := database.Init("2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	request.Init(db)
+	query.Init(db)
+
+	mock, err := ftcmock.NewServer("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	request.SetFTCServer(mock.URL, ftcmock.Username, ftcmock.AuthKey)
+
+	request.RequestAndSaveAll("2025", false, false)
+
+	// The event's rankings are those in its rankings.json
+	rankings, err := query.EventTeamRankingQuery("USMOCKQ1", 2025)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rankings == nil {
+		t.Fatal("USMOCKQ1 was not synced")
+	}
+	var ranked []int
+	for _, r := range rankings.TeamRankings {
+		ranked = append(ranked, r.Team.TeamID)
+	}
+	want := []int{90007, 90002, 90003, 90010, 90004, 90008, 90009, 90005, 90006, 90001}
+	if !slices.Equal(ranked, want) {
+		t.Errorf("USMOCKQ1 rankings = %v, want %v", ranked, want)
+	}
+
+	// Every match in its matches.json is saved with its scores
+	matches, err := query.MatchesByEventQuery("USMOCKQ1", 2025)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 17 {
+		t.Errorf("USMOCKQ1 has %d matches, want 17", len(matches))
+	}
+
+	// The team rankings are calculated from the matches of every event in the region
+	performances, err := query.TeamRankingsQuery("USMOCK", "", nil, 2025, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(performances) != 12 {
+		t.Fatalf("USMOCK has %d ranked teams, want 12", len(performances))
+	}
+	if top := performances[0]; top.TeamID != 90002 || top.Matches != 16 {
+		t.Errorf("top ranked team = %d with %d matches, want 90002 with 16 matches", top.TeamID, top.Matches)
+	}
+}
@@ -0,0 +1,174 @@
+// Package locale formats numbers and dates for the locale selected with --locale, so reports can be read by teams,
+// parents, and schools outside the United States. The default locale, en-US, formats them as the reports always
+// have: "12.34" and "Jan 2, 2006 3:04 PM".
+package locale
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// layouts are the time layouts used to format dates and times in a locale.
+type layouts struct {
+	date      string // Date with the year, such as "Jan 2, 2006"
+	shortDate string // Date without the year, such as "Jan 2"
+	longDate  string // Date with the month spelled out, such as "January 2, 2006"
+	fullDate  string // Long date with the day of the week, such as "Monday, January 2, 2006"
+	time      string // Time of day, such as "3:04 PM"
+}
+
+// usLayouts are the layouts of the default locale, en-US.
+var usLayouts = layouts{
+	date:      "Jan 2, 2006",
+	shortDate: "Jan 2",
+	longDate:  "January 2, 2006",
+	fullDate:  "Monday, January 2, 2006",
+	time:      "3:04 PM",
+}
+
+// monthFirstRegions are the regions where English dates are written with the month first, as in the United States.
+var monthFirstRegions = []string{"US", "CA", "PH"}
+
+// twelveHourRegions are the regions where English times are written with a 12-hour clock.
+var twelveHourRegions = []string{"US", "CA", "PH", "AU", "NZ", "IN"}
+
+// yearFirstRegions are the regions where numeric dates are written with the year first, such as "2006-01-02".
+var yearFirstRegions = []string{"CA", "CN", "HU", "JP", "KR", "LT", "SE", "TW"}
+
+// dotRegions are the regions where numeric dates are separated by dots, such as "02.01.2006".
+var dotRegions = []string{"AT", "BG", "BY", "CH", "CZ", "DE", "DK", "EE", "FI", "HR", "KZ", "LV", "NO", "PL", "RO", "RS", "RU", "SK", "TR", "UA"}
+
+var (
+	decimal = "."       // Decimal separator of the selected locale
+	formats = usLayouts // Date and time layouts of the selected locale
+)
+
+// Set selects the locale numbers and dates are formatted for. The locale can be a BCP 47 tag, such as "de" or
+// "en-GB", or a POSIX locale, such as "fr_CA.UTF-8". A tag without a region takes the language's most likely one,
+// so "de" formats dates as in Germany. An empty locale, or "C" or "POSIX", selects en-US. An error is returned if
+// the locale isn't valid, and en-US is selected.
+func Set(name string) error {
+	decimal, formats = ".", usLayouts
+
+	// Drop the encoding and modifier of a POSIX locale, such as ".UTF-8" or "@euro"
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ReplaceAll(name, "_", "-")
+	if name == "" || name == "C" || name == "POSIX" {
+		return nil
+	}
+
+	tag, err := language.Parse(name)
+	if err != nil {
+		return fmt.Errorf("invalid locale %q: %w", name, err)
+	}
+	decimal = decimalSeparator(tag)
+	formats = layoutsFor(tag)
+	return nil
+}
+
+// FromEnv returns the user's locale for formatting numbers and dates, taken from the LC_ALL, LC_NUMERIC, LC_TIME,
+// or LANG environment variable in that order, or an empty string if none is set.
+func FromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LC_TIME", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// decimalSeparator returns the decimal separator of a locale, such as "," for French, or "." if it uses digits
+// other than 0 through 9, which aren't used in the reports.
+func decimalSeparator(tag language.Tag) string {
+	s := message.NewPrinter(tag).Sprintf("%.1f", 1.5)
+	if len(s) < 3 || !strings.HasPrefix(s, "1") || !strings.HasSuffix(s, "5") {
+		return "."
+	}
+	return s[1 : len(s)-1]
+}
+
+// layoutsFor returns the date and time layouts of a locale. English dates spell out the month, in the order used in
+// the locale's region; other languages use numeric dates, since Go only names the months in English.
+func layoutsFor(tag language.Tag) layouts {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	code := region.String()
+
+	if base.String() == "en" {
+		if slices.Contains(monthFirstRegions, code) {
+			return usLayouts
+		}
+		l := layouts{
+			date:      "2 Jan 2006",
+			shortDate: "2 Jan",
+			longDate:  "2 January 2006",
+			fullDate:  "Monday, 2 January 2006",
+			time:      "15:04",
+		}
+		if slices.Contains(twelveHourRegions, code) {
+			l.time = usLayouts.time
+		}
+		return l
+	}
+
+	var l layouts
+	switch {
+	case slices.Contains(yearFirstRegions, code):
+		l.date, l.shortDate = "2006-01-02", "01-02"
+	case slices.Contains(dotRegions, code):
+		l.date, l.shortDate = "02.01.2006", "02.01."
+	default:
+		l.date, l.shortDate = "02/01/2006", "02/01"
+	}
+	l.longDate, l.fullDate, l.time = l.date, l.date, "15:04"
+	return l
+}
+
+// Number formats a number with a format holding a single floating-point verb, such as "%.2f" or "%+.1f%%", using
+// the decimal separator of the selected locale.
+func Number(format string, value float64) string {
+	s := fmt.Sprintf(format, value)
+	if decimal != "." {
+		s = strings.Replace(s, ".", decimal, 1)
+	}
+	return s
+}
+
+// Date formats a date with the year, such as "Jan 2, 2006" or "02/01/2006".
+func Date(t time.Time) string {
+	return t.Format(formats.date)
+}
+
+// ShortDate formats a date without the year, such as "Jan 2" or "02/01".
+func ShortDate(t time.Time) string {
+	return t.Format(formats.shortDate)
+}
+
+// LongDate formats a date with the month spelled out, such as "January 2, 2006", in locales that name the months.
+func LongDate(t time.Time) string {
+	return t.Format(formats.longDate)
+}
+
+// FullDate formats a long date with the day of the week, such as "Monday, January 2, 2006", in locales that name
+// the months.
+func FullDate(t time.Time) string {
+	return t.Format(formats.fullDate)
+}
+
+// Time formats a time of day, such as "3:04 PM" or "15:04".
+func Time(t time.Time) string {
+	return t.Format(formats.time)
+}
+
+// DateTime formats a date and time of day, such as "Jan 2, 2006 3:04 PM" or "02/01/2006 15:04".
+func DateTime(t time.Time) string {
+	return Date(t) + " " + Time(t)
+}
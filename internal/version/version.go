@@ -0,0 +1,113 @@
+// Package version reports the version of the binaries, which is injected at build time, along with the commit and
+// date they were built from, the Go version, and the active database backend.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/spf13/cobra"
+)
+
+// The version, commit, and build date are set at build time with -ldflags, for example:
+//
+//	go build -ldflags "-X github.com/rbrabson/ftcstanding/internal/version.Version=0.2.0" ./cmd/ftc
+//
+// A binary built without them reports the commit and build date recorded by the Go toolchain, if any.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// Info describes the build of a binary and the database backend it is configured to use.
+type Info struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	GoVersion string
+	Platform  string // Operating system and architecture, such as linux/arm64
+	Backend   string // Database backend selected by DB_TYPE, or an empty string if it isn't set
+}
+
+// Get returns the build information of the running binary. Values that weren't injected at build time are taken
+// from the module and version control information the Go toolchain embeds in the binary; in that case the build
+// date is the time of the commit.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		Backend:   database.Backend(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		// Installed with go install, which records the module version
+		info.Version = strings.TrimPrefix(build.Main.Version, "v")
+	}
+	var modified bool
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = setting.Value
+			}
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if modified && Commit == "" && info.Commit != "" {
+		info.Commit += "-dirty"
+	}
+	return info
+}
+
+// String returns the build information, one value to a line.
+func (i Info) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Version:    %s\n", i.Version))
+	sb.WriteString(fmt.Sprintf("Commit:     %s\n", valueOrUnknown(i.Commit)))
+	sb.WriteString(fmt.Sprintf("Built:      %s\n", valueOrUnknown(i.BuildDate)))
+	sb.WriteString(fmt.Sprintf("Go version: %s\n", i.GoVersion))
+	sb.WriteString(fmt.Sprintf("Platform:   %s\n", i.Platform))
+	sb.WriteString(fmt.Sprintf("Backend:    %s\n", valueOrUnknown(i.Backend)))
+	return sb.String()
+}
+
+// valueOrUnknown returns the value, or "unknown" if it is empty.
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+// NewCommand returns the version subcommand of a binary, which prints the binary's build information. It doesn't
+// connect to the database, so it works when the database is unavailable.
+func NewCommand(binary string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show the version, commit, build date, Go version, and database backend",
+		Long: `Show the version of ` + binary + `, the commit and date it was built from, the Go version it was built with,
+its operating system and architecture, and the database backend selected by DB_TYPE. Include the output when
+reporting a problem.`,
+		Example: "  " + binary + " version",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Printf("%s\n%s", binary, Get())
+		},
+	}
+}
@@ -0,0 +1,239 @@
+// Package pdf renders reports as PDF documents that can be printed or posted alongside an event's announcements.
+//
+// Documents are drawn with github.com/jung-kurt/gofpdf. The library is archived, but it is pure Go, has no dependencies,
+// and the tables drawn here use only its core page, cell, and color calls, which haven't changed in years. Its
+// maintained fork, github.com/go-pdf/fpdf (now codeberg.org/go-pdf/fpdf), keeps the same API, so moving to it is a change
+// of import path if a fix is ever needed.
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+const (
+	pageMargin = 12.0 // Page margins in millimeters
+	rowHeight  = 7.0  // Height of a table row in millimeters
+)
+
+// rgb is a color used in a document.
+type rgb struct {
+	r, g, b int
+}
+
+var (
+	headerFill    = rgb{0x1B, 0x2A, 0x49} // Dark blue
+	accent        = rgb{0xF5, 0x7E, 0x25} // FTC orange
+	advancingFill = rgb{0xD4, 0xED, 0xDA} // Light green behind advancing teams
+	stripeFill    = rgb{0xF2, 0xF4, 0xF7} // Light gray behind every other row
+	white         = rgb{0xFF, 0xFF, 0xFF}
+	text          = rgb{0x1F, 0x1F, 0x1F}
+	muted         = rgb{0x6B, 0x72, 0x80} // Gray for details and notes
+	rule          = rgb{0xD1, 0xD5, 0xDB} // Light gray lines between rows
+)
+
+// tableColumn is a column of a table in a document.
+type tableColumn struct {
+	header string
+	width  float64 // Width in millimeters
+	align  string  // gofpdf alignment: "L", "C", or "R"
+}
+
+// advancementColumns are the columns of the advancement report's table, which fit the width of a letter page.
+var advancementColumns = []tableColumn{
+	{header: "Rank", width: 13, align: "C"},
+	{header: "Team", width: 15, align: "C"},
+	{header: "Team Name", width: 66, align: "L"},
+	{header: "Total", width: 15, align: "R"},
+	{header: "Judging", width: 16, align: "R"},
+	{header: "Playoff", width: 16, align: "R"},
+	{header: "Selection", width: 18, align: "R"},
+	{header: "Qual", width: 15, align: "R"},
+	{header: "Adv #", width: 14, align: "C"},
+}
+
+// WriteAdvancementReport renders an event's advancement report in the layout of a regional announcement and writes
+// it to w as a PDF document. Teams are listed in rank order with their advancement points, and the rows of the
+// advancing teams are highlighted. The footer of each page notes now's date.
+func WriteAdvancementReport(w io.Writer, report *query.AdvancementReport, now time.Time) error {
+	if report == nil || report.Event == nil {
+		return fmt.Errorf("no event data available")
+	}
+	event := report.Event
+
+	doc := gofpdf.New("P", "mm", "Letter", "")
+	tr := doc.UnicodeTranslatorFromDescriptor("") // Team names are UTF-8, while the core fonts use code page 1252
+	doc.SetMargins(pageMargin, pageMargin, pageMargin)
+	doc.SetDrawColor(rule.r, rule.g, rule.b)
+	doc.SetAutoPageBreak(false, pageMargin)
+	doc.SetTitle(fmt.Sprintf("%s Advancement", event.Name), true)
+	doc.SetCreator("FTC Standing", true)
+	doc.AliasNbPages("")
+	doc.SetFooterFunc(func() {
+		doc.SetY(-pageMargin - 4)
+		setTextColor(doc, muted)
+		doc.SetFont("Helvetica", "", 8)
+		doc.CellFormat(0, 4, fmt.Sprintf("FTC Standing  |  As of %s", locale.Date(now)), "", 0, "L", false, 0, "")
+		doc.SetX(pageMargin)
+		doc.CellFormat(0, 4, fmt.Sprintf("Page %d of {nb}", doc.PageNo()), "", 0, "R", false, 0, "")
+	})
+	doc.AddPage()
+
+	// Banner with the event name and details
+	pageWidth, pageHeight := doc.GetPageSize()
+	contentWidth := pageWidth - 2*pageMargin
+	setFillColor(doc, accent)
+	doc.Rect(pageMargin, pageMargin, contentWidth, 1.5, "F")
+	doc.Ln(4)
+	setTextColor(doc, text)
+	doc.SetFont("Helvetica", "B", 18)
+	doc.CellFormat(0, 9, fitText(doc, tr(event.Name), contentWidth), "", 1, "L", false, 0, "")
+	doc.SetFont("Helvetica", "", 13)
+	doc.CellFormat(0, 7, fmt.Sprintf("Advancement  |  %d Season", event.Year), "", 1, "L", false, 0, "")
+
+	setTextColor(doc, muted)
+	doc.SetFont("Helvetica", "", 10)
+	details := []string{event.EventCode}
+	if dates := eventDates(event.DateStart, event.DateEnd); dates != "" {
+		details = append(details, dates)
+	}
+	var location []string
+	for _, part := range []string{event.City, event.StateProv, event.Country} {
+		if part != "" {
+			location = append(location, part)
+		}
+	}
+	if len(location) > 0 {
+		details = append(details, strings.Join(location, ", "))
+	}
+	doc.CellFormat(0, 5, fitText(doc, tr(strings.Join(details, "  |  ")), contentWidth), "", 1, "L", false, 0, "")
+	if tc := report.TypicalCutoff; tc != nil {
+		doc.CellFormat(0, 5, tr(fmt.Sprintf("Typical cutoff: %d points at events with %s (range %d-%d, events: %d)",
+			tc.Median, tc.Label(), tc.Low, tc.High, tc.Events)), "", 1, "L", false, 0, "")
+	}
+	doc.Ln(4)
+
+	if len(report.TeamAdvancements) == 0 {
+		setTextColor(doc, text)
+		doc.SetFont("Helvetica", "", 11)
+		doc.CellFormat(0, rowHeight, "No teams found for this event.", "", 1, "L", false, 0, "")
+		return doc.Output(w)
+	}
+
+	// Ranked table, repeating the header at the top of each page
+	writeTableHeader(doc, advancementColumns)
+	var advancementRank, advancing int
+	for i, ta := range report.TeamAdvancements {
+		if doc.GetY()+rowHeight > pageHeight-pageMargin-8 {
+			doc.AddPage()
+			writeTableHeader(doc, advancementColumns)
+		}
+
+		name := ta.Team.Name
+		if ta.InspireSlot {
+			name += " (Inspire slot)"
+		}
+		var advancementNumber string
+		switch {
+		case ta.Status == "already_advancing":
+			name += " (already advanced)"
+			advancementNumber = "-"
+		case ta.AdvancementNumber != "-":
+			advancementRank++
+			advancementNumber = strconv.Itoa(advancementRank)
+		default:
+			advancementNumber = "-"
+		}
+
+		fill := i%2 == 1
+		setFillColor(doc, stripeFill)
+		setTextColor(doc, text)
+		doc.SetFont("Helvetica", "", 10)
+		if ta.Advances {
+			advancing++
+			fill = true
+			setFillColor(doc, advancingFill)
+			doc.SetFont("Helvetica", "B", 10)
+		}
+		cells := []string{
+			strconv.Itoa(ta.Rank),
+			strconv.Itoa(ta.Team.TeamID),
+			fitText(doc, tr(name), advancementColumns[2].width-2),
+			strconv.Itoa(ta.TotalPoints),
+			strconv.Itoa(ta.JudgingPoints),
+			strconv.Itoa(ta.PlayoffPoints),
+			strconv.Itoa(ta.SelectionPoints),
+			strconv.Itoa(ta.QualificationPoints),
+			advancementNumber,
+		}
+		for j, c := range advancementColumns {
+			doc.CellFormat(c.width, rowHeight, cells[j], "B", 0, c.align, fill, 0, "")
+		}
+		doc.Ln(-1)
+	}
+
+	// Legend for the highlighted rows
+	doc.Ln(3)
+	setFillColor(doc, advancingFill)
+	doc.Rect(pageMargin, doc.GetY()+1, 4, 4, "F")
+	doc.SetX(pageMargin + 6)
+	setTextColor(doc, muted)
+	doc.SetFont("Helvetica", "", 9)
+	doc.CellFormat(0, 6, fmt.Sprintf("Advancing (%d teams). Adv # is the order in which the teams advance.", advancing), "", 1, "L", false, 0, "")
+
+	return doc.Output(w)
+}
+
+// writeTableHeader writes the header row of a table at the current position.
+func writeTableHeader(doc *gofpdf.Fpdf, columns []tableColumn) {
+	setFillColor(doc, headerFill)
+	setTextColor(doc, white)
+	doc.SetFont("Helvetica", "B", 10)
+	for _, c := range columns {
+		doc.CellFormat(c.width, rowHeight+1, c.header, "", 0, c.align, true, 0, "")
+	}
+	doc.Ln(-1)
+}
+
+// eventDates formats the dates of an event, giving a single date for a one-day event.
+func eventDates(start, end time.Time) string {
+	switch {
+	case start.IsZero():
+		return ""
+	case end.IsZero() || end.Format("2006-01-02") == start.Format("2006-01-02"):
+		return locale.Date(start)
+	case start.Year() != end.Year():
+		return fmt.Sprintf("%s - %s", locale.Date(start), locale.Date(end))
+	default:
+		return fmt.Sprintf("%s - %s", locale.ShortDate(start), locale.Date(end))
+	}
+}
+
+// fitText shortens text with an ellipsis, if needed, so it fits in the width using the current font. The text has
+// already been translated to the single-byte code page of the core fonts.
+func fitText(doc *gofpdf.Fpdf, s string, width float64) string {
+	if doc.GetStringWidth(s) <= width {
+		return s
+	}
+	for len(s) > 0 && doc.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return strings.TrimRight(s, " ") + "..."
+}
+
+// setFillColor sets the color used to fill cells and shapes.
+func setFillColor(doc *gofpdf.Fpdf, c rgb) {
+	doc.SetFillColor(c.r, c.g, c.b)
+}
+
+// setTextColor sets the color used to write text.
+func setTextColor(doc *gofpdf.Fpdf, c rgb) {
+	doc.SetTextColor(c.r, c.g, c.b)
+}
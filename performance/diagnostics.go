@@ -0,0 +1,167 @@
+package performance
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/matrix"
+)
+
+// DiagnosticMetrics are the metrics solved by least squares whose calculation can be diagnosed.
+var DiagnosticMetrics = []string{"opr", "npopr", "ccwm", "dpr", "npdpr", "autoopr"}
+
+// metricScores return the score each metric is fit to for an alliance in a match, keyed by the metric.
+var metricScores = map[string]func(m Match, isRed bool) float64{
+	"opr": func(m Match, isRed bool) float64 {
+		if isRed {
+			return m.RedScore
+		}
+		return m.BlueScore
+	},
+	"npopr": func(m Match, isRed bool) float64 {
+		if isRed {
+			return m.RedScore - m.RedPenalties
+		}
+		return m.BlueScore - m.BluePenalties
+	},
+	"ccwm": func(m Match, isRed bool) float64 {
+		if isRed {
+			return m.RedScore - m.BlueScore
+		}
+		return m.BlueScore - m.RedScore
+	},
+	"dpr": func(m Match, isRed bool) float64 {
+		if isRed {
+			return m.BlueScore
+		}
+		return m.RedScore
+	},
+	"npdpr": func(m Match, isRed bool) float64 {
+		if isRed {
+			return m.BlueScore - m.BluePenalties
+		}
+		return m.RedScore - m.RedPenalties
+	},
+	"autoopr": func(m Match, isRed bool) float64 {
+		if isRed {
+			return m.RedAuto
+		}
+		return m.BlueAuto
+	},
+}
+
+// AllianceFit is how well a metric's ratings fit an alliance's score in a match: the score the ratings of the
+// alliance's teams add up to, against the actual score.
+type AllianceFit struct {
+	Match     string  `json:"match"`
+	Alliance  string  `json:"alliance"`
+	Teams     []int   `json:"teams"`
+	Actual    float64 `json:"actual"`
+	Predicted float64 `json:"predicted"`
+	Residual  float64 `json:"residual"` // Actual less predicted score
+}
+
+// Diagnostics are the inputs and results of solving a metric by least squares, so the calculation can be audited
+// and extended outside of the calculator. Row i of the design matrix marks the teams on the alliance of Fits[i],
+// with a column for each of Teams, and Scores[i] is the score the row is fit to.
+type Diagnostics struct {
+	Metric  string        `json:"metric"`
+	Lambda  float64       `json:"lambda"`
+	Teams   []int         `json:"teams"`
+	Matrix  [][]float64   `json:"matrix"`
+	Scores  []float64     `json:"scores"`
+	Ratings []float64     `json:"ratings"` // Solved rating of each of Teams
+	Fits    []AllianceFit `json:"fits"`
+	RMSE    float64       `json:"rmse"` // Root mean square of the residuals
+}
+
+// Diagnose solves a metric the same way as its Calculate method, and returns the design matrix, the scores it was
+// fit to, the solved ratings, and the predicted and actual score of each alliance.
+func (p *Calculator) Diagnose(metric string) (*Diagnostics, error) {
+	scoreFunc, ok := metricScores[metric]
+	if !ok {
+		return nil, fmt.Errorf("invalid metric %q, expected one of %s", metric, strings.Join(DiagnosticMetrics, ", "))
+	}
+	A, b, activeTeams := buildMatchMatrices(p.Matches, p.Teams, scoreFunc)
+
+	d := &Diagnostics{
+		Metric:  metric,
+		Lambda:  p.Lambda,
+		Teams:   activeTeams,
+		Matrix:  A,
+		Scores:  b,
+		Ratings: []float64{},
+		Fits:    []AllianceFit{},
+	}
+	if len(A) == 0 || len(activeTeams) == 0 {
+		return d, nil
+	}
+	if p.Lambda == 0 {
+		d.Ratings = matrix.SolveLeastSquares(A, b)
+	} else {
+		d.Ratings = matrix.SolveLeastSquaresRegularized(A, b, p.Lambda)
+	}
+
+	// The rows alternate between the red and blue alliance of each match
+	var squares float64
+	for i, row := range A {
+		m := p.Matches[i/2]
+		fit := AllianceFit{Match: m.Label, Alliance: "red", Teams: m.RedTeams, Actual: b[i]}
+		if i%2 == 1 {
+			fit.Alliance = "blue"
+			fit.Teams = m.BlueTeams
+		}
+		for j, x := range row {
+			fit.Predicted += x * d.Ratings[j]
+		}
+		fit.Residual = fit.Actual - fit.Predicted
+		squares += fit.Residual * fit.Residual
+		d.Fits = append(d.Fits, fit)
+	}
+	d.RMSE = math.Sqrt(squares / float64(len(A)))
+	return d, nil
+}
+
+// WriteCSV writes the diagnostics as CSV, with a row for each alliance in each match: the match and alliance, the
+// alliance's row of the design matrix with a column for each team, and the actual and predicted scores along with
+// the residual. A final row labeled "rating" holds the solved rating of each team.
+func (d *Diagnostics) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"match", "alliance"}
+	for _, team := range d.Teams {
+		header = append(header, strconv.Itoa(team))
+	}
+	header = append(header, "actual", "predicted", "residual")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for i, fit := range d.Fits {
+		record := []string{fit.Match, fit.Alliance}
+		for _, x := range d.Matrix[i] {
+			record = append(record, format(x))
+		}
+		record = append(record, format(fit.Actual), format(fit.Predicted), format(fit.Residual))
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	ratings := []string{"rating", ""}
+	for _, rating := range d.Ratings {
+		ratings = append(ratings, format(rating))
+	}
+	if err := writer.Write(slices.Concat(ratings, []string{"", "", ""})); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -2,6 +2,8 @@ package performance
 
 // Match represents a single match between two alliances of teams.
 type Match struct {
+	Label string // Name of the match, which identifies it in the diagnostics
+
 	RedTeams  []int
 	BlueTeams []int
 
@@ -10,6 +12,9 @@ type Match struct {
 
 	RedPenalties  float64
 	BluePenalties float64
+
+	RedAuto  float64 // Points scored in the autonomous period
+	BlueAuto float64
 }
 
 // buildMatchMatrices constructs the matrices A and b used for regression based on the matches and teams.
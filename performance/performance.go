@@ -4,11 +4,14 @@ import (
 	"github.com/rbrabson/ftcstanding/matrix"
 )
 
-// Calculator calculates various performance metrics for teams based on match data.
+// Calculator calculates various performance metrics for teams based on match data. The ratings are least squares
+// fits of each alliance's score to the sum of its teams' ratings. If Lambda is greater than 0, they are regularized
+// (ridge regression), which shrinks them toward 0 and lets them be calculated from too few matches to tell every team
+// apart, such as early in an event.
 type Calculator struct {
 	Matches []Match
-	Teams   []int
-	Lambda  float64
+	Teams   []int   // Teams to rate; a team that didn't play in the matches is rated 0
+	Lambda  float64 // Regularization strength, or 0 for none
 }
 
 // CalculateCCWM calculates the Calculated Contribution to Winning Margin (CCWM) for each team.
@@ -171,3 +174,31 @@ func (p *Calculator) CalculateOPR() map[int]float64 {
 	}
 	return out
 }
+
+// CalculateAutoOPR calculates the Offensive Power Rating (OPR) for each team from the points scored in the
+// autonomous period alone.
+func (p *Calculator) CalculateAutoOPR() map[int]float64 {
+	A, b, activeTeams := buildMatchMatrices(p.Matches, p.Teams, func(m Match, isRed bool) float64 {
+		if isRed {
+			return m.RedAuto
+		}
+		return m.BlueAuto
+	})
+
+	var x []float64
+	if p.Lambda == 0 {
+		x = matrix.SolveLeastSquares(A, b)
+	} else {
+		x = matrix.SolveLeastSquaresRegularized(A, b, p.Lambda)
+	}
+
+	// Map results back to all teams (inactive teams get 0)
+	out := map[int]float64{}
+	for _, t := range p.Teams {
+		out[t] = 0
+	}
+	for i, t := range activeTeams {
+		out[t] = x[i]
+	}
+	return out
+}
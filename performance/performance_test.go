@@ -0,0 +1,62 @@
+package performance
+
+import (
+	"math"
+	"testing"
+)
+
+// roundRobin are the three matches that pair each of four teams with every other team once. Team n contributes 10*n
+// points to its alliance's score, and each alliance gives up 5 points in fouls to its opponent.
+var roundRobin = []Match{
+	{RedTeams: []int{1, 2}, BlueTeams: []int{3, 4}, RedScore: 30, BlueScore: 70, RedPenalties: 5, BluePenalties: 5},
+	{RedTeams: []int{1, 3}, BlueTeams: []int{2, 4}, RedScore: 40, BlueScore: 60, RedPenalties: 5, BluePenalties: 5},
+	{RedTeams: []int{1, 4}, BlueTeams: []int{2, 3}, RedScore: 50, BlueScore: 50, RedPenalties: 5, BluePenalties: 5},
+}
+
+// checkMetric checks the metric of each team is within a small tolerance of what it should be.
+func checkMetric(t *testing.T, name string, got, want map[int]float64) {
+	t.Helper()
+	for team, w := range want {
+		if math.Abs(got[team]-w) > 1e-6 {
+			t.Errorf("%s of team %d = %.4f, want %.4f", name, team, got[team], w)
+		}
+	}
+}
+
+func TestCalculator(t *testing.T) {
+	p := &Calculator{Matches: roundRobin, Teams: []int{1, 2, 3, 4, 5}}
+
+	// Team 5 didn't play, so it has no rating
+	checkMetric(t, "OPR", p.CalculateOPR(), map[int]float64{1: 10, 2: 20, 3: 30, 4: 40, 5: 0})
+	checkMetric(t, "NpOPR", p.CalculateNpOPR(), map[int]float64{1: 7.5, 2: 17.5, 3: 27.5, 4: 37.5})
+	checkMetric(t, "DPR", p.CalculateDPR(), map[int]float64{1: 40, 2: 30, 3: 20, 4: 10, 5: 0})
+	checkMetric(t, "NpDPR", p.CalculateNpDPR(), map[int]float64{1: 37.5, 2: 27.5, 3: 17.5, 4: 7.5})
+	checkMetric(t, "CCWM", p.CalculateCCWM(), map[int]float64{1: -30, 2: -10, 3: 10, 4: 30})
+	if got := p.CalculateNpAVG(nil, 1); math.Abs(got-35) > 1e-6 {
+		t.Errorf("NpAVG of team 1 = %.4f, want 35", got)
+	}
+}
+
+func TestCalculatorRegularized(t *testing.T) {
+	// A single match can't tell alliance partners apart, so only the regularized ratings can be calculated. They
+	// split the alliance's score between the partners, shrunk by lambda: score / (2 + lambda).
+	single := []Match{{RedTeams: []int{1, 2}, BlueTeams: []int{3, 4}, RedScore: 30, BlueScore: 70}}
+	p := &Calculator{Matches: single, Teams: []int{1, 2, 3, 4}, Lambda: 1}
+	checkMetric(t, "OPR", p.CalculateOPR(), map[int]float64{1: 10, 2: 10, 3: 70.0 / 3, 4: 70.0 / 3})
+	checkMetric(t, "DPR", p.CalculateDPR(), map[int]float64{1: 70.0 / 3, 2: 70.0 / 3, 3: 10, 4: 10})
+	checkMetric(t, "CCWM", p.CalculateCCWM(), map[int]float64{1: -40.0 / 3, 2: -40.0 / 3, 3: 40.0 / 3, 4: 40.0 / 3})
+
+	// Regularizing ratings that can be calculated without it shrinks them toward 0
+	norm := func(ratings map[int]float64) float64 {
+		var sum float64
+		for _, r := range ratings {
+			sum += r * r
+		}
+		return math.Sqrt(sum)
+	}
+	plain := (&Calculator{Matches: roundRobin, Teams: []int{1, 2, 3, 4}}).CalculateOPR()
+	ridge := (&Calculator{Matches: roundRobin, Teams: []int{1, 2, 3, 4}, Lambda: 0.5}).CalculateOPR()
+	if norm(ridge) >= norm(plain) {
+		t.Errorf("regularized OPR %v isn't smaller than OPR %v", ridge, plain)
+	}
+}
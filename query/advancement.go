@@ -25,17 +25,22 @@ type TeamAdvancement struct {
 	AdvancementNumber   string // Rank by total points for advancing teams, or "-"
 	Advances            bool
 	Status              string // Status from EventAdvancement (e.g., "already advanced")
+	InspireSlot         bool   // Whether the team takes the Inspire Award's advancement slot ahead of the point order
 }
 
 // AdvancementReport represents an event with all team advancement information.
 type AdvancementReport struct {
 	Event            *database.Event
 	TeamAdvancements []*TeamAdvancement
+	TypicalCutoff    *TypicalCutoff // Cutoff at the season's other events of about the same size, or nil if unknown
 }
 
 // AdvancementReportQuery retrieves advancement information for all teams at an event.
-// It returns an AdvancementReport with teams sorted by their ranking.
+// It returns an AdvancementReport with teams sorted by their ranking, along with the typical cutoff at the
+// season's other events with about the same number of teams.
 func AdvancementReportQuery(eventCode string, year int) (*AdvancementReport, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
 	// Get the event details
 	filter := database.EventFilter{
 		EventCodes: []string{eventCode},
@@ -90,7 +95,12 @@ func AdvancementReportQuery(eventCode string, year int) (*AdvancementReport, err
 	if err != nil {
 		return nil, err
 	}
-	judgingPointsMap := calculateJudgingPoints(awards)
+	rules := AdvancementRulesFor(event.Year)
+	classifier, err := newAwardClassifier(event.Year, rules)
+	if err != nil {
+		return nil, err
+	}
+	judgingPointsMap := calculateJudgingPoints(awards, rules, classifier)
 	playoffPointsMap, err := calculatePlayoffPoints(event)
 	if err != nil {
 		return nil, err
@@ -152,6 +162,13 @@ func AdvancementReportQuery(eventCode string, year int) (*AdvancementReport, err
 		return a.Ranking.Rank - b.Ranking.Rank
 	})
 
+	// The Inspire Award winner may take the first advancement slot regardless of points
+	if rules.InspireFirst {
+		if ta := applyInspireFirst(teamAdvancements, inspirePlaces(awards, classifier)); ta != nil {
+			ta.InspireSlot = true
+		}
+	}
+
 	// Assign advancement numbers and update rank to match sorted order
 	advancementRank := 1
 	for i, ta := range teamAdvancements {
@@ -172,50 +189,55 @@ func AdvancementReportQuery(eventCode string, year int) (*AdvancementReport, err
 		}
 	}
 
+	typicalCutoff, err := TypicalCutoffQuery(event, len(teamAdvancements))
+	if err != nil {
+		return nil, err
+	}
+
 	return &AdvancementReport{
 		Event:            event,
 		TeamAdvancements: teamAdvancements,
+		TypicalCutoff:    typicalCutoff,
 	}, nil
 }
 
-// calculateJudgingPoints calculates judging points based on awards.
-// Points are awarded as follows:
+// calculateJudgingPoints calculates judging points based on awards and the season's advancement rules.
+// By default, points are awarded as follows:
 //
 // - Inspire 1: 60 points, Inspire 2: 30 points, Inspire 3: 15 points
 //
 // - Other judged awards: 1st place (series 1): 12 points, 2nd place (series 2): 6 points, 3rd place (series 3): 3 points
-func calculateJudgingPoints(awards []*database.EventAward) map[int]int {
-	pointsMap := make(map[int]int)
+//
+// The rules may limit a team that wins more than one award to the points for its Inspire Award or its highest
+// judged award, rather than adding them up. Playoff awards and awards that earn no points are skipped.
+func calculateJudgingPoints(awards []*database.EventAward, rules AdvancementRules, ac *awardClassifier) map[int]int {
+	inspireMap := make(map[int]int)
+	judgedMap := make(map[int]int)
 
 	for _, award := range awards {
-		// Skip playoff awards (winning/finalist alliance)
-		if isPlayoffAward(award.Name) {
-			continue
-		}
-
 		// Assign points based on award type and series
-		var points int
-		switch {
-		case containsIgnoreCase(award.Name, "inspire"):
-			switch award.Series {
-			case 1:
-				points = 60
-			case 2:
-				points = 30
-			case 3:
-				points = 15
-			}
-		case isJudgedAward(award.Name):
-			switch award.Series {
-			case 1:
-				points = 12
-			case 2:
-				points = 6
-			case 3:
-				points = 3
+		switch ac.classify(award) {
+		case InspireAward:
+			inspireMap[award.TeamID] += placePoints(rules.InspirePoints, award.Series)
+		case JudgedAward:
+			points := placePoints(rules.JudgedPoints, award.Series)
+			if rules.BestJudgedOnly {
+				judgedMap[award.TeamID] = max(judgedMap[award.TeamID], points)
+			} else {
+				judgedMap[award.TeamID] += points
 			}
 		}
-		pointsMap[award.TeamID] += points
+	}
+
+	pointsMap := make(map[int]int)
+	for teamID, points := range inspireMap {
+		pointsMap[teamID] = points
+	}
+	for teamID, points := range judgedMap {
+		if _, ok := inspireMap[teamID]; ok && rules.InspireOnly {
+			continue
+		}
+		pointsMap[teamID] += points
 	}
 
 	return pointsMap
@@ -225,82 +247,26 @@ func calculateJudgingPoints(awards []*database.EventAward) map[int]int {
 // Points are awarded as follows:
 // - Winning Alliance: 40 points
 // - Finalist Alliance: 20 points
-// - 3rd Place: 10 points (highest scoring losing semifinalist)
-// - 4th Place: 5 points (lowest scoring losing semifinalist)
+// - 3rd Place: 10 points
+// - 4th Place: 5 points
 //
-// This handles both single-elimination and modified double-elimination (winners/losers bracket) formats.
+// The places are determined from the structure of the bracket, so this handles both single-elimination and
+// double-elimination (winners/losers bracket) formats. Every team that played for an alliance, including backup
+// teams, earns the alliance's points.
 func calculatePlayoffPoints(event *database.Event) (map[int]int, error) {
 	pointsMap := make(map[int]int)
 
-	// Get all matches for the event
-	matches, err := db.GetMatchesByEvent(event.EventID)
+	bracket, err := playoffBracketQuery(event)
 	if err != nil {
 		return nil, err
 	}
 
-	// Filter for playoff matches only
-	var playoffMatches []*database.Match
-	for _, match := range matches {
-		if strings.EqualFold(match.TournamentLevel, string(ftc.PLAYOFF)) {
-			playoffMatches = append(playoffMatches, match)
-		}
-	}
-
-	if len(playoffMatches) == 0 {
-		return pointsMap, nil
-	}
-
-	// Sort playoff matches by match number to identify finals (highest number)
-	slices.SortFunc(playoffMatches, func(a, b *database.Match) int {
-		return b.MatchNumber - a.MatchNumber // Descending order
-	})
-
-	for _, match := range playoffMatches {
-		// Get alliance scores for finals
-		redScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceRed)
-		if err != nil {
-			return nil, err
-		}
-		blueScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceBlue)
-		if err != nil {
-			return nil, err
+	for place, alliance := range bracket.finishOrder() {
+		if place >= len(PlayoffFinishes) || !PlayoffFinishes[place].Selected {
+			break
 		}
-
-		if redScore != nil && blueScore != nil {
-			var winningAlliance string
-			if redScore.TotalPoints > blueScore.TotalPoints {
-				winningAlliance = database.AllianceRed
-			} else {
-				winningAlliance = database.AllianceBlue
-			}
-
-			var winningPoints, losingPoints int
-			switch len(pointsMap) {
-			case 0:
-				winningPoints = 40
-				losingPoints = 20
-			case 4:
-				losingPoints = 10
-			case 6:
-				losingPoints = 5
-			default:
-				break
-			}
-
-			// Assign 40 points to winning alliance teams
-			teams, err := db.GetMatchTeams(match.MatchID)
-			if err != nil {
-				return nil, err
-			}
-			for _, mt := range teams {
-				if pointsMap[mt.TeamID] == 0 {
-					if mt.Alliance == winningAlliance {
-						pointsMap[mt.TeamID] = winningPoints
-					} else {
-						pointsMap[mt.TeamID] = losingPoints
-					}
-				}
-			}
+		for _, teamID := range bracket.Alliances[alliance] {
+			pointsMap[teamID] = PlayoffFinishes[place].Points
 		}
 	}
 
@@ -367,7 +333,19 @@ func calculateQualificationPoints(rankings []*database.EventRanking) map[int]int
 		return pointsMap
 	}
 
-	// Sort rankings by ranking score (SortOrder1) in descending order
+	sortedRankings := qualificationOrder(rankings)
+	N := len(sortedRankings)
+	for i, ranking := range sortedRankings {
+		R := i + 1
+		pointsMap[ranking.TeamID] = ftcQualificationPoints(R, N)
+	}
+
+	return pointsMap
+}
+
+// qualificationOrder returns a copy of the rankings sorted by ranking score (SortOrder1) in descending order, which
+// is the order qualification points are awarded in.
+func qualificationOrder(rankings []*database.EventRanking) []*database.EventRanking {
 	sortedRankings := make([]*database.EventRanking, len(rankings))
 	copy(sortedRankings, rankings)
 	slices.SortFunc(sortedRankings, func(a, b *database.EventRanking) int {
@@ -379,41 +357,7 @@ func calculateQualificationPoints(rankings []*database.EventRanking) map[int]int
 		}
 		return 0
 	})
-
-	N := len(sortedRankings)
-	for i, ranking := range sortedRankings {
-		R := i + 1
-		pointsMap[ranking.TeamID] = ftcQualificationPoints(R, N)
-	}
-
-	return pointsMap
-}
-
-// isPlayoffAward returns true if the award is a playoff-related award.
-func isPlayoffAward(awardName string) bool {
-	return containsIgnoreCase(awardName, "winning alliance") ||
-		containsIgnoreCase(awardName, "finalist alliance")
-}
-
-// isJudgedAward returns true if the award is a judged award (not alliance/playoff awards).
-func isJudgedAward(awardName string) bool {
-	// Check if it's a playoff award first
-	if isPlayoffAward(awardName) {
-		return false
-	}
-
-	// Other awards are typically judged
-	return containsIgnoreCase(awardName, "award") ||
-		containsIgnoreCase(awardName, "innovate") ||
-		containsIgnoreCase(awardName, "design") ||
-		containsIgnoreCase(awardName, "control") ||
-		containsIgnoreCase(awardName, "motivate") ||
-		containsIgnoreCase(awardName, "compass") ||
-		containsIgnoreCase(awardName, "promote") ||
-		containsIgnoreCase(awardName, "think") ||
-		containsIgnoreCase(awardName, "connect") ||
-		containsIgnoreCase(awardName, "sustain") ||
-		containsIgnoreCase(awardName, "reach")
+	return sortedRankings
 }
 
 // containsIgnoreCase checks if a string contains a substring (case-insensitive).
@@ -421,9 +365,12 @@ func containsIgnoreCase(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
 
+// qualificationAlpha is the alpha of the FTC Qualification Phase Performance points formula.
+const qualificationAlpha = 1.07
+
 // ftcQualificationPoints computes FTC Qualification Phase Performance points
 func ftcQualificationPoints(rank, teams int) int {
-	alpha := 1.07
+	alpha := qualificationAlpha
 
 	r := float64(rank)
 	n := float64(teams)
@@ -448,6 +395,7 @@ type RegionTeamAdvancement struct {
 	AdvancingEvent           *database.Event        // The event from which the team advanced
 	AdvancingEventAwards     []*database.EventAward // Awards from the advancing event
 	OtherEventParticipations []*EventParticipation  // Other events the team participated in
+	Visiting                 bool                   // True if the team's home region is outside the region it advanced from
 }
 
 // RegionAdvancementReport represents all teams advancing from events in a region.
@@ -458,8 +406,11 @@ type RegionAdvancementReport struct {
 }
 
 // RegionAdvancementQuery retrieves advancement information for all teams advancing in a region.
-// It returns a RegionAdvancementReport with teams sorted by team number.
-func RegionAdvancementQuery(regionCode string, year int) (*RegionAdvancementReport, error) {
+// Teams from other regions that advanced from the region's events are marked as visiting, and are left out if
+// excludeVisitors is true. It returns a RegionAdvancementReport with teams sorted by team number.
+func RegionAdvancementQuery(regionCode string, year int, excludeVisitors bool) (*RegionAdvancementReport, error) {
+	regionCode = database.NormalizeCode(regionCode)
+
 	// Get all events in the region for the given year
 	filter := database.EventFilter{
 		RegionCodes: []string{regionCode},
@@ -581,6 +532,10 @@ func RegionAdvancementQuery(regionCode string, year int) (*RegionAdvancementRepo
 		if team == nil {
 			continue
 		}
+		visiting := team.HomeRegion != regionCode
+		if visiting && excludeVisitors {
+			continue
+		}
 
 		// Get awards from the advancing event
 		var advancingEventAwards []*database.EventAward
@@ -627,6 +582,7 @@ func RegionAdvancementQuery(regionCode string, year int) (*RegionAdvancementRepo
 			AdvancingEvent:           advancingEvent,
 			AdvancingEventAwards:     advancingEventAwards,
 			OtherEventParticipations: otherParticipations,
+			Visiting:                 visiting,
 		})
 	}
 
@@ -665,6 +621,8 @@ type QualifiedTeam struct {
 
 // EventAdvancementSummaryQuery retrieves a summary of all qualified teams organized by their qualifying events.
 func EventAdvancementSummaryQuery(regionCode string, year int) (*EventAdvancementSummary, error) {
+	regionCode = database.NormalizeCode(regionCode)
+
 	// Get all events in the region for the given year
 	filter := database.EventFilter{
 		RegionCodes: []string{regionCode},
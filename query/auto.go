@@ -0,0 +1,29 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+)
+
+// AutoLeaderboardQuery ranks teams by the share of their non-penalty scoring that comes from the autonomous period,
+// from the highest share to the lowest. The teams and their metrics are the same as TeamRankingsQuery's, leaving
+// out the teams whose npOPR isn't positive, as their share isn't meaningful. Teams with the same share are ordered
+// by auto OPR and then team number.
+func AutoLeaderboardQuery(region string, country string, eventCodes []string, year int, includeUnofficial bool) ([]TeamPerformance, error) {
+	performances, err := TeamRankingsQuery(region, country, eventCodes, year, includeUnofficial, false)
+	if err != nil {
+		return nil, err
+	}
+
+	performances = slices.DeleteFunc(performances, func(p TeamPerformance) bool { return p.NpOPR <= 0 })
+	slices.SortFunc(performances, func(a, b TeamPerformance) int {
+		if c := cmp.Compare(b.AutoShare(), a.AutoShare()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.AutoOPR, a.AutoOPR); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+	return performances, nil
+}
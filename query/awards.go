@@ -15,13 +15,17 @@ type TeamAward struct {
 
 // EventAwards represents an event with all team awards.
 type EventAwards struct {
-	Event  *database.Event
-	Awards []*TeamAward
+	Event        *database.Event
+	Awards       []*TeamAward
+	PersonAwards []*TeamAward // Awards given to a person, such as the Dean's List awards, along with the person's team
 }
 
 // AwardsByEventQuery retrieves all awards won by teams at a given event.
-// It returns an EventAwards object containing the event and all awards with full team details.
+// It returns an EventAwards object containing the event and all awards with full team details. The awards given to
+// a person rather than a team are returned apart from the team awards.
 func AwardsByEventQuery(eventCode string, year int) (*EventAwards, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
 	// Get the event details
 	filter := database.EventFilter{
 		EventCodes: []string{eventCode},
@@ -54,27 +58,49 @@ func AwardsByEventQuery(eventCode string, year int) (*EventAwards, error) {
 	}
 	if len(eventAwards) == 0 {
 		return &EventAwards{
-			Event:  event,
-			Awards: []*TeamAward{},
+			Event:        event,
+			Awards:       []*TeamAward{},
+			PersonAwards: []*TeamAward{},
 		}, nil
 	}
+	ac, err := newAwardClassifier(event.Year, AdvancementRulesFor(event.Year))
+	if err != nil {
+		return nil, err
+	}
 
 	// Retrieve the full team details for each award
-	var teamAwards []*TeamAward
+	teamAwards := []*TeamAward{}
+	personAwards := []*TeamAward{}
 	for _, award := range eventAwards {
 		team, err := db.GetTeam(award.TeamID)
 		if err != nil {
 			return nil, err
 		}
-		if team != nil {
-			teamAwards = append(teamAwards, &TeamAward{
-				Award: award,
-				Team:  team,
-			})
+		if team == nil {
+			continue
+		}
+		teamAward := &TeamAward{
+			Award: award,
+			Team:  team,
+		}
+		if ac.forPerson(award) {
+			personAwards = append(personAwards, teamAward)
+		} else {
+			teamAwards = append(teamAwards, teamAward)
 		}
 	}
+	sortTeamAwards(teamAwards)
+	sortTeamAwards(personAwards)
+
+	return &EventAwards{
+		Event:        event,
+		Awards:       teamAwards,
+		PersonAwards: personAwards,
+	}, nil
+}
 
-	// Sort by award ID, then series, then team ID
+// sortTeamAwards sorts awards by their sort priority, then series, then team ID.
+func sortTeamAwards(teamAwards []*TeamAward) {
 	slices.SortFunc(teamAwards, func(a, b *TeamAward) int {
 		// Get sort priorities for each award
 		priorityA := getAwardSortPriority(a.Award.Name)
@@ -93,11 +119,58 @@ func AwardsByEventQuery(eventCode string, year int) (*EventAwards, error) {
 		// Finally, sort by team ID
 		return a.Team.TeamID - b.Team.TeamID
 	})
+}
 
-	return &EventAwards{
-		Event:  event,
-		Awards: teamAwards,
-	}, nil
+// AwardDefinition is one of the season's awards, along with how it counts toward advancement.
+type AwardDefinition struct {
+	AwardID     int
+	Name        string
+	Description string
+	ForPerson   bool       // True if the award is given to a person, such as the Dean's List awards, rather than a team
+	Class       AwardClass // How the award counts toward advancement
+	Points      [3]int     // Advancement points for 1st, 2nd, and 3rd place, if the award carries advancement
+}
+
+// Advancement returns true if the award carries advancement, earning the team that wins it advancement points.
+// The alliance awards don't, as their points are earned in the playoff bracket instead.
+func (d *AwardDefinition) Advancement() bool {
+	return d.Class == InspireAward || d.Class == JudgedAward
+}
+
+// AwardDefinitionsQuery retrieves the season's awards, ordered by award ID, and classifies each under the season's
+// advancement rules in the same way as the awards given at its events.
+func AwardDefinitionsQuery(year int) ([]*AwardDefinition, error) {
+	rules := AdvancementRulesFor(year)
+	ac, err := newAwardClassifier(year, rules)
+	if err != nil {
+		return nil, err
+	}
+	awards, err := db.GetAllAwards()
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]*AwardDefinition, 0, len(awards))
+	for _, award := range awards {
+		definition := &AwardDefinition{
+			AwardID:     award.AwardID,
+			Name:        award.Name,
+			Description: award.Description,
+			ForPerson:   award.ForPerson,
+			Class:       ac.classify(&database.EventAward{AwardID: award.AwardID, Name: award.Name}),
+		}
+		switch definition.Class {
+		case InspireAward:
+			definition.Points = rules.InspirePoints
+		case JudgedAward:
+			definition.Points = rules.JudgedPoints
+		}
+		definitions = append(definitions, definition)
+	}
+	slices.SortFunc(definitions, func(a, b *AwardDefinition) int {
+		return a.AwardID - b.AwardID
+	})
+	return definitions, nil
 }
 
 // getAwardSortPriority returns the sort priority for an award based on its name.
@@ -0,0 +1,202 @@
+package query
+
+import (
+	"slices"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// DefaultBackToBackDays is the most days apart two events can start and still be played on consecutive weekends.
+const DefaultBackToBackDays = 8
+
+// EventPair is two events a team played one after the other, with the change in its npOPR from the first to the
+// second.
+type EventPair struct {
+	TeamID     int
+	TeamName   string
+	First      *database.Event
+	Second     *database.Event
+	Days       int     // Days from the start of the first event to the start of the second
+	FirstNpOPR float64 // Team's npOPR at the first event
+	NpOPR      float64 // Team's npOPR at the second event
+	Delta      float64 // Change in the team's npOPR from the first event to the second
+	BackToBack bool    // True if the events were played on consecutive weekends
+}
+
+// EventPairGroup summarizes the change in npOPR between a group of event pairs.
+type EventPairGroup struct {
+	Pairs        int
+	Teams        int     // Teams that played any of the pairs
+	AverageDays  float64 // Average days between the starts of the events
+	AverageDelta float64 // Average change in npOPR from the first event to the second
+	MedianDelta  float64 // Median change in npOPR from the first event to the second
+	Improved     int     // Pairs in which the team's npOPR went up
+}
+
+// ImprovedPercent returns the percentage of the pairs in which the team's npOPR went up.
+func (g *EventPairGroup) ImprovedPercent() float64 {
+	if g.Pairs == 0 {
+		return 0
+	}
+	return 100 * float64(g.Improved) / float64(g.Pairs)
+}
+
+// BackToBack compares how a region's teams performed at events played on consecutive weekends with how they
+// performed at events with more time between them.
+type BackToBack struct {
+	RegionCode string
+	Year       int
+	Days       int            // Most days apart two events can start and still be back-to-back
+	BackToBack EventPairGroup // Pairs of events played on consecutive weekends
+	Spaced     EventPairGroup // Pairs of events with more time between them
+	Pairs      []*EventPair   // Every pair, from the largest gain in npOPR to the largest loss
+}
+
+// Difference returns how much more a team's npOPR changed, on average, between spaced events than between
+// back-to-back events. A positive difference means teams improved more with the extra time between events.
+func (b *BackToBack) Difference() float64 {
+	return b.Spaced.AverageDelta - b.BackToBack.AverageDelta
+}
+
+// BackToBackQuery compares the change in npOPR of a region's teams between events played on consecutive weekends,
+// whose starts are at most days apart, with the change between events with more time between them. Each team's
+// official events of the season, in and out of the region, are ordered by date, and every event is paired with the
+// team's next one. Events at which the team didn't play a match aren't included. If days isn't positive,
+// DefaultBackToBackDays is used.
+func BackToBackQuery(regionCode string, year int, days int) (*BackToBack, error) {
+	if days <= 0 {
+		days = DefaultBackToBackDays
+	}
+	result := &BackToBack{RegionCode: database.NormalizeCode(regionCode), Year: year, Days: days}
+
+	teams, err := db.GetAllTeams(database.TeamFilter{HomeRegions: []string{result.RegionCode}})
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return result, nil
+	}
+	teamMap := make(map[int]*database.Team, len(teams))
+	teamIDs := make([]int, 0, len(teams))
+	for _, team := range teams {
+		teamMap[team.TeamID] = team
+		teamIDs = append(teamIDs, team.TeamID)
+	}
+
+	events, err := getRankedEvents(database.EventFilter{Year: year}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	eventMap := make(map[string]*database.Event, len(events))
+	eventIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		if TierOf(event) == TierNone {
+			continue
+		}
+		eventMap[event.EventID] = event
+		eventIDs = append(eventIDs, event.EventID)
+	}
+	if len(eventIDs) == 0 {
+		return result, nil
+	}
+
+	rankings, err := db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: teamIDs, EventIDs: eventIDs})
+	if err != nil {
+		return nil, err
+	}
+	teamRankings := make(map[int][]*database.TeamRanking)
+	for _, ranking := range rankings {
+		if ranking.NumMatches == 0 || eventMap[ranking.EventID] == nil {
+			continue
+		}
+		teamRankings[ranking.TeamID] = append(teamRankings[ranking.TeamID], ranking)
+	}
+
+	for teamID, rankings := range teamRankings {
+		slices.SortFunc(rankings, func(a, b *database.TeamRanking) int {
+			return eventMap[a.EventID].DateStart.Compare(eventMap[b.EventID].DateStart)
+		})
+		for i := 1; i < len(rankings); i++ {
+			first, second := eventMap[rankings[i-1].EventID], eventMap[rankings[i].EventID]
+			pair := &EventPair{
+				TeamID:     teamID,
+				TeamName:   teamMap[teamID].Name,
+				First:      first,
+				Second:     second,
+				Days:       daysBetween(first.DateStart, second.DateStart),
+				FirstNpOPR: rankings[i-1].NpOPR,
+				NpOPR:      rankings[i].NpOPR,
+				Delta:      rankings[i].NpOPR - rankings[i-1].NpOPR,
+			}
+			// A division and its parent event start on the same day, and are counted as one event
+			if pair.Days == 0 {
+				continue
+			}
+			pair.BackToBack = pair.Days <= days
+			result.Pairs = append(result.Pairs, pair)
+		}
+	}
+	slices.SortFunc(result.Pairs, func(a, b *EventPair) int {
+		if a.Delta != b.Delta {
+			if a.Delta > b.Delta {
+				return -1
+			}
+			return 1
+		}
+		if a.TeamID != b.TeamID {
+			return a.TeamID - b.TeamID
+		}
+		return a.First.DateStart.Compare(b.First.DateStart)
+	})
+
+	var backToBack, spaced []*EventPair
+	for _, pair := range result.Pairs {
+		if pair.BackToBack {
+			backToBack = append(backToBack, pair)
+		} else {
+			spaced = append(spaced, pair)
+		}
+	}
+	result.BackToBack = summarizeEventPairs(backToBack)
+	result.Spaced = summarizeEventPairs(spaced)
+	return result, nil
+}
+
+// summarizeEventPairs summarizes the change in npOPR between the event pairs.
+func summarizeEventPairs(pairs []*EventPair) EventPairGroup {
+	group := EventPairGroup{Pairs: len(pairs)}
+	if len(pairs) == 0 {
+		return group
+	}
+	teams := make(map[int]bool)
+	deltas := make([]float64, 0, len(pairs))
+	for _, pair := range pairs {
+		teams[pair.TeamID] = true
+		deltas = append(deltas, pair.Delta)
+		group.AverageDays += float64(pair.Days)
+		group.AverageDelta += pair.Delta
+		if pair.Delta > 0 {
+			group.Improved++
+		}
+	}
+	group.Teams = len(teams)
+	group.AverageDays /= float64(len(pairs))
+	group.AverageDelta /= float64(len(pairs))
+
+	slices.Sort(deltas)
+	mid := len(deltas) / 2
+	if len(deltas)%2 == 0 {
+		group.MedianDelta = (deltas[mid-1] + deltas[mid]) / 2
+	} else {
+		group.MedianDelta = deltas[mid]
+	}
+	return group
+}
+
+// daysBetween returns the number of calendar days from one date to a later one.
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,223 @@
+package query
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// playoffSeries is the result of one series of a playoff bracket. Playoff matches are saved by series, so a
+// series played as a best of three, such as the finals, is represented by its last match, whose winner won the
+// series.
+type playoffSeries struct {
+	Number    int
+	Red       int // Alliance on the red side, as an index into the bracket's alliances
+	Blue      int // Alliance on the blue side, as an index into the bracket's alliances
+	RedScore  int
+	BlueScore int
+}
+
+// winner returns the alliance that won the series, or -1 if the series was tied.
+func (s playoffSeries) winner() int {
+	switch {
+	case s.RedScore > s.BlueScore:
+		return s.Red
+	case s.BlueScore > s.RedScore:
+		return s.Blue
+	}
+	return -1
+}
+
+// loser returns the alliance that lost the series, or -1 if the series was tied.
+func (s playoffSeries) loser() int {
+	switch {
+	case s.RedScore > s.BlueScore:
+		return s.Blue
+	case s.BlueScore > s.RedScore:
+		return s.Red
+	}
+	return -1
+}
+
+// score returns the alliance's score in the series.
+func (s playoffSeries) score(alliance int) int {
+	if alliance == s.Red {
+		return s.RedScore
+	}
+	return s.BlueScore
+}
+
+// playoffBracket is the structure of an event's playoffs: the alliances, and the series they played in the order
+// they were played. It supports both the double-elimination brackets used since the 2023 season, where an
+// alliance is eliminated by its second loss, and the single-elimination brackets of earlier seasons.
+type playoffBracket struct {
+	Alliances [][]int // Teams on each alliance, including any backup teams that played
+	Series    []playoffSeries
+}
+
+// playoffBracketQuery builds the playoff bracket of an event from its saved playoff matches. Teams that played on
+// the same side of a playoff match are on the same alliance, so alliances that rotate their teams between matches
+// are still recognized. Matches without scores for both alliances aren't included.
+func playoffBracketQuery(event *database.Event) (*playoffBracket, error) {
+	matches, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	type sides struct {
+		number    int
+		red, blue []int
+		redScore  int
+		blueScore int
+	}
+	var played []sides
+	parent := make(map[int]int) // Union-find of the teams on each alliance
+	var find func(team int) int
+	find = func(team int) int {
+		if parent[team] != team {
+			parent[team] = find(parent[team])
+		}
+		return parent[team]
+	}
+	union := func(teams []int) {
+		for _, team := range teams {
+			if _, ok := parent[team]; !ok {
+				parent[team] = team
+			}
+			parent[find(team)] = find(teams[0])
+		}
+	}
+
+	for _, match := range matches {
+		if !strings.EqualFold(match.TournamentLevel, string(ftc.PLAYOFF)) {
+			continue
+		}
+		redScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceRed)
+		if err != nil {
+			return nil, err
+		}
+		blueScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceBlue)
+		if err != nil {
+			return nil, err
+		}
+		if redScore == nil || blueScore == nil {
+			continue
+		}
+		teams, err := db.GetMatchTeams(match.MatchID)
+		if err != nil {
+			return nil, err
+		}
+
+		s := sides{number: match.MatchNumber, redScore: redScore.TotalPoints, blueScore: blueScore.TotalPoints}
+		for _, mt := range teams {
+			if mt.Alliance == database.AllianceRed {
+				s.red = append(s.red, mt.TeamID)
+			} else {
+				s.blue = append(s.blue, mt.TeamID)
+			}
+		}
+		if len(s.red) == 0 || len(s.blue) == 0 {
+			continue
+		}
+		union(s.red)
+		union(s.blue)
+		played = append(played, s)
+	}
+
+	// Number the alliances in the order they first played, and list the teams on each
+	bracket := &playoffBracket{}
+	slices.SortFunc(played, func(a, b sides) int {
+		return cmp.Compare(a.number, b.number)
+	})
+	allianceOf := make(map[int]int)
+	alliance := func(team int) int {
+		root := find(team)
+		if i, ok := allianceOf[root]; ok {
+			return i
+		}
+		allianceOf[root] = len(bracket.Alliances)
+		bracket.Alliances = append(bracket.Alliances, nil)
+		return allianceOf[root]
+	}
+	for _, s := range played {
+		bracket.Series = append(bracket.Series, playoffSeries{
+			Number:    s.number,
+			Red:       alliance(s.red[0]),
+			Blue:      alliance(s.blue[0]),
+			RedScore:  s.redScore,
+			BlueScore: s.blueScore,
+		})
+	}
+	for _, team := range slices.Sorted(maps.Keys(parent)) {
+		i := alliance(team)
+		bracket.Alliances[i] = append(bracket.Alliances[i], team)
+	}
+
+	return bracket, nil
+}
+
+// doubleElimination returns true if any alliance played again after losing a series, which only happens in a
+// double-elimination bracket.
+func (b *playoffBracket) doubleElimination() bool {
+	lost := make(map[int]bool)
+	for _, s := range b.Series {
+		if lost[s.Red] || lost[s.Blue] {
+			return true
+		}
+		if loser := s.loser(); loser >= 0 {
+			lost[loser] = true
+		}
+	}
+	return false
+}
+
+// finishOrder returns the alliances in the order they finished, starting with the winner, for as many places as
+// the bracket decides. The last series is the final, so its winner is first and its loser second. In a
+// double-elimination bracket, the remaining alliances are placed by how late they were eliminated, which is the
+// last series each one played. In a single-elimination bracket, the alliances the finalists beat in the round
+// before the final are placed third and fourth by their score in that series.
+func (b *playoffBracket) finishOrder() []int {
+	if len(b.Series) == 0 {
+		return nil
+	}
+	final := b.Series[len(b.Series)-1]
+	if final.winner() < 0 {
+		return nil
+	}
+	order := []int{final.winner(), final.loser()}
+
+	if b.doubleElimination() {
+		// Walking back from the final, an alliance losing a series it didn't play after was eliminated by it
+		seen := map[int]bool{final.Red: true, final.Blue: true}
+		for i := len(b.Series) - 2; i >= 0; i-- {
+			s := b.Series[i]
+			if loser := s.loser(); loser >= 0 && !seen[loser] {
+				order = append(order, loser)
+			}
+			seen[s.Red], seen[s.Blue] = true, true
+		}
+		return order
+	}
+
+	// Find the alliance each finalist beat in the last series it won before the final
+	var semifinals []playoffSeries
+	for _, finalist := range order {
+		for i := len(b.Series) - 2; i >= 0; i-- {
+			if s := b.Series[i]; s.winner() == finalist {
+				semifinals = append(semifinals, s)
+				break
+			}
+		}
+	}
+	slices.SortStableFunc(semifinals, func(x, y playoffSeries) int {
+		return cmp.Compare(y.score(y.loser()), x.score(x.loser()))
+	})
+	for _, s := range semifinals {
+		order = append(order, s.loser())
+	}
+	return order
+}
@@ -0,0 +1,254 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// playedSeries is the deciding match of a playoff series, with the teams that played on each side.
+type playedSeries struct {
+	red, blue           []int
+	redScore, blueScore int
+}
+
+// saveBracket saves the series as the playoff matches of an event, numbered in the order given, and returns the
+// event. The qualification matches of the event are left out, as they don't affect the bracket.
+func saveBracket(t *testing.T, eventID string, series []playedSeries) *database.Event {
+	t.Helper()
+	for i, s := range series {
+		matchID := fmt.Sprintf("%s-P%d", eventID, i+1)
+		if err := db.SaveMatch(&database.Match{
+			MatchID:         matchID,
+			EventID:         eventID,
+			MatchNumber:     i + 1,
+			TournamentLevel: string(ftc.PLAYOFF),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		for alliance, score := range map[string]int{database.AllianceRed: s.redScore, database.AllianceBlue: s.blueScore} {
+			if err := db.SaveMatchAllianceScore(&database.MatchAllianceScore{MatchID: matchID, Alliance: alliance, TotalPoints: score}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for alliance, teams := range map[string][]int{database.AllianceRed: s.red, database.AllianceBlue: s.blue} {
+			for _, team := range teams {
+				if err := db.SaveMatchTeam(&database.MatchTeam{MatchID: matchID, TeamID: team, Alliance: alliance, OnField: true}); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	return &database.Event{EventID: eventID}
+}
+
+// Alliances used in the brackets, each given by its teams. The captain has the lowest team number.
+var (
+	a1 = []int{101, 102}
+	a2 = []int{201, 202}
+	a3 = []int{301, 302}
+	a4 = []int{401, 402}
+	a5 = []int{501, 502}
+	a6 = []int{601, 602}
+	a7 = []int{701, 702}
+	a8 = []int{801, 802}
+)
+
+func TestFinishOrder(t *testing.T) {
+	t.Setenv("DB_TYPE", "file")
+	t.Setenv("FILEDB_DATA_DIR", t.TempDir())
+	testDB, err := database.Init("2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	Init(testDB)
+
+	tests := []struct {
+		name   string
+		series []playedSeries
+		want   []int // Captains of the alliances in the order they finished
+	}{
+		{
+			// Eight alliances in the single-elimination bracket played before the 2023 season. The semifinal losers
+			// are placed by their score in the semifinal.
+			name: "single elimination",
+			series: []playedSeries{
+				{red: a1, blue: a8, redScore: 212, blueScore: 98},
+				{red: a4, blue: a5, redScore: 143, blueScore: 151},
+				{red: a2, blue: a7, redScore: 188, blueScore: 120},
+				{red: a3, blue: a6, redScore: 176, blueScore: 160},
+				{red: a1, blue: a5, redScore: 205, blueScore: 131},
+				{red: a2, blue: a3, redScore: 190, blueScore: 194},
+				{red: a1, blue: a3, redScore: 201, blueScore: 187},
+			},
+			want: []int{101, 301, 201, 501},
+		},
+		{
+			// Four alliances in the double-elimination bracket played since the 2023 season: the winners of the
+			// first round meet in the upper bracket, its loser plays the winner of the lower bracket, and the finals
+			// are played by the winners of the upper and lower brackets.
+			name: "double elimination",
+			series: []playedSeries{
+				{red: a1, blue: a4, redScore: 230, blueScore: 142},
+				{red: a2, blue: a3, redScore: 171, blueScore: 183},
+				{red: a4, blue: a2, redScore: 139, blueScore: 165},
+				{red: a1, blue: a3, redScore: 204, blueScore: 210},
+				{red: a1, blue: a2, redScore: 226, blueScore: 180},
+				{red: a3, blue: a1, redScore: 198, blueScore: 215},
+			},
+			want: []int{101, 301, 201, 401},
+		},
+		{
+			// The same bracket, with the first alliance bringing in its backup team for the finals
+			name: "double elimination with a backup team",
+			series: []playedSeries{
+				{red: a1, blue: a4, redScore: 230, blueScore: 142},
+				{red: a2, blue: a3, redScore: 171, blueScore: 183},
+				{red: a4, blue: a2, redScore: 139, blueScore: 165},
+				{red: a1, blue: a3, redScore: 204, blueScore: 210},
+				{red: a1, blue: a2, redScore: 226, blueScore: 180},
+				{red: a3, blue: []int{101, 103}, redScore: 198, blueScore: 215},
+			},
+			want: []int{101, 301, 201, 401},
+		},
+		{
+			// A final that ended in a tie doesn't decide any places
+			name: "tied final",
+			series: []playedSeries{
+				{red: a1, blue: a4, redScore: 230, blueScore: 142},
+				{red: a2, blue: a3, redScore: 171, blueScore: 183},
+				{red: a4, blue: a2, redScore: 139, blueScore: 165},
+				{red: a1, blue: a3, redScore: 204, blueScore: 210},
+				{red: a1, blue: a2, redScore: 226, blueScore: 180},
+				{red: a3, blue: a1, redScore: 201, blueScore: 201},
+			},
+			want: nil,
+		},
+		{
+			name: "no playoffs",
+			want: nil,
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := saveBracket(t, fmt.Sprintf("2025-BRACKET%d", i+1), test.series)
+			bracket, err := playoffBracketQuery(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int
+			for _, alliance := range bracket.finishOrder() {
+				got = append(got, bracket.Alliances[alliance][0])
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("finish order = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// recordedBracket is the playoffs of a real event, as recorded in testdata/brackets. Matches is the response of the
+// FTC Events API's /v2.0/{season}/matches/{eventCode}?tournamentLevel=playoff request, saved as it was returned, and
+// FinishOrder is a team of each alliance, such as its captain, in the order the event's published results placed the
+// alliances.
+type recordedBracket struct {
+	Event       string       `json:"event"`  // Season and event code, such as 2024 USNCCMP
+	Source      string       `json:"source"` // Where the finish order was published
+	FinishOrder []int        `json:"finishOrder"`
+	Matches     []*ftc.Match `json:"matches"`
+}
+
+// series returns the deciding match of each playoff series in the order they were played, as the sync saves them:
+// a series is saved by its number, so the last match of a series replaces the ones before it.
+func (b *recordedBracket) series() []playedSeries {
+	deciding := make(map[int]*ftc.Match)
+	for _, m := range b.Matches {
+		if !strings.EqualFold(m.TournamentLevel, string(ftc.PLAYOFF)) {
+			continue
+		}
+		if last, ok := deciding[m.Series]; !ok || m.MatchNumber > last.MatchNumber {
+			deciding[m.Series] = m
+		}
+	}
+	numbers := make([]int, 0, len(deciding))
+	for number := range deciding {
+		numbers = append(numbers, number)
+	}
+	slices.Sort(numbers)
+
+	var series []playedSeries
+	for _, number := range numbers {
+		m := deciding[number]
+		s := playedSeries{redScore: m.ScoreRedFinal, blueScore: m.ScoreBlueFinal}
+		for _, team := range m.Teams {
+			if strings.HasPrefix(strings.ToLower(team.Station), "red") {
+				s.red = append(s.red, team.TeamNumber)
+			} else {
+				s.blue = append(s.blue, team.TeamNumber)
+			}
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+// TestFinishOrderOfRecordedEvents checks the finish order of the playoffs of the real events recorded in
+// testdata/brackets against their published results. Each file holds a recordedBracket, so another event is checked
+// by saving its playoff matches from the FTC Events API, along with its published finish order, to a new file.
+func TestFinishOrderOfRecordedEvents(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "brackets", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no events are recorded in testdata/brackets")
+	}
+
+	t.Setenv("DB_TYPE", "file")
+	t.Setenv("FILEDB_DATA_DIR", t.TempDir())
+	testDB, err := database.Init("2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	Init(testDB)
+
+	for i, file := range files {
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".json"), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var recorded recordedBracket
+			if err := json.Unmarshal(data, &recorded); err != nil {
+				t.Fatal(err)
+			}
+
+			event := saveBracket(t, fmt.Sprintf("RECORDED%d", i+1), recorded.series())
+			bracket, err := playoffBracketQuery(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The finish order may list more places than the bracket decides, such as the alliances knocked out in
+			// the first round, so only the places it decides are compared
+			order := bracket.finishOrder()
+			if len(order) == 0 {
+				t.Fatalf("%s: no finish order, want %v", recorded.Event, recorded.FinishOrder)
+			}
+			for place, alliance := range order[:min(len(order), len(recorded.FinishOrder))] {
+				if !slices.Contains(bracket.Alliances[alliance], recorded.FinishOrder[place]) {
+					t.Errorf("%s: place %d is the alliance of %v, want the alliance of %d from %s",
+						recorded.Event, place+1, bracket.Alliances[alliance], recorded.FinishOrder[place], recorded.Source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package query
+
+import (
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// AdvancementBreakdown is a team's advancement points at an event, along with the inputs each kind of points is
+// calculated from.
+type AdvancementBreakdown struct {
+	Event         *database.Event
+	Advancement   *TeamAdvancement // Team's points and advancement, or nil if the team isn't ranked at the event
+	Qualification QualificationInputs
+	Judging       JudgingInputs
+	Playoff       PlayoffInputs
+	Selection     SelectionInputs
+}
+
+// QualificationInputs are the inputs to a team's qualification points: its position when the event's teams are
+// ordered by ranking score, out of the number of ranked teams, and the alpha of the FTC Qualification Phase
+// Performance formula.
+type QualificationInputs struct {
+	Position int
+	Teams    int
+	Alpha    float64
+}
+
+// JudgedAwardPoints is a judged award a team won, and the judging points it is worth.
+type JudgedAwardPoints struct {
+	Name    string
+	Place   int  // 1st, 2nd, or 3rd place
+	Points  int  // Judging points for the award and place
+	Counted bool // Whether the points count toward the team's total under the season's rules
+}
+
+// JudgingInputs are the judged awards a team won at the event and the season's rules for combining them.
+type JudgingInputs struct {
+	Awards         []JudgedAwardPoints
+	InspireOnly    bool // An Inspire Award winner earns the points for that award alone
+	BestJudgedOnly bool // A team that wins more than one other judged award earns the points for the highest one
+}
+
+// PlayoffInputs are how far a team's alliance went in the playoffs.
+type PlayoffInputs struct {
+	Place    int    // 1 for the winning alliance through 4 for 4th place, or 0 if the team didn't place
+	Finish   string // Name of the finish, such as "Finalist", or "Eliminated" or "Not Selected"
+	Alliance []int  // Teams that played for the team's alliance
+}
+
+// SelectionInputs are the alliance a team was selected for.
+type SelectionInputs struct {
+	Alliance int // Alliance number, or 0 if the team wasn't on an alliance
+}
+
+// AdvancementBreakdownQuery returns a team's advancement points at an event and the inputs they are calculated
+// from. The points are the same as in the event's advancement report. It returns nil if the event isn't found,
+// and a breakdown without an advancement if the team isn't ranked at the event.
+func AdvancementBreakdownQuery(eventCode string, teamID int, year int) (*AdvancementBreakdown, error) {
+	report, err := AdvancementReportQuery(eventCode, year)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil || report.Event == nil {
+		return nil, nil
+	}
+	event := report.Event
+
+	breakdown := &AdvancementBreakdown{Event: event}
+	for _, ta := range report.TeamAdvancements {
+		if ta.Team.TeamID == teamID {
+			breakdown.Advancement = ta
+			break
+		}
+	}
+	if breakdown.Advancement == nil {
+		return breakdown, nil
+	}
+
+	// Qualification points
+	rankings, err := db.GetEventRankings(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	sortedRankings := qualificationOrder(rankings)
+	breakdown.Qualification = QualificationInputs{
+		Position: slices.IndexFunc(sortedRankings, func(er *database.EventRanking) bool { return er.TeamID == teamID }) + 1,
+		Teams:    len(sortedRankings),
+		Alpha:    qualificationAlpha,
+	}
+
+	// Judging points
+	awards, err := db.GetTeamAwardsByEvent(event.EventID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	rules := AdvancementRulesFor(event.Year)
+	classifier, err := newAwardClassifier(event.Year, rules)
+	if err != nil {
+		return nil, err
+	}
+	breakdown.Judging = judgingInputs(awards, rules, classifier)
+
+	// Playoff points
+	bracket, err := playoffBracketQuery(event)
+	if err != nil {
+		return nil, err
+	}
+	breakdown.Playoff.Finish = PlayoffFinishes[len(PlayoffFinishes)-1].Name
+	for i, teams := range bracket.Alliances {
+		if !slices.Contains(teams, teamID) {
+			continue
+		}
+		breakdown.Playoff.Alliance = teams
+		breakdown.Playoff.Finish = "Eliminated"
+		if place := slices.Index(bracket.finishOrder(), i); place >= 0 && place < len(PlayoffFinishes) && PlayoffFinishes[place].Points > 0 {
+			breakdown.Playoff.Place = place + 1
+			breakdown.Playoff.Finish = PlayoffFinishes[place].Name
+		}
+	}
+
+	// Selection points are 20 for the 1st alliance and one less for each alliance after it
+	if points := breakdown.Advancement.SelectionPoints; points > 0 {
+		breakdown.Selection.Alliance = 21 - points
+	}
+
+	return breakdown, nil
+}
+
+// judgingInputs returns the judging points of each judged award a team won, marking the awards whose points count
+// under the season's rules in the same way as calculateJudgingPoints.
+func judgingInputs(awards []*database.EventAward, rules AdvancementRules, ac *awardClassifier) JudgingInputs {
+	inputs := JudgingInputs{
+		InspireOnly:    rules.InspireOnly,
+		BestJudgedOnly: rules.BestJudgedOnly,
+	}
+
+	wonInspire := false
+	best := -1         // Index of the highest scoring judged award other than the Inspire Award
+	var inspire []bool // Whether each of the awards is an Inspire Award
+	for _, award := range awards {
+		switch ac.classify(award) {
+		case InspireAward:
+			wonInspire = true
+			inspire = append(inspire, true)
+			inputs.Awards = append(inputs.Awards, JudgedAwardPoints{
+				Name:    award.Name,
+				Place:   award.Series,
+				Points:  placePoints(rules.InspirePoints, award.Series),
+				Counted: true,
+			})
+		case JudgedAward:
+			points := placePoints(rules.JudgedPoints, award.Series)
+			inspire = append(inspire, false)
+			inputs.Awards = append(inputs.Awards, JudgedAwardPoints{
+				Name:    award.Name,
+				Place:   award.Series,
+				Points:  points,
+				Counted: !rules.BestJudgedOnly,
+			})
+			if best < 0 || points > inputs.Awards[best].Points {
+				best = len(inputs.Awards) - 1
+			}
+		}
+	}
+	if rules.BestJudgedOnly && best >= 0 {
+		inputs.Awards[best].Counted = true
+	}
+	if rules.InspireOnly && wonInspire {
+		for i := range inputs.Awards {
+			if !inspire[i] {
+				inputs.Awards[i].Counted = false
+			}
+		}
+	}
+	return inputs
+}
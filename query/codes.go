@@ -0,0 +1,238 @@
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// CodeIndex is a lookup of the region codes for a season and the event codes within each region. It is small
+// enough to be cached, so region and event codes can be looked up without loading the full database.
+type CodeIndex struct {
+	Season  string              `json:"season"`
+	Updated time.Time           `json:"updated"`
+	Regions map[string][]string `json:"regions"` // Region code to the event codes in that region
+}
+
+// CodeIndexQuery builds a lookup of all region codes and the event codes within each region.
+func CodeIndexQuery(season string) (*CodeIndex, error) {
+	regionCodes, err := db.GetRegionCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	index := &CodeIndex{
+		Season:  season,
+		Updated: time.Now().UTC(),
+		Regions: make(map[string][]string, len(regionCodes)),
+	}
+	for _, regionCode := range regionCodes {
+		eventCodes, err := db.GetEventCodesByRegion(regionCode)
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(eventCodes)
+		index.Regions[regionCode] = eventCodes
+	}
+
+	return index, nil
+}
+
+// RegionCodes returns the sorted list of region codes.
+func (ci *CodeIndex) RegionCodes() []string {
+	regionCodes := make([]string, 0, len(ci.Regions))
+	for regionCode := range ci.Regions {
+		regionCodes = append(regionCodes, regionCode)
+	}
+	slices.Sort(regionCodes)
+	return regionCodes
+}
+
+// EventCodes returns the sorted list of event codes in the given region, or in all regions if the region is empty.
+// Region codes are matched without regard to case.
+func (ci *CodeIndex) EventCodes(region string) []string {
+	var eventCodes []string
+	for regionCode, codes := range ci.Regions {
+		if region == "" || strings.EqualFold(regionCode, region) {
+			eventCodes = append(eventCodes, codes...)
+		}
+	}
+	slices.Sort(eventCodes)
+	return slices.Compact(eventCodes)
+}
+
+// maxSuggestions is the most known codes suggested for a code that isn't found.
+const maxSuggestions = 3
+
+// maxSuggestionDistance is the most single-character edits needed to turn a code that isn't found into a known
+// code for the known code to be suggested.
+const maxSuggestionDistance = 2
+
+// NotFoundError is returned for an event or region code that doesn't match any known code, or a season whose rules
+// aren't known. It includes the known codes closest to the code, so the user can be asked whether they meant one of
+// them.
+type NotFoundError struct {
+	Kind        string   // "event", "region", or "season"
+	Code        string   // The code that wasn't found
+	Year        int      // The year searched for an event; 0 for a region or season
+	Suggestions []string // The known codes closest to the code, closest first
+}
+
+// Error returns a message that the code wasn't found, along with any suggested codes.
+func (e *NotFoundError) Error() string {
+	message := fmt.Sprintf("%s %s not found", e.Kind, e.Code)
+	if e.Year > 0 {
+		message += fmt.Sprintf(" for %d", e.Year)
+	}
+	if hint := DidYouMean(e.Suggestions); hint != "" {
+		message += "; " + hint
+	}
+	return message
+}
+
+// EventNotFound returns a NotFoundError for an event code that doesn't match any event in the year, suggesting
+// the event codes in the year that are closest to it. An error reading the event codes is returned in its place.
+func EventNotFound(eventCode string, year int) error {
+	eventCode = database.NormalizeCode(eventCode)
+
+	events, err := db.GetAllEvents(database.EventFilter{Year: year})
+	if err != nil {
+		return err
+	}
+	eventCodes := make([]string, 0, len(events))
+	for _, event := range events {
+		eventCodes = append(eventCodes, event.EventCode)
+	}
+
+	return &NotFoundError{
+		Kind:        "event",
+		Code:        eventCode,
+		Year:        year,
+		Suggestions: SuggestCodes(eventCode, eventCodes),
+	}
+}
+
+// RegionNotFound returns a NotFoundError for a region code that doesn't match any region, suggesting the region
+// codes that are closest to it. An error reading the region codes is returned in its place.
+func RegionNotFound(regionCode string) error {
+	regionCode = database.NormalizeCode(regionCode)
+
+	regionCodes, err := db.GetRegionCodes()
+	if err != nil {
+		return err
+	}
+
+	return &NotFoundError{
+		Kind:        "region",
+		Code:        regionCode,
+		Suggestions: SuggestCodes(regionCode, regionCodes),
+	}
+}
+
+// SuggestCodes returns up to three of the known codes that are closest to a code that isn't found, closest first.
+// A known code is close if it can be made from the code with at most two single-character edits, or if it
+// contains the code, so both "USNCRQA" and "NCRAQ" suggest "USNCRAQ".
+func SuggestCodes(code string, known []string) []string {
+	code = database.NormalizeCode(code)
+	if code == "" {
+		return nil
+	}
+
+	type candidate struct {
+		code     string
+		distance int
+	}
+	var candidates []candidate
+	for _, knownCode := range known {
+		if knownCode == code {
+			continue
+		}
+		distance := editDistance(code, knownCode)
+		if distance > maxSuggestionDistance && (len(code) < 3 || !strings.Contains(knownCode, code)) {
+			continue
+		}
+		candidates = append(candidates, candidate{code: knownCode, distance: distance})
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return cmp.Compare(a.distance, b.distance)
+		}
+		return cmp.Compare(a.code, b.code)
+	})
+	candidates = slices.Compact(candidates)
+
+	suggestions := make([]string, 0, min(len(candidates), maxSuggestions))
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		suggestions = append(suggestions, c.code)
+	}
+	return suggestions
+}
+
+// DidYouMean returns a question asking whether one of the suggested codes was meant, such as "did you mean
+// USNCRAQ or USNCRAL?", or an empty string if there are no suggestions.
+func DidYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("did you mean %s?", suggestions[0])
+	case 2:
+		return fmt.Sprintf("did you mean %s or %s?", suggestions[0], suggestions[1])
+	}
+	last := len(suggestions) - 1
+	return fmt.Sprintf("did you mean %s, or %s?", strings.Join(suggestions[:last], ", "), suggestions[last])
+}
+
+// editDistance returns the number of single-character insertions, deletions, and substitutions needed to turn
+// one code into another.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// normalizeEventFilter returns a copy of the event filter with its event and region codes normalized.
+func normalizeEventFilter(filter database.EventFilter) database.EventFilter {
+	filter.EventCodes = database.NormalizeCodes(filter.EventCodes)
+	filter.RegionCodes = database.NormalizeCodes(filter.RegionCodes)
+	return filter
+}
+
+// normalizeEventFilters returns copies of the event filters with their event and region codes normalized.
+func normalizeEventFilters(filters []database.EventFilter) []database.EventFilter {
+	normalized := make([]database.EventFilter, 0, len(filters))
+	for _, filter := range filters {
+		normalized = append(normalized, normalizeEventFilter(filter))
+	}
+	return normalized
+}
+
+// normalizeTeamFilters returns copies of the team filters with their home region and event codes normalized.
+func normalizeTeamFilters(filters []database.TeamFilter) []database.TeamFilter {
+	normalized := make([]database.TeamFilter, 0, len(filters))
+	for _, filter := range filters {
+		filter.HomeRegions = database.NormalizeCodes(filter.HomeRegions)
+		filter.EventCodes = database.NormalizeCodes(filter.EventCodes)
+		normalized = append(normalized, filter)
+	}
+	return normalized
+}
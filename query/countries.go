@@ -0,0 +1,150 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// countryCodes are the ISO 3166-1 alpha-2 codes of the countries teams and events are in, keyed by the lower case
+// name the FTC Events API gives the country.
+var countryCodes = map[string]string{
+	"argentina":            "AR",
+	"australia":            "AU",
+	"azerbaijan":           "AZ",
+	"bangladesh":           "BD",
+	"brazil":               "BR",
+	"bulgaria":             "BG",
+	"canada":               "CA",
+	"chile":                "CL",
+	"china":                "CN",
+	"chinese taipei":       "TW",
+	"colombia":             "CO",
+	"cyprus":               "CY",
+	"czech republic":       "CZ",
+	"dominican republic":   "DO",
+	"egypt":                "EG",
+	"france":               "FR",
+	"germany":              "DE",
+	"ghana":                "GH",
+	"greece":               "GR",
+	"hong kong":            "HK",
+	"india":                "IN",
+	"indonesia":            "ID",
+	"israel":               "IL",
+	"jamaica":              "JM",
+	"japan":                "JP",
+	"jordan":               "JO",
+	"kazakhstan":           "KZ",
+	"kenya":                "KE",
+	"korea":                "KR",
+	"lebanon":              "LB",
+	"libya":                "LY",
+	"macau":                "MO",
+	"malaysia":             "MY",
+	"mexico":               "MX",
+	"moldova":              "MD",
+	"mongolia":             "MN",
+	"morocco":              "MA",
+	"netherlands":          "NL",
+	"new zealand":          "NZ",
+	"nigeria":              "NG",
+	"pakistan":             "PK",
+	"panama":               "PA",
+	"paraguay":             "PY",
+	"peru":                 "PE",
+	"philippines":          "PH",
+	"poland":               "PL",
+	"puerto rico":          "PR",
+	"qatar":                "QA",
+	"romania":              "RO",
+	"russia":               "RU",
+	"saudi arabia":         "SA",
+	"singapore":            "SG",
+	"south africa":         "ZA",
+	"south korea":          "KR",
+	"spain":                "ES",
+	"taiwan":               "TW",
+	"thailand":             "TH",
+	"turkey":               "TR",
+	"türkiye":              "TR",
+	"uganda":               "UG",
+	"ukraine":              "UA",
+	"united arab emirates": "AE",
+	"united kingdom":       "GB",
+	"united states":        "US",
+	"usa":                  "US",
+	"vietnam":              "VN",
+}
+
+// CountryCode returns the ISO 3166-1 alpha-2 code of a country, given either its name or its code. It returns an
+// empty string if the country isn't known.
+func CountryCode(country string) string {
+	country = strings.TrimSpace(country)
+	if code, ok := countryCodes[strings.ToLower(country)]; ok {
+		return code
+	}
+	if len(country) == 2 && isLetters(country) {
+		return strings.ToUpper(country)
+	}
+	return ""
+}
+
+// CountryFlag returns the flag emoji of a country, given either its name or its code. It returns an empty string if
+// the country isn't known.
+func CountryFlag(country string) string {
+	code := CountryCode(country)
+	if code == "" {
+		return ""
+	}
+	// Each letter of the code is shown as its regional indicator symbol, and a pair of them is shown as the flag
+	var flag strings.Builder
+	for _, letter := range code {
+		flag.WriteRune('🇦' + letter - 'A')
+	}
+	return flag.String()
+}
+
+// isLetters returns true if s is made up of only the letters A to Z, in either case.
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// CountryGroup is the teams, or their rankings, from one country.
+type CountryGroup[T any] struct {
+	Country     string `json:"country"`
+	CountryCode string `json:"country_code,omitempty"` // ISO 3166-1 alpha-2 code of the country, if it is known
+	Teams       []T    `json:"teams"`
+}
+
+// GroupByCountry groups the teams, or their rankings, by the country returned for each of them, keeping the order
+// they are in within each country. The countries are ordered by name, with teams that have no country last.
+func GroupByCountry[T any](teams []T, country func(T) string) []*CountryGroup[T] {
+	groups := make(map[string]*CountryGroup[T])
+	var ordered []*CountryGroup[T]
+	for _, team := range teams {
+		name := country(team)
+		group, ok := groups[name]
+		if !ok {
+			group = &CountryGroup[T]{Country: name, CountryCode: CountryCode(name)}
+			groups[name] = group
+			ordered = append(ordered, group)
+		}
+		group.Teams = append(group.Teams, team)
+	}
+	slices.SortFunc(ordered, func(a, b *CountryGroup[T]) int {
+		if (a.Country == "") != (b.Country == "") {
+			if a.Country == "" {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.Country, b.Country)
+	})
+	return ordered
+}
@@ -0,0 +1,238 @@
+package query
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// TypicalCutoff represents the advancement cutoffs at a season's completed events of a similar size.
+type TypicalCutoff struct {
+	MinTeams int // Fewest teams ranked at an event of this size
+	MaxTeams int // Most teams ranked at an event of this size, or 0 if there is no limit
+	Events   int // Completed events the cutoff is taken from
+	Median   int // Median cutoff at the events
+	Low      int // Lowest cutoff at the events
+	High     int // Highest cutoff at the events
+}
+
+// Label returns the range of event sizes the cutoff covers, such as "17-24 teams".
+func (tc *TypicalCutoff) Label() string {
+	switch {
+	case tc.MaxTeams == 0:
+		return fmt.Sprintf("%d+ teams", tc.MinTeams)
+	case tc.MinTeams <= 1:
+		return fmt.Sprintf("up to %d teams", tc.MaxTeams)
+	}
+	return fmt.Sprintf("%d-%d teams", tc.MinTeams, tc.MaxTeams)
+}
+
+// contains returns true if an event with the given number of teams falls within the cutoff's range of sizes.
+func (tc *TypicalCutoff) contains(teams int) bool {
+	return teams >= tc.MinTeams && (tc.MaxTeams == 0 || teams <= tc.MaxTeams)
+}
+
+// eventSizes are the ranges of event sizes cutoffs are grouped by. Larger events have more advancement slots and
+// award more qualification points, so their cutoffs are compared with each other.
+var eventSizes = []TypicalCutoff{
+	{MinTeams: 1, MaxTeams: 16},
+	{MinTeams: 17, MaxTeams: 24},
+	{MinTeams: 25, MaxTeams: 32},
+	{MinTeams: 33},
+}
+
+// CutoffAnalysis represents the advancement cutoffs at a season's completed league tournaments and qualifiers.
+type CutoffAnalysis struct {
+	Year    int
+	Sizes   []*TypicalCutoff // Typical cutoff for each range of event sizes, from the smallest to the largest
+	Cutoffs []*EventCutoff   // Cutoff at each completed event, in date order
+}
+
+// AdvancementCutoffAnalysisQuery analyzes the advancement cutoffs saved for a season's events, grouping the events
+// by the number of teams ranked at them. Only the sizes with at least one completed event are returned.
+func AdvancementCutoffAnalysisQuery(year int) (*CutoffAnalysis, error) {
+	cutoffs, err := seasonCutoffs(year)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &CutoffAnalysis{
+		Year:    year,
+		Sizes:   []*TypicalCutoff{},
+		Cutoffs: cutoffs,
+	}
+	for _, size := range eventSizes {
+		if typical := typicalCutoff(size, cutoffs, ""); typical != nil {
+			analysis.Sizes = append(analysis.Sizes, typical)
+		}
+	}
+	return analysis, nil
+}
+
+// TypicalCutoffQuery returns the typical advancement cutoff at the season's other completed events with about the
+// same number of teams as the given event, or nil if there are none.
+func TypicalCutoffQuery(event *database.Event, teams int) (*TypicalCutoff, error) {
+	cutoffs, err := seasonCutoffs(event.Year)
+	if err != nil {
+		return nil, err
+	}
+	for _, size := range eventSizes {
+		if size.contains(teams) {
+			return typicalCutoff(size, cutoffs, event.EventID), nil
+		}
+	}
+	return nil, nil
+}
+
+// typicalCutoff returns the typical cutoff at the events within the range of sizes, leaving out the event with the
+// given ID. It returns nil if there are no such events.
+func typicalCutoff(size TypicalCutoff, cutoffs []*EventCutoff, excludeEventID string) *TypicalCutoff {
+	var matched []*EventCutoff
+	for _, c := range cutoffs {
+		if c.Event.EventID != excludeEventID && size.contains(c.Teams) {
+			matched = append(matched, c)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	typical := &TypicalCutoff{
+		MinTeams: size.MinTeams,
+		MaxTeams: size.MaxTeams,
+		Events:   len(matched),
+		Median:   medianCutoff(matched),
+		Low:      matched[0].Cutoff,
+		High:     matched[0].Cutoff,
+	}
+	for _, c := range matched {
+		typical.Low = min(typical.Low, c.Cutoff)
+		typical.High = max(typical.High, c.Cutoff)
+	}
+	return typical
+}
+
+// seasonCutoffs returns the advancement cutoffs saved for the season's official events, in date order.
+func seasonCutoffs(year int) ([]*EventCutoff, error) {
+	official := false
+	events, err := db.GetAllEvents(database.EventFilter{Year: year, Unofficial: &official})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return []*EventCutoff{}, nil
+	}
+	slices.SortFunc(events, func(a, b *database.Event) int {
+		return a.DateStart.Compare(b.DateStart)
+	})
+
+	eventIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+	saved, err := db.GetAdvancementCutoffs(database.AdvancementCutoffFilter{EventIDs: eventIDs})
+	if err != nil {
+		return nil, err
+	}
+	savedMap := make(map[string]*database.AdvancementCutoff, len(saved))
+	for _, cutoff := range saved {
+		savedMap[cutoff.EventID] = cutoff
+	}
+
+	cutoffs := []*EventCutoff{}
+	for _, event := range events {
+		if cutoff, ok := savedMap[event.EventID]; ok {
+			cutoffs = append(cutoffs, &EventCutoff{Event: event, Teams: cutoff.Teams, Advancing: cutoff.Advancing, Cutoff: cutoff.Cutoff})
+		}
+	}
+	return cutoffs, nil
+}
+
+// SaveAdvancementCutoffs calculates and saves the advancement cutoff of each of the season's league tournaments and
+// qualifiers that teams have advanced from. An event's cutoff is only recalculated if the number of teams that
+// advanced from it has changed since it was saved, unless refresh is true. It returns the number of cutoffs saved.
+func SaveAdvancementCutoffs(year int, refresh bool) (int, error) {
+	official := false
+	events, err := db.GetAllEvents(database.EventFilter{Year: year, Unofficial: &official})
+	if err != nil {
+		return 0, err
+	}
+	saved, err := db.GetAdvancementCutoffs()
+	if err != nil {
+		return 0, err
+	}
+	savedMap := make(map[string]*database.AdvancementCutoff, len(saved))
+	for _, cutoff := range saved {
+		savedMap[cutoff.EventID] = cutoff
+	}
+
+	count := 0
+	for _, event := range events {
+		if tier := TierOf(event); tier != TierLeagueTournament && tier != TierQualifier {
+			continue
+		}
+		advancements, err := db.GetEventAdvancements(event.EventID)
+		if err != nil {
+			return count, err
+		}
+		advancing := 0
+		for _, adv := range advancements {
+			if adv.Status != "already_advancing" {
+				advancing++
+			}
+		}
+		if advancing == 0 {
+			continue
+		}
+		if cutoff, ok := savedMap[event.EventID]; ok && !refresh && cutoff.Advancing == advancing {
+			continue
+		}
+
+		eventCutoff, err := calculateAdvancementCutoff(event)
+		if err != nil {
+			return count, err
+		}
+		if eventCutoff == nil {
+			continue
+		}
+		cutoff := &database.AdvancementCutoff{
+			EventID:   event.EventID,
+			Teams:     eventCutoff.Teams,
+			Advancing: eventCutoff.Advancing,
+			Cutoff:    eventCutoff.Cutoff,
+		}
+		if err := db.SaveAdvancementCutoff(cutoff); err != nil {
+			return count, err
+		}
+		slog.Debug("saved advancement cutoff", "event", event.EventCode, "cutoff", cutoff.Cutoff)
+		count++
+	}
+	return count, nil
+}
+
+// calculateAdvancementCutoff returns the lowest total advancement points of a team that advanced from an event,
+// ignoring teams that had already advanced from an earlier event. It returns nil if no team advanced.
+func calculateAdvancementCutoff(event *database.Event) (*EventCutoff, error) {
+	report, err := AdvancementReportQuery(event.EventCode, event.Year)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, nil
+	}
+
+	var eventCutoff *EventCutoff
+	for _, ta := range report.TeamAdvancements {
+		if !ta.Advances || ta.Status == "already_advancing" {
+			continue
+		}
+		if eventCutoff == nil {
+			eventCutoff = &EventCutoff{Event: event, Teams: len(report.TeamAdvancements), Cutoff: ta.TotalPoints}
+		}
+		eventCutoff.Advancing++
+		eventCutoff.Cutoff = min(eventCutoff.Cutoff, ta.TotalPoints)
+	}
+	return eventCutoff, nil
+}
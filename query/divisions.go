@@ -0,0 +1,76 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// EventDivisions returns the divisions of a multi-division event, sorted by event code. A division is an event of
+// the same season whose division code is the event's code. It returns an empty list if the event has no divisions,
+// which includes an event that is itself a division.
+func EventDivisions(event *database.Event) ([]*database.Event, error) {
+	events, err := db.GetAllEvents(database.EventFilter{Year: event.Year})
+	if err != nil {
+		return nil, err
+	}
+	var divisions []*database.Event
+	for _, e := range events {
+		if e.DivisionCode == event.EventCode && e.EventCode != event.EventCode {
+			divisions = append(divisions, e)
+		}
+	}
+	slices.SortFunc(divisions, func(a, b *database.Event) int {
+		return cmp.Compare(a.EventCode, b.EventCode)
+	})
+	return divisions, nil
+}
+
+// withDivisionCodes returns the event codes along with the codes of each event's divisions, so a query of a
+// multi-division event covers the matches played in each division. The divisions are each ranked on their own, so
+// their matches aren't pooled into one calculation.
+func withDivisionCodes(eventCodes []string, year int) ([]string, error) {
+	events, err := db.GetAllEvents(database.EventFilter{EventCodes: eventCodes, Year: year})
+	if err != nil {
+		return nil, err
+	}
+	codes := slices.Clone(eventCodes)
+	for _, event := range events {
+		divisions, err := EventDivisions(event)
+		if err != nil {
+			return nil, err
+		}
+		for _, division := range divisions {
+			codes = append(codes, division.EventCode)
+		}
+	}
+	return codes, nil
+}
+
+// labelDivisions sets the division of each team whose performance includes a ranking from a division, so the
+// combined rankings of a multi-division event show where each team played.
+func labelDivisions(performances []TeamPerformance, rankings []*database.TeamRanking) error {
+	divisions := make(map[string]string) // Division code by event ID, or empty if the event isn't a division
+	teamDivisions := make(map[int]string)
+	for _, ranking := range rankings {
+		code, ok := divisions[ranking.EventID]
+		if !ok {
+			event, err := db.GetEvent(ranking.EventID)
+			if err != nil {
+				return err
+			}
+			if event != nil && event.DivisionCode != "" && event.DivisionCode != event.EventCode {
+				code = event.EventCode
+			}
+			divisions[ranking.EventID] = code
+		}
+		if code != "" {
+			teamDivisions[ranking.TeamID] = code
+		}
+	}
+	for i := range performances {
+		performances[i].Division = teamDivisions[performances[i].TeamID]
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package query
 
 import (
+	"cmp"
 	"slices"
+	"strings"
 
 	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/geocode"
 )
 
 // EventTeams represents an event with all participating teams.
@@ -15,6 +18,8 @@ type EventTeams struct {
 // TeamsByEventQuery retrieves all teams that have or will participate in an event.
 // It returns an EventTeams object containing the event and its participating teams.
 func TeamsByEventQuery(eventCode string, year int) (*EventTeams, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
 	// Get the event details
 	filter := database.EventFilter{
 		EventCodes: []string{eventCode},
@@ -75,17 +80,23 @@ func TeamsByEventQuery(eventCode string, year int) (*EventTeams, error) {
 type TeamRanking struct {
 	Team           *database.Team
 	Ranking        *database.EventRanking
-	HighMatchScore int // Highest total points scored in any match
+	HighMatchScore int             // Highest total points scored in any match
+	Division       *database.Event // Division the team was ranked in, or nil if the event has no divisions
 }
 
 // EventTeamRankings represents an event with all team rankings.
 type EventTeamRankings struct {
 	Event        *database.Event
+	Divisions    []*database.Event // Divisions of a multi-division event, sorted by event code
 	TeamRankings []*TeamRanking
 }
 
-// EventTeamRankingQuery retrieves an event and all teams with their rankings, sorted by rank.
+// EventTeamRankingQuery retrieves an event and all teams with their rankings, sorted by rank. The rankings of a
+// multi-division event are those of each of its divisions, labeled with the division and sorted by division and
+// then by rank, since each division is ranked separately.
 func EventTeamRankingQuery(eventCode string, year int) (*EventTeamRankings, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
 	// Get the event details
 	filter := database.EventFilter{
 		EventCodes: []string{eventCode},
@@ -111,6 +122,50 @@ func EventTeamRankingQuery(eventCode string, year int) (*EventTeamRankings, erro
 		return nil, nil
 	}
 
+	divisions, err := EventDivisions(event)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the rankings of the event, or of each of its divisions
+	var teamRankings []*TeamRanking
+	rankedEvents := []*database.Event{event}
+	if len(divisions) > 0 {
+		rankedEvents = divisions
+	}
+	for _, rankedEvent := range rankedEvents {
+		rankings, err := getEventTeamRankings(rankedEvent)
+		if err != nil {
+			return nil, err
+		}
+		for _, tr := range rankings {
+			if len(divisions) > 0 {
+				tr.Division = rankedEvent
+			}
+			teamRankings = append(teamRankings, tr)
+		}
+	}
+	if len(teamRankings) == 0 {
+		return nil, nil
+	}
+
+	// Sort by division and then by rank
+	slices.SortStableFunc(teamRankings, func(a, b *TeamRanking) int {
+		if a.Division != nil && b.Division != nil && a.Division != b.Division {
+			return strings.Compare(a.Division.EventCode, b.Division.EventCode)
+		}
+		return a.Ranking.Rank - b.Ranking.Rank
+	})
+
+	return &EventTeamRankings{
+		Event:        event,
+		Divisions:    divisions,
+		TeamRankings: teamRankings,
+	}, nil
+}
+
+// getEventTeamRankings returns the teams ranked at an event along with their rankings and high scores.
+func getEventTeamRankings(event *database.Event) ([]*TeamRanking, error) {
 	// Get all event rankings for the event
 	eventRankings, err := db.GetEventRankings(event.EventID)
 	if err != nil {
@@ -177,14 +232,234 @@ func EventTeamRankingQuery(eventCode string, year int) (*EventTeamRankings, erro
 			})
 		}
 	}
+	return teamRankings, nil
+}
 
-	// Sort by rank
-	slices.SortFunc(teamRankings, func(a, b *TeamRanking) int {
-		return a.Ranking.Rank - b.Ranking.Rank
+// EventsQuery retrieves all events that match the optional filter.
+func EventsQuery(filter ...database.EventFilter) ([]*database.Event, error) {
+	events, err := db.GetAllEvents(normalizeEventFilters(filter)...)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// EventDistance represents an event along with its distance from a location.
+type EventDistance struct {
+	Event    *database.Event
+	Distance float64 // Distance in miles
+}
+
+// EventsNearQuery retrieves all events that match the filter and are within the given number of miles
+// of the origin, sorted by distance. Events whose venue has not been geocoded are not included.
+func EventsNearQuery(filter database.EventFilter, origin geocode.Location, withinMiles float64) ([]*EventDistance, error) {
+	events, err := db.GetAllEvents(normalizeEventFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*EventDistance
+	for _, event := range events {
+		if !event.HasLocation() {
+			continue
+		}
+		distance := geocode.Distance(origin, geocode.Location{Latitude: event.Latitude, Longitude: event.Longitude})
+		if distance <= withinMiles {
+			results = append(results, &EventDistance{
+				Event:    event,
+				Distance: distance,
+			})
+		}
+	}
+
+	// Sort by distance, then by start date
+	slices.SortFunc(results, func(a, b *EventDistance) int {
+		if a.Distance != b.Distance {
+			return cmp.Compare(a.Distance, b.Distance)
+		}
+		return a.Event.DateStart.Compare(b.Event.DateStart)
 	})
 
-	return &EventTeamRankings{
-		Event:        event,
-		TeamRankings: teamRankings,
-	}, nil
+	return results, nil
+}
+
+// EventStats represents summary statistics for an event, including a comparison of the teams registered for the
+// event with the teams that actually played.
+type EventStats struct {
+	Event          *database.Event
+	QualMatches    int
+	PlayoffMatches int
+	HighScore      int     // Highest alliance score in any match
+	AverageScore   float64 // Average alliance score across all matches
+	Attendance     *database.EventAttendance
+	TeamNames      map[int]string // Names of the teams that did not show or played without being registered
+}
+
+// EventStatsQuery retrieves summary statistics for an event, including any no-shows and walk-ons. Teams are
+// considered registered if they are in the event's team list or rankings.
+func EventStatsQuery(eventCode string, year int) (*EventStats, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
+	// Get the event details
+	filter := database.EventFilter{
+		EventCodes: []string{eventCode},
+	}
+	events, err := db.GetAllEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the event matching the year
+	var event *database.Event
+	for _, e := range events {
+		if e.Year == year {
+			event = e
+			break
+		}
+	}
+	if event == nil {
+		return nil, nil
+	}
+
+	// Get the registered teams for the event
+	eventTeams, err := db.GetEventTeams(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	rankings, err := db.GetEventRankings(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	registered := make([]int, 0, len(eventTeams)+len(rankings))
+	for _, et := range eventTeams {
+		registered = append(registered, et.TeamID)
+	}
+	for _, ranking := range rankings {
+		registered = append(registered, ranking.TeamID)
+	}
+
+	// Go through each match to get the match counts, scores, and the teams that played
+	matches, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &EventStats{Event: event}
+	var matchTeams []*database.MatchTeam
+	var totalScore, scoreCount int
+	for _, match := range matches {
+		if strings.EqualFold(match.TournamentLevel, "playoff") {
+			stats.PlayoffMatches++
+		} else {
+			stats.QualMatches++
+		}
+
+		mts, err := db.GetMatchTeams(match.MatchID)
+		if err != nil {
+			return nil, err
+		}
+		matchTeams = append(matchTeams, mts...)
+
+		for _, alliance := range []string{database.AllianceRed, database.AllianceBlue} {
+			score, err := db.GetMatchAllianceScore(match.MatchID, alliance)
+			if err != nil {
+				return nil, err
+			}
+			if score == nil {
+				continue
+			}
+			totalScore += score.TotalPoints
+			scoreCount++
+			if score.TotalPoints > stats.HighScore {
+				stats.HighScore = score.TotalPoints
+			}
+		}
+	}
+	if scoreCount > 0 {
+		stats.AverageScore = float64(totalScore) / float64(scoreCount)
+	}
+	stats.Attendance = database.GetEventAttendance(event.EventID, registered, matchTeams)
+
+	// Get the names of the teams with attendance anomalies
+	stats.TeamNames = make(map[int]string)
+	for _, teamID := range slices.Concat(stats.Attendance.NoShows, stats.Attendance.WalkOns) {
+		team, err := db.GetTeam(teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team != nil {
+			stats.TeamNames[teamID] = team.Name
+		}
+	}
+
+	return stats, nil
+}
+
+// EventSummaryEntry pairs an event with its materialized summary.
+type EventSummaryEntry struct {
+	Event   *database.Event
+	Summary *database.EventSummary
+}
+
+// EventSummariesQuery returns the summaries of the events in a season, optionally limited to the given regions,
+// ordered from the strongest field (highest average NpOPR) to the weakest. The summaries are read from the
+// event_summary table, so no match data is loaded.
+func EventSummariesQuery(year int, regionCodes ...string) ([]*EventSummaryEntry, error) {
+	summaries, err := db.GetEventSummaries(database.EventSummaryFilter{RegionCodes: database.NormalizeCodes(regionCodes)})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*EventSummaryEntry, 0, len(summaries))
+	for _, summary := range summaries {
+		event, err := db.GetEvent(summary.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if event == nil || event.Year != year {
+			continue
+		}
+		entries = append(entries, &EventSummaryEntry{Event: event, Summary: summary})
+	}
+
+	slices.SortStableFunc(entries, func(a, b *EventSummaryEntry) int {
+		if c := cmp.Compare(b.Summary.AverageNpOPR, a.Summary.AverageNpOPR); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Event.EventCode, b.Event.EventCode)
+	})
+
+	return entries, nil
+}
+
+// EventSummaryQuery returns the summary of a single event. It returns nil if the event does not exist or has not
+// been summarized yet.
+func EventSummaryQuery(eventCode string, year int) (*EventSummaryEntry, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
+	events, err := db.GetAllEvents(database.EventFilter{EventCodes: []string{eventCode}})
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the event matching the year
+	var event *database.Event
+	for _, e := range events {
+		if e.Year == year {
+			event = e
+			break
+		}
+	}
+	if event == nil {
+		return nil, nil
+	}
+
+	summaries, err := db.GetEventSummaries(database.EventSummaryFilter{EventIDs: []string{event.EventID}})
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, nil
+	}
+
+	return &EventSummaryEntry{Event: event, Summary: summaries[0]}, nil
 }
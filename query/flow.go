@@ -0,0 +1,176 @@
+package query
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// maxMatchCycle is the longest time between the starts of two matches that is counted as a match cycle. Longer gaps
+// are breaks, such as lunch or alliance selection, and would throw off the estimate of the cycle time.
+const maxMatchCycle = 20 * time.Minute
+
+// MatchBreak represents a gap between two consecutive matches that is too long to be a match cycle, such as lunch
+// or a field fault.
+type MatchBreak struct {
+	After  *database.Match // Match played before the break
+	Before *database.Match // Match played after the break
+	Length time.Duration   // Time between the starts of the two matches
+}
+
+// LevelFlow represents the flow of the matches at one tournament level of an event.
+type LevelFlow struct {
+	Level           string
+	Played          int             // Number of matches played
+	Scheduled       int             // Number of matches in the schedule, or 0 if the schedule couldn't be fetched
+	First           *database.Match // First match played, or nil if none have been played
+	Latest          *database.Match // Latest match played, or nil if none have been played
+	FirstStart      time.Time       // Actual start time of the first match
+	LatestStart     time.Time       // Actual start time of the latest match
+	CycleTime       time.Duration   // Average time between the starts of consecutive matches, or 0 if it can't be estimated
+	Cycles          int             // Number of match cycles the cycle time is averaged over
+	Breaks          []MatchBreak
+	Slip            time.Duration // How far the latest match started behind its scheduled time, negative if it was ahead
+	SlipKnown       bool          // Whether the scheduled time of the latest match is known
+	ProjectedFinish time.Time     // Projected end of the last scheduled match, or the zero time if it can't be projected
+}
+
+// Remaining returns the number of scheduled matches that haven't been played, or 0 if the schedule isn't known.
+func (lf *LevelFlow) Remaining() int {
+	return max(lf.Scheduled-lf.Played, 0)
+}
+
+// EventFlow represents the flow of the matches at an event: the pace they are played at, the breaks between them,
+// and how far they have slipped from the schedule.
+type EventFlow struct {
+	Event  *database.Event
+	Levels []*LevelFlow // Qualification, then playoff; a level is left out if no matches have been played at it
+}
+
+// matchStart is a match and the time it actually started.
+type matchStart struct {
+	match *database.Match
+	start time.Time
+}
+
+// EventFlowQuery returns the flow of the matches played at an event, measured from their actual start times. The
+// number of matches and their scheduled times are taken from the event's schedule in the FTC API; if the schedule of
+// a level can't be fetched, its remaining matches, slip, and projected finish are left unknown. It returns nil if
+// the event isn't found.
+func EventFlowQuery(eventCode string, year int) (*EventFlow, error) {
+	event, err := overlayEvent(eventCode, year)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	matches, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	flow := &EventFlow{Event: event, Levels: []*LevelFlow{}}
+	for _, level := range []ftc.MatchType{ftc.QUALIFIER, ftc.PLAYOFF} {
+		var levelMatches []*database.Match
+		for _, match := range matches {
+			if strings.EqualFold(match.TournamentLevel, string(level)) {
+				levelMatches = append(levelMatches, match)
+			}
+		}
+		if len(levelMatches) == 0 {
+			continue
+		}
+		flow.Levels = append(flow.Levels, levelFlow(event, level, levelMatches))
+	}
+	return flow, nil
+}
+
+// levelFlow returns the flow of the matches played at a tournament level of the event.
+func levelFlow(event *database.Event, level ftc.MatchType, matches []*database.Match) *LevelFlow {
+	lf := &LevelFlow{Level: string(level), Played: len(matches)}
+	starts := matchStarts(matches, event.Location())
+	if len(starts) == 0 {
+		return lf
+	}
+	lf.First, lf.FirstStart = starts[0].match, starts[0].start
+	latest := starts[len(starts)-1]
+	lf.Latest, lf.LatestStart = latest.match, latest.start
+	lf.CycleTime, lf.Cycles = cycleTime(starts)
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i].start.Sub(starts[i-1].start); gap > maxMatchCycle {
+			lf.Breaks = append(lf.Breaks, MatchBreak{After: starts[i-1].match, Before: starts[i].match, Length: gap})
+		}
+	}
+
+	schedule, err := ftc.GetEventSchedule(strconv.Itoa(event.Year), event.EventCode, level)
+	if err != nil {
+		slog.Warn("Failed to fetch the schedule for the event flow", "eventCode", event.EventCode, "year", event.Year, "level", level, "error", err)
+		return lf
+	}
+	slices.SortFunc(schedule, func(a, b *ftc.EventSchedule) int {
+		return cmp.Or(a.Series-b.Series, a.MatchNumber-b.MatchNumber)
+	})
+	scheduled := make(map[int]bool, len(schedule))
+	for _, s := range schedule {
+		number := scheduledMatchNumber(s)
+		if scheduled[number] {
+			// A playoff series is scheduled as several matches, which are saved as one
+			continue
+		}
+		scheduled[number] = true
+		if number == lf.Latest.MatchNumber {
+			if t := database.ParseStartTime(s.StartTime, event.Location()); !t.IsZero() {
+				lf.Slip, lf.SlipKnown = lf.LatestStart.Sub(t), true
+			}
+		}
+	}
+	lf.Scheduled = max(len(scheduled), lf.Played)
+	if remaining := lf.Remaining(); remaining > 0 && lf.CycleTime > 0 {
+		// The last match starts a cycle after each remaining match before it, and ends a cycle after it starts
+		lf.ProjectedFinish = lf.LatestStart.Add(time.Duration(remaining+1) * lf.CycleTime)
+	}
+	return lf
+}
+
+// matchStarts returns the matches that have an actual start time, in the order they were played. The start times are
+// in the location, which is the event's time zone.
+func matchStarts(matches []*database.Match, loc *time.Location) []matchStart {
+	var starts []matchStart
+	for _, match := range matches {
+		if !match.ActualStartTime.IsZero() {
+			starts = append(starts, matchStart{match, match.ActualStartTime.In(loc)})
+		}
+	}
+	slices.SortFunc(starts, func(a, b matchStart) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.match.TournamentLevel), strings.ToLower(b.match.TournamentLevel)),
+			a.match.MatchNumber-b.match.MatchNumber)
+	})
+	return starts
+}
+
+// cycleTime returns the average time between the starts of consecutive matches at the same tournament level, and
+// the number of cycles it is averaged over. Gaps longer than maxMatchCycle are breaks, and aren't counted. It returns
+// 0 if no cycles were found.
+func cycleTime(starts []matchStart) (time.Duration, int) {
+	var total time.Duration
+	var cycles int
+	for i := 1; i < len(starts); i++ {
+		if !strings.EqualFold(starts[i].match.TournamentLevel, starts[i-1].match.TournamentLevel) {
+			continue
+		}
+		cycle := starts[i].start.Sub(starts[i-1].start)
+		if cycle <= 0 || cycle > maxMatchCycle {
+			continue
+		}
+		total += cycle
+		cycles++
+	}
+	if cycles == 0 {
+		return 0, 0
+	}
+	return total / time.Duration(cycles), cycles
+}
@@ -0,0 +1,250 @@
+package query
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// FormulaMetrics are the metrics a formula can weigh: the metrics of the team rankings, along with consistency.
+var FormulaMetrics = []string{"opr", "npopr", "ccwm", "dpr", "npdpr", "npavg", "autoopr", "autopct", "matches", "consistency"}
+
+// FormulaTerm is a metric in a formula along with its weight.
+type FormulaTerm struct {
+	Weight float64
+	Metric string
+}
+
+// Formula is a composite score over the team ranking metrics, made up of the weighted sum of its terms. A formula
+// lets a team weigh the metrics by its own strategy, such as favoring consistent scorers when picking alliance
+// partners.
+type Formula []FormulaTerm
+
+// String returns the formula in the form accepted by ParseFormula.
+func (f Formula) String() string {
+	var sb strings.Builder
+	for i, term := range f {
+		weight := term.Weight
+		switch {
+		case i == 0 && weight < 0:
+			sb.WriteString("-")
+			weight = -weight
+		case i > 0 && weight < 0:
+			sb.WriteString(" - ")
+			weight = -weight
+		case i > 0:
+			sb.WriteString(" + ")
+		}
+		if weight != 1 {
+			sb.WriteString(strconv.FormatFloat(weight, 'g', -1, 64) + "*")
+		}
+		sb.WriteString(term.Metric)
+	}
+	return sb.String()
+}
+
+// Metrics returns the metrics used by the formula, in the order they first appear.
+func (f Formula) Metrics() []string {
+	var metrics []string
+	for _, term := range f {
+		if !slices.Contains(metrics, term.Metric) {
+			metrics = append(metrics, term.Metric)
+		}
+	}
+	return metrics
+}
+
+// Uses returns true if the formula weighs the metric.
+func (f Formula) Uses(metric string) bool {
+	return slices.ContainsFunc(f, func(term FormulaTerm) bool { return term.Metric == metric })
+}
+
+// ParseFormula parses a formula such as "0.5*npopr + 0.3*ccwm + 0.2*consistency". Each term is a metric, optionally
+// preceded by a weight and '*', and terms are joined by '+' or '-'. A metric without a weight has a weight of 1, so
+// "npopr - npdpr" is also a formula.
+func ParseFormula(s string) (Formula, error) {
+	expr := strings.ToLower(strings.Join(strings.Fields(s), ""))
+	if expr == "" {
+		return nil, fmt.Errorf("empty formula")
+	}
+
+	var formula Formula
+	for expr != "" {
+		sign := 1.0
+		switch expr[0] {
+		case '-':
+			sign = -1
+			expr = expr[1:]
+		case '+':
+			expr = expr[1:]
+		default:
+			if formula != nil {
+				return nil, fmt.Errorf("invalid formula %q, expected '+' or '-' between terms", s)
+			}
+		}
+
+		end := strings.IndexAny(expr, "+-")
+		if end < 0 {
+			end = len(expr)
+		}
+		term := expr[:end]
+		expr = expr[end:]
+
+		weight := 1.0
+		metric := term
+		if w, m, ok := strings.Cut(term, "*"); ok {
+			var err error
+			if weight, err = strconv.ParseFloat(w, 64); err != nil {
+				return nil, fmt.Errorf("invalid weight %q in formula %q", w, s)
+			}
+			metric = m
+		}
+		if !slices.Contains(FormulaMetrics, metric) {
+			return nil, fmt.Errorf("invalid metric %q in formula %q, expected one of %s", metric, s, strings.Join(FormulaMetrics, ", "))
+		}
+		formula = append(formula, FormulaTerm{Weight: sign * weight, Metric: metric})
+	}
+	return formula, nil
+}
+
+// LoadFormulas loads named formulas from a JSON file that maps each name to a formula, such as
+// {"coach": "0.5*npopr + 0.3*ccwm + 0.2*consistency"}.
+func LoadFormulas(file string) (map[string]Formula, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var named map[string]string
+	if err := json.Unmarshal(data, &named); err != nil {
+		return nil, fmt.Errorf("invalid formulas file %s: %w", file, err)
+	}
+	formulas := make(map[string]Formula, len(named))
+	for name, s := range named {
+		formula, err := ParseFormula(s)
+		if err != nil {
+			return nil, fmt.Errorf("formula %q: %w", name, err)
+		}
+		formulas[strings.ToLower(name)] = formula
+	}
+	return formulas, nil
+}
+
+// FormulaRanking represents a team's score under a formula, along with the metrics it was calculated from.
+type FormulaRanking struct {
+	TeamPerformance
+	Consistency float64 // How steadily the team's alliances score, from 0 to 100; only calculated if the formula uses it
+	Score       float64
+}
+
+// Value returns the value of a formula metric for the team.
+func (r FormulaRanking) Value(metric string) float64 {
+	if metric == "consistency" {
+		return r.Consistency
+	}
+	return sortValue(metric, r.TeamID, r.OPR, r.NpOPR, r.CCWM, r.DPR, r.NpDPR, r.NpAVG, r.AutoOPR, r.Matches)
+}
+
+// FormulaRankingsQuery ranks teams by a formula, from the highest score to the lowest, with teams that score the
+// same ordered by team number. The teams and their metrics are the same as TeamRankingsQuery's.
+//
+// A team's consistency is 100 less the spread of its alliances' non-penalty scores in the qualification matches it
+// played at the events, as a percentage of their average, so a team whose alliances always score the same has a
+// consistency of 100. A team with fewer than two scored matches has a consistency of 0.
+func FormulaRankingsQuery(formula Formula, region string, country string, eventCodes []string, year int, includeUnofficial bool) ([]FormulaRanking, error) {
+	performances, err := TeamRankingsQuery(region, country, eventCodes, year, includeUnofficial, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var consistency map[int]float64
+	if formula.Uses("consistency") {
+		_, _, eventIDs, err := getTeamRankingScope(database.NormalizeCode(region), country, database.NormalizeCodes(eventCodes), year, includeUnofficial, false)
+		if err != nil {
+			return nil, err
+		}
+		if consistency, err = teamConsistency(eventIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	rankings := make([]FormulaRanking, 0, len(performances))
+	for _, perf := range performances {
+		ranking := FormulaRanking{TeamPerformance: perf, Consistency: consistency[perf.TeamID]}
+		for _, term := range formula {
+			ranking.Score += term.Weight * ranking.Value(term.Metric)
+		}
+		rankings = append(rankings, ranking)
+	}
+	slices.SortFunc(rankings, func(a, b FormulaRanking) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+	return rankings, nil
+}
+
+// teamConsistency returns the consistency of each team that played a scored qualification match at the events.
+func teamConsistency(eventIDs []string) (map[int]float64, error) {
+	scores := make(map[int][]float64)
+	for _, eventID := range eventIDs {
+		matches, err := db.GetMatchesByEvent(eventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if !strings.EqualFold(match.TournamentLevel, string(ftc.QUALIFIER)) {
+				continue
+			}
+			allianceScores := make(map[string]*database.MatchAllianceScore, 2)
+			for _, alliance := range []string{database.AllianceRed, database.AllianceBlue} {
+				score, err := db.GetMatchAllianceScore(match.MatchID, alliance)
+				if err != nil {
+					return nil, err
+				}
+				allianceScores[alliance] = score
+			}
+			teams, err := db.GetMatchTeams(match.MatchID)
+			if err != nil {
+				return nil, err
+			}
+			for _, team := range teams {
+				score := allianceScores[team.Alliance]
+				if score == nil || !team.OnField {
+					continue
+				}
+				scores[team.TeamID] = append(scores[team.TeamID], float64(score.PreFoulTotal))
+			}
+		}
+	}
+
+	consistency := make(map[int]float64, len(scores))
+	for teamID, values := range scores {
+		if len(values) < 2 {
+			continue
+		}
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		mean := sum / float64(len(values))
+		if mean <= 0 {
+			continue
+		}
+		var squares float64
+		for _, v := range values {
+			squares += (v - mean) * (v - mean)
+		}
+		spread := math.Sqrt(squares/float64(len(values))) / mean
+		consistency[teamID] = max(100*(1-spread), 0)
+	}
+	return consistency, nil
+}
@@ -0,0 +1,126 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// TeamFoulImpact is how much fouls changed the scores of a team's matches over a season: the foul points its
+// alliances received from their opponents' fouls and the foul points they gave their opponents.
+type TeamFoulImpact struct {
+	TeamID      int
+	TeamName    string
+	Region      string
+	Matches     int
+	Received    int // Foul points awarded to the team's alliances for their opponents' fouls
+	Committed   int // Foul points awarded to the team's opponents for its alliances' fouls
+	WonOnFouls  int // Matches won that would have been lost or tied on the points scored alone
+	LostOnFouls int // Matches lost that would have been won or tied on the points scored alone
+}
+
+// Net returns the foul points the team's alliances received less the foul points they committed.
+func (f TeamFoulImpact) Net() int {
+	return f.Received - f.Committed
+}
+
+// NetPerMatch returns the team's net foul points per match played.
+func (f TeamFoulImpact) NetPerMatch() float64 {
+	if f.Matches == 0 {
+		return 0
+	}
+	return float64(f.Net()) / float64(f.Matches)
+}
+
+// CommittedPerMatch returns the foul points the team's alliances committed per match played.
+func (f TeamFoulImpact) CommittedPerMatch() float64 {
+	if f.Matches == 0 {
+		return 0
+	}
+	return float64(f.Committed) / float64(f.Matches)
+}
+
+// FoulImpactQuery totals the foul points received and committed by the alliances of each team in every scored
+// match at the season's events, from the team that gained the most from fouls to the team that lost the most. The
+// teams and events are chosen the same way as TeamRankingsQuery's, and teams that didn't play a scored match are
+// left out. Teams with the same net foul points are ordered by team number.
+//
+// The foul points an alliance received are its total score less its score before fouls, so a team that wins on its
+// opponents' penalties stands out from one that scores cleanly.
+func FoulImpactQuery(region string, country string, eventCodes []string, year int, includeUnofficial bool) ([]TeamFoulImpact, error) {
+	region = database.NormalizeCode(region)
+	eventCodes = database.NormalizeCodes(eventCodes)
+
+	teamMap, _, eventIDs, err := getTeamRankingScope(region, country, eventCodes, year, includeUnofficial, false)
+	if err != nil {
+		return nil, err
+	}
+
+	impacts := make(map[int]*TeamFoulImpact)
+	for _, eventID := range eventIDs {
+		matches, err := db.GetMatchesByEvent(eventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			redScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceRed)
+			if err != nil {
+				return nil, err
+			}
+			blueScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceBlue)
+			if err != nil {
+				return nil, err
+			}
+			if redScore == nil || blueScore == nil {
+				continue
+			}
+			matchTeams, err := db.GetMatchTeams(match.MatchID)
+			if err != nil {
+				return nil, err
+			}
+			for _, mt := range matchTeams {
+				team, ok := teamMap[mt.TeamID]
+				if !ok || !mt.OnField {
+					continue
+				}
+				score, opponent := redScore, blueScore
+				if mt.Alliance == database.AllianceBlue {
+					score, opponent = blueScore, redScore
+				}
+
+				impact, ok := impacts[mt.TeamID]
+				if !ok {
+					impact = &TeamFoulImpact{TeamID: team.TeamID, TeamName: team.Name, Region: team.HomeRegion}
+					impacts[mt.TeamID] = impact
+				}
+				impact.Matches++
+				impact.Received += foulPoints(score)
+				impact.Committed += foulPoints(opponent)
+				switch {
+				case score.TotalPoints > opponent.TotalPoints && score.PreFoulTotal <= opponent.PreFoulTotal:
+					impact.WonOnFouls++
+				case score.TotalPoints < opponent.TotalPoints && score.PreFoulTotal >= opponent.PreFoulTotal:
+					impact.LostOnFouls++
+				}
+			}
+		}
+	}
+
+	results := make([]TeamFoulImpact, 0, len(impacts))
+	for _, impact := range impacts {
+		results = append(results, *impact)
+	}
+	slices.SortFunc(results, func(a, b TeamFoulImpact) int {
+		if c := cmp.Compare(b.Net(), a.Net()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+	return results, nil
+}
+
+// foulPoints returns the foul points awarded to an alliance, the points it was given beyond what it scored.
+func foulPoints(score *database.MatchAllianceScore) int {
+	return max(score.TotalPoints-score.PreFoulTotal, 0)
+}
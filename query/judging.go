@@ -0,0 +1,241 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// JudgedAwardPerformance is a judged award won at an event, along with how the team that won it performed on the
+// field at the event.
+type JudgedAwardPerformance struct {
+	Event   *database.Event
+	Award   *database.EventAward
+	Class   AwardClass // InspireAward or JudgedAward
+	Team    *database.Team
+	Rank    int     // Qualification rank at the event, or 0 if the team wasn't ranked
+	Teams   int     // Number of teams ranked at the event, or in the team's division of a multi-division event
+	OPR     float64 // OPR at the event
+	OPRRank int     // Rank of the team's OPR among the teams at the event, or 0 if the team has no OPR
+}
+
+// TopQuarter returns true if the team's qualification rank was in the top quarter of the teams at the event.
+func (p *JudgedAwardPerformance) TopQuarter() bool {
+	return p.Rank > 0 && p.Rank*4 <= p.Teams+3
+}
+
+// JudgedAwardSummary summarizes how the winners of a judged award performed on the field.
+type JudgedAwardSummary struct {
+	Name       string // Name of the award
+	Class      AwardClass
+	Winners    int     // Number of times the award was won, in any place
+	Ranked     int     // Number of those winners that were ranked at the event
+	AvgRank    float64 // Average qualification rank of the ranked winners
+	AvgOPRRank float64 // Average OPR rank of the ranked winners that have an OPR
+	TopQuarter int     // Number of ranked winners with a qualification rank in the top quarter of their event
+}
+
+// JudgingReport correlates the judged awards won at a region's events with the on-field performance of the teams
+// that won them.
+type JudgingReport struct {
+	RegionCode string   // Region the events are in, or an empty string for a list of events
+	EventCodes []string // Events the report is for, or empty for a region
+	Year       int
+	Awards     []*JudgedAwardPerformance // Sorted by event date, then by award and place
+	Summaries  []*JudgedAwardSummary     // Sorted by award, with the Inspire Award first
+}
+
+// fieldPerformance is how a team performed on the field at an event.
+type fieldPerformance struct {
+	rank    int
+	teams   int
+	opr     float64
+	oprRank int
+}
+
+// JudgingPerformanceQuery returns the judged awards won at the events in a region, or at the given events if any
+// event codes are given, along with each winner's qualification rank and OPR at the event, so judging can be compared
+// with performance on the field. Only the Inspire Award and the other judged awards are included; alliance and
+// Dean's List awards are left out. The winners at a multi-division event are ranked within their division. It
+// returns nil if event codes are given and none of the events are found.
+func JudgingPerformanceQuery(regionCode string, eventCodes []string, year int) (*JudgingReport, error) {
+	report := &JudgingReport{Year: year}
+	var events []*database.Event
+	if len(eventCodes) > 0 {
+		report.EventCodes = database.NormalizeCodes(eventCodes)
+		found, err := db.GetAllEvents(database.EventFilter{EventCodes: report.EventCodes, Year: year})
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, nil
+		}
+		events = found
+	} else {
+		report.RegionCode = database.NormalizeCode(regionCode)
+		found, err := db.GetAllEvents(database.EventFilter{RegionCodes: []string{report.RegionCode}, Year: year})
+		if err != nil {
+			return nil, err
+		}
+		events = found
+	}
+	slices.SortFunc(events, func(a, b *database.Event) int {
+		if c := a.DateStart.Compare(b.DateStart); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.EventCode, b.EventCode)
+	})
+
+	ac, err := newAwardClassifier(year, AdvancementRulesFor(year))
+	if err != nil {
+		return nil, err
+	}
+	teams := make(map[int]*database.Team)
+	report.Awards = []*JudgedAwardPerformance{}
+	for _, event := range events {
+		awards, err := db.GetEventAwards(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		var judged []*database.EventAward
+		for _, award := range awards {
+			if class := ac.classify(award); class == InspireAward || class == JudgedAward {
+				judged = append(judged, award)
+			}
+		}
+		if len(judged) == 0 {
+			continue
+		}
+
+		field, err := eventFieldPerformance(event)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(judged, func(a, b *database.EventAward) int {
+			if c := cmp.Compare(getAwardSortPriority(a.Name), getAwardSortPriority(b.Name)); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.Name, b.Name); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Series, b.Series)
+		})
+		for _, award := range judged {
+			team, ok := teams[award.TeamID]
+			if !ok {
+				team, err = db.GetTeam(award.TeamID)
+				if err != nil {
+					return nil, err
+				}
+				if team == nil {
+					team = &database.Team{TeamID: award.TeamID}
+				}
+				teams[award.TeamID] = team
+			}
+			perf := field[award.TeamID]
+			report.Awards = append(report.Awards, &JudgedAwardPerformance{
+				Event:   event,
+				Award:   award,
+				Class:   ac.classify(award),
+				Team:    team,
+				Rank:    perf.rank,
+				Teams:   perf.teams,
+				OPR:     perf.opr,
+				OPRRank: perf.oprRank,
+			})
+		}
+	}
+
+	report.Summaries = summarizeJudgedAwards(report.Awards)
+	return report, nil
+}
+
+// eventFieldPerformance returns the qualification rank and OPR of each team ranked at an event, keyed by team ID.
+// The teams at a multi-division event are ranked within their division, since its finals have no qualification
+// matches.
+func eventFieldPerformance(event *database.Event) (map[int]fieldPerformance, error) {
+	divisions, err := EventDivisions(event)
+	if err != nil {
+		return nil, err
+	}
+	rankedEvents := []*database.Event{event}
+	if len(divisions) > 0 {
+		rankedEvents = divisions
+	}
+
+	field := make(map[int]fieldPerformance)
+	for _, e := range rankedEvents {
+		rankings, err := db.GetEventRankings(e.EventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ranking := range rankings {
+			field[ranking.TeamID] = fieldPerformance{rank: ranking.Rank, teams: len(rankings)}
+		}
+
+		teamRankings, err := db.GetTeamRankings(database.TeamRankingFilter{EventIDs: []string{e.EventID}})
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(teamRankings, func(a, b *database.TeamRanking) int {
+			return cmp.Compare(b.OPR, a.OPR)
+		})
+		for i, ranking := range teamRankings {
+			perf, ok := field[ranking.TeamID]
+			if !ok {
+				continue
+			}
+			perf.opr = ranking.OPR
+			perf.oprRank = i + 1
+			field[ranking.TeamID] = perf
+		}
+	}
+	return field, nil
+}
+
+// summarizeJudgedAwards summarizes the performance of the winners of each judged award, sorted with the Inspire
+// Award first and then in the order the awards are presented.
+func summarizeJudgedAwards(awards []*JudgedAwardPerformance) []*JudgedAwardSummary {
+	byName := make(map[string]*JudgedAwardSummary)
+	oprRanked := make(map[*JudgedAwardSummary]int)
+	var summaries []*JudgedAwardSummary
+	for _, award := range awards {
+		key := strings.ToLower(award.Award.Name)
+		summary, ok := byName[key]
+		if !ok {
+			summary = &JudgedAwardSummary{Name: award.Award.Name, Class: award.Class}
+			byName[key] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.Winners++
+		if award.Rank == 0 {
+			continue
+		}
+		summary.Ranked++
+		summary.AvgRank += float64(award.Rank)
+		if award.OPRRank > 0 {
+			summary.AvgOPRRank += float64(award.OPRRank)
+			oprRanked[summary]++
+		}
+		if award.TopQuarter() {
+			summary.TopQuarter++
+		}
+	}
+	for _, summary := range summaries {
+		if summary.Ranked > 0 {
+			summary.AvgRank /= float64(summary.Ranked)
+		}
+		if oprRanked[summary] > 0 {
+			summary.AvgOPRRank /= float64(oprRanked[summary])
+		}
+	}
+	slices.SortStableFunc(summaries, func(a, b *JudgedAwardSummary) int {
+		if c := cmp.Compare(getAwardSortPriority(a.Name), getAwardSortPriority(b.Name)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return summaries
+}
@@ -33,6 +33,8 @@ type TeamMatchResult struct {
 
 // MatchesByEventQuery retrieves all matches for an event, including alliance scores and all participating teams.
 func MatchesByEventQuery(eventCode string, year int) ([]*MatchDetails, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
 	// Get the event details
 	filter := database.EventFilter{
 		EventCodes: []string{eventCode},
@@ -95,6 +97,10 @@ func MatchesByEventQuery(eventCode string, year int) ([]*MatchDetails, error) {
 			if err != nil {
 				return nil, err
 			}
+			if t == nil {
+				// Matches entered by hand may include teams that haven't been synced
+				t = &database.Team{TeamID: team.TeamID}
+			}
 			if team.Alliance == database.AllianceRed {
 				redTeams = append(redTeams, t)
 			} else {
@@ -137,9 +143,25 @@ func MatchesByEventQuery(eventCode string, year int) ([]*MatchDetails, error) {
 	return results, nil
 }
 
+// RecentMatchesQuery retrieves the last matches played at an event, newest first, up to the limit, or every match if
+// the limit is 0. Playoff matches are played after qualification matches.
+func RecentMatchesQuery(eventCode string, year int, limit int) ([]*MatchDetails, error) {
+	results, err := MatchesByEventQuery(eventCode, year)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && limit < len(results) {
+		results = results[len(results)-limit:]
+	}
+	slices.Reverse(results)
+	return results, nil
+}
+
 // MatchesByEventAndTeamQuery retrieves all matches for a specific team at an event.
 // It shows the match from the team's perspective with their result (Won/Lost/Tied).
 func MatchesByEventAndTeamQuery(eventCode string, teamID int, year int) ([]*TeamMatchResult, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
 	// Get the event details
 	filter := database.EventFilter{
 		EventCodes: []string{eventCode},
@@ -296,6 +318,8 @@ func MatchesByEventAndTeamQuery(eventCode string, teamID int, year int) ([]*Team
 
 // GetEventTeamsQuery retrieves all EventTeam entries for a given event.
 func GetEventTeamsQuery(eventCode string, year int) ([]*database.EventTeam, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
 	// Get the event details
 	filter := database.EventFilter{
 		EventCodes: []string{eventCode},
@@ -326,11 +350,12 @@ func GetEventTeamsQuery(eventCode string, year int) ([]*database.EventTeam, erro
 	return teams, nil
 }
 
-// SaveEventTeam saves an EventTeam entry to the database.
+// SaveEventTeam saves an EventTeam entry to the database for a team that played at the event.
 func SaveEventTeam(eventID string, teamID int) error {
 	eventTeam := &database.EventTeam{
 		EventID: eventID,
 		TeamID:  teamID,
+		Played:  true,
 	}
 	return db.SaveEventTeam(eventTeam)
 }
@@ -0,0 +1,76 @@
+package query
+
+import (
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// DataScope identifies the data a response is built from: a single event, the events in a region, or, if neither
+// is given, every event in the season.
+type DataScope struct {
+	Year       int
+	EventCode  string
+	EventCodes []string // Codes of several events, for a scope that covers more than one event
+	RegionCode string   // Region code or alias
+}
+
+// LastModifiedQuery returns the latest time the data in the scope was created or changed by a sync, along with the
+// teams and award definitions. It returns the zero time if the scope has no events, such as when the event or
+// region isn't found.
+func LastModifiedQuery(scope DataScope) (time.Time, error) {
+	eventIDs, err := scopeEventIDs(scope)
+	if err != nil || len(eventIDs) == 0 {
+		return time.Time{}, err
+	}
+	return db.GetLastUpdated(database.LastUpdatedFilter{EventIDs: eventIDs})
+}
+
+// DataAsOfQuery returns the latest time the results of an event in the scope were successfully synced from the data
+// source, which tells whether the data is live or stale. It returns the zero time if no event in the scope has been
+// synced, or if the scope has no events.
+func DataAsOfQuery(scope DataScope) (time.Time, error) {
+	eventIDs, err := scopeEventIDs(scope)
+	if err != nil || len(eventIDs) == 0 {
+		return time.Time{}, err
+	}
+	syncs, err := db.GetEventSyncs(database.EventSyncFilter{EventIDs: eventIDs})
+	if err != nil {
+		return time.Time{}, err
+	}
+	var latest time.Time
+	for _, sync := range syncs {
+		if sync.SyncedAt.After(latest) {
+			latest = sync.SyncedAt
+		}
+	}
+	return latest, nil
+}
+
+// scopeEventIDs returns the IDs of the events in the scope.
+func scopeEventIDs(scope DataScope) ([]string, error) {
+	filter := database.EventFilter{Year: scope.Year}
+	if scope.EventCode != "" || len(scope.EventCodes) > 0 {
+		filter.EventCodes = database.NormalizeCodes(scope.EventCodes)
+		if scope.EventCode != "" {
+			filter.EventCodes = append(filter.EventCodes, database.NormalizeCode(scope.EventCode))
+		}
+	}
+	if scope.RegionCode != "" {
+		regionCode, err := ResolveRegion(scope.RegionCode)
+		if err != nil {
+			return nil, err
+		}
+		filter.RegionCodes = []string{regionCode}
+	}
+
+	events, err := db.GetAllEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+	eventIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+	return eventIDs, nil
+}
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// RankChange is the change in a team's region rank from the results of one event.
+type RankChange struct {
+	TeamID   int
+	TeamName string
+	Before   int // Region rank before the event, or 0 if the event was the team's first
+	After    int // Region rank including the event
+}
+
+// Places returns the number of places the team moved in the region's rankings. Positive values indicate the team
+// moved up.
+func (rc RankChange) Places() int {
+	return rc.Before - rc.After
+}
+
+// EventRankMovement summarizes how an event moved the teams that played in it within their region's team rankings.
+type EventRankMovement struct {
+	Event   *database.Event
+	Region  string
+	Ranked  int          // Number of teams in the region's rankings, including the event
+	Risers  []RankChange // Teams that moved up the most, biggest move first
+	Fallers []RankChange // Teams that moved down the most, biggest move first
+	New     []RankChange // Teams ranked for the first time, best rank first
+}
+
+// EventRankMovementQuery compares the region's team rankings with and without the event's results, and returns the
+// teams from the event's region that moved up and down the most, up to limit of each. Teams are ranked by npAVG,
+// the default order of the region's team rankings, and unofficial events aren't included. It returns nil if the
+// event has no team rankings.
+func EventRankMovementQuery(event *database.Event, limit int) (*EventRankMovement, error) {
+	region := event.RegionCode
+	teamMap, teamIDs, eventIDs, err := getTeamRankingScope(region, "", nil, event.Year, false, false)
+	if err != nil {
+		return nil, err
+	}
+	rankings, err := db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: teamIDs, EventIDs: eventIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	// The teams from the region that played in the event
+	played := make(map[int]bool)
+	var before []*database.TeamRanking
+	for _, ranking := range rankings {
+		if ranking.EventID == event.EventID {
+			played[ranking.TeamID] = true
+			continue
+		}
+		before = append(before, ranking)
+	}
+	if len(played) == 0 {
+		return nil, nil
+	}
+
+	keys := []SortKey{{Field: "npavg", Descending: true}}
+	current := consolidateTeamRankings(teamMap, rankings, "")
+	SortTeamPerformances(current, keys)
+	previous := consolidateTeamRankings(teamMap, before, "")
+	SortTeamPerformances(previous, keys)
+
+	previousRanks := make(map[int]int, len(previous))
+	for i, perf := range previous {
+		previousRanks[perf.TeamID] = i + 1
+	}
+
+	movement := &EventRankMovement{
+		Event:  event,
+		Region: region,
+		Ranked: len(current),
+	}
+	for i, perf := range current {
+		if !played[perf.TeamID] {
+			continue
+		}
+		change := RankChange{
+			TeamID:   perf.TeamID,
+			TeamName: perf.TeamName,
+			Before:   previousRanks[perf.TeamID],
+			After:    i + 1,
+		}
+		switch {
+		case change.Before == 0:
+			movement.New = append(movement.New, change)
+		case change.Places() > 0:
+			movement.Risers = append(movement.Risers, change)
+		case change.Places() < 0:
+			movement.Fallers = append(movement.Fallers, change)
+		}
+	}
+
+	// Biggest moves first, with ties in the order of the current rankings
+	slices.SortStableFunc(movement.Risers, func(a, b RankChange) int {
+		return cmp.Compare(b.Places(), a.Places())
+	})
+	slices.SortStableFunc(movement.Fallers, func(a, b RankChange) int {
+		return cmp.Compare(a.Places(), b.Places())
+	})
+	movement.Risers = movement.Risers[:min(limit, len(movement.Risers))]
+	movement.Fallers = movement.Fallers[:min(limit, len(movement.Fallers))]
+	movement.New = movement.New[:min(limit, len(movement.New))]
+
+	return movement, nil
+}
@@ -0,0 +1,374 @@
+package query
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// OverlayTeam represents a team as it is shown on a stream overlay, with its standing at the event.
+type OverlayTeam struct {
+	Team         *database.Team
+	Rank         int     // Qualification rank, or 0 if the team isn't ranked yet
+	Wins         int     // Qualification wins
+	Losses       int     // Qualification losses
+	Ties         int     // Qualification ties
+	RankingScore float64 // Ranking score, the first sort order of the rankings
+	OPR          float64 // OPR at the event
+	NpOPR        float64 // Non-penalty OPR at the event
+}
+
+// OverlayMatch represents a match as it is shown on a stream overlay.
+type OverlayMatch struct {
+	Event     *database.Event
+	Match     *database.Match // The match, or nil if there is no match to show
+	Red       []*OverlayTeam
+	Blue      []*OverlayTeam
+	RedScore  *database.MatchAllianceScore // Red alliance's score, or nil if the match hasn't been played
+	BlueScore *database.MatchAllianceScore // Blue alliance's score, or nil if the match hasn't been played
+}
+
+// Label returns the short name of the match, such as "Q12" for the 12th qualification match or "P3" for the 3rd
+// playoff series. It returns an empty string if there is no match.
+func (om *OverlayMatch) Label() string {
+	if om.Match == nil || om.Match.TournamentLevel == "" {
+		return ""
+	}
+	return strings.ToUpper(om.Match.TournamentLevel[:1]) + strconv.Itoa(om.Match.MatchNumber)
+}
+
+// Winner returns the alliance that won the match, "tie" if the scores are even, or an empty string if the match
+// hasn't been played.
+func (om *OverlayMatch) Winner() string {
+	if om.RedScore == nil || om.BlueScore == nil {
+		return ""
+	}
+	switch {
+	case om.RedScore.TotalPoints > om.BlueScore.TotalPoints:
+		return database.AllianceRed
+	case om.BlueScore.TotalPoints > om.RedScore.TotalPoints:
+		return database.AllianceBlue
+	}
+	return "tie"
+}
+
+// OverlayLeaderboardQuery returns the teams at the top of an event's qualification rankings, up to the limit, or
+// every ranked team if the limit is 0. It returns nil if the event isn't found.
+func OverlayLeaderboardQuery(eventCode string, year int, limit int) ([]*OverlayTeam, error) {
+	event, err := overlayEvent(eventCode, year)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	teams, err := overlayTeams(event)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := []*OverlayTeam{}
+	for _, team := range teams {
+		if team.Rank > 0 {
+			leaderboard = append(leaderboard, team)
+		}
+	}
+	slices.SortFunc(leaderboard, func(a, b *OverlayTeam) int {
+		return a.Rank - b.Rank
+	})
+	if limit > 0 && limit < len(leaderboard) {
+		leaderboard = leaderboard[:limit]
+	}
+	return leaderboard, nil
+}
+
+// OPRLeaderboardQuery returns the teams with the highest OPRs at an event, up to the limit, or every team with an OPR
+// if the limit is 0. Teams with the same OPR are ordered by their qualification rank. It returns nil if the event
+// isn't found.
+func OPRLeaderboardQuery(eventCode string, year int, limit int) ([]*OverlayTeam, error) {
+	event, err := overlayEvent(eventCode, year)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	teams, err := overlayTeams(event)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := []*OverlayTeam{}
+	for _, team := range teams {
+		if team.OPR != 0 || team.NpOPR != 0 {
+			leaderboard = append(leaderboard, team)
+		}
+	}
+	slices.SortFunc(leaderboard, func(a, b *OverlayTeam) int {
+		return cmp.Or(cmp.Compare(b.OPR, a.OPR), a.Rank-b.Rank, a.Team.TeamID-b.Team.TeamID)
+	})
+	if limit > 0 && limit < len(leaderboard) {
+		leaderboard = leaderboard[:limit]
+	}
+	return leaderboard, nil
+}
+
+// CurrentMatchQuery returns the match that is on the field or next up at an event: the first match in the event's
+// schedule, qualification matches before playoff matches, that doesn't have a result yet. A playoff series counts
+// as played once a result is saved for it, as results are saved by series. The schedule is requested from the FTC
+// API; if it can't be fetched, the match is left empty. It returns nil if the event isn't found.
+func CurrentMatchQuery(eventCode string, year int) (*OverlayMatch, error) {
+	event, err := overlayEvent(eventCode, year)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	upcoming, err := upcomingMatches(event, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(upcoming) == 0 {
+		return &OverlayMatch{Event: event, Red: []*OverlayTeam{}, Blue: []*OverlayTeam{}}, nil
+	}
+	return upcoming[0], nil
+}
+
+// UpcomingMatchesQuery returns the matches in an event's schedule that don't have a result yet, in the order they
+// will be played, up to the limit, or every unplayed match if the limit is 0. The first match is the one returned by
+// CurrentMatchQuery. Playoff matches are only included once they have been scheduled. If the schedule of a
+// tournament level can't be fetched from the FTC API, the matches of the earlier levels are returned. It returns nil
+// if the event isn't found.
+func UpcomingMatchesQuery(eventCode string, year int, limit int) ([]*OverlayMatch, error) {
+	event, err := overlayEvent(eventCode, year)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	return upcomingMatches(event, limit)
+}
+
+// upcomingMatches returns the unplayed matches in the event's schedule, up to the limit, or every unplayed match if
+// the limit is 0.
+func upcomingMatches(event *database.Event, limit int) ([]*OverlayMatch, error) {
+	matches, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	played := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		played[match.MatchID] = true
+	}
+
+	var unplayed []*ftc.EventSchedule
+	for _, level := range []ftc.MatchType{ftc.QUALIFIER, ftc.PLAYOFF} {
+		if limit > 0 && len(unplayed) >= limit {
+			break
+		}
+		schedule, err := ftc.GetEventSchedule(strconv.Itoa(event.Year), event.EventCode, level)
+		if err != nil {
+			slog.Warn("Failed to fetch the schedule for the upcoming matches", "eventCode", event.EventCode, "year", event.Year, "level", level, "error", err)
+			break
+		}
+		slices.SortFunc(schedule, func(a, b *ftc.EventSchedule) int {
+			return cmp.Or(a.Series-b.Series, a.MatchNumber-b.MatchNumber)
+		})
+		for _, scheduled := range schedule {
+			matchID := database.GetMatchID(event, scheduled.TournamentLevel, scheduledMatchNumber(scheduled))
+			if played[matchID] {
+				continue
+			}
+			// A playoff series is scheduled as several matches, which are saved as one
+			played[matchID] = true
+			unplayed = append(unplayed, scheduled)
+		}
+	}
+	if limit > 0 && limit < len(unplayed) {
+		unplayed = unplayed[:limit]
+	}
+	if len(unplayed) == 0 {
+		return []*OverlayMatch{}, nil
+	}
+
+	teams, err := overlayTeams(event)
+	if err != nil {
+		return nil, err
+	}
+	upcoming := make([]*OverlayMatch, 0, len(unplayed))
+	for _, scheduled := range unplayed {
+		overlay := &OverlayMatch{
+			Event: event,
+			Match: &database.Match{
+				MatchID:         database.GetMatchID(event, scheduled.TournamentLevel, scheduledMatchNumber(scheduled)),
+				EventID:         event.EventID,
+				MatchNumber:     scheduledMatchNumber(scheduled),
+				ActualStartTime: database.ParseStartTime(scheduled.StartTime, event.Location()),
+				Description:     scheduled.Description,
+				TournamentLevel: scheduled.TournamentLevel,
+			},
+			Red:  []*OverlayTeam{},
+			Blue: []*OverlayTeam{},
+		}
+		for _, station := range scheduled.Teams {
+			if station.TeamNumber == 0 {
+				continue
+			}
+			team, err := overlayTeam(teams, station.TeamNumber)
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(strings.ToLower(station.Station), database.AllianceRed) {
+				overlay.Red = append(overlay.Red, team)
+			} else {
+				overlay.Blue = append(overlay.Blue, team)
+			}
+		}
+		upcoming = append(upcoming, overlay)
+	}
+	return upcoming, nil
+}
+
+// LastMatchQuery returns the result of the last match played at an event: the latest playoff series if the playoffs
+// have started, and otherwise the latest qualification match. If no match has been played, the match is left empty.
+// It returns nil if the event isn't found.
+func LastMatchQuery(eventCode string, year int) (*OverlayMatch, error) {
+	event, err := overlayEvent(eventCode, year)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	overlay := &OverlayMatch{Event: event, Red: []*OverlayTeam{}, Blue: []*OverlayTeam{}}
+
+	matches, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		if overlay.Match == nil || laterMatch(match, overlay.Match) {
+			overlay.Match = match
+		}
+	}
+	if overlay.Match == nil {
+		return overlay, nil
+	}
+
+	overlay.RedScore, err = db.GetMatchAllianceScore(overlay.Match.MatchID, database.AllianceRed)
+	if err != nil {
+		return nil, err
+	}
+	overlay.BlueScore, err = db.GetMatchAllianceScore(overlay.Match.MatchID, database.AllianceBlue)
+	if err != nil {
+		return nil, err
+	}
+
+	matchTeams, err := db.GetMatchTeams(overlay.Match.MatchID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := overlayTeams(event)
+	if err != nil {
+		return nil, err
+	}
+	for _, mt := range matchTeams {
+		team, err := overlayTeam(teams, mt.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if mt.Alliance == database.AllianceRed {
+			overlay.Red = append(overlay.Red, team)
+		} else {
+			overlay.Blue = append(overlay.Blue, team)
+		}
+	}
+	slices.SortFunc(overlay.Red, func(a, b *OverlayTeam) int { return a.Team.TeamID - b.Team.TeamID })
+	slices.SortFunc(overlay.Blue, func(a, b *OverlayTeam) int { return a.Team.TeamID - b.Team.TeamID })
+	return overlay, nil
+}
+
+// overlayEvent returns the event with the code in the given season, or nil if it isn't found.
+func overlayEvent(eventCode string, year int) (*database.Event, error) {
+	events, err := db.GetAllEvents(database.EventFilter{EventCodes: []string{database.NormalizeCode(eventCode)}, Year: year})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.Year == year {
+			return event, nil
+		}
+	}
+	return nil, nil
+}
+
+// overlayTeams returns the ranking and OPRs of each team ranked at the event, keyed by team ID.
+func overlayTeams(event *database.Event) (map[int]*OverlayTeam, error) {
+	rankings, err := db.GetEventRankings(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	teamRankings, err := db.GetTeamRankings(database.TeamRankingFilter{EventIDs: []string{event.EventID}})
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make(map[int]*OverlayTeam)
+	for _, ranking := range rankings {
+		teams[ranking.TeamID] = &OverlayTeam{
+			Rank:         ranking.Rank,
+			Wins:         ranking.Wins,
+			Losses:       ranking.Losses,
+			Ties:         ranking.Ties,
+			RankingScore: ranking.SortOrder1,
+		}
+	}
+	for _, tr := range teamRankings {
+		team, ok := teams[tr.TeamID]
+		if !ok {
+			team = &OverlayTeam{}
+			teams[tr.TeamID] = team
+		}
+		team.OPR = tr.OPR
+		team.NpOPR = tr.NpOPR
+	}
+	for teamID, team := range teams {
+		t, err := db.GetTeam(teamID)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			t = &database.Team{TeamID: teamID}
+		}
+		team.Team = t
+	}
+	return teams, nil
+}
+
+// overlayTeam returns the team from the event's teams, or looks it up if the team hasn't been ranked at the event.
+func overlayTeam(teams map[int]*OverlayTeam, teamID int) (*OverlayTeam, error) {
+	if team, ok := teams[teamID]; ok {
+		return team, nil
+	}
+	t, err := db.GetTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		// Teams that haven't been synced are shown by number alone
+		t = &database.Team{TeamID: teamID}
+	}
+	return &OverlayTeam{Team: t}, nil
+}
+
+// scheduledMatchNumber returns the number a scheduled match is saved under: the series for playoff matches, and
+// the match number otherwise.
+func scheduledMatchNumber(scheduled *ftc.EventSchedule) int {
+	if strings.EqualFold(scheduled.TournamentLevel, string(ftc.PLAYOFF)) {
+		return scheduled.Series
+	}
+	return scheduled.MatchNumber
+}
+
+// laterMatch returns true if match a was played after match b. Playoff matches are played after qualification
+// matches, and matches at the same level are played in order of their number.
+func laterMatch(a, b *database.Match) bool {
+	aPlayoff := strings.EqualFold(a.TournamentLevel, string(ftc.PLAYOFF))
+	bPlayoff := strings.EqualFold(b.TournamentLevel, string(ftc.PLAYOFF))
+	if aPlayoff != bPlayoff {
+		return aPlayoff
+	}
+	return a.MatchNumber > b.MatchNumber
+}
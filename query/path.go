@@ -0,0 +1,218 @@
+package query
+
+import (
+	"slices"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// EventTier is a level of the advancement chain, from league meets up to the FIRST Championship.
+type EventTier int
+
+const (
+	TierNone             EventTier = iota // Events that aren't part of the advancement chain, such as scrimmages
+	TierLeagueMeet                        // League meets
+	TierLeagueTournament                  // League tournaments
+	TierQualifier                         // Qualifiers and super qualifiers
+	TierChampionship                      // Regional championships
+	TierWorlds                            // The FIRST Championship
+)
+
+// eventTiers maps the event type codes used by the FTC Events API to the tier of the advancement chain the events
+// belong to. Other event types, such as scrimmages and off-season events, aren't part of the chain.
+var eventTiers = map[string]EventTier{
+	"1": TierLeagueMeet,
+	"2": TierQualifier,
+	"3": TierLeagueTournament,
+	"4": TierChampionship,
+	"6": TierWorlds,
+	"7": TierQualifier,
+}
+
+// tierNames are the names of the tiers.
+var tierNames = map[EventTier]string{
+	TierNone:             "None",
+	TierLeagueMeet:       "League Meet",
+	TierLeagueTournament: "League Tournament",
+	TierQualifier:        "Qualifier",
+	TierChampionship:     "Championship",
+	TierWorlds:           "Worlds",
+}
+
+// String returns the name of the tier.
+func (t EventTier) String() string {
+	if name, ok := tierNames[t]; ok {
+		return name
+	}
+	return tierNames[TierNone]
+}
+
+// MarshalText returns the name of the tier, so tiers are given by name in JSON responses.
+func (t EventTier) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// TierOf returns the tier of the advancement chain an event belongs to, or TierNone if the event isn't part of the
+// chain.
+func TierOf(event *database.Event) EventTier {
+	return eventTiers[event.Type]
+}
+
+// nextTier returns the tier a team advances to from an event in the given tier. Teams advance from league
+// tournaments and qualifiers to the regional championship, and from the regional championship to Worlds.
+func nextTier(tier EventTier) EventTier {
+	switch tier {
+	case TierLeagueMeet:
+		return TierLeagueTournament
+	case TierLeagueTournament, TierQualifier:
+		return TierChampionship
+	case TierChampionship:
+		return TierWorlds
+	}
+	return TierNone
+}
+
+// PathEvent represents a team's result at an event in its advancement chain.
+type PathEvent struct {
+	EventCode string
+	EventName string
+	DateStart time.Time
+	QualRank  int      // Qualification rank, or 0 if the team wasn't ranked
+	Advanced  bool     // Whether the team advanced from the event
+	Status    string   // Advancement status, such as "already_advancing"
+	Awards    []string // Awards won at the event
+}
+
+// PathStep represents a tier of a team's advancement chain.
+type PathStep struct {
+	Tier     EventTier
+	Events   []PathEvent // Events at the tier, ordered by date, or empty if the team has qualified but not yet competed
+	Advanced bool        // Whether the team advanced from any event at the tier
+}
+
+// AdvancementPath represents a team's progression through the tiers of the advancement chain in a season.
+type AdvancementPath struct {
+	TeamID       int
+	Name         string
+	Steps        []PathStep // Tiers the team competed at or qualified for, from the lowest to the highest
+	HighestTier  EventTier  // Highest tier the team competed at, or TierNone if it hasn't competed in the chain
+	QualifiedFor EventTier  // Tier the team has qualified for but not yet competed at, or TierNone
+}
+
+// AdvancementPathQuery follows a team's advancement chain (league meet, league tournament, qualifier, regional
+// championship, and Worlds) through the events it attended. Each tier the team competed at lists its events and
+// whether the team advanced from them. If the team advanced to a tier above the highest one it competed at, that
+// tier is included with no events, since the team has qualified for it but hasn't competed there yet. It returns
+// nil if the team isn't found.
+func AdvancementPathQuery(teamID int) (*AdvancementPath, error) {
+	team, err := db.GetTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, nil
+	}
+
+	eventIDs, err := db.GetEventsByTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make(map[EventTier]*PathStep)
+	for _, eventID := range eventIDs {
+		event, err := db.GetEvent(eventID)
+		if err != nil {
+			return nil, err
+		}
+		if event == nil {
+			continue
+		}
+		tier := TierOf(event)
+		if tier == TierNone {
+			continue
+		}
+		eventTeams, err := db.GetEventTeams(eventID)
+		if err != nil {
+			return nil, err
+		}
+		if !playedEvent(eventTeams, teamID) {
+			continue
+		}
+
+		pathEvent := PathEvent{
+			EventCode: event.EventCode,
+			EventName: event.Name,
+			DateStart: event.DateStart,
+			Awards:    []string{},
+		}
+
+		rankings, err := db.GetEventRankings(eventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ranking := range rankings {
+			if ranking.TeamID == teamID {
+				pathEvent.QualRank = ranking.Rank
+				break
+			}
+		}
+
+		advancements, err := db.GetEventAdvancements(eventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, adv := range advancements {
+			if adv.TeamID == teamID {
+				pathEvent.Advanced = true
+				pathEvent.Status = adv.Status
+				break
+			}
+		}
+
+		awards, err := db.GetTeamAwardsByEvent(eventID, teamID)
+		if err != nil {
+			return nil, err
+		}
+		for _, award := range awards {
+			pathEvent.Awards = append(pathEvent.Awards, award.Name)
+		}
+
+		step, ok := steps[tier]
+		if !ok {
+			step = &PathStep{Tier: tier}
+			steps[tier] = step
+		}
+		step.Events = append(step.Events, pathEvent)
+		step.Advanced = step.Advanced || pathEvent.Advanced
+	}
+
+	path := &AdvancementPath{
+		TeamID: team.TeamID,
+		Name:   team.Name,
+		Steps:  make([]PathStep, 0, len(steps)+1),
+	}
+	for tier := TierLeagueMeet; tier <= TierWorlds; tier++ {
+		step, ok := steps[tier]
+		if !ok {
+			continue
+		}
+		slices.SortFunc(step.Events, func(a, b PathEvent) int {
+			return a.DateStart.Compare(b.DateStart)
+		})
+		path.Steps = append(path.Steps, *step)
+		path.HighestTier = tier
+	}
+
+	// A team that advanced to a tier above the highest one it competed at has qualified for that tier
+	for _, step := range path.Steps {
+		if next := nextTier(step.Tier); step.Advanced && next > path.HighestTier {
+			path.QualifiedFor = next
+		}
+	}
+	if path.QualifiedFor != TierNone {
+		path.Steps = append(path.Steps, PathStep{Tier: path.QualifiedFor, Events: []PathEvent{}})
+	}
+
+	return path, nil
+}
@@ -0,0 +1,192 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// PreviewTeam represents a team in the field of an upcoming event, with its season before the event.
+type PreviewTeam struct {
+	Team         *database.Team
+	Events       int     // Official events the team played before the event
+	Matches      int     // Matches played at those events
+	OPR          float64 // Weighted by the matches played at each event, the same as the team rankings
+	NpOPR        float64 // Weighted the same way
+	NpAVG        float64 // Weighted the same way
+	Rookie       bool    // The event is in the team's rookie season
+	AdvancedFrom string  // Code of the event the team already advanced from this season, if any
+}
+
+// HasMetrics returns true if the team has played an official event this season before the event.
+func (pt *PreviewTeam) HasMetrics() bool {
+	return pt.Matches > 0
+}
+
+// EventPreview summarizes the field of an event before it is played, for media previews and scouting.
+type EventPreview struct {
+	Event     *database.Event
+	Teams     int            // Teams in the field
+	Unranked  int            // Teams in the field that haven't played an official event this season
+	TopTeams  []*PreviewTeam // Teams with the best npOPR this season, highest first
+	Seeds     []*PreviewTeam // Predicted top seeds, one for each playoff alliance
+	Advancers []*PreviewTeam // Teams that have already advanced from an earlier event this season
+	Rookies   []*PreviewTeam // Teams in their rookie season
+}
+
+// EventPreviewQuery previews the field of an event: the teams registered for it, or the teams that played in it if
+// no registrations were synced. Each team's season is taken from the official events that ended before the event
+// started, so a preview of a past event shows the field as it was going in. The top teams are the limit teams with
+// the best npOPR this season, or every ranked team if limit is 0.
+//
+// Qualification rankings follow wins, which an alliance's combined OPR predicts, so the predicted top seeds are the
+// teams with the best OPR this season, one for each playoff alliance the field supports. It returns nil if the event
+// isn't found.
+func EventPreviewQuery(eventCode string, year int, limit int) (*EventPreview, error) {
+	eventCode = database.NormalizeCode(eventCode)
+
+	events, err := db.GetAllEvents(database.EventFilter{EventCodes: []string{eventCode}, Year: year})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	event := events[0]
+
+	eventTeams, err := db.GetEventTeams(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(eventTeams, func(et *database.EventTeam) bool { return et.Registered }) {
+		eventTeams = slices.DeleteFunc(eventTeams, func(et *database.EventTeam) bool { return !et.Registered })
+	}
+
+	preview := &EventPreview{
+		Event:     event,
+		Teams:     len(eventTeams),
+		TopTeams:  []*PreviewTeam{},
+		Seeds:     []*PreviewTeam{},
+		Advancers: []*PreviewTeam{},
+		Rookies:   []*PreviewTeam{},
+	}
+	if len(eventTeams) == 0 {
+		return preview, nil
+	}
+
+	// The season before the event is made up of the official events that ended before it started
+	seasonEvents, err := getRankedEvents(database.EventFilter{Year: year}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	var eventIDs []string
+	for _, e := range seasonEvents {
+		if e.EventID != event.EventID && e.DateEnd.Before(event.DateStart) {
+			eventIDs = append(eventIDs, e.EventID)
+		}
+	}
+	slices.SortFunc(seasonEvents, func(a, b *database.Event) int {
+		return a.DateStart.Compare(b.DateStart)
+	})
+
+	teamIDs := make([]int, 0, len(eventTeams))
+	teamMap := make(map[int]*database.Team, len(eventTeams))
+	teams := make([]*PreviewTeam, 0, len(eventTeams))
+	for _, et := range eventTeams {
+		team, err := db.GetTeam(et.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			team = &database.Team{TeamID: et.TeamID}
+		}
+		teamIDs = append(teamIDs, et.TeamID)
+		teamMap[et.TeamID] = team
+		teams = append(teams, &PreviewTeam{Team: team, Rookie: team.RookieYear == year})
+	}
+
+	if len(eventIDs) > 0 {
+		rankings, err := db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: teamIDs, EventIDs: eventIDs})
+		if err != nil {
+			return nil, err
+		}
+		events := make(map[int]int)
+		for _, ranking := range rankings {
+			events[ranking.TeamID]++
+		}
+		season := make(map[int]TeamPerformance)
+		for _, perf := range consolidateTeamRankings(teamMap, rankings, "") {
+			season[perf.TeamID] = perf
+		}
+		for _, pt := range teams {
+			if perf, ok := season[pt.Team.TeamID]; ok {
+				pt.Events = events[pt.Team.TeamID]
+				pt.Matches = perf.Matches
+				pt.OPR = perf.OPR
+				pt.NpOPR = perf.NpOPR
+				pt.NpAVG = perf.NpAVG
+			}
+		}
+	}
+
+	// Advancements from the earlier events, in date order so each team is credited with the first event it
+	// advanced from
+	advancedFrom := make(map[int]string)
+	for _, e := range seasonEvents {
+		if !slices.Contains(eventIDs, e.EventID) {
+			continue
+		}
+		advancements, err := db.GetEventAdvancements(e.EventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, adv := range advancements {
+			if _, ok := advancedFrom[adv.TeamID]; !ok {
+				advancedFrom[adv.TeamID] = e.EventCode
+			}
+		}
+	}
+
+	var ranked []*PreviewTeam
+	for _, pt := range teams {
+		pt.AdvancedFrom = advancedFrom[pt.Team.TeamID]
+		if pt.AdvancedFrom != "" {
+			preview.Advancers = append(preview.Advancers, pt)
+		}
+		if pt.Rookie {
+			preview.Rookies = append(preview.Rookies, pt)
+		}
+		if pt.HasMetrics() {
+			ranked = append(ranked, pt)
+		} else {
+			preview.Unranked++
+		}
+	}
+
+	byMetric := func(metric func(*PreviewTeam) float64) func(a, b *PreviewTeam) int {
+		return func(a, b *PreviewTeam) int {
+			if c := cmp.Compare(metric(b), metric(a)); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Team.TeamID, b.Team.TeamID)
+		}
+	}
+	byTeam := func(a, b *PreviewTeam) int {
+		return cmp.Compare(a.Team.TeamID, b.Team.TeamID)
+	}
+
+	slices.SortFunc(ranked, byMetric(func(pt *PreviewTeam) float64 { return pt.NpOPR }))
+	preview.TopTeams = append(preview.TopTeams, ranked...)
+	if limit > 0 && limit < len(preview.TopTeams) {
+		preview.TopTeams = preview.TopTeams[:limit]
+	}
+
+	seeds := slices.Clone(ranked)
+	slices.SortFunc(seeds, byMetric(func(pt *PreviewTeam) float64 { return pt.OPR }))
+	preview.Seeds = append(preview.Seeds, seeds[:min(len(seeds), allianceCount(len(eventTeams)))]...)
+
+	slices.SortFunc(preview.Advancers, byMetric(func(pt *PreviewTeam) float64 { return pt.NpOPR }))
+	slices.SortFunc(preview.Rookies, byTeam)
+	return preview, nil
+}
@@ -0,0 +1,215 @@
+package query
+
+import (
+	"math"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// ProjectionStatus describes how likely a team is to be in the regional championship field.
+type ProjectionStatus string
+
+const (
+	ProjectionLocked ProjectionStatus = "locked" // The team has already advanced
+	ProjectionLikely ProjectionStatus = "likely" // The team is projected to advance from a remaining event
+	ProjectionBubble ProjectionStatus = "bubble" // The team is the next in line at a remaining event
+)
+
+// ProjectedTeam represents a team in the projected championship field.
+type ProjectedTeam struct {
+	Team   *database.Team
+	Status ProjectionStatus
+	Event  *database.Event // Event the team advanced from, or is projected to advance from or be on the bubble at
+	NpOPR  float64         // Best npOPR at any event this season, used to project the remaining events
+}
+
+// RemainingEvent represents an event in the region that teams may still advance from.
+type RemainingEvent struct {
+	Event      *database.Event
+	Registered int // Teams registered for the event
+	Slots      int // Advancement slots projected for the event
+	OpenSlots  int // Slots that couldn't be projected because too few teams that haven't advanced are registered
+}
+
+// ChampionshipProjection represents the projected field of a region's championship.
+type ChampionshipProjection struct {
+	RegionCode      string
+	Year            int
+	Championship    *database.Event // The region's championship, or nil if it isn't in the database
+	CompletedEvents int             // Events that teams have advanced from
+	SlotsPerEvent   int             // Advancement slots projected for each remaining event
+	RemainingEvents []*RemainingEvent
+	Teams           []*ProjectedTeam // Ordered by status, then by npOPR from the highest to the lowest
+}
+
+// ChampionshipProjectionQuery projects the field of a region's championship from the teams that have already
+// advanced and the teams registered for the region's remaining league tournaments and qualifiers. An event is
+// remaining until advancements are recorded for it. At each remaining event, in date order, the teams that haven't
+// advanced are ranked by their best npOPR this season; the top teams are projected to take the event's slots, and
+// the teams behind them are on the bubble. The number of slots at each remaining event is slotsPerEvent, or, if
+// it is 0, the average number of teams that advanced from each completed event.
+func ChampionshipProjectionQuery(regionCode string, year int, slotsPerEvent int) (*ChampionshipProjection, error) {
+	regionCode = database.NormalizeCode(regionCode)
+
+	official := false
+	events, err := db.GetAllEvents(database.EventFilter{RegionCodes: []string{regionCode}, Year: year, Unofficial: &official})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(events, func(a, b *database.Event) int {
+		return a.DateStart.Compare(b.DateStart)
+	})
+
+	projection := &ChampionshipProjection{
+		RegionCode:      regionCode,
+		Year:            year,
+		RemainingEvents: []*RemainingEvent{},
+		Teams:           []*ProjectedTeam{},
+	}
+
+	// Teams that have already advanced are locked into the field
+	projected := make(map[int]*ProjectedTeam)
+	var remaining []*database.Event
+	advancedTotal := 0
+	for _, event := range events {
+		tier := TierOf(event)
+		if tier == TierChampionship && projection.Championship == nil {
+			projection.Championship = event
+		}
+		if tier != TierLeagueTournament && tier != TierQualifier {
+			continue
+		}
+
+		advancements, err := db.GetEventAdvancements(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if len(advancements) == 0 {
+			remaining = append(remaining, event)
+			continue
+		}
+
+		projection.CompletedEvents++
+		for _, adv := range advancements {
+			if adv.Status == "already_advancing" {
+				continue
+			}
+			advancedTotal++
+			if _, ok := projected[adv.TeamID]; !ok {
+				projected[adv.TeamID] = &ProjectedTeam{Status: ProjectionLocked, Event: event}
+			}
+		}
+	}
+
+	projection.SlotsPerEvent = slotsPerEvent
+	if slotsPerEvent <= 0 && projection.CompletedEvents > 0 {
+		projection.SlotsPerEvent = int(math.Round(float64(advancedTotal) / float64(projection.CompletedEvents)))
+	}
+
+	// Rank the teams by their best npOPR at any event this season, including events outside the region
+	seasonEvents, err := db.GetAllEvents(database.EventFilter{Year: year})
+	if err != nil {
+		return nil, err
+	}
+	eventIDs := make([]string, 0, len(seasonEvents))
+	for _, event := range seasonEvents {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+	var rankings []*database.TeamRanking
+	if len(eventIDs) > 0 {
+		rankings, err = db.GetTeamRankings(database.TeamRankingFilter{EventIDs: eventIDs})
+		if err != nil {
+			return nil, err
+		}
+	}
+	bestNpOPR := make(map[int]float64)
+	for _, ranking := range rankings {
+		if npOPR, ok := bestNpOPR[ranking.TeamID]; !ok || ranking.NpOPR > npOPR {
+			bestNpOPR[ranking.TeamID] = ranking.NpOPR
+		}
+	}
+
+	// Project the remaining events in date order, so a team projected to advance from an earlier event passes
+	// its slot at later events to the next team
+	for _, event := range remaining {
+		eventTeams, err := db.GetEventTeams(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		var contenders []int
+		for _, et := range eventTeams {
+			if p, ok := projected[et.TeamID]; ok && p.Status != ProjectionBubble {
+				continue
+			}
+			contenders = append(contenders, et.TeamID)
+		}
+		slices.SortFunc(contenders, func(a, b int) int {
+			if bestNpOPR[a] != bestNpOPR[b] {
+				if bestNpOPR[a] > bestNpOPR[b] {
+					return -1
+				}
+				return 1
+			}
+			return a - b
+		})
+
+		remainingEvent := &RemainingEvent{
+			Event:      event,
+			Registered: len(eventTeams),
+			Slots:      projection.SlotsPerEvent,
+		}
+		for i, teamID := range contenders {
+			switch {
+			case i < projection.SlotsPerEvent:
+				projected[teamID] = &ProjectedTeam{Status: ProjectionLikely, Event: event}
+			case i < 2*projection.SlotsPerEvent:
+				if _, ok := projected[teamID]; !ok {
+					projected[teamID] = &ProjectedTeam{Status: ProjectionBubble, Event: event}
+				}
+			}
+		}
+		remainingEvent.OpenSlots = max(projection.SlotsPerEvent-len(contenders), 0)
+		projection.RemainingEvents = append(projection.RemainingEvents, remainingEvent)
+	}
+
+	for teamID, p := range projected {
+		team, err := db.GetTeam(teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			continue
+		}
+		p.Team = team
+		p.NpOPR = bestNpOPR[teamID]
+		projection.Teams = append(projection.Teams, p)
+	}
+
+	statusOrder := map[ProjectionStatus]int{ProjectionLocked: 0, ProjectionLikely: 1, ProjectionBubble: 2}
+	slices.SortFunc(projection.Teams, func(a, b *ProjectedTeam) int {
+		if statusOrder[a.Status] != statusOrder[b.Status] {
+			return statusOrder[a.Status] - statusOrder[b.Status]
+		}
+		if a.NpOPR != b.NpOPR {
+			if a.NpOPR > b.NpOPR {
+				return -1
+			}
+			return 1
+		}
+		return a.Team.TeamID - b.Team.TeamID
+	})
+
+	return projection, nil
+}
+
+// FieldSize returns the number of teams that are locked into or projected to advance to the championship.
+func (cp *ChampionshipProjection) FieldSize() int {
+	size := 0
+	for _, team := range cp.Teams {
+		if team.Status != ProjectionBubble {
+			size++
+		}
+	}
+	return size
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// QueuedMatch represents an unplayed match in the queue at an event.
+type QueuedMatch struct {
+	*OverlayMatch
+	MatchesAway int           // Number of matches to be played before this one, 0 for the match that is on the field or next up
+	Wait        time.Duration // Estimated time until the match starts, or 0 if the cycle time isn't known
+}
+
+// QueueReport represents the next matches to be played at an event, for the volunteers who queue the teams for them.
+type QueueReport struct {
+	Event     *database.Event
+	Matches   []*QueuedMatch
+	CycleTime time.Duration // Average time between the starts of consecutive matches, or 0 if it can't be estimated
+	Cycles    int           // Number of match cycles the cycle time is averaged over
+}
+
+// QueueQuery returns the next unplayed matches at an event, in the order they will be played, up to the limit, or
+// every unplayed match if the limit is 0. The matches are taken from the event's schedule, as with
+// UpcomingMatchesQuery. The wait for each match is estimated from the average cycle time of the matches played so
+// far, which is measured from their actual start times. It returns nil if the event isn't found.
+func QueueQuery(eventCode string, year int, limit int) (*QueueReport, error) {
+	event, err := overlayEvent(eventCode, year)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	upcoming, err := upcomingMatches(event, limit)
+	if err != nil {
+		return nil, err
+	}
+	played, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &QueueReport{Event: event, Matches: make([]*QueuedMatch, 0, len(upcoming))}
+	report.CycleTime, report.Cycles = cycleTime(matchStarts(played, event.Location()))
+	for i, match := range upcoming {
+		report.Matches = append(report.Matches, &QueuedMatch{
+			OverlayMatch: match,
+			MatchesAway:  i,
+			Wait:         time.Duration(i) * report.CycleTime,
+		})
+	}
+	return report, nil
+}
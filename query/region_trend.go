@@ -0,0 +1,145 @@
+package query
+
+import (
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// RegionSeason summarizes how competitive a region was in a season.
+type RegionSeason struct {
+	Year           int
+	Events         int     // Official events in the advancement chain that have been played
+	Teams          int     // Teams ranked at any of those events
+	Matches        int     // Qualification and playoff matches played at the events that have been summarized
+	AverageScore   float64 // Average alliance score, weighted by the matches played at each event
+	AverageNpScore float64 // Average alliance score without penalty points, weighted the same way
+	HighScore      int     // Highest alliance score at any of the events
+	AverageNpOPR   float64 // Average NpOPR of the teams at the events, weighted by the teams at each event
+	ToChampionship int     // Teams that advanced from a league tournament or qualifier to the championship
+	ToWorlds       int     // Teams that advanced from the championship to the FIRST Championship
+}
+
+// RegionTrend compares a region's seasons, so changes in its competitiveness can be seen year over year.
+type RegionTrend struct {
+	RegionCode string
+	Seasons    []*RegionSeason // Seasons with events in the region, oldest first
+	Missing    []int           // Seasons without any played events in the region, such as seasons that haven't been synced
+}
+
+// RegionTrendQuery compares a region's seasons, using the database of each season. The scores of each season come
+// from the event summaries saved when its events were synced, so events that haven't been summarized count toward
+// the events, teams, and advancements but not the scores. Scrimmages and unofficial events aren't included. Scores
+// aren't comparable across seasons with different games; the team counts and advancement depth are.
+func RegionTrendQuery(regionCode string, seasons map[int]database.DB) (*RegionTrend, error) {
+	trend := &RegionTrend{RegionCode: database.NormalizeCode(regionCode), Seasons: []*RegionSeason{}}
+	years := make([]int, 0, len(seasons))
+	for year := range seasons {
+		years = append(years, year)
+	}
+	slices.Sort(years)
+
+	for _, year := range years {
+		season, err := regionSeason(seasons[year], trend.RegionCode, year)
+		if err != nil {
+			return nil, err
+		}
+		if season == nil {
+			trend.Missing = append(trend.Missing, year)
+			continue
+		}
+		trend.Seasons = append(trend.Seasons, season)
+	}
+	return trend, nil
+}
+
+// regionSeason summarizes a region's season from the season's database. It returns nil if no events in the
+// advancement chain have been played in the region.
+func regionSeason(seasonDB database.DB, regionCode string, year int) (*RegionSeason, error) {
+	events, err := seasonDB.GetAllEvents(database.EventFilter{RegionCodes: []string{regionCode}, Year: year})
+	if err != nil {
+		return nil, err
+	}
+
+	season := &RegionSeason{Year: year}
+	teams := make(map[int]bool)
+	toChampionship := make(map[int]bool)
+	toWorlds := make(map[int]bool)
+	var eventIDs []string
+	for _, event := range events {
+		tier := TierOf(event)
+		if event.Unofficial || tier == TierNone {
+			continue
+		}
+		rankings, err := seasonDB.GetEventRankings(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if len(rankings) == 0 {
+			continue
+		}
+		season.Events++
+		eventIDs = append(eventIDs, event.EventID)
+		for _, ranking := range rankings {
+			teams[ranking.TeamID] = true
+		}
+
+		var advanced map[int]bool
+		switch nextTier(tier) {
+		case TierChampionship:
+			advanced = toChampionship
+		case TierWorlds:
+			advanced = toWorlds
+		default:
+			continue
+		}
+		advancements, err := seasonDB.GetEventAdvancements(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, adv := range advancements {
+			advanced[adv.TeamID] = true
+		}
+	}
+	if season.Events == 0 {
+		return nil, nil
+	}
+	season.Teams = len(teams)
+	season.ToChampionship = len(toChampionship)
+	season.ToWorlds = len(toWorlds)
+
+	summaries, err := seasonDB.GetEventSummaries(database.EventSummaryFilter{EventIDs: eventIDs})
+	if err != nil {
+		return nil, err
+	}
+	var summarizedTeams int
+	for _, summary := range summaries {
+		matches := summary.QualMatches + summary.PlayoffMatches
+		season.Matches += matches
+		season.AverageScore += summary.AverageScore * float64(matches)
+		season.AverageNpScore += summary.AverageNpScore * float64(matches)
+		season.HighScore = max(season.HighScore, summary.HighScore)
+		season.AverageNpOPR += summary.AverageNpOPR * float64(summary.NumTeams)
+		summarizedTeams += summary.NumTeams
+	}
+	if season.Matches > 0 {
+		season.AverageScore /= float64(season.Matches)
+		season.AverageNpScore /= float64(season.Matches)
+	}
+	if summarizedTeams > 0 {
+		season.AverageNpOPR /= float64(summarizedTeams)
+	}
+	return season, nil
+}
+
+// Previous returns the season before the given season in the trend, or nil if it is the first season or the season
+// before it has no events in the region.
+func (t *RegionTrend) Previous(season *RegionSeason) *RegionSeason {
+	i := slices.IndexFunc(t.Seasons, func(s *RegionSeason) bool {
+		return s.Year == season.Year-1
+	})
+	if i < 0 {
+		return nil
+	}
+	return t.Seasons[i]
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// RegionSummary represents a region along with the number of events and teams in the region.
+type RegionSummary struct {
+	RegionCode string
+	Aliases    []string // Friendly names the region is also known by
+	EventCount int      // Number of events in the region for the season
+	TeamCount  int      // Number of teams whose home region is the region
+}
+
+// RegionCodesQuery returns the sorted list of all region codes.
+func RegionCodesQuery() ([]string, error) {
+	regionCodes, err := db.GetRegionCodes()
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(regionCodes)
+	return regionCodes, nil
+}
+
+// RegionAliasesQuery returns all region aliases, sorted by alias.
+func RegionAliasesQuery() ([]*database.RegionAlias, error) {
+	return db.GetRegionAliases()
+}
+
+// ResolveRegion returns the region code for a region given as either a region code or an alias, such as
+// "North Carolina" for USNC. Region codes and aliases are matched without regard to case. If the region is
+// neither a known region code nor an alias, it is returned as a normalized region code, so the caller can report
+// that the region wasn't found.
+func ResolveRegion(region string) (string, error) {
+	regionCode := database.NormalizeCode(region)
+	if regionCode == "" {
+		return "", nil
+	}
+
+	regionCodes, err := db.GetRegionCodes()
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(regionCodes, regionCode) {
+		return regionCode, nil
+	}
+
+	aliases, err := db.GetRegionAliases()
+	if err != nil {
+		return "", err
+	}
+	alias := database.NormalizeAlias(region)
+	for _, a := range aliases {
+		if strings.EqualFold(a.Alias, alias) {
+			return a.RegionCode, nil
+		}
+	}
+
+	return regionCode, nil
+}
+
+// RegionsQuery returns a summary of every region, including the number of events in the region for the given
+// year and the number of teams in the region, sorted by region code.
+func RegionsQuery(year int) ([]*RegionSummary, error) {
+	regionCodes, err := RegionCodesQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	// Count the events in each region
+	events, err := db.GetAllEvents(database.EventFilter{Year: year})
+	if err != nil {
+		return nil, err
+	}
+	eventCounts := make(map[string]int)
+	for _, event := range events {
+		eventCounts[event.RegionCode]++
+	}
+
+	// Count the teams in each region
+	teams, err := db.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	teamCounts := make(map[string]int)
+	for _, team := range teams {
+		teamCounts[team.HomeRegion]++
+	}
+
+	// Find the aliases of each region
+	aliases, err := db.GetRegionAliases()
+	if err != nil {
+		return nil, err
+	}
+	regionAliases := make(map[string][]string)
+	for _, alias := range aliases {
+		regionAliases[alias.RegionCode] = append(regionAliases[alias.RegionCode], alias.Alias)
+	}
+
+	summaries := make([]*RegionSummary, 0, len(regionCodes))
+	for _, regionCode := range regionCodes {
+		summaries = append(summaries, &RegionSummary{
+			RegionCode: regionCode,
+			Aliases:    regionAliases[regionCode],
+			EventCount: eventCounts[regionCode],
+			TeamCount:  teamCounts[regionCode],
+		})
+	}
+
+	return summaries, nil
+}
+
+// RegionEventsQuery returns a page of the events in a region for the given year, sorted by start date and event code.
+// A limit of 0 returns every event after the offset. The database returns only the page of events.
+func RegionEventsQuery(regionCode string, year int, limit, offset int) ([]*database.Event, error) {
+	regionCode = database.NormalizeCode(regionCode)
+
+	filter := database.EventFilter{
+		RegionCodes: []string{regionCode},
+		Year:        year,
+		Limit:       limit,
+		Offset:      offset,
+	}
+	return db.GetAllEvents(filter)
+}
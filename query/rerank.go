@@ -0,0 +1,205 @@
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// RerankSchemes are the schemes an event's qualification rankings can be recomputed under:
+//   - official: the event's ranking score under the season's rules, as ranked by FIRST
+//   - record: 2 ranking points for a win and 1 for a tie, averaged over the matches played
+//   - avg: the average alliance score, including foul points
+//   - npavg: the average alliance score before fouls
+//   - opr: the team's OPR at the event
+var RerankSchemes = []string{"official", "record", "avg", "npavg", "opr"}
+
+// RerankedTeam is a team's place in an event's qualification rankings under a ranking scheme, along with its place
+// in the official rankings.
+type RerankedTeam struct {
+	Team         *database.Team
+	OfficialRank int
+	Rank         int
+	Value        float64 // The team's value under the scheme, which the teams are ranked by
+	Wins         int
+	Losses       int
+	Ties         int
+	Matches      int // Qualification matches played
+}
+
+// Movement returns how many places the team moved up from its official rank under the scheme, which is negative
+// if the team moved down.
+func (t *RerankedTeam) Movement() int {
+	return t.OfficialRank - t.Rank
+}
+
+// EventRerank is an event's qualification rankings recomputed under a ranking scheme.
+type EventRerank struct {
+	Event     *database.Event
+	Scheme    string
+	Alliances int // Playoff alliances at the event, whose captains are the top-ranked teams
+	Teams     []*RerankedTeam
+}
+
+// RerankQuery recomputes an event's qualification rankings under a ranking scheme from its qualification matches,
+// so the season's ranking rules can be compared with alternatives such as the average score. The ranked teams are
+// the teams in the event's official rankings. Teams that tie under the scheme are ordered by their average score,
+// and then by their official rank. It returns nil if the event isn't found.
+func RerankQuery(eventCode string, year int, scheme string) (*EventRerank, error) {
+	eventCode = database.NormalizeCode(eventCode)
+	scheme = strings.ToLower(scheme)
+	if !slices.Contains(RerankSchemes, scheme) {
+		return nil, fmt.Errorf("invalid ranking scheme %q, expected one of %s", scheme, strings.Join(RerankSchemes, ", "))
+	}
+
+	events, err := db.GetAllEvents(database.EventFilter{EventCodes: []string{eventCode}, Year: year})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	event := events[0]
+
+	rankings, err := db.GetEventRankings(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	rerank := &EventRerank{Event: event, Scheme: scheme, Alliances: allianceCount(len(rankings)), Teams: []*RerankedTeam{}}
+	if len(rankings) == 0 {
+		return rerank, nil
+	}
+
+	// Total each team's record and scores in the qualification matches
+	type qualStats struct {
+		wins, losses, ties, matches int
+		score, npScore              int
+	}
+	stats := make(map[int]*qualStats, len(rankings))
+	for _, ranking := range rankings {
+		stats[ranking.TeamID] = &qualStats{}
+	}
+	matches, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		if !strings.EqualFold(match.TournamentLevel, string(ftc.QUALIFIER)) {
+			continue
+		}
+		redScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceRed)
+		if err != nil {
+			return nil, err
+		}
+		blueScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceBlue)
+		if err != nil {
+			return nil, err
+		}
+		if redScore == nil || blueScore == nil {
+			continue
+		}
+		matchTeams, err := db.GetMatchTeams(match.MatchID)
+		if err != nil {
+			return nil, err
+		}
+		for _, mt := range matchTeams {
+			s, ok := stats[mt.TeamID]
+			if !ok || !mt.OnField {
+				continue
+			}
+			score, opponent := redScore, blueScore
+			if mt.Alliance == database.AllianceBlue {
+				score, opponent = blueScore, redScore
+			}
+			s.matches++
+			if mt.Dq {
+				// A disqualified team earns nothing from the match
+				s.losses++
+				continue
+			}
+			s.score += score.TotalPoints
+			s.npScore += score.PreFoulTotal
+			switch {
+			case score.TotalPoints > opponent.TotalPoints:
+				s.wins++
+			case score.TotalPoints < opponent.TotalPoints:
+				s.losses++
+			default:
+				s.ties++
+			}
+		}
+	}
+
+	var oprs map[int]float64
+	if scheme == "opr" {
+		teamRankings, err := db.GetTeamRankings(database.TeamRankingFilter{EventIDs: []string{event.EventID}})
+		if err != nil {
+			return nil, err
+		}
+		oprs = make(map[int]float64, len(teamRankings))
+		for _, tr := range teamRankings {
+			oprs[tr.TeamID] = tr.OPR
+		}
+	}
+
+	averages := make(map[int]float64, len(rankings))
+	for _, ranking := range rankings {
+		team, err := db.GetTeam(ranking.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			team = &database.Team{TeamID: ranking.TeamID}
+		}
+		s := stats[ranking.TeamID]
+		rt := &RerankedTeam{
+			Team:         team,
+			OfficialRank: ranking.Rank,
+			Wins:         s.wins,
+			Losses:       s.losses,
+			Ties:         s.ties,
+			Matches:      s.matches,
+		}
+		if s.matches > 0 {
+			averages[ranking.TeamID] = float64(s.score) / float64(s.matches)
+		}
+		switch scheme {
+		case "official":
+			rt.Value = ranking.SortOrder1
+		case "record":
+			if s.matches > 0 {
+				rt.Value = float64(2*s.wins+s.ties) / float64(s.matches)
+			}
+		case "avg":
+			rt.Value = averages[ranking.TeamID]
+		case "npavg":
+			if s.matches > 0 {
+				rt.Value = float64(s.npScore) / float64(s.matches)
+			}
+		case "opr":
+			rt.Value = oprs[ranking.TeamID]
+		}
+		rerank.Teams = append(rerank.Teams, rt)
+	}
+
+	slices.SortFunc(rerank.Teams, func(a, b *RerankedTeam) int {
+		if scheme == "official" {
+			return cmp.Compare(a.OfficialRank, b.OfficialRank)
+		}
+		if c := cmp.Compare(b.Value, a.Value); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(averages[b.Team.TeamID], averages[a.Team.TeamID]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.OfficialRank, b.OfficialRank)
+	})
+	for i, rt := range rerank.Teams {
+		rt.Rank = i + 1
+	}
+	return rerank, nil
+}
@@ -0,0 +1,256 @@
+package query
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// AwardClass is how an award counts toward advancement.
+type AwardClass int
+
+const (
+	UnknownAward AwardClass = iota // An award that couldn't be classified
+	InspireAward                   // The Inspire Award, which earns the season's Inspire points
+	JudgedAward                    // One of the other judged awards, which earns the season's judged points
+	PlayoffAward                   // An alliance award, whose points are earned in the playoff bracket instead
+	OtherAward                     // An award that earns no advancement points, such as the Dean's List awards
+)
+
+// String returns the name of the award class.
+func (c AwardClass) String() string {
+	switch c {
+	case InspireAward:
+		return "inspire"
+	case JudgedAward:
+		return "judged"
+	case PlayoffAward:
+		return "playoff"
+	case OtherAward:
+		return "other"
+	}
+	return "unknown"
+}
+
+// AdvancementRules are the rules used to calculate a season's advancement points and order.
+type AdvancementRules struct {
+	InspirePoints [3]int // Judging points for 1st, 2nd, and 3rd place Inspire Awards
+	JudgedPoints  [3]int // Judging points for 1st, 2nd, and 3rd place in the other judged awards
+
+	// InspireOnly gives a team that wins an Inspire Award the points for that award alone, rather than adding the
+	// points for the other judged awards it wins.
+	InspireOnly bool
+	// BestJudgedOnly gives a team that wins more than one of the other judged awards the points for the highest
+	// one alone.
+	BestJudgedOnly bool
+	// InspireFirst gives the first advancement slot to the Inspire Award winner, ahead of the teams with more
+	// points. If the winner had already advanced from an earlier event, the slot ripples down to the 2nd place
+	// Inspire Award winner, and then to 3rd place.
+	InspireFirst bool
+	// Awards are the classes of the season's awards, keyed by the award's name in the awards table. Seasons that
+	// don't list their awards use defaultAwardClasses.
+	Awards map[string]AwardClass
+}
+
+// defaultAwardClasses are the classes of the awards given in recent seasons.
+var defaultAwardClasses = map[string]AwardClass{
+	"Inspire Award":             InspireAward,
+	"Think Award":               JudgedAward,
+	"Connect Award":             JudgedAward,
+	"Innovate Award":            JudgedAward,
+	"Design Award":              JudgedAward,
+	"Motivate Award":            JudgedAward,
+	"Control Award":             JudgedAward,
+	"Promote Award":             JudgedAward,
+	"Compass Award":             OtherAward,
+	"Reach Award":               JudgedAward,
+	"Sustain Award":             JudgedAward,
+	"Judges' Award":             JudgedAward,
+	"Judges' Choice Award":      JudgedAward,
+	"Winning Alliance Award":    PlayoffAward,
+	"Finalist Alliance Award":   PlayoffAward,
+	"Dean's List Semi-Finalist": OtherAward,
+	"Dean's List Finalist":      OtherAward,
+	"Dean's List Winner":        OtherAward,
+}
+
+// awardKeyword classifies the awards whose names contain the keyword.
+type awardKeyword struct {
+	keyword string
+	class   AwardClass
+}
+
+// awardKeywords classify the awards that aren't in a season's award classes by their names. The first keyword
+// found in an award's name gives its class, so awards whose names only contain "award" are taken to be judged.
+var awardKeywords = []awardKeyword{
+	{"winning alliance", PlayoffAward},
+	{"finalist alliance", PlayoffAward},
+	{"inspire", InspireAward},
+	{"dean's list", OtherAward},
+	{"innovate", JudgedAward},
+	{"design", JudgedAward},
+	{"control", JudgedAward},
+	{"motivate", JudgedAward},
+	{"compass", OtherAward},
+	{"promote", JudgedAward},
+	{"think", JudgedAward},
+	{"connect", JudgedAward},
+	{"sustain", JudgedAward},
+	{"reach", JudgedAward},
+	{"award", JudgedAward},
+}
+
+// defaultAdvancementRules add up the points for every judged award a team wins, and order the advancement slots
+// by points alone.
+var defaultAdvancementRules = AdvancementRules{
+	InspirePoints: [3]int{60, 30, 15},
+	JudgedPoints:  [3]int{12, 6, 3},
+}
+
+// SeasonAdvancementRules are the advancement rules of the seasons that don't use the default rules, keyed by the
+// season's year. Seasons that aren't listed use the default rules.
+var SeasonAdvancementRules = map[int]AdvancementRules{
+	2025: {
+		InspirePoints:  [3]int{60, 30, 15},
+		JudgedPoints:   [3]int{12, 6, 3},
+		InspireOnly:    true,
+		BestJudgedOnly: true,
+		InspireFirst:   true,
+	},
+}
+
+// AdvancementRulesFor returns the advancement rules of a season.
+func AdvancementRulesFor(year int) AdvancementRules {
+	if rules, ok := SeasonAdvancementRules[year]; ok {
+		return rules
+	}
+	return defaultAdvancementRules
+}
+
+// unknownAwards are the awards that have been classified by keyword, so a warning is only logged the first time
+// each is seen.
+var unknownAwards sync.Map
+
+// awardClassifier classifies the awards given at a season's events.
+type awardClassifier struct {
+	year    int
+	classes map[string]AwardClass // Classes of the season's awards, keyed by the lower case award name
+	names   map[int]string        // Names of the awards in the awards table, keyed by award ID
+	persons map[int]bool          // Awards in the awards table that are given to a person, keyed by award ID
+}
+
+// newAwardClassifier returns a classifier for the awards given at events of the year, under the season's rules.
+func newAwardClassifier(year int, rules AdvancementRules) (*awardClassifier, error) {
+	awards, err := db.GetAllAwards()
+	if err != nil {
+		return nil, err
+	}
+	configured := rules.Awards
+	if configured == nil {
+		configured = defaultAwardClasses
+	}
+	ac := &awardClassifier{
+		year:    year,
+		classes: make(map[string]AwardClass, len(configured)),
+		names:   make(map[int]string, len(awards)),
+		persons: make(map[int]bool),
+	}
+	for name, class := range configured {
+		ac.classes[strings.ToLower(name)] = class
+	}
+	for _, award := range awards {
+		ac.names[award.AwardID] = award.Name
+		if award.ForPerson {
+			ac.persons[award.AwardID] = true
+		}
+	}
+	return ac, nil
+}
+
+// forPerson returns true if an award given at an event was given to a person rather than a team, either because it
+// names the person it was given to or because the awards table says the award is given to a person.
+func (ac *awardClassifier) forPerson(award *database.EventAward) bool {
+	return award.Person != "" || ac.persons[award.AwardID]
+}
+
+// classify returns the class of an award given at an event. Awards given to a person, such as the Dean's List and
+// Compass Awards, earn the person's team no points. Otherwise, the award is looked up in the awards table by its
+// award ID, and its name there is looked up in the season's award classes; if it isn't found, the name the award
+// was given under at the event is tried. Awards that still aren't found are classified by the keywords in their
+// name, and a warning is logged so the season's award classes can be brought up to date.
+func (ac *awardClassifier) classify(award *database.EventAward) AwardClass {
+	if ac.forPerson(award) {
+		return OtherAward
+	}
+	if name, ok := ac.names[award.AwardID]; ok {
+		if class, ok := ac.classes[strings.ToLower(name)]; ok {
+			return class
+		}
+	}
+	if class, ok := ac.classes[strings.ToLower(award.Name)]; ok {
+		return class
+	}
+
+	class := UnknownAward
+	for _, kw := range awardKeywords {
+		if containsIgnoreCase(award.Name, kw.keyword) {
+			class = kw.class
+			break
+		}
+	}
+	key := fmt.Sprintf("%d/%d/%s", ac.year, award.AwardID, strings.ToLower(award.Name))
+	if _, warned := unknownAwards.LoadOrStore(key, true); !warned {
+		slog.Warn("Award isn't in the season's award classes, so it was classified by its name",
+			"year", ac.year, "awardID", award.AwardID, "name", award.Name, "class", class)
+	}
+	return class
+}
+
+// placePoints returns the points for a 1st, 2nd, or 3rd place award, or 0 for any other place.
+func placePoints(points [3]int, series int) int {
+	if series < 1 || series > len(points) {
+		return 0
+	}
+	return points[series-1]
+}
+
+// inspirePlaces returns the place of the Inspire Award won by each team at an event, keyed by team ID.
+func inspirePlaces(awards []*database.EventAward, ac *awardClassifier) map[int]int {
+	places := make(map[int]int)
+	for _, award := range awards {
+		if ac.classify(award) != InspireAward {
+			continue
+		}
+		if place, ok := places[award.TeamID]; !ok || award.Series < place {
+			places[award.TeamID] = award.Series
+		}
+	}
+	return places
+}
+
+// applyInspireFirst moves the team that takes the Inspire Award's advancement slot to the front of the teams,
+// which are ordered by points. The slot goes to the 1st place Inspire Award winner, or, if that team had already
+// advanced, ripples down to the next place. It returns the team that takes the slot, or nil if no team does.
+func applyInspireFirst(teamAdvancements []*TeamAdvancement, places map[int]int) *TeamAdvancement {
+	for place := 1; place <= len(defaultAdvancementRules.InspirePoints); place++ {
+		i := slices.IndexFunc(teamAdvancements, func(ta *TeamAdvancement) bool {
+			p, ok := places[ta.Team.TeamID]
+			return ok && p == place
+		})
+		if i < 0 {
+			continue
+		}
+		ta := teamAdvancements[i]
+		if ta.Status == "already_advancing" {
+			continue
+		}
+		copy(teamAdvancements[1:i+1], teamAdvancements[:i])
+		teamAdvancements[0] = ta
+		return ta
+	}
+	return nil
+}
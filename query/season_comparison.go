@@ -0,0 +1,105 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// TeamSeasonComparison compares how a team performed at an event with its performance across the season.
+type TeamSeasonComparison struct {
+	TeamID        int
+	EventOPR      float64
+	SeasonOPR     float64
+	EventNpAVG    float64
+	SeasonNpAVG   float64
+	EventMatches  int
+	SeasonMatches int
+}
+
+// OPRDelta returns how much higher the team's OPR was at the event than across the season.
+func (c TeamSeasonComparison) OPRDelta() float64 {
+	return c.EventOPR - c.SeasonOPR
+}
+
+// NpAVGDelta returns how much higher the team's npAVG was at the event than across the season.
+func (c TeamSeasonComparison) NpAVGDelta() float64 {
+	return c.EventNpAVG - c.SeasonNpAVG
+}
+
+// EventSeasonComparisonQuery returns, for each team ranked at the event, the team's OPR and npAVG at the event
+// along with the values across the season, so teams that peaked or struggled at the event stand out. Season values
+// are weighted by the number of matches played at each event, the same as the team rankings, and include the
+// event itself along with the season's other official events. The comparisons are sorted by npAVG delta, highest
+// first. The event values of a multi-division event are those from the division each team played in. It returns nil
+// if the event has no team rankings.
+func EventSeasonComparisonQuery(event *database.Event) ([]TeamSeasonComparison, error) {
+	divisions, err := EventDivisions(event)
+	if err != nil {
+		return nil, err
+	}
+	eventIDs := []string{event.EventID}
+	for _, division := range divisions {
+		eventIDs = append(eventIDs, division.EventID)
+	}
+	eventRankings, err := db.GetTeamRankings(database.TeamRankingFilter{EventIDs: eventIDs})
+	if err != nil {
+		return nil, err
+	}
+	if len(divisions) > 0 {
+		// The finals of a multi-division event are compared by the division rankings instead
+		eventRankings = slices.DeleteFunc(eventRankings, func(r *database.TeamRanking) bool {
+			return r.EventID == event.EventID
+		})
+	}
+	if len(eventRankings) == 0 {
+		return nil, nil
+	}
+
+	seasonEvents, err := getRankedEvents(database.EventFilter{Year: event.Year}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range seasonEvents {
+		if !slices.Contains(eventIDs, e.EventID) {
+			eventIDs = append(eventIDs, e.EventID)
+		}
+	}
+
+	teamIDs := make([]int, 0, len(eventRankings))
+	teamMap := make(map[int]*database.Team)
+	for _, ranking := range eventRankings {
+		teamIDs = append(teamIDs, ranking.TeamID)
+		teamMap[ranking.TeamID] = &database.Team{TeamID: ranking.TeamID}
+	}
+	seasonRankings, err := db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: teamIDs, EventIDs: eventIDs})
+	if err != nil {
+		return nil, err
+	}
+	season := make(map[int]TeamPerformance)
+	for _, perf := range consolidateTeamRankings(teamMap, seasonRankings, "") {
+		season[perf.TeamID] = perf
+	}
+
+	comparisons := make([]TeamSeasonComparison, 0, len(eventRankings))
+	for _, ranking := range eventRankings {
+		perf := season[ranking.TeamID]
+		comparisons = append(comparisons, TeamSeasonComparison{
+			TeamID:        ranking.TeamID,
+			EventOPR:      ranking.OPR,
+			SeasonOPR:     perf.OPR,
+			EventNpAVG:    ranking.NpAvg,
+			SeasonNpAVG:   perf.NpAVG,
+			EventMatches:  ranking.NumMatches,
+			SeasonMatches: perf.Matches,
+		})
+	}
+	slices.SortFunc(comparisons, func(a, b TeamSeasonComparison) int {
+		if c := cmp.Compare(b.NpAVGDelta(), a.NpAVGDelta()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+	return comparisons, nil
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// seasonsJSON describes each season's game and how its qualification matches are ranked, which the FTC Events API
+// doesn't provide.
+//
+//go:embed seasons.json
+var seasonsJSON []byte
+
+// RankingPoint is a way for an alliance to earn ranking points in a qualification match.
+type RankingPoint struct {
+	Name        string `json:"name"`
+	Points      int    `json:"points"`
+	Description string `json:"description"`
+}
+
+// seasonInfo is a season's entry in seasonsJSON.
+type seasonInfo struct {
+	Year          int            `json:"year"`
+	Game          string         `json:"game"`
+	RankingPoints []RankingPoint `json:"ranking_points"`
+	SortOrders    []string       `json:"sort_orders"`
+}
+
+// seasons are the seasons described by seasonsJSON, keyed by year.
+var seasons = func() map[int]seasonInfo {
+	var list []seasonInfo
+	if err := json.Unmarshal(seasonsJSON, &list); err != nil {
+		panic(fmt.Sprintf("invalid seasons.json: %v", err))
+	}
+	byYear := make(map[int]seasonInfo, len(list))
+	for _, info := range list {
+		byYear[info.Year] = info
+	}
+	return byYear
+}()
+
+// maxSelectionAlliance is the highest alliance number the selection points are listed for.
+const maxSelectionAlliance = 8
+
+// SelectionPoints are the advancement points earned by the teams on an alliance for its number.
+type SelectionPoints struct {
+	Alliance int
+	Points   int
+}
+
+// AwardRule is one of the awards a season's rules classify, along with how it counts toward advancement.
+type AwardRule struct {
+	Name   string
+	Class  AwardClass
+	Points [3]int // Advancement points for 1st, 2nd, and 3rd place, if the award carries advancement
+}
+
+// Advancement returns true if the award carries advancement points.
+func (a AwardRule) Advancement() bool {
+	return a.Class == InspireAward || a.Class == JudgedAward
+}
+
+// SeasonRules describes a season for labelling its data: the game, how qualification matches are ranked, how
+// advancement points are earned, and the awards given.
+type SeasonRules struct {
+	Year          int
+	Game          string
+	RankingPoints []RankingPoint
+	// SortOrders are the names of the values an event's qualification rankings are sorted by, in order, which are
+	// the sort orders of the event rankings. They are empty if they aren't known for the season.
+	SortOrders  []string
+	Advancement AdvancementRules
+	Selection   []SelectionPoints
+	Playoffs    []PlayoffFinish           // Playoff finishes that earn points, from the best to the worst
+	Awards      []AwardRule               // Awards classified by the season's rules, ordered by class and then name
+	Points      *QualificationPointsTable // Qualification points at each rank, if an event size was given
+}
+
+// SeasonRulesQuery returns the rules of a season, which are built into the application rather than read from the
+// database. If teams is greater than 0, the qualification points earned at each rank at an event with that many
+// teams are included. A *NotFoundError is returned if the season isn't known.
+func SeasonRulesQuery(year int, teams int) (*SeasonRules, error) {
+	info, ok := seasons[year]
+	if !ok {
+		return nil, &NotFoundError{Kind: "season", Code: strconv.Itoa(year)}
+	}
+	rules := &SeasonRules{
+		Year:          year,
+		Game:          info.Game,
+		RankingPoints: info.RankingPoints,
+		SortOrders:    info.SortOrders,
+		Advancement:   AdvancementRulesFor(year),
+	}
+
+	for alliance := 1; alliance <= maxSelectionAlliance; alliance++ {
+		rules.Selection = append(rules.Selection, SelectionPoints{Alliance: alliance, Points: selectionPoints(alliance, alliance)})
+	}
+	for _, finish := range PlayoffFinishes {
+		if finish.Selected && finish.Points > 0 {
+			rules.Playoffs = append(rules.Playoffs, finish)
+		}
+	}
+
+	classes := rules.Advancement.Awards
+	if classes == nil {
+		classes = defaultAwardClasses
+	}
+	for name, class := range classes {
+		award := AwardRule{Name: name, Class: class}
+		switch class {
+		case InspireAward:
+			award.Points = rules.Advancement.InspirePoints
+		case JudgedAward:
+			award.Points = rules.Advancement.JudgedPoints
+		}
+		rules.Awards = append(rules.Awards, award)
+	}
+	slices.SortFunc(rules.Awards, func(a, b AwardRule) int {
+		if a.Class != b.Class {
+			return int(a.Class) - int(b.Class)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if teams > 0 {
+		rules.Points = QualificationPointsQuery(teams)
+	}
+	return rules, nil
+}
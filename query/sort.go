@@ -0,0 +1,171 @@
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortFields are the fields team performances can be sorted by, matching the values of the CLI's --sort flag.
+// Each field sorts best-first by default: higher values first, except for DPR and NpDPR where lower is better
+// and for the team number, which sorts in ascending order. "autoopr" is the OPR from the autonomous period, and
+// "autopct" is the percentage of a team's npOPR that it scores in the autonomous period.
+var SortFields = []string{"opr", "npopr", "ccwm", "dpr", "npdpr", "npavg", "autoopr", "autopct", "matches", "team"}
+
+// SortKey is a single field to sort team performances by, along with the direction of the sort.
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// String returns the sort key in the form accepted by ParseSortKeys.
+func (sk SortKey) String() string {
+	if sk.Descending {
+		return "-" + sk.Field
+	}
+	return "+" + sk.Field
+}
+
+// SortError is returned by ParseSortKeys for a sort field or order that can't be parsed.
+type SortError struct {
+	Parameter string // "sort" for an invalid field, or "order" for an invalid order
+	Value     string // The field or order that is invalid
+}
+
+// Error returns a message naming the invalid field or order and the values that are accepted in its place.
+func (e *SortError) Error() string {
+	if e.Parameter == "order" {
+		return fmt.Sprintf("invalid order %q, expected asc or desc", e.Value)
+	}
+	return fmt.Sprintf("invalid sort field %q, expected one of %s", e.Value, strings.Join(SortFields, ", "))
+}
+
+// defaultDescending returns true if the field sorts from the highest value to the lowest by default.
+func defaultDescending(field string) bool {
+	switch field {
+	case "dpr", "npdpr", "team":
+		return false
+	default:
+		return true
+	}
+}
+
+// ParseSortKeys parses a comma-separated list of sort fields, such as "ccwm,-matches". A field prefixed with
+// '-' sorts in descending order and a field prefixed with '+' sorts in ascending order. Fields without a prefix
+// use the order, which is either "asc" or "desc", or the field's best-first direction if order is empty.
+// Earlier fields take precedence, with later fields used to break ties. An invalid field or order is returned as a
+// *SortError.
+func ParseSortKeys(sortBy string, order string) ([]SortKey, error) {
+	order = strings.ToLower(strings.TrimSpace(order))
+	if order != "" && order != "asc" && order != "desc" {
+		return nil, &SortError{Parameter: "order", Value: order}
+	}
+
+	var keys []SortKey
+	for field := range strings.SplitSeq(sortBy, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+
+		var key SortKey
+		switch field[0] {
+		case '-':
+			key = SortKey{Field: field[1:], Descending: true}
+		case '+':
+			key = SortKey{Field: field[1:], Descending: false}
+		default:
+			key = SortKey{Field: field, Descending: defaultDescending(field)}
+			if order != "" {
+				key.Descending = order == "desc"
+			}
+		}
+		if !slices.Contains(SortFields, key.Field) {
+			return nil, &SortError{Parameter: "sort", Value: key.Field}
+		}
+		keys = append(keys, key)
+	}
+
+	// An order without any fields applies to the default sort by NpAVG
+	if len(keys) == 0 && order != "" {
+		keys = append(keys, SortKey{Field: "npavg", Descending: order == "desc"})
+	}
+
+	return keys, nil
+}
+
+// sortValue returns the value of the sort field for a team's performance metrics.
+func sortValue(field string, teamID int, opr, npopr, ccwm, dpr, npdpr, npavg, autoopr float64, matches int) float64 {
+	switch field {
+	case "opr":
+		return opr
+	case "npopr":
+		return npopr
+	case "ccwm":
+		return ccwm
+	case "dpr":
+		return dpr
+	case "npdpr":
+		return npdpr
+	case "npavg":
+		return npavg
+	case "autoopr":
+		return autoopr
+	case "autopct":
+		return autoShare(autoopr, npopr)
+	case "matches":
+		return float64(matches)
+	default:
+		return float64(teamID)
+	}
+}
+
+// compareByKeys compares two values using each of the sort keys in turn.
+func compareByKeys(keys []SortKey, a, b func(field string) float64) int {
+	for _, key := range keys {
+		c := cmp.Compare(a(key.Field), b(key.Field))
+		if key.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// SortTeamPerformances sorts the team performances by the sort keys. Teams that are equal on every key are
+// ordered by team number so the order is always the same.
+func SortTeamPerformances(performances []TeamPerformance, keys []SortKey) {
+	value := func(p TeamPerformance) func(string) float64 {
+		return func(field string) float64 {
+			return sortValue(field, p.TeamID, p.OPR, p.NpOPR, p.CCWM, p.DPR, p.NpDPR, p.NpAVG, p.AutoOPR, p.Matches)
+		}
+	}
+	slices.SortStableFunc(performances, func(a, b TeamPerformance) int {
+		if c := compareByKeys(keys, value(a), value(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TeamID, b.TeamID)
+	})
+}
+
+// SortTeamEventPerformances sorts the team event performances by the sort keys. Entries that are equal on every
+// key are ordered by team number and then event code so the order is always the same.
+func SortTeamEventPerformances(performances []TeamEventPerformance, keys []SortKey) {
+	value := func(p TeamEventPerformance) func(string) float64 {
+		return func(field string) float64 {
+			return sortValue(field, p.TeamID, p.OPR, p.NpOPR, p.CCWM, p.DPR, p.NpDPR, p.NpAVG, p.AutoOPR, p.Matches)
+		}
+	}
+	slices.SortStableFunc(performances, func(a, b TeamEventPerformance) int {
+		if c := compareByKeys(keys, value(a), value(b)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.TeamID, b.TeamID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.EventCode, b.EventCode)
+	})
+}
@@ -0,0 +1,123 @@
+package query
+
+import (
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// Standing is where a team's value of a metric places it among a group of teams.
+type Standing struct {
+	Rank       int     // 1 for the highest value; teams with the same value share a rank
+	Teams      int     // Teams in the group
+	Percentile float64 // Percentage of the teams in the group with a lower value
+}
+
+// Top returns the percentage of the teams in the group ranked at or above the team, such as 5 for a team in the
+// top 5%.
+func (s *Standing) Top() float64 {
+	if s == nil || s.Teams == 0 {
+		return 0
+	}
+	return 100 * float64(s.Rank) / float64(s.Teams)
+}
+
+// TeamStandings are where a team's season OPR and npAVG place it among the teams in its home region and among every
+// team, with a badge for the tier its npAVG places it in worldwide.
+type TeamStandings struct {
+	RegionOPR   Standing
+	WorldOPR    Standing
+	RegionNpAVG Standing
+	WorldNpAVG  Standing
+	Tier        string // Such as "Top 5%", from the team's worldwide npAVG, or empty if it isn't in the top half
+}
+
+// standingTiers are the tiers of the teams' worldwide npAVG standings, from the highest to the lowest. A team is in
+// the first tier whose percentage it is within.
+var standingTiers = []struct {
+	Top  float64
+	Name string
+}{
+	{1, "Top 1%"},
+	{5, "Top 5%"},
+	{10, "Top 10%"},
+	{25, "Top 25%"},
+	{50, "Top 50%"},
+}
+
+// TeamStandingsQuery returns where a team's OPR and npAVG place it among the teams in its home region and among
+// every team in the season. The metrics are those of TeamRankingsQuery, from the season's official events, so they
+// match the season's team rankings. Nil is returned if the team hasn't played at an official event this season.
+func TeamStandingsQuery(teamID int, year int) (*TeamStandings, error) {
+	events, err := getRankedEvents(database.EventFilter{Year: year}, nil, false)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	eventIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.EventID)
+	}
+	teams, err := db.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	teamMap := make(map[int]*database.Team, len(teams))
+	for _, team := range teams {
+		teamMap[team.TeamID] = team
+	}
+	rankings, err := db.GetTeamRankings(database.TeamRankingFilter{EventIDs: eventIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	performances := consolidateTeamRankings(teamMap, rankings, "")
+	var team *TeamPerformance
+	for i := range performances {
+		if performances[i].TeamID == teamID {
+			team = &performances[i]
+			break
+		}
+	}
+	if team == nil {
+		return nil, nil
+	}
+	var region []TeamPerformance
+	for _, p := range performances {
+		if p.Region == team.Region {
+			region = append(region, p)
+		}
+	}
+
+	opr := func(p TeamPerformance) float64 { return p.OPR }
+	npAVG := func(p TeamPerformance) float64 { return p.NpAVG }
+	standings := &TeamStandings{
+		RegionOPR:   standing(region, opr(*team), opr),
+		WorldOPR:    standing(performances, opr(*team), opr),
+		RegionNpAVG: standing(region, npAVG(*team), npAVG),
+		WorldNpAVG:  standing(performances, npAVG(*team), npAVG),
+	}
+	top := standings.WorldNpAVG.Top()
+	for _, tier := range standingTiers {
+		if top <= tier.Top {
+			standings.Tier = tier.Name
+			break
+		}
+	}
+	return standings, nil
+}
+
+// standing returns where a value of a metric places a team among the teams.
+func standing(performances []TeamPerformance, value float64, metric func(TeamPerformance) float64) Standing {
+	higher, lower := 0, 0
+	for _, p := range performances {
+		switch v := metric(p); {
+		case v > value:
+			higher++
+		case v < value:
+			lower++
+		}
+	}
+	s := Standing{Rank: higher + 1, Teams: len(performances)}
+	if s.Teams > 0 {
+		s.Percentile = 100 * float64(lower) / float64(s.Teams)
+	}
+	return s
+}
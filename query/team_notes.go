@@ -0,0 +1,37 @@
+package query
+
+import (
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// TeamNotesQuery returns the scouting notes on teams in a year, keyed by team number. If teams are given, only the
+// notes on those teams are returned.
+func TeamNotesQuery(year int, teamIDs ...int) (map[int]*database.TeamNote, error) {
+	notes, err := db.GetTeamNotes(database.TeamNoteFilter{Year: year, TeamIDs: teamIDs})
+	if err != nil {
+		return nil, err
+	}
+	byTeam := make(map[int]*database.TeamNote, len(notes))
+	for _, note := range notes {
+		byTeam[note.TeamID] = note
+	}
+	return byTeam, nil
+}
+
+// FilterRankingsByTags returns the rankings of the teams whose scouting notes have every one of the tags, in the
+// order they are ranked. Teams without notes don't have any tags.
+func FilterRankingsByTags(rankings []FormulaRanking, notes map[int]*database.TeamNote, tags []string) []FormulaRanking {
+	tags = database.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return rankings
+	}
+	return slices.DeleteFunc(slices.Clone(rankings), func(r FormulaRanking) bool {
+		note, ok := notes[r.TeamID]
+		if !ok {
+			return true
+		}
+		return slices.ContainsFunc(tags, func(tag string) bool { return !note.HasTag(tag) })
+	})
+}
@@ -3,62 +3,198 @@ package query
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/rbrabson/ftcstanding/database"
 )
 
 // TeamPerformance represents performance metrics for a team across all their matches in a season.
 type TeamPerformance struct {
-	TeamID   int
-	TeamName string
-	Region   string
-	OPR      float64
-	NpOPR    float64
-	CCWM     float64
-	DPR      float64
-	NpDPR    float64
-	NpAVG    float64
-	Matches  int
+	TeamID      int
+	TeamName    string
+	Region      string
+	Country     string
+	CountryCode string // ISO 3166-1 alpha-2 code of the team's country, if it is known
+	OPR         float64
+	NpOPR       float64
+	CCWM        float64
+	DPR         float64
+	NpDPR       float64
+	NpAVG       float64
+	AutoOPR     float64 // OPR from the points scored in the autonomous period
+	Matches     int
+	Division    string // Code of the division the team played in, if the rankings are of a multi-division event
+	Visiting    bool   // True if the team's home region is outside the region being ranked, whose events it visited
+}
+
+// AutoShare returns the percentage of the team's non-penalty scoring that comes from the autonomous period, or 0 if
+// the team's npOPR isn't positive.
+func (p TeamPerformance) AutoShare() float64 {
+	return autoShare(p.AutoOPR, p.NpOPR)
+}
+
+// autoShare returns the auto OPR as a percentage of the npOPR, or 0 if the npOPR isn't positive.
+func autoShare(autoOPR, npOPR float64) float64 {
+	if npOPR <= 0 {
+		return 0
+	}
+	return 100 * autoOPR / npOPR
 }
 
 // TeamRankingsQuery retrieves performance metrics for all teams in a region for a given year.
 // If region is provided (non-empty), only teams from that region are included; otherwise all teams are included.
 // If country is provided (non-empty), only teams from that country are included.
-// If eventCode is provided (non-empty), only rankings from that event are included.
+// If eventCodes are provided (non-empty), only rankings from those events are included. The rankings of a
+// multi-division event include the rankings from each of its divisions, which are calculated separately, and each
+// team is labeled with its division.
+// Unofficial events, such as scrimmages and off-season events, are only included if includeUnofficial is true.
+// If includeVisitors is true, the teams from other regions that competed at the region's events are included along
+// with the region's own teams, ranked on their results at the region's events, and are marked as visiting.
 // Performance metrics are retrieved from the team_rankings database table and combined using weighted averaging
 // based on the number of matches each team played in each event.
-func TeamRankingsQuery(region string, country string, eventCode string, year int) ([]TeamPerformance, error) {
+func TeamRankingsQuery(region string, country string, eventCodes []string, year int, includeUnofficial bool, includeVisitors bool) ([]TeamPerformance, error) {
+	region = database.NormalizeCode(region)
+	eventCodes = database.NormalizeCodes(eventCodes)
+
+	teamMap, teamIDs, eventIDs, err := getTeamRankingScope(region, country, eventCodes, year, includeUnofficial, includeVisitors)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get all team rankings for these teams and events from the database
+	rankingFilter := database.TeamRankingFilter{
+		TeamIDs:  teamIDs,
+		EventIDs: eventIDs,
+	}
+	rankings, err := db.GetTeamRankings(rankingFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(rankings) == 0 {
+		if region != "" {
+			return nil, fmt.Errorf("no team rankings found for teams in region %s for year %d", region, year)
+		}
+		return nil, fmt.Errorf("no team rankings found for year %d", year)
+	}
+
+	performances := consolidateTeamRankings(teamMap, rankings, region)
+	if len(eventCodes) > 0 {
+		if err := labelDivisions(performances, rankings); err != nil {
+			return nil, err
+		}
+	}
+	return performances, nil
+}
+
+// TeamRankingsAsOfQuery retrieves performance metrics for teams as they stood on the given date.
+// Rankings are taken from the most recent snapshot recorded on or before asOf, and are filtered and
+// combined in the same way as TeamRankingsQuery.
+func TeamRankingsAsOfQuery(region string, country string, eventCodes []string, year int, asOf time.Time, includeUnofficial bool, includeVisitors bool) ([]TeamPerformance, error) {
+	region = database.NormalizeCode(region)
+	eventCodes = database.NormalizeCodes(eventCodes)
+
+	teamMap, teamIDs, eventIDs, err := getTeamRankingScope(region, country, eventCodes, year, includeUnofficial, includeVisitors)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the snapshot of the team rankings for these teams and events
+	snapshotFilter := database.TeamRankingSnapshotFilter{
+		TeamIDs:  teamIDs,
+		EventIDs: eventIDs,
+		AsOf:     asOf,
+	}
+	snapshots, err := db.GetTeamRankingSnapshots(snapshotFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no team ranking snapshots found on or before %s", asOf.Format(database.SnapshotDateFormat))
+	}
+
+	rankings := make([]*database.TeamRanking, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		rankings = append(rankings, &database.TeamRanking{
+			TeamID:     snapshot.TeamID,
+			EventID:    snapshot.EventID,
+			NumMatches: snapshot.NumMatches,
+			CCWM:       snapshot.CCWM,
+			OPR:        snapshot.OPR,
+			NpOPR:      snapshot.NpOPR,
+			DPR:        snapshot.DPR,
+			NpDPR:      snapshot.NpDPR,
+			NpAvg:      snapshot.NpAvg,
+			AutoOPR:    snapshot.AutoOPR,
+		})
+	}
+
+	performances := consolidateTeamRankings(teamMap, rankings, region)
+	if len(eventCodes) > 0 {
+		if err := labelDivisions(performances, rankings); err != nil {
+			return nil, err
+		}
+	}
+	return performances, nil
+}
+
+// RankMovement compares the order of two ranked lists and returns, for each team in current, the number of
+// places the team has moved since previous. Positive values indicate the team moved up. Teams that are not
+// present in previous are omitted from the result.
+func RankMovement(current []TeamPerformance, previous []TeamPerformance) map[int]int {
+	previousRanks := make(map[int]int, len(previous))
+	for i, perf := range previous {
+		previousRanks[perf.TeamID] = i + 1
+	}
+
+	movement := make(map[int]int, len(current))
+	for i, perf := range current {
+		if rank, ok := previousRanks[perf.TeamID]; ok {
+			movement[perf.TeamID] = rank - (i + 1)
+		}
+	}
+	return movement
+}
+
+// getTeamRankingScope returns the teams and events that team rankings should be gathered from for
+// the given region, country, event codes, and year, including unofficial events if includeUnofficial is true. If
+// includeVisitors is true, the teams aren't limited to the region's own teams, and no team IDs are returned, so the
+// rankings of every team at the region's events are gathered.
+func getTeamRankingScope(region string, country string, eventCodes []string, year int, includeUnofficial bool, includeVisitors bool) (map[int]*database.Team, []int, []string, error) {
 	// Build team filter
 	var teamFilter database.TeamFilter
-	if region != "" {
+	if region != "" && !includeVisitors {
 		teamFilter.HomeRegions = []string{region}
 	}
 	if country != "" {
 		teamFilter.Countries = []string{country}
 	}
-	if eventCode != "" {
-		teamFilter.EventCodes = []string{eventCode}
+	if len(eventCodes) > 0 {
+		codes, err := withDivisionCodes(eventCodes, year)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		teamFilter.EventCodes = codes
 	}
 
 	// Get all teams based on filters
 	var teams []*database.Team
 	var err error
-	if region == "" && country == "" && eventCode == "" {
+	if region == "" && country == "" && len(eventCodes) == 0 {
 		teams, err = db.GetAllTeams()
 	} else {
 		teams, err = db.GetAllTeams(teamFilter)
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	if len(teams) == 0 {
 		if region != "" {
-			return nil, fmt.Errorf("no teams found in region %s", region)
+			return nil, nil, nil, fmt.Errorf("no teams found in region %s", region)
 		}
 		if country != "" {
-			return nil, fmt.Errorf("no teams found in country %s", country)
+			return nil, nil, nil, fmt.Errorf("no teams found in country %s", country)
 		}
-		return nil, fmt.Errorf("no teams found")
+		return nil, nil, nil, fmt.Errorf("no teams found")
 	}
 
 	// Get team info and build a map for easy lookup
@@ -74,19 +210,12 @@ func TeamRankingsQuery(region string, country string, eventCode string, year int
 	if region != "" {
 		eventFilter.RegionCodes = []string{region}
 	}
-	if eventCode != "" {
-		eventFilter.EventCodes = []string{eventCode}
-	} else {
-		// When no specific event is specified, only include qualifiers and championships
-		// (exclude scrimmages, league meets, and other non-competitive events)
-		eventFilter.Types = []string{"2", "4"}
-	}
-	events, err := db.GetAllEvents(eventFilter)
+	events, err := getRankedEvents(eventFilter, eventCodes, includeUnofficial)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	if len(events) == 0 {
-		return nil, fmt.Errorf("no events found")
+		return nil, nil, nil, fmt.Errorf("no events found")
 	}
 
 	// Collect event IDs
@@ -95,25 +224,54 @@ func TeamRankingsQuery(region string, country string, eventCode string, year int
 		eventIDs = append(eventIDs, event.EventID)
 	}
 
-	// Get all team rankings for these teams and events from the database
-	rankingFilter := database.TeamRankingFilter{
-		TeamIDs:  teamIDs,
-		EventIDs: eventIDs,
+	// The region's events select the visiting teams, and consolidateTeamRankings drops the teams outside the country
+	if region != "" && includeVisitors {
+		teamIDs = nil
 	}
-	rankings, err := db.GetTeamRankings(rankingFilter)
+	return teamMap, teamIDs, eventIDs, nil
+}
+
+// getRankedEvents returns the events that team rankings are gathered from. If eventCodes are provided, only those
+// events and their divisions are included. Otherwise the official qualifiers and championships are included (excluding scrimmages,
+// league meets, and other non-competitive events), along with every unofficial event if includeUnofficial is true.
+func getRankedEvents(eventFilter database.EventFilter, eventCodes []string, includeUnofficial bool) ([]*database.Event, error) {
+	if len(eventCodes) > 0 {
+		codes, err := withDivisionCodes(eventCodes, eventFilter.Year)
+		if err != nil {
+			return nil, err
+		}
+		eventFilter.EventCodes = codes
+		return db.GetAllEvents(eventFilter)
+	}
+
+	official := false
+	eventFilter.Unofficial = &official
+	eventFilter.Types = []string{"2", "4"}
+	events, err := db.GetAllEvents(eventFilter)
+	if err != nil || !includeUnofficial {
+		return events, err
+	}
+
+	unofficial := true
+	eventFilter.Unofficial = &unofficial
+	eventFilter.Types = nil
+	unofficialEvents, err := db.GetAllEvents(eventFilter)
 	if err != nil {
 		return nil, err
 	}
-	if len(rankings) == 0 {
-		if region != "" {
-			return nil, fmt.Errorf("no team rankings found for teams in region %s for year %d", region, year)
-		}
-		return nil, fmt.Errorf("no team rankings found for year %d", year)
-	}
+	return append(events, unofficialEvents...), nil
+}
 
+// consolidateTeamRankings combines per-event rankings into a single performance for each team using
+// weighted averaging based on the number of matches played, sorted by NpAVG (descending). The rankings of teams that
+// aren't in teamMap are left out. If a region is given, the teams from other regions are marked as visiting.
+func consolidateTeamRankings(teamMap map[int]*database.Team, rankings []*database.TeamRanking, region string) []TeamPerformance {
 	// Group rankings by team
 	teamRankings := make(map[int][]*database.TeamRanking)
 	for _, ranking := range rankings {
+		if teamMap[ranking.TeamID] == nil {
+			continue
+		}
 		teamRankings[ranking.TeamID] = append(teamRankings[ranking.TeamID], ranking)
 	}
 
@@ -123,7 +281,7 @@ func TeamRankingsQuery(region string, country string, eventCode string, year int
 		// Calculate weighted averages
 		var totalMatches int
 		var weightedOPR, weightedNpOPR, weightedCCWM float64
-		var weightedDPR, weightedNpDPR, weightedNpAVG float64
+		var weightedDPR, weightedNpDPR, weightedNpAVG, weightedAutoOPR float64
 
 		for _, ranking := range eventRankings {
 			weight := float64(ranking.NumMatches)
@@ -135,6 +293,7 @@ func TeamRankingsQuery(region string, country string, eventCode string, year int
 			weightedDPR += ranking.DPR * weight
 			weightedNpDPR += ranking.NpDPR * weight
 			weightedNpAVG += ranking.NpAvg * weight
+			weightedAutoOPR += ranking.AutoOPR * weight
 		}
 
 		// Normalize by total matches
@@ -146,20 +305,25 @@ func TeamRankingsQuery(region string, country string, eventCode string, year int
 			weightedDPR /= totalWeight
 			weightedNpDPR /= totalWeight
 			weightedNpAVG /= totalWeight
+			weightedAutoOPR /= totalWeight
 		}
 
 		team := teamMap[teamID]
 		results = append(results, TeamPerformance{
-			TeamID:   teamID,
-			TeamName: team.Name,
-			Region:   team.HomeRegion,
-			OPR:      weightedOPR,
-			NpOPR:    weightedNpOPR,
-			CCWM:     weightedCCWM,
-			DPR:      weightedDPR,
-			NpDPR:    weightedNpDPR,
-			NpAVG:    weightedNpAVG,
-			Matches:  totalMatches,
+			TeamID:      teamID,
+			TeamName:    team.Name,
+			Region:      team.HomeRegion,
+			Country:     team.Country,
+			CountryCode: CountryCode(team.Country),
+			OPR:         weightedOPR,
+			NpOPR:       weightedNpOPR,
+			CCWM:        weightedCCWM,
+			DPR:         weightedDPR,
+			NpDPR:       weightedNpDPR,
+			NpAVG:       weightedNpAVG,
+			AutoOPR:     weightedAutoOPR,
+			Matches:     totalMatches,
+			Visiting:    region != "" && team.HomeRegion != region,
 		})
 	}
 
@@ -168,7 +332,7 @@ func TeamRankingsQuery(region string, country string, eventCode string, year int
 		return results[i].NpAVG > results[j].NpAVG
 	})
 
-	return results, nil
+	return results
 }
 
 // TeamEventPerformance represents performance metrics for a team at a specific event.
@@ -185,13 +349,24 @@ type TeamEventPerformance struct {
 	DPR       float64
 	NpDPR     float64
 	NpAVG     float64
+	AutoOPR   float64 // OPR from the points scored in the autonomous period
 	Matches   int
 }
 
+// AutoShare returns the percentage of the team's non-penalty scoring at the event that comes from the autonomous
+// period, or 0 if the team's npOPR isn't positive.
+func (p TeamEventPerformance) AutoShare() float64 {
+	return autoShare(p.AutoOPR, p.NpOPR)
+}
+
 // TeamEventRankingsQuery retrieves performance metrics for teams at individual events.
 // Unlike TeamRankingsQuery, this does not consolidate rankings across events - each team-event
-// combination is returned as a separate entry.
-func TeamEventRankingsQuery(region string, country string, eventCode string, year int) ([]TeamEventPerformance, error) {
+// combination is returned as a separate entry. If eventCodes are provided (non-empty), only those events are
+// included. Unofficial events are only included if includeUnofficial is true.
+func TeamEventRankingsQuery(region string, country string, eventCodes []string, year int, includeUnofficial bool) ([]TeamEventPerformance, error) {
+	region = database.NormalizeCode(region)
+	eventCodes = database.NormalizeCodes(eventCodes)
+
 	// Build team filter
 	var teamFilter database.TeamFilter
 	if region != "" {
@@ -200,14 +375,18 @@ func TeamEventRankingsQuery(region string, country string, eventCode string, yea
 	if country != "" {
 		teamFilter.Countries = []string{country}
 	}
-	if eventCode != "" {
-		teamFilter.EventCodes = []string{eventCode}
+	if len(eventCodes) > 0 {
+		codes, err := withDivisionCodes(eventCodes, year)
+		if err != nil {
+			return nil, err
+		}
+		teamFilter.EventCodes = codes
 	}
 
 	// Get all teams based on filters
 	var teams []*database.Team
 	var err error
-	if region == "" && country == "" && eventCode == "" {
+	if region == "" && country == "" && len(eventCodes) == 0 {
 		teams, err = db.GetAllTeams()
 	} else {
 		teams, err = db.GetAllTeams(teamFilter)
@@ -238,13 +417,7 @@ func TeamEventRankingsQuery(region string, country string, eventCode string, yea
 	if region != "" {
 		eventFilter.RegionCodes = []string{region}
 	}
-	if eventCode != "" {
-		eventFilter.EventCodes = []string{eventCode}
-	} else {
-		// When no specific event is specified, only include qualifiers and championships
-		eventFilter.Types = []string{"2", "4"}
-	}
-	events, err := db.GetAllEvents(eventFilter)
+	events, err := getRankedEvents(eventFilter, eventCodes, includeUnofficial)
 	if err != nil {
 		return nil, err
 	}
@@ -295,6 +468,7 @@ func TeamEventRankingsQuery(region string, country string, eventCode string, yea
 			DPR:       ranking.DPR,
 			NpDPR:     ranking.NpDPR,
 			NpAVG:     ranking.NpAvg,
+			AutoOPR:   ranking.AutoOPR,
 			Matches:   ranking.NumMatches,
 		})
 	}
@@ -17,6 +17,7 @@ type Record struct {
 
 // EventDetails represents detailed information about a team's participation in an event.
 type EventDetails struct {
+	EventID       string
 	EventCode     string
 	EventName     string
 	DateStart     time.Time
@@ -28,6 +29,31 @@ type EventDetails struct {
 	Awards        []string
 }
 
+// UpcomingEvent represents an event a team is registered for that hasn't ended and that the team hasn't played in yet.
+type UpcomingEvent struct {
+	EventID    string
+	EventCode  string
+	EventName  string
+	DateStart  time.Time
+	DateEnd    time.Time
+	Venue      string
+	City       string
+	StateProv  string
+	Country    string
+	Registered int           // Teams registered for the event
+	Notable    []NotableTeam // Other registered teams with the best npOPR this season, highest first
+}
+
+// NotableTeam represents one of the strongest other teams registered for an upcoming event.
+type NotableTeam struct {
+	TeamID int
+	Name   string
+	NpOPR  float64 // Best npOPR at any event this season
+}
+
+// notableTeams is the number of notable teams listed for each upcoming event.
+const notableTeams = 3
+
 // TeamDetails represents comprehensive information about a team.
 type TeamDetails struct {
 	TeamID        int
@@ -38,23 +64,31 @@ type TeamDetails struct {
 	Country       string
 	Region        string
 	RookieYear    int
+	History       []*database.TeamHistory // Names and home regions the team had before, most recent first
 	TotalRecord   Record
 	QualRecord    Record
 	PlayoffRecord Record
 	Events        []EventDetails
+	Upcoming      []UpcomingEvent  // Events the team is registered for but hasn't played in yet, soonest first
+	Path          *AdvancementPath // Progression through the tiers of the advancement chain
+	Standings     *TeamStandings   // Where the team's OPR and npAVG place it, or nil if it hasn't played an official event
 }
 
 // TeamsQuery returns a list of teams that match the given filter.
 func TeamsQuery(filter ...database.TeamFilter) ([]*database.Team, error) {
-	teams, err := db.GetAllTeams(filter...)
+	teams, err := db.GetAllTeams(normalizeTeamFilters(filter)...)
 	if err != nil {
 		return nil, err
 	}
 	return teams, nil
 }
 
-// TeamDetailsQuery returns detailed information about a specific team.
-func TeamDetailsQuery(teamID int) (*TeamDetails, error) {
+// TeamDetailsQuery returns detailed information about a specific team, along with the names and home regions it had
+// before. The events the team has played in are listed with its results, and the events it is registered for that
+// haven't ended are listed as upcoming, along with the strongest of the other teams registered for them. Events the
+// team was registered for but didn't play in are left out once they have ended. The team's standings in its region
+// and worldwide are those of the year's team rankings.
+func TeamDetailsQuery(teamID int, year int) (*TeamDetails, error) {
 	// Get team basic information
 	team, err := db.GetTeam(teamID)
 	if err != nil {
@@ -75,6 +109,13 @@ func TeamDetailsQuery(teamID int) (*TeamDetails, error) {
 		Region:     team.HomeRegion,
 		RookieYear: team.RookieYear,
 		Events:     []EventDetails{},
+		Upcoming:   []UpcomingEvent{},
+	}
+
+	// Get the names and regions the team had before
+	details.History, err = db.GetTeamHistory(database.TeamHistoryFilter{TeamIDs: []int{teamID}})
+	if err != nil {
+		return nil, err
 	}
 
 	// Get all events for this team
@@ -84,6 +125,8 @@ func TeamDetailsQuery(teamID int) (*TeamDetails, error) {
 	}
 
 	// Process each event
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	upcomingTeams := make(map[string][]int)
 	for _, eventID := range eventIDs {
 		event, err := db.GetEvent(eventID)
 		if err != nil {
@@ -93,7 +136,25 @@ func TeamDetailsQuery(teamID int) (*TeamDetails, error) {
 			continue
 		}
 
+		eventTeams, err := db.GetEventTeams(eventID)
+		if err != nil {
+			return nil, err
+		}
+		if !playedEvent(eventTeams, teamID) {
+			if event.DateEnd.Before(today) {
+				continue
+			}
+			details.Upcoming = append(details.Upcoming, upcomingEvent(event, eventTeams))
+			for _, et := range eventTeams {
+				if et.Registered && et.TeamID != teamID {
+					upcomingTeams[eventID] = append(upcomingTeams[eventID], et.TeamID)
+				}
+			}
+			continue
+		}
+
 		eventDetail := EventDetails{
+			EventID:   event.EventID,
 			EventCode: event.EventCode,
 			EventName: event.Name,
 			DateStart: event.DateStart,
@@ -224,6 +285,190 @@ func TeamDetailsQuery(teamID int) (*TeamDetails, error) {
 	sort.Slice(details.Events, func(i, j int) bool {
 		return details.Events[i].DateStart.Before(details.Events[j].DateStart)
 	})
+	sort.Slice(details.Upcoming, func(i, j int) bool {
+		return details.Upcoming[i].DateStart.Before(details.Upcoming[j].DateStart)
+	})
+	if err := addNotableTeams(details.Upcoming, upcomingTeams); err != nil {
+		return nil, err
+	}
+
+	details.Path, err = AdvancementPathQuery(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Standings, err = TeamStandingsQuery(teamID, year)
+	if err != nil {
+		return nil, err
+	}
 
 	return details, nil
 }
+
+// playedEvent returns true if the team has played in the event, or if the event's teams were saved before
+// registrations were recorded and so are all teams that played.
+func playedEvent(eventTeams []*database.EventTeam, teamID int) bool {
+	for _, et := range eventTeams {
+		if et.TeamID == teamID {
+			return et.Played || !et.Registered
+		}
+	}
+	return true
+}
+
+// upcomingEvent returns an upcoming event with the number of teams registered for it.
+func upcomingEvent(event *database.Event, eventTeams []*database.EventTeam) UpcomingEvent {
+	upcoming := UpcomingEvent{
+		EventID:   event.EventID,
+		EventCode: event.EventCode,
+		EventName: event.Name,
+		DateStart: event.DateStart,
+		DateEnd:   event.DateEnd,
+		Venue:     event.Venue,
+		City:      event.City,
+		StateProv: event.StateProv,
+		Country:   event.Country,
+		Notable:   []NotableTeam{},
+	}
+	for _, et := range eventTeams {
+		if et.Registered {
+			upcoming.Registered++
+		}
+	}
+	return upcoming
+}
+
+// addNotableTeams adds the other registered teams with the best npOPR this season to each upcoming event. Teams
+// without performance metrics this season aren't notable.
+func addNotableTeams(upcoming []UpcomingEvent, teamsByEvent map[string][]int) error {
+	var teamIDs []int
+	for _, ids := range teamsByEvent {
+		teamIDs = append(teamIDs, ids...)
+	}
+	if len(teamIDs) == 0 {
+		return nil
+	}
+	rankings, err := db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: teamIDs})
+	if err != nil {
+		return err
+	}
+	bestNpOPR := make(map[int]float64)
+	for _, ranking := range rankings {
+		if npOPR, ok := bestNpOPR[ranking.TeamID]; !ok || ranking.NpOPR > npOPR {
+			bestNpOPR[ranking.TeamID] = ranking.NpOPR
+		}
+	}
+
+	for i := range upcoming {
+		var ranked []int
+		for _, teamID := range teamsByEvent[upcoming[i].EventID] {
+			if _, ok := bestNpOPR[teamID]; ok {
+				ranked = append(ranked, teamID)
+			}
+		}
+		sort.Slice(ranked, func(a, b int) bool {
+			if bestNpOPR[ranked[a]] != bestNpOPR[ranked[b]] {
+				return bestNpOPR[ranked[a]] > bestNpOPR[ranked[b]]
+			}
+			return ranked[a] < ranked[b]
+		})
+		for _, teamID := range ranked[:min(len(ranked), notableTeams)] {
+			notable := NotableTeam{TeamID: teamID, NpOPR: bestNpOPR[teamID]}
+			team, err := db.GetTeam(teamID)
+			if err != nil {
+				return err
+			}
+			if team != nil {
+				notable.Name = team.Name
+			}
+			upcoming[i].Notable = append(upcoming[i].Notable, notable)
+		}
+	}
+	return nil
+}
+
+// TeamEventMetrics represents a team's results and performance metrics at a single event, along with the
+// change in each metric since the previous event the team played.
+type TeamEventMetrics struct {
+	EventDetails
+	HasMetrics bool // False if no performance metrics have been calculated for the event
+	Matches    int
+	OPR        float64
+	NpOPR      float64
+	CCWM       float64
+	NpAVG      float64
+	OPRDelta   float64 // Change in OPR since the previous event with metrics
+	NpOPRDelta float64 // Change in NpOPR since the previous event with metrics
+	CCWMDelta  float64 // Change in CCWM since the previous event with metrics
+	NpAVGDelta float64 // Change in NpAVG since the previous event with metrics
+}
+
+// TeamEventComparison compares a team's performance across each event they attended.
+type TeamEventComparison struct {
+	Team   *TeamDetails
+	Events []TeamEventMetrics
+	First  *TeamEventMetrics // First event with metrics, or nil if there are none
+	Latest *TeamEventMetrics // Latest event with metrics, or nil if there are none
+}
+
+// TeamEventComparisonQuery compares a team's metrics, rank, record, and awards at each event they attended,
+// ordered by event date. The change in each metric is calculated relative to the previous event the team played.
+// The team's details are those of TeamDetailsQuery for the year.
+func TeamEventComparisonQuery(teamID int, year int) (*TeamEventComparison, error) {
+	details, err := TeamDetailsQuery(teamID, year)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, nil
+	}
+
+	// Get the team's performance metrics at each event
+	rankings, err := db.GetTeamRankings(database.TeamRankingFilter{TeamIDs: []int{teamID}})
+	if err != nil {
+		return nil, err
+	}
+	rankingMap := make(map[string]*database.TeamRanking, len(rankings))
+	for _, ranking := range rankings {
+		rankingMap[ranking.EventID] = ranking
+	}
+
+	comparison := &TeamEventComparison{
+		Team:   details,
+		Events: make([]TeamEventMetrics, 0, len(details.Events)),
+	}
+
+	// Events are already sorted by date, so each event is compared with the one before it
+	var previous *TeamEventMetrics
+	for _, event := range details.Events {
+		metrics := TeamEventMetrics{EventDetails: event}
+		if ranking, ok := rankingMap[event.EventID]; ok {
+			metrics.HasMetrics = true
+			metrics.Matches = ranking.NumMatches
+			metrics.OPR = ranking.OPR
+			metrics.NpOPR = ranking.NpOPR
+			metrics.CCWM = ranking.CCWM
+			metrics.NpAVG = ranking.NpAvg
+			if previous != nil {
+				metrics.OPRDelta = metrics.OPR - previous.OPR
+				metrics.NpOPRDelta = metrics.NpOPR - previous.NpOPR
+				metrics.CCWMDelta = metrics.CCWM - previous.CCWM
+				metrics.NpAVGDelta = metrics.NpAVG - previous.NpAVG
+			}
+			previous = &metrics
+		}
+		comparison.Events = append(comparison.Events, metrics)
+	}
+
+	// Find the first and latest events with metrics
+	for i := range comparison.Events {
+		if comparison.Events[i].HasMetrics {
+			if comparison.First == nil {
+				comparison.First = &comparison.Events[i]
+			}
+			comparison.Latest = &comparison.Events[i]
+		}
+	}
+
+	return comparison, nil
+}
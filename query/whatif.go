@@ -0,0 +1,296 @@
+package query
+
+import (
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// PlayoffFinish is how far a team's alliance goes in the playoffs, or whether the team isn't selected for an
+// alliance at all.
+type PlayoffFinish struct {
+	Name     string
+	Points   int  // Playoff points for the finish
+	Selected bool // Whether the team is on an alliance, and so earns selection points
+}
+
+// JudgedFinish is the judged award a team wins at an event, if any.
+type JudgedFinish struct {
+	Name   string
+	Points int // Judging points for the award
+}
+
+// PlayoffFinishes are the playoff finishes considered by WhatIfQuery, from the best to the worst.
+var PlayoffFinishes = []PlayoffFinish{
+	{Name: "Winner", Points: 40, Selected: true},
+	{Name: "Finalist", Points: 20, Selected: true},
+	{Name: "3rd Place", Points: 10, Selected: true},
+	{Name: "4th Place", Points: 5, Selected: true},
+	{Name: "Eliminated", Points: 0, Selected: true},
+	{Name: "Not Selected", Points: 0, Selected: false},
+}
+
+// JudgedFinishes are the judged awards considered by WhatIfQuery, from no award to the Inspire Award.
+var JudgedFinishes = []JudgedFinish{
+	{Name: "No Award", Points: 0},
+	{Name: "Judged 3rd", Points: 3},
+	{Name: "Judged 2nd", Points: 6},
+	{Name: "Judged 1st", Points: 12},
+	{Name: "Inspire 3rd", Points: 15},
+	{Name: "Inspire 2nd", Points: 30},
+	{Name: "Inspire 1st", Points: 60},
+}
+
+// EventCutoff represents the advancement points of the last team to advance from a completed event.
+type EventCutoff struct {
+	Event     *database.Event
+	Teams     int // Teams ranked at the event
+	Advancing int // Teams that advanced, not counting teams that had already advanced
+	Cutoff    int // Lowest total advancement points of a team that advanced from the event
+}
+
+// WhatIfEvent represents the finishes a team needs at a remaining event to reach the advancement cutoff.
+type WhatIfEvent struct {
+	Event      *database.Event
+	Registered bool // Whether the team is registered for the event
+	Teams      int  // Teams registered for the event, or 0 if none have registered yet
+	Alliances  int  // Alliances in the playoffs
+	// Points holds the qualification points earned at each rank at the event, and is nil if no teams have
+	// registered for the event.
+	Points *QualificationPointsTable
+	// MaxRank holds, for each playoff finish and judged award, the lowest qualification rank that still reaches
+	// the cutoff, or 0 if no rank does. It is indexed by PlayoffFinishes and then by JudgedFinishes, and is nil if
+	// no teams have registered for the event.
+	MaxRank [][]int
+}
+
+// WhatIfReport represents the finishes a team needs at each remaining event in a region to advance.
+type WhatIfReport struct {
+	Team         *database.Team
+	RegionCode   string
+	Year         int
+	AdvancedFrom *database.Event // Event the team has already advanced from, or nil
+	Cutoffs      []*EventCutoff  // Cutoffs at the region's completed events, in date order
+	Cutoff       int             // Points assumed to be needed to advance from a remaining event
+	Events       []*WhatIfEvent
+}
+
+// WhatIfQuery calculates the minimum finishes a team needs at each remaining league tournament and qualifier in a
+// region to earn an advancement slot. The points needed are the cutoff given, or, if it is 0, the median of the
+// cutoffs at the region's completed events, where an event's cutoff is the lowest total advancement points of a
+// team that advanced from it. Finishes are calculated with the same qualification, selection, playoff, and judging
+// points as the advancement report. A team that ranks in the top of the qualification rankings is assumed to
+// captain the alliance of the same number; a lower-ranked team that is selected is assumed to be picked by the last
+// alliance, so the finishes are the least the team can count on. The events the team is registered for are
+// returned; if it isn't registered for any, every remaining event in the region is returned. It returns nil if
+// the team isn't found.
+func WhatIfQuery(teamID int, regionCode string, year int, cutoff int) (*WhatIfReport, error) {
+	team, err := db.GetTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, nil
+	}
+	regionCode = database.NormalizeCode(regionCode)
+	if regionCode == "" {
+		regionCode = team.HomeRegion
+	}
+
+	official := false
+	events, err := db.GetAllEvents(database.EventFilter{RegionCodes: []string{regionCode}, Year: year, Unofficial: &official})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(events, func(a, b *database.Event) int {
+		return a.DateStart.Compare(b.DateStart)
+	})
+
+	report := &WhatIfReport{
+		Team:       team,
+		RegionCode: regionCode,
+		Year:       year,
+		Cutoffs:    []*EventCutoff{},
+		Events:     []*WhatIfEvent{},
+	}
+
+	saved, err := seasonCutoffs(year)
+	if err != nil {
+		return nil, err
+	}
+	savedMap := make(map[string]*EventCutoff, len(saved))
+	for _, c := range saved {
+		savedMap[c.Event.EventID] = c
+	}
+
+	var remaining []*database.Event
+	for _, event := range events {
+		if tier := TierOf(event); tier != TierLeagueTournament && tier != TierQualifier {
+			continue
+		}
+		advancements, err := db.GetEventAdvancements(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if len(advancements) == 0 {
+			remaining = append(remaining, event)
+			continue
+		}
+		for _, adv := range advancements {
+			if adv.TeamID == teamID && report.AdvancedFrom == nil {
+				report.AdvancedFrom = event
+			}
+		}
+
+		// Use the saved cutoff if there is one, as calculating it looks up the event's alliances
+		eventCutoff, ok := savedMap[event.EventID]
+		if !ok {
+			eventCutoff, err = calculateAdvancementCutoff(event)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if eventCutoff != nil {
+			report.Cutoffs = append(report.Cutoffs, eventCutoff)
+		}
+	}
+
+	report.Cutoff = cutoff
+	if cutoff <= 0 {
+		report.Cutoff = medianCutoff(report.Cutoffs)
+	}
+	if report.AdvancedFrom != nil || report.Cutoff <= 0 {
+		return report, nil
+	}
+
+	// Only show the events the team is registered for, unless it isn't registered for any
+	registered := make(map[string]bool)
+	teamCounts := make(map[string]int)
+	for _, event := range remaining {
+		eventTeams, err := db.GetEventTeams(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		teamCounts[event.EventID] = len(eventTeams)
+		if slices.ContainsFunc(eventTeams, func(et *database.EventTeam) bool { return et.TeamID == teamID }) {
+			registered[event.EventID] = true
+		}
+	}
+	for _, event := range remaining {
+		if len(registered) > 0 && !registered[event.EventID] {
+			continue
+		}
+		whatIf := &WhatIfEvent{
+			Event:      event,
+			Registered: registered[event.EventID],
+			Teams:      teamCounts[event.EventID],
+			Alliances:  allianceCount(teamCounts[event.EventID]),
+		}
+		if whatIf.Teams > 0 {
+			whatIf.Points = QualificationPointsQuery(whatIf.Teams)
+			whatIf.MaxRank = make([][]int, len(PlayoffFinishes))
+			for i, playoff := range PlayoffFinishes {
+				whatIf.MaxRank[i] = make([]int, len(JudgedFinishes))
+				for j, judged := range JudgedFinishes {
+					whatIf.MaxRank[i][j] = maxRankForCutoff(report.Cutoff, whatIf.Teams, whatIf.Alliances, playoff, judged)
+				}
+			}
+		}
+		report.Events = append(report.Events, whatIf)
+	}
+
+	return report, nil
+}
+
+// AdvancementPoints returns the total advancement points for a finish at an event with the given number of teams
+// and alliances. A team ranked within the number of alliances is assumed to captain the alliance of the same
+// number, and a lower-ranked team that is selected is assumed to be picked by the last alliance.
+func AdvancementPoints(rank, teams, alliances int, playoff PlayoffFinish, judged JudgedFinish) int {
+	points := ftcQualificationPoints(rank, teams) + playoff.Points + judged.Points
+	if playoff.Selected {
+		points += selectionPoints(rank, alliances)
+	}
+	return points
+}
+
+// selectionPoints returns the alliance selection points for a selected team with the given qualification rank. A
+// team ranked within the number of alliances is assumed to captain the alliance of the same number, and a
+// lower-ranked team is assumed to be picked by the last alliance.
+func selectionPoints(rank, alliances int) int {
+	alliance := min(rank, alliances)
+	return max(20-(alliance-1), 0)
+}
+
+// QualificationRank represents the points a team earns for its qualification rank at an event.
+type QualificationRank struct {
+	Rank      int
+	Points    int // Qualification Phase Performance points
+	Selection int // Alliance selection points, if the team is selected for an alliance
+}
+
+// QualificationPointsTable represents the qualification points earned at each rank at an event of a given size.
+type QualificationPointsTable struct {
+	Teams     int
+	Alliances int
+	Ranks     []QualificationRank // Ranks from first to last
+}
+
+// QualificationPointsQuery returns the qualification points earned at each rank at an event with the given number
+// of teams, using the same points as the advancement report. The selection points assume a team ranked within the
+// number of alliances captains the alliance of the same number, and a lower-ranked team is picked by the last
+// alliance.
+func QualificationPointsQuery(teams int) *QualificationPointsTable {
+	table := &QualificationPointsTable{
+		Teams:     teams,
+		Alliances: allianceCount(teams),
+		Ranks:     make([]QualificationRank, 0, teams),
+	}
+	for rank := 1; rank <= teams; rank++ {
+		table.Ranks = append(table.Ranks, QualificationRank{
+			Rank:      rank,
+			Points:    ftcQualificationPoints(rank, teams),
+			Selection: selectionPoints(rank, table.Alliances),
+		})
+	}
+	return table
+}
+
+// maxRankForCutoff returns the lowest qualification rank at which a team with the playoff finish and judged award
+// still reaches the cutoff, or 0 if it doesn't at any rank.
+func maxRankForCutoff(cutoff, teams, alliances int, playoff PlayoffFinish, judged JudgedFinish) int {
+	// Points never increase as the rank gets lower, so the last rank that reaches the cutoff is the answer
+	maxRank := 0
+	for rank := 1; rank <= teams; rank++ {
+		if AdvancementPoints(rank, teams, alliances, playoff, judged) < cutoff {
+			break
+		}
+		maxRank = rank
+	}
+	return maxRank
+}
+
+// allianceCount returns the number of playoff alliances at an event with the given number of teams: 4 at events
+// with up to 20 teams, and 6 at larger events.
+func allianceCount(teams int) int {
+	if teams > 20 {
+		return 6
+	}
+	return 4
+}
+
+// medianCutoff returns the median of the cutoffs, or 0 if there are none.
+func medianCutoff(cutoffs []*EventCutoff) int {
+	if len(cutoffs) == 0 {
+		return 0
+	}
+	values := make([]int, 0, len(cutoffs))
+	for _, c := range cutoffs {
+		values = append(values, c.Cutoff)
+	}
+	slices.Sort(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid] + 1) / 2
+	}
+	return values[mid]
+}
@@ -0,0 +1,223 @@
+package query
+
+import (
+	"math"
+	"slices"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// MatchWinProbability is the chance the red alliance was given of winning a match before it was played, along with
+// how the match turned out.
+type MatchWinProbability struct {
+	Match          *database.Match
+	EventCode      string
+	RedTeams       []int
+	BlueTeams      []int
+	RedMargin      float64 // Predicted red score minus predicted blue score
+	RedProbability float64 // Chance of the red alliance winning before the match was played
+	RedResult      float64 // 1 if the red alliance won, 0 if it lost, and 0.5 for a tie
+}
+
+// TeamWPA is a team's win probability added over a season: how many more matches the team won than its alliances
+// were expected to win before each match was played. A positive WPA marks a team that wins more than its ratings
+// predict, such as in close matches, and a negative WPA one that wins less.
+type TeamWPA struct {
+	TeamID   int
+	Matches  int
+	Wins     float64 // Matches won, counting a tie as half a win
+	Expected float64 // Sum of the pre-match win probabilities of the team's alliances
+}
+
+// WPA returns the team's win probability added, the wins above or below the expected number.
+func (t TeamWPA) WPA() float64 {
+	return t.Wins - t.Expected
+}
+
+// PerMatch returns the team's win probability added per match played.
+func (t TeamWPA) PerMatch() float64 {
+	if t.Matches == 0 {
+		return 0
+	}
+	return t.WPA() / float64(t.Matches)
+}
+
+// WinProbabilityReport holds the pre-match win probabilities of a season's matches and the win probability added
+// by each team that played in them.
+type WinProbabilityReport struct {
+	Matches []MatchWinProbability
+	Teams   map[int]TeamWPA
+	Sigma   float64 // Standard deviation of the actual score margins around the predicted margins
+}
+
+// WinProbabilityQuery predicts the winner of each match at the season's events and totals how each team did
+// against the predictions. If region is provided (non-empty), only the region's events are included, and if
+// eventCodes are provided (non-empty), only those events are included. Unofficial events are only included if
+// includeUnofficial is true.
+//
+// An alliance's predicted score is the sum of its teams' OPRs going into the event, weighted by the number of
+// matches played at each of the team's earlier events; a team without an earlier event is rated by its OPR at the
+// event itself. The red alliance's win probability is the chance that a normally distributed score margin, centered
+// on the predicted margin, is positive, where the spread of the distribution is measured from how far the actual
+// margins of every included match fell from the predictions.
+func WinProbabilityQuery(region string, eventCodes []string, year int, includeUnofficial bool) (*WinProbabilityReport, error) {
+	region = database.NormalizeCode(region)
+	eventCodes = database.NormalizeCodes(eventCodes)
+
+	eventFilter := database.EventFilter{Year: year}
+	if region != "" {
+		eventFilter.RegionCodes = []string{region}
+	}
+	events, err := getRankedEvents(eventFilter, eventCodes, includeUnofficial)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ratings come from every event in the season, since teams may have played outside the region
+	seasonEvents, err := getRankedEvents(database.EventFilter{Year: year}, nil, includeUnofficial)
+	if err != nil {
+		return nil, err
+	}
+	seasonEventMap := make(map[string]*database.Event, len(seasonEvents))
+	seasonEventIDs := make([]string, 0, len(seasonEvents))
+	for _, event := range slices.Concat(seasonEvents, events) {
+		if _, ok := seasonEventMap[event.EventID]; !ok {
+			seasonEventMap[event.EventID] = event
+			seasonEventIDs = append(seasonEventIDs, event.EventID)
+		}
+	}
+	rankings, err := db.GetTeamRankings(database.TeamRankingFilter{EventIDs: seasonEventIDs})
+	if err != nil {
+		return nil, err
+	}
+	teamRankings := make(map[int][]*database.TeamRanking)
+	for _, ranking := range rankings {
+		teamRankings[ranking.TeamID] = append(teamRankings[ranking.TeamID], ranking)
+	}
+
+	report := &WinProbabilityReport{Teams: make(map[int]TeamWPA)}
+	var squaredErrors float64
+	for _, event := range events {
+		matches, err := db.GetMatchesByEvent(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		ratings := make(map[int]float64)
+		for _, match := range matches {
+			prediction, actualMargin, err := predictMatch(match, event, teamRankings, seasonEventMap, ratings)
+			if err != nil {
+				return nil, err
+			}
+			if prediction == nil {
+				continue
+			}
+			squaredErrors += (actualMargin - prediction.RedMargin) * (actualMargin - prediction.RedMargin)
+			report.Matches = append(report.Matches, *prediction)
+		}
+	}
+	if len(report.Matches) == 0 {
+		return report, nil
+	}
+
+	// Convert each predicted margin to a win probability using the spread of the actual margins around the
+	// predictions
+	report.Sigma = max(math.Sqrt(squaredErrors/float64(len(report.Matches))), 1)
+	for i := range report.Matches {
+		m := &report.Matches[i]
+		m.RedProbability = 0.5 * (1 + math.Erf(m.RedMargin/(report.Sigma*math.Sqrt2)))
+		for _, teamID := range m.RedTeams {
+			report.Teams[teamID] = addWPA(report.Teams[teamID], teamID, m.RedResult, m.RedProbability)
+		}
+		for _, teamID := range m.BlueTeams {
+			report.Teams[teamID] = addWPA(report.Teams[teamID], teamID, 1-m.RedResult, 1-m.RedProbability)
+		}
+	}
+
+	return report, nil
+}
+
+// predictMatch returns the predicted margin and result of a match, along with its actual score margin. It returns
+// nil if the match hasn't been scored, doesn't have teams on both alliances, or has a team that can't be rated.
+// Ratings caches the teams' ratings going into the event.
+func predictMatch(match *database.Match, event *database.Event, teamRankings map[int][]*database.TeamRanking, eventMap map[string]*database.Event, ratings map[int]float64) (*MatchWinProbability, float64, error) {
+	redScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceRed)
+	if err != nil {
+		return nil, 0, err
+	}
+	blueScore, err := db.GetMatchAllianceScore(match.MatchID, database.AllianceBlue)
+	if err != nil {
+		return nil, 0, err
+	}
+	if redScore == nil || blueScore == nil {
+		return nil, 0, nil
+	}
+	matchTeams, err := db.GetMatchTeams(match.MatchID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	prediction := &MatchWinProbability{Match: match, EventCode: event.EventCode}
+	for _, mt := range matchTeams {
+		if !mt.OnField || mt.Dq {
+			continue
+		}
+		rating, ok := ratings[mt.TeamID]
+		if !ok {
+			if rating, ok = teamRating(teamRankings[mt.TeamID], event, eventMap); !ok {
+				return nil, 0, nil
+			}
+			ratings[mt.TeamID] = rating
+		}
+		if mt.Alliance == database.AllianceRed {
+			prediction.RedTeams = append(prediction.RedTeams, mt.TeamID)
+			prediction.RedMargin += rating
+		} else {
+			prediction.BlueTeams = append(prediction.BlueTeams, mt.TeamID)
+			prediction.RedMargin -= rating
+		}
+	}
+	if len(prediction.RedTeams) == 0 || len(prediction.BlueTeams) == 0 {
+		return nil, 0, nil
+	}
+
+	switch {
+	case redScore.TotalPoints > blueScore.TotalPoints:
+		prediction.RedResult = 1
+	case redScore.TotalPoints == blueScore.TotalPoints:
+		prediction.RedResult = 0.5
+	}
+	return prediction, float64(redScore.TotalPoints - blueScore.TotalPoints), nil
+}
+
+// teamRating returns a team's OPR going into an event, weighted by the number of matches played at each of the
+// team's events that ended before the event started. If the team has no earlier events, its OPR at the event is
+// returned. It returns false if the team has no ranking to rate it by.
+func teamRating(rankings []*database.TeamRanking, event *database.Event, eventMap map[string]*database.Event) (float64, bool) {
+	var weightedOPR float64
+	var totalMatches int
+	for _, ranking := range rankings {
+		e := eventMap[ranking.EventID]
+		if e != nil && e.DateEnd.Before(event.DateStart) {
+			weightedOPR += ranking.OPR * float64(ranking.NumMatches)
+			totalMatches += ranking.NumMatches
+		}
+	}
+	if totalMatches > 0 {
+		return weightedOPR / float64(totalMatches), true
+	}
+	for _, ranking := range rankings {
+		if ranking.EventID == event.EventID {
+			return ranking.OPR, true
+		}
+	}
+	return 0, false
+}
+
+// addWPA adds a match's result and pre-match win probability to a team's win probability added.
+func addWPA(wpa TeamWPA, teamID int, result float64, probability float64) TeamWPA {
+	wpa.TeamID = teamID
+	wpa.Matches++
+	wpa.Wins += result
+	wpa.Expected += probability
+	return wpa
+}
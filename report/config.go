@@ -0,0 +1,96 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Types of sections a report can include
+const (
+	SectionRegionAdvancement = "region-advancement" // Teams advancing from the region's events
+	SectionLeaderboard       = "leaderboard"        // Region's teams ranked by their performance
+)
+
+// defaultLeaderboardLimit is the number of teams on a leaderboard section if no limit is given.
+const defaultLeaderboardLimit = 25
+
+// Section is a single report included in a report email.
+type Section struct {
+	Type   string `json:"type"`
+	Region string `json:"region"`          // Region code or alias
+	Sort   string `json:"sort,omitempty"`  // Leaderboard sort fields, such as "npopr" or "ccwm,-matches"; defaults to "opr"
+	Limit  int    `json:"limit,omitempty"` // Teams on a leaderboard; defaults to 25
+}
+
+// Report is an email of one or more sections, sent to its recipients on a schedule.
+type Report struct {
+	Name     string    `json:"name"`     // Name of the report, used as the subject of the email
+	Schedule string    `json:"schedule"` // Cron schedule the report is sent on, such as "0 7 * * 1" for 7:00 every Monday
+	To       []string  `json:"to"`       // Email addresses the report is sent to
+	Sections []Section `json:"sections"`
+
+	schedule *Schedule
+}
+
+// Config is the set of reports sent by the scheduler.
+type Config struct {
+	Reports []*Report `json:"reports"`
+}
+
+// LoadConfig reads the report configuration from a JSON file and checks that every report has a valid schedule, at
+// least one recipient, and at least one section.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(config.Reports) == 0 {
+		return nil, fmt.Errorf("no reports in %s", path)
+	}
+
+	names := make(map[string]bool)
+	for i, report := range config.Reports {
+		if report.Name == "" {
+			return nil, fmt.Errorf("report %d has no name", i+1)
+		}
+		if names[strings.ToLower(report.Name)] {
+			return nil, fmt.Errorf("report %q is defined more than once", report.Name)
+		}
+		names[strings.ToLower(report.Name)] = true
+
+		if report.schedule, err = ParseSchedule(report.Schedule); err != nil {
+			return nil, fmt.Errorf("report %q: %w", report.Name, err)
+		}
+		if len(report.To) == 0 {
+			return nil, fmt.Errorf("report %q has no recipients", report.Name)
+		}
+		if len(report.Sections) == 0 {
+			return nil, fmt.Errorf("report %q has no sections", report.Name)
+		}
+		for _, section := range report.Sections {
+			if section.Type != SectionRegionAdvancement && section.Type != SectionLeaderboard {
+				return nil, fmt.Errorf("report %q: unknown section type %q, expected %s or %s", report.Name, section.Type, SectionRegionAdvancement, SectionLeaderboard)
+			}
+			if section.Region == "" {
+				return nil, fmt.Errorf("report %q: %s section has no region", report.Name, section.Type)
+			}
+		}
+	}
+	return &config, nil
+}
+
+// Find returns the report with the name, ignoring case, or nil if there isn't one.
+func (c *Config) Find(name string) *Report {
+	for _, report := range c.Reports {
+		if strings.EqualFold(report.Name, name) {
+			return report
+		}
+	}
+	return nil
+}
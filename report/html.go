@@ -0,0 +1,164 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// table is a rendered section of a report.
+type table struct {
+	Title   string
+	Summary string
+	Columns []string
+	Rows    [][]string
+	Empty   string // Message shown instead of the table if there are no rows
+}
+
+// reportPage is the data the report template is rendered with.
+type reportPage struct {
+	Name      string
+	Year      int
+	Generated string
+	Tables    []*table
+}
+
+// reportTemplate lays out a report as an HTML email. The styles are inline, as many mail clients ignore style sheets.
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Name}}</title></head>
+<body style="font-family: Arial, Helvetica, sans-serif; color: #222222;">
+<h1 style="font-size: 20px; margin-bottom: 4px;">{{.Name}}</h1>
+<p style="color: #666666; margin-top: 0;">{{.Year}} season, generated {{.Generated}}</p>
+{{range .Tables}}
+<h2 style="font-size: 16px; margin-top: 24px; margin-bottom: 4px;">{{.Title}}</h2>
+{{if .Summary}}<p style="margin-top: 0;">{{.Summary}}</p>{{end}}
+{{if .Rows}}
+<table style="border-collapse: collapse; font-size: 13px;">
+<tr>{{range .Columns}}<th style="text-align: left; border-bottom: 2px solid #444444; padding: 4px 8px;">{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td style="border-bottom: 1px solid #dddddd; padding: 4px 8px; vertical-align: top;">{{.}}</td>{{end}}</tr>
+{{end}}
+</table>
+{{else}}
+<p style="color: #666666;">{{.Empty}}</p>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// Render renders the report's sections for the season as an HTML document.
+func Render(report *Report, year int, now time.Time) (string, error) {
+	page := &reportPage{
+		Name:      report.Name,
+		Year:      year,
+		Generated: fmt.Sprintf("%s at %s %s", locale.FullDate(now), locale.Time(now), now.Format("MST")),
+	}
+	for _, section := range report.Sections {
+		var t *table
+		var err error
+		switch section.Type {
+		case SectionRegionAdvancement:
+			t, err = regionAdvancementTable(section, year)
+		case SectionLeaderboard:
+			t, err = leaderboardTable(section, year)
+		default:
+			err = fmt.Errorf("unknown section type %q", section.Type)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to render %s section for %s: %w", section.Type, section.Region, err)
+		}
+		page.Tables = append(page.Tables, t)
+	}
+
+	var sb strings.Builder
+	if err := reportTemplate.Execute(&sb, page); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// regionAdvancementTable renders the teams advancing from the region's events.
+func regionAdvancementTable(section Section, year int) (*table, error) {
+	regionCode, err := query.ResolveRegion(section.Region)
+	if err != nil {
+		return nil, err
+	}
+	report, err := query.RegionAdvancementQuery(regionCode, year, false)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &table{
+		Title:   fmt.Sprintf("Advancing Teams - %s", regionCode),
+		Columns: []string{"Team", "Advancing Event", "Awards"},
+		Empty:   "No teams have advanced from this region yet.",
+	}
+	if report == nil {
+		return t, nil
+	}
+	t.Summary = fmt.Sprintf("Teams advancing: %d", len(report.TeamAdvancements))
+	for _, ta := range report.TeamAdvancements {
+		var awards []string
+		for _, award := range ta.AdvancingEventAwards {
+			awards = append(awards, award.Name)
+		}
+		t.Rows = append(t.Rows, []string{
+			fmt.Sprintf("%d - %s", ta.Team.TeamID, ta.Team.Name),
+			fmt.Sprintf("%s - %s", ta.AdvancingEvent.EventCode, ta.AdvancingEvent.Name),
+			strings.Join(awards, ", "),
+		})
+	}
+	return t, nil
+}
+
+// leaderboardTable renders the region's teams ranked by their performance at the season's official events.
+func leaderboardTable(section Section, year int) (*table, error) {
+	regionCode, err := query.ResolveRegion(section.Region)
+	if err != nil {
+		return nil, err
+	}
+	sortBy := section.Sort
+	if sortBy == "" {
+		sortBy = "opr"
+	}
+	keys, err := query.ParseSortKeys(sortBy, "")
+	if err != nil {
+		return nil, err
+	}
+	limit := section.Limit
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+
+	performances, err := query.TeamRankingsQuery(regionCode, "", nil, year, false, false)
+	if err != nil {
+		return nil, err
+	}
+	query.SortTeamPerformances(performances, keys)
+	if limit < len(performances) {
+		performances = performances[:limit]
+	}
+
+	t := &table{
+		Title:   fmt.Sprintf("Leaderboard - %s", regionCode),
+		Summary: fmt.Sprintf("Top %d teams sorted by %s", limit, sortBy),
+		Columns: []string{"Rank", "Team", "OPR", "npOPR", "CCWM", "Matches"},
+		Empty:   "No team rankings are available for this region yet.",
+	}
+	for i, p := range performances {
+		t.Rows = append(t.Rows, []string{
+			fmt.Sprintf("%d", i+1),
+			fmt.Sprintf("%d - %s", p.TeamID, p.TeamName),
+			locale.Number("%.2f", p.OPR),
+			locale.Number("%.2f", p.NpOPR),
+			locale.Number("%.2f", p.CCWM),
+			fmt.Sprintf("%d", p.Matches),
+		})
+	}
+	return t, nil
+}
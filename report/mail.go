@@ -0,0 +1,74 @@
+package report
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultSMTPPort is the mail submission port used if SMTP_PORT isn't set.
+const defaultSMTPPort = 587
+
+// SMTPConfig is the mail server reports are sent through.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // Username to sign in with, or empty if the server doesn't require it
+	Password string
+	From     string // Address the reports are sent from
+}
+
+// SMTPConfigFromEnv reads the mail server from the SMTP_HOST, SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD, and SMTP_FROM
+// environment variables. SMTP_PORT defaults to 587, and SMTP_FROM defaults to SMTP_USERNAME.
+func SMTPConfigFromEnv() (*SMTPConfig, error) {
+	config := &SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     defaultSMTPPort,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+	if config.Host == "" {
+		return nil, fmt.Errorf("SMTP_HOST is not set")
+	}
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		var err error
+		if config.Port, err = strconv.Atoi(port); err != nil || config.Port <= 0 {
+			return nil, fmt.Errorf("invalid SMTP_PORT %q", port)
+		}
+	}
+	if config.From == "" {
+		config.From = config.Username
+	}
+	if config.From == "" {
+		return nil, fmt.Errorf("SMTP_FROM is not set")
+	}
+	return config, nil
+}
+
+// Send emails the HTML document to the recipients. The connection is upgraded with STARTTLS if the server supports
+// it, and the username and password are only sent over an encrypted connection or to a server on localhost.
+func (c *SMTPConfig) Send(to []string, subject, html string, now time.Time) error {
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + c.From + "\r\n")
+	msg.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(html, "\n", "\r\n"))
+
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	return smtp.SendMail(addr, auth, c.From, to, []byte(msg.String()))
+}
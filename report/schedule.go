@@ -0,0 +1,139 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleAliases are the shorthand schedules accepted in place of the five cron fields.
+var scheduleAliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// Schedule is a parsed cron schedule with the standard five fields: minute, hour, day of the month, month, and day
+// of the week. Each field is "*", a number, a range such as "1-5", or a comma-separated list of them, and may be
+// followed by a step such as "*/15". Days of the week are numbered from 0 for Sunday, and 7 is also Sunday. As in
+// cron, if both the day of the month and the day of the week are restricted, a day matching either one matches.
+type Schedule struct {
+	minutes  [60]bool
+	hours    [24]bool
+	days     [32]bool
+	months   [13]bool
+	weekdays [7]bool
+	anyDay   bool // The day of the month is "*"
+	anyWeek  bool // The day of the week is "*"
+}
+
+// ParseSchedule parses a cron schedule, such as "0 7 * * 1" for 7:00 every Monday, or one of @hourly, @daily,
+// @weekly, or @monthly.
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if alias, ok := scheduleAliases[strings.ToLower(spec)]; ok {
+		spec = alias
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q, expected 5 fields: minute hour day-of-month month day-of-week", spec)
+	}
+
+	s := &Schedule{anyDay: fields[2] == "*", anyWeek: fields[4] == "*"}
+	if err := parseScheduleField(fields[0], 0, 59, s.minutes[:]); err != nil {
+		return nil, fmt.Errorf("invalid minute in schedule %q: %w", spec, err)
+	}
+	if err := parseScheduleField(fields[1], 0, 23, s.hours[:]); err != nil {
+		return nil, fmt.Errorf("invalid hour in schedule %q: %w", spec, err)
+	}
+	if err := parseScheduleField(fields[2], 1, 31, s.days[:]); err != nil {
+		return nil, fmt.Errorf("invalid day of the month in schedule %q: %w", spec, err)
+	}
+	if err := parseScheduleField(fields[3], 1, 12, s.months[:]); err != nil {
+		return nil, fmt.Errorf("invalid month in schedule %q: %w", spec, err)
+	}
+	var weekdays [8]bool
+	if err := parseScheduleField(fields[4], 0, 7, weekdays[:]); err != nil {
+		return nil, fmt.Errorf("invalid day of the week in schedule %q: %w", spec, err)
+	}
+	copy(s.weekdays[:], weekdays[:7])
+	s.weekdays[0] = s.weekdays[0] || weekdays[7]
+	return s, nil
+}
+
+// parseScheduleField marks the values matched by a cron field, which must be between lowest and highest.
+func parseScheduleField(field string, lowest, highest int, values []bool) error {
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		low, high := lowest, highest
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return fmt.Errorf("invalid value %q", part)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return fmt.Errorf("invalid value %q", part)
+				}
+			} else if hasStep {
+				high = highest
+			}
+		}
+		if low < lowest || high > highest || low > high {
+			return fmt.Errorf("%q is outside %d-%d", part, lowest, highest)
+		}
+		for v := low; v <= high; v += step {
+			values[v] = true
+		}
+	}
+	return nil
+}
+
+// matchesDay returns true if the schedule runs on the day of the time.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day, weekday := s.days[t.Day()], s.weekdays[t.Weekday()]
+	switch {
+	case s.anyDay && s.anyWeek:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeek:
+		return day
+	}
+	return day || weekday
+}
+
+// Next returns the first time after the given time that the schedule runs, in the time's location. It returns the
+// zero time if the schedule never runs, such as on February 30th.
+func (s *Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.months[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !s.hours[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !s.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/internal/locale"
+)
+
+// Send renders the report for the season and emails it to the report's recipients.
+func Send(report *Report, year int, mailer *SMTPConfig) error {
+	now := time.Now()
+	html, err := Render(report, year, now)
+	if err != nil {
+		return err
+	}
+	subject := fmt.Sprintf("%s - %s", report.Name, locale.LongDate(now))
+	return mailer.Send(report.To, subject, html, now)
+}
+
+// Run sends each report in the configuration on its schedule until the context is canceled. A report that fails to
+// render or send is logged and sent again at its next scheduled time.
+func Run(ctx context.Context, config *Config, year int, mailer *SMTPConfig) {
+	var wg sync.WaitGroup
+	for _, report := range config.Reports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runReport(ctx, report, year, mailer)
+		}()
+	}
+	wg.Wait()
+}
+
+// runReport sends the report each time its schedule runs until the context is canceled.
+func runReport(ctx context.Context, report *Report, year int, mailer *SMTPConfig) {
+	for {
+		next := report.schedule.Next(time.Now())
+		if next.IsZero() {
+			slog.Warn("report schedule never runs", "report", report.Name, "schedule", report.Schedule)
+			return
+		}
+		slog.Info("Scheduled report", "report", report.Name, "next", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := Send(report, year, mailer); err != nil {
+			slog.Error("failed to send report", "report", report.Name, "error", err)
+			continue
+		}
+		slog.Info("Sent report", "report", report.Name, "recipients", len(report.To))
+	}
+}
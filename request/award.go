@@ -1,16 +1,15 @@
 package request
 
 // Add code to request and build the database models and save them in the database.
-// This should use the ftc package to do all of the processing.
+// This should use the data source to retrieve all of the data.
 
 import (
 	"log/slog"
 
-	"github.com/rbrabson/ftc"
 	"github.com/rbrabson/ftcstanding/database"
 )
 
-// RequestAndSaveAwards requests awards from the FTC API for a given season and saves them in the database.
+// RequestAndSaveAwards requests awards from the data source for a given season and saves them in the database.
 func RequestAndSaveAwards(season string) []*database.Award {
 	awards := RequestAwards(season)
 	for _, award := range awards {
@@ -19,11 +18,11 @@ func RequestAndSaveAwards(season string) []*database.Award {
 	return awards
 }
 
-// RequestAwards requests awards from the FTC API for a given season.
+// RequestAwards requests awards from the data source for a given season.
 func RequestAwards(season string) []*database.Award {
-	ftcAwards, err := ftc.GetAwardListing(season)
+	ftcAwards, err := source.GetAwardListing(season)
 	if err != nil {
-		slog.Error("Error requesting awards:", "year", season, "error", err)
+		slog.Error("Error requesting awards:", "year", season, "source", source.Name(), "error", err)
 		return nil
 	}
 	slog.Info("Retrieved awards...", "count", len(ftcAwards))
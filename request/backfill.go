@@ -0,0 +1,122 @@
+package request
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// ImportSeason requests the awards, teams, and events of a season from another data source and saves them in the
+// database. It is used for seasons that the FTC Events API no longer provides, before the results of the season's
+// events are backfilled with BackfillEvents. The events that were saved are returned.
+func ImportSeason(ds DataSource, season string) []*database.Event {
+	previous := source
+	source = ds
+	defer func() {
+		source = previous
+	}()
+
+	RequestAndSaveAwards(season)
+	RequestAndSaveTeams(season)
+	return RequestAndSaveEvents(season)
+}
+
+// BackfillEvents requests the matches, rankings, and awards of events from another data source and saves them in
+// the database. It is used for events whose results are missing from the FTC Events API. Each match and event
+// ranking that is saved records the data source it was requested from. Awards are skipped if the data source
+// doesn't provide them.
+//
+// If eventCodes is empty, every event in the season that has ended but has no matches is backfilled. Otherwise the
+// given events are backfilled; events that aren't in the database are requested from the data source first. Events
+// that already have matches are skipped unless refresh is true. The team rankings are calculated for each event
+// that is backfilled, and the backfilled events are returned.
+func BackfillEvents(ds DataSource, season string, eventCodes []string, refresh bool) []*database.Event {
+	previous := source
+	source = ds
+	defer func() {
+		source = previous
+	}()
+
+	events, err := backfillCandidates(season, eventCodes)
+	if err != nil {
+		slog.Error("failed to load events to backfill", "season", season, "error", err)
+		return nil
+	}
+
+	var backfilled []*database.Event
+	for i, event := range events {
+		if event.Unofficial {
+			slog.Info("Skipping backfill of unofficial event", "event", event.EventCode)
+			continue
+		}
+		if event.DateEnd.After(time.Now()) {
+			slog.Info("Skipping backfill of future event", "event", event.EventCode, "dateEnd", event.DateEnd)
+			continue
+		}
+		matches, err := db.GetMatchesByEvent(event.EventID)
+		if err != nil {
+			slog.Warn("failed to load matches", "event", event.EventCode, "error", err)
+		}
+		if !refresh && len(matches) > 0 {
+			slog.Info("Skipping backfill of event with matches", "event", event.EventCode, "matches", len(matches))
+			continue
+		}
+
+		slog.Info("Backfilling event", "eventNumber", i+1, "totalEvents", len(events), "event", event.EventCode, "source", ds.Name())
+		if len(RequestAndSaveMatches(event)) == 0 {
+			slog.Warn("no matches found to backfill", "event", event.EventCode, "source", ds.Name())
+			continue
+		}
+		RequestAndSaveEventRankings(event)
+		RequestAndSaveEventAwards(event)
+		RequestAndSaveTeamsInEvent(event)
+		if err := RequestAndSaveTeamRankings(event); err != nil {
+			slog.Warn("failed to calculate team rankings", "event", event.EventCode, "error", err)
+		}
+		backfilled = append(backfilled, event)
+	}
+	slog.Info("Finished backfilling events", "season", season, "source", ds.Name(), "events", len(backfilled))
+	return backfilled
+}
+
+// backfillCandidates returns the events that may be backfilled. If eventCodes is empty, these are the events in the
+// season. Otherwise they are the given events, requesting any that aren't in the database from the data source.
+func backfillCandidates(season string, eventCodes []string) ([]*database.Event, error) {
+	if len(eventCodes) == 0 {
+		return db.GetAllEvents()
+	}
+
+	events, err := db.GetAllEvents(database.EventFilter{EventCodes: eventCodes})
+	if err != nil {
+		return nil, err
+	}
+	missing := slices.DeleteFunc(slices.Clone(eventCodes), func(code string) bool {
+		return slices.ContainsFunc(events, func(e *database.Event) bool {
+			return strings.EqualFold(e.EventCode, code)
+		})
+	})
+	if len(missing) == 0 {
+		return events, nil
+	}
+
+	for _, event := range RequestEvents(season) {
+		if !slices.ContainsFunc(missing, func(code string) bool { return strings.EqualFold(event.EventCode, code) }) {
+			continue
+		}
+		setEventLocation(event)
+		if err := db.SaveEvent(event); err != nil {
+			slog.Warn("failed to save event", "event", event.EventCode, "error", err)
+			continue
+		}
+		events = append(events, event)
+	}
+	for _, code := range missing {
+		if !slices.ContainsFunc(events, func(e *database.Event) bool { return strings.EqualFold(e.EventCode, code) }) {
+			slog.Warn("event not found", "event", code, "source", source.Name())
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,119 @@
+package request
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// Endpoints of the data source whose responses are hashed for an event.
+const (
+	awardsEndpoint        = "awards"
+	rankingsEndpoint      = "rankings"
+	advancementsEndpoint  = "advancements"
+	registrationsEndpoint = "registrations"
+)
+
+// matchesEndpoint returns the endpoint of the data source for the matches of a type.
+func matchesEndpoint(matchType ftc.MatchType) string {
+	return "matches/" + strings.ToLower(string(matchType))
+}
+
+// endpointHashes compares the responses from the data source's endpoints for an event with the hashes saved by the
+// last sync, so the responses that haven't changed don't have to be saved again. The endpoints that were saved and
+// skipped are recorded in the event's reconciliation.
+type endpointHashes struct {
+	event          *database.Event
+	stored         map[string]string
+	reconciliation *database.EventReconciliation
+}
+
+// loadEndpointHashes loads the hashes saved by the last sync of an event. If they can't be loaded, every response is
+// treated as changed.
+func loadEndpointHashes(event *database.Event, reconciliation *database.EventReconciliation) *endpointHashes {
+	h := &endpointHashes{event: event, stored: make(map[string]string), reconciliation: reconciliation}
+	hashes, err := db.GetEndpointHashes(event.EventID)
+	if err != nil {
+		slog.Warn("failed to load endpoint hashes", "event", event.EventCode, "error", err)
+		return h
+	}
+	for _, hash := range hashes {
+		h.stored[hash.Endpoint] = hash.Hash
+	}
+	return h
+}
+
+// changed hashes the records returned by an endpoint and returns the hash, along with true if the records need to be
+// saved. The records are skipped if they hash the same as those saved by the last sync, or if the endpoint returned
+// none, as there's nothing to save and the saved records are kept.
+func (h *endpointHashes) changed(endpoint string, count int, records any) (string, bool) {
+	if count == 0 {
+		h.skip(endpoint)
+		return "", false
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		slog.Warn("failed to hash endpoint response", "event", h.event.EventCode, "endpoint", endpoint, "error", err)
+		return "", true
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if h.stored[endpoint] == hash {
+		h.skip(endpoint)
+		return hash, false
+	}
+	return hash, true
+}
+
+// skip records that the response from an endpoint wasn't saved.
+func (h *endpointHashes) skip(endpoint string) {
+	h.reconciliation.SkippedEndpoints = append(h.reconciliation.SkippedEndpoints, endpoint)
+}
+
+// save records that the response from an endpoint was saved, and saves its hash so the next sync can skip it if it
+// hasn't changed. An empty hash isn't saved, so the next sync saves the response again.
+func (h *endpointHashes) save(endpoint string, hash string) {
+	h.reconciliation.UpdatedEndpoints = append(h.reconciliation.UpdatedEndpoints, endpoint)
+	if hash == "" {
+		return
+	}
+	err := db.SaveEndpointHash(&database.EndpointHash{
+		EventID:  h.event.EventID,
+		Endpoint: endpoint,
+		Hash:     hash,
+		HashedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		slog.Warn("failed to save endpoint hash", "event", h.event.EventCode, "endpoint", endpoint, "error", err)
+	}
+}
+
+// updated returns true if the response from any of the endpoints was saved.
+func (h *endpointHashes) updated(endpoints ...string) bool {
+	return slices.ContainsFunc(endpoints, func(endpoint string) bool {
+		return slices.Contains(h.reconciliation.UpdatedEndpoints, endpoint)
+	})
+}
+
+// EndpointStats counts the endpoints whose responses were saved during a sync because they changed, and those that
+// were skipped because they hadn't.
+type EndpointStats struct {
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+// Add adds the endpoints saved and skipped while syncing an event.
+func (s *EndpointStats) Add(reconciliation *database.EventReconciliation) {
+	if reconciliation == nil {
+		return
+	}
+	s.Updated += len(reconciliation.UpdatedEndpoints)
+	s.Skipped += len(reconciliation.SkippedEndpoints)
+}
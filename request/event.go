@@ -1,32 +1,94 @@
 package request
 
 // Add code to request and build the database models and save them in the database.
-// This should use the ftc package to do all of the processing.
+// This should use the data source to retrieve all of the data.
 
 import (
+	"errors"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/rbrabson/ftc"
 	"github.com/rbrabson/ftcstanding/database"
 )
 
-// RequestAndSaveEvents requests events from the FTC API for a given season and saves them in the database.
+// RequestAndSaveEvents requests events from the data source for a given season and saves them in the database.
+// If a geocoder has been set, the location of each event's venue is looked up before it is saved. Events whose
+// start date moved to another year are moved to their new event ID, rather than being saved a second time.
 func RequestAndSaveEvents(season string) []*database.Event {
 	events := RequestEvents(season)
 	for _, event := range events {
-		db.SaveEvent(event)
+		setEventLocation(event)
+		if err := db.SaveEvent(event); err != nil {
+			slog.Error("failed to save event", "event", event.EventCode, "error", err)
+			continue
+		}
+		moveRescheduledEvent(event)
 	}
 	return events
 }
 
-// RequestEvents requests events from the FTC API for a given season.
+// moveRescheduledEvent moves the records of an event saved under an earlier event ID to the event's current ID.
+// Event IDs embed the year the event starts, so an event that is rescheduled into another year, such as from
+// December to January, is given a new event ID. Unofficial events that share the event code are left alone.
+func moveRescheduledEvent(event *database.Event) {
+	saved, err := db.GetAllEvents(database.EventFilter{EventCodes: []string{event.EventCode}, Year: event.Year})
+	if err != nil {
+		slog.Warn("failed to load events", "event", event.EventCode, "error", err)
+		return
+	}
+	for _, old := range saved {
+		if old.EventID == event.EventID || old.Unofficial {
+			continue
+		}
+		if err := db.MoveEvent(old.EventID, event.EventID); err != nil {
+			slog.Error("failed to move rescheduled event", "event", event.EventCode, "from", old.EventID, "to", event.EventID, "error", err)
+			continue
+		}
+		slog.Warn("Moved rescheduled event to its new event ID", "event", event.EventCode, "from", old.EventID, "to", event.EventID,
+			"oldDateStart", old.DateStart.Format(time.DateOnly), "dateStart", event.DateStart.Format(time.DateOnly))
+	}
+}
+
+// setEventLocation sets the latitude and longitude of the event's venue. The location saved for the event is
+// reused if the address hasn't changed; otherwise the address is geocoded if a geocoder has been set.
+func setEventLocation(event *database.Event) {
+	existing, _ := db.GetEvent(event.EventID)
+	if existing != nil && existing.HasLocation() && existing.Address == event.Address && existing.City == event.City {
+		event.Latitude = existing.Latitude
+		event.Longitude = existing.Longitude
+		return
+	}
+	if geocoder == nil {
+		return
+	}
+
+	address := strings.Join(slices.DeleteFunc([]string{event.Address, event.City, event.StateProv, event.Country}, func(s string) bool {
+		return s == ""
+	}), ", ")
+	if address == "" {
+		return
+	}
+	location, err := geocoder.Geocode(address)
+	if err != nil {
+		slog.Warn("failed to geocode event address", "event", event.EventCode, "address", address, "error", err)
+		return
+	}
+	if location == nil {
+		slog.Debug("no location found for event address", "event", event.EventCode, "address", address)
+		return
+	}
+	event.Latitude = location.Latitude
+	event.Longitude = location.Longitude
+}
+
+// RequestEvents requests events from the data source for a given season.
 func RequestEvents(season string) []*database.Event {
-	ftcEvents, err := ftc.GetEvents(season)
+	ftcEvents, err := source.GetEvents(season)
 	if err != nil {
-		slog.Error("Error requesting events:", "year", season, "error", err)
+		slog.Error("Error requesting events:", "year", season, "source", source.Name(), "error", err)
 		return nil
 	}
 	slog.Info("Retrieved events...", "count", len(ftcEvents))
@@ -65,7 +127,7 @@ func RequestEvents(season string) []*database.Event {
 	return events
 }
 
-// RequestAndSaveEventAwards requests event awards from the FTC API for a given event and saves them in the database.
+// RequestAndSaveEventAwards requests event awards from the data source for a given event and saves them in the database.
 func RequestAndSaveEventAwards(event *database.Event) []*database.EventAward {
 	eventAwards := RequestEventAwards(event)
 	for _, eventAward := range eventAwards {
@@ -74,11 +136,15 @@ func RequestAndSaveEventAwards(event *database.Event) []*database.EventAward {
 	return eventAwards
 }
 
-// RequestEventAwards requests event awards from the FTC API for a given event.
+// RequestEventAwards requests event awards from the data source for a given event.
 func RequestEventAwards(event *database.Event) []*database.EventAward {
-	ftcEventAwards, err := ftc.GetEventAwards(strconv.Itoa(event.Year), event.EventCode)
+	ftcEventAwards, err := source.GetEventAwards(strconv.Itoa(event.Year), event.EventCode)
 	if err != nil {
-		slog.Error("Error requesting event awards:", "year", event.Year, "eventCode", event.EventCode, "error", err)
+		if errors.Is(err, errors.ErrUnsupported) {
+			slog.Debug("Event awards not provided by the data source", "eventCode", event.EventCode, "source", source.Name())
+			return nil
+		}
+		slog.Error("Error requesting event awards:", "year", event.Year, "eventCode", event.EventCode, "source", source.Name(), "error", err)
 		return nil
 	}
 	slog.Info("Retrieved event awards...", "count", len(ftcEventAwards))
@@ -91,13 +157,16 @@ func RequestEventAwards(event *database.Event) []*database.EventAward {
 			Name:    ftcEventAward.Name,
 			Series:  ftcEventAward.Series,
 		}
+		if ftcEventAward.Person != nil {
+			eventAward.Person = strings.TrimSpace(*ftcEventAward.Person)
+		}
 		eventAwards = append(eventAwards, &eventAward)
 	}
 	slog.Info("Finished processing event awards", "count", len(eventAwards))
 	return eventAwards
 }
 
-// RequestAndSaveEventRankings requests event rankings from the FTC API for a given event and saves them in the database.
+// RequestAndSaveEventRankings requests event rankings from the data source for a given event and saves them in the database.
 func RequestAndSaveEventRankings(event *database.Event) []*database.EventRanking {
 	eventRankings := RequestEventRanking(event)
 	for _, eventRanking := range eventRankings {
@@ -106,11 +175,11 @@ func RequestAndSaveEventRankings(event *database.Event) []*database.EventRanking
 	return eventRankings
 }
 
-// RequestEventRanking requests event rankings from the FTC API for a given event.
+// RequestEventRanking requests event rankings from the data source for a given event.
 func RequestEventRanking(event *database.Event) []*database.EventRanking {
-	ftcEventRankings, err := ftc.GetRankings(strconv.Itoa(event.Year), event.EventCode)
+	ftcEventRankings, err := source.GetRankings(strconv.Itoa(event.Year), event.EventCode)
 	if err != nil {
-		slog.Error("Error requesting event rankings:", "year", event.Year, "eventCode", event.EventCode, "error", err)
+		slog.Error("Error requesting event rankings:", "year", event.Year, "eventCode", event.EventCode, "source", source.Name(), "error", err)
 		return nil
 	}
 	eventRankings := make([]*database.EventRanking, 0, len(ftcEventRankings))
@@ -131,6 +200,7 @@ func RequestEventRanking(event *database.Event) []*database.EventRanking {
 			Dq:             ftcEventRanking.DQ,
 			MatchesPlayed:  ftcEventRanking.MatchesPlayed,
 			MatchesCounted: ftcEventRanking.MatchesCounted,
+			Source:         source.Name(),
 		}
 		eventRankings = append(eventRankings, &eventRanking)
 	}
@@ -138,7 +208,7 @@ func RequestEventRanking(event *database.Event) []*database.EventRanking {
 	return eventRankings
 }
 
-// RequestAndSaveEventAdvancements requests event advancements from the FTC API for a given event and saves them in the database.
+// RequestAndSaveEventAdvancements requests event advancements from the data source for a given event and saves them in the database.
 func RequestAndSaveEventAdvancements(event *database.Event) []*database.EventAdvancement {
 	eventAdvancements := RequestEventAdvancements(event)
 	for _, eventAdvancement := range eventAdvancements {
@@ -147,11 +217,11 @@ func RequestAndSaveEventAdvancements(event *database.Event) []*database.EventAdv
 	return eventAdvancements
 }
 
-// RequestEventAdvancements requests event advancements from the FTC API for a given season and event.
+// RequestEventAdvancements requests event advancements from the data source for a given season and event.
 func RequestEventAdvancements(event *database.Event) []*database.EventAdvancement {
-	ftcEventAdvancements, err := ftc.GetAdvancementsTo(strconv.Itoa(event.Year), event.EventCode)
+	ftcEventAdvancements, err := source.GetAdvancementsTo(strconv.Itoa(event.Year), event.EventCode)
 	if err != nil {
-		slog.Error("Error requesting event advancements:", "year", event.Year, "eventCode", event.EventCode, "error", err)
+		slog.Error("Error requesting event advancements:", "year", event.Year, "eventCode", event.EventCode, "source", source.Name(), "error", err)
 		return nil
 	}
 	eventAdvancements := make([]*database.EventAdvancement, 0, len(ftcEventAdvancements.Advancement))
@@ -198,6 +268,7 @@ func RequestTeamsInEvent(event *database.Event) []*database.EventTeam {
 		eventTeam := &database.EventTeam{
 			EventID: event.EventID,
 			TeamID:  teamID,
+			Played:  true,
 		}
 		eventTeams = append(eventTeams, eventTeam)
 	}
@@ -206,17 +277,61 @@ func RequestTeamsInEvent(event *database.Event) []*database.EventTeam {
 	return eventTeams
 }
 
-// RequestAndSaveTeamsInEvent retrieves all teams for an event and saves them to the database.
+// RequestAndSaveTeamsInEvent retrieves all teams that played in an event's matches and saves them to the database,
+// keeping whether they are registered for the event.
 func RequestAndSaveTeamsInEvent(event *database.Event) []*database.EventTeam {
 	eventTeams := RequestTeamsInEvent(event)
-
-	for _, eventTeam := range eventTeams {
-		if err := db.SaveEventTeam(eventTeam); err != nil {
-			slog.Error("failed to save event team", "eventID", event.EventID, "teamID", eventTeam.TeamID, "error", err)
-			return nil
-		}
+	if err := saveEventTeams(event, eventTeams, playedFlag); err != nil {
+		return nil
 	}
 
 	slog.Info("stored event teams from matches", "eventID", event.EventID, "eventCode", event.EventCode, "teamCount", len(eventTeams))
+	CheckEventAttendance(event)
 	return eventTeams
 }
+
+// CheckEventAttendance compares the teams registered for an event with the teams that actually played in its
+// matches, logging a warning for any teams that did not show or that played without being registered. Teams are
+// considered registered if they are registered for the event or are in its rankings.
+func CheckEventAttendance(event *database.Event) *database.EventAttendance {
+	eventTeams, err := db.GetEventTeams(event.EventID)
+	if err != nil {
+		slog.Error("failed to load event teams", "eventID", event.EventID, "error", err)
+		return nil
+	}
+	rankings, err := db.GetEventRankings(event.EventID)
+	if err != nil {
+		slog.Error("failed to load event rankings", "eventID", event.EventID, "error", err)
+		return nil
+	}
+	registered := make([]int, 0, len(eventTeams)+len(rankings))
+	for _, et := range eventTeams {
+		if et.Registered {
+			registered = append(registered, et.TeamID)
+		}
+	}
+	for _, ranking := range rankings {
+		registered = append(registered, ranking.TeamID)
+	}
+
+	matches, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		slog.Error("failed to load matches for event", "eventID", event.EventID, "error", err)
+		return nil
+	}
+	var matchTeams []*database.MatchTeam
+	for _, match := range matches {
+		mts, err := db.GetMatchTeams(match.MatchID)
+		if err != nil {
+			slog.Error("failed to load match teams", "matchID", match.MatchID, "error", err)
+			continue
+		}
+		matchTeams = append(matchTeams, mts...)
+	}
+
+	attendance := database.GetEventAttendance(event.EventID, registered, matchTeams)
+	if len(matches) > 0 && attendance.HasAnomalies() {
+		slog.Warn("event attendance does not match registration", "eventCode", event.EventCode, "registered", len(attendance.Registered), "played", len(attendance.Played), "noShows", attendance.NoShows, "walkOns", attendance.WalkOns)
+	}
+	return attendance
+}
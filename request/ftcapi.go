@@ -0,0 +1,88 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rbrabson/ftc"
+)
+
+// ftcServer holds the FTC Events API server and credentials used for the requests the ftc package doesn't support.
+// They are read from the same environment variables as the ftc package, and changed along with it by SetFTCServer.
+var ftcServer struct {
+	mu       sync.RWMutex
+	url      string
+	username string
+	authKey  string
+}
+
+// ftcClient sends the requests the ftc package doesn't support.
+var ftcClient = &http.Client{Timeout: 30 * time.Second}
+
+// init reads the FTC Events API server and credentials from the environment.
+func init() {
+	godotenv.Load()
+
+	ftcServer.url = os.Getenv("FTC_SERVER")
+	ftcServer.username = os.Getenv("FTC_USERNAME")
+	ftcServer.authKey = os.Getenv("FTC_AUTHORIZATION_KEY")
+}
+
+// setFTCServer sets the server and credentials used for the requests the ftc package doesn't support.
+func setFTCServer(serverURL, username, authKey string) {
+	ftcServer.mu.Lock()
+	defer ftcServer.mu.Unlock()
+
+	ftcServer.url = serverURL
+	ftcServer.username = username
+	ftcServer.authKey = authKey
+}
+
+// getFTC sends a GET request for the path, which is relative to the server URL, to the FTC Events API and decodes the
+// JSON response into v.
+func getFTC(path string, v any) error {
+	ftcServer.mu.RLock()
+	serverURL, username, authKey := ftcServer.url, ftcServer.username, ftcServer.authKey
+	ftcServer.mu.RUnlock()
+
+	req, err := http.NewRequest(http.MethodGet, serverURL+path, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, authKey)
+
+	resp, err := ftcClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("HTTP Status Code: %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// GetEventTeams returns the teams registered for an event. The teams are listed before the event starts, and are
+// requested page by page here, as the ftc package can't page through the teams of an event.
+func (FTCEventsAPI) GetEventTeams(season, eventCode string) ([]*ftc.Team, error) {
+	var teams []*ftc.Team
+	for page, pages := 1, 1; page <= pages; page++ {
+		var output ftc.Teams
+		path := fmt.Sprintf("/%s/teams?eventCode=%s&page=%d", url.PathEscape(season), url.QueryEscape(eventCode), page)
+		if err := getFTC(path, &output); err != nil {
+			return nil, err
+		}
+		teams = append(teams, output.Teams...)
+		pages = output.PageTotal
+	}
+	return teams, nil
+}
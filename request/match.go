@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/rbrabson/ftc"
 	"github.com/rbrabson/ftcstanding/database"
@@ -13,46 +14,103 @@ import (
 
 var titleCaser = cases.Title(language.English)
 
+// matchTypes are the tournament levels whose matches are requested for an event.
+var matchTypes = []ftc.MatchType{ftc.QUALIFIER, ftc.PLAYOFF}
+
+// matchResults are the matches of a type requested for an event, along with the alliance scores and teams of each
+// match, and the error if they couldn't be requested.
+type matchResults struct {
+	matchType ftc.MatchType
+	matches   []*database.Match
+	scores    []*database.MatchAllianceScore
+	teams     []*database.MatchTeam
+	err       error
+}
+
 // GetAndSaveMatches retrieves all matches for an event and saves them to the database.
 func RequestAndSaveMatches(event *database.Event) []*database.Match {
-	qualifierMatches := RequestAndSaveMatchesByType(event, ftc.QUALIFIER)
-	playoffMatches := RequestAndSaveMatchesByType(event, ftc.PLAYOFF)
-	return append(qualifierMatches, playoffMatches...)
+	var matches []*database.Match
+	for _, results := range requestAllMatches(event) {
+		saveMatchResults(results)
+		matches = append(matches, results.matches...)
+	}
+	return matches
 }
 
 // GetMatches retrieves all matches for an event.
 func RequestMatches(event *database.Event) []*database.Match {
-	qualifierMatches := RequestMatchesByType(event, ftc.QUALIFIER)
-	playoffMatches := RequestMatchesByType(event, ftc.PLAYOFF)
-	return append(qualifierMatches, playoffMatches...)
+	var matches []*database.Match
+	for _, results := range requestAllMatches(event) {
+		matches = append(matches, results.matches...)
+	}
+	return matches
 }
 
 // GetAndSaveMatchesByType retrieves all qualification matches for an event and saves them to the database.
 func RequestAndSaveMatchesByType(event *database.Event, matchType ftc.MatchType) []*database.Match {
-	matches := RequestMatchesByType(event, matchType)
-	for _, match := range matches {
+	matches, scores, matchTeams, err := requestMatchesByType(event, matchType)
+	saveMatchResults(matchResults{matchType: matchType, matches: matches, scores: scores, teams: matchTeams, err: err})
+	return matches
+}
+
+// requestAllMatches requests the matches of each of the match types for an event from the data source
+// concurrently, as the requests are independent of each other. The results are returned in the order of the match
+// types.
+func requestAllMatches(event *database.Event) []matchResults {
+	results := make([]matchResults, len(matchTypes))
+	var wg sync.WaitGroup
+	for i, matchType := range matchTypes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matches, scores, matchTeams, err := requestMatchesByType(event, matchType)
+			results[i] = matchResults{matchType: matchType, matches: matches, scores: scores, teams: matchTeams, err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// saveMatchResults saves the requested matches to the database, along with their scores and teams. Each match is
+// saved before the rows that depend on it.
+func saveMatchResults(results matchResults) {
+	for _, match := range results.matches {
 		_ = db.SaveMatch(match)
 	}
-	return matches
+	for _, score := range results.scores {
+		_ = db.SaveMatchAllianceScore(score)
+	}
+	for _, team := range results.teams {
+		_ = db.SaveMatchTeam(team)
+	}
 }
 
 // GetMatchesByType retrieves all qualification matches for an event.
 func RequestMatchesByType(event *database.Event, matchType ftc.MatchType) []*database.Match {
-	ftcMatches, err := ftc.GetMatchResults(strconv.Itoa(event.Year), event.EventCode, matchType)
+	matches, _, _, _ := requestMatchesByType(event, matchType)
+	return matches
+}
+
+// requestMatchesByType retrieves the matches of a type for an event, along with the alliance scores and teams of
+// each match. The error is returned, after it is logged, if the matches or scores couldn't be requested.
+func requestMatchesByType(event *database.Event, matchType ftc.MatchType) ([]*database.Match, []*database.MatchAllianceScore, []*database.MatchTeam, error) {
+	ftcMatches, err := source.GetMatchResults(strconv.Itoa(event.Year), event.EventCode, matchType)
 	if err != nil {
-		slog.Error("Error requesting match results:", "year", event.Year, "eventCode", event.EventCode, "matchType", matchType, "error", err)
-		return nil
+		slog.Error("Error requesting match results:", "year", event.Year, "eventCode", event.EventCode, "matchType", matchType, "source", source.Name(), "error", err)
+		return nil, nil, nil, err
 	}
 	slog.Info("Retrieved match results...", "count", len(ftcMatches))
 
-	ftcScores, err := ftc.GetEventScores(strconv.Itoa(event.Year), event.EventCode, matchType)
+	ftcScores, err := source.GetEventScores(strconv.Itoa(event.Year), event.EventCode, matchType)
 	if err != nil {
-		slog.Error("failed to get event scores", "year", event.Year, "eventCode", event.EventCode, "matchType", matchType, "error", err)
-		return nil
+		slog.Error("failed to get event scores", "year", event.Year, "eventCode", event.EventCode, "matchType", matchType, "source", source.Name(), "error", err)
+		return nil, nil, nil, err
 	}
 	slog.Info("Retrieved event scores...", "count", len(ftcScores))
 
 	matches := make([]*database.Match, 0, len(ftcMatches))
+	scores := make([]*database.MatchAllianceScore, 0, 2*len(ftcMatches))
+	var matchTeams []*database.MatchTeam
 	for _, ftcMatch := range ftcMatches {
 		match := getMatch(event, ftcMatch)
 		matches = append(matches, match)
@@ -76,19 +134,14 @@ func RequestMatchesByType(event *database.Event, matchType ftc.MatchType) []*dat
 		}
 
 		redScore, blueScore := getMatchScores(match, ftcMatch, ftcScore)
-		_ = db.SaveMatchAllianceScore(redScore)
-		_ = db.SaveMatchAllianceScore(blueScore)
+		scores = append(scores, redScore, blueScore)
 
 		redTeams, blueTeams := getMatchTeams(match, ftcMatch)
-		for _, team := range redTeams {
-			_ = db.SaveMatchTeam(team)
-		}
-		for _, team := range blueTeams {
-			_ = db.SaveMatchTeam(team)
-		}
+		matchTeams = append(matchTeams, redTeams...)
+		matchTeams = append(matchTeams, blueTeams...)
 	}
 	slog.Info("Finished processing match results and event results", "count", len(matches))
-	return matches
+	return matches, scores, matchTeams, nil
 }
 
 // getMatch creates a database.Match from an ftc.Match.
@@ -106,9 +159,10 @@ func getMatch(event *database.Event, ftcMatch *ftc.Match) *database.Match {
 		MatchID:         database.GetMatchID(event, ftcMatch.TournamentLevel, matchNumber),
 		MatchType:       tournamentLevel,
 		MatchNumber:     matchNumber,
-		ActualStartTime: ftcMatch.ActualStartTime,
+		ActualStartTime: database.ParseStartTime(ftcMatch.ActualStartTime, event.Location()),
 		Description:     ftcMatch.Description,
 		TournamentLevel: ftcMatch.TournamentLevel,
+		Source:          source.Name(),
 	}
 
 	return match
@@ -211,16 +265,13 @@ func StoreEventTeamsFromMatches(event *database.Event) error {
 		}
 	}
 
-	// Store EventTeam entries for all unique teams
+	// Store EventTeam entries for all unique teams, keeping whether they are registered
+	eventTeams := make([]*database.EventTeam, 0, len(teamIDsMap))
 	for teamID := range teamIDsMap {
-		eventTeam := &database.EventTeam{
-			EventID: event.EventID,
-			TeamID:  teamID,
-		}
-		if err := db.SaveEventTeam(eventTeam); err != nil {
-			slog.Error("failed to save event team", "eventID", event.EventID, "teamID", teamID, "error", err)
-			return err
-		}
+		eventTeams = append(eventTeams, &database.EventTeam{EventID: event.EventID, TeamID: teamID})
+	}
+	if err := saveEventTeams(event, eventTeams, playedFlag); err != nil {
+		return err
 	}
 
 	slog.Info("stored event teams from matches", "eventID", event.EventID, "eventCode", event.EventCode, "teamCount", len(teamIDsMap))
@@ -0,0 +1,288 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// RequestAndSaveEventResults requests the awards, rankings, advancements, matches, registrations, and teams for an
+// event and saves them in the database, then removes the records saved by an earlier sync that the data source no longer returns,
+// such as a deleted match or a team that was removed from the event. Team rankings aren't calculated, but those of
+// teams removed from the event are deleted. The removed records are logged and returned.
+//
+// Records of a kind are only removed when the data source returned at least one record of that kind, so a failed
+// request, or results withdrawn while they are being corrected, doesn't wipe out what was saved. Matches entered
+// manually or backfilled from another data source, and rankings requested from another data source, are never removed.
+//
+// A hash of the records returned by each of the data source's endpoints is saved, and the records are only saved
+// and swept when their hash differs from the last sync's, so frequent syncs of an event whose results haven't changed
+// don't write to the database. The event's teams are only updated from its matches when the matches changed, and its
+// attendance is only checked when the matches, rankings, or registrations changed. The endpoints that were saved and
+// skipped are listed in the reconciliation.
+//
+// The time the sync started is saved as the event's last sync once the event's matches have been requested from the
+// data source, so a sync that couldn't reach the data source doesn't make stale results look current. Otherwise the
+// event is queued for later syncs to retry. The event is recorded in the sync run in progress, if any.
+func RequestAndSaveEventResults(event *database.Event) *database.EventReconciliation {
+	started := time.Now().UTC()
+	reconciliation := &database.EventReconciliation{EventID: event.EventID}
+	hashes := loadEndpointHashes(event, reconciliation)
+
+	// The event's awards, rankings, advancements, matches, and registrations don't depend on each other, so they are
+	// requested from the data source concurrently. They're saved once every request has finished, so the records
+	// are written in the same order as they would be if requested one at a time.
+	var (
+		awards        []*database.EventAward
+		rankings      []*database.EventRanking
+		advancements  []*database.EventAdvancement
+		registrations []*database.EventTeam
+		matches       []matchResults
+	)
+	requests := []func(){
+		func() { awards = RequestEventAwards(event) },
+		func() { rankings = RequestEventRanking(event) },
+		func() { advancements = RequestEventAdvancements(event) },
+		func() { registrations = RequestEventRegistrations(event) },
+		func() { matches = requestAllMatches(event) },
+	}
+	var wg sync.WaitGroup
+	for _, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			request()
+		}()
+	}
+	wg.Wait()
+
+	if hash, changed := hashes.changed(awardsEndpoint, len(awards), awards); changed {
+		for _, eventAward := range awards {
+			db.SaveEventAward(eventAward)
+		}
+		if stored, err := db.GetEventAwards(event.EventID); err != nil {
+			slog.Warn("failed to load event awards", "event", event.EventCode, "error", err)
+		} else {
+			reconciliation.Awards = sweep(event, "award", stored, keys(awards, eventAwardKey), eventAwardKey, db.DeleteEventAward)
+		}
+		hashes.save(awardsEndpoint, hash)
+	}
+
+	if hash, changed := hashes.changed(rankingsEndpoint, len(rankings), rankings); changed {
+		for _, eventRanking := range rankings {
+			db.SaveEventRanking(eventRanking)
+		}
+		if stored, err := db.GetEventRankings(event.EventID); err != nil {
+			slog.Warn("failed to load event rankings", "event", event.EventCode, "error", err)
+		} else {
+			stored = filter(stored, func(er *database.EventRanking) bool { return fromSource(er.Source) })
+			reconciliation.Rankings = sweep(event, "ranking", stored, keys(rankings, eventRankingKey), eventRankingKey, func(er *database.EventRanking) error {
+				return db.DeleteEventRanking(er.EventID, er.TeamID)
+			})
+		}
+		hashes.save(rankingsEndpoint, hash)
+	}
+
+	if hash, changed := hashes.changed(advancementsEndpoint, len(advancements), advancements); changed {
+		for _, eventAdvancement := range advancements {
+			db.SaveEventAdvancement(eventAdvancement)
+		}
+		if stored, err := db.GetEventAdvancements(event.EventID); err != nil {
+			slog.Warn("failed to load event advancements", "event", event.EventCode, "error", err)
+		} else {
+			reconciliation.Advancements = sweep(event, "advancement", stored, keys(advancements, eventAdvancementKey), eventAdvancementKey, func(ea *database.EventAdvancement) error {
+				return db.DeleteEventAdvancement(ea.EventID, ea.TeamID)
+			})
+		}
+		hashes.save(advancementsEndpoint, hash)
+	}
+
+	var errs []error
+	var matchEndpoints []string
+	for _, results := range matches {
+		endpoint := matchesEndpoint(results.matchType)
+		matchEndpoints = append(matchEndpoints, endpoint)
+		if results.err != nil {
+			// Matches that were only partly requested are saved, but not hashed, so they're saved again next time
+			errs = append(errs, fmt.Errorf("%s matches: %w", results.matchType, results.err))
+		}
+		hash, changed := hashes.changed(endpoint, len(results.matches), []any{results.matches, results.scores, results.teams})
+		if !changed && results.err == nil {
+			continue
+		}
+		saveMatchResults(results)
+		reconciliation.Matches = append(reconciliation.Matches, sweepMatches(event, results.matchType, results.matches)...)
+		reconciliation.MatchTeams = append(reconciliation.MatchTeams, sweepMatchTeams(event, results.matches, results.teams)...)
+		if results.err != nil {
+			hash = ""
+		}
+		hashes.save(endpoint, hash)
+	}
+	matchErr := errors.Join(errs...)
+	if matchErr != nil {
+		slog.Error("failed to request the event's matches", "event", event.EventCode, "error", matchErr)
+	}
+	synced := matchErr == nil
+
+	// Attendance is checked once the teams removed from the event are swept, so they aren't reported as no-shows
+	if hash, changed := hashes.changed(registrationsEndpoint, len(registrations), registrations); changed {
+		if err := saveEventTeams(event, registrations, registeredFlag); err != nil {
+			hash = ""
+		} else {
+			reconciliation.EventTeams = append(reconciliation.EventTeams, sweepEventTeams(event, registrations, registeredFlag)...)
+		}
+		hashes.save(registrationsEndpoint, hash)
+	}
+	if hashes.updated(matchEndpoints...) {
+		eventTeams := RequestTeamsInEvent(event)
+		if err := saveEventTeams(event, eventTeams, playedFlag); err != nil {
+			eventTeams = nil
+		}
+		reconciliation.EventTeams = append(reconciliation.EventTeams, sweepEventTeams(event, eventTeams, playedFlag)...)
+		if stored, err := db.GetTeamRankings(database.TeamRankingFilter{EventIDs: []string{event.EventID}}); err != nil {
+			slog.Warn("failed to load team rankings", "event", event.EventCode, "error", err)
+		} else {
+			reconciliation.TeamRankings = sweep(event, "team ranking", stored, keys(eventTeams, eventTeamKey), teamRankingKey, func(tr *database.TeamRanking) error {
+				return db.DeleteTeamRanking(tr.EventID, tr.TeamID)
+			})
+		}
+	}
+	if hashes.updated(append(matchEndpoints, rankingsEndpoint, registrationsEndpoint)...) {
+		CheckEventAttendance(event)
+	}
+
+	if reconciliation.Count() > 0 {
+		slog.Info("Removed records no longer returned by the data source", "event", event.EventCode, "reconciliation", reconciliation)
+	}
+	if synced {
+		if err := db.SaveEventSync(&database.EventSync{EventID: event.EventID, SyncedAt: started}); err != nil {
+			slog.Warn("failed to save event sync", "event", event.EventCode, "error", err)
+		}
+	}
+	recordEventSync(event, reconciliation, matchErr)
+	recordSyncRetry(event, matchErr)
+	return reconciliation
+}
+
+// sweepMatches removes the saved matches of a type that were requested from the data source but are no longer
+// returned by it. Deleting a match also deletes its scores and teams.
+func sweepMatches(event *database.Event, matchType ftc.MatchType, matches []*database.Match) []*database.Match {
+	stored, err := db.GetMatchesByEvent(event.EventID)
+	if err != nil {
+		slog.Warn("failed to load matches", "event", event.EventCode, "error", err)
+		return nil
+	}
+	stored = filter(stored, func(m *database.Match) bool {
+		return strings.EqualFold(m.TournamentLevel, string(matchType)) && fromSource(m.Source)
+	})
+	return sweep(event, "match", stored, keys(matches, matchKey), matchKey, func(m *database.Match) error {
+		return db.DeleteMatch(m.MatchID)
+	})
+}
+
+// sweepMatchTeams removes the teams that are no longer in the matches returned by the data source, such as a team
+// that was replaced in a match after it was first synced.
+func sweepMatchTeams(event *database.Event, matches []*database.Match, matchTeams []*database.MatchTeam) []*database.MatchTeam {
+	teamsByMatch := make(map[string][]*database.MatchTeam, len(matches))
+	for _, mt := range matchTeams {
+		teamsByMatch[mt.MatchID] = append(teamsByMatch[mt.MatchID], mt)
+	}
+	var removed []*database.MatchTeam
+	for _, match := range matches {
+		stored, err := db.GetMatchTeams(match.MatchID)
+		if err != nil {
+			slog.Warn("failed to load match teams", "event", event.EventCode, "matchID", match.MatchID, "error", err)
+			continue
+		}
+		removed = append(removed, sweep(event, "match team", stored, keys(teamsByMatch[match.MatchID], matchTeamKey), matchTeamKey, func(mt *database.MatchTeam) error {
+			return db.DeleteMatchTeam(mt.MatchID, mt.TeamID)
+		})...)
+	}
+	return removed
+}
+
+// sweep removes the stored records whose key isn't among the requested keys, and returns the removed records. If
+// no keys were requested, nothing is removed, as the request either failed or the data source has withdrawn the
+// records for now.
+func sweep[T any](event *database.Event, kind string, stored []*T, requested map[string]bool, key func(*T) string, remove func(*T) error) []*T {
+	if len(requested) == 0 {
+		if len(stored) > 0 {
+			slog.Warn("Data source returned no records, keeping the saved records", "event", event.EventCode, "kind", kind, "count", len(stored))
+		}
+		return nil
+	}
+	var removed []*T
+	for _, record := range stored {
+		if requested[key(record)] {
+			continue
+		}
+		if err := remove(record); err != nil {
+			slog.Error("failed to remove record no longer returned by the data source", "event", event.EventCode, "kind", kind, "record", record, "error", err)
+			continue
+		}
+		slog.Info("Removed record no longer returned by the data source", "event", event.EventCode, "kind", kind, "record", record)
+		removed = append(removed, record)
+	}
+	return removed
+}
+
+// keys returns the set of keys of the records.
+func keys[T any](records []*T, key func(*T) string) map[string]bool {
+	set := make(map[string]bool, len(records))
+	for _, record := range records {
+		set[key(record)] = true
+	}
+	return set
+}
+
+// filter returns the records for which keep returns true.
+func filter[T any](records []*T, keep func(*T) bool) []*T {
+	var kept []*T
+	for _, record := range records {
+		if keep(record) {
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
+
+// fromSource returns true if a record with the given source was requested from the current data source. Records
+// saved before the source was recorded are treated as coming from the current data source.
+func fromSource(recordSource string) bool {
+	return recordSource == "" || recordSource == source.Name()
+}
+
+func eventAwardKey(ea *database.EventAward) string {
+	return strconv.Itoa(ea.AwardID) + "/" + strconv.Itoa(ea.Series) + "/" + strconv.Itoa(ea.TeamID)
+}
+
+func eventRankingKey(er *database.EventRanking) string {
+	return strconv.Itoa(er.TeamID)
+}
+
+func eventAdvancementKey(ea *database.EventAdvancement) string {
+	return strconv.Itoa(ea.TeamID)
+}
+
+func eventTeamKey(et *database.EventTeam) string {
+	return strconv.Itoa(et.TeamID)
+}
+
+func teamRankingKey(tr *database.TeamRanking) string {
+	return strconv.Itoa(tr.TeamID)
+}
+
+func matchKey(m *database.Match) string {
+	return m.MatchID
+}
+
+func matchTeamKey(mt *database.MatchTeam) string {
+	return strconv.Itoa(mt.TeamID)
+}
@@ -0,0 +1,136 @@
+package request
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// eventTeamFlag is one of the flags of an event team: whether the team is registered for the event, or whether it
+// has played in the event's matches. Registrations and matches are requested separately, so each sets and clears
+// only its own flag.
+type eventTeamFlag struct {
+	kind string                          // Kind of record logged when the flag is cleared
+	flag func(*database.EventTeam) *bool // Returns the flag of the event team
+}
+
+var (
+	registeredFlag = eventTeamFlag{"event registration", func(et *database.EventTeam) *bool { return &et.Registered }}
+	playedFlag     = eventTeamFlag{"event team", func(et *database.EventTeam) *bool { return &et.Played }}
+)
+
+// RequestAndSaveEventRegistrations requests the teams registered for an event from the data source and saves them
+// as the event's registered teams, so the teams at an event are known before it starts. Teams that are no longer
+// registered have their registration cleared, and are removed from the event unless they have played in it.
+func RequestAndSaveEventRegistrations(event *database.Event) []*database.EventTeam {
+	registrations := RequestEventRegistrations(event)
+	if err := saveEventTeams(event, registrations, registeredFlag); err != nil {
+		return nil
+	}
+	sweepEventTeams(event, registrations, registeredFlag)
+	return registrations
+}
+
+// RequestEventRegistrations requests the teams registered for an event from the data source.
+func RequestEventRegistrations(event *database.Event) []*database.EventTeam {
+	ftcTeams, err := source.GetEventTeams(strconv.Itoa(event.Year), event.EventCode)
+	if err != nil {
+		if errors.Is(err, errors.ErrUnsupported) {
+			slog.Debug("Event registrations not provided by the data source", "eventCode", event.EventCode, "source", source.Name())
+			return nil
+		}
+		slog.Error("Error requesting event registrations:", "year", event.Year, "eventCode", event.EventCode, "source", source.Name(), "error", err)
+		return nil
+	}
+	registrations := make([]*database.EventTeam, 0, len(ftcTeams))
+	for _, ftcTeam := range ftcTeams {
+		registrations = append(registrations, &database.EventTeam{
+			EventID:    event.EventID,
+			TeamID:     ftcTeam.TeamNumber,
+			Registered: true,
+		})
+	}
+	slog.Info("Retrieved event registrations", "eventCode", event.EventCode, "count", len(registrations))
+	return registrations
+}
+
+// SyncRegistrations requests and saves the teams registered for the season's events that haven't ended, limited to
+// the events in a region if a region code is given. It returns the events whose registrations were requested.
+func SyncRegistrations(season string, regionCode string) []*database.Event {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		slog.Error("invalid season", "season", season, "error", err)
+		return nil
+	}
+	filter := database.EventFilter{Year: year}
+	if regionCode != "" {
+		filter.RegionCodes = []string{regionCode}
+	}
+	events, err := db.GetAllEvents(filter)
+	if err != nil {
+		slog.Error("failed to load events", "season", season, "region", regionCode, "error", err)
+		return nil
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	var upcoming []*database.Event
+	for _, event := range events {
+		if event.DateEnd.Before(today) {
+			continue
+		}
+		registrations := RequestAndSaveEventRegistrations(event)
+		slog.Info("Synced event registrations", "eventCode", event.EventCode, "teams", len(registrations))
+		upcoming = append(upcoming, event)
+	}
+	return upcoming
+}
+
+// saveEventTeams saves the event teams with the flag set, keeping the other flag of the teams already saved for the
+// event.
+func saveEventTeams(event *database.Event, eventTeams []*database.EventTeam, f eventTeamFlag) error {
+	stored, err := db.GetEventTeams(event.EventID)
+	if err != nil {
+		slog.Error("failed to load event teams", "eventID", event.EventID, "error", err)
+		return err
+	}
+	saved := make(map[int]*database.EventTeam, len(stored))
+	for _, et := range stored {
+		saved[et.TeamID] = et
+	}
+
+	for _, eventTeam := range eventTeams {
+		if existing, ok := saved[eventTeam.TeamID]; ok {
+			eventTeam.Registered = existing.Registered
+			eventTeam.Played = existing.Played
+		}
+		*f.flag(eventTeam) = true
+		if err := db.SaveEventTeam(eventTeam); err != nil {
+			slog.Error("failed to save event team", "eventID", event.EventID, "teamID", eventTeam.TeamID, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// sweepEventTeams clears the flag of the saved event teams that have it but aren't among the requested event teams,
+// removing the teams that are left neither registered nor played, and returns the event teams that were changed. If
+// no event teams were requested, nothing is changed, as the request either failed or the data source has withdrawn
+// them for now.
+func sweepEventTeams(event *database.Event, eventTeams []*database.EventTeam, f eventTeamFlag) []*database.EventTeam {
+	stored, err := db.GetEventTeams(event.EventID)
+	if err != nil {
+		slog.Warn("failed to load event teams", "event", event.EventCode, "error", err)
+		return nil
+	}
+	stored = filter(stored, func(et *database.EventTeam) bool { return *f.flag(et) })
+	return sweep(event, f.kind, stored, keys(eventTeams, eventTeamKey), eventTeamKey, func(et *database.EventTeam) error {
+		*f.flag(et) = false
+		if !et.Registered && !et.Played {
+			return db.DeleteEventTeam(et.EventID, et.TeamID)
+		}
+		return db.SaveEventTeam(et)
+	})
+}
@@ -1,14 +1,17 @@
 package request
 
 import (
+	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/geocode"
 )
 
 var (
-	db database.DB
+	db       database.DB
+	geocoder geocode.Geocoder
 )
 
 // Init initializes the request package with a database connection.
@@ -16,21 +19,48 @@ func Init(database database.DB) {
 	db = database
 }
 
+// SetGeocoder sets the geocoder used to look up the location of event venues.
+// If no geocoder is set, event locations are not looked up.
+func SetGeocoder(g geocode.Geocoder) {
+	geocoder = g
+}
+
 // RequestAndSaveAll requests and saves all data for a given season.
-func RequestAndSaveAll(season string, refresh bool) {
+//
+// Each event is checkpointed in the database once it has been processed. If resume is true, the events that were
+// completed by an earlier sync that was interrupted are skipped, as is refreshing the awards, teams, and events that
+// the earlier sync already retrieved. Otherwise, any checkpoints are cleared and the sync starts from the beginning.
+// The checkpoints are cleared once every event has been processed. The events whose details were synced, rather
+// than skipped, are returned.
+func RequestAndSaveAll(season string, refresh bool, resume bool) []*database.Event {
+	completed := make(map[string]bool)
+	if resume {
+		checkpoints, err := db.GetSyncCheckpoints(season)
+		if err != nil {
+			slog.Warn("failed to load sync checkpoints from db", "error", err)
+		}
+		for _, checkpoint := range checkpoints {
+			completed[checkpoint.EventID] = true
+		}
+		slog.Info("Resuming sync", "season", season, "completedEvents", len(completed))
+	} else if err := db.DeleteSyncCheckpoints(season); err != nil {
+		slog.Warn("failed to clear sync checkpoints", "error", err)
+	}
+	// The earlier sync already refreshed the awards, teams, and events before processing any events
+	refreshAll := refresh && len(completed) == 0
 
 	awards, err := db.GetAllAwards()
 	if err != nil {
 		slog.Warn("failed to load awards from db", "error", err)
 	}
-	if refresh || len(awards) == 0 {
+	if refreshAll || len(awards) == 0 {
 		awards = RequestAndSaveAwards(season)
 	}
 	teams, err := db.GetAllTeams()
 	if err != nil {
 		slog.Warn("failed to load teams from db", "error", err)
 	}
-	if refresh || len(teams) == 0 {
+	if refreshAll || len(teams) == 0 {
 		teams = RequestAndSaveTeams(season)
 	}
 
@@ -38,45 +68,91 @@ func RequestAndSaveAll(season string, refresh bool) {
 	if err != nil {
 		slog.Warn("failed to load events from db", "error", err)
 	}
-	if refresh || len(events) == 0 {
+	if refreshAll || len(events) == 0 {
 		events = RequestAndSaveEvents(season)
 	}
 
+	addSyncRunEvents(len(events))
+	removed := 0
+	var stats EndpointStats
+	var synced []*database.Event
 	for i, event := range events {
-		slog.Info("Processing event", "eventNumber", i+1, "totalEvents", len(events), "event", event.EventCode)
-		if event.DateEnd.After(time.Now()) {
-			slog.Info("Skipping event details for future event", "event", event.EventCode, "dateEnd", event.DateEnd)
-			continue
-		}
-		advancementFilter := database.AdvancementFilter{
-			EventCodes: []string{event.EventCode},
-		}
-		advancements, err := db.GetAllAdvancements(advancementFilter)
-		if err != nil {
-			slog.Warn("failed to load advancements", "event", event.EventCode, "error", err)
-		}
-		if !refresh && len(advancements) > 0 && event.DateEnd.Before(time.Now().Add(-24*time.Hour)) {
-			slog.Info("Skipping event details for already processed event", "event", event.EventCode, "advancements", len(advancements), "dateEnd", event.DateEnd)
+		if completed[event.EventID] {
+			slog.Info("Skipping event completed by an earlier sync", "eventNumber", i+1, "totalEvents", len(events), "event", event.EventCode)
 			continue
 		}
-		filter := database.MatchFilter{
-			EventIDs: []string{event.EventID},
+		if reconciliation := requestAndSaveEventDetails(event, i, len(events), refresh); reconciliation != nil {
+			removed += reconciliation.Count()
+			stats.Add(reconciliation)
+			synced = append(synced, event)
 		}
-		matches, err := db.GetAllMatches(filter)
-		if err != nil {
-			slog.Warn("failed to load matches", "event", event.EventCode, "error", err)
+
+		checkpoint := &database.SyncCheckpoint{
+			Season:      season,
+			EventID:     event.EventID,
+			CompletedAt: time.Now().UTC(),
 		}
-		if !refresh && len(matches) > 0 && event.DateEnd.Before(time.Now().Add(-24*6*time.Hour)) {
-			slog.Info("Skipping event details for already processed event with advancements", "event", event.EventCode, "matches", len(matches), "dateEnd", event.DateEnd)
-			continue
+		if err := db.SaveSyncCheckpoint(checkpoint); err != nil {
+			slog.Warn("failed to save sync checkpoint", "event", event.EventCode, "error", err)
 		}
-		slog.Info("Processing event details for event", "event", event.EventCode, "matches", len(matches), "advancements", len(advancements), "dateEnd", event.DateEnd)
-		RequestAndSaveEventAwards(event)
-		RequestAndSaveEventRankings(event)
-		RequestAndSaveEventAdvancements(event)
-		RequestAndSaveMatches(event)
-		RequestAndSaveTeamsInEvent(event)
-		RequestAndSaveTeamRankings(event)
-		slog.Info("Finished processing event details for event", "event", event.EventCode)
 	}
+
+	if removed > 0 {
+		slog.Info("Removed records no longer returned by the data source", "season", season, "count", removed)
+	}
+	slog.Info("Finished syncing the season's events", "season", season, "events", len(synced), "updatedEndpoints", stats.Updated, "skippedEndpoints", stats.Skipped)
+
+	// All events were processed, so the next sync starts from the beginning
+	if err := db.DeleteSyncCheckpoints(season); err != nil {
+		slog.Warn("failed to clear sync checkpoints", "error", err)
+	}
+	return synced
+}
+
+// requestAndSaveEventDetails requests and saves the awards, rankings, advancements, matches, teams, and team
+// rankings for an event, skipping unofficial events and events that have not finished or that were already processed.
+// The team rankings aren't calculated again if none of the event's results changed since the last sync. The event's
+// reconciliation is returned, or nil if the event was skipped.
+func requestAndSaveEventDetails(event *database.Event, i int, totalEvents int, refresh bool) *database.EventReconciliation {
+	slog.Info("Processing event", "eventNumber", i+1, "totalEvents", totalEvents, "event", event.EventCode)
+	if event.Unofficial {
+		slog.Info("Skipping event details for unofficial event", "event", event.EventCode)
+		return nil
+	}
+	if event.DateEnd.After(time.Now()) {
+		slog.Info("Skipping event details for future event", "event", event.EventCode, "dateEnd", event.DateEnd)
+		return nil
+	}
+	advancementFilter := database.AdvancementFilter{
+		EventCodes: []string{event.EventCode},
+	}
+	advancements, err := db.GetAllAdvancements(advancementFilter)
+	if err != nil {
+		slog.Warn("failed to load advancements", "event", event.EventCode, "error", err)
+	}
+	if !refresh && len(advancements) > 0 && event.DateEnd.Before(time.Now().Add(-24*time.Hour)) {
+		slog.Info("Skipping event details for already processed event", "event", event.EventCode, "advancements", len(advancements), "dateEnd", event.DateEnd)
+		return nil
+	}
+	filter := database.MatchFilter{
+		EventIDs: []string{event.EventID},
+	}
+	matches, err := db.GetAllMatches(filter)
+	if err != nil {
+		slog.Warn("failed to load matches", "event", event.EventCode, "error", err)
+	}
+	if !refresh && len(matches) > 0 && event.DateEnd.Before(time.Now().Add(-24*6*time.Hour)) {
+		slog.Info("Skipping event details for already processed event with advancements", "event", event.EventCode, "matches", len(matches), "dateEnd", event.DateEnd)
+		return nil
+	}
+	slog.Info("Processing event details for event", "event", event.EventCode, "matches", len(matches), "advancements", len(advancements), "dateEnd", event.DateEnd)
+	reconciliation := RequestAndSaveEventResults(event)
+	if !reconciliation.Changed() {
+		slog.Info("Skipping team rankings for event whose results haven't changed", "event", event.EventCode)
+	} else if err := RequestAndSaveTeamRankings(event); err != nil {
+		slog.Warn("failed to calculate team rankings", "event", event.EventCode, "error", err)
+		recordSyncError(fmt.Errorf("%s team rankings: %w", event.EventCode, err))
+	}
+	slog.Info("Finished processing event details for event", "event", event.EventCode, "updatedEndpoints", len(reconciliation.UpdatedEndpoints), "skippedEndpoints", len(reconciliation.SkippedEndpoints))
+	return reconciliation
 }
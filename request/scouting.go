@@ -0,0 +1,250 @@
+package request
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// ScoutingColumns maps the columns of a scouting spreadsheet to the notes and tags saved on each team. Columns are
+// named by their header, matched without regard to case or spacing. Any other columns are ignored.
+type ScoutingColumns struct {
+	Team  string            `json:"team"`            // Column holding the team number; defaults to "team"
+	Notes []string          `json:"notes"`           // Columns whose text is joined into the notes; defaults to "notes"
+	Tags  []string          `json:"tags"`            // Columns holding tags separated by commas or semicolons; defaults to "tags"
+	Flags map[string]string `json:"flags,omitempty"` // Columns, such as checkboxes, that add the tag when marked yes
+}
+
+// LoadScoutingColumns reads the mapping of a scouting spreadsheet's columns from a JSON file, such as:
+//
+//	{
+//	  "team": "Team #",
+//	  "notes": ["Drivetrain", "Pit Notes"],
+//	  "tags": ["Tags"],
+//	  "flags": {"Can Hang?": "hang"}
+//	}
+//
+// Columns that aren't given take their defaults.
+func LoadScoutingColumns(path string) (*ScoutingColumns, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var columns ScoutingColumns
+	if err := json.Unmarshal(data, &columns); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &columns, nil
+}
+
+// withDefaults returns the mapping with the default columns in place of any that aren't given.
+func (sc *ScoutingColumns) withDefaults() ScoutingColumns {
+	var columns ScoutingColumns
+	if sc != nil {
+		columns = *sc
+	}
+	if strings.TrimSpace(columns.Team) == "" {
+		columns.Team = "team"
+	}
+	if len(columns.Notes) == 0 {
+		columns.Notes = []string{"notes"}
+	}
+	if len(columns.Tags) == 0 {
+		columns.Tags = []string{"tags"}
+	}
+	return columns
+}
+
+// columnKey returns the key a column's header is matched by, which ignores case and spacing.
+func columnKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ReadTeamNotes reads the scouting notes on teams from the rows of a spreadsheet, the first of which is the header
+// naming the columns. The team column is required. A mapped notes or tags column that isn't in the header is an
+// error unless it is one of the defaults, so a spreadsheet with only some of them can be read without a mapping.
+//
+// The notes are the text of the notes columns, each labelled with its header if there are several. A flag column
+// adds its tag when its cell is yes, true, x, or 1. Rows without a team number are skipped, and the rows of a team
+// that appears more than once are merged. Every row is validated, and the errors of all invalid rows are returned
+// together, identified by row number.
+func ReadTeamNotes(rows [][]string, mapping *ScoutingColumns) ([]*database.TeamNote, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("missing header row")
+	}
+	columns := mapping.withDefaults()
+	header := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		if key := columnKey(name); key != "" {
+			if _, ok := header[key]; !ok {
+				header[key] = i
+			}
+		}
+	}
+
+	teamColumn, ok := header[columnKey(columns.Team)]
+	if !ok {
+		return nil, fmt.Errorf("missing team column %q", columns.Team)
+	}
+	find := func(names []string, optional bool) ([]int, []string, error) {
+		var indexes []int
+		var headers []string
+		for _, name := range names {
+			i, ok := header[columnKey(name)]
+			if !ok {
+				if optional {
+					continue
+				}
+				return nil, nil, fmt.Errorf("missing column %q", name)
+			}
+			indexes = append(indexes, i)
+			headers = append(headers, strings.TrimSpace(rows[0][i]))
+		}
+		return indexes, headers, nil
+	}
+	noteColumns, noteHeaders, err := find(columns.Notes, mapping == nil || len(mapping.Notes) == 0)
+	if err != nil {
+		return nil, err
+	}
+	tagColumns, _, err := find(columns.Tags, mapping == nil || len(mapping.Tags) == 0)
+	if err != nil {
+		return nil, err
+	}
+	// Flag columns are kept in the order they are in the spreadsheet, so the tags they add are always in that order
+	var flagColumns []int
+	flagTags := make(map[int]string, len(columns.Flags))
+	for name, tag := range columns.Flags {
+		i, ok := header[columnKey(name)]
+		if !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+		flagColumns = append(flagColumns, i)
+		flagTags[i] = tag
+	}
+	slices.Sort(flagColumns)
+
+	cell := func(row []string, i int) string {
+		if i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var notes []*database.TeamNote
+	byTeam := make(map[int]*database.TeamNote)
+	var errs []error
+	for n, row := range rows[1:] {
+		value := cell(row, teamColumn)
+		if value == "" {
+			continue
+		}
+		teamID, err := strconv.Atoi(value)
+		if err != nil || teamID <= 0 {
+			errs = append(errs, fmt.Errorf("row %d: invalid team number %q", n+2, value))
+			continue
+		}
+
+		var text []string
+		for j, i := range noteColumns {
+			if value := cell(row, i); value != "" {
+				if len(noteColumns) > 1 {
+					value = noteHeaders[j] + ": " + value
+				}
+				text = append(text, value)
+			}
+		}
+		var tags []string
+		for _, i := range tagColumns {
+			tags = append(tags, strings.FieldsFunc(cell(row, i), func(r rune) bool { return r == ',' || r == ';' })...)
+		}
+		for _, i := range flagColumns {
+			if marked(cell(row, i)) {
+				tags = append(tags, flagTags[i])
+			}
+		}
+
+		note, ok := byTeam[teamID]
+		if !ok {
+			note = &database.TeamNote{TeamID: teamID}
+			byTeam[teamID] = note
+			notes = append(notes, note)
+		}
+		if len(text) > 0 {
+			if note.Notes != "" {
+				note.Notes += "; "
+			}
+			note.Notes += strings.Join(text, "; ")
+		}
+		note.Tags = database.NormalizeTags(append(note.Tags, tags...))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	slices.SortFunc(notes, func(a, b *database.TeamNote) int { return a.TeamID - b.TeamID })
+	return notes, nil
+}
+
+// marked returns true if a flag column's cell is marked yes.
+func marked(value string) bool {
+	switch strings.ToLower(value) {
+	case "yes", "y", "true", "x", "1":
+		return true
+	}
+	return false
+}
+
+// ReadTeamNotesCSV reads the scouting notes on teams from a CSV file, in the same way as ReadTeamNotes.
+func ReadTeamNotesCSV(r io.Reader, mapping *ScoutingColumns) ([]*database.TeamNote, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return ReadTeamNotes(rows, mapping)
+}
+
+// CheckTeamNotes returns an error naming the teams with scouting notes that aren't in the database, which are often
+// mistyped team numbers.
+func CheckTeamNotes(notes []*database.TeamNote) error {
+	var missing []string
+	for _, note := range notes {
+		team, err := db.GetTeam(note.TeamID)
+		if err != nil {
+			return fmt.Errorf("failed to load team %d: %w", note.TeamID, err)
+		}
+		if team == nil {
+			missing = append(missing, strconv.Itoa(note.TeamID))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("teams not in the database: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// SaveTeamNotes saves the scouting notes on teams for the year, recording the source they were imported from. Every
+// team must already be in the database, and nothing is saved if any isn't. A team's notes replace any it had.
+func SaveTeamNotes(notes []*database.TeamNote, year int, source string) error {
+	if err := CheckTeamNotes(notes); err != nil {
+		return err
+	}
+	for _, note := range notes {
+		note.Year = year
+		note.Source = source
+		if err := db.SaveTeamNote(note); err != nil {
+			return fmt.Errorf("failed to save notes on team %d: %w", note.TeamID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package request
+
+import (
+	"github.com/rbrabson/ftc"
+)
+
+// DataSource provides the FTC data that is requested and saved in the database. Data is returned using the types
+// of the FTC Events API, so any source that can be mapped to those types, such as another results site or a CSV
+// import, feeds the same pipeline that saves the data in the database. A data source must be safe for concurrent use,
+// as the data for an event is requested concurrently.
+type DataSource interface {
+	// Name returns the name of the data source. It is logged, and recorded as the source of the matches and
+	// event rankings requested from the data source.
+	Name() string
+	// GetAwardListing returns the awards that may be given in the season.
+	GetAwardListing(season string) ([]*ftc.Award, error)
+	// GetTeams returns the teams registered for the season.
+	GetTeams(season string) ([]*ftc.Team, error)
+	// GetEvents returns the events held in the season.
+	GetEvents(season string) ([]*ftc.Event, error)
+	// GetEventTeams returns the teams registered for an event, which are known before the event starts.
+	GetEventTeams(season, eventCode string) ([]*ftc.Team, error)
+	// GetEventAwards returns the awards given at an event.
+	GetEventAwards(season, eventCode string) ([]*ftc.TeamAward, error)
+	// GetRankings returns the qualification rankings of an event.
+	GetRankings(season, eventCode string) ([]*ftc.Ranking, error)
+	// GetAdvancementsTo returns the teams advancing from an event.
+	GetAdvancementsTo(season, eventCode string) (*ftc.AdvancementsTo, error)
+	// GetMatchResults returns the results of an event's matches at the tournament level.
+	GetMatchResults(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.Match, error)
+	// GetEventScores returns the detailed scores of an event's matches at the tournament level.
+	GetEventScores(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.MatchScores, error)
+}
+
+// source is the data source that data is requested from.
+var source DataSource = FTCEventsAPI{}
+
+// SetDataSource sets the data source that data is requested from. By default, data is requested from the FTC
+// Events API.
+func SetDataSource(ds DataSource) {
+	source = ds
+}
+
+// FTCEventsAPI is a DataSource that requests data from the FTC Events API.
+type FTCEventsAPI struct{}
+
+// SetFTCServer sets the URL of the FTC Events API server that requests are sent to, along with the credentials used
+// to authenticate with it. By default, the FTC_SERVER, FTC_USERNAME, and FTC_AUTHORIZATION_KEY environment variables
+// are used. This allows requests to be sent to another server, such as the mock server in internal/ftcmock.
+func SetFTCServer(url, username, authKey string) {
+	ftc.SetServerURL(url)
+	ftc.SetAuthCredentials(username, authKey)
+	setFTCServer(url, username, authKey)
+}
+
+// Name returns the name of the FTC Events API.
+func (FTCEventsAPI) Name() string {
+	return "ftcevents"
+}
+
+// GetAwardListing returns the awards that may be given in the season.
+func (FTCEventsAPI) GetAwardListing(season string) ([]*ftc.Award, error) {
+	return ftc.GetAwardListing(season)
+}
+
+// GetTeams returns the teams registered for the season.
+func (FTCEventsAPI) GetTeams(season string) ([]*ftc.Team, error) {
+	return ftc.GetTeams(season)
+}
+
+// GetEvents returns the events held in the season.
+func (FTCEventsAPI) GetEvents(season string) ([]*ftc.Event, error) {
+	return ftc.GetEvents(season)
+}
+
+// GetEventAwards returns the awards given at an event.
+func (FTCEventsAPI) GetEventAwards(season, eventCode string) ([]*ftc.TeamAward, error) {
+	return ftc.GetEventAwards(season, eventCode)
+}
+
+// GetRankings returns the qualification rankings of an event.
+func (FTCEventsAPI) GetRankings(season, eventCode string) ([]*ftc.Ranking, error) {
+	return ftc.GetRankings(season, eventCode)
+}
+
+// GetAdvancementsTo returns the teams advancing from an event.
+func (FTCEventsAPI) GetAdvancementsTo(season, eventCode string) (*ftc.AdvancementsTo, error) {
+	return ftc.GetAdvancementsTo(season, eventCode)
+}
+
+// GetMatchResults returns the results of an event's matches at the tournament level.
+func (FTCEventsAPI) GetMatchResults(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.Match, error) {
+	return ftc.GetMatchResults(season, eventCode, tournamentLevel)
+}
+
+// GetEventScores returns the detailed scores of an event's matches at the tournament level.
+func (FTCEventsAPI) GetEventScores(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.MatchScores, error) {
+	return ftc.GetEventScores(season, eventCode, tournamentLevel)
+}
@@ -0,0 +1,203 @@
+package request
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// SeasonIsEmpty returns true if the database has no events for the season, which is the case before the season has
+// been synced for the first time.
+func SeasonIsEmpty(season string) (bool, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return false, fmt.Errorf("invalid season %q", season)
+	}
+	events, err := db.GetAllEvents(database.EventFilter{Year: year})
+	if err != nil {
+		return false, fmt.Errorf("failed to load events: %w", err)
+	}
+	return len(events) == 0, nil
+}
+
+// BootstrapSeason requests and saves the season's teams, awards, and events if the database doesn't have any events
+// for the season yet. The results of the events aren't requested.
+func BootstrapSeason(season string) error {
+	empty, err := SeasonIsEmpty(season)
+	if err != nil || !empty {
+		return err
+	}
+
+	slog.Info("Requesting the season's teams, awards, and events", "season", season)
+	RequestAndSaveTeams(season)
+	RequestAndSaveAwards(season)
+	if len(RequestAndSaveEvents(season)) == 0 {
+		return fmt.Errorf("no events were returned for the %s season; check the FTC Events API credentials", season)
+	}
+	return nil
+}
+
+// SyncEvent requests and saves the results of an event, removing the records the data source no longer returns, and
+// recalculates the event's team rankings if any of its results changed since the last sync. If the season hasn't
+// been synced yet, its teams, awards, and events are requested first. An error is returned if the event isn't found.
+func SyncEvent(season string, eventCode string) (*database.Event, error) {
+	events, err := SyncEvents(season, []string{eventCode}, "")
+	if err != nil {
+		return nil, err
+	}
+	return events[0], nil
+}
+
+// SyncEvents requests and saves the results of each of the events in the same way as SyncEvent, returning the events
+// that were synced. If a region code is given, the events are restricted to those in the region, and the others are
+// skipped with a warning. An error is returned before any event is synced if any of the events isn't found in the
+// season, or if none of them are in the region.
+func SyncEvents(season string, eventCodes []string, regionCode string) ([]*database.Event, error) {
+	slog.Info("Processing events", "eventCodes", eventCodes, "regionCode", regionCode, "season", season)
+	if err := BootstrapSeason(season); err != nil {
+		return nil, err
+	}
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season %q", season)
+	}
+
+	found, err := db.GetAllEvents(database.EventFilter{EventCodes: eventCodes, Year: year})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+	eventsByCode := make(map[string]*database.Event, len(found))
+	for _, event := range found {
+		eventsByCode[event.EventCode] = event
+	}
+	var missing []string
+	for _, eventCode := range eventCodes {
+		if eventsByCode[eventCode] == nil {
+			missing = append(missing, eventCode)
+		}
+	}
+	switch {
+	case len(missing) == 1:
+		return nil, fmt.Errorf("event %s not found", missing[0])
+	case len(missing) > 1:
+		return nil, fmt.Errorf("events %s not found", strings.Join(missing, ", "))
+	}
+
+	events := make([]*database.Event, 0, len(eventCodes))
+	for _, eventCode := range eventCodes {
+		event := eventsByCode[eventCode]
+		if regionCode != "" && event.RegionCode != regionCode {
+			slog.Warn("Skipping event that isn't in the region", "event", eventCode, "eventRegion", event.RegionCode, "regionCode", regionCode)
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		if len(eventCodes) == 1 {
+			return nil, fmt.Errorf("event %s is not in region %s", eventCodes[0], regionCode)
+		}
+		return nil, fmt.Errorf("none of the events are in region %s", regionCode)
+	}
+
+	addSyncRunEvents(len(events))
+	for i, event := range events {
+		slog.Info("Processing event", "eventNumber", i+1, "totalEvents", len(events), "event", event.EventCode)
+		reconciliation := RequestAndSaveEventResults(event)
+		if !reconciliation.Changed() {
+			slog.Info("Skipping team rankings for event whose results haven't changed", "event", event.EventCode)
+		} else if err := RequestAndSaveTeamRankings(event); err != nil {
+			slog.Warn("failed to calculate team rankings", "event", event.EventCode, "error", err)
+			recordSyncError(fmt.Errorf("%s team rankings: %w", event.EventCode, err))
+		}
+		slog.Info("Finished processing event", "eventCode", event.EventCode, "removed", reconciliation.Count(), "updatedEndpoints", len(reconciliation.UpdatedEndpoints), "skippedEndpoints", len(reconciliation.SkippedEndpoints))
+	}
+	return events, nil
+}
+
+// SyncRegion requests and saves the results of the events in a region, returning the events that were synced. The
+// season's teams, awards, and events are requested if refresh is true or the database has none. Unless refresh is
+// true, only the events that started in the past 24 hours are synced. The team rankings of the synced events whose
+// results changed are calculated in parallel by the number of workers, or by one worker for each CPU if workers is 0.
+func SyncRegion(season string, regionCode string, refresh bool, workers int) []*database.Event {
+	slog.Info("Processing region", "regionCode", regionCode, "season", season)
+
+	// Get or refresh teams and awards
+	teams, err := db.GetAllTeams()
+	if err != nil {
+		slog.Warn("failed to load teams", "error", err)
+	}
+	if refresh || len(teams) == 0 {
+		RequestAndSaveTeams(season)
+	}
+
+	awards, err := db.GetAllAwards()
+	if err != nil {
+		slog.Warn("failed to load awards", "error", err)
+	}
+	if refresh || len(awards) == 0 {
+		RequestAndSaveAwards(season)
+	}
+
+	// Get events for the region
+	filter := database.EventFilter{
+		RegionCodes: []string{regionCode},
+	}
+	events, err := db.GetAllEvents(filter)
+	if err != nil {
+		slog.Warn("failed to load region events", "regionCode", regionCode, "error", err)
+	}
+
+	if refresh || len(events) == 0 {
+		// Refresh all events and filter
+		allEvents := RequestAndSaveEvents(season)
+		events = nil
+		for _, e := range allEvents {
+			if e.RegionCode == regionCode {
+				events = append(events, e)
+			}
+		}
+	}
+
+	slog.Info("Found events in region", "regionCode", regionCode, "eventCount", len(events))
+
+	// If not refresh, filter events to only those in the past 24 hours
+	filteredEvents := events
+	if !refresh {
+		now := time.Now()
+		var recentEvents []*database.Event
+		for _, event := range events {
+			if event.DateStart.Before(now) && event.DateStart.After(now.Add(-24*time.Hour)) {
+				recentEvents = append(recentEvents, event)
+			}
+		}
+		filteredEvents = recentEvents
+	}
+
+	addSyncRunEvents(len(filteredEvents))
+	var stats EndpointStats
+	var changedEvents []*database.Event
+	for i, event := range filteredEvents {
+		slog.Info("Processing event", "eventNumber", i+1, "totalEvents", len(filteredEvents), "event", event.EventCode)
+
+		reconciliation := RequestAndSaveEventResults(event)
+		stats.Add(reconciliation)
+		if reconciliation.Changed() {
+			changedEvents = append(changedEvents, event)
+		}
+
+		slog.Info("Finished processing event", "eventCode", event.EventCode, "removed", reconciliation.Count(), "updatedEndpoints", len(reconciliation.UpdatedEndpoints), "skippedEndpoints", len(reconciliation.SkippedEndpoints))
+	}
+
+	// Calculate the team rankings for the region's changed events in parallel
+	if err := RequestAndSaveTeamRankingsForEvents(changedEvents, workers); err != nil {
+		slog.Warn("failed to calculate team rankings for region", "regionCode", regionCode, "error", err)
+		recordSyncError(fmt.Errorf("team rankings: %w", err))
+	}
+
+	slog.Info("Finished processing region", "regionCode", regionCode, "events", len(filteredEvents), "changedEvents", len(changedEvents), "updatedEndpoints", stats.Updated, "skippedEndpoints", stats.Skipped)
+	return filteredEvents
+}
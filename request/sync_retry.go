@@ -0,0 +1,102 @@
+package request
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// recordSyncRetry queues an event whose results failed to sync, so later syncs retry it, counting the syncs in a row
+// that failed. Once the event syncs, it is removed from the queue.
+func recordSyncRetry(event *database.Event, err error) {
+	if err == nil {
+		if err := db.DeleteSyncRetry(event.EventID); err != nil {
+			slog.Warn("failed to remove sync retry", "event", event.EventCode, "error", err)
+		}
+		return
+	}
+
+	now := time.Now().UTC()
+	retry := &database.SyncRetry{EventID: event.EventID, FirstFailedAt: now}
+	retries, loadErr := db.GetSyncRetries()
+	if loadErr != nil {
+		slog.Warn("failed to load sync retries", "error", loadErr)
+	}
+	for _, r := range retries {
+		if r.EventID == event.EventID {
+			retry = r
+			break
+		}
+	}
+	retry.Attempts++
+	retry.LastError = strings.ReplaceAll(err.Error(), "\n", "; ")
+	retry.LastFailedAt = now
+	if err := db.SaveSyncRetry(retry); err != nil {
+		slog.Warn("failed to save sync retry", "event", event.EventCode, "error", err)
+		return
+	}
+	if retry.Quarantined() {
+		slog.Warn("Quarantined event that failed to sync too many times in a row, so it is no longer retried",
+			"event", event.EventCode, "attempts", retry.Attempts)
+	}
+}
+
+// RetryFailedEvents syncs the season's events whose results failed to sync in an earlier run, and recalculates the
+// team rankings of those whose results changed. The events already synced by the current run are skipped, as are
+// the events quarantined after failing to sync database.MaxSyncAttempts times in a row, which are only synced again
+// by a run that syncs the event itself, such as 'ftcdata --event'. The events that were retried are returned.
+func RetryFailedEvents(season string, synced []*database.Event) []*database.Event {
+	retries, err := db.GetSyncRetries()
+	if err != nil {
+		slog.Warn("failed to load sync retries", "error", err)
+		return nil
+	}
+	skip := make(map[string]bool, len(synced))
+	for _, event := range synced {
+		skip[event.EventID] = true
+	}
+
+	var events []*database.Event
+	for _, retry := range retries {
+		if skip[retry.EventID] {
+			continue
+		}
+		if retry.Quarantined() {
+			slog.Info("Skipping quarantined event", "eventID", retry.EventID, "attempts", retry.Attempts, "lastError", retry.LastError)
+			continue
+		}
+		event, err := db.GetEvent(retry.EventID)
+		if err != nil {
+			slog.Warn("failed to load event to retry", "eventID", retry.EventID, "error", err)
+			continue
+		}
+		// The queue isn't kept by season, so a database shared by the seasons holds the retries of each of them
+		if event == nil || strconv.Itoa(event.Year) != season {
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	addSyncRunEvents(len(events))
+	var stats EndpointStats
+	for i, event := range events {
+		slog.Info("Retrying event that failed to sync", "eventNumber", i+1, "totalEvents", len(events), "event", event.EventCode)
+		reconciliation := RequestAndSaveEventResults(event)
+		stats.Add(reconciliation)
+		if !reconciliation.Changed() {
+			slog.Info("Skipping team rankings for event whose results haven't changed", "event", event.EventCode)
+		} else if err := RequestAndSaveTeamRankings(event); err != nil {
+			slog.Warn("failed to calculate team rankings", "event", event.EventCode, "error", err)
+			recordSyncError(fmt.Errorf("%s team rankings: %w", event.EventCode, err))
+		}
+	}
+	slog.Info("Finished retrying events that failed to sync", "events", len(events), "updatedEndpoints", stats.Updated, "skippedEndpoints", stats.Skipped)
+	return events
+}
@@ -0,0 +1,120 @@
+package request
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// maxSyncRunErrors is the number of errors recorded for a sync run. Any later errors are only counted.
+const maxSyncRunErrors = 20
+
+var (
+	syncRunMu sync.Mutex
+	syncRun   *database.SyncRun // The run in progress, which the events synced are recorded in
+)
+
+// StartSyncRun records the start of a run that syncs the season's data, described by the scope, such as "all",
+// "region USNC", or "event USNCRAQ". Until FinishSyncRun is called, the events synced by RequestAndSaveEventResults
+// and the errors they run into are recorded in the run, and the run is saved after each event so its progress can be
+// followed. Only one run is recorded at a time; starting another run stops recording the first.
+func StartSyncRun(season string, scope string) *database.SyncRun {
+	started := time.Now().UTC()
+	run := &database.SyncRun{
+		RunID:     started.Format("20060102T150405.000000000Z"),
+		Season:    season,
+		Scope:     scope,
+		Status:    database.SyncRunRunning,
+		StartedAt: started,
+	}
+
+	syncRunMu.Lock()
+	defer syncRunMu.Unlock()
+	syncRun = run
+	saveSyncRun(run)
+	return run
+}
+
+// FinishSyncRun records the end of a sync run, along with the error that ended it, if any. The run has failed if it
+// ended with an error or ran into any errors along the way.
+func FinishSyncRun(run *database.SyncRun, err error) {
+	syncRunMu.Lock()
+	defer syncRunMu.Unlock()
+	if syncRun == run {
+		syncRun = nil
+	}
+	if err != nil {
+		addSyncRunError(run, err)
+	}
+	run.FinishedAt = time.Now().UTC()
+	run.Status = database.SyncRunCompleted
+	if run.ErrorCount > 0 {
+		run.Status = database.SyncRunFailed
+	}
+	saveSyncRun(run)
+	slog.Info("Finished sync run", "run", run.RunID, "scope", run.Scope, "status", run.Status, "events", run.Events,
+		"changedEvents", run.ChangedEvents, "updatedEndpoints", run.UpdatedEndpoints, "skippedEndpoints", run.SkippedEndpoints,
+		"removed", run.Removed, "errors", run.ErrorCount, "duration", run.FinishedAt.Sub(run.StartedAt))
+}
+
+// addSyncRunEvents adds to the number of events the run in progress is going to sync.
+func addSyncRunEvents(total int) {
+	syncRunMu.Lock()
+	defer syncRunMu.Unlock()
+	if syncRun == nil {
+		return
+	}
+	syncRun.TotalEvents += total
+	saveSyncRun(syncRun)
+}
+
+// recordEventSync records an event synced by the run in progress, along with the error it ran into, if any.
+func recordEventSync(event *database.Event, reconciliation *database.EventReconciliation, err error) {
+	syncRunMu.Lock()
+	defer syncRunMu.Unlock()
+	if syncRun == nil {
+		return
+	}
+	syncRun.Events++
+	if reconciliation.Changed() {
+		syncRun.ChangedEvents++
+	}
+	syncRun.UpdatedEndpoints += len(reconciliation.UpdatedEndpoints)
+	syncRun.SkippedEndpoints += len(reconciliation.SkippedEndpoints)
+	syncRun.Removed += reconciliation.Count()
+	if err != nil {
+		addSyncRunError(syncRun, fmt.Errorf("%s: %w", event.EventCode, err))
+	}
+	saveSyncRun(syncRun)
+}
+
+// recordSyncError records an error the run in progress ran into.
+func recordSyncError(err error) {
+	syncRunMu.Lock()
+	defer syncRunMu.Unlock()
+	if syncRun == nil {
+		return
+	}
+	addSyncRunError(syncRun, err)
+	saveSyncRun(syncRun)
+}
+
+// addSyncRunError counts an error in a sync run, and records it on a single line if the run hasn't recorded
+// maxSyncRunErrors yet.
+func addSyncRunError(run *database.SyncRun, err error) {
+	run.ErrorCount++
+	if len(run.Errors) < maxSyncRunErrors {
+		run.Errors = append(run.Errors, strings.ReplaceAll(err.Error(), "\n", "; "))
+	}
+}
+
+// saveSyncRun saves a sync run, logging a failure to save it rather than failing the sync.
+func saveSyncRun(run *database.SyncRun) {
+	if err := db.SaveSyncRun(run); err != nil {
+		slog.Warn("failed to save sync run", "run", run.RunID, "error", err)
+	}
+}
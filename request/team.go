@@ -3,28 +3,72 @@ package request
 import (
 	"log/slog"
 	"strings"
+	"time"
 
-	"github.com/rbrabson/ftc"
 	"github.com/rbrabson/ftcstanding/database"
 )
 
-// RequestAndSaveTeams retrieves the list of teams for a given season and stores them in the database.
+// RequestAndSaveTeams retrieves the list of teams for a given season and stores them in the database. The name and
+// home region a team had before are saved in the team's history when the data source returns new ones.
 func RequestAndSaveTeams(season string) []*database.Team {
 	teams := RequestTeams(season)
 	if teams == nil {
 		return nil
 	}
+	saved, err := db.GetAllTeams()
+	if err != nil {
+		slog.Warn("failed to load teams from db", "error", err)
+	}
+	previous := make(map[int]*database.Team, len(saved))
+	for _, team := range saved {
+		previous[team.TeamID] = team
+	}
+	changedAt := time.Now().UTC()
 	for _, team := range teams {
+		if old := previous[team.TeamID]; old != nil {
+			saveTeamHistory(old, team, changedAt)
+		}
 		db.SaveTeam(team)
 	}
 	return teams
 }
 
+// saveTeamHistory saves the name and home region a team had before, if the team's new record changes either of them.
+// A name or region that is missing from either record isn't counted as a change, as the data source doesn't always
+// return them.
+func saveTeamHistory(old *database.Team, team *database.Team, changedAt time.Time) {
+	renamed := old.Name != "" && team.Name != "" && old.Name != team.Name
+	moved := old.HomeRegion != "" && team.HomeRegion != "" && old.HomeRegion != team.HomeRegion
+	if !renamed && !moved {
+		return
+	}
+
+	// The earlier name and region took effect when the team's last change was recorded
+	history := &database.TeamHistory{
+		TeamID:     old.TeamID,
+		Name:       old.Name,
+		HomeRegion: old.HomeRegion,
+		ValidTo:    changedAt,
+	}
+	earlier, err := db.GetTeamHistory(database.TeamHistoryFilter{TeamIDs: []int{old.TeamID}})
+	if err != nil {
+		slog.Warn("failed to load team history", "team", old.TeamID, "error", err)
+	} else if len(earlier) > 0 {
+		history.ValidFrom = earlier[0].ValidTo
+	}
+	if err := db.SaveTeamHistory(history); err != nil {
+		slog.Warn("failed to save team history", "team", old.TeamID, "error", err)
+		return
+	}
+	slog.Info("Recorded team change", "team", team.TeamID, "name", team.Name, "formerName", old.Name,
+		"region", team.HomeRegion, "formerRegion", old.HomeRegion)
+}
+
 // RequestTeams retrieves the list of teams for a given season.
 func RequestTeams(season string) []*database.Team {
-	ftcTeams, err := ftc.GetTeams(season)
+	ftcTeams, err := source.GetTeams(season)
 	if err != nil {
-		slog.Error("Error requesting teams:", "year", season, "error", err)
+		slog.Error("Error requesting teams:", "year", season, "source", source.Name(), "error", err)
 		return nil
 	}
 	slog.Info("Retrieved teams...", "count", len(ftcTeams))
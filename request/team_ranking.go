@@ -3,8 +3,12 @@ package request
 import (
 	"log/slog"
 	"maps"
+	"runtime"
 	"slices"
 	"sort"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/rbrabson/ftcstanding/database"
 	"github.com/rbrabson/ftcstanding/performance"
@@ -14,15 +18,80 @@ import (
 // It retrieves match data from the database, calculates performance metrics (OPR, NpOPR, CCWM, DPR, NpDPR, NpAvg),
 // and stores the results as TeamRanking records in the database.
 func RequestAndSaveTeamRankings(event *database.Event) error {
+	rankings, err := calculateTeamRankings(event)
+	if err != nil {
+		return err
+	}
+	saveTeamRankings(event, rankings)
+	return nil
+}
+
+// RequestAndSaveTeamRankingsForEvents calculates and saves team performance rankings for multiple events.
+// The matches for each event are loaded and the metrics solved on a bounded pool of workers, since the
+// least-squares solves dominate the time taken for a region or season. If workers is less than one, one
+// worker per CPU is used. Rankings are saved in the order of the events once all calculations complete,
+// so the results do not depend on which worker finished first.
+func RequestAndSaveTeamRankingsForEvents(events []*database.Event, workers int) error {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, len(events))
+
+	type result struct {
+		rankings []*database.TeamRanking
+		err      error
+	}
+	results := make([]result, len(events))
+
+	slog.Info("calculating team rankings for events", "events", len(events), "workers", workers)
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				rankings, err := calculateTeamRankings(events[i])
+				results[i] = result{rankings: rankings, err: err}
+			}
+		}()
+	}
+	for i := range events {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var firstErr error
+	for i, event := range events {
+		if results[i].err != nil {
+			slog.Error("Failed to calculate team rankings", "event", event.EventCode, "error", results[i].err)
+			if firstErr == nil {
+				firstErr = results[i].err
+			}
+			continue
+		}
+		saveTeamRankings(event, results[i].rankings)
+	}
+
+	slog.Info("Finished calculating team rankings for events", "events", len(events))
+	return firstErr
+}
+
+// eventCalculator retrieves the scored matches of an event from the database and returns a calculator for the
+// performance metrics of the teams that played in them. It returns nil if the event has no scored matches with
+// teams on both alliances.
+func eventCalculator(event *database.Event) (*performance.Calculator, error) {
 	// Get all matches for this event from the database
 	dbMatches, err := db.GetMatchesByEvent(event.EventID)
 	if err != nil {
 		slog.Error("failed to get matches for event", "eventID", event.EventID, "error", err)
-		return err
+		return nil, err
 	}
 	if len(dbMatches) == 0 {
 		slog.Info("No matches found for event", "event", event.EventCode)
-		return nil
+		return nil, nil
 	}
 
 	var matches []performance.Match
@@ -68,19 +137,22 @@ func RequestAndSaveTeamRankings(event *database.Event) error {
 		}
 
 		matches = append(matches, performance.Match{
+			Label:         matchLabel(dbMatch),
 			RedTeams:      redTeams,
 			BlueTeams:     blueTeams,
 			RedScore:      float64(redScore.TotalPoints),
 			BlueScore:     float64(blueScore.TotalPoints),
 			RedPenalties:  float64(redScore.FoulPointsCommitted),
 			BluePenalties: float64(blueScore.FoulPointsCommitted),
+			RedAuto:       float64(redScore.AutoPoints),
+			BlueAuto:      float64(blueScore.AutoPoints),
 		})
 	}
 
 	// Skip if no valid matches
 	if len(matches) == 0 {
 		slog.Info("No valid matches found for event", "event", event.EventCode)
-		return nil
+		return nil, nil
 	}
 
 	// Convert teamSet to sorted slice
@@ -90,22 +162,54 @@ func RequestAndSaveTeamRankings(event *database.Event) error {
 	// Calculate lambda for this event
 	lambdaValue := getLambda()
 
-	slog.Info("calculating team rankings", "event", event.EventCode, "matches", len(matches), "teams", len(eventTeams), "lambda", lambdaValue)
-
-	// Calculate performance metrics for this event
-	calculator := performance.Calculator{
+	return &performance.Calculator{
 		Matches: matches,
 		Teams:   eventTeams,
 		Lambda:  lambdaValue,
+	}, nil
+}
+
+// CalculateEventDiagnostics solves a performance metric for the teams at an event the same way the team rankings
+// are calculated, and returns the design matrix, residuals, and predicted and actual scores of the calculation.
+// It returns nil if the event has no scored matches.
+func CalculateEventDiagnostics(event *database.Event, metric string) (*performance.Diagnostics, error) {
+	calculator, err := eventCalculator(event)
+	if err != nil || calculator == nil {
+		return nil, err
 	}
+	return calculator.Diagnose(metric)
+}
+
+// matchLabel returns the name of a match in an event's diagnostics, such as "Qualification 12".
+func matchLabel(match *database.Match) string {
+	if match.Description != "" {
+		return match.Description
+	}
+	return match.TournamentLevel + " " + strconv.Itoa(match.MatchNumber)
+}
+
+// calculateTeamRankings retrieves the match data for an event from the database and calculates the performance
+// metrics for each team that played. Nothing is written to the database, so it is safe to call concurrently.
+func calculateTeamRankings(event *database.Event) ([]*database.TeamRanking, error) {
+	calculator, err := eventCalculator(event)
+	if err != nil || calculator == nil {
+		return nil, err
+	}
+	matches := calculator.Matches
+	eventTeams := calculator.Teams
+
+	slog.Info("calculating team rankings", "event", event.EventCode, "matches", len(matches), "teams", len(eventTeams), "lambda", calculator.Lambda)
 
+	// Calculate performance metrics for this event
 	opr := calculator.CalculateOPR()
 	npopr := calculator.CalculateNpOPR()
 	ccwm := calculator.CalculateCCWM()
 	dpr := calculator.CalculateDPR()
 	npdpr := calculator.CalculateNpDPR()
+	autoOPR := calculator.CalculateAutoOPR()
 
-	// Save TeamRanking records for each team
+	// Build TeamRanking records for each team
+	rankings := make([]*database.TeamRanking, 0, len(eventTeams))
 	for _, teamID := range eventTeams {
 		// Count matches for this team in this event
 		matchCount := 0
@@ -117,7 +221,7 @@ func RequestAndSaveTeamRankings(event *database.Event) error {
 
 		npavg := calculator.CalculateNpAVG(matches, teamID)
 
-		teamRanking := &database.TeamRanking{
+		rankings = append(rankings, &database.TeamRanking{
 			TeamID:     teamID,
 			EventID:    event.EventID,
 			NumMatches: matchCount,
@@ -127,14 +231,63 @@ func RequestAndSaveTeamRankings(event *database.Event) error {
 			DPR:        dpr[teamID],
 			NpDPR:      npdpr[teamID],
 			NpAvg:      npavg,
-		}
+			AutoOPR:    autoOPR[teamID],
+		})
+	}
 
+	return rankings, nil
+}
+
+// saveTeamRankings saves the calculated team rankings for an event and refreshes the event's summary.
+func saveTeamRankings(event *database.Event, rankings []*database.TeamRanking) {
+	if len(rankings) == 0 {
+		return
+	}
+	for _, teamRanking := range rankings {
 		if err := db.SaveTeamRanking(teamRanking); err != nil {
-			slog.Error("Failed to save team ranking", "event", event.EventCode, "team", teamID, "error", err)
+			slog.Error("Failed to save team ranking", "event", event.EventCode, "team", teamRanking.TeamID, "error", err)
+			continue
+		}
+	}
+
+	// Keep the event's summary in step with its rankings
+	if err := db.RefreshEventSummary(event.EventID); err != nil {
+		slog.Error("Failed to refresh event summary", "event", event.EventCode, "error", err)
+	}
+
+	slog.Info("Finished calculating team rankings", "event", event.EventCode, "teamsProcessed", len(rankings))
+}
+
+// SaveTeamRankingSnapshot records the current team rankings as a snapshot for the given date.
+// Snapshots allow rankings to be compared over time, such as showing movement since last week.
+func SaveTeamRankingSnapshot(date time.Time) error {
+	rankings, err := db.GetTeamRankings()
+	if err != nil {
+		slog.Error("failed to get team rankings", "error", err)
+		return err
+	}
+
+	snapshotDate := database.GetSnapshotDate(date)
+	for _, ranking := range rankings {
+		snapshot := &database.TeamRankingSnapshot{
+			SnapshotDate: snapshotDate,
+			TeamID:       ranking.TeamID,
+			EventID:      ranking.EventID,
+			NumMatches:   ranking.NumMatches,
+			CCWM:         ranking.CCWM,
+			OPR:          ranking.OPR,
+			NpOPR:        ranking.NpOPR,
+			DPR:          ranking.DPR,
+			NpDPR:        ranking.NpDPR,
+			NpAvg:        ranking.NpAvg,
+			AutoOPR:      ranking.AutoOPR,
+		}
+		if err := db.SaveTeamRankingSnapshot(snapshot); err != nil {
+			slog.Error("Failed to save team ranking snapshot", "event", ranking.EventID, "team", ranking.TeamID, "error", err)
 			continue
 		}
 	}
 
-	slog.Info("Finished calculating team rankings", "event", event.EventCode, "teamsProcessed", len(eventTeams))
+	slog.Info("Saved team ranking snapshot", "date", snapshotDate.Format(database.SnapshotDateFormat), "rankings", len(rankings))
 	return nil
 }
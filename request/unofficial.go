@@ -0,0 +1,313 @@
+package request
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftc"
+	"github.com/rbrabson/ftcstanding/database"
+)
+
+// ManualSource is recorded as the source of matches that are entered manually rather than requested from a data
+// source.
+const ManualSource = "manual"
+
+// ManualMatch is the result of a match entered manually, such as a match played at a scrimmage or off-season event.
+type ManualMatch struct {
+	EventCode       string // Event the match was played at, if read from a file that names it
+	TournamentLevel string // ftc.QUALIFIER or ftc.PLAYOFF
+	MatchNumber     int    // Match number for qualification matches, or series for playoff matches
+	RedTeams        []int
+	BlueTeams       []int
+	RedScore        int // Total score of the red alliance, including foul points
+	BlueScore       int // Total score of the blue alliance, including foul points
+	RedAuto         int // Autonomous points scored by the red alliance
+	BlueAuto        int // Autonomous points scored by the blue alliance
+	RedFoulPoints   int // Foul points awarded to the red alliance for fouls committed by the blue alliance
+	BlueFoulPoints  int // Foul points awarded to the blue alliance for fouls committed by the red alliance
+}
+
+// Validate reports whether the match can be saved.
+func (m *ManualMatch) Validate() error {
+	if !strings.EqualFold(m.TournamentLevel, string(ftc.QUALIFIER)) && !strings.EqualFold(m.TournamentLevel, string(ftc.PLAYOFF)) {
+		return fmt.Errorf("invalid tournament level %q", m.TournamentLevel)
+	}
+	if m.MatchNumber < 1 {
+		return fmt.Errorf("invalid match number %d", m.MatchNumber)
+	}
+	if len(m.RedTeams) == 0 || len(m.BlueTeams) == 0 {
+		return errors.New("both alliances must have at least one team")
+	}
+	teams := append(slices.Clone(m.RedTeams), m.BlueTeams...)
+	for i, team := range teams {
+		if team < 1 {
+			return fmt.Errorf("invalid team number %d", team)
+		}
+		if slices.Contains(teams[:i], team) {
+			return fmt.Errorf("team %d is in the match more than once", team)
+		}
+	}
+	for _, points := range []int{m.RedScore, m.BlueScore, m.RedAuto, m.BlueAuto, m.RedFoulPoints, m.BlueFoulPoints} {
+		if points < 0 {
+			return fmt.Errorf("invalid points %d", points)
+		}
+	}
+	if m.RedAuto+m.RedFoulPoints > m.RedScore || m.BlueAuto+m.BlueFoulPoints > m.BlueScore {
+		return errors.New("autonomous and foul points can't exceed the alliance's score")
+	}
+	return nil
+}
+
+// RegisterUnofficialEvent saves an event that isn't in the FTC Events API, such as a scrimmage or off-season event.
+// The event is flagged as unofficial, so it is excluded from metrics unless they ask for unofficial events. An
+// error is returned if an official event has the same event ID.
+func RegisterUnofficialEvent(event *database.Event) error {
+	event.EventCode = strings.ToUpper(event.EventCode)
+	event.EventID = database.GetEventID(&ftc.Event{Code: event.EventCode}, event.DateStart)
+	event.Unofficial = true
+
+	existing, err := db.GetEvent(event.EventID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && !existing.Unofficial {
+		return fmt.Errorf("event %s is an official event", event.EventID)
+	}
+	setEventLocation(event)
+	return db.SaveEvent(event)
+}
+
+// CheckManualMatches validates manually entered matches for an event without saving them. An error is returned
+// if any match is invalid, or would replace a match requested from a data source.
+func CheckManualMatches(event *database.Event, matches []*ManualMatch) error {
+	for i, m := range matches {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("match %d: %w", i+1, err)
+		}
+		matchID := database.GetMatchID(event, strings.ToUpper(m.TournamentLevel), m.MatchNumber)
+		existing, err := db.GetMatch(matchID)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.Source != ManualSource {
+			return fmt.Errorf("match %d: %s was requested from a data source and can't be replaced", i+1, matchID)
+		}
+	}
+	return nil
+}
+
+// SaveManualMatches validates and saves manually entered matches for an event, then saves the teams in the event
+// and calculates their team rankings. Matches may be entered for unofficial events, and for official events whose
+// results aren't posted to the FTC Events API. Each match records ManualSource as its source. No matches are saved
+// if any of them is invalid, or would replace a match requested from a data source.
+func SaveManualMatches(event *database.Event, matches []*ManualMatch) ([]*database.Match, error) {
+	if err := CheckManualMatches(event, matches); err != nil {
+		return nil, err
+	}
+
+	saved := make([]*database.Match, 0, len(matches))
+	for _, m := range matches {
+		level := strings.ToUpper(m.TournamentLevel)
+		match := &database.Match{
+			EventID:         event.EventID,
+			MatchID:         database.GetMatchID(event, level, m.MatchNumber),
+			MatchType:       titleCaser.String(level),
+			MatchNumber:     m.MatchNumber,
+			Description:     fmt.Sprintf("%s %d", titleCaser.String(level), m.MatchNumber),
+			TournamentLevel: level,
+			Source:          ManualSource,
+		}
+		if err := db.SaveMatch(match); err != nil {
+			return saved, fmt.Errorf("failed to save match %s: %w", match.MatchID, err)
+		}
+
+		scores := []*database.MatchAllianceScore{
+			manualAllianceScore(match, database.AllianceRed, m.RedScore, m.RedAuto, m.RedFoulPoints),
+			manualAllianceScore(match, database.AllianceBlue, m.BlueScore, m.BlueAuto, m.BlueFoulPoints),
+		}
+		for _, score := range scores {
+			if err := db.SaveMatchAllianceScore(score); err != nil {
+				return saved, fmt.Errorf("failed to save %s score for match %s: %w", score.Alliance, match.MatchID, err)
+			}
+		}
+
+		teams := make([]*database.MatchTeam, 0, len(m.RedTeams)+len(m.BlueTeams))
+		for _, team := range m.RedTeams {
+			teams = append(teams, &database.MatchTeam{MatchID: match.MatchID, TeamID: team, Alliance: database.AllianceRed, OnField: true})
+		}
+		for _, team := range m.BlueTeams {
+			teams = append(teams, &database.MatchTeam{MatchID: match.MatchID, TeamID: team, Alliance: database.AllianceBlue, OnField: true})
+		}
+		for _, team := range teams {
+			if err := db.SaveMatchTeam(team); err != nil {
+				return saved, fmt.Errorf("failed to save team %d for match %s: %w", team.TeamID, match.MatchID, err)
+			}
+		}
+		saved = append(saved, match)
+	}
+	slog.Info("Saved manually entered matches", "event", event.EventCode, "matches", len(saved))
+
+	RequestAndSaveTeamsInEvent(event)
+	if err := RequestAndSaveTeamRankings(event); err != nil {
+		return saved, fmt.Errorf("failed to calculate team rankings: %w", err)
+	}
+	return saved, nil
+}
+
+// manualAllianceScore creates the score of an alliance in a manually entered match.
+func manualAllianceScore(match *database.Match, alliance string, total, auto, foulPoints int) *database.MatchAllianceScore {
+	return &database.MatchAllianceScore{
+		MatchID:             match.MatchID,
+		Alliance:            alliance,
+		AutoPoints:          auto,
+		TeleopPoints:        total - auto - foulPoints,
+		FoulPointsCommitted: foulPoints,
+		PreFoulTotal:        total - foulPoints,
+		TotalPoints:         total,
+	}
+}
+
+// ReadManualMatchesCSV reads manually entered matches from CSV. The first row is a header naming the columns:
+//
+//	event,level,match,red1,red2,blue1,blue2,red_score,blue_score,red_auto,blue_auto,red_fouls,blue_fouls
+//
+// The columns may be in any order. The event is the code of the event the match was played at. The level is
+// Qualification or Playoff, or Q or P for short. The match is the match number, or the series for playoff
+// matches. The scores include foul points. The event, red_auto, blue_auto, red_fouls, and blue_fouls columns are
+// optional, and the fouls are the foul points awarded to the alliance. Team columns may be left empty for
+// alliances with a single team.
+//
+// Every row is validated, and the errors of all invalid rows are returned together, identified by line number.
+// A match that appears more than once is an error.
+func ReadManualMatchesCSV(r io.Reader) ([]*ManualMatch, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"level", "match", "red1", "blue1", "red_score", "blue_score"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var matches []*ManualMatch
+	var errs []error
+	seen := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		match, err := parseManualMatch(record, columns)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%d", match.EventCode, match.TournamentLevel, match.MatchNumber)
+		if previous, ok := seen[key]; ok {
+			errs = append(errs, fmt.Errorf("line %d: %s match %d is also on line %d", line, match.TournamentLevel, match.MatchNumber, previous))
+			continue
+		}
+		seen[key] = line
+		matches = append(matches, match)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return matches, nil
+}
+
+// parseManualMatch parses and validates a row of a CSV file of manually entered matches. The columns map the name
+// of each column to its index in the row.
+func parseManualMatch(record []string, columns map[string]int) (*ManualMatch, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	number := func(name string) (int, error) {
+		value := field(name)
+		if value == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q", name, value)
+		}
+		return n, nil
+	}
+
+	level, err := ParseTournamentLevel(field("level"))
+	if err != nil {
+		return nil, err
+	}
+	match := &ManualMatch{
+		EventCode:       strings.ToUpper(field("event")),
+		TournamentLevel: level,
+	}
+	values := []struct {
+		name  string
+		value *int
+	}{
+		{"match", &match.MatchNumber},
+		{"red_score", &match.RedScore},
+		{"blue_score", &match.BlueScore},
+		{"red_auto", &match.RedAuto},
+		{"blue_auto", &match.BlueAuto},
+		{"red_fouls", &match.RedFoulPoints},
+		{"blue_fouls", &match.BlueFoulPoints},
+	}
+	for _, v := range values {
+		if *v.value, err = number(v.name); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range []string{"red1", "red2", "blue1", "blue2"} {
+		team, err := number(name)
+		if err != nil {
+			return nil, err
+		}
+		if team == 0 {
+			continue
+		}
+		if strings.HasPrefix(name, "red") {
+			match.RedTeams = append(match.RedTeams, team)
+		} else {
+			match.BlueTeams = append(match.BlueTeams, team)
+		}
+	}
+	if err := match.Validate(); err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// ParseTournamentLevel returns the tournament level named by s, which is Qualification or Playoff, or Q or P for
+// short.
+func ParseTournamentLevel(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "q", "qual", "qualification":
+		return string(ftc.QUALIFIER), nil
+	case "p", "playoff":
+		return string(ftc.PLAYOFF), nil
+	}
+	return "", fmt.Errorf("invalid tournament level %q", s)
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Role is the level of access granted to a request. Roles are ordered, so each role has the access of the roles before it. The API has no endpoints that write data yet, so every role can read the same data.
+type Role int
+
+const (
+	RoleReadOnly Role = iota // Read the published data; the role of requests without an API key
+	RoleScout                // Reserved for writing scouting data, such as notes and tags on teams
+	RoleAdmin                // Reserved for correcting synced data
+)
+
+// roleNames are the names of the roles, as they are given in the API key file.
+var roleNames = map[Role]string{
+	RoleReadOnly: "read-only",
+	RoleScout:    "scout",
+	RoleAdmin:    "admin",
+}
+
+// String returns the name of the role.
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("Role(%d)", int(r))
+}
+
+// ParseRole returns the role with the name, without regard to case.
+func ParseRole(name string) (Role, error) {
+	for role, roleName := range roleNames {
+		if strings.EqualFold(strings.TrimSpace(name), roleName) {
+			return role, nil
+		}
+	}
+	return RoleReadOnly, fmt.Errorf("unknown role %q; valid roles are read-only, scout, and admin", name)
+}
+
+// APIKey is a key that clients send in the Authorization header to be granted a role.
+type APIKey struct {
+	Name string `json:"name"` // Who the key was issued to, such as a team's scouts
+	Key  string `json:"key"`
+	Role string `json:"role"`
+}
+
+// caller is the holder of the API key a request was made with. A request without a key has a read-only caller that isn't authenticated.
+type caller struct {
+	name          string
+	role          Role
+	authenticated bool
+}
+
+// callerKey is the context key for the caller of a request.
+type callerKey struct{}
+
+// LoadAPIKeys loads API keys from a JSON file holding a list of keys, each with the name of who it was issued to, the key, and its role. The keys are checked when they are given to SetAPIKeys.
+func LoadAPIKeys(file string) ([]APIKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API keys: %w", err)
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys in %s: %w", file, err)
+	}
+	return keys, nil
+}
+
+// SetAPIKeys sets the API keys the server accepts. Requests without a key are read-only. The keys are held by their SHA-256 hashes, so they aren't kept in memory. An error is returned, and the keys are left unchanged, if a key is empty, is given more than once, or has an unknown role.
+func (s *Server) SetAPIKeys(keys []APIKey) error {
+	callers := make(map[[sha256.Size]byte]caller, len(keys))
+	for i, key := range keys {
+		if key.Key == "" {
+			return fmt.Errorf("API key %d (%s) is empty", i+1, key.Name)
+		}
+		role, err := ParseRole(key.Role)
+		if err != nil {
+			return fmt.Errorf("API key %d (%s): %w", i+1, key.Name, err)
+		}
+		hash := sha256.Sum256([]byte(key.Key))
+		if _, ok := callers[hash]; ok {
+			return fmt.Errorf("API key %d (%s) is given more than once", i+1, key.Name)
+		}
+		callers[hash] = caller{name: key.Name, role: role, authenticated: true}
+	}
+	s.apiKeys = callers
+	return nil
+}
+
+// authenticate returns the request with its caller added to its context. A request with an API key in its Authorization header, as "Bearer <key>", is granted the key's role; a request without one is read-only. If no API keys are accepted, or the header uses another scheme, such as the Basic credentials added by a proxy in front of the server, the request is read-only as well. If a bearer key isn't accepted, a 401 Unauthorized error is written and false is returned.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	anonymous := r.WithContext(context.WithValue(r.Context(), callerKey{}, caller{role: RoleReadOnly}))
+	if len(s.apiKeys) == 0 {
+		return anonymous, true
+	}
+	scheme, key, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return anonymous, true
+	}
+	c, ok := s.apiKeys[sha256.Sum256([]byte(strings.TrimSpace(key)))]
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		s.writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized, "invalid API key")
+		return r, false
+	}
+	return r.WithContext(context.WithValue(r.Context(), callerKey{}, c)), true
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"encoding/json"
+	"expvar"
+	"math"
+	"net/http"
+	"net/http/pprof"
+	"runtime/metrics"
+)
+
+// NewDebugHandler returns a handler serving pprof profiles under /debug/pprof/, the expvar variables, including the memory statistics, at /debug/vars, and the Go runtime metrics at /debug/metrics. It is served on its own address so the profiles aren't exposed on the API's port.
+func NewDebugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/metrics", handleRuntimeMetrics)
+	return mux
+}
+
+// handleRuntimeMetrics writes the current value of each Go runtime metric as a JSON object keyed by the metric's name. Histograms, such as the GC pause times, are written with their bucket boundaries and counts.
+func handleRuntimeMetrics(w http.ResponseWriter, r *http.Request) {
+	descs := metrics.All()
+	samples := make([]metrics.Sample, len(descs))
+	for i, desc := range descs {
+		samples[i].Name = desc.Name
+	}
+	metrics.Read(samples)
+
+	values := make(map[string]any, len(samples))
+	for _, sample := range samples {
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			values[sample.Name] = sample.Value.Uint64()
+		case metrics.KindFloat64:
+			values[sample.Name] = sample.Value.Float64()
+		case metrics.KindFloat64Histogram:
+			h := sample.Value.Float64Histogram()
+			// The outer boundaries may be infinite, which JSON can't represent
+			buckets := make([]any, len(h.Buckets))
+			for i, b := range h.Buckets {
+				buckets[i] = b
+				if math.IsInf(b, 0) {
+					buckets[i] = nil
+				}
+			}
+			values[sample.Name] = map[string]any{"buckets": buckets, "counts": h.Counts}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(values)
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// dataAsOfHeader is the HTTP header that returns the time the data in a response was last synced.
+const dataAsOfHeader = "X-Data-As-Of"
+
+// streamBufferSize is the size of the buffer used when writing JSON responses. Once the buffer fills it is written to the client, so large responses are sent as they are encoded rather than after the whole response has been encoded.
+const streamBufferSize = 32 * 1024
+
+// gzipWriterPool holds gzip writers for reuse, since allocating a new writer for every response is expensive.
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(io.Discard)
+	},
+}
+
+// gzipResponseWriter compresses the body of a response with gzip. The gzip writer is only created once the body is first written, so responses without a body, such as 204 No Content, are not compressed.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz *gzip.Writer
+}
+
+// WriteHeader removes any Content-Length header, since it would be the length of the uncompressed body, and writes the status code.
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	g.Header().Del("Content-Length")
+	g.ResponseWriter.WriteHeader(status)
+}
+
+// Write compresses the data and writes it to the underlying response writer.
+func (g *gzipResponseWriter) Write(b []byte) (int, error) {
+	if g.gz == nil {
+		g.Header().Del("Content-Length")
+		g.gz = gzipWriterPool.Get().(*gzip.Writer)
+		g.gz.Reset(g.ResponseWriter)
+	}
+	return g.gz.Write(b)
+}
+
+// Flush writes any compressed data to the client.
+func (g *gzipResponseWriter) Flush() {
+	if g.gz != nil {
+		g.gz.Flush()
+	}
+	if flusher, ok := g.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close writes the end of the compressed body and returns the gzip writer to the pool.
+func (g *gzipResponseWriter) Close() {
+	if g.gz == nil {
+		return
+	}
+	g.gz.Close()
+	gzipWriterPool.Put(g.gz)
+	g.gz = nil
+}
+
+// acceptsGzip returns true if the client accepts a gzip-encoded response, based on the Accept-Encoding request header. An encoding with a quality of zero is treated as not accepted.
+func acceptsGzip(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept-Encoding") {
+		for encoding := range strings.SplitSeq(header, ",") {
+			name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+			if !strings.EqualFold(strings.TrimSpace(name), "gzip") && strings.TrimSpace(name) != "*" {
+				continue
+			}
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				if quality, err := strconv.ParseFloat(q, 64); err == nil && quality == 0 {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// withGzip returns a response writer that compresses the response if the client accepts gzip, along with a function that must be called once the response has been written. If the client does not accept gzip, the response writer is returned unchanged.
+func withGzip(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !acceptsGzip(r) {
+		return w, func() {}
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	gw := &gzipResponseWriter{ResponseWriter: w}
+	return gw, gw.Close
+}
+
+// encodeJSON writes the data to the writer as JSON followed by a newline, in the same form as json.Encoder. Slices are written one element at a time, so a large list is sent to the client as it is encoded instead of first being encoded in full in memory.
+func encodeJSON(w io.Writer, data any) error {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice || value.IsNil() || value.Type().Elem().Kind() == reflect.Uint8 {
+		return json.NewEncoder(w).Encode(data)
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i := range value.Len() {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		elem, err := json.Marshal(value.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(elem); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
+
+// addDataAsOf returns the data with a data_as_of field holding the time added as the first field, if the data is encoded as a JSON object. Any other data, such as a list, is returned unchanged, since it has nowhere to hold the field; the time is still available in the X-Data-As-Of header.
+func addDataAsOf(data any, dataAsOf string) (any, error) {
+	if value := reflect.ValueOf(data); value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		return data, nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(encoded) < 2 || encoded[0] != '{' {
+		return data, nil
+	}
+	field, err := json.Marshal(map[string]string{"data_as_of": dataAsOf})
+	if err != nil {
+		return nil, err
+	}
+	if string(encoded) == "{}" {
+		return json.RawMessage(field), nil
+	}
+	// Splice the field into the start of the object so it doesn't change the order of the data's own fields
+	spliced := append(field[:len(field)-1:len(field)-1], ',')
+	return json.RawMessage(append(spliced, encoded[1:]...)), nil
+}
+
+// newStreamWriter returns a buffered writer for a response body. The caller must flush the writer once the body has been written.
+func newStreamWriter(w http.ResponseWriter) *bufio.Writer {
+	return bufio.NewWriterSize(w, streamBufferSize)
+}
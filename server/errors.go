@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// Error codes returned in the code field of an error response. Clients should use the code, rather than the
+// message or HTTP status, to decide how to handle an error.
+const (
+	ErrCodeInvalidParameter    = "invalid_parameter"    // A path or query parameter is missing or invalid
+	ErrCodeUnauthorized        = "unauthorized"         // The API key is missing or isn't accepted
+	ErrCodeNotFound            = "not_found"            // The requested resource does not exist
+	ErrCodeMethodNotAllowed    = "method_not_allowed"   // The HTTP method is not supported; only GET requests are allowed
+	ErrCodeUpstreamUnavailable = "upstream_unavailable" // The database could not be reached; the request may be retried
+	ErrCodeInternal            = "internal_error"       // An unexpected error occurred on the server
+)
+
+// requestIDHeader is the HTTP header used to pass a request ID to the server and return it to the client.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key for the request ID.
+type requestIDKey struct{}
+
+// ErrorResponse is the envelope for all error responses
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes an error, including a machine-readable code, a human-readable message, optional details such as the name of an invalid parameter, and the ID of the request so it can be found in the server logs
+type ErrorDetail struct {
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"request_id"`
+}
+
+// withRequestID returns the request with a request ID added to its context. The ID is taken from the X-Request-ID header if the client provided one; otherwise a new ID is generated. The ID is also returned to the client in the X-Request-ID response header.
+func withRequestID(w http.ResponseWriter, r *http.Request) *http.Request {
+	requestID := r.Header.Get(requestIDHeader)
+	if requestID == "" {
+		b := make([]byte, 8)
+		rand.Read(b)
+		requestID = hex.EncodeToString(b)
+	}
+	w.Header().Set(requestIDHeader, requestID)
+	return r.WithContext(context.WithValue(r.Context(), requestIDKey{}, requestID))
+}
+
+// requestID returns the ID of the request, or an empty string if the request does not have one.
+func requestID(r *http.Request) string {
+	requestID, _ := r.Context().Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// writeError is a helper function to write an error response in JSON format with the given status code, error code, and message. Optional details, such as the name of an invalid parameter, are included in the response.
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, status int, code, message string, details ...map[string]string) {
+	response := ErrorResponse{
+		Error: ErrorDetail{
+			Code:      code,
+			Message:   message,
+			RequestID: requestID(r),
+		},
+	}
+	if len(details) > 0 {
+		response.Error.Details = details[0]
+	}
+	s.writeJSON(w, status, response)
+}
+
+// writeParameterError is a helper function to write an error response for a missing or invalid path or query parameter. The name of the parameter is included in the error details.
+func (s *Server) writeParameterError(w http.ResponseWriter, r *http.Request, parameter, message string) {
+	s.writeError(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, message, map[string]string{"parameter": parameter})
+}
+
+// writeServerError is a helper function to write an error response for an error returned while querying the data. Errors caused by the database being unreachable are returned as 503 Service Unavailable so clients know the request can be retried; all other errors are returned as 500 Internal Server Error.
+func (s *Server) writeServerError(w http.ResponseWriter, r *http.Request, err error) {
+	if database.IsUnavailable(err) {
+		s.logger.Warn("database unavailable", "requestID", requestID(r), "error", err)
+		s.writeError(w, r, http.StatusServiceUnavailable, ErrCodeUpstreamUnavailable, "the database is unavailable, try again later")
+		return
+	}
+	s.logger.Error("request failed", "requestID", requestID(r), "path", r.URL.Path, "error", err)
+	s.writeError(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+}
+
+// writeNotFound is a helper function to write an error response for an event or region code that doesn't match any known code, or a season whose rules aren't known. The error is the one returned by query.EventNotFound, query.RegionNotFound, or query.SeasonRulesQuery; the closest known codes are included in the suggestions detail as a comma-separated list. Any other error is written as a server error.
+func (s *Server) writeNotFound(w http.ResponseWriter, r *http.Request, err error) {
+	var notFound *query.NotFoundError
+	if !errors.As(err, &notFound) {
+		s.writeServerError(w, r, err)
+		return
+	}
+	var details map[string]string
+	if len(notFound.Suggestions) > 0 {
+		details = map[string]string{"suggestions": strings.Join(notFound.Suggestions, ",")}
+	}
+	s.writeError(w, r, http.StatusNotFound, ErrCodeNotFound, notFound.Error(), details)
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// fieldTree is the set of fields requested with the 'fields' query parameter. Each field maps to the sub-fields requested within it, or to nil if the whole value of the field is included.
+type fieldTree map[string]fieldTree
+
+// parseFields parses a comma-separated list of field names, such as "team_id,name" or "rankings.team.team_id,rankings.wins". Nested fields are separated by dots. It returns nil if no fields are given.
+func parseFields(fields string) (fieldTree, error) {
+	var tree fieldTree
+	for field := range strings.SplitSeq(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if tree == nil {
+			tree = fieldTree{}
+		}
+
+		node := tree
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+			if i == len(parts)-1 {
+				// The whole value is included, even if sub-fields were requested elsewhere
+				node[part] = nil
+				break
+			}
+			child, ok := node[part]
+			if ok && child == nil {
+				// The whole value is already included
+				break
+			}
+			if child == nil {
+				child = fieldTree{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return tree, nil
+}
+
+// project returns the value with only the requested fields. Arrays are projected element by element, so the fields of a list of teams are given as they would be for a single team. Field names are matched without regard to case. An error is returned if a requested field does not exist.
+func (tree fieldTree) project(value any, path string) (any, error) {
+	switch v := value.(type) {
+	case []any:
+		projected := make([]any, 0, len(v))
+		for _, elem := range v {
+			p, err := tree.project(elem, path)
+			if err != nil {
+				return nil, err
+			}
+			projected = append(projected, p)
+		}
+		return projected, nil
+	case map[string]any:
+		projected := make(map[string]any, len(tree))
+		for field, subtree := range tree {
+			key, ok := findKey(v, field)
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q", path+field)
+			}
+			if subtree == nil {
+				projected[key] = v[key]
+				continue
+			}
+			p, err := subtree.project(v[key], path+field+".")
+			if err != nil {
+				return nil, err
+			}
+			projected[key] = p
+		}
+		return projected, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("field %q has no sub-fields", strings.TrimSuffix(path, "."))
+	}
+}
+
+// findKey returns the key in the object that matches the field name without regard to case.
+func findKey(object map[string]any, field string) (string, bool) {
+	if _, ok := object[field]; ok {
+		return field, true
+	}
+	for key := range object {
+		if strings.EqualFold(key, field) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// writeFieldsJSON writes the data as JSON like writeJSON, limited to the fields requested with the 'fields' query parameter. If the parameter is not present, the full response is written. A 400 Bad Request error is returned if a requested field does not exist.
+func (s *Server) writeFieldsJSON(w http.ResponseWriter, r *http.Request, status int, data any) {
+	tree, err := parseFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		s.writeParameterError(w, r, "fields", err.Error())
+		return
+	}
+	if tree == nil {
+		s.writeJSON(w, status, data)
+		return
+	}
+
+	// Round-trip the response through JSON so the projection uses the same field names clients see. Private team fields are hidden first, since the projection may drop the team ID that marks an object as a team.
+	var encoded []byte
+	if pw, ok := w.(*privateResponseWriter); ok {
+		encoded, err = pw.privacy.apply(data)
+	} else {
+		encoded, err = json.Marshal(data)
+	}
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+
+	projected, err := tree.project(value, "")
+	if err != nil {
+		s.writeParameterError(w, r, "fields", err.Error())
+		return
+	}
+	s.writeJSON(w, status, projected)
+}
@@ -0,0 +1,338 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// overlayCacheTTL is how long an overlay response is reused before it is built again. Overlays poll the same few endpoints every second or two while a match is on, so a short cache answers nearly every request from memory while keeping the data within a few seconds of the database.
+const overlayCacheTTL = 5 * time.Second
+
+// defaultLeaderboardSize is the number of teams on the overlay leaderboard if no limit is given.
+const defaultLeaderboardSize = 8
+
+// Formats an overlay response can be encoded in
+const (
+	overlayFormatJSON = "json"
+	overlayFormatXML  = "xml"
+	overlayFormatCSV  = "csv"
+)
+
+// overlayMediaTypes maps the media types accepted in an Accept header to the overlay format they select.
+var overlayMediaTypes = map[string]string{
+	"application/json": overlayFormatJSON,
+	"application/xml":  overlayFormatXML,
+	"text/xml":         overlayFormatXML,
+	"text/csv":         overlayFormatCSV,
+}
+
+// OverlayTeamResponse represents a team on the overlay leaderboard.
+type OverlayTeamResponse struct {
+	Rank         int     `json:"rank" xml:"rank"`
+	Team         int     `json:"team" xml:"team"`
+	Name         string  `json:"name" xml:"name"`
+	Wins         int     `json:"wins" xml:"wins"`
+	Losses       int     `json:"losses" xml:"losses"`
+	Ties         int     `json:"ties" xml:"ties"`
+	RankingScore float64 `json:"ranking_score" xml:"ranking_score"`
+	OPR          float64 `json:"opr" xml:"opr"`
+	NpOPR        float64 `json:"np_opr" xml:"np_opr"`
+}
+
+// OverlayMatchResponse represents a match on a stream overlay. The teams are flattened into numbered fields for each alliance station so overlay tools can bind to them directly. Fields are left empty if there is no match, or if a station has no team.
+type OverlayMatchResponse struct {
+	XMLName     xml.Name `json:"-" xml:"overlay"`
+	EventCode   string   `json:"event_code" xml:"event_code"`
+	Match       string   `json:"match" xml:"match"`
+	Description string   `json:"description" xml:"description"`
+	Level       string   `json:"level" xml:"level"`
+	Number      int      `json:"number" xml:"number"`
+	StartTime   string   `json:"start_time" xml:"start_time"`
+	RedScore    int      `json:"red_score" xml:"red_score"`
+	BlueScore   int      `json:"blue_score" xml:"blue_score"`
+	Winner      string   `json:"winner" xml:"winner"`
+	Red1        int      `json:"red1" xml:"red1"`
+	Red1Name    string   `json:"red1_name" xml:"red1_name"`
+	Red1Rank    int      `json:"red1_rank" xml:"red1_rank"`
+	Red1OPR     float64  `json:"red1_opr" xml:"red1_opr"`
+	Red2        int      `json:"red2" xml:"red2"`
+	Red2Name    string   `json:"red2_name" xml:"red2_name"`
+	Red2Rank    int      `json:"red2_rank" xml:"red2_rank"`
+	Red2OPR     float64  `json:"red2_opr" xml:"red2_opr"`
+	Blue1       int      `json:"blue1" xml:"blue1"`
+	Blue1Name   string   `json:"blue1_name" xml:"blue1_name"`
+	Blue1Rank   int      `json:"blue1_rank" xml:"blue1_rank"`
+	Blue1OPR    float64  `json:"blue1_opr" xml:"blue1_opr"`
+	Blue2       int      `json:"blue2" xml:"blue2"`
+	Blue2Name   string   `json:"blue2_name" xml:"blue2_name"`
+	Blue2Rank   int      `json:"blue2_rank" xml:"blue2_rank"`
+	Blue2OPR    float64  `json:"blue2_opr" xml:"blue2_opr"`
+}
+
+// OverlayLeaderboardResponse represents the teams on the overlay leaderboard. It is encoded as a JSON list, or as a <leaderboard> element with a <team> element for each team in XML.
+type OverlayLeaderboardResponse []*OverlayTeamResponse
+
+// MarshalXML encodes the leaderboard as a <leaderboard> element holding a <team> element for each team.
+func (lb OverlayLeaderboardResponse) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "leaderboard"
+	return e.EncodeElement(struct {
+		Teams []*OverlayTeamResponse `xml:"team"`
+	}{Teams: lb}, start)
+}
+
+// overlayCache holds the overlay responses built in the last few seconds, keyed by the request path and query.
+type overlayCache struct {
+	mu      sync.Mutex
+	entries map[string]overlayCacheEntry
+}
+
+// overlayCacheEntry is a cached overlay response and the time it expires.
+type overlayCacheEntry struct {
+	response any
+	expires  time.Time
+}
+
+// newOverlayCache creates an empty overlay cache.
+func newOverlayCache() *overlayCache {
+	return &overlayCache{entries: make(map[string]overlayCacheEntry)}
+}
+
+// get returns the cached response for the key, or false if there isn't one or it has expired.
+func (c *overlayCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.response, true
+}
+
+// set caches the response for the key, removing any expired responses.
+func (c *overlayCache) set(key string, response any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = overlayCacheEntry{response: response, expires: now.Add(overlayCacheTTL)}
+}
+
+// toOverlayTeamResponse converts a query.OverlayTeam to the leaderboard response format.
+func toOverlayTeamResponse(ot *query.OverlayTeam) *OverlayTeamResponse {
+	return &OverlayTeamResponse{
+		Rank:         ot.Rank,
+		Team:         ot.Team.TeamID,
+		Name:         ot.Team.Name,
+		Wins:         ot.Wins,
+		Losses:       ot.Losses,
+		Ties:         ot.Ties,
+		RankingScore: ot.RankingScore,
+		OPR:          ot.OPR,
+		NpOPR:        ot.NpOPR,
+	}
+}
+
+// overlayStation returns the team at the station of an alliance, counting from 1, or an empty team if the alliance has no team there.
+func overlayStation(teams []*query.OverlayTeam, station int) OverlayTeamResponse {
+	if station > len(teams) {
+		return OverlayTeamResponse{}
+	}
+	return *toOverlayTeamResponse(teams[station-1])
+}
+
+// toOverlayMatchResponse converts a query.OverlayMatch to the flattened overlay response format.
+func toOverlayMatchResponse(om *query.OverlayMatch) *OverlayMatchResponse {
+	response := &OverlayMatchResponse{EventCode: om.Event.EventCode}
+	if om.Match == nil {
+		return response
+	}
+
+	response.Match = om.Label()
+	response.Description = om.Match.Description
+	response.Level = om.Match.TournamentLevel
+	response.Number = om.Match.MatchNumber
+	response.StartTime = formatStartTime(om.Match.ActualStartTime, om.Event)
+	response.Winner = om.Winner()
+	if om.RedScore != nil {
+		response.RedScore = om.RedScore.TotalPoints
+	}
+	if om.BlueScore != nil {
+		response.BlueScore = om.BlueScore.TotalPoints
+	}
+
+	red1, red2 := overlayStation(om.Red, 1), overlayStation(om.Red, 2)
+	blue1, blue2 := overlayStation(om.Blue, 1), overlayStation(om.Blue, 2)
+	response.Red1, response.Red1Name, response.Red1Rank, response.Red1OPR = red1.Team, red1.Name, red1.Rank, red1.OPR
+	response.Red2, response.Red2Name, response.Red2Rank, response.Red2OPR = red2.Team, red2.Name, red2.Rank, red2.OPR
+	response.Blue1, response.Blue1Name, response.Blue1Rank, response.Blue1OPR = blue1.Team, blue1.Name, blue1.Rank, blue1.OPR
+	response.Blue2, response.Blue2Name, response.Blue2Rank, response.Blue2OPR = blue2.Team, blue2.Name, blue2.Rank, blue2.OPR
+	return response
+}
+
+// writeOverlay writes the overlay response for the request, reusing the response cached for the same path and query if it hasn't expired. Otherwise the response is built with build, which returns nil if the event isn't found.
+func (s *Server) writeOverlay(w http.ResponseWriter, r *http.Request, year int, build func(eventCode string, year int) (any, error)) {
+	format, err := overlayFormat(r)
+	if err != nil {
+		s.writeParameterError(w, r, "format", err.Error())
+		return
+	}
+
+	key := r.URL.Path + "?" + r.URL.RawQuery
+	if response, ok := s.overlays.get(key); ok {
+		s.writeOverlayFormat(w, r, format, response)
+		return
+	}
+
+	eventCode := r.PathValue("eventCode")
+	response, err := build(eventCode, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if response == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+	s.overlays.set(key, response)
+	s.writeOverlayFormat(w, r, format, response)
+}
+
+// overlayFormat returns the format requested for an overlay response with the 'format' query parameter, or, if the parameter isn't given, the first media type in the Accept header that selects a format. JSON is used if neither selects one. It returns an error if the parameter isn't a supported format.
+func overlayFormat(r *http.Request) (string, error) {
+	if format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))); format != "" {
+		switch format {
+		case overlayFormatJSON, overlayFormatXML, overlayFormatCSV:
+			return format, nil
+		}
+		return "", fmt.Errorf("invalid format: %s; must be json, xml, or csv", format)
+	}
+	for part := range strings.SplitSeq(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(part, ";")
+		if format, ok := overlayMediaTypes[strings.ToLower(strings.TrimSpace(mediaType))]; ok {
+			return format, nil
+		}
+	}
+	return overlayFormatJSON, nil
+}
+
+// writeOverlayFormat writes the overlay response in the format. JSON responses are limited to the fields requested with the 'fields' query parameter, while XML and CSV responses always have every field.
+func (s *Server) writeOverlayFormat(w http.ResponseWriter, r *http.Request, format string, response any) {
+	w.Header().Add("Vary", "Accept")
+
+	switch format {
+	case overlayFormatXML:
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, xml.Header)
+		if err := xml.NewEncoder(w).Encode(response); err != nil {
+			s.logger.Error("failed to encode XML response", "error", err)
+		}
+		io.WriteString(w, "\n")
+	case overlayFormatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		if err := csv.NewWriter(w).WriteAll(overlayCSVRecords(response)); err != nil {
+			s.logger.Error("failed to encode CSV response", "error", err)
+		}
+	default:
+		s.writeFieldsJSON(w, r, http.StatusOK, response)
+	}
+}
+
+// overlayCSVRecords returns the CSV records of an overlay response: a header row with the JSON name of each field, followed by a row for the response, or a row for each item if the response is a list.
+func overlayCSVRecords(response any) [][]string {
+	value := reflect.Indirect(reflect.ValueOf(response))
+	var items []reflect.Value
+	itemType := value.Type()
+	if value.Kind() == reflect.Slice {
+		itemType = itemType.Elem()
+		for i := range value.Len() {
+			items = append(items, reflect.Indirect(value.Index(i)))
+		}
+	} else {
+		items = append(items, value)
+	}
+	if itemType.Kind() == reflect.Pointer {
+		itemType = itemType.Elem()
+	}
+
+	var header []string
+	var fields []int
+	for i := range itemType.NumField() {
+		name, _, _ := strings.Cut(itemType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+
+	records := [][]string{header}
+	for _, item := range items {
+		record := make([]string, 0, len(fields))
+		for _, i := range fields {
+			record = append(record, fmt.Sprint(item.Field(i).Interface()))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// handleOverlayCurrentMatch handles requests for the match on the field or next up at an event, with each team's rank and OPR at the event.
+func (s *Server) handleOverlayCurrentMatch(w http.ResponseWriter, r *http.Request, year int) {
+	s.writeOverlay(w, r, year, func(eventCode string, year int) (any, error) {
+		match, err := query.CurrentMatchQuery(eventCode, year)
+		if err != nil || match == nil {
+			return nil, err
+		}
+		return toOverlayMatchResponse(match), nil
+	})
+}
+
+// handleOverlayLastMatch handles requests for the result of the last match played at an event.
+func (s *Server) handleOverlayLastMatch(w http.ResponseWriter, r *http.Request, year int) {
+	s.writeOverlay(w, r, year, func(eventCode string, year int) (any, error) {
+		match, err := query.LastMatchQuery(eventCode, year)
+		if err != nil || match == nil {
+			return nil, err
+		}
+		return toOverlayMatchResponse(match), nil
+	})
+}
+
+// handleOverlayLeaderboard handles requests for the top of an event's qualification rankings. It supports a 'limit' query parameter for the number of teams, which defaults to 8.
+func (s *Server) handleOverlayLeaderboard(w http.ResponseWriter, r *http.Request, year int) {
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	if limit == 0 {
+		limit = defaultLeaderboardSize
+	}
+
+	s.writeOverlay(w, r, year, func(eventCode string, year int) (any, error) {
+		teams, err := query.OverlayLeaderboardQuery(eventCode, year, limit)
+		if err != nil || teams == nil {
+			return nil, err
+		}
+		leaderboard := make(OverlayLeaderboardResponse, 0, len(teams))
+		for _, team := range teams {
+			leaderboard = append(leaderboard, toOverlayTeamResponse(team))
+		}
+		return leaderboard, nil
+	})
+}
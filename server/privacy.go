@@ -0,0 +1,199 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PrivacyMode is how private team fields are hidden from public responses.
+type PrivacyMode string
+
+const (
+	PrivacyRedact PrivacyMode = "redact" // The fields are given as empty strings, so responses keep their shape
+	PrivacyOmit   PrivacyMode = "omit"   // The fields are left out of responses
+)
+
+// privateTeamFields are the team fields that can be kept private, by the names used in JSON responses.
+var privateTeamFields = []string{"full_name", "city", "state_prov", "country", "country_code", "website", "robot_name"}
+
+// privacyFilter hides private team fields from the responses to requests made without an API key.
+type privacyFilter struct {
+	fields map[string]bool // Private fields, by their normalized names
+	mode   PrivacyMode
+}
+
+// normalizeFieldName returns the name of a JSON field in lower case without underscores, so a field is matched whether it is named full_name or FullName.
+func normalizeFieldName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "")
+}
+
+// SetPrivateTeamFields sets the team fields, such as a team's full name with the names of its sponsors and school, that are hidden from requests made without an API key. The fields are still kept in the database, and are returned to requests made with any accepted API key. An error is returned, and the fields are left unchanged, if a field can't be kept private or the mode is unknown. Keeping the country private keeps its country code private as well. Giving no fields makes every field public.
+func (s *Server) SetPrivateTeamFields(fields []string, mode PrivacyMode) error {
+	if len(fields) == 0 {
+		s.privacy = nil
+		return nil
+	}
+	if mode != PrivacyRedact && mode != PrivacyOmit {
+		return fmt.Errorf("unknown privacy mode %q; valid modes are redact and omit", mode)
+	}
+
+	private := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		name := normalizeFieldName(strings.TrimSpace(field))
+		valid := false
+		for _, privateField := range privateTeamFields {
+			if name == normalizeFieldName(privateField) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("team field %q can't be kept private; valid fields are %s", field, strings.Join(privateTeamFields, ", "))
+		}
+		private[name] = true
+		if name == "country" {
+			// A country's code gives the country away
+			private["countrycode"] = true
+		}
+	}
+	s.privacy = &privacyFilter{fields: private, mode: mode}
+	return nil
+}
+
+// hidesFrom returns whether the team field is hidden from the request, as it is private and the request was made without an API key.
+func (s *Server) hidesFrom(r *http.Request, field string) bool {
+	if s.privacy == nil || !s.privacy.fields[normalizeFieldName(field)] {
+		return false
+	}
+	c, _ := r.Context().Value(callerKey{}).(caller)
+	return !c.authenticated
+}
+
+// privateResponseWriter is the response writer for a request made without an API key when team fields are private. JSON responses written to it have the private fields hidden.
+type privateResponseWriter struct {
+	http.ResponseWriter
+	request *http.Request // The request being responded to, for reporting errors
+	privacy *privacyFilter
+}
+
+// Unwrap returns the underlying response writer, for use by http.ResponseController.
+func (p *privateResponseWriter) Unwrap() http.ResponseWriter {
+	return p.ResponseWriter
+}
+
+// withPrivacy returns a response writer that hides the private team fields if the request was made without an API key. The response writer is returned unchanged if no fields are private or the request has an accepted API key.
+func (s *Server) withPrivacy(w http.ResponseWriter, r *http.Request) http.ResponseWriter {
+	if s.privacy == nil {
+		return w
+	}
+	// Shared caches must not give a response with the private fields to a request without a key
+	w.Header().Add("Vary", "Authorization")
+	if c, _ := r.Context().Value(callerKey{}).(caller); c.authenticated {
+		return w
+	}
+	return &privateResponseWriter{ResponseWriter: w, request: r, privacy: s.privacy}
+}
+
+// apply returns the data encoded as JSON with the private fields of each team hidden. A team is any object with a team ID, so teams nested in rankings, awards, and matches are hidden as well. The order of the fields is kept.
+func (f *privacyFilter) apply(data any) (json.RawMessage, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var buf bytes.Buffer
+	if err := f.filterValue(decoder, &buf); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+// filterValue reads the next JSON value from the decoder and writes it to the buffer with the private fields of any teams within it hidden.
+func (f *privacyFilter) filterValue(decoder *json.Decoder, buf *bytes.Buffer) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '{' {
+			return f.filterObject(decoder, buf)
+		}
+		buf.WriteByte('[')
+		for i := 0; decoder.More(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := f.filterValue(decoder, buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		_, err := decoder.Token()
+		return err
+	default:
+		encoded, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+		return nil
+	}
+}
+
+// filterObject reads the fields of a JSON object, whose opening brace has already been read, and writes the object to the buffer. If the object is a team, its private fields are hidden.
+func (f *privacyFilter) filterObject(decoder *json.Decoder, buf *bytes.Buffer) error {
+	type member struct {
+		key   string
+		value []byte
+	}
+	var members []member
+	isTeam := false
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		var value bytes.Buffer
+		if err := f.filterValue(decoder, &value); err != nil {
+			return err
+		}
+		members = append(members, member{key: key, value: value.Bytes()})
+		if normalizeFieldName(key) == "teamid" {
+			isTeam = true
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	buf.WriteByte('{')
+	first := true
+	for _, m := range members {
+		value := m.value
+		if isTeam && f.fields[normalizeFieldName(m.key)] {
+			if f.mode == PrivacyOmit {
+				continue
+			}
+			value = []byte(`""`)
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// defaultQueueSize is the number of matches in the queue if no limit is given.
+const defaultQueueSize = 5
+
+// QueueTeamResponse represents a team to be queued for a match.
+type QueueTeamResponse struct {
+	Team int    `json:"team"`
+	Name string `json:"name"`
+}
+
+// QueueMatchResponse represents an unplayed match in an event's queue.
+type QueueMatchResponse struct {
+	Match         string              `json:"match"`
+	Description   string              `json:"description"`
+	Level         string              `json:"level"`
+	Number        int                 `json:"number"`
+	ScheduledTime string              `json:"scheduled_time"`
+	MatchesAway   int                 `json:"matches_away"`
+	WaitSeconds   int                 `json:"wait_seconds"`
+	Red           []QueueTeamResponse `json:"red"`
+	Blue          []QueueTeamResponse `json:"blue"`
+}
+
+// QueueResponse represents the next matches to be played at an event. The cycle time is 0 if not enough matches have been played to estimate it, in which case the waits are 0 as well.
+type QueueResponse struct {
+	EventCode        string                `json:"event_code"`
+	CycleTimeSeconds int                   `json:"cycle_time_seconds"`
+	Cycles           int                   `json:"cycles"`
+	Matches          []*QueueMatchResponse `json:"matches"`
+}
+
+// handleEventQueue handles requests for the next matches to be played at an event, with the teams to queue for each and the estimated wait until it starts. It supports a 'limit' query parameter for the number of matches, which defaults to 5.
+func (s *Server) handleEventQueue(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	if limit == 0 {
+		limit = defaultQueueSize
+	}
+
+	report, err := query.QueueQuery(eventCode, year, limit)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if report == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, toQueueResponse(report))
+}
+
+// toQueueResponse converts a query.QueueReport to the response format.
+func toQueueResponse(report *query.QueueReport) *QueueResponse {
+	response := &QueueResponse{
+		EventCode:        report.Event.EventCode,
+		CycleTimeSeconds: int(report.CycleTime.Seconds()),
+		Cycles:           report.Cycles,
+		Matches:          make([]*QueueMatchResponse, 0, len(report.Matches)),
+	}
+	for _, m := range report.Matches {
+		response.Matches = append(response.Matches, &QueueMatchResponse{
+			Match:         m.Label(),
+			Description:   m.Match.Description,
+			Level:         m.Match.TournamentLevel,
+			Number:        m.Match.MatchNumber,
+			ScheduledTime: formatStartTime(m.Match.ActualStartTime, report.Event),
+			MatchesAway:   m.MatchesAway,
+			WaitSeconds:   int(m.Wait.Seconds()),
+			Red:           toQueueTeamResponses(m.Red),
+			Blue:          toQueueTeamResponses(m.Blue),
+		})
+	}
+	return response
+}
+
+// toQueueTeamResponses converts the teams of an alliance to the response format.
+func toQueueTeamResponses(teams []*query.OverlayTeam) []QueueTeamResponse {
+	responses := make([]QueueTeamResponse, 0, len(teams))
+	for _, team := range teams {
+		responses = append(responses, QueueTeamResponse{Team: team.Team.TeamID, Name: team.Team.Name})
+	}
+	return responses
+}
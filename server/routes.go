@@ -0,0 +1,188 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// seasonHandlerFunc is the signature for handlers of routes under /v1/{season}. The season in the path has already been validated and is passed to the handler as the year.
+type seasonHandlerFunc func(w http.ResponseWriter, r *http.Request, year int)
+
+// scopeFunc returns the scope of the data a route's response is built from, which gives the response its Last-Modified and data-as-of times.
+type scopeFunc func(r *http.Request, year int) query.DataScope
+
+// seasonScope is the scope of routes built from every event in the season.
+func seasonScope(r *http.Request, year int) query.DataScope {
+	return query.DataScope{Year: year}
+}
+
+// eventScope is the scope of routes built from the event in the path.
+func eventScope(r *http.Request, year int) query.DataScope {
+	return query.DataScope{Year: year, EventCode: r.PathValue("eventCode")}
+}
+
+// regionScope returns the scope of routes built from the events in the region named by the path parameter. If the route has no region, the scope is the whole season.
+func regionScope(param string) scopeFunc {
+	return func(r *http.Request, year int) query.DataScope {
+		return query.DataScope{Year: year, RegionCode: r.PathValue(param)}
+	}
+}
+
+// setupRoutes registers the HTTP handlers for the server's endpoints. Path parameters are available to the handlers through r.PathValue.
+func (s *Server) setupRoutes() {
+	s.handle("/health", s.handleHealth)
+	s.handle("/v1/version", s.handleVersion)
+
+	s.handleSeason("/v1/{season}/team/{teamID}", seasonScope, s.handleTeam)
+	s.handleSeason("/v1/{season}/teams", regionScope("region"), s.handleTeams)
+	s.handleSeason("/v1/{season}/teams/{region}", regionScope("region"), s.handleTeams)
+
+	s.handleSeason("/v1/{season}/events/{eventCode}/teams", eventScope, s.handleEventTeams)
+	s.handleSeason("/v1/{season}/events/{eventCode}/rankings", eventScope, s.handleEventRankings)
+	s.handleSeason("/v1/{season}/events/{eventCode}/awards", eventScope, s.handleEventAwards)
+	s.handleSeason("/v1/{season}/events/{eventCode}/advancement", eventScope, s.handleEventAdvancement)
+	s.handleSeason("/v1/{season}/events/{eventCode}/advancement/{teamID}", eventScope, s.handleEventTeamAdvancement)
+	s.handleSeason("/v1/{season}/events/{eventCode}/matches", eventScope, s.handleEventMatches)
+	s.handleSeason("/v1/{season}/events/{eventCode}/summary", eventScope, s.handleEventSummary)
+	s.handleSeason("/v1/{season}/event-summaries", seasonScope, s.handleEventSummaries)
+	s.handleSeason("/v1/{season}/awards", seasonScope, s.handleAwards)
+	s.handleSeason("/v1/{season}/rules", nil, s.handleSeasonRules)
+
+	// Plain text renderings of the reports, laid out the same as the tables printed by the CLI
+	s.handleSeason("/v1/{season}/events/{eventCode}/rankings.txt", eventScope, s.handleEventRankingsText)
+	s.handleSeason("/v1/{season}/events/{eventCode}/awards.txt", eventScope, s.handleEventAwardsText)
+	s.handleSeason("/v1/{season}/events/{eventCode}/advancement.txt", eventScope, s.handleEventAdvancementText)
+	s.handleSeason("/v1/{season}/events/{eventCode}/matches.txt", eventScope, s.handleEventMatchesText)
+	s.handleSeason("/v1/{season}/regions/{regionCode}/advancement.txt", regionScope("regionCode"), s.handleRegionAdvancementText)
+	s.handleSeason("/v1/{season}/team-rankings.txt", seasonScope, s.handleTeamRankingsText)
+
+	// Overlay responses are cached for a few seconds, and the current match depends on the event's schedule, which isn't stored, so they don't carry a Last-Modified time
+	s.handleLiveSeason("/v1/{season}/events/{eventCode}/overlay/current-match", eventScope, s.handleOverlayCurrentMatch)
+	s.handleLiveSeason("/v1/{season}/events/{eventCode}/overlay/last-match", eventScope, s.handleOverlayLastMatch)
+	s.handleLiveSeason("/v1/{season}/events/{eventCode}/overlay/leaderboard", eventScope, s.handleOverlayLeaderboard)
+
+	// The queue depends on the event's schedule, which isn't stored, so it doesn't carry a Last-Modified time either
+	s.handleLiveSeason("/v1/{season}/events/{eventCode}/queue", eventScope, s.handleEventQueue)
+	s.handleLiveSeason("/v1/{season}/events/{eventCode}/queue.txt", eventScope, s.handleEventQueueText)
+
+	s.handleSeason("/v1/{season}/team-rankings", seasonScope, s.handleTeamRankings)
+	s.handleSeason("/v1/{season}/team-event-rankings", seasonScope, s.handleTeamEventRankings)
+
+	s.handleSeason("/v1/{season}/regions", seasonScope, s.handleRegionList)
+	s.handleSeason("/v1/{season}/regions/{regionCode}/teams", regionScope("regionCode"), s.handleRegionTeams)
+	s.handleSeason("/v1/{season}/regions/{regionCode}/events", regionScope("regionCode"), s.handleRegionEvents)
+	s.handleSeason("/v1/{season}/regions/{regionCode}/advancement", regionScope("regionCode"), s.handleRegionAdvancement)
+
+	s.handleSeason("/v1/{season}/advancement", seasonScope, s.handleAllAdvancement)
+	s.handleSeason("/v1/{season}/changes", nil, s.handleChanges)
+
+	// Sync runs are saved as they happen, so the status doesn't carry a Last-Modified time
+	s.handleLiveSeason("/v1/{season}/sync-status", seasonScope, s.handleSyncStatus)
+
+	// Anything that doesn't match a route above is not found
+	s.mux.HandleFunc("/", s.handleNotFound)
+}
+
+// handle registers a handler for the pattern. Only GET (and HEAD) requests are allowed.
+func (s *Server) handle(pattern string, handler http.HandlerFunc) {
+	s.mux.Handle(pattern, s.requireGET(handler))
+}
+
+// handleSeason registers a handler for a pattern under /v1/{season}. The season is validated before the handler is called. If scope is not nil, responses carry the time the data in the scope was last changed and the time it was last synced, and conditional requests for data that hasn't changed are answered without calling the handler.
+func (s *Server) handleSeason(pattern string, scope scopeFunc, handler seasonHandlerFunc) {
+	if scope != nil {
+		handler = s.withDataAsOf(scope, s.withLastModified(scope, handler))
+	}
+	s.handle(pattern, s.withSeason(handler))
+}
+
+// handleLiveSeason registers a handler for a pattern under /v1/{season} whose response depends on live data that isn't stored, such as the event's schedule. Responses carry the time the data in the scope was last synced, but not a Last-Modified time, since the response can change without the stored data changing.
+func (s *Server) handleLiveSeason(pattern string, scope scopeFunc, handler seasonHandlerFunc) {
+	s.handle(pattern, s.withSeason(s.withDataAsOf(scope, handler)))
+}
+
+// requireGET is middleware that rejects any request that is not a GET or HEAD request with a 405 Method Not Allowed error.
+func (s *Server) requireGET(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+			s.writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, fmt.Sprintf("method %s is not allowed", r.Method))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// withSeason is middleware that parses the season in the URL path and passes it to the handler as the year. It returns a 400 Bad Request error if the season is not a valid year.
+func (s *Server) withSeason(next seasonHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		season := r.PathValue("season")
+		year, err := strconv.Atoi(season)
+		if err != nil || year <= 0 {
+			s.writeParameterError(w, r, "season", fmt.Sprintf("invalid season: %s", season))
+			return
+		}
+		next(w, r, year)
+	}
+}
+
+// withLastModified is middleware that sets the Last-Modified header to the latest time the data in the route's scope was changed by a sync, and responds with 304 Not Modified if the data hasn't changed since the time in the request's If-Modified-Since header. Responses are marked no-cache so clients check with the server before reusing them, which costs a 304 with no body when nothing has changed. HTTP times are whole seconds, so the time is rounded up to the next second. If the time can't be found, the handler is called without setting the header.
+func (s *Server) withLastModified(scope scopeFunc, next seasonHandlerFunc) seasonHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, year int) {
+		lastModified, err := query.LastModifiedQuery(scope(r, year))
+		if err != nil {
+			s.logger.Warn("failed to find last modified time", "path", r.URL.Path, "error", err)
+		}
+		if err != nil || lastModified.IsZero() {
+			next(w, r, year)
+			return
+		}
+
+		if truncated := lastModified.Truncate(time.Second); !truncated.Equal(lastModified) {
+			lastModified = truncated.Add(time.Second)
+		}
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", "no-cache")
+
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !lastModified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		next(w, r, year)
+	}
+}
+
+// withDataAsOf is middleware that sets the X-Data-As-Of header to the latest time the results of an event in the route's scope were successfully synced, so clients can tell whether they are looking at live or stale data. writeJSON also adds the time to JSON object responses as data_as_of. If no event in the scope has been synced, or the time can't be found, the handler is called without setting the header.
+func (s *Server) withDataAsOf(scope scopeFunc, next seasonHandlerFunc) seasonHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, year int) {
+		dataAsOf, err := query.DataAsOfQuery(scope(r, year))
+		if err != nil {
+			s.logger.Warn("failed to find data as of time", "path", r.URL.Path, "error", err)
+		}
+		if err == nil && !dataAsOf.IsZero() {
+			w.Header().Set(dataAsOfHeader, dataAsOf.UTC().Format(time.RFC3339))
+		}
+		next(w, r, year)
+	}
+}
+
+// handleNotFound responds with a 404 Not Found error for any path that does not match a route.
+func (s *Server) handleNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, r, http.StatusNotFound, ErrCodeNotFound, fmt.Sprintf("unknown resource: %s", r.URL.Path))
+}
+
+// trimTrailingSlash removes any trailing slashes from the request path so that, for example, /v1/2024/teams/ is handled the same as /v1/2024/teams.
+func trimTrailingSlash(r *http.Request) {
+	if len(r.URL.Path) > 1 && strings.HasSuffix(r.URL.Path, "/") {
+		r.URL.Path = strings.TrimRight(r.URL.Path, "/")
+		if r.URL.Path == "" {
+			r.URL.Path = "/"
+		}
+		r.URL.RawPath = ""
+	}
+}
@@ -1,21 +1,29 @@
 package server
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/version"
 	"github.com/rbrabson/ftcstanding/query"
 )
 
 type Server struct {
-	db     database.DB
-	mux    *http.ServeMux
-	logger *slog.Logger
+	db       database.DB
+	mux      *http.ServeMux
+	logger   *slog.Logger
+	overlays *overlayCache
+	apiKeys  map[[sha256.Size]byte]caller // Callers by the SHA-256 hash of their API key
+	privacy  *privacyFilter               // Team fields hidden from requests without an API key, or nil if every field is public
 }
 
 // Response types for event resources - grouped under event
@@ -37,6 +45,7 @@ type EventResponse struct {
 	Timezone     string `json:"timezone"`
 	DateStart    string `json:"date_start"`
 	DateEnd      string `json:"date_end"`
+	Unofficial   bool   `json:"unofficial,omitempty"`
 }
 
 // MatchResponse represents a match without event_id
@@ -49,13 +58,90 @@ type MatchResponse struct {
 }
 
 type AwardResponse struct {
-	Name string         `json:"name"`
-	Team *database.Team `json:"team"`
+	Name   string         `json:"name"`
+	Person string         `json:"person,omitempty"` // Name of the person the award was given to, for awards given to a person
+	Team   *database.Team `json:"team"`
+}
+
+// AwardDefinitionResponse represents one of the season's awards, with what it is given for and how it counts toward advancement
+type AwardDefinitionResponse struct {
+	AwardID           int    `json:"award_id"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	ForPerson         bool   `json:"for_person"`
+	Class             string `json:"class"`                        // inspire, judged, playoff, other, or unknown
+	Advancement       bool   `json:"advancement"`                  // True if the award earns the team that wins it advancement points
+	AdvancementPoints []int  `json:"advancement_points,omitempty"` // Points for 1st, 2nd, and 3rd place, if the award carries advancement
+}
+
+// SeasonRulesResponse describes a season so clients can label its data: the game, how qualification matches are ranked, how advancement points are earned, and the awards given
+type SeasonRulesResponse struct {
+	Year          int                          `json:"year"`
+	Game          string                       `json:"game"`
+	RankingPoints []RankingPointResponse       `json:"ranking_points"`
+	SortOrders    []string                     `json:"sort_orders"` // Names of sort_order1, sort_order2, and so on in the event rankings, or empty if they aren't known
+	Advancement   AdvancementRulesResponse     `json:"advancement"`
+	Awards        []AwardRuleResponse          `json:"awards"`
+	Qualification *QualificationPointsResponse `json:"qualification_points,omitempty"` // Points at each rank, if the teams parameter was given
+}
+
+// RankingPointResponse represents a way for an alliance to earn ranking points in a qualification match
+type RankingPointResponse struct {
+	Name        string `json:"name"`
+	Points      int    `json:"points"`
+	Description string `json:"description"`
+}
+
+// AdvancementRulesResponse represents the points teams earn toward advancement at an event and how they are combined
+type AdvancementRulesResponse struct {
+	InspirePoints  []int                     `json:"inspire_points"`   // Points for 1st, 2nd, and 3rd place Inspire Awards
+	JudgedPoints   []int                     `json:"judged_points"`    // Points for 1st, 2nd, and 3rd place in the other judged awards
+	InspireOnly    bool                      `json:"inspire_only"`     // An Inspire Award winner earns the points for that award alone
+	BestJudgedOnly bool                      `json:"best_judged_only"` // A team winning several judged awards earns the points for the highest alone
+	InspireFirst   bool                      `json:"inspire_first"`    // The Inspire Award winner takes the first advancement slot
+	Selection      []SelectionPointsResponse `json:"selection_points"`
+	Playoffs       []PlayoffPointsResponse   `json:"playoff_points"`
+}
+
+// SelectionPointsResponse represents the points earned by the teams on an alliance for its number
+type SelectionPointsResponse struct {
+	Alliance int `json:"alliance"`
+	Points   int `json:"points"`
+}
+
+// PlayoffPointsResponse represents the points earned by the teams on an alliance for how it finished the playoffs
+type PlayoffPointsResponse struct {
+	Finish string `json:"finish"`
+	Points int    `json:"points"`
+}
+
+// AwardRuleResponse represents one of the awards a season's rules classify and how it counts toward advancement
+type AwardRuleResponse struct {
+	Name              string `json:"name"`
+	Class             string `json:"class"` // inspire, judged, playoff, or other
+	Advancement       bool   `json:"advancement"`
+	AdvancementPoints []int  `json:"advancement_points,omitempty"` // Points for 1st, 2nd, and 3rd place, if the award carries advancement
+}
+
+// QualificationPointsResponse represents the points earned at each qualification rank at an event with a given number of teams
+type QualificationPointsResponse struct {
+	Teams     int                         `json:"teams"`
+	Alliances int                         `json:"alliances"`
+	Ranks     []QualificationRankResponse `json:"ranks"`
+}
+
+// QualificationRankResponse represents the points earned at a qualification rank
+type QualificationRankResponse struct {
+	Rank      int `json:"rank"`
+	Points    int `json:"points"`
+	Selection int `json:"selection_points"` // Points if the team is selected for an alliance
 }
 
 type RankingResponse struct {
 	Team           *database.Team `json:"team"`
 	Year           int            `json:"year"`
+	Rank           int            `json:"rank"`
+	Division       string         `json:"division,omitempty"` // Code of the division the team was ranked in, for a multi-division event
 	SortOrder1     float64        `json:"sort_order1"`
 	SortOrder2     float64        `json:"sort_order2"`
 	SortOrder3     float64        `json:"sort_order3"`
@@ -82,16 +168,23 @@ type EventTeamsResponse struct {
 	Event *EventWithTeams `json:"event"`
 }
 
+// EventWithDivisions represents an event along with the codes of its divisions
+type EventWithDivisions struct {
+	*EventResponse
+	Divisions []string `json:"divisions,omitempty"`
+}
+
 // EventRankingsResponse represents the response for an event's rankings endpoint
 type EventRankingsResponse struct {
-	Event    *EventResponse    `json:"event"`
-	Rankings []RankingResponse `json:"rankings"`
+	Event    *EventWithDivisions `json:"event"`
+	Rankings []RankingResponse   `json:"rankings"`
 }
 
 // EventWithAwards represents an event along with its awards
 type EventWithAwards struct {
 	*EventResponse
-	Awards []AwardResponse `json:"awards"`
+	Awards       []AwardResponse `json:"awards"`
+	PersonAwards []AwardResponse `json:"person_awards"` // Awards given to a person, such as the Dean's List awards
 }
 
 // EventAwardsResponse represents the response for an event's awards endpoint
@@ -156,20 +249,136 @@ type EventMatchesResponse struct {
 type EventAdvancementResponse struct {
 	Event            *EventResponse           `json:"event"`
 	TeamAdvancements []*query.TeamAdvancement `json:"team_advancements"`
+	TypicalCutoff    *query.TypicalCutoff     `json:"typical_cutoff,omitempty"`
+}
+
+// AdvancementBreakdownResponse represents a team's advancement points at an event, along with the inputs each kind of points is calculated from
+type AdvancementBreakdownResponse struct {
+	Event               *EventResponse            `json:"event"`
+	TeamID              int                       `json:"team_id"`
+	TeamName            string                    `json:"team_name"`
+	Rank                int                       `json:"rank"` // Rank by total points, after any Inspire Award slot
+	TotalPoints         int                       `json:"total_points"`
+	QualificationPoints int                       `json:"qualification_points"`
+	JudgingPoints       int                       `json:"judging_points"`
+	PlayoffPoints       int                       `json:"playoff_points"`
+	SelectionPoints     int                       `json:"selection_points"`
+	Advances            bool                      `json:"advances"`
+	AdvancementNumber   string                    `json:"advancement_number"`
+	Status              string                    `json:"status,omitempty"`
+	InspireSlot         bool                      `json:"inspire_slot"`
+	Inputs              AdvancementInputsResponse `json:"inputs"`
+}
+
+// AdvancementInputsResponse represents the inputs to each kind of advancement points
+type AdvancementInputsResponse struct {
+	Qualification QualificationInputsResponse `json:"qualification"`
+	Judging       JudgingInputsResponse       `json:"judging"`
+	Playoff       PlayoffInputsResponse       `json:"playoff"`
+	Selection     SelectionInputsResponse     `json:"selection"`
+}
+
+// QualificationInputsResponse represents the inputs to the qualification points formula, where R is the team's position by ranking score and N is the number of ranked teams
+type QualificationInputsResponse struct {
+	Position int     `json:"position"`
+	Teams    int     `json:"teams"`
+	Alpha    float64 `json:"alpha"`
+	Formula  string  `json:"formula"`
+}
+
+// JudgingInputsResponse represents the judged awards a team won and the season's rules for combining their points
+type JudgingInputsResponse struct {
+	Awards         []JudgedAwardPointsResponse `json:"awards"`
+	InspireOnly    bool                        `json:"inspire_only"`
+	BestJudgedOnly bool                        `json:"best_judged_only"`
+}
+
+// JudgedAwardPointsResponse represents a judged award and whether its points count toward the team's total
+type JudgedAwardPointsResponse struct {
+	Name    string `json:"name"`
+	Place   int    `json:"place"`
+	Points  int    `json:"points"`
+	Counted bool   `json:"counted"`
+}
+
+// PlayoffInputsResponse represents how far the team's alliance went in the playoffs
+type PlayoffInputsResponse struct {
+	Place    int    `json:"place"`
+	Finish   string `json:"finish"`
+	Alliance []int  `json:"alliance"`
+}
+
+// SelectionInputsResponse represents the alliance the team was selected for
+type SelectionInputsResponse struct {
+	Alliance int    `json:"alliance"`
+	Formula  string `json:"formula"`
 }
 
 // TeamPerformanceResponse represents the performance metrics for a team across events in a season
 type PerformanceResponse struct {
-	TeamID   int     `json:"team_id"`
-	TeamName string  `json:"team_name"`
-	Region   string  `json:"region"`
-	OPR      float64 `json:"opr"`
-	NpOPR    float64 `json:"np_opr"`
-	CCWM     float64 `json:"ccwm"`
-	DPR      float64 `json:"dpr"`
-	NpDPR    float64 `json:"np_dpr"`
-	NpAVG    float64 `json:"np_avg"`
-	Matches  int     `json:"matches"`
+	TeamID      int     `json:"team_id"`
+	TeamName    string  `json:"team_name"`
+	Region      string  `json:"region"`
+	Country     string  `json:"country"`
+	CountryCode string  `json:"country_code,omitempty"` // ISO 3166-1 alpha-2 code of the team's country, if it is known
+	OPR         float64 `json:"opr"`
+	NpOPR       float64 `json:"np_opr"`
+	CCWM        float64 `json:"ccwm"`
+	DPR         float64 `json:"dpr"`
+	NpDPR       float64 `json:"np_dpr"`
+	NpAVG       float64 `json:"np_avg"`
+	AutoOPR     float64 `json:"auto_opr"`
+	Matches     int     `json:"matches"`
+	Division    string  `json:"division,omitempty"` // Code of the division the team played in, for the rankings of a multi-division event
+	Visiting    bool    `json:"visiting,omitempty"` // True if the team's home region is outside the region being ranked, whose events it visited
+}
+
+// PerformanceMovementResponse represents a team's performance along with how its rank has changed since a previous snapshot
+type PerformanceMovementResponse struct {
+	PerformanceResponse
+	Rank         int  `json:"rank"`
+	PreviousRank *int `json:"previous_rank"`
+	Movement     *int `json:"movement"`
+}
+
+// RegionResponse represents a region along with the number of events and teams in the region
+type RegionResponse struct {
+	RegionCode string   `json:"region_code"`
+	Aliases    []string `json:"aliases,omitempty"`
+	EventCount int      `json:"event_count"`
+	TeamCount  int      `json:"team_count"`
+}
+
+// EventSummaryResponse represents the materialized summary of an event's matches and team performance metrics
+type EventSummaryResponse struct {
+	Event          *EventResponse `json:"event"`
+	QualMatches    int            `json:"qual_matches"`
+	PlayoffMatches int            `json:"playoff_matches"`
+	NumTeams       int            `json:"num_teams"`
+	HighScore      int            `json:"high_score"`
+	AverageScore   float64        `json:"average_score"`
+	AverageNpScore float64        `json:"average_np_score"`
+	AverageOPR     float64        `json:"average_opr"`
+	AverageNpOPR   float64        `json:"average_np_opr"`
+	TopOPRTeamID   int            `json:"top_opr_team_id"`
+	TopOPR         float64        `json:"top_opr"`
+	TopNpOPRTeamID int            `json:"top_np_opr_team_id"`
+	TopNpOPR       float64        `json:"top_np_opr"`
+}
+
+// ChangesResponse represents the records that were created or changed since a point in time. Until is the time the changes were gathered, and should be used as the 'since' value of the next request.
+type ChangesResponse struct {
+	*database.ChangeSet
+	Until time.Time `json:"until"`
+	Count int       `json:"count"`
+}
+
+// SyncStatusResponse represents the recent runs that synced a season's data. LastRun is the most recent run, which may still be running, and LastCompleted is the most recent run that completed without errors. Either is null if there is no such run.
+type SyncStatusResponse struct {
+	Season        int                 `json:"season"`
+	LastRun       *database.SyncRun   `json:"last_run"`
+	LastCompleted *database.SyncRun   `json:"last_completed"`
+	Runs          []*database.SyncRun `json:"runs"`
 }
 
 // EventPerformanceResponse represents the performance metrics for a team at a specific event in a season
@@ -186,6 +395,7 @@ type EventPerformanceResponse struct {
 	DPR       float64 `json:"dpr"`
 	NpDPR     float64 `json:"np_dpr"`
 	NpAVG     float64 `json:"np_avg"`
+	AutoOPR   float64 `json:"auto_opr"`
 	Matches   int     `json:"matches"`
 }
 
@@ -232,6 +442,26 @@ func toEventResponse(e *database.Event) *EventResponse {
 		Timezone:     e.Timezone,
 		DateStart:    e.DateStart.Format("2006-01-02T15:04:05Z07:00"),
 		DateEnd:      e.DateEnd.Format("2006-01-02T15:04:05Z07:00"),
+		Unofficial:   e.Unofficial,
+	}
+}
+
+// toEventSummaryResponse converts a query.EventSummaryEntry to an EventSummaryResponse, which is used in API responses without exposing internal event_id
+func toEventSummaryResponse(entry *query.EventSummaryEntry) *EventSummaryResponse {
+	return &EventSummaryResponse{
+		Event:          toEventResponse(entry.Event),
+		QualMatches:    entry.Summary.QualMatches,
+		PlayoffMatches: entry.Summary.PlayoffMatches,
+		NumTeams:       entry.Summary.NumTeams,
+		HighScore:      entry.Summary.HighScore,
+		AverageScore:   entry.Summary.AverageScore,
+		AverageNpScore: entry.Summary.AverageNpScore,
+		AverageOPR:     entry.Summary.AverageOPR,
+		AverageNpOPR:   entry.Summary.AverageNpOPR,
+		TopOPRTeamID:   entry.Summary.TopOPRTeamID,
+		TopOPR:         entry.Summary.TopOPR,
+		TopNpOPRTeamID: entry.Summary.TopNpOPRTeamID,
+		TopNpOPR:       entry.Summary.TopNpOPR,
 	}
 }
 
@@ -263,15 +493,23 @@ func toMatchAllianceDetailsResponse(mad *query.MatchAllianceDetails) *MatchAllia
 	}
 }
 
+// formatStartTime formats the start time of a match at the event in the event's local time, with its offset from UTC. It returns an empty string if the start time isn't known.
+func formatStartTime(t time.Time, event *database.Event) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(event.Location()).Format(time.RFC3339)
+}
+
 // toMatchWithAlliancesResponse converts a database.Match along with its alliance details to a MatchWithAlliancesResponse, which is used in API responses without exposing internal event_id
-func toMatchWithAlliancesResponse(m *database.Match, red, blue *query.MatchAllianceDetails) *MatchWithAlliancesResponse {
+func toMatchWithAlliancesResponse(event *database.Event, m *database.Match, red, blue *query.MatchAllianceDetails) *MatchWithAlliancesResponse {
 	if m == nil {
 		return nil
 	}
 	return &MatchWithAlliancesResponse{
 		MatchType:       m.MatchType,
 		MatchNumber:     m.MatchNumber,
-		ActualStartTime: m.ActualStartTime,
+		ActualStartTime: formatStartTime(m.ActualStartTime, event),
 		Description:     m.Description,
 		TournamentLevel: m.TournamentLevel,
 		RedAlliance:     toMatchAllianceDetailsResponse(red),
@@ -287,7 +525,7 @@ func toTeamMatchResultResponse(tmr *query.TeamMatchResult) *TeamMatchResultRespo
 	return &TeamMatchResultResponse{
 		MatchType:       tmr.Match.MatchType,
 		MatchNumber:     tmr.Match.MatchNumber,
-		ActualStartTime: tmr.Match.ActualStartTime,
+		ActualStartTime: formatStartTime(tmr.Match.ActualStartTime, tmr.Event),
 		Description:     tmr.Match.Description,
 		TournamentLevel: tmr.Match.TournamentLevel,
 		RedAlliance:     toMatchAllianceDetailsResponse(tmr.TeamAlliance),
@@ -297,6 +535,27 @@ func toTeamMatchResultResponse(tmr *query.TeamMatchResult) *TeamMatchResultRespo
 	}
 }
 
+// toPerformanceResponse converts a query.TeamPerformance to a PerformanceResponse
+func toPerformanceResponse(p query.TeamPerformance) PerformanceResponse {
+	return PerformanceResponse{
+		TeamID:      p.TeamID,
+		TeamName:    p.TeamName,
+		Region:      p.Region,
+		Country:     p.Country,
+		CountryCode: p.CountryCode,
+		OPR:         p.OPR,
+		NpOPR:       p.NpOPR,
+		CCWM:        p.CCWM,
+		DPR:         p.DPR,
+		NpDPR:       p.NpDPR,
+		NpAVG:       p.NpAVG,
+		AutoOPR:     p.AutoOPR,
+		Matches:     p.Matches,
+		Division:    p.Division,
+		Visiting:    p.Visiting,
+	}
+}
+
 // toEventAdvancementSummaryResponse converts a query.EventAdvancementSummary to an EventAdvancementSummaryResponse, which includes the region code, year, and summaries for each event in the region
 func toEventAdvancementSummaryResponse(summary *query.EventAdvancementSummary) *EventAdvancementSummaryResponse {
 	if summary == nil {
@@ -329,28 +588,35 @@ func toEventAdvancementSummaryResponse(summary *query.EventAdvancementSummary) *
 // NewServer creates a new Server instance with the given database connection and sets up the routes
 func NewServer(db database.DB) *Server {
 	s := &Server{
-		db:     db,
-		mux:    http.NewServeMux(),
-		logger: slog.Default(),
+		db:       db,
+		mux:      http.NewServeMux(),
+		logger:   slog.Default(),
+		overlays: newOverlayCache(),
 	}
 	s.setupRoutes()
 	return s
 }
 
-// setupRoutes registers the HTTP handlers for the server's endpoints
-func (s *Server) setupRoutes() {
-	s.mux.HandleFunc("/v1/", s.handleV1Routes)
-	s.mux.HandleFunc("/health", s.handleHealth)
-}
-
 // ServeHTTP allows Server to satisfy the http.Handler interface by delegating to the internal ServeMux
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = withRequestID(w, r)
 	s.applyCORSHeaders(w, r)
 	if r.Method == http.MethodOptions {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
+	trimTrailingSlash(r)
+
+	r, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	w, done := withGzip(w, r)
+	defer done()
+	w = s.withPrivacy(w, r)
+
 	s.mux.ServeHTTP(w, r)
 }
 
@@ -364,7 +630,8 @@ func (s *Server) applyCORSHeaders(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Modified-Since")
+	w.Header().Set("Access-Control-Expose-Headers", dataAsOfHeader)
 	w.Header().Set("Access-Control-Max-Age", "86400")
 }
 
@@ -384,161 +651,218 @@ func (s *Server) parseLimit(r *http.Request) (int, error) {
 	return limit, nil
 }
 
-// handleHealth responds with a simple JSON indicating the server is healthy. This can be used for health checks.
-func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+// parseOffset extracts the 'offset' query parameter from the request, the number of results to skip before the first one returned, so a client can page through a long list with 'limit'. It returns 0 if the parameter is not present, and an error if the offset is invalid.
+func (s *Server) parseOffset(r *http.Request) (int, error) {
+	offsetStr := r.URL.Query().Get("offset")
+	if offsetStr == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset: %s", offsetStr)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("offset must be non-negative")
+	}
+	return offset, nil
 }
 
-// handleV1Routes is the main handler for all /v1/ API routes. It parses the URL path to determine the requested resource and delegates to the appropriate handler function based on the resource type.
-func (s *Server) handleV1Routes(w http.ResponseWriter, r *http.Request) {
-	path := strings.TrimPrefix(r.URL.Path, "/v1/")
-	parts := strings.Split(path, "/")
+// page returns the results on the page selected by the limit and offset, for results that are calculated rather than paged by the database. A limit of 0 returns every result after the offset.
+func page[T any](results []T, limit, offset int) []T {
+	if offset >= len(results) {
+		return results[:0]
+	}
+	results = results[offset:]
+	if limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+	return results
+}
 
-	if len(parts) < 1 || parts[0] == "" {
-		s.writeError(w, http.StatusBadRequest, "season is required in path")
-		return
+// parseIncludeUnofficial parses the 'include_unofficial' query parameter, which includes unofficial events such as scrimmages and off-season events in the rankings. It returns false if the parameter is not present.
+func (s *Server) parseIncludeUnofficial(r *http.Request) (bool, error) {
+	return s.parseBool(r, "include_unofficial")
+}
+
+// parseGroupBy parses the 'group_by' query parameter, which groups the teams in a response by their country. It returns an empty string if the parameter is not present, and an error if team countries are private and the request was made without an API key.
+func (s *Server) parseGroupBy(r *http.Request) (string, error) {
+	value := r.URL.Query().Get("group_by")
+	groupBy := strings.ToLower(value)
+	if groupBy != "" && groupBy != "country" {
+		return "", fmt.Errorf("invalid group_by: %s, must be country", value)
+	}
+	if groupBy == "country" && s.hidesFrom(r, "country") {
+		// The group headers would give away each team's country
+		return "", fmt.Errorf("group_by=country requires an API key, as team countries are private")
 	}
+	return groupBy, nil
+}
 
-	season := parts[0]
-	year, err := strconv.Atoi(season)
-	if err != nil {
-		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid season: %s", season))
-		return
+// parseCountry parses the 'country' query parameter, which limits the teams in a response to those from a country. It returns an empty string if the parameter is not present, and an error if team countries are private and the request was made without an API key.
+func (s *Server) parseCountry(r *http.Request) (string, error) {
+	country := r.URL.Query().Get("country")
+	if country != "" && s.hidesFrom(r, "country") {
+		// The teams in the response would give away which of them are from the country
+		return "", fmt.Errorf("country requires an API key, as team countries are private")
 	}
+	return country, nil
+}
 
-	if len(parts) < 2 {
-		s.writeError(w, http.StatusBadRequest, "resource type is required")
-		return
+// parseEventCodes parses the 'event' query parameter, which may be repeated or given a comma-separated list of event codes. It returns nil if the parameter is not present.
+func (s *Server) parseEventCodes(r *http.Request) []string {
+	var eventCodes []string
+	for _, value := range r.URL.Query()["event"] {
+		for code := range strings.SplitSeq(value, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				eventCodes = append(eventCodes, code)
+			}
+		}
 	}
+	return eventCodes
+}
 
-	resource := parts[1]
+// parseBool parses a boolean query parameter. It returns false if the parameter is not present.
+func (s *Server) parseBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %s", name, value)
+	}
+	return b, nil
+}
 
-	switch resource {
-	case "team":
-		s.handleTeam(w, r, year, parts[2:])
-	case "teams":
-		s.handleTeams(w, r, year, parts[2:])
-	case "events":
-		s.handleEvents(w, r, year, parts[2:])
-	case "team-rankings":
-		s.handleTeamRankings(w, r, year, parts[2:])
-	case "team-event-rankings":
-		s.handleTeamEventRankings(w, r, year, parts[2:])
-	case "regions":
-		s.handleRegions(w, r, year, parts[2:])
-	case "advancement":
-		s.handleAllAdvancement(w, r, year, parts[2:])
-	default:
-		s.writeError(w, http.StatusNotFound, fmt.Sprintf("unknown resource: %s", resource))
+// parseSort parses the 'sort' and 'order' query parameters into the keys used to sort team performances, writing an error response naming the invalid parameter if either cannot be parsed. It returns no keys if neither parameter is present.
+func (s *Server) parseSort(w http.ResponseWriter, r *http.Request) ([]query.SortKey, bool) {
+	keys, err := query.ParseSortKeys(r.URL.Query().Get("sort"), r.URL.Query().Get("order"))
+	if err != nil {
+		parameter := "sort"
+		var sortErr *query.SortError
+		if errors.As(err, &sortErr) {
+			parameter = sortErr.Parameter
+		}
+		s.writeParameterError(w, r, parameter, err.Error())
+		return nil, false
 	}
+	return keys, true
 }
 
-// handleTeam handles requests for a specific team's details. It expects the team ID to be provided in the URL path and returns the team's information in JSON format.
-func (s *Server) handleTeam(w http.ResponseWriter, r *http.Request, year int, parts []string) {
-	if len(parts) < 1 {
-		s.writeError(w, http.StatusBadRequest, "teamID is required")
+// handleHealth responds with a simple JSON indicating whether the server is healthy. This can be used for health checks. The server is unhealthy, and responds with 503 Service Unavailable, while the database can't be reached.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := database.Ping(r.Context(), s.db); err != nil {
+		s.logger.Warn("health check failed", "requestID", requestID(r), "error", err)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"status": "unavailable", "database": err.Error()})
 		return
 	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// VersionResponse represents the build of the server and the database backend it is using. The commit and build date are empty if they weren't recorded when the server was built.
+type VersionResponse struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+	Backend   string `json:"backend"`
+}
 
-	teamID, err := strconv.Atoi(parts[0])
+// handleVersion responds with the version of the server, the commit and date it was built from, the Go version it was built with, and the database backend it is using, so problems can be traced to a build.
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	info := version.Get()
+	s.writeJSON(w, http.StatusOK, VersionResponse{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildDate: info.BuildDate,
+		GoVersion: info.GoVersion,
+		Platform:  info.Platform,
+		Backend:   info.Backend,
+	})
+}
+
+// handleTeam handles requests for a specific team's details. It expects the team ID to be provided in the URL path and returns the team's information in JSON format.
+func (s *Server) handleTeam(w http.ResponseWriter, r *http.Request, year int) {
+	teamID, err := strconv.Atoi(r.PathValue("teamID"))
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid teamID: %s", parts[0]))
+		s.writeParameterError(w, r, "teamID", fmt.Sprintf("invalid teamID: %s", r.PathValue("teamID")))
 		return
 	}
 
-	details, err := query.TeamDetailsQuery(teamID)
+	details, err := query.TeamDetailsQuery(teamID, year)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
 	if details == nil {
-		s.writeError(w, http.StatusNotFound, fmt.Sprintf("team %d not found", teamID))
+		s.writeError(w, r, http.StatusNotFound, ErrCodeNotFound, fmt.Sprintf("team %d not found", teamID))
 		return
 	}
 
-	s.writeJSON(w, http.StatusOK, details)
+	s.writeFieldsJSON(w, r, http.StatusOK, details)
 }
 
-// handleTeams handles requests for teams, optionally filtered by region. It supports a 'limit' query parameter to limit the number of teams returned. If a region is specified in the URL path, it filters teams by that region; otherwise, it returns all teams.
-func (s *Server) handleTeams(w http.ResponseWriter, r *http.Request, year int, parts []string) {
+// handleTeams handles requests for teams, optionally filtered by region. It supports 'limit' and 'offset' query parameters to return a page of the teams, which are ordered by team number, and a 'group_by' query parameter to group the teams by country. If a region is specified in the URL path, it filters teams by that region; otherwise, it returns all teams.
+func (s *Server) handleTeams(w http.ResponseWriter, r *http.Request, year int) {
 	limit, err := s.parseLimit(r)
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeParameterError(w, r, "limit", err.Error())
 		return
 	}
-
-	var teams []*database.Team
-	if len(parts) > 0 && parts[0] != "" {
-		// Region specified - filter by region
-		region := parts[0]
-		teamsFilter := database.TeamFilter{
-			HomeRegions: []string{region},
-		}
-		teams, err = query.TeamsQuery(teamsFilter)
-	} else {
-		// No region specified - get all teams
-		teams, err = query.TeamsQuery(database.TeamFilter{})
+	offset, err := s.parseOffset(r)
+	if err != nil {
+		s.writeParameterError(w, r, "offset", err.Error())
+		return
 	}
+	groupBy, err := s.parseGroupBy(r)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeParameterError(w, r, "group_by", err.Error())
 		return
 	}
 
-	if limit > 0 && limit < len(teams) {
-		teams = teams[:limit]
+	// The database returns only the page of teams, so the whole table isn't loaded
+	teamsFilter := database.TeamFilter{Limit: limit, Offset: offset}
+	if region := r.PathValue("region"); region != "" {
+		// Region specified - filter by region
+		regionCode, ok := s.resolveRegion(w, r, region)
+		if !ok {
+			return
+		}
+		teamsFilter.HomeRegions = []string{regionCode}
 	}
-
-	s.writeJSON(w, http.StatusOK, teams)
-}
-
-// handleEvents handles requests for events, optionally filtered by event code. It expects the event code to be provided in the URL path and supports a 'limit' query parameter to limit the number of events returned. It delegates to specific handlers for different event resources such as teams, rankings, awards, advancement, and matches based on the second part of the URL path.
-func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request, year int, parts []string) {
-	if len(parts) < 1 {
-		s.writeError(w, http.StatusBadRequest, "eventCode is required")
+	teams, err := query.TeamsQuery(teamsFilter)
+	if err != nil {
+		s.writeServerError(w, r, err)
 		return
 	}
 
-	eventCode := parts[0]
-
-	if len(parts) < 2 {
-		s.writeError(w, http.StatusBadRequest, "event resource type is required")
+	if groupBy == "country" {
+		s.writeFieldsJSON(w, r, http.StatusOK, query.GroupByCountry(teams, func(t *database.Team) string { return t.Country }))
 		return
 	}
-
-	resource := parts[1]
-
-	switch resource {
-	case "teams":
-		s.handleEventTeams(w, r, year, eventCode)
-	case "rankings":
-		s.handleEventRankings(w, r, year, eventCode)
-	case "awards":
-		s.handleEventAwards(w, r, year, eventCode)
-	case "advancement":
-		s.handleEventAdvancement(w, r, year, eventCode)
-	case "matches":
-		s.handleEventMatches(w, r, year, eventCode)
-	default:
-		s.writeError(w, http.StatusNotFound, fmt.Sprintf("unknown event resource: %s", resource))
-	}
+	s.writeFieldsJSON(w, r, http.StatusOK, teams)
 }
 
 // handleEventTeams handles requests for the teams participating in a specific event. It expects the event code to be provided in the URL path and supports a 'limit' query parameter to limit the number of teams returned. It returns the event details along with the list of teams in JSON format.
-func (s *Server) handleEventTeams(w http.ResponseWriter, r *http.Request, year int, eventCode string) {
+func (s *Server) handleEventTeams(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
 	limit, err := s.parseLimit(r)
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeParameterError(w, r, "limit", err.Error())
 		return
 	}
 
 	eventTeams, err := query.TeamsByEventQuery(eventCode, year)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
 	if eventTeams == nil {
-		s.writeError(w, http.StatusNotFound, "event not found")
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
 		return
 	}
 
@@ -554,33 +878,41 @@ func (s *Server) handleEventTeams(w http.ResponseWriter, r *http.Request, year i
 		},
 	}
 
-	s.writeJSON(w, http.StatusOK, response)
+	s.writeFieldsJSON(w, r, http.StatusOK, response)
 }
 
 // handleEventRankings handles requests for the team rankings of a specific event. It expects the event code to be provided in the URL path and supports a 'limit' query parameter to limit the number of rankings returned. It returns the event details along with the list of team rankings in JSON format.
-func (s *Server) handleEventRankings(w http.ResponseWriter, r *http.Request, year int, eventCode string) {
+func (s *Server) handleEventRankings(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
 	limit, err := s.parseLimit(r)
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeParameterError(w, r, "limit", err.Error())
 		return
 	}
 
 	rankings, err := query.EventTeamRankingQuery(eventCode, year)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
 	if rankings == nil {
-		s.writeError(w, http.StatusNotFound, "event not found")
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
 		return
 	}
 
 	// Convert to clean response format
 	rankingList := make([]RankingResponse, 0, len(rankings.TeamRankings))
 	for _, tr := range rankings.TeamRankings {
+		var division string
+		if tr.Division != nil {
+			division = tr.Division.EventCode
+		}
 		rankingList = append(rankingList, RankingResponse{
 			Team:           tr.Team,
 			Year:           rankings.Event.Year,
+			Rank:           tr.Ranking.Rank,
+			Division:       division,
 			SortOrder1:     tr.Ranking.SortOrder1,
 			SortOrder2:     tr.Ranking.SortOrder2,
 			SortOrder3:     tr.Ranking.SortOrder3,
@@ -601,49 +933,166 @@ func (s *Server) handleEventRankings(w http.ResponseWriter, r *http.Request, yea
 		rankingList = rankingList[:limit]
 	}
 
+	divisions := make([]string, 0, len(rankings.Divisions))
+	for _, division := range rankings.Divisions {
+		divisions = append(divisions, division.EventCode)
+	}
 	response := EventRankingsResponse{
-		Event:    toEventResponse(rankings.Event),
+		Event: &EventWithDivisions{
+			EventResponse: toEventResponse(rankings.Event),
+			Divisions:     divisions,
+		},
 		Rankings: rankingList,
 	}
 
-	s.writeJSON(w, http.StatusOK, response)
+	s.writeFieldsJSON(w, r, http.StatusOK, response)
 }
 
-// handleEventAwards handles requests for the awards given at a specific event. It expects the event code to be provided in the URL path and supports a 'limit' query parameter to limit the number of awards returned. It returns the event details along with the list of awards in JSON format.
-func (s *Server) handleEventAwards(w http.ResponseWriter, r *http.Request, year int, eventCode string) {
-	limit, err := s.parseLimit(r)
+// handleAwards handles requests for the awards given during a specific season. It supports an 'advancement' query parameter to only return the awards that carry advancement. It returns the list of awards, ordered by award ID, with what each is given for and how it counts toward advancement in JSON format.
+func (s *Server) handleAwards(w http.ResponseWriter, r *http.Request, year int) {
+	advancementOnly, err := s.parseBool(r, "advancement")
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeParameterError(w, r, "advancement", err.Error())
 		return
 	}
 
-	awards, err := query.AwardsByEventQuery(eventCode, year)
+	definitions, err := query.AwardDefinitionsQuery(year)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
-	if awards == nil {
-		s.writeError(w, http.StatusNotFound, "event not found")
+
+	responses := make([]AwardDefinitionResponse, 0, len(definitions))
+	for _, definition := range definitions {
+		if advancementOnly && !definition.Advancement() {
+			continue
+		}
+		response := AwardDefinitionResponse{
+			AwardID:     definition.AwardID,
+			Name:        definition.Name,
+			Description: definition.Description,
+			ForPerson:   definition.ForPerson,
+			Class:       definition.Class.String(),
+			Advancement: definition.Advancement(),
+		}
+		if definition.Advancement() {
+			response.AdvancementPoints = definition.Points[:]
+		}
+		responses = append(responses, response)
+	}
+
+	s.writeFieldsJSON(w, r, http.StatusOK, responses)
+}
+
+// handleSeasonRules handles GET requests for the rules of a season, which are built into the server rather than synced, so clients can label the season's data without hard-coding it. The 'teams' parameter adds the qualification points earned at each rank at an event with that many teams.
+func (s *Server) handleSeasonRules(w http.ResponseWriter, r *http.Request, year int) {
+	teams := 0
+	if value := r.URL.Query().Get("teams"); value != "" {
+		var err error
+		if teams, err = strconv.Atoi(value); err != nil || teams < 1 {
+			s.writeParameterError(w, r, "teams", fmt.Sprintf("invalid teams: %s", value))
+			return
+		}
+	}
+
+	rules, err := query.SeasonRulesQuery(year, teams)
+	if err != nil {
+		s.writeNotFound(w, r, err)
 		return
 	}
 
-	// Convert to clean response format without event_id
-	awardList := make([]AwardResponse, 0, len(awards.Awards))
-	for _, ta := range awards.Awards {
+	response := SeasonRulesResponse{
+		Year:          rules.Year,
+		Game:          rules.Game,
+		RankingPoints: make([]RankingPointResponse, 0, len(rules.RankingPoints)),
+		SortOrders:    rules.SortOrders,
+		Advancement: AdvancementRulesResponse{
+			InspirePoints:  rules.Advancement.InspirePoints[:],
+			JudgedPoints:   rules.Advancement.JudgedPoints[:],
+			InspireOnly:    rules.Advancement.InspireOnly,
+			BestJudgedOnly: rules.Advancement.BestJudgedOnly,
+			InspireFirst:   rules.Advancement.InspireFirst,
+			Selection:      make([]SelectionPointsResponse, 0, len(rules.Selection)),
+			Playoffs:       make([]PlayoffPointsResponse, 0, len(rules.Playoffs)),
+		},
+		Awards: make([]AwardRuleResponse, 0, len(rules.Awards)),
+	}
+	if response.SortOrders == nil {
+		response.SortOrders = []string{}
+	}
+	for _, rp := range rules.RankingPoints {
+		response.RankingPoints = append(response.RankingPoints, RankingPointResponse(rp))
+	}
+	for _, selection := range rules.Selection {
+		response.Advancement.Selection = append(response.Advancement.Selection, SelectionPointsResponse{Alliance: selection.Alliance, Points: selection.Points})
+	}
+	for _, finish := range rules.Playoffs {
+		response.Advancement.Playoffs = append(response.Advancement.Playoffs, PlayoffPointsResponse{Finish: finish.Name, Points: finish.Points})
+	}
+	for _, award := range rules.Awards {
+		ar := AwardRuleResponse{Name: award.Name, Class: award.Class.String(), Advancement: award.Advancement()}
+		if award.Advancement() {
+			ar.AdvancementPoints = award.Points[:]
+		}
+		response.Awards = append(response.Awards, ar)
+	}
+	if rules.Points != nil {
+		response.Qualification = &QualificationPointsResponse{
+			Teams:     rules.Points.Teams,
+			Alliances: rules.Points.Alliances,
+			Ranks:     make([]QualificationRankResponse, 0, len(rules.Points.Ranks)),
+		}
+		for _, rank := range rules.Points.Ranks {
+			response.Qualification.Ranks = append(response.Qualification.Ranks, QualificationRankResponse(rank))
+		}
+	}
+
+	// The rules only change when the server is upgraded, so clients may reuse them for a day
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	s.writeFieldsJSON(w, r, http.StatusOK, response)
+}
+
+// toAwardResponses converts the awards given at an event to the clean response format without event_id, keeping at most limit of them if limit is greater than 0.
+func toAwardResponses(teamAwards []*query.TeamAward, limit int) []AwardResponse {
+	if limit > 0 && limit < len(teamAwards) {
+		teamAwards = teamAwards[:limit]
+	}
+	awardList := make([]AwardResponse, 0, len(teamAwards))
+	for _, ta := range teamAwards {
 		awardList = append(awardList, AwardResponse{
-			Name: ta.Award.Name,
-			Team: ta.Team,
+			Name:   ta.Award.Name,
+			Person: ta.Award.Person,
+			Team:   ta.Team,
 		})
 	}
+	return awardList
+}
+
+// handleEventAwards handles requests for the awards given at a specific event. It expects the event code to be provided in the URL path and supports a 'limit' query parameter to limit the number of awards returned. It returns the event details along with the list of team awards and the list of awards given to a person in JSON format.
+func (s *Server) handleEventAwards(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
 
-	if limit > 0 && limit < len(awardList) {
-		awardList = awardList[:limit]
+	awards, err := query.AwardsByEventQuery(eventCode, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if awards == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
 	}
 
 	response := EventAwardsResponse{
 		Event: &EventWithAwards{
 			EventResponse: toEventResponse(awards.Event),
-			Awards:        awardList,
+			Awards:        toAwardResponses(awards.Awards, limit),
+			PersonAwards:  toAwardResponses(awards.PersonAwards, limit),
 		},
 	}
 
@@ -651,30 +1100,117 @@ func (s *Server) handleEventAwards(w http.ResponseWriter, r *http.Request, year
 }
 
 // handleEventAdvancement handles requests for the advancement details of a specific event. It expects the event code to be provided in the URL path and returns the event details along with the team advancements in JSON format.
-func (s *Server) handleEventAdvancement(w http.ResponseWriter, r *http.Request, year int, eventCode string) {
+func (s *Server) handleEventAdvancement(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
 	advancement, err := query.AdvancementReportQuery(eventCode, year)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
 	if advancement == nil || advancement.Event == nil {
-		s.writeError(w, http.StatusNotFound, "event not found")
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
 		return
 	}
 
 	response := EventAdvancementResponse{
 		Event:            toEventResponse(advancement.Event),
 		TeamAdvancements: advancement.TeamAdvancements,
+		TypicalCutoff:    advancement.TypicalCutoff,
 	}
 
 	s.writeJSON(w, http.StatusOK, response)
 }
 
+// handleEventTeamAdvancement handles requests for a single team's advancement points at an event. It expects the event code and team ID to be provided in the URL path, and returns the team's points of each kind along with the inputs they are calculated from, such as its position by ranking score, its judged awards, its playoff finish, and its alliance.
+func (s *Server) handleEventTeamAdvancement(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+	teamID, err := strconv.Atoi(r.PathValue("teamID"))
+	if err != nil {
+		s.writeParameterError(w, r, "teamID", fmt.Sprintf("invalid teamID: %s", r.PathValue("teamID")))
+		return
+	}
+
+	breakdown, err := query.AdvancementBreakdownQuery(eventCode, teamID, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if breakdown == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+	if breakdown.Advancement == nil {
+		s.writeError(w, r, http.StatusNotFound, ErrCodeNotFound, fmt.Sprintf("team %d is not ranked at event %s", teamID, breakdown.Event.EventCode))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, toAdvancementBreakdownResponse(breakdown))
+}
+
+// toAdvancementBreakdownResponse converts a team's advancement breakdown to its response
+func toAdvancementBreakdownResponse(breakdown *query.AdvancementBreakdown) *AdvancementBreakdownResponse {
+	ta := breakdown.Advancement
+	awards := make([]JudgedAwardPointsResponse, 0, len(breakdown.Judging.Awards))
+	for _, award := range breakdown.Judging.Awards {
+		awards = append(awards, JudgedAwardPointsResponse{
+			Name:    award.Name,
+			Place:   award.Place,
+			Points:  award.Points,
+			Counted: award.Counted,
+		})
+	}
+	alliance := breakdown.Playoff.Alliance
+	if alliance == nil {
+		alliance = []int{}
+	}
+
+	return &AdvancementBreakdownResponse{
+		Event:               toEventResponse(breakdown.Event),
+		TeamID:              ta.Team.TeamID,
+		TeamName:            ta.Team.Name,
+		Rank:                ta.Rank,
+		TotalPoints:         ta.TotalPoints,
+		QualificationPoints: ta.QualificationPoints,
+		JudgingPoints:       ta.JudgingPoints,
+		PlayoffPoints:       ta.PlayoffPoints,
+		SelectionPoints:     ta.SelectionPoints,
+		Advances:            ta.Advances,
+		AdvancementNumber:   ta.AdvancementNumber,
+		Status:              ta.Status,
+		InspireSlot:         ta.InspireSlot,
+		Inputs: AdvancementInputsResponse{
+			Qualification: QualificationInputsResponse{
+				Position: breakdown.Qualification.Position,
+				Teams:    breakdown.Qualification.Teams,
+				Alpha:    breakdown.Qualification.Alpha,
+				Formula:  "ceil(erfinv((N - 2R + 2) / (alpha * N)) * 7 / erfinv(1 / alpha) + 9)",
+			},
+			Judging: JudgingInputsResponse{
+				Awards:         awards,
+				InspireOnly:    breakdown.Judging.InspireOnly,
+				BestJudgedOnly: breakdown.Judging.BestJudgedOnly,
+			},
+			Playoff: PlayoffInputsResponse{
+				Place:    breakdown.Playoff.Place,
+				Finish:   breakdown.Playoff.Finish,
+				Alliance: alliance,
+			},
+			Selection: SelectionInputsResponse{
+				Alliance: breakdown.Selection.Alliance,
+				Formula:  "21 - alliance",
+			},
+		},
+	}
+}
+
 // handleEventMatches handles requests for the matches of a specific event. It expects the event code to be provided in the URL path and supports an optional 'team' query parameter to filter matches by a specific team. It also supports a 'limit' query parameter to limit the number of matches returned. It returns the event details along with the list of matches (with alliance details if team filter is not applied) in JSON format.
-func (s *Server) handleEventMatches(w http.ResponseWriter, r *http.Request, year int, eventCode string) {
+func (s *Server) handleEventMatches(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
 	limit, err := s.parseLimit(r)
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeParameterError(w, r, "limit", err.Error())
 		return
 	}
 
@@ -685,12 +1221,12 @@ func (s *Server) handleEventMatches(w http.ResponseWriter, r *http.Request, year
 	if teamIDStr != "" {
 		teamID, err := strconv.Atoi(teamIDStr)
 		if err != nil {
-			s.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid team parameter: %s", teamIDStr))
+			s.writeParameterError(w, r, "team", fmt.Sprintf("invalid team parameter: %s", teamIDStr))
 			return
 		}
 		matchList, err := query.MatchesByEventAndTeamQuery(eventCode, teamID, year)
 		if err != nil {
-			s.writeError(w, http.StatusInternalServerError, err.Error())
+			s.writeServerError(w, r, err)
 			return
 		}
 		if len(matchList) > 0 {
@@ -708,7 +1244,7 @@ func (s *Server) handleEventMatches(w http.ResponseWriter, r *http.Request, year
 	} else {
 		matchList, err := query.MatchesByEventQuery(eventCode, year)
 		if err != nil {
-			s.writeError(w, http.StatusInternalServerError, err.Error())
+			s.writeServerError(w, r, err)
 			return
 		}
 		if len(matchList) > 0 {
@@ -720,13 +1256,13 @@ func (s *Server) handleEventMatches(w http.ResponseWriter, r *http.Request, year
 		// Convert to MatchWithAlliancesResponse list
 		convertedMatches := make([]*MatchWithAlliancesResponse, 0, len(matchList))
 		for _, m := range matchList {
-			convertedMatches = append(convertedMatches, toMatchWithAlliancesResponse(m.Match, m.RedAlliance, m.BlueAlliance))
+			convertedMatches = append(convertedMatches, toMatchWithAlliancesResponse(m.Event, m.Match, m.RedAlliance, m.BlueAlliance))
 		}
 		matches = convertedMatches
 	}
 
 	if event == nil {
-		s.writeError(w, http.StatusNotFound, "event not found")
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
 		return
 	}
 
@@ -737,51 +1273,233 @@ func (s *Server) handleEventMatches(w http.ResponseWriter, r *http.Request, year
 		},
 	}
 
-	s.writeJSON(w, http.StatusOK, response)
+	s.writeFieldsJSON(w, r, http.StatusOK, response)
 }
 
-// handleTeamRankings handles requests for the overall team rankings for a specific season. It supports optional query parameters for region, country, and event code to filter the rankings. It also supports a 'limit' query parameter to limit the number of rankings returned. It returns a list of team performances in JSON format.
-func (s *Server) handleTeamRankings(w http.ResponseWriter, r *http.Request, year int, parts []string) {
+// handleEventSummary handles requests for the summary of a specific event. It expects the event code to be provided in the URL path and returns the event's match counts, scores, and performance metrics in JSON format.
+func (s *Server) handleEventSummary(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	summary, err := query.EventSummaryQuery(eventCode, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if summary == nil {
+		s.writeError(w, r, http.StatusNotFound, ErrCodeNotFound, "event summary not found")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, toEventSummaryResponse(summary))
+}
+
+// handleEventSummaries handles requests for the summaries of all events in a season. It supports an optional 'region' query parameter to filter the events by region and a 'limit' query parameter to limit the number of events returned. Events are ordered from the highest average NpOPR to the lowest.
+func (s *Server) handleEventSummaries(w http.ResponseWriter, r *http.Request, year int) {
 	limit, err := s.parseLimit(r)
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeParameterError(w, r, "limit", err.Error())
 		return
 	}
 
-	region := r.URL.Query().Get("region")
-	country := r.URL.Query().Get("country")
-	eventCode := r.URL.Query().Get("event")
+	var regionCodes []string
+	if region := r.URL.Query().Get("region"); region != "" {
+		regionCode, ok := s.resolveRegion(w, r, region)
+		if !ok {
+			return
+		}
+		regionCodes = append(regionCodes, regionCode)
+	}
 
-	performances, err := query.TeamRankingsQuery(region, country, eventCode, year)
+	summaries, err := query.EventSummariesQuery(year, regionCodes...)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
 
-	if limit > 0 && limit < len(performances) {
-		performances = performances[:limit]
+	responses := make([]*EventSummaryResponse, 0, len(summaries))
+	for _, summary := range summaries {
+		responses = append(responses, toEventSummaryResponse(summary))
+	}
+	if limit > 0 && limit < len(responses) {
+		responses = responses[:limit]
 	}
 
-	s.writeJSON(w, http.StatusOK, performances)
+	s.writeJSON(w, http.StatusOK, responses)
 }
 
-// handleTeamEventRankings handles requests for the team rankings at specific events for a specific season. It supports optional query parameters for region, country, and event code to filter the rankings. It also supports a 'limit' query parameter to limit the number of rankings returned. It returns a list of team performances at events in JSON format.
-func (s *Server) handleTeamEventRankings(w http.ResponseWriter, r *http.Request, year int, parts []string) {
+// handleTeamRankings handles requests for the overall team rankings for a specific season. It supports optional query parameters for region, country, and event code to filter the rankings. It also supports 'sort' and 'order' query parameters to sort the rankings by one or more fields, 'limit' and 'offset' query parameters to return a page of the sorted rankings, an 'as_of' query parameter to return the rankings from a dated snapshot, and a 'since' query parameter to include each team's rank movement since a previous snapshot, an 'include_unofficial' query parameter to include unofficial events, a 'visitors' query parameter to include the teams from other regions that competed at the region's events, and a 'group_by' query parameter to group the teams by country. It returns a list of team performances in JSON format, or a list of countries with the performances of their teams if they are grouped.
+func (s *Server) handleTeamRankings(w http.ResponseWriter, r *http.Request, year int) {
 	limit, err := s.parseLimit(r)
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	offset, err := s.parseOffset(r)
+	if err != nil {
+		s.writeParameterError(w, r, "offset", err.Error())
+		return
+	}
+
+	sortKeys, ok := s.parseSort(w, r)
+	if !ok {
+		return
+	}
+
+	includeUnofficial, err := s.parseIncludeUnofficial(r)
+	if err != nil {
+		s.writeParameterError(w, r, "include_unofficial", err.Error())
+		return
+	}
+	includeVisitors, err := s.parseBool(r, "visitors")
+	if err != nil {
+		s.writeParameterError(w, r, "visitors", err.Error())
+		return
+	}
+	groupBy, err := s.parseGroupBy(r)
+	if err != nil {
+		s.writeParameterError(w, r, "group_by", err.Error())
 		return
 	}
 
 	region := r.URL.Query().Get("region")
-	country := r.URL.Query().Get("country")
-	eventCode := r.URL.Query().Get("event")
+	if region != "" {
+		regionCode, ok := s.resolveRegion(w, r, region)
+		if !ok {
+			return
+		}
+		region = regionCode
+	}
+	country, err := s.parseCountry(r)
+	if err != nil {
+		s.writeParameterError(w, r, "country", err.Error())
+		return
+	}
+	eventCodes := s.parseEventCodes(r)
+	asOfStr := r.URL.Query().Get("as_of")
+	sinceStr := r.URL.Query().Get("since")
+
+	var performances []query.TeamPerformance
+	if asOfStr != "" {
+		asOf, err := time.Parse(database.SnapshotDateFormat, asOfStr)
+		if err != nil {
+			s.writeParameterError(w, r, "as_of", "invalid as_of date, expected YYYY-MM-DD")
+			return
+		}
+		performances, err = query.TeamRankingsAsOfQuery(region, country, eventCodes, year, asOf, includeUnofficial, includeVisitors)
+		if err != nil {
+			s.writeServerError(w, r, err)
+			return
+		}
+	} else {
+		performances, err = query.TeamRankingsQuery(region, country, eventCodes, year, includeUnofficial, includeVisitors)
+		if err != nil {
+			s.writeServerError(w, r, err)
+			return
+		}
+	}
+
+	if len(sortKeys) > 0 {
+		query.SortTeamPerformances(performances, sortKeys)
+	}
+
+	if sinceStr != "" {
+		since, err := time.Parse(database.SnapshotDateFormat, sinceStr)
+		if err != nil {
+			s.writeParameterError(w, r, "since", "invalid since date, expected YYYY-MM-DD")
+			return
+		}
+		previous, err := query.TeamRankingsAsOfQuery(region, country, eventCodes, year, since, includeUnofficial, includeVisitors)
+		if err != nil {
+			s.writeServerError(w, r, err)
+			return
+		}
+		if len(sortKeys) > 0 {
+			query.SortTeamPerformances(previous, sortKeys)
+		}
+		movement := query.RankMovement(performances, previous)
+
+		// The rankings are calculated from each team's events and sorted by the calculated metrics, so they are paged once sorted
+		performances = page(performances, limit, offset)
+
+		responses := make([]PerformanceMovementResponse, 0, len(performances))
+		for i, perf := range performances {
+			rank := offset + i + 1
+			response := PerformanceMovementResponse{
+				PerformanceResponse: toPerformanceResponse(perf),
+				Rank:                rank,
+			}
+			if places, ok := movement[perf.TeamID]; ok {
+				previousRank := rank + places
+				response.PreviousRank = &previousRank
+				response.Movement = &places
+			}
+			responses = append(responses, response)
+		}
+		if groupBy == "country" {
+			s.writeFieldsJSON(w, r, http.StatusOK, query.GroupByCountry(responses, func(p PerformanceMovementResponse) string { return p.Country }))
+			return
+		}
+		s.writeFieldsJSON(w, r, http.StatusOK, responses)
+		return
+	}
+
+	performances = page(performances, limit, offset)
+
+	if groupBy == "country" {
+		s.writeFieldsJSON(w, r, http.StatusOK, query.GroupByCountry(performances, func(p query.TeamPerformance) string { return p.Country }))
+		return
+	}
+	s.writeFieldsJSON(w, r, http.StatusOK, performances)
+}
+
+// handleTeamEventRankings handles requests for the team rankings at specific events for a specific season. It supports optional query parameters for region, country, and event code to filter the rankings. It also supports 'sort' and 'order' query parameters to sort the rankings by one or more fields and 'limit' and 'offset' query parameters to return a page of the sorted rankings, and an 'include_unofficial' query parameter to include unofficial events. It returns a list of team performances at events in JSON format.
+func (s *Server) handleTeamEventRankings(w http.ResponseWriter, r *http.Request, year int) {
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	offset, err := s.parseOffset(r)
+	if err != nil {
+		s.writeParameterError(w, r, "offset", err.Error())
+		return
+	}
+
+	sortKeys, ok := s.parseSort(w, r)
+	if !ok {
+		return
+	}
+
+	includeUnofficial, err := s.parseIncludeUnofficial(r)
+	if err != nil {
+		s.writeParameterError(w, r, "include_unofficial", err.Error())
+		return
+	}
+
+	region := r.URL.Query().Get("region")
+	if region != "" {
+		regionCode, ok := s.resolveRegion(w, r, region)
+		if !ok {
+			return
+		}
+		region = regionCode
+	}
+	country, err := s.parseCountry(r)
+	if err != nil {
+		s.writeParameterError(w, r, "country", err.Error())
+		return
+	}
+	eventCodes := s.parseEventCodes(r)
 
-	performances, err := query.TeamEventRankingsQuery(region, country, eventCode, year)
+	performances, err := query.TeamEventRankingsQuery(region, country, eventCodes, year, includeUnofficial)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
+	if len(sortKeys) > 0 {
+		query.SortTeamEventPerformances(performances, sortKeys)
+	}
+	performances = page(performances, limit, offset)
 
 	// Convert to EventPerformanceResponse (without event_id, with year)
 	responses := make([]EventPerformanceResponse, 0, len(performances))
@@ -799,10 +1517,37 @@ func (s *Server) handleTeamEventRankings(w http.ResponseWriter, r *http.Request,
 			DPR:       p.DPR,
 			NpDPR:     p.NpDPR,
 			NpAVG:     p.NpAVG,
+			AutoOPR:   p.AutoOPR,
 			Matches:   p.Matches,
 		})
 	}
 
+	s.writeFieldsJSON(w, r, http.StatusOK, responses)
+}
+
+// handleRegionList handles requests for the list of regions. It supports a 'limit' query parameter to limit the number of regions returned. It returns each region code along with the number of events in the region for the season and the number of teams in the region in JSON format.
+func (s *Server) handleRegionList(w http.ResponseWriter, r *http.Request, year int) {
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+
+	regions, err := query.RegionsQuery(year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+
+	responses := make([]RegionResponse, 0, len(regions))
+	for _, region := range regions {
+		responses = append(responses, RegionResponse{
+			RegionCode: region.RegionCode,
+			Aliases:    region.Aliases,
+			EventCount: region.EventCount,
+			TeamCount:  region.TeamCount,
+		})
+	}
 	if limit > 0 && limit < len(responses) {
 		responses = responses[:limit]
 	}
@@ -810,65 +1555,213 @@ func (s *Server) handleTeamEventRankings(w http.ResponseWriter, r *http.Request,
 	s.writeJSON(w, http.StatusOK, responses)
 }
 
-// handleRegions handles requests for region-specific resources. It expects the region code to be provided in the URL path and delegates to specific handlers for different region resources such as advancement based on the second part of the URL path.
-func (s *Server) handleRegions(w http.ResponseWriter, r *http.Request, year int, parts []string) {
-	if len(parts) < 1 {
-		s.writeError(w, http.StatusBadRequest, "region code is required")
+// resolveRegion returns the region code for a region given as either a region code or an alias, such as "North Carolina" for USNC, and true if it is one of the known regions. If it is not, it writes an error response that suggests the closest region codes and returns false. Region codes and aliases are matched without regard to case.
+func (s *Server) resolveRegion(w http.ResponseWriter, r *http.Request, region string) (string, bool) {
+	regionCode, err := query.ResolveRegion(region)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return "", false
+	}
+	regionCodes, err := query.RegionCodesQuery()
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return "", false
+	}
+	if !slices.Contains(regionCodes, regionCode) {
+		s.writeNotFound(w, r, query.RegionNotFound(region))
+		return "", false
+	}
+	return regionCode, true
+}
+
+// handleRegionTeams handles requests for the teams in a specific region. It expects the region code to be provided in the URL path and supports 'limit' and 'offset' query parameters to return a page of the teams. It returns the list of teams whose home region is the region, ordered by team number, in JSON format.
+func (s *Server) handleRegionTeams(w http.ResponseWriter, r *http.Request, year int) {
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	offset, err := s.parseOffset(r)
+	if err != nil {
+		s.writeParameterError(w, r, "offset", err.Error())
+		return
+	}
+	regionCode, ok := s.resolveRegion(w, r, r.PathValue("regionCode"))
+	if !ok {
+		return
+	}
+
+	teams, err := query.TeamsQuery(database.TeamFilter{HomeRegions: []string{regionCode}, Limit: limit, Offset: offset})
+	if err != nil {
+		s.writeServerError(w, r, err)
 		return
 	}
 
-	regionCode := parts[0]
+	s.writeFieldsJSON(w, r, http.StatusOK, teams)
+}
 
-	if len(parts) < 2 {
-		s.writeError(w, http.StatusBadRequest, "region resource type is required")
+// handleRegionEvents handles requests for the events in a specific region. It expects the region code to be provided in the URL path and supports 'limit' and 'offset' query parameters to return a page of the events. It returns the list of events in the region for the season, sorted by start date, in JSON format.
+func (s *Server) handleRegionEvents(w http.ResponseWriter, r *http.Request, year int) {
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	offset, err := s.parseOffset(r)
+	if err != nil {
+		s.writeParameterError(w, r, "offset", err.Error())
+		return
+	}
+	regionCode, ok := s.resolveRegion(w, r, r.PathValue("regionCode"))
+	if !ok {
 		return
 	}
 
-	resource := parts[1]
+	events, err := query.RegionEventsQuery(regionCode, year, limit, offset)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
 
-	switch resource {
-	case "advancement":
-		s.handleRegionAdvancement(w, r, year, regionCode)
-	default:
-		s.writeError(w, http.StatusNotFound, fmt.Sprintf("unknown region resource: %s", resource))
+	responses := make([]*EventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, toEventResponse(event))
 	}
+
+	s.writeJSON(w, http.StatusOK, responses)
 }
 
-// handleRegionAdvancement handles requests for the advancement summary of a specific region and season. It expects the region code to be provided in the URL path and returns the advancement summary for that region and season in JSON format.
-func (s *Server) handleRegionAdvancement(w http.ResponseWriter, r *http.Request, year int, regionCode string) {
-	advancement, err := query.RegionAdvancementQuery(regionCode, year)
+// handleRegionAdvancement handles requests for the advancement summary of a specific region and season. It expects the region code to be provided in the URL path, and supports an 'exclude_visitors' query parameter to leave out the teams from other regions that advanced from the region's events. It returns the advancement summary for that region and season in JSON format.
+func (s *Server) handleRegionAdvancement(w http.ResponseWriter, r *http.Request, year int) {
+	excludeVisitors, err := s.parseBool(r, "exclude_visitors")
+	if err != nil {
+		s.writeParameterError(w, r, "exclude_visitors", err.Error())
+		return
+	}
+	regionCode, ok := s.resolveRegion(w, r, r.PathValue("regionCode"))
+	if !ok {
+		return
+	}
+
+	advancement, err := query.RegionAdvancementQuery(regionCode, year, excludeVisitors)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
 	s.writeJSON(w, http.StatusOK, advancement)
 }
 
 // handleAllAdvancement handles requests for the advancement summary of all regions for a specific season. It supports an optional 'region' query parameter to filter the summary by a specific region. It returns the advancement summary for the specified region (or all regions if no region is specified) and season in JSON format.
-func (s *Server) handleAllAdvancement(w http.ResponseWriter, r *http.Request, year int, parts []string) {
+func (s *Server) handleAllAdvancement(w http.ResponseWriter, r *http.Request, year int) {
 	region := r.URL.Query().Get("region")
-	if region == "" {
+	if region == "" || strings.EqualFold(region, "ALL") {
 		region = "ALL"
+	} else {
+		regionCode, ok := s.resolveRegion(w, r, region)
+		if !ok {
+			return
+		}
+		region = regionCode
 	}
 	advancement, err := query.EventAdvancementSummaryQuery(region, year)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err)
 		return
 	}
 	response := toEventAdvancementSummaryResponse(advancement)
 	s.writeJSON(w, http.StatusOK, response)
 }
 
-// writeJSON is a helper function to write a JSON response with the given status code and data. It sets the appropriate content type header and encodes the data as JSON. If encoding fails, it logs an error.
+// handleChanges handles requests for the records that were created or changed since a point in time. It requires a 'since' query parameter in RFC 3339 format, and returns the changed records grouped by type so downstream mirrors can sync incrementally.
+func (s *Server) handleChanges(w http.ResponseWriter, r *http.Request, year int) {
+	sinceStr := r.URL.Query().Get("since")
+	if sinceStr == "" {
+		s.writeParameterError(w, r, "since", "since is required")
+		return
+	}
+	since, err := time.Parse(time.RFC3339, sinceStr)
+	if err != nil {
+		s.writeParameterError(w, r, "since", fmt.Sprintf("invalid since: %s, expected RFC 3339 timestamp", sinceStr))
+		return
+	}
+
+	// Capture the time before gathering the changes so nothing saved while the changes are gathered is missed
+	until := time.Now().UTC()
+	changes, err := s.db.GetChanges(since)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, ChangesResponse{
+		ChangeSet: changes,
+		Until:     until,
+		Count:     changes.Count(),
+	})
+}
+
+// defaultSyncRunLimit is the number of sync runs listed by the sync status endpoint when no limit is given.
+const defaultSyncRunLimit = 10
+
+// handleSyncStatus handles requests for the recent runs that synced the season's data, so operators can see when the data was last refreshed and whether the runs ran into errors.
+func (s *Server) handleSyncStatus(w http.ResponseWriter, r *http.Request, year int) {
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	if limit == 0 {
+		limit = defaultSyncRunLimit
+	}
+
+	season := strconv.Itoa(year)
+	runs, err := s.db.GetSyncRuns(database.SyncRunFilter{Season: season, Limit: limit})
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	completed, err := s.db.GetSyncRuns(database.SyncRunFilter{Season: season, Status: database.SyncRunCompleted, Limit: 1})
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+
+	response := SyncStatusResponse{Season: year, Runs: runs}
+	if len(runs) > 0 {
+		response.LastRun = runs[0]
+	}
+	if len(completed) > 0 {
+		response.LastCompleted = completed[0]
+	}
+	s.writeJSON(w, http.StatusOK, response)
+}
+
+// writeJSON is a helper function to write a JSON response with the given status code and data. It sets the appropriate content type header and encodes the data as JSON, writing lists one element at a time through a buffer so large responses are streamed to the client. If the X-Data-As-Of header has been set, a successful response that is a JSON object carries the time as its data_as_of field. If the private team fields can't be hidden, a 500 Internal Server Error is written instead; if encoding fails otherwise, it logs an error.
 func (s *Server) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	if dataAsOf := w.Header().Get(dataAsOfHeader); dataAsOf != "" && status < http.StatusBadRequest {
+		withAsOf, err := addDataAsOf(data, dataAsOf)
+		if err != nil {
+			s.logger.Error("failed to encode JSON response", "error", err)
+		} else {
+			data = withAsOf
+		}
+	}
+	if pw, ok := w.(*privateResponseWriter); ok && status < http.StatusBadRequest {
+		filtered, err := pw.privacy.apply(data)
+		if err != nil {
+			// The unfiltered data must not be sent, as it has the private fields
+			s.writeServerError(w, pw.request, err)
+			return
+		}
+		data = filtered
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
+	sw := newStreamWriter(w)
+	if err := encodeJSON(sw, data); err != nil {
 		s.logger.Error("failed to encode JSON response", "error", err)
 	}
-}
-
-// writeError is a helper function to write an error response in JSON format with the given status code and error message. It uses the writeJSON helper to send a standardized error response.
-func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
-	s.writeJSON(w, status, map[string]string{"error": message})
+	if err := sw.Flush(); err != nil {
+		s.logger.Error("failed to write JSON response", "error", err)
+	}
 }
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+func TestParseSort(t *testing.T) {
+	s := NewServer(nil)
+	tests := []struct {
+		query     string
+		keys      []query.SortKey
+		parameter string // The parameter named in the error response, if the sort can't be parsed
+	}{
+		{query: "", keys: nil},
+		{query: "sort=opr,-matches", keys: []query.SortKey{{Field: "opr", Descending: true}, {Field: "matches", Descending: true}}},
+		{query: "sort=DPR&order=Desc", keys: []query.SortKey{{Field: "dpr", Descending: true}}},
+		{query: "order=ASC", keys: []query.SortKey{{Field: "npavg", Descending: false}}},
+		{query: "sort=opr&order=sideways", parameter: "order"},
+		{query: "sort=height&order=DESC", parameter: "sort"},
+		{query: "sort=height&order=sideways", parameter: "order"},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/v1/2025/team-rankings?"+test.query, nil)
+		rec := httptest.NewRecorder()
+		keys, ok := s.parseSort(rec, req)
+		if test.parameter == "" {
+			if !ok || !slices.Equal(keys, test.keys) {
+				t.Errorf("parseSort(%q) = %v, %t, want %v, true", test.query, keys, ok, test.keys)
+			}
+			continue
+		}
+
+		var response ErrorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("parseSort(%q) wrote an invalid error response: %v", test.query, err)
+		}
+		if ok || rec.Code != http.StatusBadRequest || response.Error.Details["parameter"] != test.parameter {
+			t.Errorf("parseSort(%q) = %t with status %d naming %q, want false with status %d naming %q",
+				test.query, ok, rec.Code, response.Error.Details["parameter"], http.StatusBadRequest, test.parameter)
+		}
+	}
+}
+
+func TestSeasonRulesNotFound(t *testing.T) {
+	s := NewServer(nil)
+	tests := []struct {
+		url    string
+		status int
+	}{
+		{"/v1/2025/rules", http.StatusOK},
+		{"/v1/1999/rules", http.StatusNotFound},
+		{"/v1/2025/rules?teams=0", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.url, nil))
+		if rec.Code != test.status {
+			t.Errorf("GET %s: status = %d, want %d:\n%s", test.url, rec.Code, test.status, rec.Body)
+		}
+	}
+
+	// Only a code that isn't known is reported as not found; other errors are server errors
+	req := httptest.NewRequest(http.MethodGet, "/v1/2025/rules", nil)
+	rec := httptest.NewRecorder()
+	s.writeNotFound(rec, req, errors.New("the rules couldn't be read"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("writeNotFound for an error other than a NotFoundError: status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
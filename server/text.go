@@ -0,0 +1,243 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/terminal"
+)
+
+// ansiEscape matches the escape sequences the terminal renderers use to color their output.
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// textSortOrders are the values of the 'sort' query parameter of the plain text team rankings, which sort the same way as the --sort flag of ftc team-rankings.
+var textSortOrders = []terminal.SortBy{
+	terminal.SortByOPR,
+	terminal.SortByNpOPR,
+	terminal.SortByCCWM,
+	terminal.SortByDPR,
+	terminal.SortByNpDPR,
+	terminal.SortByNpAVG,
+	terminal.SortByAutoOPR,
+	terminal.SortByAutoPct,
+	terminal.SortByMatches,
+	terminal.SortByTeamID,
+}
+
+// handleEventRankingsText handles requests for an event's rankings as the plain text table printed by ftc rankings.
+func (s *Server) handleEventRankingsText(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	rankings, err := query.EventTeamRankingQuery(eventCode, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if rankings == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+
+	s.writeText(w, http.StatusOK, terminal.RenderTeamRankings(rankings))
+}
+
+// handleEventAwardsText handles requests for the awards given at an event as the plain text table printed by ftc awards.
+func (s *Server) handleEventAwardsText(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	awards, err := query.AwardsByEventQuery(eventCode, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if awards == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+
+	s.writeText(w, http.StatusOK, terminal.RenderAwardsByEvent(awards))
+}
+
+// handleEventAdvancementText handles requests for an event's advancement report as the plain text table printed by ftc advancement.
+func (s *Server) handleEventAdvancementText(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	advancement, err := query.AdvancementReportQuery(eventCode, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if advancement == nil || advancement.Event == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+
+	s.writeText(w, http.StatusOK, terminal.RenderAdvancementReport(advancement))
+}
+
+// handleEventMatchesText handles requests for an event's matches as the plain text table printed by ftc matches. It supports a 'team' query parameter to show only the matches of a single team.
+func (s *Server) handleEventMatchesText(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	teamIDStr := r.URL.Query().Get("team")
+	if teamIDStr != "" {
+		teamID, err := strconv.Atoi(teamIDStr)
+		if err != nil {
+			s.writeParameterError(w, r, "team", fmt.Sprintf("invalid team parameter: %s", teamIDStr))
+			return
+		}
+		matchList, err := query.MatchesByEventAndTeamQuery(eventCode, teamID, year)
+		if err != nil {
+			s.writeServerError(w, r, err)
+			return
+		}
+		if len(matchList) == 0 {
+			s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+			return
+		}
+		s.writeText(w, http.StatusOK, terminal.RenderMatchesByEventAndTeam(matchList))
+		return
+	}
+
+	matchList, err := query.MatchesByEventQuery(eventCode, year)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if len(matchList) == 0 {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+	s.writeText(w, http.StatusOK, terminal.RenderMatchDetails(matchList))
+}
+
+// handleEventQueueText handles requests for the next matches to be played at an event as the plain text table printed by ftc queue. It supports a 'limit' query parameter for the number of matches, which defaults to 5.
+func (s *Server) handleEventQueueText(w http.ResponseWriter, r *http.Request, year int) {
+	eventCode := r.PathValue("eventCode")
+
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+	if limit == 0 {
+		limit = defaultQueueSize
+	}
+
+	report, err := query.QueueQuery(eventCode, year, limit)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+	if report == nil {
+		s.writeNotFound(w, r, query.EventNotFound(eventCode, year))
+		return
+	}
+
+	s.writeText(w, http.StatusOK, terminal.RenderQueue(report))
+}
+
+// handleRegionAdvancementText handles requests for the teams advancing in a region as the plain text report printed by ftc region-advancement. It supports the 'exclude_visitors' query parameter of the JSON region advancement.
+func (s *Server) handleRegionAdvancementText(w http.ResponseWriter, r *http.Request, year int) {
+	excludeVisitors, err := s.parseBool(r, "exclude_visitors")
+	if err != nil {
+		s.writeParameterError(w, r, "exclude_visitors", err.Error())
+		return
+	}
+	regionCode, ok := s.resolveRegion(w, r, r.PathValue("regionCode"))
+	if !ok {
+		return
+	}
+
+	advancement, err := query.RegionAdvancementQuery(regionCode, year, excludeVisitors)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+
+	s.writeText(w, http.StatusOK, terminal.RenderRegionAdvancementReport(advancement))
+}
+
+// handleTeamRankingsText handles requests for the team rankings as the plain text table printed by ftc team-rankings. It supports the 'region', 'country', 'event', 'limit', 'include_unofficial', 'visitors', and 'group_by' query parameters of the JSON team rankings, with the same private team fields hidden, and a 'sort' query parameter that takes a single metric, the same as the --sort flag of ftc team-rankings.
+func (s *Server) handleTeamRankingsText(w http.ResponseWriter, r *http.Request, year int) {
+	limit, err := s.parseLimit(r)
+	if err != nil {
+		s.writeParameterError(w, r, "limit", err.Error())
+		return
+	}
+
+	includeUnofficial, err := s.parseIncludeUnofficial(r)
+	if err != nil {
+		s.writeParameterError(w, r, "include_unofficial", err.Error())
+		return
+	}
+	includeVisitors, err := s.parseBool(r, "visitors")
+	if err != nil {
+		s.writeParameterError(w, r, "visitors", err.Error())
+		return
+	}
+	groupBy, err := s.parseGroupBy(r)
+	if err != nil {
+		s.writeParameterError(w, r, "group_by", err.Error())
+		return
+	}
+
+	sortBy := terminal.SortByOPR
+	if value := r.URL.Query().Get("sort"); value != "" {
+		sortBy = terminal.SortBy(strings.ToLower(value))
+		if !slices.Contains(textSortOrders, sortBy) {
+			s.writeParameterError(w, r, "sort", fmt.Sprintf("invalid sort: %s", value))
+			return
+		}
+	}
+
+	region := r.URL.Query().Get("region")
+	if region != "" {
+		regionCode, ok := s.resolveRegion(w, r, region)
+		if !ok {
+			return
+		}
+		region = regionCode
+	}
+	country, err := s.parseCountry(r)
+	if err != nil {
+		s.writeParameterError(w, r, "country", err.Error())
+		return
+	}
+	eventCodes := s.parseEventCodes(r)
+	eventCode := strings.Join(database.NormalizeCodes(eventCodes), ", ")
+
+	performances, err := query.TeamRankingsQuery(region, country, eventCodes, year, includeUnofficial, includeVisitors)
+	if err != nil {
+		s.writeServerError(w, r, err)
+		return
+	}
+
+	if groupBy == "country" {
+		s.writeText(w, http.StatusOK, terminal.RenderTeamPerformanceByCountry(performances, eventCode, sortBy, region, year, limit))
+		return
+	}
+	// Rankings across regions show each team's country code, unless it is private
+	showsCountry := region == "" && !s.hidesFrom(r, "country_code")
+	s.writeText(w, http.StatusOK, terminal.RenderTeamPerformance(performances, eventCode, sortBy, region, year, limit, showsCountry))
+}
+
+// writeText writes a report rendered for the terminal as a plain text response, removing any color escape sequences so it reads the same when fetched with curl or shown on a display that doesn't support them.
+func (s *Server) writeText(w http.ResponseWriter, status int, text string) {
+	text = ansiEscape.ReplaceAllString(text, "")
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := io.WriteString(w, text); err != nil {
+		s.logger.Error("failed to write text response", "error", err)
+	}
+}
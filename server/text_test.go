@@ -0,0 +1,96 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/ftcmock"
+	"github.com/rbrabson/ftcstanding/query"
+	"github.com/rbrabson/ftcstanding/request"
+)
+
+// countryCodeCell matches a cell of the team rankings table holding the country code of the mock season's teams.
+var countryCodeCell = regexp.MustCompile(`[│|]\s*US\s*[│|]`)
+
+// newMockSeasonServer syncs the canned season of the mock FTC Events API into a file database in a temporary
+// directory, and returns a server for it.
+func newMockSeasonServer(t *testing.T) *Server {
+	t.Helper()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Setenv("DB_TYPE", "file")
+	t.Setenv("FILEDB_DATA_DIR", t.TempDir())
+	db, err := database.Init("2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	request.Init(db)
+	query.Init(db)
+
+	mock, err := ftcmock.NewServer("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	request.SetFTCServer(mock.URL, ftcmock.Username, ftcmock.AuthKey)
+	request.RequestAndSaveAll("2025", false, false)
+
+	return NewServer(db)
+}
+
+func TestTeamRankingsTextPrivacy(t *testing.T) {
+	s := newMockSeasonServer(t)
+	if err := s.SetAPIKeys([]APIKey{{Name: "scouts", Key: "secret", Role: "scout"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetPrivateTeamFields([]string{"country"}, PrivacyRedact); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(url, key string) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String()
+	}
+
+	// Without a key, the team rankings don't show any team's country
+	status, body := get("/v1/2025/team-rankings.txt", "")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d:\n%s", status, http.StatusOK, body)
+	}
+	if !regexp.MustCompile(`Gear Grinders`).MatchString(body) {
+		t.Errorf("team rankings don't contain the teams:\n%s", body)
+	}
+	if countryCodeCell.MatchString(body) || regexp.MustCompile(`(?i)\bcountry\b`).MatchString(body) {
+		t.Errorf("team rankings without a key show the teams' countries:\n%s", body)
+	}
+
+	// With a key, they do
+	status, body = get("/v1/2025/team-rankings.txt", "secret")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d:\n%s", status, http.StatusOK, body)
+	}
+	if !countryCodeCell.MatchString(body) {
+		t.Errorf("team rankings with a key don't show the teams' countries:\n%s", body)
+	}
+
+	// Grouping or filtering the teams by their country is refused without a key
+	for _, url := range []string{"/v1/2025/team-rankings.txt?group_by=country", "/v1/2025/team-rankings.txt?country=USA"} {
+		if status, body := get(url, ""); status != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d:\n%s", url, status, http.StatusBadRequest, body)
+		}
+		if status, body := get(url, "secret"); status != http.StatusOK {
+			t.Errorf("%s with a key: status = %d, want %d:\n%s", url, status, http.StatusOK, body)
+		}
+	}
+}
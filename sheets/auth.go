@@ -0,0 +1,155 @@
+package sheets
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTokenURL = "https://oauth2.googleapis.com/token"
+	sheetsScope     = "https://www.googleapis.com/auth/spreadsheets"
+)
+
+// Credentials is the key of a Google Cloud service account, as downloaded from the Google Cloud console. The
+// spreadsheets the service account uses must be shared with its email address.
+type Credentials struct {
+	Type        string `json:"type"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+
+	key *rsa.PrivateKey
+}
+
+// LoadCredentials reads the key of a service account from a JSON file.
+func LoadCredentials(path string) (*Credentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account credentials: %w", err)
+	}
+	var credentials Credentials
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if credentials.Type != "" && credentials.Type != "service_account" {
+		return nil, fmt.Errorf("%s is a %s key, not a service account key", path, credentials.Type)
+	}
+	if credentials.ClientEmail == "" {
+		return nil, fmt.Errorf("%s has no client_email", path)
+	}
+	if credentials.key, err = parsePrivateKey(credentials.PrivateKey); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if credentials.TokenURI == "" {
+		credentials.TokenURI = defaultTokenURL
+	}
+	return &credentials, nil
+}
+
+// parsePrivateKey parses the PEM encoded RSA private key of a service account.
+func parsePrivateKey(privateKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, fmt.Errorf("private_key is not a PEM encoded key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private_key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private_key is not an RSA key")
+	}
+	return key, nil
+}
+
+// token is an OAuth 2.0 access token, and the time it expires.
+type token struct {
+	accessToken string
+	expires     time.Time
+}
+
+// tokenResponse is the response of the OAuth 2.0 token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// requestToken exchanges a signed JWT assertion for an access token to the Sheets API, using the JWT bearer grant
+// of service accounts.
+func (c *Credentials) requestToken(client *http.Client, now time.Time) (*token, error) {
+	assertion, err := c.assertion(now)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	resp, err := client.PostForm(c.TokenURI, form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request access token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("access token request for %s failed with status %d", c.ClientEmail, resp.StatusCode)
+	}
+
+	var response tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode access token: %w", err)
+	}
+	if response.AccessToken == "" {
+		return nil, fmt.Errorf("access token request for %s returned no token", c.ClientEmail)
+	}
+	return &token{
+		accessToken: response.AccessToken,
+		expires:     now.Add(time.Duration(response.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// assertion returns the JWT, signed with the service account's key, that is exchanged for an access token.
+func (c *Credentials) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iss":   c.ClientEmail,
+		"scope": sheetsScope,
+		"aud":   c.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(base64.RawURLEncoding.EncodeToString(header))
+	sb.WriteString(".")
+	sb.WriteString(base64.RawURLEncoding.EncodeToString(claims))
+	digest := sha256.Sum256([]byte(sb.String()))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign access token request: %w", err)
+	}
+	sb.WriteString(".")
+	sb.WriteString(base64.RawURLEncoding.EncodeToString(signature))
+	return sb.String(), nil
+}
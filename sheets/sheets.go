@@ -0,0 +1,209 @@
+package sheets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultSheetsURL = "https://sheets.googleapis.com"
+
+// Config is the service account Google Sheets are read and written with, and the spreadsheet used if none is
+// given.
+type Config struct {
+	CredentialsFile string // JSON key of the service account
+	SpreadsheetID   string // ID of the spreadsheet, from its URL, or empty if it must be given
+	URL             string // Base URL of the Sheets API, or empty for Google's
+}
+
+// ConfigFromEnv reads the configuration from the GOOGLE_SHEETS_CREDENTIALS, GOOGLE_SHEETS_SPREADSHEET_ID, and
+// GOOGLE_SHEETS_URL environment variables, which may be set in the .env file. GOOGLE_SHEETS_CREDENTIALS is required.
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{
+		CredentialsFile: os.Getenv("GOOGLE_SHEETS_CREDENTIALS"),
+		SpreadsheetID:   os.Getenv("GOOGLE_SHEETS_SPREADSHEET_ID"),
+		URL:             os.Getenv("GOOGLE_SHEETS_URL"),
+	}
+	if config.CredentialsFile == "" {
+		return nil, fmt.Errorf("GOOGLE_SHEETS_CREDENTIALS is not set to the service account's key file")
+	}
+	return config, nil
+}
+
+// Client reads and writes Google Sheets through the Sheets API, signed in as a service account.
+type Client struct {
+	baseURL     string
+	credentials *Credentials
+	client      *http.Client
+
+	mu    sync.Mutex
+	token *token
+}
+
+// NewClient returns a client signed in with the service account in the configuration.
+func NewClient(config *Config) (*Client, error) {
+	credentials, err := LoadCredentials(config.CredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := config.URL
+	if baseURL == "" {
+		baseURL = defaultSheetsURL
+	}
+	return &Client{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		credentials: credentials,
+		client:      &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// apiError is the error returned by the Sheets API.
+type apiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// spreadsheet is the part of a spreadsheet returned by the Sheets API that is used, the titles of its sheets.
+type spreadsheet struct {
+	Sheets []sheetInfo `json:"sheets"`
+}
+
+// sheetInfo is a sheet of a spreadsheet returned by the Sheets API.
+type sheetInfo struct {
+	Properties struct {
+		Title string `json:"title"`
+	} `json:"properties"`
+}
+
+// WriteSheet replaces the contents of a sheet in the spreadsheet with the rows, adding the sheet if the spreadsheet
+// doesn't have it. The first row is normally the header. The values are written as they are, without being parsed
+// as formulas.
+func (c *Client) WriteSheet(spreadsheetID, sheet string, rows [][]any) error {
+	var existing spreadsheet
+	if err := c.do(http.MethodGet, c.spreadsheetURL(spreadsheetID, "?fields=sheets.properties.title"), nil, &existing); err != nil {
+		return err
+	}
+	found := slices.ContainsFunc(existing.Sheets, func(s sheetInfo) bool {
+		return s.Properties.Title == sheet
+	})
+	if !found {
+		addSheet := map[string]any{
+			"requests": []any{
+				map[string]any{"addSheet": map[string]any{"properties": map[string]any{"title": sheet}}},
+			},
+		}
+		if err := c.do(http.MethodPost, c.spreadsheetURL(spreadsheetID, ":batchUpdate"), addSheet, nil); err != nil {
+			return fmt.Errorf("failed to add sheet %q: %w", sheet, err)
+		}
+	}
+
+	sheetRange := quoteSheet(sheet)
+	valuesURL := c.spreadsheetURL(spreadsheetID, "/values/"+url.PathEscape(sheetRange))
+	if err := c.do(http.MethodPost, valuesURL+":clear", map[string]any{}, nil); err != nil {
+		return fmt.Errorf("failed to clear sheet %q: %w", sheet, err)
+	}
+	values := map[string]any{
+		"range":          sheetRange,
+		"majorDimension": "ROWS",
+		"values":         rows,
+	}
+	if err := c.do(http.MethodPut, valuesURL+"?valueInputOption=RAW", values, nil); err != nil {
+		return fmt.Errorf("failed to write sheet %q: %w", sheet, err)
+	}
+	return nil
+}
+
+// valueRange is the values of a range of a sheet returned by the Sheets API.
+type valueRange struct {
+	Values [][]string `json:"values"`
+}
+
+// ReadSheet returns the rows of a sheet in the spreadsheet, as the text shown in each cell. Rows don't include the
+// empty cells at their end, and the empty rows at the end of the sheet aren't returned.
+func (c *Client) ReadSheet(spreadsheetID, sheet string) ([][]string, error) {
+	valuesURL := c.spreadsheetURL(spreadsheetID, "/values/"+url.PathEscape(quoteSheet(sheet)))
+	var values valueRange
+	if err := c.do(http.MethodGet, valuesURL+"?majorDimension=ROWS&valueRenderOption=FORMATTED_VALUE", nil, &values); err != nil {
+		return nil, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+	}
+	return values.Values, nil
+}
+
+// quoteSheet returns the name of a sheet quoted for use as a range, with any quotes in the name doubled.
+func quoteSheet(sheet string) string {
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+}
+
+// spreadsheetURL returns the URL of the spreadsheet in the Sheets API, followed by the suffix.
+func (c *Client) spreadsheetURL(spreadsheetID, suffix string) string {
+	return c.baseURL + "/v4/spreadsheets/" + url.PathEscape(spreadsheetID) + suffix
+}
+
+// accessToken returns the access token requests are made with, requesting a new one once the current one is within
+// a minute of expiring.
+func (c *Client) accessToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.token == nil || now.Add(time.Minute).After(c.token.expires) {
+		token, err := c.credentials.requestToken(c.client, now)
+		if err != nil {
+			return "", err
+		}
+		c.token = token
+	}
+	return c.token.accessToken, nil
+}
+
+// do makes a request to the Sheets API, sending the body as JSON if it isn't nil and decoding the response into the
+// result if it isn't nil.
+func (c *Client) do(method, requestURL string, body any, result any) error {
+	accessToken, err := c.accessToken()
+	if err != nil {
+		return err
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, requestURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("sheets request failed with status %d: %s", resp.StatusCode, apiErr.Error.Message)
+		}
+		return fmt.Errorf("sheets request failed with status %d", resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
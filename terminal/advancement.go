@@ -9,9 +9,22 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/renderer"
 	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
 	"github.com/rbrabson/ftcstanding/query"
 )
 
+// advancementColumns are the columns of an event's advancement report.
+var advancementColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta, color.Bold}}},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}},
+	{Key: "total-pts", Header: "Total Pts", Tint: renderer.Tint{FG: renderer.Colors{color.FgCyan, color.Bold}}},
+	{Key: "judging-pts", Header: "Judging"},
+	{Key: "playoff-pts", Header: "Playoff"},
+	{Key: "selection-pts", Header: "Selection"},
+	{Key: "qual-pts", Header: "Qualification"},
+	{Key: "adv-number", Header: "Adv #"},
+}
+
 // RenderAdvancementReport renders event details and all team advancement information in a formatted table.
 func RenderAdvancementReport(report *query.AdvancementReport) string {
 	if report == nil || report.Event == nil {
@@ -25,22 +38,23 @@ func RenderAdvancementReport(report *query.AdvancementReport) string {
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Code: %s\n", report.Event.EventCode))
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Name: %s\n", report.Event.Name))
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n", report.Event.Year))
-	sb.WriteString(color.New(color.FgCyan).Sprintf("Location: %s, %s, %s\n\n",
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Location: %s, %s, %s\n",
 		report.Event.City, report.Event.StateProv, report.Event.Country))
+	if tc := report.TypicalCutoff; tc != nil {
+		sb.WriteString(color.New(color.FgCyan).Sprintf("Typical Cutoff: %d points at events with %s (range %d-%d, events: %d)\n",
+			tc.Median, tc.Label(), tc.Low, tc.High, tc.Events))
+	}
+	sb.WriteString("\n")
 
 	// Render advancement table
+	columns := newTableColumns(advancementColumns...)
 	colorCfg := renderer.ColorizedConfig{
 		Header: renderer.Tint{
 			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
 		},
 		Column: renderer.Tint{
-			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
-			Columns: []renderer.Tint{
-				{FG: renderer.Colors{color.FgMagenta, color.Bold}}, // Magenta bold for rank
-				{FG: renderer.Colors{color.FgYellow}},              // Yellow for team
-				{FG: renderer.Colors{color.FgCyan, color.Bold}},    // Cyan bold for total
-				{}, // Default for remaining columns
-			},
+			FG:      renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: columns.tints(),
 		},
 		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
 		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
@@ -55,7 +69,7 @@ func RenderAdvancementReport(report *query.AdvancementReport) string {
 			},
 		}),
 	)
-	table.Header([]string{"Rank", "Team", "Total Pts", "Judging", "Playoff", "Selection", "Qualification", "Adv #"})
+	table.Header(columns.headers())
 
 	if len(report.TeamAdvancements) == 0 {
 		sb.WriteString("\nNo teams found for this event.\n")
@@ -65,6 +79,9 @@ func RenderAdvancementReport(report *query.AdvancementReport) string {
 		for _, ta := range report.TeamAdvancements {
 			// Format team with advancement status
 			teamName := fmt.Sprintf("%5d - %s", ta.Team.TeamID, ta.Team.Name)
+			if ta.InspireSlot {
+				teamName = fmt.Sprintf("%s\n        %s", teamName, greenColor.Sprint("(Inspire slot)"))
+			}
 			var advancementNumber string
 			switch {
 			case ta.Status == "already_advancing":
@@ -79,7 +96,7 @@ func RenderAdvancementReport(report *query.AdvancementReport) string {
 
 			// Color advancing teams in green
 			if ta.Advances {
-				table.Append([]string{
+				table.Append(columns.row(
 					greenColor.Sprint(fmt.Sprintf("%d", ta.Rank)),
 					greenColor.Sprint(teamName),
 					fmt.Sprintf("%d", ta.TotalPoints),
@@ -88,9 +105,9 @@ func RenderAdvancementReport(report *query.AdvancementReport) string {
 					fmt.Sprintf("%d", ta.SelectionPoints),
 					fmt.Sprintf("%d", ta.QualificationPoints),
 					advancementNumber,
-				})
+				))
 			} else {
-				table.Append([]string{
+				table.Append(columns.row(
 					fmt.Sprintf("%d", ta.Rank),
 					teamName,
 					fmt.Sprintf("%d", ta.TotalPoints),
@@ -99,7 +116,7 @@ func RenderAdvancementReport(report *query.AdvancementReport) string {
 					fmt.Sprintf("%d", ta.SelectionPoints),
 					fmt.Sprintf("%d", ta.QualificationPoints),
 					advancementNumber,
-				})
+				))
 			}
 		}
 	}
@@ -158,12 +175,17 @@ func RenderRegionAdvancementReport(report *query.RegionAdvancementReport) string
 			},
 		}),
 	)
-	table.Header([]string{"Team", "Advancing Event", "Other Events"})
+	table.Header(translateAll([]string{"Team", "Advancing Event", "Other Events"}))
 
 	// Populate table rows
+	visiting := false
 	for _, ta := range report.TeamAdvancements {
-		// Format team
+		// Format team, marking the teams visiting from other regions
 		teamName := fmt.Sprintf("%d - %s", ta.Team.TeamID, ta.Team.Name)
+		if ta.Visiting {
+			teamName += " *"
+			visiting = true
+		}
 
 		// Format advancing event with awards
 		advancingEvent := fmt.Sprintf("• %s - %s", ta.AdvancingEvent.EventCode, ta.AdvancingEvent.Name)
@@ -208,6 +230,9 @@ func RenderRegionAdvancementReport(report *query.RegionAdvancementReport) string
 	table.Footer([]string{fmt.Sprintf("Teams Advancing: %d", len(report.TeamAdvancements)), "", ""})
 
 	table.Render()
+	if visiting {
+		sb.WriteString(color.HiYellowString("* %s\n", translate("Visiting team, whose home region is outside the region")))
+	}
 	return sb.String()
 }
 
@@ -234,7 +259,7 @@ func RenderEventAdvancementSummary(summary *query.EventAdvancementSummary) strin
 	// Render each event's qualified teams
 	for _, eventSummary := range summary.EventSummaries {
 		// Format event date (e.g., "Jan 17, 2026")
-		eventDate := eventSummary.Event.DateStart.Format("Jan 2, 2006")
+		eventDate := locale.Date(eventSummary.Event.DateStart)
 
 		// Event header
 		sb.WriteString(cyanColor.Sprintf("From %s (%s)\n", eventSummary.Event.Name, eventDate))
@@ -262,3 +287,457 @@ func RenderEventAdvancementSummary(summary *query.EventAdvancementSummary) strin
 
 	return sb.String()
 }
+
+// RenderAdvancementPath renders a team's progression through the tiers of the advancement chain, with the events
+// at each tier and whether the team advanced from them.
+func RenderAdvancementPath(path *query.AdvancementPath) string {
+	if path == nil {
+		return "No team details available\n"
+	}
+
+	var sb strings.Builder
+
+	// Team Header Information
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	sb.WriteString(color.HiGreenString("Team %d - %s: Advancement Path\n", path.TeamID, path.Name))
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+
+	if len(path.Steps) == 0 {
+		sb.WriteString(color.YellowString("No league meets, tournaments, qualifiers, or championships found for this team.\n"))
+		return sb.String()
+	}
+
+	tierColor := color.New(color.FgYellow, color.Bold)
+	advancedColor := color.New(color.FgHiGreen)
+	for i, step := range path.Steps {
+		if i > 0 {
+			sb.WriteString(color.WhiteString("    │\n    ▼\n"))
+		}
+		sb.WriteString(tierColor.Sprintf("%s\n", step.Tier))
+
+		if len(step.Events) == 0 {
+			sb.WriteString(advancedColor.Sprint("  ★ Qualified\n"))
+			continue
+		}
+		for _, event := range step.Events {
+			marker := "  •"
+			if event.Advanced {
+				marker = advancedColor.Sprint("  ✓")
+			}
+			fmt.Fprintf(&sb, "%s %s %s %s",
+				marker,
+				color.MagentaString("%-10s", event.EventCode),
+				locale.Date(event.DateStart),
+				event.EventName)
+			if event.QualRank > 0 {
+				sb.WriteString(color.CyanString(" (Rank %d)", event.QualRank))
+			}
+			if event.Advanced {
+				sb.WriteString(advancedColor.Sprint(" advanced"))
+			}
+			sb.WriteString("\n")
+			if len(event.Awards) > 0 {
+				sb.WriteString(color.YellowString("      %s\n", strings.Join(event.Awards, ", ")))
+			}
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(color.WhiteString("Highest tier: %s\n", path.HighestTier))
+	if path.QualifiedFor != query.TierNone {
+		sb.WriteString(advancedColor.Sprintf("Qualified for: %s\n", path.QualifiedFor))
+	}
+	return sb.String()
+}
+
+// RenderChampionshipProjection renders the projected field of a region's championship, marking each team as
+// locked, likely, or on the bubble, followed by the remaining events the projection is based on.
+func RenderChampionshipProjection(projection *query.ChampionshipProjection) string {
+	if projection == nil {
+		return "No region data available\n"
+	}
+
+	var sb strings.Builder
+
+	// Render header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Championship Projection\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Region: %s\n", projection.RegionCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n", projection.Year))
+	if projection.Championship != nil {
+		sb.WriteString(color.New(color.FgCyan).Sprintf("Championship: %s - %s (%s)\n",
+			projection.Championship.EventCode, projection.Championship.Name, locale.Date(projection.Championship.DateStart)))
+	}
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Completed Events: %d\n", projection.CompletedEvents))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Remaining Events: %d\n", len(projection.RemainingEvents)))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Slots per Remaining Event: %d\n", projection.SlotsPerEvent))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Projected Field: %d\n\n", projection.FieldSize()))
+
+	if len(projection.RemainingEvents) > 0 {
+		sb.WriteString(color.YellowString("Remaining Events:\n"))
+		for _, re := range projection.RemainingEvents {
+			line := fmt.Sprintf("  • %s - %s (%s): %d registered, %d slots",
+				re.Event.EventCode, re.Event.Name, locale.Date(re.Event.DateStart), re.Registered, re.Slots)
+			if re.OpenSlots > 0 {
+				line += fmt.Sprintf(", %d open", re.OpenSlots)
+			}
+			sb.WriteString(color.WhiteString("%s\n", line))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(projection.Teams) == 0 {
+		sb.WriteString("No teams have advanced or are registered for a remaining event.\n")
+		return sb.String()
+	}
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan},
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgHiGreen}},               // Status
+				{FG: renderer.Colors{color.FgHiMagenta, color.Bold}}, // Team
+				{FG: renderer.Colors{color.FgHiWhite}},               // npOPR
+				{FG: renderer.Colors{color.FgCyan}},                  // Event
+			},
+		},
+		Footer:    renderer.Tint{FG: renderer.Colors{color.FgYellow, color.Bold}},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
+					tw.AlignLeft,  // Status
+					tw.AlignLeft,  // Team
+					tw.AlignRight, // npOPR
+					tw.AlignLeft,  // Event
+				}},
+			},
+			Footer: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Status", "Team", "npOPR", "Event"}))
+
+	for _, pt := range projection.Teams {
+		table.Append([]string{
+			projectionStatusLabel(pt.Status),
+			fmt.Sprintf("%d - %s", pt.Team.TeamID, pt.Team.Name),
+			locale.Number("%.2f", pt.NpOPR),
+			pt.Event.EventCode,
+		})
+	}
+
+	table.Footer([]string{fmt.Sprintf("Projected Field: %d", projection.FieldSize()), "", "", ""})
+
+	table.Render()
+	return sb.String()
+}
+
+// projectionStatusLabel returns the label shown for a projection status.
+func projectionStatusLabel(status query.ProjectionStatus) string {
+	switch status {
+	case query.ProjectionLocked:
+		return "🔒 Locked"
+	case query.ProjectionLikely:
+		return "✓ Likely"
+	case query.ProjectionBubble:
+		return "? Bubble"
+	}
+	return string(status)
+}
+
+// RenderWhatIf renders the finishes a team needs at each remaining event to reach the advancement cutoff. Each
+// event has a table with a row for each playoff finish and a column for each judged award, giving the lowest
+// qualification rank that still advances.
+func RenderWhatIf(report *query.WhatIfReport) string {
+	if report == nil || report.Team == nil {
+		return "No team details available\n"
+	}
+
+	var sb strings.Builder
+
+	// Render header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("What It Takes to Advance: %d - %s\n", report.Team.TeamID, report.Team.Name))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Region: %s\n", report.RegionCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n", report.Year))
+
+	if report.AdvancedFrom != nil {
+		sb.WriteString(color.New(color.FgHiGreen).Sprintf("\nTeam %d has already advanced from %s - %s.\n",
+			report.Team.TeamID, report.AdvancedFrom.EventCode, report.AdvancedFrom.Name))
+		return sb.String()
+	}
+
+	if len(report.Cutoffs) > 0 {
+		sb.WriteString(color.YellowString("\nCutoffs at Completed Events:\n"))
+		for _, c := range report.Cutoffs {
+			sb.WriteString(color.WhiteString("  • %s - %s (%d teams): %d points\n", c.Event.EventCode, c.Event.Name, c.Teams, c.Cutoff))
+		}
+	}
+	if report.Cutoff <= 0 {
+		sb.WriteString("\nNo teams have advanced from an event in this region yet, so the points needed to advance are unknown.\n")
+		return sb.String()
+	}
+	sb.WriteString(color.New(color.FgCyan).Sprintf("\nPoints Needed: %d\n\n", report.Cutoff))
+
+	if len(report.Events) == 0 {
+		sb.WriteString("No remaining league tournaments or qualifiers in this region.\n")
+		return sb.String()
+	}
+
+	for _, event := range report.Events {
+		sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("%s - %s (%s)\n",
+			event.Event.EventCode, event.Event.Name, locale.Date(event.Event.DateStart)))
+		if !event.Registered {
+			sb.WriteString(color.MagentaString("Team %d isn't registered for this event.\n", report.Team.TeamID))
+		}
+		if event.MaxRank == nil {
+			sb.WriteString("No teams have registered for this event yet.\n\n")
+			continue
+		}
+		sb.WriteString(color.WhiteString("%d teams, %d alliances. Each cell is the lowest qualification rank that advances.\n", event.Teams, event.Alliances))
+
+		colorCfg := renderer.ColorizedConfig{
+			Header: renderer.Tint{
+				FG: renderer.Colors{color.FgGreen, color.Bold},
+			},
+			Column: renderer.Tint{
+				FG: renderer.Colors{color.FgCyan},
+				Columns: []renderer.Tint{
+					{FG: renderer.Colors{color.FgHiMagenta, color.Bold}}, // Playoff finish
+				},
+			},
+			Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+			Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		}
+
+		table := tablewriter.NewTable(&sb,
+			tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+			tablewriter.WithConfig(tablewriter.Config{
+				Header: tw.CellConfig{
+					// Keep award names such as "Judged 3rd" as they are
+					Formatting: tw.CellFormatting{AutoFormat: tw.Off},
+					Alignment:  tw.CellAlignment{Global: tw.AlignCenter},
+				},
+				Row: tw.CellConfig{
+					Alignment: tw.CellAlignment{Global: tw.AlignCenter},
+				},
+			}),
+		)
+
+		header := []string{"Playoffs"}
+		for _, judged := range query.JudgedFinishes {
+			header = append(header, judged.Name)
+		}
+		table.Header(translateAll(header))
+
+		for i, playoff := range query.PlayoffFinishes {
+			row := []string{playoff.Name}
+			for _, maxRank := range event.MaxRank[i] {
+				switch {
+				case maxRank == 0:
+					row = append(row, "-")
+				case maxRank >= event.Teams:
+					row = append(row, "Any")
+				default:
+					row = append(row, "Top "+strconv.Itoa(maxRank))
+				}
+			}
+			table.Append(row)
+		}
+
+		table.Render()
+		if event.Points != nil {
+			sb.WriteString(color.WhiteString("Qualification points by rank: %s\n", qualificationPointsByRank(event.Points)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// RenderCutoffAnalysis renders the typical advancement cutoff for each range of event sizes, followed by the cutoff
+// at each completed event.
+func RenderCutoffAnalysis(analysis *query.CutoffAnalysis) string {
+	if analysis == nil {
+		return "No cutoff data available\n"
+	}
+
+	var sb strings.Builder
+
+	// Render header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Advancement Cutoffs\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n", analysis.Year))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Completed Events: %d\n\n", len(analysis.Cutoffs)))
+
+	if len(analysis.Cutoffs) == 0 {
+		sb.WriteString("No advancement cutoffs have been saved for this season.\n")
+		return sb.String()
+	}
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan},
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgHiMagenta, color.Bold}}, // Event size
+				{FG: renderer.Colors{color.FgHiWhite}},               // Events
+				{FG: renderer.Colors{color.FgHiGreen, color.Bold}},   // Median
+				{FG: renderer.Colors{color.FgCyan}},                  // Low
+				{FG: renderer.Colors{color.FgCyan}},                  // High
+			},
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
+					tw.AlignLeft,  // Event size
+					tw.AlignRight, // Events
+					tw.AlignRight, // Median
+					tw.AlignRight, // Low
+					tw.AlignRight, // High
+				}},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Event Size", "Events", "Median", "Low", "High"}))
+	for _, size := range analysis.Sizes {
+		table.Append([]string{
+			size.Label(),
+			strconv.Itoa(size.Events),
+			strconv.Itoa(size.Median),
+			strconv.Itoa(size.Low),
+			strconv.Itoa(size.High),
+		})
+	}
+	table.Render()
+	sb.WriteString("\n")
+
+	eventCfg := colorCfg
+	eventCfg.Column.Columns = []renderer.Tint{
+		{FG: renderer.Colors{color.FgYellow}},              // Event
+		{FG: renderer.Colors{color.FgCyan}},                // Date
+		{FG: renderer.Colors{color.FgHiWhite}},             // Teams
+		{FG: renderer.Colors{color.FgHiWhite}},             // Advancing
+		{FG: renderer.Colors{color.FgHiGreen, color.Bold}}, // Cutoff
+	}
+	eventTable := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(eventCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
+					tw.AlignLeft,  // Event
+					tw.AlignLeft,  // Date
+					tw.AlignRight, // Teams
+					tw.AlignRight, // Advancing
+					tw.AlignRight, // Cutoff
+				}},
+			},
+		}),
+	)
+	eventTable.Header(translateAll([]string{"Event", "Date", "Teams", "Advancing", "Cutoff"}))
+	for _, c := range analysis.Cutoffs {
+		eventTable.Append([]string{
+			fmt.Sprintf("%s - %s", c.Event.EventCode, c.Event.Name),
+			locale.Date(c.Event.DateStart),
+			strconv.Itoa(c.Teams),
+			strconv.Itoa(c.Advancing),
+			strconv.Itoa(c.Cutoff),
+		})
+	}
+	eventTable.Render()
+
+	return sb.String()
+}
+
+// RenderQualificationPoints renders the qualification points earned at each rank at an event of a given size,
+// along with the selection points of a team selected for an alliance and the total of the two.
+func RenderQualificationPoints(table *query.QualificationPointsTable) string {
+	if table == nil || len(table.Ranks) == 0 {
+		return "No qualification points available\n"
+	}
+
+	var sb strings.Builder
+
+	// Render header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Qualification Points\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Teams: %d\n", table.Teams))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Alliances: %d\n\n", table.Alliances))
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan},
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgHiMagenta, color.Bold}}, // Rank
+				{FG: renderer.Colors{color.FgHiWhite}},               // Qualification
+				{FG: renderer.Colors{color.FgCyan}},                  // Selection
+				{FG: renderer.Colors{color.FgHiGreen, color.Bold}},   // Total
+			},
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	pointsTable := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
+					tw.AlignRight, // Rank
+					tw.AlignRight, // Qualification
+					tw.AlignRight, // Selection
+					tw.AlignRight, // Total
+				}},
+			},
+		}),
+	)
+	pointsTable.Header(translateAll([]string{"Rank", "Qualification", "Selection", "Total"}))
+	for _, r := range table.Ranks {
+		pointsTable.Append([]string{
+			strconv.Itoa(r.Rank),
+			strconv.Itoa(r.Points),
+			strconv.Itoa(r.Selection),
+			strconv.Itoa(r.Points + r.Selection),
+		})
+	}
+	pointsTable.Render()
+	sb.WriteString(color.WhiteString("\nSelection points assume the top %d teams captain the alliance of the same number, and a lower-ranked team is picked by the last alliance.\n", table.Alliances))
+
+	return sb.String()
+}
+
+// qualificationPointsByRank lists the qualification points earned at each rank, grouping the ranks that earn the
+// same points, such as "1: 16, 2-3: 15".
+func qualificationPointsByRank(table *query.QualificationPointsTable) string {
+	var groups []string
+	for i := 0; i < len(table.Ranks); {
+		j := i
+		for j+1 < len(table.Ranks) && table.Ranks[j+1].Points == table.Ranks[i].Points {
+			j++
+		}
+		ranks := strconv.Itoa(table.Ranks[i].Rank)
+		if j > i {
+			ranks += "-" + strconv.Itoa(table.Ranks[j].Rank)
+		}
+		groups = append(groups, fmt.Sprintf("%s: %d", ranks, table.Ranks[i].Points))
+		i = j + 1
+	}
+	return strings.Join(groups, ", ")
+}
@@ -0,0 +1,92 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderAutoLeaderboard renders the teams ranked by the share of their non-penalty scoring that comes from the
+// autonomous period. If limit is greater than 0, only the top 'limit' teams are displayed.
+func RenderAutoLeaderboard(performances []query.TeamPerformance, region string, eventCode string, year int, limit int) string {
+	if len(performances) == 0 {
+		return color.YellowString("No performance data available for region %s in year %d\n", region, year)
+	}
+	if limit > 0 && limit < len(performances) {
+		performances = performances[:limit]
+	}
+
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	if eventCode != "" {
+		sb.WriteString(color.HiGreenString("Autonomous Leaderboard - %s (%d) - Event: %s\n", region, year, eventCode))
+	} else {
+		sb.WriteString(color.HiGreenString("Autonomous Leaderboard - %s (%d)\n", region, year))
+	}
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	sb.WriteString(color.New(color.FgWhite).Sprint("Auto OPR: a team's contribution to its alliance's autonomous points\n"))
+	sb.WriteString(color.New(color.FgWhite).Sprint("Auto %: the share of the team's npOPR scored in the autonomous period\n\n"))
+
+	columns := newTableColumns(autoLeaderboardColumns...)
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
+			},
+		}),
+	)
+
+	table.Header(columns.headers())
+
+	for i, perf := range performances {
+		table.Append(columns.row(
+			strconv.Itoa(i+1),
+			fmt.Sprintf("%5d - %s", perf.TeamID, perf.TeamName),
+			perf.Region,
+			strconv.Itoa(perf.Matches),
+			locale.Number("%.2f", perf.AutoOPR),
+			locale.Number("%.2f", perf.NpOPR),
+			locale.Number("%.1f%%", perf.AutoShare()),
+		))
+	}
+
+	table.Render()
+	return sb.String()
+}
+
+// autoLeaderboardColumns are the columns of the teams ranked by their share of scoring in the autonomous period.
+var autoLeaderboardColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "region", Header: "Region", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "matches", Header: "Matches", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiRed}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "autoopr", Header: "Auto OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npopr", Header: "npOPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "autopct", Header: "Auto %", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue, color.Bold}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
@@ -58,7 +59,7 @@ func RenderAwardsByEvent(eventAwards *query.EventAwards) string {
 			},
 		}),
 	)
-	table.Header([]string{"Award Name", "Winner"})
+	table.Header(translateAll([]string{"Award Name", "Winner"}))
 
 	if len(eventAwards.Awards) == 0 {
 		sb.WriteString("\nNo awards found for this event.\n")
@@ -80,6 +81,147 @@ func RenderAwardsByEvent(eventAwards *query.EventAwards) string {
 		})
 	}
 
+	table.Render()
+
+	if len(eventAwards.PersonAwards) > 0 {
+		sb.WriteString("\n")
+		renderPersonAwards(&sb, eventAwards.PersonAwards)
+	}
+	return sb.String()
+}
+
+// renderPersonAwards renders the awards given to a person at an event, such as the Dean's List awards, in a table
+// of their own, with the name of the person each was given to and the team they are from.
+func renderPersonAwards(sb *strings.Builder, personAwards []*query.TeamAward) {
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Individual Awards\n"))
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 0 (Award Name)
+				{FG: renderer.Colors{color.FgHiWhite}}, // High-intensity white for column 1 (Recipient)
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for column 2 (Team)
+			},
+		},
+		Footer: renderer.Tint{
+			FG: renderer.Colors{color.FgYellow, color.Bold}, // Yellow bold footer
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.Off}},
+	}
+
+	table := tablewriter.NewTable(sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Footer: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Award Name", "Recipient", "Team"}))
+
+	for _, personAward := range personAwards {
+		// The data source doesn't always name the person an award was given to
+		recipient := personAward.Award.Person
+		if recipient == "" {
+			recipient = "–"
+		}
+		table.Append([]string{
+			personAward.Award.Name,
+			recipient,
+			fmt.Sprintf("%6d - %s", personAward.Team.TeamID, personAward.Team.Name),
+		})
+	}
+	table.Footer([]string{
+		fmt.Sprintf("Total: %d", len(personAwards)),
+		"",
+		"",
+	})
+	table.Render()
+}
+
+// awardDescriptionWidth is the widest an award's description is shown before it wraps onto another line.
+const awardDescriptionWidth = 60
+
+// RenderAwardDefinitions renders the season's awards in a table, with what each award is given for and whether it
+// carries advancement, along with the advancement points for 1st, 2nd, and 3rd place if it does.
+func RenderAwardDefinitions(definitions []*query.AwardDefinition, year int) string {
+	var sb strings.Builder
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("Season Awards (%d)\n\n", year))
+	if len(definitions) == 0 {
+		sb.WriteString("No awards found for this season.\n")
+		return sb.String()
+	}
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for column 0 (ID)
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 1 (Award Name)
+				{},                                     // Inherit default (cyan) for column 2 (Given To)
+				{FG: renderer.Colors{color.FgHiGreen}}, // High-intensity green for column 3 (Advancement)
+				{FG: renderer.Colors{color.FgHiWhite}}, // High-intensity white for column 4 (Description)
+			},
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.On}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Row: tw.CellConfig{
+				Formatting:   tw.CellFormatting{AutoWrap: tw.WrapNormal},
+				Alignment:    tw.CellAlignment{Global: tw.AlignLeft},
+				ColMaxWidths: tw.CellWidth{PerColumn: tw.Mapper[int, int]{4: awardDescriptionWidth}},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"ID", "Award Name", "Given To", "Advancement", "Description"}))
+
+	for _, definition := range definitions {
+		givenTo := translate("Team")
+		if definition.ForPerson {
+			givenTo = translate("Person")
+		}
+		table.Append([]string{
+			strconv.Itoa(definition.AwardID),
+			definition.Name,
+			givenTo,
+			formatAwardAdvancement(definition),
+			definition.Description,
+		})
+	}
+
 	table.Render()
 	return sb.String()
 }
+
+// formatAwardAdvancement describes how an award counts toward advancement, such as "60/30/15 pts" for the Inspire
+// Award.
+func formatAwardAdvancement(definition *query.AwardDefinition) string {
+	switch {
+	case definition.Advancement():
+		return fmt.Sprintf("%d/%d/%d %s", definition.Points[0], definition.Points[1], definition.Points[2], translate("pts"))
+	case definition.Class == query.PlayoffAward:
+		return translate("Playoff points")
+	default:
+		return "–"
+	}
+}
@@ -0,0 +1,160 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderBackToBack renders the change in npOPR of a region's teams between back-to-back events and between spaced
+// events, followed by the teams' back-to-back events from the largest gain to the largest loss. If limit is greater
+// than 0, only the first 'limit' back-to-back pairs are listed.
+func RenderBackToBack(result *query.BackToBack, limit int) string {
+	if result == nil || len(result.Pairs) == 0 {
+		region, year := "", 0
+		if result != nil {
+			region, year = result.RegionCode, result.Year
+		}
+		return color.YellowString("No teams in region %s played more than one event in year %d\n", region, year)
+	}
+
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	sb.WriteString(color.HiGreenString("Back-to-Back Events - %s (%d)\n", result.RegionCode, result.Year))
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	sb.WriteString(color.WhiteString("Back-to-Back: the team's next event started within %d days of its previous one\n", result.Days))
+	sb.WriteString(color.WhiteString("Spaced: the team's next event started more than %d days later\n", result.Days))
+	sb.WriteString(color.WhiteString("npOPR Δ: change in the team's npOPR from the first event to the second\n\n"))
+
+	summary := newTableColumns(backToBackSummaryColumns...)
+	table := newBackToBackTable(&sb, summary)
+	table.Header(summary.headers())
+	for _, group := range []struct {
+		name  string
+		pairs query.EventPairGroup
+	}{
+		{"Back-to-Back", result.BackToBack},
+		{"Spaced", result.Spaced},
+	} {
+		avgDays, avgDelta, medianDelta, improved := "-", "-", "-", "-"
+		if group.pairs.Pairs > 0 {
+			avgDays = locale.Number("%.1f", group.pairs.AverageDays)
+			avgDelta = locale.Number("%+.2f", group.pairs.AverageDelta)
+			medianDelta = locale.Number("%+.2f", group.pairs.MedianDelta)
+			improved = fmt.Sprintf("%d (%.0f%%)", group.pairs.Improved, group.pairs.ImprovedPercent())
+		}
+		table.Append(summary.row(
+			group.name,
+			strconv.Itoa(group.pairs.Pairs),
+			strconv.Itoa(group.pairs.Teams),
+			avgDays,
+			avgDelta,
+			medianDelta,
+			improved,
+		))
+	}
+	table.Render()
+
+	if result.BackToBack.Pairs > 0 && result.Spaced.Pairs > 0 {
+		difference := result.Difference()
+		switch {
+		case difference > 0:
+			sb.WriteString(color.YellowString("Teams gained %s more npOPR on average when their events were spaced out.\n", locale.Number("%.2f", difference)))
+		case difference < 0:
+			sb.WriteString(color.YellowString("Teams gained %s more npOPR on average when their events were back-to-back.\n", locale.Number("%.2f", -difference)))
+		default:
+			sb.WriteString(color.YellowString("Teams' npOPR changed the same on average whether their events were back-to-back or spaced out.\n"))
+		}
+	}
+
+	if result.BackToBack.Pairs == 0 {
+		sb.WriteString(color.YellowString("\nNo teams played events on consecutive weekends.\n"))
+		return sb.String()
+	}
+
+	sb.WriteString(color.HiGreenString("\nBack-to-Back Events\n"))
+	pairs := newTableColumns(backToBackPairColumns...)
+	table = newBackToBackTable(&sb, pairs)
+	table.Header(pairs.headers())
+	shown := 0
+	for _, pair := range result.Pairs {
+		if !pair.BackToBack {
+			continue
+		}
+		if limit > 0 && shown == limit {
+			break
+		}
+		shown++
+		table.Append(pairs.row(
+			fmt.Sprintf("%5d - %s", pair.TeamID, pair.TeamName),
+			pair.First.EventCode,
+			pair.Second.EventCode,
+			strconv.Itoa(pair.Days),
+			locale.Number("%.2f", pair.FirstNpOPR),
+			locale.Number("%.2f", pair.NpOPR),
+			locale.Number("%+.2f", pair.Delta),
+		))
+	}
+	table.Render()
+
+	return sb.String()
+}
+
+// newBackToBackTable returns a table for the back-to-back report with the given columns.
+func newBackToBackTable(sb *strings.Builder, columns *tableColumns) *tablewriter.Table {
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	return tablewriter.NewTable(sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
+			},
+		}),
+	)
+}
+
+// backToBackSummaryColumns are the columns comparing back-to-back events with spaced events.
+var backToBackSummaryColumns = []column{
+	{Key: "spacing", Header: "Spacing", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "pairs", Header: "Pairs", Tint: renderer.Tint{FG: renderer.Colors{color.FgCyan}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "teams", Header: "Teams", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "avgdays", Header: "Avg Days", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "avgdelta", Header: "Avg npOPR Δ", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen, color.Bold}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "mediandelta", Header: "Median npOPR Δ", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "improved", Header: "Improved", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
+
+// backToBackPairColumns are the columns of the teams' back-to-back events.
+var backToBackPairColumns = []column{
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "firstevent", Header: "First Event", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "secondevent", Header: "Second Event", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "days", Header: "Days", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "firstnpopr", Header: "First npOPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "secondnpopr", Header: "Second npOPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npoprdelta", Header: "npOPR Δ", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta, color.Bold}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
@@ -0,0 +1,173 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// column is a column of a rendered table. Its key identifies the column in the column configuration, and the same
+// key is used for the same value in every table, so renaming "npavg" renames the npAVG column wherever it is shown.
+type column struct {
+	Key         string
+	Header      string        // English header, translated into the selected language unless the column is relabeled
+	Tint        renderer.Tint // Color of the column's cells, or the table's default color if empty
+	HeaderAlign tw.Align
+	Align       tw.Align
+}
+
+// tableColumns are the columns of a table, along with which of them are shown under the column configuration.
+type tableColumns struct {
+	columns []column
+	shown   []int // Indexes of the shown columns
+}
+
+// newTableColumns returns the columns of a table, leaving out the columns hidden by the column configuration.
+func newTableColumns(columns ...column) *tableColumns {
+	tc := &tableColumns{columns: columns}
+	for i, c := range columns {
+		if !slices.Contains(columnConfig.Hidden, c.Key) {
+			tc.shown = append(tc.shown, i)
+		}
+	}
+	return tc
+}
+
+// headers returns the headers of the shown columns, using the configured label of a column if it has one.
+func (tc *tableColumns) headers() []string {
+	headers := make([]string, 0, len(tc.shown))
+	for _, i := range tc.shown {
+		c := tc.columns[i]
+		if label, ok := columnConfig.Labels[c.Key]; ok {
+			headers = append(headers, label)
+		} else {
+			headers = append(headers, translate(c.Header))
+		}
+	}
+	return headers
+}
+
+// tints returns the colors of the shown columns.
+func (tc *tableColumns) tints() []renderer.Tint {
+	tints := make([]renderer.Tint, 0, len(tc.shown))
+	for _, i := range tc.shown {
+		tints = append(tints, tc.columns[i].Tint)
+	}
+	return tints
+}
+
+// headerAlignments returns the header alignments of the shown columns.
+func (tc *tableColumns) headerAlignments() []tw.Align {
+	alignments := make([]tw.Align, 0, len(tc.shown))
+	for _, i := range tc.shown {
+		alignments = append(alignments, tc.columns[i].HeaderAlign)
+	}
+	return alignments
+}
+
+// alignments returns the row alignments of the shown columns.
+func (tc *tableColumns) alignments() []tw.Align {
+	alignments := make([]tw.Align, 0, len(tc.shown))
+	for _, i := range tc.shown {
+		alignments = append(alignments, tc.columns[i].Align)
+	}
+	return alignments
+}
+
+// row returns the cells of the shown columns from a row with a cell for every column.
+func (tc *tableColumns) row(cells ...string) []string {
+	row := make([]string, 0, len(tc.shown))
+	for _, i := range tc.shown {
+		row = append(row, cells[i])
+	}
+	return row
+}
+
+// ColumnConfig renames and hides the columns of the ranking, performance, and advancement tables. Columns are
+// identified by keys, such as "npavg" or "high-score", which are listed by ColumnKeys.
+type ColumnConfig struct {
+	Labels map[string]string `json:"labels,omitempty"` // Header of a column by key, such as "npavg": "True Avg"
+	Hidden []string          `json:"hidden,omitempty"` // Keys of the columns that aren't shown
+}
+
+// columnConfig is the column configuration used when rendering tables.
+var columnConfig ColumnConfig
+
+// SetColumnConfig sets the column configuration used when rendering tables.
+func SetColumnConfig(config *ColumnConfig) {
+	if config == nil {
+		config = &ColumnConfig{}
+	}
+	columnConfig = *config
+}
+
+// columnSpecs are the columns of every table that can be configured.
+var columnSpecs = [][]column{
+	eventRankingColumns,
+	{divisionColumn},
+	seasonComparisonColumns,
+	advancementColumns,
+	teamPerformanceColumns,
+	{performanceDivisionColumn, performanceCountryColumn, moveColumn, wpaColumn},
+	autoColumns,
+	teamEventPerformanceColumns,
+	teamEventsColumns,
+	teamEventComparisonColumns,
+	markdownRankingColumns,
+	markdownPerformanceColumns,
+	kioskRankingColumns,
+	kioskLeaderboardColumns,
+	formulaRankingColumns,
+	formulaMetricColumns,
+	autoLeaderboardColumns,
+	foulImpactColumns,
+	rerankColumns,
+}
+
+// ColumnKeys returns the keys of the columns that can be renamed or hidden, in sorted order.
+func ColumnKeys() []string {
+	keys := make(map[string]bool)
+	for _, spec := range columnSpecs {
+		for _, c := range spec {
+			keys[c.Key] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// LoadColumnConfig reads a column configuration from a JSON file and checks that every column it names exists.
+func LoadColumnConfig(path string) (*ColumnConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config ColumnConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	keys := ColumnKeys()
+	check := func(key string) error {
+		if !slices.Contains(keys, key) {
+			return fmt.Errorf("%s: unknown column %q; columns are %s", path, key, strings.Join(keys, ", "))
+		}
+		return nil
+	}
+	for key := range config.Labels {
+		if err := check(key); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range config.Hidden {
+		if err := check(key); err != nil {
+			return nil, err
+		}
+	}
+	return &config, nil
+}
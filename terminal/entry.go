@@ -0,0 +1,252 @@
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/request"
+)
+
+// matchEntry reads the answers to the prompts of an interactive match entry session.
+type matchEntry struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+// EnterMatches runs an interactive session for entering the results of an event's matches, such as those of a
+// league that only posts its scores on paper. The teams, scores, autonomous points, and foul points of each match
+// are prompted for, and each match is validated as it is entered. The entered matches can be listed, edited, and
+// deleted before they are saved. The matches are returned along with whether they should be saved; they should not
+// be saved if the session was quit or the input ended before the matches were saved.
+func EnterMatches(in io.Reader, out io.Writer, event *database.Event) ([]*request.ManualMatch, bool) {
+	e := &matchEntry{scanner: bufio.NewScanner(in), out: out}
+
+	fmt.Fprintln(out, color.New(color.FgGreen, color.Bold).Sprintf("Entering matches for %s - %s", event.EventCode, event.Name))
+	fmt.Fprintln(out, "Commands: a (add a match), e N (edit match N), d N (delete match N), l (list), s (save), q (quit)")
+
+	var matches []*request.ManualMatch
+	for {
+		command, ok := e.prompt("Command", "a")
+		if !ok {
+			return matches, false
+		}
+		fields := strings.Fields(command)
+		index := -1
+		if len(fields) > 1 {
+			if n, err := strconv.Atoi(fields[1]); err == nil && n >= 1 && n <= len(matches) {
+				index = n - 1
+			}
+		}
+
+		switch strings.ToLower(fields[0]) {
+		case "a", "add":
+			match, ok := e.enterMatch(nextMatch(matches))
+			if !ok {
+				return matches, false
+			}
+			if match != nil {
+				matches = append(matches, match)
+				fmt.Fprintf(out, "  added %d: %s\n", len(matches), describeManualMatch(match))
+			}
+		case "e", "edit":
+			if index < 0 {
+				fmt.Fprintf(out, "  enter the number of the match to edit, from 1 to %d\n", len(matches))
+				continue
+			}
+			match, ok := e.enterMatch(matches[index])
+			if !ok {
+				return matches, false
+			}
+			if match != nil {
+				matches[index] = match
+				fmt.Fprintf(out, "  updated %d: %s\n", index+1, describeManualMatch(match))
+			}
+		case "d", "delete":
+			if index < 0 {
+				fmt.Fprintf(out, "  enter the number of the match to delete, from 1 to %d\n", len(matches))
+				continue
+			}
+			fmt.Fprintf(out, "  deleted %d: %s\n", index+1, describeManualMatch(matches[index]))
+			matches = append(matches[:index], matches[index+1:]...)
+		case "l", "list":
+			fmt.Fprint(out, RenderManualMatches(matches))
+		case "s", "save":
+			return matches, true
+		case "q", "quit":
+			return matches, false
+		default:
+			fmt.Fprintf(out, "  unknown command %q\n", command)
+		}
+	}
+}
+
+// RenderManualMatches renders manually entered matches in a table, numbered in the order they were entered.
+func RenderManualMatches(matches []*request.ManualMatch) string {
+	if len(matches) == 0 {
+		return "No matches entered\n"
+	}
+
+	var sb strings.Builder
+	table := tablewriter.NewTable(&sb)
+	table.Header(translateAll([]string{"#", "Level", "Match", "Red Teams", "Blue Teams", "Red Score", "Blue Score", "Red Auto", "Blue Auto", "Red Fouls", "Blue Fouls"}))
+	for i, match := range matches {
+		table.Append([]string{
+			strconv.Itoa(i + 1),
+			match.TournamentLevel,
+			strconv.Itoa(match.MatchNumber),
+			joinTeams(match.RedTeams),
+			joinTeams(match.BlueTeams),
+			strconv.Itoa(match.RedScore),
+			strconv.Itoa(match.BlueScore),
+			strconv.Itoa(match.RedAuto),
+			strconv.Itoa(match.BlueAuto),
+			strconv.Itoa(match.RedFoulPoints),
+			strconv.Itoa(match.BlueFoulPoints),
+		})
+	}
+	table.Render()
+	return sb.String()
+}
+
+// enterMatch prompts for the results of a match, using the values of the given match as the defaults. It returns
+// nil if the entered match is invalid, and false if the input ended.
+func (e *matchEntry) enterMatch(defaults *request.ManualMatch) (*request.ManualMatch, bool) {
+	var level string
+	for {
+		value, ok := e.prompt("Level (Q/P)", defaults.TournamentLevel[:1])
+		if !ok {
+			return nil, false
+		}
+		var err error
+		if level, err = request.ParseTournamentLevel(value); err == nil {
+			break
+		}
+		fmt.Fprintf(e.out, "  %v\n", err)
+	}
+
+	match := &request.ManualMatch{TournamentLevel: level}
+	var ok bool
+	if match.MatchNumber, ok = e.promptInt("Match number", defaults.MatchNumber); !ok {
+		return nil, false
+	}
+	if match.RedTeams, ok = e.promptTeams("Red teams", defaults.RedTeams); !ok {
+		return nil, false
+	}
+	if match.BlueTeams, ok = e.promptTeams("Blue teams", defaults.BlueTeams); !ok {
+		return nil, false
+	}
+	points := []struct {
+		label        string
+		value        *int
+		defaultValue int
+	}{
+		{"Red score", &match.RedScore, defaults.RedScore},
+		{"Blue score", &match.BlueScore, defaults.BlueScore},
+		{"Red auto points", &match.RedAuto, defaults.RedAuto},
+		{"Blue auto points", &match.BlueAuto, defaults.BlueAuto},
+		{"Foul points awarded to red", &match.RedFoulPoints, defaults.RedFoulPoints},
+		{"Foul points awarded to blue", &match.BlueFoulPoints, defaults.BlueFoulPoints},
+	}
+	for _, p := range points {
+		if *p.value, ok = e.promptInt(p.label, p.defaultValue); !ok {
+			return nil, false
+		}
+	}
+
+	if err := match.Validate(); err != nil {
+		fmt.Fprintf(e.out, "  match not entered: %v\n", err)
+		return nil, true
+	}
+	return match, true
+}
+
+// prompt prompts for a value, returning the default if no value is entered, and false if the input ended.
+func (e *matchEntry) prompt(label, defaultValue string) (string, bool) {
+	if defaultValue != "" {
+		fmt.Fprintf(e.out, "%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Fprintf(e.out, "%s: ", label)
+	}
+	if !e.scanner.Scan() {
+		fmt.Fprintln(e.out)
+		return "", false
+	}
+	value := strings.TrimSpace(e.scanner.Text())
+	if value == "" {
+		value = defaultValue
+	}
+	return value, true
+}
+
+// promptInt prompts for a number until one is entered.
+func (e *matchEntry) promptInt(label string, defaultValue int) (int, bool) {
+	for {
+		value, ok := e.prompt(label, strconv.Itoa(defaultValue))
+		if !ok {
+			return 0, false
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			return n, true
+		}
+		fmt.Fprintf(e.out, "  %q is not a number\n", value)
+	}
+}
+
+// promptTeams prompts for team numbers, separated by spaces or commas, until valid team numbers are entered.
+func (e *matchEntry) promptTeams(label string, defaultTeams []int) ([]int, bool) {
+	for {
+		value, ok := e.prompt(label, joinTeams(defaultTeams))
+		if !ok {
+			return nil, false
+		}
+		teams, err := parseTeams(value)
+		if err == nil && len(teams) > 0 {
+			return teams, true
+		}
+		fmt.Fprintln(e.out, "  enter team numbers separated by spaces")
+	}
+}
+
+// nextMatch returns the defaults for the match entered after the given matches: the next match number at the
+// same tournament level as the last match.
+func nextMatch(matches []*request.ManualMatch) *request.ManualMatch {
+	if len(matches) == 0 {
+		return &request.ManualMatch{TournamentLevel: "Qualification", MatchNumber: 1}
+	}
+	last := matches[len(matches)-1]
+	return &request.ManualMatch{TournamentLevel: last.TournamentLevel, MatchNumber: last.MatchNumber + 1}
+}
+
+// describeManualMatch returns a one-line description of a manually entered match.
+func describeManualMatch(match *request.ManualMatch) string {
+	return fmt.Sprintf("%s %d: red %s %d, blue %s %d", match.TournamentLevel, match.MatchNumber,
+		joinTeams(match.RedTeams), match.RedScore, joinTeams(match.BlueTeams), match.BlueScore)
+}
+
+// joinTeams returns the team numbers separated by spaces.
+func joinTeams(teams []int) string {
+	numbers := make([]string, 0, len(teams))
+	for _, team := range teams {
+		numbers = append(numbers, strconv.Itoa(team))
+	}
+	return strings.Join(numbers, " ")
+}
+
+// parseTeams parses team numbers separated by spaces or commas.
+func parseTeams(s string) ([]int, error) {
+	var teams []int
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		team, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/renderer"
 	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/locale"
 	"github.com/rbrabson/ftcstanding/query"
 )
 
@@ -28,8 +31,8 @@ func RenderTeamsByEvent(eventTeams *query.EventTeams) string {
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Location: %s, %s, %s\n",
 		eventTeams.Event.City, eventTeams.Event.StateProv, eventTeams.Event.Country))
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Dates: %s to %s\n\n",
-		eventTeams.Event.DateStart.Format("Jan 2, 2006"),
-		eventTeams.Event.DateEnd.Format("Jan 2, 2006")))
+		locale.Date(eventTeams.Event.DateStart),
+		locale.Date(eventTeams.Event.DateEnd)))
 
 	// Render teams table
 	colorCfg := renderer.ColorizedConfig{
@@ -71,7 +74,7 @@ func RenderTeamsByEvent(eventTeams *query.EventTeams) string {
 			},
 		}),
 	)
-	table.Header([]string{"Number", "Name", "Location", "Region", "Rookie Year"})
+	table.Header(translateAll([]string{"Number", "Name", "Location", "Region", "Rookie Year"}))
 
 	if len(eventTeams.Teams) == 0 {
 		sb.WriteString("\nNo teams found for this event.\n")
@@ -102,8 +105,81 @@ func RenderTeamsByEvent(eventTeams *query.EventTeams) string {
 	return sb.String()
 }
 
+// eventRankingColumns are the columns of an event's qualification rankings.
+var eventRankingColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta, color.Bold}}, Align: tw.AlignRight},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, Align: tw.AlignLeft},
+	{Key: "rs", Header: "RS", Align: tw.AlignRight},
+	{Key: "match-pts", Header: "Match Pts", Align: tw.AlignRight},
+	{Key: "base-pts", Header: "Base Pts", Align: tw.AlignRight},
+	{Key: "auto-pts", Header: "Auto Pts", Align: tw.AlignRight},
+	{Key: "high-score", Header: "High Score", Align: tw.AlignRight},
+	{Key: "record", Header: "W–L–T", Align: tw.AlignCenter},
+	{Key: "matches", Header: "Matches", Align: tw.AlignCenter},
+}
+
+// divisionColumn is the column of an event's qualification rankings showing the division each team was ranked in,
+// shown for a multi-division event.
+var divisionColumn = column{Key: "division", Header: "Division", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, Align: tw.AlignLeft}
+
+// seasonComparisonColumns are the columns added to an event's qualification rankings to compare each team's
+// performance at the event with its performance across the season.
+var seasonComparisonColumns = []column{
+	{Key: "event-opr", Header: "Event OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, Align: tw.AlignRight},
+	{Key: "season-opr", Header: "Season OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgGreen}}, Align: tw.AlignRight},
+	{Key: "opr-delta", Header: "OPR Δ", Align: tw.AlignRight},
+	{Key: "event-npavg", Header: "Event npAVG", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, Align: tw.AlignRight},
+	{Key: "season-npavg", Header: "Season npAVG", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, Align: tw.AlignRight},
+	{Key: "npavg-delta", Header: "npAVG Δ", Align: tw.AlignRight},
+}
+
 // RenderTeamRankings renders event details and team rankings in a formatted table.
 func RenderTeamRankings(eventRankings *query.EventTeamRankings) string {
+	return renderTeamRankings(eventRankings, nil)
+}
+
+// RenderTeamRankingsVsSeason renders event details and team rankings like RenderTeamRankings, adding columns that
+// compare each team's OPR and npAVG at the event with its values across the season.
+func RenderTeamRankingsVsSeason(eventRankings *query.EventTeamRankings, comparisons []query.TeamSeasonComparison) string {
+	if comparisons == nil {
+		comparisons = []query.TeamSeasonComparison{}
+	}
+	return renderTeamRankings(eventRankings, comparisons)
+}
+
+// divisionName returns the name of a division to show in the rankings, or an empty string if there's no division.
+func divisionName(division *database.Event) string {
+	if division == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s - %s", division.EventCode, division.Name)
+}
+
+// divisionNames returns the codes of an event's divisions, separated by commas.
+func divisionNames(divisions []*database.Event) string {
+	codes := make([]string, 0, len(divisions))
+	for _, division := range divisions {
+		codes = append(codes, division.EventCode)
+	}
+	return strings.Join(codes, ", ")
+}
+
+// formatSeasonDelta formats the difference between a team's value at an event and across the season, in green if the
+// team did better at the event and in red if it did worse.
+func formatSeasonDelta(delta float64) string {
+	switch {
+	case delta >= 0.005:
+		return color.GreenString(locale.Number("%+.2f", delta))
+	case delta <= -0.005:
+		return color.RedString(locale.Number("%.2f", delta))
+	default:
+		return locale.Number("%.2f", 0)
+	}
+}
+
+// renderTeamRankings renders event details and team rankings. If comparisons is non-nil, columns comparing each
+// team's performance at the event with its performance across the season are added.
+func renderTeamRankings(eventRankings *query.EventTeamRankings, comparisons []query.TeamSeasonComparison) string {
 	if eventRankings == nil || eventRankings.Event == nil {
 		return "No event data available\n"
 	}
@@ -118,21 +194,32 @@ func RenderTeamRankings(eventRankings *query.EventTeamRankings) string {
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Location: %s, %s, %s\n",
 		eventRankings.Event.City, eventRankings.Event.StateProv, eventRankings.Event.Country))
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Dates: %s to %s\n\n",
-		eventRankings.Event.DateStart.Format("Jan 2, 2006"),
-		eventRankings.Event.DateEnd.Format("Jan 2, 2006")))
+		locale.Date(eventRankings.Event.DateStart),
+		locale.Date(eventRankings.Event.DateEnd)))
+	if len(eventRankings.Divisions) > 0 {
+		sb.WriteString(color.New(color.FgCyan).Sprintf("Divisions: %s\n\n", divisionNames(eventRankings.Divisions)))
+	}
 
 	// Render rankings table
+	spec := eventRankingColumns
+	if len(eventRankings.Divisions) > 0 {
+		spec = append([]column{divisionColumn}, spec...)
+	}
+	if comparisons != nil {
+		spec = append(slices.Clone(spec), seasonComparisonColumns...)
+	}
+	columns := newTableColumns(spec...)
+	seasonComparisons := make(map[int]query.TeamSeasonComparison, len(comparisons))
+	for _, c := range comparisons {
+		seasonComparisons[c.TeamID] = c
+	}
 	colorCfg := renderer.ColorizedConfig{
 		Header: renderer.Tint{
 			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
 		},
 		Column: renderer.Tint{
-			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
-			Columns: []renderer.Tint{
-				{FG: renderer.Colors{color.FgMagenta, color.Bold}}, // Magenta for column 0 (Rank)
-				{FG: renderer.Colors{color.FgYellow}},              // Yellow for column 1 (Team)
-				{},                                                 // Default for remaining columns
-			},
+			FG:      renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: columns.tints(),
 		},
 		Footer: renderer.Tint{
 			FG: renderer.Colors{color.FgYellow, color.Bold}, // Yellow bold footer
@@ -149,22 +236,12 @@ func RenderTeamRankings(eventRankings *query.EventTeamRankings) string {
 				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
 			},
 			Row: tw.CellConfig{
-				Merging: tw.CellMerging{Mode: tw.MergeHierarchical},
-				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
-					tw.AlignRight,
-					tw.AlignLeft,
-					tw.AlignRight,
-					tw.AlignRight,
-					tw.AlignRight,
-					tw.AlignRight,
-					tw.AlignRight,
-					tw.AlignCenter,
-					tw.AlignCenter,
-				}},
+				Merging:   tw.CellMerging{Mode: tw.MergeHierarchical},
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
 			},
 		}),
 	)
-	table.Header([]string{"Rank", "Team", "RS", "Match Pts", "Base Pts", "Auto Pts", "High Score", "W–L–T", "Matches"})
+	table.Header(columns.headers())
 
 	if len(eventRankings.TeamRankings) == 0 {
 		sb.WriteString("\nNo rankings found for this event.\n")
@@ -172,17 +249,36 @@ func RenderTeamRankings(eventRankings *query.EventTeamRankings) string {
 		for _, tr := range eventRankings.TeamRankings {
 			team := fmt.Sprintf("%5d - %s", tr.Team.TeamID, tr.Team.Name)
 			wlt := fmt.Sprintf("%d–%d–%d", tr.Ranking.Wins, tr.Ranking.Losses, tr.Ranking.Ties)
-			table.Append([]string{
+			vsSeason := []string{"", "", "", "", "", ""}
+			if c, ok := seasonComparisons[tr.Team.TeamID]; ok {
+				vsSeason = []string{
+					locale.Number("%.2f", c.EventOPR),
+					locale.Number("%.2f", c.SeasonOPR),
+					formatSeasonDelta(c.OPRDelta()),
+					locale.Number("%.2f", c.EventNpAVG),
+					locale.Number("%.2f", c.SeasonNpAVG),
+					formatSeasonDelta(c.NpAVGDelta()),
+				}
+			}
+			var cells []string
+			if len(eventRankings.Divisions) > 0 {
+				cells = append(cells, divisionName(tr.Division))
+			}
+			cells = append(cells,
 				strconv.Itoa(tr.Ranking.Rank),
 				team,
-				fmt.Sprintf("%.2f", tr.Ranking.SortOrder1),
-				fmt.Sprintf("%6.2f", tr.Ranking.SortOrder2),
-				fmt.Sprintf("%5.2f", tr.Ranking.SortOrder3),
-				fmt.Sprintf("%5.2f", tr.Ranking.SortOrder4),
+				locale.Number("%.2f", tr.Ranking.SortOrder1),
+				locale.Number("%6.2f", tr.Ranking.SortOrder2),
+				locale.Number("%5.2f", tr.Ranking.SortOrder3),
+				locale.Number("%5.2f", tr.Ranking.SortOrder4),
 				fmt.Sprintf("%3d", tr.HighMatchScore),
 				wlt,
 				strconv.Itoa(tr.Ranking.MatchesPlayed),
-			})
+			)
+			if comparisons != nil {
+				cells = append(cells, vsSeason...)
+			}
+			table.Append(columns.row(cells...))
 		}
 
 		// Add footer with team count
@@ -203,3 +299,190 @@ func RenderTeamRankings(eventRankings *query.EventTeamRankings) string {
 
 	return sb.String()
 }
+
+// RenderEvents renders a list of events in a formatted table.
+func RenderEvents(events []*database.Event) string {
+	if len(events) == 0 {
+		return "No events found\n"
+	}
+	return renderEvents(events, nil)
+}
+
+// RenderEventsNear renders a list of events along with their distance from a location in a formatted table.
+func RenderEventsNear(events []*query.EventDistance, near string, withinMiles float64) string {
+	if len(events) == 0 {
+		return fmt.Sprintf("No events found within %.0f miles of %s\n", withinMiles, near)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("Events within %.0f miles of %s\n\n", withinMiles, near))
+
+	list := make([]*database.Event, 0, len(events))
+	distances := make([]float64, 0, len(events))
+	for _, ed := range events {
+		list = append(list, ed.Event)
+		distances = append(distances, ed.Distance)
+	}
+	sb.WriteString(renderEvents(list, distances))
+	return sb.String()
+}
+
+// renderEvents renders the events table. If distances is non-nil, a distance column is included.
+func renderEvents(events []*database.Event, distances []float64) string {
+	var sb strings.Builder
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for event code
+				{},                                     // Inherit default (cyan) for event name
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for dates
+				{FG: renderer.Colors{color.FgHiRed}},   // High-intensity red for location
+				{},                                     // Inherit default (cyan) for remaining columns
+			},
+		},
+		Footer: renderer.Tint{
+			FG: renderer.Colors{color.FgYellow, color.Bold}, // Yellow bold footer
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.Off}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
+					tw.AlignLeft,
+					tw.AlignLeft,
+					tw.AlignLeft,
+					tw.AlignLeft,
+					tw.AlignLeft,
+					tw.AlignRight,
+				}},
+			},
+		}),
+	)
+
+	header := []string{"Code", "Name", "Dates", "Location", "Region"}
+	if distances != nil {
+		header = append(header, "Distance")
+	}
+	table.Header(translateAll(header))
+
+	for i, event := range events {
+		row := []string{
+			event.EventCode,
+			event.Name,
+			fmt.Sprintf("%s - %s", locale.ShortDate(event.DateStart), locale.Date(event.DateEnd)),
+			fmt.Sprintf("%s, %s, %s", event.City, event.StateProv, event.Country),
+			event.RegionCode,
+		}
+		if distances != nil {
+			row = append(row, fmt.Sprintf("%.0f mi", distances[i]))
+		}
+		table.Append(row)
+	}
+
+	footer := []string{fmt.Sprintf("Events: %d", len(events)), "", "", "", ""}
+	if distances != nil {
+		footer = append(footer, "")
+	}
+	table.Footer(footer)
+
+	table.Render()
+	return sb.String()
+}
+
+// RenderEventStats renders summary statistics for an event, followed by a table of any teams that registered but
+// did not play (no-shows) or played without being registered (walk-ons).
+func RenderEventStats(stats *query.EventStats) string {
+	if stats == nil || stats.Event == nil {
+		return "No event data available\n"
+	}
+	event := stats.Event
+	attendance := stats.Attendance
+
+	var sb strings.Builder
+
+	// Render event information header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Event Information\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Code: %s\n", event.EventCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Name: %s\n", event.Name))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n", event.Year))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Dates: %s to %s\n\n",
+		locale.Date(event.DateStart),
+		locale.Date(event.DateEnd)))
+
+	// Render the event statistics
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Event Statistics\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Registered Teams: %d\n", len(attendance.Registered)))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Teams Played:     %d\n", len(attendance.Played)))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Qual Matches:     %d\n", stats.QualMatches))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Playoff Matches:  %d\n", stats.PlayoffMatches))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("High Score:       %d\n", stats.HighScore))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Average Score:    %s\n\n", locale.Number("%.2f", stats.AverageScore)))
+
+	if stats.QualMatches+stats.PlayoffMatches == 0 {
+		sb.WriteString(color.YellowString("No matches have been played at this event.\n"))
+		return sb.String()
+	}
+	if !attendance.HasAnomalies() {
+		sb.WriteString(color.GreenString("All registered teams played and no unregistered teams played.\n"))
+		return sb.String()
+	}
+
+	// Render the attendance anomalies table
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for column 0 (Team Number)
+				{},                                     // Inherit default (cyan) for column 1 (Team Name)
+				{FG: renderer.Colors{color.FgHiRed}},   // High-intensity red for column 2 (Status)
+			},
+		},
+		Footer: renderer.Tint{
+			FG: renderer.Colors{color.FgYellow, color.Bold}, // Yellow bold footer
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Number", "Name", "Status"}))
+
+	for _, teamID := range attendance.NoShows {
+		table.Append([]string{strconv.Itoa(teamID), stats.TeamNames[teamID], "No-show"})
+	}
+	for _, teamID := range attendance.WalkOns {
+		table.Append([]string{strconv.Itoa(teamID), stats.TeamNames[teamID], "Walk-on"})
+	}
+
+	table.Footer([]string{
+		fmt.Sprintf("No-shows: %d", len(attendance.NoShows)),
+		fmt.Sprintf("Walk-ons: %d", len(attendance.WalkOns)),
+		"",
+	})
+	table.Render()
+
+	return sb.String()
+}
@@ -0,0 +1,93 @@
+package terminal
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderEventFlow renders the flow of the matches at an event for each tournament level: the matches played, the
+// average cycle time, the breaks between matches, how far the matches have slipped from the schedule, and when the
+// scheduled matches are projected to finish.
+func RenderEventFlow(flow *query.EventFlow) string {
+	if flow == nil || flow.Event == nil {
+		return "No event data available\n"
+	}
+	event := flow.Event
+
+	var sb strings.Builder
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Event Information\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Code: %s\n", event.EventCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Name: %s\n", event.Name))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n\n", event.Year))
+
+	if len(flow.Levels) == 0 {
+		sb.WriteString(color.YellowString("No matches have been played at this event.\n"))
+		return sb.String()
+	}
+
+	for _, lf := range flow.Levels {
+		cyan := color.New(color.FgCyan)
+		sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("%s Matches\n", levelName(lf.Level)))
+		if lf.Scheduled > 0 {
+			sb.WriteString(cyan.Sprintf("Matches Played:   %d of %d\n", lf.Played, lf.Scheduled))
+		} else {
+			sb.WriteString(cyan.Sprintf("Matches Played:   %d\n", lf.Played))
+		}
+		if lf.Latest == nil {
+			sb.WriteString(color.YellowString("No start times have been reported for these matches.\n\n"))
+			continue
+		}
+		sb.WriteString(cyan.Sprintf("First Match:      %s at %s\n", matchLabel(lf.First), locale.Time(lf.FirstStart)))
+		sb.WriteString(cyan.Sprintf("Latest Match:     %s at %s\n", matchLabel(lf.Latest), locale.Time(lf.LatestStart)))
+		if lf.CycleTime > 0 {
+			sb.WriteString(cyan.Sprintf("Average Cycle:    %s over %d match cycles\n", formatDuration(lf.CycleTime), lf.Cycles))
+		} else {
+			sb.WriteString(cyan.Sprint("Average Cycle:    not enough matches have been played\n"))
+		}
+		if lf.SlipKnown {
+			sb.WriteString(cyan.Sprint("Schedule:         ") + formatSlip(lf.Slip) + "\n")
+		}
+		switch {
+		case lf.Scheduled > 0 && lf.Remaining() == 0:
+			sb.WriteString(cyan.Sprint("Projected Finish: complete\n"))
+		case !lf.ProjectedFinish.IsZero():
+			sb.WriteString(cyan.Sprintf("Projected Finish: %s (%d matches remaining)\n", locale.Time(lf.ProjectedFinish), lf.Remaining()))
+		}
+
+		if len(lf.Breaks) == 0 {
+			sb.WriteString(cyan.Sprint("Breaks:           none\n\n"))
+			continue
+		}
+		sb.WriteString(cyan.Sprint("Breaks:\n"))
+		for _, b := range lf.Breaks {
+			sb.WriteString(color.New(color.FgYellow).Sprintf("  %s to %s: %s\n", matchLabel(b.After), matchLabel(b.Before), formatDuration(b.Length)))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// levelName returns the name of a tournament level, such as "Qualification" for "QUALIFICATION".
+func levelName(level string) string {
+	if level == "" {
+		return ""
+	}
+	return strings.ToUpper(level[:1]) + strings.ToLower(level[1:])
+}
+
+// formatSlip formats how far matches have slipped from the schedule, in red if they are behind and in green if they
+// are on time or ahead. Slips of less than a minute are on time.
+func formatSlip(slip time.Duration) string {
+	switch {
+	case slip >= time.Minute:
+		return color.New(color.FgHiRed).Sprintf("%s behind", formatDuration(slip))
+	case slip <= -time.Minute:
+		return color.New(color.FgHiGreen).Sprintf("%s ahead", formatDuration(-slip))
+	default:
+		return color.New(color.FgHiGreen).Sprint("on time")
+	}
+}
@@ -0,0 +1,198 @@
+package terminal
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderFormulaRankings renders the teams ranked by a formula, with the score and each metric the formula weighs.
+// If limit is greater than 0, only the top 'limit' teams are displayed.
+func RenderFormulaRankings(rankings []query.FormulaRanking, formula query.Formula, region string, eventCode string, year int, limit int) string {
+	if len(rankings) == 0 {
+		return color.YellowString("No performance data available for region %s in year %d\n", region, year)
+	}
+	if limit > 0 && limit < len(rankings) {
+		rankings = rankings[:limit]
+	}
+
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	if eventCode != "" {
+		sb.WriteString(color.HiGreenString("Team Formula Rankings - %s (%d) - Event: %s\n", region, year, eventCode))
+	} else {
+		sb.WriteString(color.HiGreenString("Team Formula Rankings - %s (%d)\n", region, year))
+	}
+	sb.WriteString(color.HiYellowString("Formula: %s\n", formula))
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n\n"))
+
+	sb.WriteString(renderFormulaTable(rankings, formula, nil))
+	return sb.String()
+}
+
+// RenderPickList renders a pick list for alliance selection at an event: the teams at the event ranked by a formula,
+// leaving out the teams already picked. The tags from the scouting notes on the teams are shown alongside their
+// metrics, and their notes are listed below the table. If limit is greater than 0, only the top 'limit' teams are
+// displayed.
+func RenderPickList(rankings []query.FormulaRanking, formula query.Formula, event *database.Event, picked []int, notes map[int]*database.TeamNote, limit int) string {
+	if event == nil {
+		return "No event data available\n"
+	}
+
+	var sb strings.Builder
+
+	// Render event information header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Pick List\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Code: %s\n", event.EventCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Name: %s\n", event.Name))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Formula: %s\n", formula))
+	if len(picked) > 0 {
+		teams := make([]string, 0, len(picked))
+		for _, teamID := range picked {
+			teams = append(teams, strconv.Itoa(teamID))
+		}
+		sb.WriteString(color.New(color.FgCyan).Sprintf("Already Picked: %s\n", strings.Join(teams, ", ")))
+	}
+	sb.WriteString("\n")
+
+	available := slices.DeleteFunc(slices.Clone(rankings), func(r query.FormulaRanking) bool {
+		return slices.Contains(picked, r.TeamID)
+	})
+	if len(available) == 0 {
+		sb.WriteString("No teams left to pick.\n")
+		return sb.String()
+	}
+	if limit > 0 && limit < len(available) {
+		available = available[:limit]
+	}
+
+	sb.WriteString(renderFormulaTable(available, formula, notes))
+
+	// List the scouting notes on the teams shown, in the order they are ranked
+	var scouted []string
+	for _, r := range available {
+		if note, ok := notes[r.TeamID]; ok && note.Notes != "" {
+			scouted = append(scouted, fmt.Sprintf("  %-6d %s\n", r.TeamID, note.Notes))
+		}
+	}
+	if len(scouted) > 0 {
+		sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("\nScouting Notes\n"))
+		for _, line := range scouted {
+			sb.WriteString(line)
+		}
+	}
+	return sb.String()
+}
+
+// renderFormulaTable renders the table of teams ranked by a formula. If any of the teams have scouting notes, their
+// tags are shown in the last column.
+func renderFormulaTable(rankings []query.FormulaRanking, formula query.Formula, notes map[int]*database.TeamNote) string {
+	spec := slices.Clone(formulaRankingColumns)
+	metrics := formula.Metrics()
+	for _, metric := range metrics {
+		i := slices.IndexFunc(formulaMetricColumns, func(c column) bool { return c.Key == metric })
+		spec = append(spec, formulaMetricColumns[i])
+	}
+	showTags := slices.ContainsFunc(rankings, func(r query.FormulaRanking) bool {
+		_, ok := notes[r.TeamID]
+		return ok
+	})
+	if showTags {
+		spec = append(spec, tagsColumn)
+	}
+	columns := newTableColumns(spec...)
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	var sb strings.Builder
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
+			},
+		}),
+	)
+
+	table.Header(columns.headers())
+
+	for i, r := range rankings {
+		row := []string{
+			strconv.Itoa(i + 1),
+			strconv.Itoa(r.TeamID),
+			r.TeamName,
+			r.Region,
+			locale.Number("%.2f", r.Score),
+		}
+		for _, metric := range metrics {
+			if metric == "matches" {
+				row = append(row, strconv.Itoa(r.Matches))
+			} else {
+				row = append(row, locale.Number("%.2f", r.Value(metric)))
+			}
+		}
+		if showTags {
+			var tags []string
+			if note, ok := notes[r.TeamID]; ok {
+				tags = note.Tags
+			}
+			row = append(row, strings.Join(tags, ", "))
+		}
+		table.Append(columns.row(row...))
+	}
+
+	table.Render()
+	return sb.String()
+}
+
+// formulaRankingColumns are the columns of the teams ranked by a formula, which are followed by a column for each
+// metric the formula weighs.
+var formulaRankingColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "name", Header: "Name", Tint: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "region", Header: "Region", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "score", Header: "Score", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen, color.Bold}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
+
+// tagsColumn is the column of the tags from the scouting notes on the teams ranked by a formula.
+var tagsColumn = column{Key: "tags", Header: "Tags", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft}
+
+// formulaMetricColumns are the columns of the metrics a formula can weigh, keyed by the metric.
+var formulaMetricColumns = []column{
+	{Key: "opr", Header: "OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npopr", Header: "npOPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "ccwm", Header: "CCWM", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "dpr", Header: "DPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npdpr", Header: "npDPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npavg", Header: "npAVG", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "autoopr", Header: "Auto OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "autopct", Header: "Auto %", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "matches", Header: "Matches", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiRed}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "consistency", Header: "Consistency", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
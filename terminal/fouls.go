@@ -0,0 +1,128 @@
+package terminal
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// foulHighlights is the number of teams listed under each of the highlights below the foul impact table.
+const foulHighlights = 5
+
+// RenderFoulImpact renders the foul points each team's alliances received and committed, followed by the teams
+// that won the most matches on their opponents' fouls and the teams that committed the fewest foul points. If limit
+// is greater than 0, only the top 'limit' teams are displayed in the table.
+func RenderFoulImpact(impacts []query.TeamFoulImpact, region string, eventCode string, year int, limit int) string {
+	if len(impacts) == 0 {
+		return color.YellowString("No match data available for region %s in year %d\n", region, year)
+	}
+
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	if eventCode != "" {
+		sb.WriteString(color.HiGreenString("Foul Impact - %s (%d) - Event: %s\n", region, year, eventCode))
+	} else {
+		sb.WriteString(color.HiGreenString("Foul Impact - %s (%d)\n", region, year))
+	}
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	sb.WriteString(color.WhiteString("Received: foul points awarded to the team's alliances for their opponents' fouls\n"))
+	sb.WriteString(color.WhiteString("Committed: foul points awarded to the opponents for the team's alliances' fouls\n"))
+	sb.WriteString(color.WhiteString("Foul Wins/Losses: matches the fouls turned into a win or a loss\n\n"))
+
+	shown := impacts
+	if limit > 0 && limit < len(shown) {
+		shown = shown[:limit]
+	}
+
+	columns := newTableColumns(foulImpactColumns...)
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
+			},
+		}),
+	)
+
+	table.Header(columns.headers())
+
+	for i, impact := range shown {
+		table.Append(columns.row(
+			strconv.Itoa(i+1),
+			fmt.Sprintf("%5d - %s", impact.TeamID, impact.TeamName),
+			impact.Region,
+			strconv.Itoa(impact.Matches),
+			strconv.Itoa(impact.Received),
+			strconv.Itoa(impact.Committed),
+			fmt.Sprintf("%+d", impact.Net()),
+			locale.Number("%+.2f", impact.NetPerMatch()),
+			strconv.Itoa(impact.WonOnFouls),
+			strconv.Itoa(impact.LostOnFouls),
+		))
+	}
+
+	table.Render()
+
+	// Highlight the teams that win on their opponents' fouls and the teams that score cleanly
+	wonOnFouls := slices.DeleteFunc(slices.Clone(impacts), func(f query.TeamFoulImpact) bool { return f.WonOnFouls == 0 })
+	slices.SortStableFunc(wonOnFouls, func(a, b query.TeamFoulImpact) int { return cmp.Compare(b.WonOnFouls, a.WonOnFouls) })
+	sb.WriteString(color.YellowString("\nMost Wins on Opponents' Fouls:\n"))
+	if len(wonOnFouls) == 0 {
+		sb.WriteString(color.WhiteString("  None\n"))
+	}
+	for _, f := range wonOnFouls[:min(foulHighlights, len(wonOnFouls))] {
+		sb.WriteString(color.WhiteString("  • %d %s (%d of %d matches)\n", f.TeamID, f.TeamName, f.WonOnFouls, f.Matches))
+	}
+
+	clean := slices.Clone(impacts)
+	slices.SortStableFunc(clean, func(a, b query.TeamFoulImpact) int {
+		return cmp.Compare(a.CommittedPerMatch(), b.CommittedPerMatch())
+	})
+	sb.WriteString(color.YellowString("\nCleanest Teams:\n"))
+	for _, f := range clean[:min(foulHighlights, len(clean))] {
+		sb.WriteString(color.WhiteString("  • %d %s (%s foul points committed per match)\n", f.TeamID, f.TeamName, locale.Number("%.2f", f.CommittedPerMatch())))
+	}
+
+	return sb.String()
+}
+
+// foulImpactColumns are the columns of the foul points received and committed by each team's alliances.
+var foulImpactColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "region", Header: "Region", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "matches", Header: "Matches", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiRed}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "received", Header: "Received", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "committed", Header: "Committed", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "net", Header: "Net", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta, color.Bold}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "netpermatch", Header: "Net/Match", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "foulwins", Header: "Foul Wins", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "foullosses", Header: "Foul Losses", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
@@ -0,0 +1,35 @@
+package terminal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+)
+
+// RenderDataAsOf renders the footer of a report, with the last time the data in the report was synced from the FTC
+// Events API and how long ago that was, so the reader can tell whether the standings are live or stale. The time is
+// shown in the local time zone. If the data has never been synced, the footer says so.
+func RenderDataAsOf(dataAsOf time.Time, now time.Time) string {
+	if dataAsOf.IsZero() {
+		return color.New(color.Faint).Sprint("Data as of: not synced yet")
+	}
+	return color.New(color.Faint).Sprintf("Data as of %s (%s)", locale.DateTime(dataAsOf.Local()), formatAge(now.Sub(dataAsOf)))
+}
+
+// formatAge formats how long ago something happened, in the largest whole unit up to days.
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%d min ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%d hr ago", int(age.Hours()))
+	case age < 48*time.Hour:
+		return "1 day ago"
+	default:
+		return fmt.Sprintf("%d days ago", int(age.Hours()/24))
+	}
+}
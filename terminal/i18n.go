@@ -0,0 +1,84 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// catalogs maps each supported language other than English to its messages, keyed by the English message.
+var catalogs = map[language.Tag]map[string]string{
+	language.Spanish: spanishMessages,
+	language.French:  frenchMessages,
+}
+
+// languageMatcher matches a requested language to one that terminal output can be rendered in.
+var languageMatcher = language.NewMatcher([]language.Tag{language.English, language.Spanish, language.French})
+
+// catalog holds the messages of the selected language, or nil if English is selected.
+var catalog map[string]string
+
+// Languages are the languages that terminal output can be rendered in.
+var Languages = []string{"en", "es", "fr"}
+
+// SetLanguage selects the language of table headers and metric definitions. The language can be a BCP 47 tag, such
+// as "es" or "fr-CA", or a POSIX locale, such as "es_MX.UTF-8". An empty language, or "C" or "POSIX", selects
+// English. An error is returned if the language isn't supported, and English is selected.
+func SetLanguage(lang string) error {
+	catalog = nil
+
+	// Drop the encoding and modifier of a POSIX locale, such as ".UTF-8" or "@euro"
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	lang = strings.ReplaceAll(lang, "_", "-")
+	if lang == "" || lang == "C" || lang == "POSIX" {
+		return nil
+	}
+
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return fmt.Errorf("invalid language %q: %w", lang, err)
+	}
+	matched, _, confidence := languageMatcher.Match(tag)
+	if confidence == language.No {
+		return fmt.Errorf("unsupported language %q; supported languages are %s", lang, strings.Join(Languages, ", "))
+	}
+	base, _ := matched.Base()
+	for t, messages := range catalogs {
+		if b, _ := t.Base(); b == base {
+			catalog = messages
+		}
+	}
+	return nil
+}
+
+// LanguageFromEnv returns the language of the user's locale, taken from the LC_ALL, LC_MESSAGES, or LANG environment
+// variable in that order, or an empty string if none is set.
+func LanguageFromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang := os.Getenv(name); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// translate returns the message in the selected language, or the English message if it hasn't been translated.
+func translate(message string) string {
+	if translated, ok := catalog[message]; ok {
+		return translated
+	}
+	return message
+}
+
+// translateAll returns the messages in the selected language, such as the headers of a table.
+func translateAll(messages []string) []string {
+	translated := make([]string, len(messages))
+	for i, message := range messages {
+		translated[i] = translate(message)
+	}
+	return translated
+}
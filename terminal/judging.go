@@ -0,0 +1,166 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderJudgingReport renders the judged awards won at a region's events, or at a single event, alongside each
+// winner's qualification rank and OPR at the event, followed by a summary of how the winners of each award performed
+// on the field.
+func RenderJudgingReport(report *query.JudgingReport) string {
+	if report == nil {
+		return "No event data available\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Judged Awards vs. Field Performance\n"))
+	if len(report.EventCodes) > 0 {
+		sb.WriteString(color.New(color.FgCyan).Sprintf("Event: %s\n", strings.Join(report.EventCodes, ", ")))
+	} else {
+		sb.WriteString(color.New(color.FgCyan).Sprintf("Region: %s\n", report.RegionCode))
+	}
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n\n", report.Year))
+
+	if len(report.Awards) == 0 {
+		sb.WriteString(color.YellowString("No judged awards have been given.\n"))
+		return sb.String()
+	}
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgCyan}},    // Cyan for column 0 (Event)
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 1 (Award)
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 2 (Place)
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for column 3 (Team)
+				{FG: renderer.Colors{color.FgHiGreen}}, // Green for column 4 (Rank)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 5 (OPR)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 6 (OPR Rank)
+			},
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.Off}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{tw.AlignLeft, tw.AlignLeft, tw.AlignLeft, tw.AlignLeft, tw.AlignRight, tw.AlignRight, tw.AlignRight}},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Event", "Award", "Place", "Team", "Rank", "OPR", "OPR Rank"}))
+	for _, award := range report.Awards {
+		rank, opr, oprRank := "-", "-", "-"
+		if award.Rank > 0 {
+			rank = fmt.Sprintf("%d of %d", award.Rank, award.Teams)
+		}
+		if award.OPRRank > 0 {
+			opr = locale.Number("%.2f", award.OPR)
+			oprRank = fmt.Sprintf("%d", award.OPRRank)
+		}
+		table.Append([]string{
+			award.Event.EventCode,
+			award.Award.Name,
+			ordinal(award.Award.Series),
+			fmt.Sprintf("%6d - %s", award.Team.TeamID, award.Team.Name),
+			rank,
+			opr,
+			oprRank,
+		})
+	}
+	table.Render()
+
+	sb.WriteString("\n")
+	sb.WriteString(renderJudgedAwardSummaries(report.Summaries))
+	return sb.String()
+}
+
+// renderJudgedAwardSummaries renders how the winners of each judged award performed on the field.
+func renderJudgedAwardSummaries(summaries []*query.JudgedAwardSummary) string {
+	var sb strings.Builder
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 0 (Award)
+				{FG: renderer.Colors{color.FgCyan}},    // Cyan for column 1 (Winners)
+				{FG: renderer.Colors{color.FgHiGreen}}, // Green for column 2 (Avg Rank)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 3 (Avg OPR Rank)
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for column 4 (Top Quarter)
+			},
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.Off}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{tw.AlignLeft, tw.AlignRight, tw.AlignRight, tw.AlignRight, tw.AlignRight}},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Award", "Winners", "Avg Rank", "Avg OPR Rank", "Top Quarter"}))
+	for _, summary := range summaries {
+		avgRank, avgOPRRank, topQuarter := "-", "-", "-"
+		if summary.Ranked > 0 {
+			avgRank = locale.Number("%.1f", summary.AvgRank)
+			topQuarter = fmt.Sprintf("%d of %d", summary.TopQuarter, summary.Ranked)
+		}
+		if summary.AvgOPRRank > 0 {
+			avgOPRRank = locale.Number("%.1f", summary.AvgOPRRank)
+		}
+		table.Append([]string{
+			summary.Name,
+			fmt.Sprintf("%d", summary.Winners),
+			avgRank,
+			avgOPRRank,
+			topQuarter,
+		})
+	}
+	table.Render()
+	sb.WriteString(color.New(color.FgWhite).Sprint("Top Quarter is the number of ranked winners whose qualification rank was in the top quarter of their event.\n"))
+	return sb.String()
+}
+
+// ordinal returns the place as an ordinal number, such as 1st or 2nd.
+func ordinal(place int) string {
+	suffix := "th"
+	switch {
+	case place%100 >= 11 && place%100 <= 13:
+	case place%10 == 1:
+		suffix = "st"
+	case place%10 == 2:
+		suffix = "nd"
+	case place%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", place, suffix)
+}
@@ -0,0 +1,234 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// kioskRankingColumns are the columns of an event's qualification rankings on the pit display.
+var kioskRankingColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta, color.Bold}}, Align: tw.AlignRight},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, Align: tw.AlignLeft},
+	{Key: "record", Header: "W–L–T", Align: tw.AlignCenter},
+	{Key: "rs", Header: "RS", Align: tw.AlignRight},
+	{Key: "opr", Header: "OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, Align: tw.AlignRight},
+}
+
+// kioskLeaderboardColumns are the columns of an event's OPR leaderboard on the pit display.
+var kioskLeaderboardColumns = []column{
+	{Key: "position", Header: "#", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite, color.Bold}}, Align: tw.AlignRight},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, Align: tw.AlignLeft},
+	{Key: "opr", Header: "OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen, color.Bold}}, Align: tw.AlignRight},
+	{Key: "npopr", Header: "npOPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, Align: tw.AlignRight},
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, Align: tw.AlignRight},
+}
+
+// RenderKioskPage renders a page of the pit display: a banner with the event's name, the page's title and position
+// among the pages, and the time its data was loaded, followed by the page's body. If height is greater than 0, the
+// body is cut short so the page fits in that many lines of the screen.
+func RenderKioskPage(event *database.Event, title string, body string, page int, pages int, updated time.Time, height int) string {
+	var sb strings.Builder
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("%s (%s)\n", event.Name, event.EventCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("%s  |  %d/%d  |  Updated %s\n\n", title, page, pages, locale.Time(updated)))
+
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	if height > 0 {
+		available := max(height-4, 1) // Banner, blank line, and a line left free for the cursor
+		if len(lines) > available {
+			hidden := len(lines) - available + 1
+			lines = append(lines[:available-1], color.New(color.FgHiBlack).Sprintf("... %d more lines", hidden))
+		}
+	}
+	sb.WriteString(strings.Join(lines, "\n"))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// RenderKioskRankings renders an event's qualification rankings for the pit display.
+func RenderKioskRankings(teams []*query.OverlayTeam) string {
+	if len(teams) == 0 {
+		return "No teams have been ranked yet.\n"
+	}
+
+	var sb strings.Builder
+	columns := newTableColumns(kioskRankingColumns...)
+	table := newKioskTable(&sb, columns.headers(), columns.tints(), columns.alignments())
+	for _, team := range teams {
+		table.Append(columns.row(
+			strconv.Itoa(team.Rank),
+			fmt.Sprintf("%5d - %s", team.Team.TeamID, team.Team.Name),
+			fmt.Sprintf("%d-%d-%d", team.Wins, team.Losses, team.Ties),
+			locale.Number("%.2f", team.RankingScore),
+			locale.Number("%.2f", team.OPR),
+		))
+	}
+	table.Render()
+	return sb.String()
+}
+
+// RenderKioskLeaderboard renders the teams with the highest OPRs at an event for the pit display.
+func RenderKioskLeaderboard(teams []*query.OverlayTeam) string {
+	if len(teams) == 0 {
+		return "No OPRs have been calculated yet.\n"
+	}
+
+	var sb strings.Builder
+	columns := newTableColumns(kioskLeaderboardColumns...)
+	table := newKioskTable(&sb, columns.headers(), columns.tints(), columns.alignments())
+	for i, team := range teams {
+		rank := "-"
+		if team.Rank > 0 {
+			rank = strconv.Itoa(team.Rank)
+		}
+		table.Append(columns.row(
+			strconv.Itoa(i+1),
+			fmt.Sprintf("%5d - %s", team.Team.TeamID, team.Team.Name),
+			locale.Number("%.2f", team.OPR),
+			locale.Number("%.2f", team.NpOPR),
+			rank,
+		))
+	}
+	table.Render()
+	return sb.String()
+}
+
+// RenderRecentMatches renders the last matches played at an event, newest first, for the pit display.
+func RenderRecentMatches(matches []*query.MatchDetails) string {
+	if len(matches) == 0 {
+		return "No matches have been played yet.\n"
+	}
+
+	var sb strings.Builder
+	table := newKioskTable(&sb,
+		translateAll([]string{"Match", "Red Alliance", "Red Score", "Blue Score", "Blue Alliance", "Winner"}),
+		[]renderer.Tint{
+			{FG: renderer.Colors{color.FgMagenta}},
+			{FG: renderer.Colors{color.FgHiRed}},
+			{FG: renderer.Colors{color.FgHiRed, color.Bold}},
+			{FG: renderer.Colors{color.FgHiBlue, color.Bold}},
+			{FG: renderer.Colors{color.FgHiBlue}},
+			{},
+		},
+		[]tw.Align{tw.AlignLeft, tw.AlignLeft, tw.AlignRight, tw.AlignRight, tw.AlignLeft, tw.AlignCenter})
+	for _, m := range matches {
+		redScore, blueScore := "-", "-"
+		var redPoints, bluePoints int
+		if m.RedAlliance.Score != nil {
+			redPoints = m.RedAlliance.Score.TotalPoints
+			redScore = strconv.Itoa(redPoints)
+		}
+		if m.BlueAlliance.Score != nil {
+			bluePoints = m.BlueAlliance.Score.TotalPoints
+			blueScore = strconv.Itoa(bluePoints)
+		}
+		var winner string
+		switch {
+		case redPoints > bluePoints:
+			winner = color.New(color.FgRed, color.Bold).Sprint("Red")
+		case bluePoints > redPoints:
+			winner = color.New(color.FgBlue, color.Bold).Sprint("Blue")
+		default:
+			winner = "Tie"
+		}
+		table.Append([]string{
+			matchLabel(m.Match),
+			allianceTeams(m.RedAlliance.Teams),
+			redScore,
+			blueScore,
+			allianceTeams(m.BlueAlliance.Teams),
+			winner,
+		})
+	}
+	table.Render()
+	return sb.String()
+}
+
+// RenderUpcomingMatches renders the next matches to be played at an event, in the order they will be played, for
+// the pit display.
+func RenderUpcomingMatches(matches []*query.OverlayMatch) string {
+	if len(matches) == 0 {
+		return "No upcoming matches are scheduled.\n"
+	}
+
+	var sb strings.Builder
+	table := newKioskTable(&sb,
+		translateAll([]string{"Match", "Red Alliance", "Blue Alliance"}),
+		[]renderer.Tint{
+			{FG: renderer.Colors{color.FgMagenta}},
+			{FG: renderer.Colors{color.FgHiRed}},
+			{FG: renderer.Colors{color.FgHiBlue}},
+		},
+		[]tw.Align{tw.AlignLeft, tw.AlignLeft, tw.AlignLeft})
+	for _, m := range matches {
+		red := make([]*database.Team, 0, len(m.Red))
+		for _, team := range m.Red {
+			red = append(red, team.Team)
+		}
+		blue := make([]*database.Team, 0, len(m.Blue))
+		for _, team := range m.Blue {
+			blue = append(blue, team.Team)
+		}
+		table.Append([]string{matchLabel(m.Match), allianceTeams(red), allianceTeams(blue)})
+	}
+	table.Render()
+	return sb.String()
+}
+
+// newKioskTable returns a table for a page of the pit display with the given headers, column colors, and column
+// alignments.
+func newKioskTable(w io.Writer, headers []string, tints []renderer.Tint, alignments []tw.Align) *tablewriter.Table {
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: tints,
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.Off}},
+	}
+	table := tablewriter.NewTable(w,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: alignments},
+			},
+		}),
+	)
+	table.Header(headers)
+	return table
+}
+
+// matchLabel returns the short name of a match, such as "Q12" for the 12th qualification match or "P3" for the 3rd
+// playoff series.
+func matchLabel(match *database.Match) string {
+	if match == nil || match.TournamentLevel == "" {
+		return ""
+	}
+	return strings.ToUpper(match.TournamentLevel[:1]) + strconv.Itoa(match.MatchNumber)
+}
+
+// allianceTeams returns the numbers of an alliance's teams, separated by spaces.
+func allianceTeams(teams []*database.Team) string {
+	numbers := make([]string, 0, len(teams))
+	for _, team := range teams {
+		numbers = append(numbers, strconv.Itoa(team.TeamID))
+	}
+	return strings.Join(numbers, "  ")
+}
@@ -0,0 +1,297 @@
+package terminal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// MarkdownTopTeams is the number of teams shown in a Markdown ranking if no limit is given, which keeps a chat
+// message short.
+const MarkdownTopTeams = 10
+
+// markdownRankingColumns are the columns of an event's qualification rankings in Markdown.
+var markdownRankingColumns = []column{
+	{Key: "rank", Header: "Rank"},
+	{Key: "team", Header: "Team"},
+	{Key: "record", Header: "W-L-T"},
+	{Key: "rs", Header: "RS"},
+	{Key: "high-score", Header: "High"},
+}
+
+// markdownPerformanceColumns are the columns of the team performance rankings in Markdown.
+var markdownPerformanceColumns = []column{
+	{Key: "rank", Header: "#"},
+	{Key: "team", Header: "Team"},
+	{Key: "opr", Header: "OPR"},
+	{Key: "npopr", Header: "npOPR"},
+	{Key: "ccwm", Header: "CCWM"},
+	{Key: "npavg", Header: "npAVG"},
+}
+
+// markdownTable renders a plain text table of the shown columns inside a fenced code block. Slack and Discord don't display Markdown
+// tables, but both show code blocks in a fixed-width font, so the columns stay lined up.
+func markdownTable(columns *tableColumns, rows [][]string) string {
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{Symbols: tw.NewSymbols(tw.StyleASCII)})),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment:  tw.CellAlignment{Global: tw.AlignLeft},
+				Formatting: tw.CellFormatting{AutoFormat: tw.Off},
+			},
+		}),
+	)
+	table.Header(columns.headers())
+	table.Bulk(rows)
+	table.Render()
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+// markdownMovement formats the number of places a team has moved as an arrow without any color codes.
+func markdownMovement(teamID int, movement map[int]int) string {
+	places, ok := movement[teamID]
+	switch {
+	case !ok:
+		return "new"
+	case places > 0:
+		return fmt.Sprintf("▲%d", places)
+	case places < 0:
+		return fmt.Sprintf("▼%d", -places)
+	default:
+		return "–"
+	}
+}
+
+// RenderTeamRankingsMarkdown renders the top of an event's qualification rankings as Markdown for posting in Slack or
+// Discord. If limit is 0, the top 10 teams are shown.
+func RenderTeamRankingsMarkdown(eventRankings *query.EventTeamRankings, limit int) string {
+	if eventRankings == nil || eventRankings.Event == nil {
+		return "No event data available\n"
+	}
+	if limit <= 0 {
+		limit = MarkdownTopTeams
+	}
+
+	var sb strings.Builder
+	event := eventRankings.Event
+	sb.WriteString(fmt.Sprintf("**%s Rankings** (%s, %s)\n", event.Name, event.EventCode, locale.Date(event.DateStart)))
+
+	if len(eventRankings.TeamRankings) == 0 {
+		sb.WriteString("No rankings are available yet.\n")
+		return sb.String()
+	}
+
+	// Each division of a multi-division event is ranked separately, so each gets its own table
+	if len(eventRankings.Divisions) > 0 {
+		for _, division := range eventRankings.Divisions {
+			var teamRankings []*query.TeamRanking
+			for _, tr := range eventRankings.TeamRankings {
+				if tr.Division == division {
+					teamRankings = append(teamRankings, tr)
+				}
+			}
+			if len(teamRankings) > 0 {
+				sb.WriteString(fmt.Sprintf("\n**%s** (%s)\n", division.Name, division.EventCode))
+				writeMarkdownRankings(&sb, teamRankings, limit)
+			}
+		}
+		return sb.String()
+	}
+
+	writeMarkdownRankings(&sb, eventRankings.TeamRankings, limit)
+	return sb.String()
+}
+
+// writeMarkdownRankings writes a Markdown table of the top teams in the rankings, up to limit.
+func writeMarkdownRankings(sb *strings.Builder, teamRankings []*query.TeamRanking, limit int) {
+	columns := newTableColumns(markdownRankingColumns...)
+	var rows [][]string
+	for _, tr := range teamRankings {
+		if len(rows) == limit {
+			break
+		}
+		rows = append(rows, columns.row(
+			fmt.Sprintf("%d", tr.Ranking.Rank),
+			fmt.Sprintf("%d %s", tr.Team.TeamID, tr.Team.Name),
+			fmt.Sprintf("%d-%d-%d", tr.Ranking.Wins, tr.Ranking.Losses, tr.Ranking.Ties),
+			locale.Number("%.2f", tr.Ranking.SortOrder1),
+			fmt.Sprintf("%d", tr.HighMatchScore),
+		))
+	}
+	sb.WriteString(markdownTable(columns, rows))
+	if len(teamRankings) > len(rows) {
+		sb.WriteString(fmt.Sprintf("Top %d of %d teams\n", len(rows), len(teamRankings)))
+	}
+}
+
+// RenderTeamPerformanceMarkdown renders team performance rankings as Markdown for posting in Slack or Discord. If
+// previous is non-nil, each team's movement since the earlier rankings is included. If limit is 0, the top 10
+// teams are shown.
+func RenderTeamPerformanceMarkdown(performances []query.TeamPerformance, previous []query.TeamPerformance, eventCode string, sortBy SortBy, region string, year int, limit int, since time.Time) string {
+	if len(performances) == 0 {
+		return fmt.Sprintf("No performance data available for region %s in year %d\n", region, year)
+	}
+	if limit <= 0 {
+		limit = MarkdownTopTeams
+	}
+
+	sortTeamPerformances(performances, sortBy)
+	var movement map[int]int
+	if previous != nil {
+		sortTeamPerformances(previous, sortBy)
+		movement = query.RankMovement(performances, previous)
+	}
+	total := len(performances)
+	if limit < len(performances) {
+		performances = performances[:limit]
+	}
+
+	var sb strings.Builder
+	title := "Team Performance Rankings"
+	if region != "" {
+		title += " - " + region
+	}
+	sb.WriteString(fmt.Sprintf("**%s** (%d)\n", title, year))
+	if eventCode != "" {
+		sb.WriteString(fmt.Sprintf("Event: %s\n", eventCode))
+	}
+	sb.WriteString(fmt.Sprintf("Sorted by %s", sortBy))
+	if movement != nil {
+		sb.WriteString(fmt.Sprintf(", movement since %s", since.Format(database.SnapshotDateFormat)))
+	}
+	sb.WriteString("\n")
+
+	spec := markdownPerformanceColumns
+	if movement != nil {
+		spec = append(slices.Clone(spec), moveColumn)
+	}
+	columns := newTableColumns(spec...)
+	var rows [][]string
+	visiting := false
+	for i, p := range performances {
+		move := ""
+		if movement != nil {
+			move = markdownMovement(p.TeamID, movement)
+		}
+		team := fmt.Sprintf("%d %s", p.TeamID, p.TeamName)
+		if p.Visiting {
+			team += " *"
+			visiting = true
+		}
+		rows = append(rows, columns.row(
+			fmt.Sprintf("%d", i+1),
+			team,
+			locale.Number("%.2f", p.OPR),
+			locale.Number("%.2f", p.NpOPR),
+			locale.Number("%.2f", p.CCWM),
+			locale.Number("%.2f", p.NpAVG),
+			move,
+		))
+	}
+	sb.WriteString(markdownTable(columns, rows))
+	if total > len(rows) {
+		sb.WriteString(fmt.Sprintf("Top %d of %d teams\n", len(rows), total))
+	}
+	if visiting {
+		sb.WriteString("\\* Visiting team, whose home region is outside the region\n")
+	}
+	return sb.String()
+}
+
+// RenderAdvancementReportMarkdown renders the teams advancing from an event as a Markdown list for posting in Slack
+// or Discord.
+func RenderAdvancementReportMarkdown(report *query.AdvancementReport) string {
+	if report == nil || report.Event == nil {
+		return "No event data available\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**Advancing from %s** (%s)\n", report.Event.Name, report.Event.EventCode))
+
+	count := 0
+	for _, ta := range report.TeamAdvancements {
+		if !ta.Advances {
+			continue
+		}
+		count++
+		line := fmt.Sprintf("%d. **%d** %s - %d pts", count, ta.Team.TeamID, ta.Team.Name, ta.TotalPoints)
+		switch {
+		case ta.Status == "already_advancing":
+			line += " (already advanced)"
+		case ta.InspireSlot:
+			line += " (Inspire slot)"
+		}
+		sb.WriteString(line + "\n")
+	}
+	if count == 0 {
+		sb.WriteString("No teams have advanced yet.\n")
+	}
+	return sb.String()
+}
+
+// RenderRegionAdvancementMarkdown renders the teams advancing in a region as a Markdown list for posting in Slack or
+// Discord, grouped by the event each team advanced from.
+func RenderRegionAdvancementMarkdown(report *query.RegionAdvancementReport) string {
+	if report == nil {
+		return "No region data available\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s Advancing Teams** (%d) - %d teams\n", report.RegionCode, report.Year, len(report.TeamAdvancements)))
+	if len(report.TeamAdvancements) == 0 {
+		sb.WriteString("No teams have advanced yet.\n")
+		return sb.String()
+	}
+
+	// Group the teams by the event they advanced from, with the events in date order
+	var eventIDs []string
+	byEvent := make(map[string][]*query.RegionTeamAdvancement)
+	events := make(map[string]*database.Event)
+	for _, ta := range report.TeamAdvancements {
+		eventID := ta.AdvancingEvent.EventID
+		if _, ok := byEvent[eventID]; !ok {
+			eventIDs = append(eventIDs, eventID)
+			events[eventID] = ta.AdvancingEvent
+		}
+		byEvent[eventID] = append(byEvent[eventID], ta)
+	}
+	slices.SortFunc(eventIDs, func(a, b string) int {
+		return events[a].DateStart.Compare(events[b].DateStart)
+	})
+
+	visiting := false
+	for _, eventID := range eventIDs {
+		event := events[eventID]
+		sb.WriteString(fmt.Sprintf("\n__%s__ (%s)\n", event.Name, event.EventCode))
+		for _, ta := range byEvent[eventID] {
+			line := fmt.Sprintf("- **%d** %s", ta.Team.TeamID, ta.Team.Name)
+			if ta.Visiting {
+				line += " \\*"
+				visiting = true
+			}
+			if len(ta.AdvancingEventAwards) > 0 {
+				var awards []string
+				for _, award := range ta.AdvancingEventAwards {
+					awards = append(awards, award.Name)
+				}
+				line += " - " + strings.Join(awards, ", ")
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	if visiting {
+		sb.WriteString("\n\\* Visiting team, whose home region is outside the region\n")
+	}
+	return sb.String()
+}
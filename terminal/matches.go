@@ -9,6 +9,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/renderer"
 	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
 	"github.com/rbrabson/ftcstanding/query"
 )
 
@@ -26,8 +27,8 @@ func RenderMatchDetails(details []*query.MatchDetails) string {
 		sb.WriteString(color.New(color.FgCyan).Sprintf("Location: %s, %s, %s\n",
 			event.City, event.StateProv, event.Country))
 		sb.WriteString(color.New(color.FgCyan).Sprintf("Dates: %s to %s\n\n",
-			event.DateStart.Format("Jan 2, 2006"),
-			event.DateEnd.Format("Jan 2, 2006")))
+			locale.Date(event.DateStart),
+			locale.Date(event.DateEnd)))
 	}
 
 	colorCfg := renderer.ColorizedConfig{
@@ -96,7 +97,7 @@ func RenderMatchDetails(details []*query.MatchDetails) string {
 		}),
 	)
 
-	table.Header([]string{"Type", "Match #", "Red Alliance", "Red Alliance", "Blue Alliance", "Blue Alliance", "Scores", "Winner"})
+	table.Header(translateAll([]string{"Type", "Match #", "Red Alliance", "Red Alliance", "Blue Alliance", "Blue Alliance", "Scores", "Winner"}))
 
 	for _, detail := range details {
 		// Get red alliance teams
@@ -188,8 +189,8 @@ func RenderMatchesByEventAndTeam(results []*query.TeamMatchResult) string {
 		event.City, event.StateProv, event.Country))
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Year: %d\n", event.Year))
 	sb.WriteString(color.New(color.FgCyan).Sprintf("Dates: %s to %s\n\n",
-		event.DateStart.Format("Jan 2, 2006"),
-		event.DateEnd.Format("Jan 2, 2006")))
+		locale.Date(event.DateStart),
+		locale.Date(event.DateEnd)))
 
 	colorCfg := renderer.ColorizedConfig{
 		Header: renderer.Tint{
@@ -248,7 +249,7 @@ func RenderMatchesByEventAndTeam(results []*query.TeamMatchResult) string {
 		}),
 	)
 
-	table.Header([]string{"Type", "Match #", "Team Alliance", "Team Alliance", "Opponent Alliance", "Opponent Alliance", "Scores", "Result"})
+	table.Header(translateAll([]string{"Type", "Match #", "Team Alliance", "Team Alliance", "Opponent Alliance", "Opponent Alliance", "Scores", "Result"}))
 
 	for _, result := range results {
 		// Get team alliance teams with coloring based on alliance
@@ -0,0 +1,185 @@
+package terminal
+
+// spanishMessages are the Spanish translations of the table headers and metric definitions.
+var spanishMessages = map[string]string{
+	// Table headers
+	"#":                 "#",
+	"Adv #":             "Nº Clasif.",
+	"Advanced":          "Clasificó",
+	"Advancing":         "Clasifican",
+	"Advancing Event":   "Evento de Clasificación",
+	"Auto %":            "% Auto",
+	"Auto OPR":          "OPR Auto",
+	"Auto Pts":          "Pts Auto",
+	"Avg Days":          "Días Medios",
+	"Avg NP Score":      "Puntaje NP Medio",
+	"Avg npOPR":         "npOPR Medio",
+	"Avg npOPR Δ":       "Δ npOPR Medio",
+	"Avg OPR Rank":      "Puesto OPR Medio",
+	"Avg Rank":          "Puesto Medio",
+	"Avg Score":         "Puntaje Medio",
+	"Award":             "Premio",
+	"Award Name":        "Premio",
+	"Awards":            "Premios",
+	"Base Pts":          "Pts Base",
+	"Blue Alliance":     "Alianza Azul",
+	"Blue Auto":         "Auto Azul",
+	"Blue Fouls":        "Faltas Azul",
+	"Blue Score":        "Puntos Azul",
+	"Blue Teams":        "Equipos Azul",
+	"Code":              "Código",
+	"Committed":         "Cometidas",
+	"Consistency":       "Consistencia",
+	"Country":           "País",
+	"Cutoff":            "Corte",
+	"Date":              "Fecha",
+	"Dates":             "Fechas",
+	"Days":              "Días",
+	"Distance":          "Distancia",
+	"Division":          "División",
+	"Event":             "Evento",
+	"Event Code":        "Código de Evento",
+	"Event Name":        "Nombre del Evento",
+	"Event npAVG":       "npAVG Evento",
+	"Event OPR":         "OPR Evento",
+	"Event Size":        "Tamaño del Evento",
+	"Events":            "Eventos",
+	"First Event":       "Primer Evento",
+	"First npOPR":       "npOPR Primero",
+	"Foul Losses":       "Derrotas x Falta",
+	"Foul Wins":         "Victorias x Falta",
+	"High":              "Máximo",
+	"High Score":        "Puntaje Máximo",
+	"Improved":          "Mejoraron",
+	"Judging":           "Jueces",
+	"Level":             "Nivel",
+	"Location":          "Ubicación",
+	"Low":               "Mínimo",
+	"Match":             "Partido",
+	"Match #":           "Partido #",
+	"Match Pts":         "Pts Partido",
+	"Matches":           "Partidos",
+	"Matches Away":      "Partidos Antes",
+	"Median":            "Mediana",
+	"Median npOPR Δ":    "Δ npOPR Mediano",
+	"Move":              "Cambio",
+	"Name":              "Nombre",
+	"npAVG Δ":           "Δ npAVG",
+	"Net":               "Neto",
+	"Net/Match":         "Neto/Partido",
+	"Notable Teams":     "Equipos Destacados",
+	"Notes":             "Notas",
+	"npOPR Δ":           "Δ npOPR",
+	"Number":            "Número",
+	"Official":          "Oficial",
+	"Opponent Alliance": "Alianza Rival",
+	"OPR":               "OPR",
+	"OPR Rank":          "Puesto OPR",
+	"OPR Δ":             "Δ OPR",
+	"Other Events":      "Otros Eventos",
+	"Pairs":             "Pares",
+	"Place":             "Lugar",
+	"Playoff":           "Eliminatorias",
+	"Playoffs":          "Eliminatorias",
+	"RP Avg":            "PR Medio",
+	"Qual":              "Clasif.",
+	"Qualification":     "Clasificatorias",
+	"Rank":              "Puesto",
+	"Received":          "Recibidas",
+	"Record":            "Récord",
+	"Red Alliance":      "Alianza Roja",
+	"Red Auto":          "Auto Rojo",
+	"Red Fouls":         "Faltas Rojo",
+	"Red Score":         "Puntos Rojo",
+	"Red Teams":         "Equipos Rojo",
+	"Region":            "Región",
+	"Result":            "Resultado",
+	"Rookie Year":       "Año de Inicio",
+	"Score":             "Puntuación",
+	"Scores":            "Puntajes",
+	"Season":            "Temporada",
+	"Season npAVG":      "npAVG Temporada",
+	"Season OPR":        "OPR Temporada",
+	"Second Event":      "Segundo Evento",
+	"Second npOPR":      "npOPR Segundo",
+	"Selection":         "Selección",
+	"Spacing":           "Separación",
+	"Status":            "Estado",
+	"Tags":              "Etiquetas",
+	"Team":              "Equipo",
+	"Team Alliance":     "Alianza del Equipo",
+	"Teams":             "Equipos",
+	"To Champs":         "Al Campeonato",
+	"To Worlds":         "Al Mundial",
+	"Top Quarter":       "Primer Cuarto",
+	"Total":             "Total",
+	"Total Pts":         "Pts Totales",
+	"Type":              "Tipo",
+	"Venue":             "Sede",
+	"W-L-T":             "G-P-E",
+	"W–L–T":             "G–P–E",
+	"Wait":              "Espera",
+	"Winner":            "Ganador",
+	"Winners":           "Ganadores",
+	"WPA":               "WPA",
+
+	// Metric definitions
+	"Metric Definitions:": "Definiciones de las Métricas:",
+
+	"CCWM — Calculated Contribution to Winning Margin":                                        "CCWM — Contribución Calculada al Margen de Victoria",
+	"Estimates how much a team affects the margin of victory or loss.":                        "Estima cuánto influye un equipo en el margen de victoria o derrota.",
+	"Positive CCWM → team usually helps alliances win by more":                                "CCWM positivo → el equipo suele ayudar a sus alianzas a ganar por más",
+	"Negative CCWM → alliances with this team often lose by more":                             "CCWM negativo → las alianzas con este equipo suelen perder por más",
+	"👉 This blends offense, defense, and penalties into one \"do they help us win?\" number.": "👉 Combina ataque, defensa y penalizaciones en un solo número: \"¿nos ayudan a ganar?\"",
+
+	"OPR — Offensive Power Rating": "OPR — Índice de Poder Ofensivo",
+	"An estimate of how many points a team contributes per match to their alliance.": "Una estimación de cuántos puntos aporta un equipo a su alianza en cada partido.",
+	"Calculated using math across all matches, factoring in partners and opponents.": "Se calcula con todos los partidos, teniendo en cuenta a compañeros y rivales.",
+	"Higher OPR = stronger overall scoring impact.":                                  "Un OPR más alto = mayor impacto en la puntuación.",
+	"👉 Think of it as: \"If this team plays, how many points do they add?\"":         "👉 Piénsalo así: \"Si este equipo juega, ¿cuántos puntos suma?\"",
+
+	"NP OPR — Non-Penalty Offensive Power Rating":                                                "NP OPR — Índice de Poder Ofensivo sin Penalizaciones",
+	"Same idea as OPR, but penalties are removed.":                                               "La misma idea que el OPR, pero sin las penalizaciones.",
+	"Only counts points scored through gameplay, not points gained because opponents messed up.": "Solo cuenta los puntos anotados jugando, no los obtenidos por errores de los rivales.",
+	"👉 Useful when you want to see true scoring ability, not \"we won because the other":         "👉 Útil para ver la verdadera capacidad de anotar, no \"ganamos porque la otra",
+	"   alliance kept getting penalties.\"":                                                      "   alianza no dejaba de cometer faltas.\"",
+
+	"DPR — Defensive Power Rating":                                                                "DPR — Índice de Poder Defensivo",
+	"Estimates how many points a team allows opponents to score.":                                 "Estima cuántos puntos permite anotar un equipo a sus rivales.",
+	"Lower DPR = better defense.":                                                                 "Un DPR más bajo = mejor defensa.",
+	"A strong defensive robot often has a noticeably low DPR even if OPR isn't huge.":             "Un robot con buena defensa suele tener un DPR bajo aunque su OPR no sea alto.",
+	"👉 Think of it as: \"If this team plays, how well do they keep the opponents from scoring?\"": "👉 Piénsalo así: \"Si este equipo juega, ¿qué tan bien evita que los rivales anoten?\"",
+
+	"NP DPR — Non-Penalty Defensive Power Rating":                           "NP DPR — Índice de Poder Defensivo sin Penalizaciones",
+	"Same as DPR, but ignores penalty points.":                              "Igual que el DPR, pero sin contar los puntos por penalizaciones.",
+	"Focuses only on how well a team limits actual scoring, not ref calls.": "Solo mide qué tan bien limita un equipo la anotación real, no las decisiones de los árbitros.",
+	"👉 Great for identifying clean, effective defense.":                     "👉 Ideal para identificar una defensa limpia y eficaz.",
+
+	"NP AVG — Non-Penalty Average Score":                                                                  "NP AVG — Puntaje Promedio sin Penalizaciones",
+	"The average number of non-penalty points a team's alliance scores in matches involving them.":        "El promedio de puntos sin penalizaciones que anota la alianza de un equipo en los partidos en que participa.",
+	"Subtracts the penalties commited by the team's alliance to determine the true scoring contribution.": "Resta las penalizaciones cometidas por la alianza del equipo para obtener su verdadera contribución.",
+	"Less math-heavy than OPR, more literal.":                                                             "Menos matemático que el OPR, más literal.",
+	"Still partner-dependent, but easier to interpret.":                                                   "Sigue dependiendo de los compañeros, pero es más fácil de interpretar.",
+	"👉 Think: \"On average, when this team plays, how many real points get scored?\"":                     "👉 Piensa: \"En promedio, cuando este equipo juega, ¿cuántos puntos reales se anotan?\"",
+
+	"WPA — Win Probability Added": "WPA — Probabilidad de Victoria Añadida",
+	"Before each match, a win probability is predicted from the OPRs the alliances brought into the event.": "Antes de cada partido se predice una probabilidad de victoria a partir de los OPR con que las alianzas llegaron al evento.",
+	"WPA is the number of matches a team won minus the number its alliances were expected to win.":          "El WPA es el número de partidos que ganó un equipo menos el número que se esperaba que ganaran sus alianzas.",
+	"Positive WPA → team wins more than its ratings predict":                                                "WPA positivo → el equipo gana más de lo que predicen sus índices",
+	"Negative WPA → team wins less than its ratings predict":                                                "WPA negativo → el equipo gana menos de lo que predicen sus índices",
+	"👉 Think: \"Does this team come through when it counts?\"":                                              "👉 Piensa: \"¿Este equipo responde cuando más importa?\"",
+
+	"Visiting team, whose home region is outside the region": "Equipo visitante, cuya región de origen está fuera de la región",
+	"Unknown country": "País desconocido",
+	"teams":           "equipos",
+	"team":            "equipo",
+
+	"ID":             "ID",
+	"Given To":       "Otorgado A",
+	"Advancement":    "Avance",
+	"Description":    "Descripción",
+	"Person":         "Persona",
+	"pts":            "pts",
+	"Playoff points": "Puntos de eliminatorias",
+	"Recipient":      "Destinatario",
+}
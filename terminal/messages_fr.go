@@ -0,0 +1,185 @@
+package terminal
+
+// frenchMessages are the French translations of the table headers and metric definitions.
+var frenchMessages = map[string]string{
+	// Table headers
+	"#":                 "#",
+	"Adv #":             "N° Qualif.",
+	"Advanced":          "Qualifiée",
+	"Advancing":         "Qualifiées",
+	"Advancing Event":   "Événement Qualificatif",
+	"Auto %":            "% Auto",
+	"Auto OPR":          "OPR Auto",
+	"Auto Pts":          "Pts Auto",
+	"Avg Days":          "Jours Moyens",
+	"Avg NP Score":      "Score NP Moyen",
+	"Avg npOPR":         "npOPR Moyen",
+	"Avg npOPR Δ":       "Δ npOPR Moyen",
+	"Avg OPR Rank":      "Rang OPR Moyen",
+	"Avg Rank":          "Rang Moyen",
+	"Avg Score":         "Score Moyen",
+	"Award":             "Prix",
+	"Award Name":        "Prix",
+	"Awards":            "Prix",
+	"Base Pts":          "Pts de Base",
+	"Blue Alliance":     "Alliance Bleue",
+	"Blue Auto":         "Auto Bleu",
+	"Blue Fouls":        "Fautes Bleu",
+	"Blue Score":        "Score Bleu",
+	"Blue Teams":        "Équipes Bleues",
+	"Code":              "Code",
+	"Committed":         "Commises",
+	"Consistency":       "Régularité",
+	"Country":           "Pays",
+	"Cutoff":            "Seuil",
+	"Date":              "Date",
+	"Dates":             "Dates",
+	"Days":              "Jours",
+	"Distance":          "Distance",
+	"Division":          "Division",
+	"Event":             "Événement",
+	"Event Code":        "Code d'Événement",
+	"Event Name":        "Nom de l'Événement",
+	"Event npAVG":       "npAVG Événement",
+	"Event OPR":         "OPR Événement",
+	"Event Size":        "Taille de l'Événement",
+	"Events":            "Événements",
+	"First Event":       "Premier Événement",
+	"First npOPR":       "npOPR Premier",
+	"Foul Losses":       "Défaites Fautes",
+	"Foul Wins":         "Victoires Fautes",
+	"High":              "Max",
+	"High Score":        "Meilleur Score",
+	"Improved":          "En Progrès",
+	"Judging":           "Jury",
+	"Level":             "Niveau",
+	"Location":          "Lieu",
+	"Low":               "Min",
+	"Match":             "Match",
+	"Match #":           "Match n°",
+	"Match Pts":         "Pts Match",
+	"Matches":           "Matchs",
+	"Matches Away":      "Matchs Avant",
+	"Median":            "Médiane",
+	"Median npOPR Δ":    "Δ npOPR Médian",
+	"Move":              "Évolution",
+	"Name":              "Nom",
+	"npAVG Δ":           "Δ npAVG",
+	"Net":               "Net",
+	"Net/Match":         "Net/Match",
+	"Notable Teams":     "Équipes Notables",
+	"Notes":             "Notes",
+	"npOPR Δ":           "Δ npOPR",
+	"Number":            "Numéro",
+	"Official":          "Officiel",
+	"Opponent Alliance": "Alliance Adverse",
+	"OPR":               "OPR",
+	"OPR Rank":          "Rang OPR",
+	"OPR Δ":             "Δ OPR",
+	"Other Events":      "Autres Événements",
+	"Pairs":             "Paires",
+	"Place":             "Place",
+	"Playoff":           "Éliminatoires",
+	"Playoffs":          "Éliminatoires",
+	"RP Avg":            "PC Moyens",
+	"Qual":              "Qualif.",
+	"Qualification":     "Qualification",
+	"Rank":              "Rang",
+	"Received":          "Reçues",
+	"Record":            "Bilan",
+	"Red Alliance":      "Alliance Rouge",
+	"Red Auto":          "Auto Rouge",
+	"Red Fouls":         "Fautes Rouge",
+	"Red Score":         "Score Rouge",
+	"Red Teams":         "Équipes Rouges",
+	"Region":            "Région",
+	"Result":            "Résultat",
+	"Rookie Year":       "Année de Début",
+	"Score":             "Score",
+	"Scores":            "Scores",
+	"Season":            "Saison",
+	"Season npAVG":      "npAVG Saison",
+	"Season OPR":        "OPR Saison",
+	"Second Event":      "Second Événement",
+	"Second npOPR":      "npOPR Second",
+	"Selection":         "Sélection",
+	"Spacing":           "Espacement",
+	"Status":            "Statut",
+	"Tags":              "Étiquettes",
+	"Team":              "Équipe",
+	"Team Alliance":     "Alliance de l'Équipe",
+	"Teams":             "Équipes",
+	"To Champs":         "Au Championnat",
+	"To Worlds":         "Au Mondial",
+	"Top Quarter":       "Premier Quart",
+	"Total":             "Total",
+	"Total Pts":         "Pts Totaux",
+	"Type":              "Type",
+	"Venue":             "Lieu",
+	"W-L-T":             "V-D-N",
+	"W–L–T":             "V–D–N",
+	"Wait":              "Attente",
+	"Winner":            "Gagnant",
+	"Winners":           "Gagnants",
+	"WPA":               "WPA",
+
+	// Metric definitions
+	"Metric Definitions:": "Définitions des Indicateurs :",
+
+	"CCWM — Calculated Contribution to Winning Margin":                                        "CCWM — Contribution Calculée à la Marge de Victoire",
+	"Estimates how much a team affects the margin of victory or loss.":                        "Estime l'influence d'une équipe sur l'écart de victoire ou de défaite.",
+	"Positive CCWM → team usually helps alliances win by more":                                "CCWM positif → l'équipe aide souvent ses alliances à gagner plus largement",
+	"Negative CCWM → alliances with this team often lose by more":                             "CCWM négatif → les alliances avec cette équipe perdent souvent plus largement",
+	"👉 This blends offense, defense, and penalties into one \"do they help us win?\" number.": "👉 Combine attaque, défense et pénalités en un seul chiffre : « nous aident-ils à gagner ? »",
+
+	"OPR — Offensive Power Rating": "OPR — Indice de Puissance Offensive",
+	"An estimate of how many points a team contributes per match to their alliance.": "Une estimation des points qu'une équipe apporte à son alliance à chaque match.",
+	"Calculated using math across all matches, factoring in partners and opponents.": "Calculé sur l'ensemble des matchs, en tenant compte des partenaires et des adversaires.",
+	"Higher OPR = stronger overall scoring impact.":                                  "Un OPR plus élevé = un plus grand impact sur le score.",
+	"👉 Think of it as: \"If this team plays, how many points do they add?\"":         "👉 En clair : « Si cette équipe joue, combien de points ajoute-t-elle ? »",
+
+	"NP OPR — Non-Penalty Offensive Power Rating":                                                "NP OPR — Indice de Puissance Offensive hors Pénalités",
+	"Same idea as OPR, but penalties are removed.":                                               "Même principe que l'OPR, mais sans les pénalités.",
+	"Only counts points scored through gameplay, not points gained because opponents messed up.": "Ne compte que les points marqués en jouant, pas ceux gagnés grâce aux erreurs des adversaires.",
+	"👉 Useful when you want to see true scoring ability, not \"we won because the other":         "👉 Utile pour voir la vraie capacité à marquer, pas « nous avons gagné parce que l'autre",
+	"   alliance kept getting penalties.\"":                                                      "   alliance accumulait les pénalités. »",
+
+	"DPR — Defensive Power Rating":                                                                "DPR — Indice de Puissance Défensive",
+	"Estimates how many points a team allows opponents to score.":                                 "Estime combien de points une équipe laisse marquer à ses adversaires.",
+	"Lower DPR = better defense.":                                                                 "Un DPR plus bas = une meilleure défense.",
+	"A strong defensive robot often has a noticeably low DPR even if OPR isn't huge.":             "Un bon robot défensif a souvent un DPR nettement bas, même si son OPR n'est pas élevé.",
+	"👉 Think of it as: \"If this team plays, how well do they keep the opponents from scoring?\"": "👉 En clair : « Si cette équipe joue, empêche-t-elle bien les adversaires de marquer ? »",
+
+	"NP DPR — Non-Penalty Defensive Power Rating":                           "NP DPR — Indice de Puissance Défensive hors Pénalités",
+	"Same as DPR, but ignores penalty points.":                              "Comme le DPR, mais sans les points de pénalité.",
+	"Focuses only on how well a team limits actual scoring, not ref calls.": "Mesure seulement la capacité d'une équipe à limiter le score réel, pas les décisions des arbitres.",
+	"👉 Great for identifying clean, effective defense.":                     "👉 Idéal pour repérer une défense propre et efficace.",
+
+	"NP AVG — Non-Penalty Average Score":                                                                  "NP AVG — Score Moyen hors Pénalités",
+	"The average number of non-penalty points a team's alliance scores in matches involving them.":        "Le nombre moyen de points hors pénalités marqués par l'alliance d'une équipe dans ses matchs.",
+	"Subtracts the penalties commited by the team's alliance to determine the true scoring contribution.": "Retire les pénalités commises par l'alliance de l'équipe pour obtenir sa vraie contribution au score.",
+	"Less math-heavy than OPR, more literal.":                                                             "Moins mathématique que l'OPR, plus concret.",
+	"Still partner-dependent, but easier to interpret.":                                                   "Dépend toujours des partenaires, mais plus facile à interpréter.",
+	"👉 Think: \"On average, when this team plays, how many real points get scored?\"":                     "👉 En clair : « En moyenne, quand cette équipe joue, combien de vrais points sont marqués ? »",
+
+	"WPA — Win Probability Added": "WPA — Probabilité de Victoire Ajoutée",
+	"Before each match, a win probability is predicted from the OPRs the alliances brought into the event.": "Avant chaque match, une probabilité de victoire est prédite à partir des OPR des alliances à leur arrivée à l'événement.",
+	"WPA is the number of matches a team won minus the number its alliances were expected to win.":          "Le WPA est le nombre de matchs gagnés par une équipe moins le nombre que ses alliances devaient gagner.",
+	"Positive WPA → team wins more than its ratings predict":                                                "WPA positif → l'équipe gagne plus que ne le prédisent ses indices",
+	"Negative WPA → team wins less than its ratings predict":                                                "WPA négatif → l'équipe gagne moins que ne le prédisent ses indices",
+	"👉 Think: \"Does this team come through when it counts?\"":                                              "👉 En clair : « Cette équipe répond-elle présent quand ça compte ? »",
+
+	"Visiting team, whose home region is outside the region": "Équipe visiteuse, dont la région d'origine est hors de la région",
+	"Unknown country": "Pays inconnu",
+	"teams":           "équipes",
+	"team":            "équipe",
+
+	"ID":             "ID",
+	"Given To":       "Décerné À",
+	"Advancement":    "Qualification",
+	"Description":    "Description",
+	"Person":         "Personne",
+	"pts":            "pts",
+	"Playoff points": "Points des éliminatoires",
+	"Recipient":      "Lauréat",
+}
@@ -0,0 +1,138 @@
+package terminal
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderEventPreview renders a preview of an event's field: the top teams by their season so far, followed by the
+// predicted top seeds, the teams that have already advanced, and the rookies.
+func RenderEventPreview(preview *query.EventPreview) string {
+	if preview == nil || preview.Event == nil {
+		return "No event data available\n"
+	}
+
+	var sb strings.Builder
+	event := preview.Event
+
+	// Render event information header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Event Preview\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Code: %s\n", event.EventCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Name: %s\n", event.Name))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Location: %s, %s, %s\n", event.City, event.StateProv, event.Country))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Dates: %s to %s\n",
+		locale.Date(event.DateStart),
+		locale.Date(event.DateEnd)))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Teams: %d (%d without an event this season)\n\n", preview.Teams, preview.Unranked))
+
+	if preview.Teams == 0 {
+		sb.WriteString("No teams are registered for this event.\n")
+		return sb.String()
+	}
+
+	if len(preview.TopTeams) > 0 {
+		sb.WriteString(color.YellowString("Top Teams:\n"))
+		sb.WriteString(renderPreviewTeams(preview.TopTeams))
+		sb.WriteString("\n")
+	}
+
+	if len(preview.Seeds) > 0 {
+		sb.WriteString(color.YellowString("Predicted Top Seeds:\n"))
+		for i, pt := range preview.Seeds {
+			sb.WriteString(color.WhiteString("  %d. %d %s (OPR %s)\n", i+1, pt.Team.TeamID, pt.Team.Name, locale.Number("%.2f", pt.OPR)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(color.YellowString("Already Advanced:\n"))
+	if len(preview.Advancers) == 0 {
+		sb.WriteString(color.WhiteString("  None\n"))
+	}
+	for _, pt := range preview.Advancers {
+		sb.WriteString(color.WhiteString("  • %d %s (from %s)\n", pt.Team.TeamID, pt.Team.Name, pt.AdvancedFrom))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(color.YellowString("Rookies:\n"))
+	if len(preview.Rookies) == 0 {
+		sb.WriteString(color.WhiteString("  None\n"))
+	}
+	for _, pt := range preview.Rookies {
+		sb.WriteString(color.WhiteString("  • %d %s\n", pt.Team.TeamID, pt.Team.Name))
+	}
+
+	return sb.String()
+}
+
+// renderPreviewTeams renders the season so far of the top teams in an event's field.
+func renderPreviewTeams(teams []*query.PreviewTeam) string {
+	columns := newTableColumns(previewTeamsColumns...)
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	var sb strings.Builder
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+		}),
+	)
+
+	table.Header(columns.headers())
+
+	for i, pt := range teams {
+		var notes []string
+		if pt.AdvancedFrom != "" {
+			notes = append(notes, "Advanced from "+pt.AdvancedFrom)
+		}
+		if pt.Rookie {
+			notes = append(notes, "Rookie")
+		}
+		table.Append(columns.row(
+			strconv.Itoa(i+1),
+			strconv.Itoa(pt.Team.TeamID),
+			pt.Team.Name,
+			pt.Team.HomeRegion,
+			strconv.Itoa(pt.Events),
+			locale.Number("%.2f", pt.OPR),
+			locale.Number("%.2f", pt.NpOPR),
+			locale.Number("%.2f", pt.NpAVG),
+			strings.Join(notes, ", "),
+		))
+	}
+
+	table.Render()
+	return sb.String()
+}
+
+// previewTeamsColumns are the columns of the top teams in an event preview.
+var previewTeamsColumns = []column{
+	{Key: "rank", Header: "#", HeaderAlign: tw.AlignCenter},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta, color.Bold}}, HeaderAlign: tw.AlignLeft},
+	{Key: "name", Header: "Name", Tint: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, HeaderAlign: tw.AlignLeft},
+	{Key: "region", Header: "Region", HeaderAlign: tw.AlignLeft},
+	{Key: "events", Header: "Events", HeaderAlign: tw.AlignCenter},
+	{Key: "opr", Header: "OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignRight},
+	{Key: "npopr", Header: "npOPR", HeaderAlign: tw.AlignRight},
+	{Key: "npavg", Header: "npAVG", HeaderAlign: tw.AlignRight},
+	{Key: "notes", Header: "Notes", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, HeaderAlign: tw.AlignLeft},
+}
@@ -0,0 +1,105 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderQueue renders the next matches to be played at an event, with the names of the teams to queue for each
+// match and the estimated wait until it starts.
+func RenderQueue(report *query.QueueReport) string {
+	if report == nil || report.Event == nil {
+		return "No event data available\n"
+	}
+	event := report.Event
+
+	var sb strings.Builder
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("Match Queue for %s (%s)\n", event.Name, event.EventCode))
+	if report.CycleTime > 0 {
+		sb.WriteString(color.New(color.FgCyan).Sprintf("Average cycle time: %s over %d match cycles\n\n", formatDuration(report.CycleTime), report.Cycles))
+	} else {
+		sb.WriteString(color.New(color.FgCyan).Sprint("Average cycle time: not enough matches have been played\n\n"))
+	}
+
+	if len(report.Matches) == 0 {
+		sb.WriteString(color.YellowString("No upcoming matches are scheduled.\n"))
+		return sb.String()
+	}
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for column 0 (Match)
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 1 (Matches Away)
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 2 (Wait)
+				{FG: renderer.Colors{color.FgHiRed}},   // Red for column 3 (Red Alliance)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 4 (Blue Alliance)
+			},
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.On}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: []tw.Align{tw.AlignLeft, tw.AlignRight, tw.AlignRight, tw.AlignLeft, tw.AlignLeft}},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Match", "Matches Away", "Wait", "Red Alliance", "Blue Alliance"}))
+
+	for _, m := range report.Matches {
+		away := strconv.Itoa(m.MatchesAway)
+		if m.MatchesAway == 0 {
+			away = "Next"
+		}
+		wait := "-"
+		if m.Wait > 0 {
+			wait = "~" + formatDuration(m.Wait)
+		}
+		table.Append([]string{
+			m.Label(),
+			away,
+			wait,
+			queuedTeams(m.Red),
+			queuedTeams(m.Blue),
+		})
+	}
+	table.Render()
+	return sb.String()
+}
+
+// queuedTeams returns the numbers and names of an alliance's teams, one team to a line.
+func queuedTeams(teams []*query.OverlayTeam) string {
+	lines := make([]string, 0, len(teams))
+	for _, team := range teams {
+		lines = append(lines, fmt.Sprintf("%5d - %s", team.Team.TeamID, team.Team.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatDuration formats a duration to the nearest minute, or in seconds if it is less than a minute.
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%d sec", int(d.Round(time.Second).Seconds()))
+	}
+	return fmt.Sprintf("%d min", int(d.Round(time.Minute).Minutes()))
+}
@@ -0,0 +1,114 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// RenderRegionTrend renders a region's seasons side by side, oldest first, with the change in the number of teams
+// and advancements from the season before.
+func RenderRegionTrend(trend *query.RegionTrend) string {
+	if trend == nil {
+		return "No region data available\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprintf("Season Trend for %s\n\n", trend.RegionCode))
+
+	if len(trend.Seasons) == 0 {
+		sb.WriteString(color.YellowString("No events have been played in the region in these seasons.\n"))
+		return sb.String()
+	}
+
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold}, // Green bold headers
+		},
+		Column: renderer.Tint{
+			FG: renderer.Colors{color.FgCyan}, // Default cyan for rows
+			Columns: []renderer.Tint{
+				{FG: renderer.Colors{color.FgYellow}},  // Yellow for column 0 (Season)
+				{FG: renderer.Colors{color.FgCyan}},    // Cyan for column 1 (Events)
+				{FG: renderer.Colors{color.FgMagenta}}, // Magenta for column 2 (Teams)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 3 (Avg Score)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 4 (Avg NP Score)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 5 (High Score)
+				{FG: renderer.Colors{color.FgHiBlue}},  // Blue for column 6 (Avg npOPR)
+				{FG: renderer.Colors{color.FgHiGreen}}, // Green for column 7 (To Champs)
+				{FG: renderer.Colors{color.FgHiGreen}}, // Green for column 8 (To Worlds)
+			},
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White borders
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, // White separators
+		Settings:  tw.Settings{Separators: tw.Separators{BetweenRows: tw.Off}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignRight},
+			},
+		}),
+	)
+	table.Header(translateAll([]string{"Season", "Events", "Teams", "Avg Score", "Avg NP Score", "High Score", "Avg npOPR", "To Champs", "To Worlds"}))
+
+	for _, season := range trend.Seasons {
+		previous := trend.Previous(season)
+		avgScore, avgNpScore, highScore, avgNpOPR := "-", "-", "-", "-"
+		if season.Matches > 0 {
+			avgScore = locale.Number("%.1f", season.AverageScore)
+			avgNpScore = locale.Number("%.1f", season.AverageNpScore)
+			highScore = strconv.Itoa(season.HighScore)
+			avgNpOPR = locale.Number("%.2f", season.AverageNpOPR)
+		}
+		var teams, toChampionship, toWorlds string
+		if previous != nil {
+			teams = withCountChange(season.Teams, previous.Teams)
+			toChampionship = withCountChange(season.ToChampionship, previous.ToChampionship)
+			toWorlds = withCountChange(season.ToWorlds, previous.ToWorlds)
+		} else {
+			teams = strconv.Itoa(season.Teams)
+			toChampionship = strconv.Itoa(season.ToChampionship)
+			toWorlds = strconv.Itoa(season.ToWorlds)
+		}
+		table.Append([]string{
+			strconv.Itoa(season.Year),
+			strconv.Itoa(season.Events),
+			teams,
+			avgScore,
+			avgNpScore,
+			highScore,
+			avgNpOPR,
+			toChampionship,
+			toWorlds,
+		})
+	}
+	table.Render()
+
+	sb.WriteString(color.New(color.FgWhite).Sprint("Scores depend on each season's game, so compare them within a season; team counts and advancements compare across seasons.\n"))
+	if len(trend.Missing) > 0 {
+		missing := make([]string, 0, len(trend.Missing))
+		for _, year := range trend.Missing {
+			missing = append(missing, strconv.Itoa(year))
+		}
+		sb.WriteString(color.YellowString("No events have been played in the region in %s; sync the season with ftcdata to include it.\n", strings.Join(missing, ", ")))
+	}
+	return sb.String()
+}
+
+// withCountChange formats a count along with its change from the previous count, such as "142 (+12)".
+func withCountChange(count int, previous int) string {
+	return fmt.Sprintf("%d (%+d)", count, count-previous)
+}
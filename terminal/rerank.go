@@ -0,0 +1,132 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/internal/locale"
+	"github.com/rbrabson/ftcstanding/query"
+)
+
+// rerankSchemes describe the ranking schemes of RerankQuery, along with the header of the value they rank by.
+var rerankSchemes = map[string]struct {
+	Description string
+	Header      string
+}{
+	"official": {"Ranking score under the season's rules", "RS"},
+	"record":   {"2 points for a win and 1 for a tie, averaged over the matches played", "RP Avg"},
+	"avg":      {"Average alliance score, including foul points", "Avg Score"},
+	"npavg":    {"Average alliance score before fouls", "npAVG"},
+	"opr":      {"OPR at the event", "OPR"},
+}
+
+// RenderRerank renders an event's qualification rankings recomputed under a ranking scheme, alongside each team's
+// official rank and how far it moved, followed by the changes to the teams ranked high enough to captain an
+// alliance.
+func RenderRerank(rerank *query.EventRerank) string {
+	if rerank == nil || rerank.Event == nil {
+		return "No event data available\n"
+	}
+
+	var sb strings.Builder
+	event := rerank.Event
+	scheme := rerankSchemes[rerank.Scheme]
+
+	// Render event information header
+	sb.WriteString(color.New(color.FgGreen, color.Bold).Sprint("Event Re-Ranking\n"))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Code: %s\n", event.EventCode))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Name: %s\n", event.Name))
+	sb.WriteString(color.New(color.FgCyan).Sprintf("Scheme: %s (%s)\n\n", rerank.Scheme, scheme.Description))
+
+	if len(rerank.Teams) == 0 {
+		sb.WriteString("No rankings found for this event.\n")
+		return sb.String()
+	}
+
+	spec := []column{rerankColumns[0], rerankColumns[1], rerankColumns[2], moveColumn, rerankColumns[3], rerankColumns[4], rerankColumns[5]}
+	spec[4].Header = scheme.Header
+	columns := newTableColumns(spec...)
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	table := tablewriter.NewTable(&sb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
+			},
+		}),
+	)
+
+	table.Header(columns.headers())
+
+	movement := make(map[int]int, len(rerank.Teams))
+	for _, rt := range rerank.Teams {
+		movement[rt.Team.TeamID] = rt.Movement()
+	}
+	for _, rt := range rerank.Teams {
+		table.Append(columns.row(
+			strconv.Itoa(rt.Rank),
+			fmt.Sprintf("%5d - %s", rt.Team.TeamID, rt.Team.Name),
+			strconv.Itoa(rt.OfficialRank),
+			formatMovement(rt.Team.TeamID, movement),
+			locale.Number("%.2f", rt.Value),
+			fmt.Sprintf("%d–%d–%d", rt.Wins, rt.Losses, rt.Ties),
+			strconv.Itoa(rt.Matches),
+		))
+	}
+
+	table.Render()
+
+	// List the teams that move into and out of the alliance captain positions
+	var in, out []string
+	for _, rt := range rerank.Teams {
+		switch {
+		case rt.Rank <= rerank.Alliances && rt.OfficialRank > rerank.Alliances:
+			in = append(in, fmt.Sprintf("%d %s (from %d)", rt.Team.TeamID, rt.Team.Name, rt.OfficialRank))
+		case rt.Rank > rerank.Alliances && rt.OfficialRank <= rerank.Alliances:
+			out = append(out, fmt.Sprintf("%d %s (to %d)", rt.Team.TeamID, rt.Team.Name, rt.Rank))
+		}
+	}
+	sb.WriteString(color.YellowString("\nTop %d Seeds:\n", rerank.Alliances))
+	if len(in) == 0 {
+		sb.WriteString(color.WhiteString("  Unchanged\n"))
+	}
+	for _, team := range in {
+		sb.WriteString(color.GreenString("  ▲ %s\n", team))
+	}
+	for _, team := range out {
+		sb.WriteString(color.RedString("  ▼ %s\n", team))
+	}
+
+	return sb.String()
+}
+
+// rerankColumns are the columns of an event's rankings under a ranking scheme, other than the movement column. The
+// header of the value column is replaced with the value the scheme ranks by.
+var rerankColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta, color.Bold}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignRight},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "official", Header: "Official", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "value", Header: "Value", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "record", Header: "W–L–T", HeaderAlign: tw.AlignCenter, Align: tw.AlignCenter},
+	{Key: "matches", Header: "Matches", HeaderAlign: tw.AlignCenter, Align: tw.AlignCenter},
+}
@@ -2,14 +2,18 @@ package terminal
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/renderer"
 	"github.com/olekukonko/tablewriter/tw"
+	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/locale"
 	"github.com/rbrabson/ftcstanding/query"
 )
 
@@ -23,18 +27,88 @@ const (
 	SortByDPR     SortBy = "dpr"
 	SortByNpDPR   SortBy = "npdpr"
 	SortByNpAVG   SortBy = "npavg"
+	SortByAutoOPR SortBy = "autoopr"
+	SortByAutoPct SortBy = "autopct"
 	SortByMatches SortBy = "matches"
 	SortByTeamID  SortBy = "team"
 )
 
+// showsAuto returns true if the sort is by an autonomous metric, which adds the autonomous columns to the team
+// performance rankings.
+func showsAuto(sortBy SortBy) bool {
+	return sortBy == SortByAutoOPR || sortBy == SortByAutoPct
+}
+
 // RenderTeamPerformance renders team performance metrics in a table format with sorting.
-// If limit is greater than 0, only the top 'limit' teams are displayed.
-func RenderTeamPerformance(performances []query.TeamPerformance, eventCode string, sortBy SortBy, region string, year int, limit int) string {
+// If limit is greater than 0, only the top 'limit' teams are displayed. If showsCountry is true, a column with the
+// ISO code of each team's country is included, which is usually wanted only for rankings that aren't of a single
+// region.
+func RenderTeamPerformance(performances []query.TeamPerformance, eventCode string, sortBy SortBy, region string, year int, limit int, showsCountry bool) string {
+	return renderTeamPerformance(performances, nil, nil, eventCode, sortBy, region, year, limit, time.Time{}, showsCountry)
+}
+
+// RenderTeamPerformanceMovement renders team performance metrics like RenderTeamPerformance, adding a column
+// that shows how many places each team has moved since the previous rankings (e.g. ▲3 or ▼1).
+// The previous rankings are sorted using the same criteria before the movement is calculated.
+func RenderTeamPerformanceMovement(performances []query.TeamPerformance, previous []query.TeamPerformance, eventCode string, sortBy SortBy, region string, year int, limit int, since time.Time) string {
+	if previous == nil {
+		previous = []query.TeamPerformance{}
+	}
+	return renderTeamPerformance(performances, previous, nil, eventCode, sortBy, region, year, limit, since, region == "")
+}
+
+// RenderTeamPerformanceWPA renders team performance metrics like RenderTeamPerformance, adding a column that shows
+// each team's win probability added (e.g. +1.25), the number of matches the team won above or below what its
+// alliances were expected to win. If previous is non-nil, the movement column of RenderTeamPerformanceMovement is
+// included as well.
+func RenderTeamPerformanceWPA(performances []query.TeamPerformance, previous []query.TeamPerformance, wpa map[int]query.TeamWPA, eventCode string, sortBy SortBy, region string, year int, limit int, since time.Time) string {
+	if wpa == nil {
+		wpa = map[int]query.TeamWPA{}
+	}
+	return renderTeamPerformance(performances, previous, wpa, eventCode, sortBy, region, year, limit, since, region == "")
+}
+
+// RenderTeamPerformanceByCountry renders team performance metrics like RenderTeamPerformance, with a table for each
+// country that ranks the country's teams. If limit is greater than 0, only the top 'limit' teams of each country are
+// displayed.
+func RenderTeamPerformanceByCountry(performances []query.TeamPerformance, eventCode string, sortBy SortBy, region string, year int, limit int) string {
 	if len(performances) == 0 {
 		return color.YellowString("No performance data available for region %s in year %d\n", region, year)
 	}
 
-	// Sort the performances based on the specified criteria
+	sortTeamPerformances(performances, sortBy)
+
+	var sb strings.Builder
+	writeTeamPerformanceHeader(&sb, eventCode, sortBy, region, year, time.Time{}, false)
+	writeMetricDefinitions(&sb)
+	for _, group := range query.GroupByCountry(performances, func(perf query.TeamPerformance) string { return perf.Country }) {
+		sb.WriteString("\n" + countryHeading(group.Country, len(group.Teams)))
+		teams := group.Teams
+		if limit > 0 && limit < len(teams) {
+			teams = teams[:limit]
+		}
+		writeTeamPerformanceTable(&sb, teams, nil, nil, sortBy, false)
+	}
+	return sb.String()
+}
+
+// countryHeading returns the heading shown above the teams from a country, with the country's flag if it is known.
+func countryHeading(country string, teams int) string {
+	if country == "" {
+		country = translate("Unknown country")
+	}
+	if flag := query.CountryFlag(country); flag != "" {
+		country = flag + " " + country
+	}
+	unit := "teams"
+	if teams == 1 {
+		unit = "team"
+	}
+	return color.HiGreenString("%s (%d %s)\n", country, teams, translate(unit))
+}
+
+// sortTeamPerformances sorts the performances based on the specified criteria.
+func sortTeamPerformances(performances []query.TeamPerformance, sortBy SortBy) {
 	sort.Slice(performances, func(i, j int) bool {
 		switch sortBy {
 		case SortByOPR:
@@ -49,6 +123,10 @@ func RenderTeamPerformance(performances []query.TeamPerformance, eventCode strin
 			return performances[i].NpDPR < performances[j].NpDPR // Lower is better for defense
 		case SortByNpAVG:
 			return performances[i].NpAVG > performances[j].NpAVG
+		case SortByAutoOPR:
+			return performances[i].AutoOPR > performances[j].AutoOPR
+		case SortByAutoPct:
+			return performances[i].AutoShare() > performances[j].AutoShare()
 		case SortByMatches:
 			return performances[i].Matches > performances[j].Matches
 		case SortByTeamID:
@@ -57,6 +135,202 @@ func RenderTeamPerformance(performances []query.TeamPerformance, eventCode strin
 			return performances[i].NpAVG > performances[j].NpAVG
 		}
 	})
+}
+
+// teamPerformanceColumns are the columns of the team performance rankings.
+var teamPerformanceColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "region", Header: "Region", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "matches", Header: "Matches", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiRed}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "ccwm", Header: "CCWM", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "opr", Header: "OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npopr", Header: "npOPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "dpr", Header: "DPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npdpr", Header: "npDPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npavg", Header: "npAVG", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
+
+// autoColumns are the columns of the team performance rankings showing each team's autonomous scoring, which are
+// shown when the rankings are sorted by it.
+var autoColumns = []column{
+	{Key: "autoopr", Header: "Auto OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "autopct", Header: "Auto %", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
+
+// moveColumn is the column of the team performance rankings showing how many places each team has moved.
+var moveColumn = column{Key: "move", Header: "Move", HeaderAlign: tw.AlignCenter, Align: tw.AlignRight}
+
+// performanceCountryColumn is the column of the team performance rankings that aren't of a single region showing the
+// ISO code of each team's country.
+var performanceCountryColumn = column{Key: "country", Header: "Country", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft}
+
+// performanceDivisionColumn is the column of the team performance rankings of a multi-division event showing the
+// division each team played in.
+var performanceDivisionColumn = column{Key: "division", Header: "Division", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft}
+
+// wpaColumn is the column of the team performance rankings showing each team's win probability added.
+var wpaColumn = column{Key: "wpa", Header: "WPA", HeaderAlign: tw.AlignCenter, Align: tw.AlignRight}
+
+// teamEventPerformanceColumns are the columns of the team performance rankings by event.
+var teamEventPerformanceColumns = []column{
+	{Key: "rank", Header: "Rank", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "team", Header: "Team", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "region", Header: "Region", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiCyan}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "event", Header: "Event", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiBlue}}, HeaderAlign: tw.AlignLeft, Align: tw.AlignLeft},
+	{Key: "matches", Header: "Matches", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiRed}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "ccwm", Header: "CCWM", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "opr", Header: "OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npopr", Header: "npOPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "dpr", Header: "DPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npdpr", Header: "npDPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiYellow}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+	{Key: "npavg", Header: "npAVG", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiMagenta}}, HeaderAlign: tw.AlignCenter, Align: tw.AlignRight},
+}
+
+// formatMovement formats the number of places a team has moved as an arrow indicator.
+func formatMovement(teamID int, movement map[int]int) string {
+	places, ok := movement[teamID]
+	switch {
+	case !ok:
+		return color.HiBlueString("new")
+	case places > 0:
+		return color.GreenString("▲%d", places)
+	case places < 0:
+		return color.RedString("▼%d", -places)
+	default:
+		return "–"
+	}
+}
+
+// formatWPA formats a team's win probability added, in green if the team won more matches than expected and in
+// red if it won fewer.
+func formatWPA(teamID int, wpa map[int]query.TeamWPA) string {
+	teamWPA, ok := wpa[teamID]
+	if !ok {
+		return "–"
+	}
+	switch value := teamWPA.WPA(); {
+	case value >= 0.005:
+		return color.GreenString(locale.Number("%+.2f", value))
+	case value <= -0.005:
+		return color.RedString(locale.Number("%.2f", value))
+	default:
+		return locale.Number("%.2f", 0)
+	}
+}
+
+// metricDefinition explains one of the metrics in the team performance rankings.
+type metricDefinition struct {
+	Name        string
+	Description []string
+	Tip         []string // Plain-language summary, shown after the description
+}
+
+// metricDefinitions are the metrics shown in the team performance rankings, in the order they are explained.
+var metricDefinitions = []metricDefinition{
+	{
+		Name: "CCWM — Calculated Contribution to Winning Margin",
+		Description: []string{
+			"Estimates how much a team affects the margin of victory or loss.",
+			"Positive CCWM → team usually helps alliances win by more",
+			"Negative CCWM → alliances with this team often lose by more",
+		},
+		Tip: []string{"👉 This blends offense, defense, and penalties into one \"do they help us win?\" number."},
+	},
+	{
+		Name: "OPR — Offensive Power Rating",
+		Description: []string{
+			"An estimate of how many points a team contributes per match to their alliance.",
+			"Calculated using math across all matches, factoring in partners and opponents.",
+			"Higher OPR = stronger overall scoring impact.",
+		},
+		Tip: []string{"👉 Think of it as: \"If this team plays, how many points do they add?\""},
+	},
+	{
+		Name: "NP OPR — Non-Penalty Offensive Power Rating",
+		Description: []string{
+			"Same idea as OPR, but penalties are removed.",
+			"Only counts points scored through gameplay, not points gained because opponents messed up.",
+		},
+		Tip: []string{
+			"👉 Useful when you want to see true scoring ability, not \"we won because the other",
+			"   alliance kept getting penalties.\"",
+		},
+	},
+	{
+		Name: "DPR — Defensive Power Rating",
+		Description: []string{
+			"Estimates how many points a team allows opponents to score.",
+			"Lower DPR = better defense.",
+			"A strong defensive robot often has a noticeably low DPR even if OPR isn't huge.",
+		},
+		Tip: []string{"👉 Think of it as: \"If this team plays, how well do they keep the opponents from scoring?\""},
+	},
+	{
+		Name: "NP DPR — Non-Penalty Defensive Power Rating",
+		Description: []string{
+			"Same as DPR, but ignores penalty points.",
+			"Focuses only on how well a team limits actual scoring, not ref calls.",
+		},
+		Tip: []string{"👉 Great for identifying clean, effective defense."},
+	},
+	{
+		Name: "NP AVG — Non-Penalty Average Score",
+		Description: []string{
+			"The average number of non-penalty points a team's alliance scores in matches involving them.",
+			"Subtracts the penalties commited by the team's alliance to determine the true scoring contribution.",
+			"Less math-heavy than OPR, more literal.",
+			"Still partner-dependent, but easier to interpret.",
+		},
+		Tip: []string{"👉 Think: \"On average, when this team plays, how many real points get scored?\""},
+	},
+}
+
+// wpaDefinition explains the win probability added shown by RenderTeamPerformanceWPA.
+var wpaDefinition = metricDefinition{
+	Name: "WPA — Win Probability Added",
+	Description: []string{
+		"Before each match, a win probability is predicted from the OPRs the alliances brought into the event.",
+		"WPA is the number of matches a team won minus the number its alliances were expected to win.",
+		"Positive WPA → team wins more than its ratings predict",
+		"Negative WPA → team wins less than its ratings predict",
+	},
+	Tip: []string{"👉 Think: \"Does this team come through when it counts?\""},
+}
+
+// writeMetricDefinitions writes the explanations of the team performance metrics in the selected language, along
+// with the explanations of any extra metrics shown.
+func writeMetricDefinitions(sb *strings.Builder, extra ...metricDefinition) {
+	sb.WriteString(color.HiWhiteString("\n%s\n\n", translate("Metric Definitions:")))
+	for _, metric := range slices.Concat(metricDefinitions, extra) {
+		sb.WriteString(color.HiYellowString("%s\n", translate(metric.Name)))
+		for _, line := range metric.Description {
+			sb.WriteString(color.WhiteString("  %s\n", translate(line)))
+		}
+		for _, line := range metric.Tip {
+			sb.WriteString(color.HiCyanString("  %s\n", translate(line)))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// renderTeamPerformance renders the team performance table. If previous is non-nil, a movement column
+// comparing the rankings against previous is included, and if wpa is non-nil, a win probability added column is
+// included. If showsCountry is true, the country code of each team is included.
+func renderTeamPerformance(performances []query.TeamPerformance, previous []query.TeamPerformance, wpa map[int]query.TeamWPA, eventCode string, sortBy SortBy, region string, year int, limit int, since time.Time, showsCountry bool) string {
+	if len(performances) == 0 {
+		return color.YellowString("No performance data available for region %s in year %d\n", region, year)
+	}
+
+	// Sort the performances based on the specified criteria
+	sortTeamPerformances(performances, sortBy)
+
+	// Calculate the movement before the limit is applied so teams moving into the top ranks are tracked
+	var movement map[int]int
+	if previous != nil {
+		sortTeamPerformances(previous, sortBy)
+		movement = query.RankMovement(performances, previous)
+	}
 
 	// Apply limit if specified
 	if limit > 0 && limit < len(performances) {
@@ -64,8 +338,22 @@ func RenderTeamPerformance(performances []query.TeamPerformance, eventCode strin
 	}
 
 	var sb strings.Builder
+	writeTeamPerformanceHeader(&sb, eventCode, sortBy, region, year, since, movement != nil)
 
-	// Header
+	// Metric definitions
+	if wpa != nil {
+		writeMetricDefinitions(&sb, wpaDefinition)
+	} else {
+		writeMetricDefinitions(&sb)
+	}
+
+	writeTeamPerformanceTable(&sb, performances, movement, wpa, sortBy, showsCountry)
+	return sb.String()
+}
+
+// writeTeamPerformanceHeader writes the title of the team performance rankings, along with the date the movement is
+// shown since if showsMovement is true.
+func writeTeamPerformanceHeader(sb *strings.Builder, eventCode string, sortBy SortBy, region string, year int, since time.Time, showsMovement bool) {
 	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
 	if eventCode != "" {
 		sb.WriteString(color.HiGreenString("Team Performance Rankings - %s (%d) - Event: %s\n", region, year, eventCode))
@@ -73,46 +361,34 @@ func RenderTeamPerformance(performances []query.TeamPerformance, eventCode strin
 		sb.WriteString(color.HiGreenString("Team Performance Rankings - %s (%d)\n", region, year))
 	}
 	sb.WriteString(color.HiYellowString("Sorted by: %s\n", sortBy))
+	if showsMovement {
+		sb.WriteString(color.HiYellowString("Movement since: %s\n", since.Format(database.SnapshotDateFormat)))
+	}
 	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+}
 
-	// Metric definitions
-	sb.WriteString(color.HiWhiteString("\nMetric Definitions:\n\n"))
-
-	sb.WriteString(color.HiYellowString("CCWM — Calculated Contribution to Winning Margin\n"))
-	sb.WriteString(color.WhiteString("  Estimates how much a team affects the margin of victory or loss.\n"))
-	sb.WriteString(color.WhiteString("  Positive CCWM → team usually helps alliances win by more\n"))
-	sb.WriteString(color.WhiteString("  Negative CCWM → alliances with this team often lose by more\n"))
-	sb.WriteString(color.HiCyanString("  👉 This blends offense, defense, and penalties into one \"do they help us win?\" number.\n\n"))
-
-	sb.WriteString(color.HiYellowString("OPR — Offensive Power Rating\n"))
-	sb.WriteString(color.WhiteString("  An estimate of how many points a team contributes per match to their alliance.\n"))
-	sb.WriteString(color.WhiteString("  Calculated using math across all matches, factoring in partners and opponents.\n"))
-	sb.WriteString(color.WhiteString("  Higher OPR = stronger overall scoring impact.\n"))
-	sb.WriteString(color.HiCyanString("  👉 Think of it as: \"If this team plays, how many points do they add?\"\n\n"))
-
-	sb.WriteString(color.HiYellowString("NP OPR — Non-Penalty Offensive Power Rating\n"))
-	sb.WriteString(color.WhiteString("  Same idea as OPR, but penalties are removed.\n"))
-	sb.WriteString(color.WhiteString("  Only counts points scored through gameplay, not points gained because opponents messed up.\n"))
-	sb.WriteString(color.HiCyanString("  👉 Useful when you want to see true scoring ability, not \"we won because the other\n"))
-	sb.WriteString(color.HiCyanString("     alliance kept getting penalties.\"\n\n"))
-
-	sb.WriteString(color.HiYellowString("DPR — Defensive Power Rating\n"))
-	sb.WriteString(color.WhiteString("  Estimates how many points a team allows opponents to score.\n"))
-	sb.WriteString(color.WhiteString("  Lower DPR = better defense.\n"))
-	sb.WriteString(color.WhiteString("  A strong defensive robot often has a noticeably low DPR even if OPR isn't huge.\n"))
-	sb.WriteString(color.HiCyanString("  👉 Think of it as: \"If this team plays, how well do they keep the opponents from scoring?\"\n\n"))
-
-	sb.WriteString(color.HiYellowString("NP DPR — Non-Penalty Defensive Power Rating\n"))
-	sb.WriteString(color.WhiteString("  Same as DPR, but ignores penalty points.\n"))
-	sb.WriteString(color.WhiteString("  Focuses only on how well a team limits actual scoring, not ref calls.\n"))
-	sb.WriteString(color.HiCyanString("  👉 Great for identifying clean, effective defense.\n\n"))
-
-	sb.WriteString(color.HiYellowString("NP AVG — Non-Penalty Average Score\n"))
-	sb.WriteString(color.WhiteString("  The average number of non-penalty points a team's alliance scores in matches involving them.\n"))
-	sb.WriteString(color.WhiteString("  Subtracts the penalties commited by the team's alliance to determine the true scoring contribution.\n"))
-	sb.WriteString(color.WhiteString("  Less math-heavy than OPR, more literal.\n"))
-	sb.WriteString(color.WhiteString("  Still partner-dependent, but easier to interpret.\n"))
-	sb.WriteString(color.HiCyanString("  👉 Think: \"On average, when this team plays, how many real points get scored?\"\n\n"))
+// writeTeamPerformanceTable writes the table of team performances, which are already sorted and limited. If movement
+// is non-nil, a movement column is included, if wpa is non-nil, a win probability added column is included, and if
+// showsCountry is true, a column with the ISO code of each team's country is included.
+func writeTeamPerformanceTable(sb *strings.Builder, performances []query.TeamPerformance, movement map[int]int, wpa map[int]query.TeamWPA, sortBy SortBy, showsCountry bool) {
+	divisions := slices.ContainsFunc(performances, func(perf query.TeamPerformance) bool { return perf.Division != "" })
+	spec := teamPerformanceColumns
+	if divisions {
+		spec = slices.Insert(slices.Clone(spec), 2, performanceDivisionColumn)
+	}
+	if showsCountry {
+		spec = slices.Insert(slices.Clone(spec), 3, performanceCountryColumn)
+	}
+	if showsAuto(sortBy) {
+		spec = append(slices.Clone(spec), autoColumns...)
+	}
+	if movement != nil {
+		spec = append(slices.Clone(spec), moveColumn)
+	}
+	if wpa != nil {
+		spec = append(slices.Clone(spec), wpaColumn)
+	}
+	columns := newTableColumns(spec...)
 
 	colorCfg := renderer.ColorizedConfig{
 		Header: renderer.Tint{
@@ -120,78 +396,68 @@ func RenderTeamPerformance(performances []query.TeamPerformance, eventCode strin
 			BG: renderer.Colors{color.BgBlack},
 		},
 		Column: renderer.Tint{
-			FG: renderer.Colors{color.FgCyan},
-			Columns: []renderer.Tint{
-				{FG: renderer.Colors{color.FgMagenta}},   // Rank
-				{FG: renderer.Colors{color.FgHiWhite}},   // Team
-				{FG: renderer.Colors{color.FgHiCyan}},    // Region
-				{FG: renderer.Colors{color.FgHiRed}},     // Matches
-				{FG: renderer.Colors{color.FgHiMagenta}}, // CCWM
-				{FG: renderer.Colors{color.FgHiGreen}},   // OPR
-				{FG: renderer.Colors{color.FgHiGreen}},   // npOPR
-				{FG: renderer.Colors{color.FgHiYellow}},  // DPR
-				{FG: renderer.Colors{color.FgHiYellow}},  // npDPR
-				{FG: renderer.Colors{color.FgHiMagenta}}, // npAVG
-			},
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
 		},
 		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
 		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
 	}
 
-	table := tablewriter.NewTable(&sb,
+	table := tablewriter.NewTable(sb,
 		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
 		tablewriter.WithConfig(tablewriter.Config{
 			Header: tw.CellConfig{
-				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
-					tw.AlignLeft,   // Rank
-					tw.AlignLeft,   // Team
-					tw.AlignLeft,   // Region
-					tw.AlignCenter, // Matches
-					tw.AlignCenter, // CCWM
-					tw.AlignCenter, // OPR
-					tw.AlignCenter, // npOPR
-					tw.AlignCenter, // DPR
-					tw.AlignCenter, // npDPR
-					tw.AlignCenter, // npAVG
-				}},
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
 			},
 			Row: tw.CellConfig{
-				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
-					tw.AlignLeft,  // Rank
-					tw.AlignLeft,  // Team
-					tw.AlignLeft,  // Region
-					tw.AlignRight, // Matches
-					tw.AlignRight, // CCWM
-					tw.AlignRight, // OPR
-					tw.AlignRight, // npOPR
-					tw.AlignRight, // DPR
-					tw.AlignRight, // npDPR
-					tw.AlignRight, // npAVG
-				}},
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
 			},
 		}),
 	)
 
-	table.Header([]string{"Rank", "Team", "Region", "Matches", "CCWM", "OPR", "npOPR", "DPR", "npDPR", "npAVG"})
+	table.Header(columns.headers())
 
+	visiting := false
 	for i, perf := range performances {
-		table.Append([]string{
+		team := fmt.Sprintf("%5d - %s", perf.TeamID, perf.TeamName)
+		if perf.Visiting {
+			team += " *"
+			visiting = true
+		}
+		cells := []string{
 			strconv.Itoa(i + 1),
-			fmt.Sprintf("%5d - %s", perf.TeamID, perf.TeamName),
+			team,
 			perf.Region,
 			strconv.Itoa(perf.Matches),
-			fmt.Sprintf("%.2f", perf.CCWM),
-			fmt.Sprintf("%.2f", perf.OPR),
-			fmt.Sprintf("%.2f", perf.NpOPR),
-			fmt.Sprintf("%.2f", perf.DPR),
-			fmt.Sprintf("%.2f", perf.NpDPR),
-			fmt.Sprintf("%.2f", perf.NpAVG),
-		})
+			locale.Number("%.2f", perf.CCWM),
+			locale.Number("%.2f", perf.OPR),
+			locale.Number("%.2f", perf.NpOPR),
+			locale.Number("%.2f", perf.DPR),
+			locale.Number("%.2f", perf.NpDPR),
+			locale.Number("%.2f", perf.NpAVG),
+		}
+		if divisions {
+			cells = slices.Insert(cells, 2, perf.Division)
+		}
+		if showsCountry {
+			cells = slices.Insert(cells, 3, perf.CountryCode)
+		}
+		if showsAuto(sortBy) {
+			cells = append(cells, locale.Number("%.2f", perf.AutoOPR), locale.Number("%.1f%%", perf.AutoShare()))
+		}
+		if movement != nil {
+			cells = append(cells, formatMovement(perf.TeamID, movement))
+		}
+		if wpa != nil {
+			cells = append(cells, formatWPA(perf.TeamID, wpa))
+		}
+		table.Append(columns.row(cells...))
 	}
 
 	table.Render()
-
-	return sb.String()
+	if visiting {
+		sb.WriteString(color.HiYellowString("* %s\n", translate("Visiting team, whose home region is outside the region")))
+	}
 }
 
 // RenderTeamEventPerformance renders team performance metrics by event in a table format with sorting.
@@ -217,6 +483,10 @@ func RenderTeamEventPerformance(performances []query.TeamEventPerformance, event
 			return performances[i].NpDPR < performances[j].NpDPR // Lower is better for defense
 		case SortByNpAVG:
 			return performances[i].NpAVG > performances[j].NpAVG
+		case SortByAutoOPR:
+			return performances[i].AutoOPR > performances[j].AutoOPR
+		case SortByAutoPct:
+			return performances[i].AutoShare() > performances[j].AutoShare()
 		case SortByMatches:
 			return performances[i].Matches > performances[j].Matches
 		case SortByTeamID:
@@ -246,26 +516,19 @@ func RenderTeamEventPerformance(performances []query.TeamEventPerformance, event
 	sb.WriteString(color.HiYellowString("Sorted by: %s\n", sortBy))
 	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
 
+	spec := teamEventPerformanceColumns
+	if showsAuto(sortBy) {
+		spec = append(slices.Clone(spec), autoColumns...)
+	}
+	columns := newTableColumns(spec...)
 	colorCfg := renderer.ColorizedConfig{
 		Header: renderer.Tint{
 			FG: renderer.Colors{color.FgGreen, color.Bold},
 			BG: renderer.Colors{color.BgBlack},
 		},
 		Column: renderer.Tint{
-			FG: renderer.Colors{color.FgCyan},
-			Columns: []renderer.Tint{
-				{FG: renderer.Colors{color.FgMagenta}},   // Rank
-				{FG: renderer.Colors{color.FgHiWhite}},   // Team
-				{FG: renderer.Colors{color.FgHiCyan}},    // Region
-				{FG: renderer.Colors{color.FgHiBlue}},    // Event
-				{FG: renderer.Colors{color.FgHiRed}},     // Matches
-				{FG: renderer.Colors{color.FgHiMagenta}}, // CCWM
-				{FG: renderer.Colors{color.FgHiGreen}},   // OPR
-				{FG: renderer.Colors{color.FgHiGreen}},   // npOPR
-				{FG: renderer.Colors{color.FgHiYellow}},  // DPR
-				{FG: renderer.Colors{color.FgHiYellow}},  // npDPR
-				{FG: renderer.Colors{color.FgHiMagenta}}, // npAVG
-			},
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
 		},
 		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
 		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
@@ -275,54 +538,34 @@ func RenderTeamEventPerformance(performances []query.TeamEventPerformance, event
 		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
 		tablewriter.WithConfig(tablewriter.Config{
 			Header: tw.CellConfig{
-				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
-					tw.AlignLeft,   // Rank
-					tw.AlignLeft,   // Team
-					tw.AlignLeft,   // Region
-					tw.AlignLeft,   // Event
-					tw.AlignCenter, // Matches
-					tw.AlignCenter, // CCWM
-					tw.AlignCenter, // OPR
-					tw.AlignCenter, // npOPR
-					tw.AlignCenter, // DPR
-					tw.AlignCenter, // npDPR
-					tw.AlignCenter, // npAVG
-				}},
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
 			},
 			Row: tw.CellConfig{
-				Alignment: tw.CellAlignment{PerColumn: []tw.Align{
-					tw.AlignLeft,  // Rank
-					tw.AlignLeft,  // Team
-					tw.AlignLeft,  // Region
-					tw.AlignLeft,  // Event
-					tw.AlignRight, // Matches
-					tw.AlignRight, // CCWM
-					tw.AlignRight, // OPR
-					tw.AlignRight, // npOPR
-					tw.AlignRight, // DPR
-					tw.AlignRight, // npDPR
-					tw.AlignRight, // npAVG
-				}},
+				Alignment: tw.CellAlignment{PerColumn: columns.alignments()},
 			},
 		}),
 	)
 
-	table.Header([]string{"Rank", "Team", "Region", "Event", "Matches", "CCWM", "OPR", "npOPR", "DPR", "npDPR", "npAVG"})
+	table.Header(columns.headers())
 
 	for i, perf := range performances {
-		table.Append([]string{
+		cells := []string{
 			strconv.Itoa(i + 1),
 			fmt.Sprintf("%5d - %s", perf.TeamID, perf.TeamName),
 			perf.Region,
 			perf.EventCode,
 			strconv.Itoa(perf.Matches),
-			fmt.Sprintf("%.2f", perf.CCWM),
-			fmt.Sprintf("%.2f", perf.OPR),
-			fmt.Sprintf("%.2f", perf.NpOPR),
-			fmt.Sprintf("%.2f", perf.DPR),
-			fmt.Sprintf("%.2f", perf.NpDPR),
-			fmt.Sprintf("%.2f", perf.NpAVG),
-		})
+			locale.Number("%.2f", perf.CCWM),
+			locale.Number("%.2f", perf.OPR),
+			locale.Number("%.2f", perf.NpOPR),
+			locale.Number("%.2f", perf.DPR),
+			locale.Number("%.2f", perf.NpDPR),
+			locale.Number("%.2f", perf.NpAVG),
+		}
+		if showsAuto(sortBy) {
+			cells = append(cells, locale.Number("%.2f", perf.AutoOPR), locale.Number("%.1f%%", perf.AutoShare()))
+		}
+		table.Append(columns.row(cells...))
 	}
 
 	table.Render()
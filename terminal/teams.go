@@ -10,6 +10,7 @@ import (
 	"github.com/olekukonko/tablewriter/renderer"
 	"github.com/olekukonko/tablewriter/tw"
 	"github.com/rbrabson/ftcstanding/database"
+	"github.com/rbrabson/ftcstanding/internal/locale"
 	"github.com/rbrabson/ftcstanding/query"
 )
 
@@ -66,7 +67,7 @@ func RenderTeams(teams []*database.Team) string {
 			},
 		}),
 	)
-	table.Header([]string{"Team", "Country", "Region", "Location", "Rookie Year"})
+	table.Header(translateAll([]string{"Team", "Country", "Region", "Location", "Rookie Year"}))
 
 	for _, team := range teams {
 		location := fmt.Sprintf("%s, %s, %s", team.City, team.StateProv, team.Country)
@@ -85,11 +86,48 @@ func RenderTeams(teams []*database.Team) string {
 	return sb.String()
 }
 
+// RenderTeamsByCountry renders a list of teams with a table for each country, headed by the country's flag.
+func RenderTeamsByCountry(teams []*database.Team) string {
+	var sb strings.Builder
+	for i, group := range query.GroupByCountry(teams, func(team *database.Team) string { return team.Country }) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(countryHeading(group.Country, len(group.Teams)))
+		sb.WriteString(RenderTeams(group.Teams))
+	}
+	return sb.String()
+}
+
+// formerValues returns the names or regions a team had before, most recent first, each with the date it was last
+// replaced. Values that are the same as the current one aren't listed.
+func formerValues(history []*database.TeamHistory, current string, value func(*database.TeamHistory) string) []string {
+	var values []string
+	seen := map[string]bool{current: true}
+	for _, th := range history {
+		v := value(th)
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, fmt.Sprintf("%s (until %s)", v, th.ValidTo.Local().Format("2006-01-02")))
+	}
+	return values
+}
+
 // formatRecord formats a Record as a W-L-T string.
 func formatRecord(r query.Record) string {
 	return fmt.Sprintf("%d-%d-%d", r.Wins, r.Losses, r.Ties)
 }
 
+// formatStandings formats where a team's value of a metric places it in its region and worldwide, such as
+// "3rd of 40 in USNC (92nd percentile), 150th of 6000 worldwide (97th percentile)".
+func formatStandings(region, world query.Standing, regionCode string) string {
+	return fmt.Sprintf("%s of %d in %s (%s percentile), %s of %d worldwide (%s percentile)",
+		ordinal(region.Rank), region.Teams, regionCode, ordinal(int(region.Percentile)),
+		ordinal(world.Rank), world.Teams, ordinal(int(world.Percentile)))
+}
+
 // RenderTeamDetails renders detailed information about a team including events, records, and awards.
 func RenderTeamDetails(details *query.TeamDetails) string {
 	if details == nil {
@@ -115,11 +153,20 @@ func RenderTeamDetails(details *query.TeamDetails) string {
 			}
 		}
 	}
+	formerNames := formerValues(details.History, details.Name, func(th *database.TeamHistory) string { return th.Name })
+	if len(formerNames) > 0 {
+		sb.WriteString(color.WhiteString("Formerly: %s\n", strings.Join(formerNames, ", ")))
+	}
 	sb.WriteString(color.WhiteString("Location: %s, %s, %s\n", details.City, details.StateProv, details.Country))
 	if details.RookieYear > 0 {
 		sb.WriteString(color.WhiteString("Rookie:   %d\n", details.RookieYear))
 	}
-	sb.WriteString(color.WhiteString("Region:   %s\n", details.Region))
+	formerRegions := formerValues(details.History, details.Region, func(th *database.TeamHistory) string { return th.HomeRegion })
+	if len(formerRegions) > 0 {
+		sb.WriteString(color.WhiteString("Region:   %s, formerly %s\n", details.Region, strings.Join(formerRegions, ", ")))
+	} else {
+		sb.WriteString(color.WhiteString("Region:   %s\n", details.Region))
+	}
 	sb.WriteString("\n")
 
 	// Overall Records
@@ -129,27 +176,31 @@ func RenderTeamDetails(details *query.TeamDetails) string {
 	sb.WriteString(color.WhiteString("  Playoff:       %s\n", formatRecord(details.PlayoffRecord)))
 	sb.WriteString("\n")
 
+	// Season Standings
+	if standings := details.Standings; standings != nil {
+		if standings.Tier != "" {
+			sb.WriteString(color.YellowString("Season Standings: ") + color.New(color.FgHiGreen, color.Bold).Sprintf("[%s]\n", standings.Tier))
+		} else {
+			sb.WriteString(color.YellowString("Season Standings:\n"))
+		}
+		sb.WriteString(color.WhiteString("  OPR:   %s\n", formatStandings(standings.RegionOPR, standings.WorldOPR, details.Region)))
+		sb.WriteString(color.WhiteString("  npAVG: %s\n", formatStandings(standings.RegionNpAVG, standings.WorldNpAVG, details.Region)))
+		sb.WriteString("\n")
+	}
+
 	// Events Table
 	if len(details.Events) > 0 {
 		sb.WriteString(color.YellowString("Events:\n"))
 
+		columns := newTableColumns(teamEventsColumns...)
 		colorCfg := renderer.ColorizedConfig{
 			Header: renderer.Tint{
 				FG: renderer.Colors{color.FgGreen, color.Bold},
 				BG: renderer.Colors{color.BgBlack},
 			},
 			Column: renderer.Tint{
-				FG: renderer.Colors{color.FgCyan},
-				Columns: []renderer.Tint{
-					{FG: renderer.Colors{color.FgMagenta}}, // Event Code
-					{FG: renderer.Colors{color.FgWhite}},   // Event Name
-					{},                                     // Qual Rank
-					{},                                     // Total Record
-					{},                                     // Qual Record
-					{},                                     // Playoff Record
-					{FG: renderer.Colors{color.FgHiGreen}}, // Advanced
-					{FG: renderer.Colors{color.FgYellow}},  // Awards
-				},
+				FG:      renderer.Colors{color.FgCyan},
+				Columns: columns.tints(),
 			},
 			Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
 			Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
@@ -160,19 +211,12 @@ func RenderTeamDetails(details *query.TeamDetails) string {
 			tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
 			tablewriter.WithConfig(tablewriter.Config{
 				Header: tw.CellConfig{
-					Alignment: tw.CellAlignment{PerColumn: []tw.Align{
-						tw.AlignLeft,   // Event Code
-						tw.AlignLeft,   // Event Name					tw.AlignCenter, // Qual Rank						tw.AlignCenter, // Total Record
-						tw.AlignCenter, // Qual Record
-						tw.AlignCenter, // Playoff Record
-						tw.AlignCenter, // Advanced
-						tw.AlignLeft,   // Awards
-					}},
+					Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
 				},
 			}),
 		)
 
-		table.Header([]string{"Event Code", "Event Name", "Rank", "Total", "Qual", "Playoff", "Advanced", "Awards"})
+		table.Header(columns.headers())
 
 		for _, event := range details.Events {
 			advancedStr := ""
@@ -190,7 +234,7 @@ func RenderTeamDetails(details *query.TeamDetails) string {
 				rankStr = strconv.Itoa(event.QualRank)
 			}
 
-			table.Append([]string{
+			table.Append(columns.row(
 				event.EventCode,
 				event.EventName,
 				rankStr,
@@ -199,7 +243,7 @@ func RenderTeamDetails(details *query.TeamDetails) string {
 				formatRecord(event.PlayoffRecord),
 				advancedStr,
 				awardsStr,
-			})
+			))
 		}
 
 		table.Render()
@@ -208,5 +252,231 @@ func RenderTeamDetails(details *query.TeamDetails) string {
 		sb.WriteString(color.YellowString("No events found for this team.\n"))
 	}
 
+	if len(details.Upcoming) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(renderUpcomingEvents(details.Upcoming))
+	}
+
+	return sb.String()
+}
+
+// renderUpcomingEvents renders the events a team is registered for but hasn't played in yet, along with the
+// strongest of the other teams registered for them.
+func renderUpcomingEvents(upcoming []query.UpcomingEvent) string {
+	var sb strings.Builder
+	sb.WriteString(color.YellowString("Upcoming Events:\n"))
+
+	columns := newTableColumns(upcomingEventsColumns...)
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	var tableSb strings.Builder
+	table := tablewriter.NewTable(&tableSb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+		}),
+	)
+
+	table.Header(columns.headers())
+
+	for _, event := range upcoming {
+		var location []string
+		for _, part := range []string{event.Venue, event.City, event.StateProv} {
+			if part != "" {
+				location = append(location, part)
+			}
+		}
+
+		notable := make([]string, 0, len(event.Notable))
+		for _, team := range event.Notable {
+			notable = append(notable, fmt.Sprintf("%d %s (npOPR %s)", team.TeamID, team.Name, locale.Number("%.1f", team.NpOPR)))
+		}
+
+		table.Append(columns.row(
+			event.EventCode,
+			event.EventName,
+			locale.Date(event.DateStart),
+			strings.Join(location, ", "),
+			strconv.Itoa(event.Registered),
+			strings.Join(notable, ", "),
+		))
+	}
+
+	table.Render()
+	sb.WriteString(tableSb.String())
+	return sb.String()
+}
+
+// teamEventsColumns are the columns of the events in a team's details.
+var teamEventsColumns = []column{
+	{Key: "event-code", Header: "Event Code", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft},
+	{Key: "event-name", Header: "Event Name", Tint: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, HeaderAlign: tw.AlignLeft},
+	{Key: "rank", Header: "Rank", HeaderAlign: tw.AlignCenter},
+	{Key: "record", Header: "Total", HeaderAlign: tw.AlignCenter},
+	{Key: "qual-record", Header: "Qual", HeaderAlign: tw.AlignCenter},
+	{Key: "playoff-record", Header: "Playoff", HeaderAlign: tw.AlignCenter},
+	{Key: "advanced", Header: "Advanced", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter},
+	{Key: "awards", Header: "Awards", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, HeaderAlign: tw.AlignLeft},
+}
+
+// upcomingEventsColumns are the columns of the upcoming events in a team's details. The notable teams are the
+// other registered teams with the best npOPR this season.
+var upcomingEventsColumns = []column{
+	{Key: "event-code", Header: "Event Code", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft},
+	{Key: "event-name", Header: "Event Name", Tint: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, HeaderAlign: tw.AlignLeft},
+	{Key: "date", Header: "Date", Tint: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, HeaderAlign: tw.AlignLeft},
+	{Key: "venue", Header: "Venue", HeaderAlign: tw.AlignLeft},
+	{Key: "registered", Header: "Teams", HeaderAlign: tw.AlignCenter},
+	{Key: "notable", Header: "Notable Teams", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, HeaderAlign: tw.AlignLeft},
+}
+
+// teamEventComparisonColumns are the columns of a team's event comparison.
+var teamEventComparisonColumns = []column{
+	{Key: "event-code", Header: "Event Code", Tint: renderer.Tint{FG: renderer.Colors{color.FgMagenta}}, HeaderAlign: tw.AlignLeft},
+	{Key: "date", Header: "Date", Tint: renderer.Tint{FG: renderer.Colors{color.FgWhite}}, HeaderAlign: tw.AlignLeft},
+	{Key: "rank", Header: "Rank", HeaderAlign: tw.AlignCenter},
+	{Key: "record", Header: "Record", HeaderAlign: tw.AlignCenter},
+	{Key: "opr", Header: "OPR", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiWhite}}, HeaderAlign: tw.AlignRight},
+	{Key: "npopr", Header: "npOPR", HeaderAlign: tw.AlignRight},
+	{Key: "ccwm", Header: "CCWM", HeaderAlign: tw.AlignRight},
+	{Key: "npavg", Header: "npAVG", HeaderAlign: tw.AlignRight},
+	{Key: "advanced", Header: "Advanced", Tint: renderer.Tint{FG: renderer.Colors{color.FgHiGreen}}, HeaderAlign: tw.AlignCenter},
+	{Key: "awards", Header: "Awards", Tint: renderer.Tint{FG: renderer.Colors{color.FgYellow}}, HeaderAlign: tw.AlignLeft},
+}
+
+// formatDelta formats the change in a metric since the previous event, or an empty string if there is no change.
+func formatDelta(delta float64) string {
+	switch {
+	case delta >= 0.005:
+		return " (" + locale.Number("%+.2f", delta) + ")"
+	case delta <= -0.005:
+		return " (" + locale.Number("%.2f", delta) + ")"
+	default:
+		return ""
+	}
+}
+
+// formatImprovement formats the change in a value between the first and latest events, colored green for an
+// improvement and red for a decline.
+func formatImprovement(first, latest float64) string {
+	delta := latest - first
+	text := fmt.Sprintf("%s → %s (%s)", locale.Number("%.2f", first), locale.Number("%.2f", latest), locale.Number("%+.2f", delta))
+	switch {
+	case delta >= 0.005:
+		return color.HiGreenString(text)
+	case delta <= -0.005:
+		return color.HiRedString(text)
+	default:
+		return color.WhiteString(text)
+	}
+}
+
+// RenderTeamEventComparison renders a team's metrics, rank, record, and awards at each event they attended in a
+// single table, followed by a summary of the team's improvement between their first and latest events.
+func RenderTeamEventComparison(comparison *query.TeamEventComparison) string {
+	if comparison == nil || comparison.Team == nil {
+		return "No team details available\n"
+	}
+	team := comparison.Team
+
+	var sb strings.Builder
+
+	// Team Header Information
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+	sb.WriteString(color.HiGreenString("Team %d - %s: Event Comparison\n", team.TeamID, team.Name))
+	sb.WriteString(color.HiCyanString("═══════════════════════════════════════════════════════════════\n"))
+
+	if len(comparison.Events) == 0 {
+		sb.WriteString(color.YellowString("No events found for this team.\n"))
+		return sb.String()
+	}
+
+	columns := newTableColumns(teamEventComparisonColumns...)
+	colorCfg := renderer.ColorizedConfig{
+		Header: renderer.Tint{
+			FG: renderer.Colors{color.FgGreen, color.Bold},
+			BG: renderer.Colors{color.BgBlack},
+		},
+		Column: renderer.Tint{
+			FG:      renderer.Colors{color.FgCyan},
+			Columns: columns.tints(),
+		},
+		Border:    renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+		Separator: renderer.Tint{FG: renderer.Colors{color.FgWhite}},
+	}
+
+	var tableSb strings.Builder
+	table := tablewriter.NewTable(&tableSb,
+		tablewriter.WithRenderer(renderer.NewColorized(colorCfg)),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{PerColumn: columns.headerAlignments()},
+			},
+		}),
+	)
+
+	table.Header(columns.headers())
+
+	for _, event := range comparison.Events {
+		advancedStr := ""
+		if event.Advanced {
+			advancedStr = "✓"
+		}
+
+		rankStr := ""
+		if event.QualRank > 0 {
+			rankStr = strconv.Itoa(event.QualRank)
+		}
+
+		oprStr, npOprStr, ccwmStr, npAvgStr := "", "", "", ""
+		if event.HasMetrics {
+			oprStr = locale.Number("%.2f", event.OPR) + formatDelta(event.OPRDelta)
+			npOprStr = locale.Number("%.2f", event.NpOPR) + formatDelta(event.NpOPRDelta)
+			ccwmStr = locale.Number("%.2f", event.CCWM) + formatDelta(event.CCWMDelta)
+			npAvgStr = locale.Number("%.2f", event.NpAVG) + formatDelta(event.NpAVGDelta)
+		}
+
+		table.Append(columns.row(
+			event.EventCode,
+			event.DateStart.Format("2006-01-02"),
+			rankStr,
+			formatRecord(event.TotalRecord),
+			oprStr,
+			npOprStr,
+			ccwmStr,
+			npAvgStr,
+			advancedStr,
+			strings.Join(event.Awards, ", "),
+		))
+	}
+
+	table.Render()
+	sb.WriteString(tableSb.String())
+
+	// Improvement between the first and latest events
+	first, latest := comparison.First, comparison.Latest
+	if first == nil || latest == nil || first == latest {
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	sb.WriteString(color.YellowString("Improvement (%s → %s):\n", first.EventCode, latest.EventCode))
+	sb.WriteString(color.WhiteString("  OPR:   ") + formatImprovement(first.OPR, latest.OPR) + "\n")
+	sb.WriteString(color.WhiteString("  npOPR: ") + formatImprovement(first.NpOPR, latest.NpOPR) + "\n")
+	sb.WriteString(color.WhiteString("  CCWM:  ") + formatImprovement(first.CCWM, latest.CCWM) + "\n")
+	sb.WriteString(color.WhiteString("  npAVG: ") + formatImprovement(first.NpAVG, latest.NpAVG) + "\n")
+
 	return sb.String()
 }
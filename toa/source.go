@@ -0,0 +1,528 @@
+package toa
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rbrabson/ftc"
+)
+
+const (
+	qualificationLevel = "QUALIFICATION"
+	playoffLevel       = "PLAYOFF"
+)
+
+// eventTypes maps the event types used by The Orange Alliance to the event type codes used by the FTC Events API.
+// Other event types are reported as "5" (Other).
+var eventTypes = map[string]string{
+	"SCRIMMAGE": "0",
+	"LGMEET":    "1",
+	"QUAL":      "2",
+	"LGCMP":     "3",
+	"RCMP":      "4",
+	"SPRRGNL":   "4",
+	"OTHER":     "5",
+	"OFFSSN":    "5",
+	"WRLDCMP":   "6",
+	"SPRQUAL":   "7",
+}
+
+// awards are the awards given at events, keyed by the prefix of The Orange Alliance award key. The award IDs are
+// assigned here, since The Orange Alliance does not number its awards, and are stable across imports.
+var awards = map[string]*ftc.Award{
+	"INS":  {AwardID: 1, Name: "Inspire Award"},
+	"THI":  {AwardID: 2, Name: "Think Award"},
+	"CONN": {AwardID: 3, Name: "Connect Award"},
+	"INV":  {AwardID: 4, Name: "Innovate Award"},
+	"DSGN": {AwardID: 5, Name: "Design Award"},
+	"MOT":  {AwardID: 6, Name: "Motivate Award"},
+	"CTRL": {AwardID: 7, Name: "Control Award"},
+	"PRO":  {AwardID: 8, Name: "Promote Award"},
+	"COMP": {AwardID: 9, Name: "Compass Award"},
+	"JUD":  {AwardID: 10, Name: "Judges' Award"},
+	"WIN":  {AwardID: 11, Name: "Winning Alliance Award"},
+	"FIN":  {AwardID: 12, Name: "Finalist Alliance Award"},
+	"DNSF": {AwardID: 13, Name: "Dean's List Semi-Finalist", ForPerson: true},
+	"DNF":  {AwardID: 14, Name: "Dean's List Finalist", ForPerson: true},
+	"DNW":  {AwardID: 15, Name: "Dean's List Winner", ForPerson: true},
+}
+
+// Source provides The Orange Alliance data in the form returned by the FTC Events API, so it can be used as a data
+// source by the request package. Requests for advancements fail with an error that wraps errors.ErrUnsupported.
+//
+// The Orange Alliance identifies events by keys such as "1819-NC-RAQ", so each event is given an event code. The
+// FIRST event code is used when The Orange Alliance has one; otherwise the code is derived from the event key
+// without the season, such as "NCRAQ". SetEventCode overrides the code of an event, so that events that were
+// imported before keep their event IDs.
+type Source struct {
+	client *Client
+
+	mu      sync.Mutex
+	keys    map[string]string // event code -> event key
+	codes   map[string]string // event key -> event code
+	matches map[string][]*Match
+}
+
+// eventMatch is a played match, along with the number of the match at its tournament level.
+type eventMatch struct {
+	*Match
+	number int
+}
+
+// NewSource returns a data source that requests data from The Orange Alliance using the client.
+func NewSource(client *Client) *Source {
+	return &Source{
+		client:  client,
+		keys:    make(map[string]string),
+		codes:   make(map[string]string),
+		matches: make(map[string][]*Match),
+	}
+}
+
+// Name returns the name of The Orange Alliance.
+func (s *Source) Name() string {
+	return "toa"
+}
+
+// SetEventCode sets the event code that is used for the event with The Orange Alliance event key.
+func (s *Source) SetEventCode(eventKey, eventCode string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	eventCode = strings.ToUpper(eventCode)
+	if previous, ok := s.codes[eventKey]; ok {
+		delete(s.keys, previous)
+	}
+	s.codes[eventKey] = eventCode
+	s.keys[eventCode] = eventKey
+}
+
+// EventKey returns The Orange Alliance event key of the event with the event code. Event keys are known for the
+// events returned by GetEvents and the events set by SetEventCode.
+func (s *Source) EventKey(eventCode string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[strings.ToUpper(eventCode)]
+	return key, ok
+}
+
+// GetAdvancementsTo is not supported by The Orange Alliance.
+func (s *Source) GetAdvancementsTo(season, eventCode string) (*ftc.AdvancementsTo, error) {
+	return nil, fmt.Errorf("The Orange Alliance does not provide event advancements: %w", errors.ErrUnsupported)
+}
+
+// GetAwardListing returns the awards that may be given at events.
+func (s *Source) GetAwardListing(season string) ([]*ftc.Award, error) {
+	listing := make([]*ftc.Award, 0, len(awards))
+	for _, award := range awards {
+		awardCopy := *award
+		listing = append(listing, &awardCopy)
+	}
+	slices.SortFunc(listing, func(a, b *ftc.Award) int {
+		return a.AwardID - b.AwardID
+	})
+	return listing, nil
+}
+
+// GetEvents returns the events held in the season.
+func (s *Source) GetEvents(season string) ([]*ftc.Event, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season %q", season)
+	}
+	events, err := s.client.GetEvents(year)
+	if err != nil {
+		return nil, err
+	}
+
+	ftcEvents := make([]*ftc.Event, 0, len(events))
+	for _, event := range events {
+		eventType, ok := eventTypes[strings.ToUpper(event.EventTypeKey)]
+		if !ok {
+			eventType = eventTypes["OTHER"]
+		}
+		ftcEvent := &ftc.Event{
+			Code:       s.eventCode(event),
+			Name:       event.EventName,
+			FieldCount: event.FieldCount,
+			Published:  true,
+			Type:       eventType,
+			TypeName:   event.EventTypeKey,
+			RegionCode: event.RegionKey,
+			Venue:      event.Venue,
+			City:       event.City,
+			Stateprov:  event.StateProv,
+			Country:    event.Country,
+			Website:    event.Website,
+			Timezone:   event.TimeZone,
+			DateStart:  parseDate(event.StartDate),
+			DateEnd:    parseDate(event.EndDate),
+		}
+		if event.LeagueKey != "" {
+			leagueKey := event.LeagueKey
+			ftcEvent.LeagueCode = &leagueKey
+		}
+		ftcEvents = append(ftcEvents, ftcEvent)
+	}
+	return ftcEvents, nil
+}
+
+// GetTeams returns the teams that attended an event in the season. The Orange Alliance lists teams by event, so the
+// teams of every event in the season are requested.
+func (s *Source) GetTeams(season string) ([]*ftc.Team, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil {
+		return nil, fmt.Errorf("invalid season %q", season)
+	}
+	events, err := s.client.GetEvents(year)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var teams []*ftc.Team
+	for _, event := range events {
+		participants, err := s.client.GetEventTeams(event.EventKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, participant := range participants {
+			team := participant.Team
+			if team == nil || team.TeamNumber == 0 || seen[team.TeamNumber] {
+				continue
+			}
+			seen[team.TeamNumber] = true
+			teams = append(teams, toFTCTeam(team))
+		}
+	}
+	slices.SortFunc(teams, func(a, b *ftc.Team) int {
+		return a.TeamNumber - b.TeamNumber
+	})
+	return teams, nil
+}
+
+// GetEventTeams returns the teams registered for an event.
+func (s *Source) GetEventTeams(season, eventCode string) ([]*ftc.Team, error) {
+	eventKey, err := s.eventKey(season, eventCode)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.client.GetEventTeams(eventKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []*ftc.Team
+	for _, participant := range participants {
+		if participant.Team == nil || participant.Team.TeamNumber == 0 {
+			continue
+		}
+		teams = append(teams, toFTCTeam(participant.Team))
+	}
+	slices.SortFunc(teams, func(a, b *ftc.Team) int {
+		return a.TeamNumber - b.TeamNumber
+	})
+	return teams, nil
+}
+
+// toFTCTeam returns a The Orange Alliance team in the form returned by the FTC Events API.
+func toFTCTeam(team *Team) *ftc.Team {
+	ftcTeam := &ftc.Team{
+		TeamNumber:        team.TeamNumber,
+		DisplayTeamNumber: strconv.Itoa(team.TeamNumber),
+		NameFull:          team.TeamNameLong,
+		NameShort:         team.TeamNameShort,
+		City:              team.City,
+		StateProv:         team.StateProv,
+		Country:           team.Country,
+		RookieYear:        team.RookieYear,
+	}
+	if team.Website != "" {
+		website := team.Website
+		ftcTeam.Website = &website
+	}
+	if team.RobotName != "" {
+		robotName := team.RobotName
+		ftcTeam.RobotName = &robotName
+	}
+	if team.RegionKey != "" {
+		regionKey := team.RegionKey
+		ftcTeam.HomeRegion = &regionKey
+	}
+	return ftcTeam
+}
+
+// GetEventAwards returns the awards given at an event. The series of an award is the number at the end of its
+// award key, so the Inspire Award is series 1 and the second place Inspire Award is series 2.
+func (s *Source) GetEventAwards(season, eventCode string) ([]*ftc.TeamAward, error) {
+	eventKey, err := s.eventKey(season, eventCode)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := s.client.GetEventAwards(eventKey)
+	if err != nil {
+		return nil, err
+	}
+
+	teamAwards := make([]*ftc.TeamAward, 0, len(recipients))
+	for _, recipient := range recipients {
+		teamNumber, err := strconv.Atoi(recipient.TeamKey)
+		if err != nil {
+			continue
+		}
+		prefix := strings.TrimRight(strings.ToUpper(recipient.AwardKey), "0123456789")
+		series, _ := strconv.Atoi(recipient.AwardKey[len(prefix):])
+		award, ok := awards[prefix]
+		if !ok {
+			award = &ftc.Award{AwardID: otherAwardID(prefix), Name: recipient.AwardName}
+		}
+		teamAward := &ftc.TeamAward{
+			AwardID:    award.AwardID,
+			EventCode:  strings.ToUpper(eventCode),
+			Name:       award.Name,
+			Series:     series,
+			TeamNumber: teamNumber,
+		}
+		if recipient.ReceiverName != "" {
+			person := recipient.ReceiverName
+			teamAward.Person = &person
+		}
+		teamAwards = append(teamAwards, teamAward)
+	}
+	return teamAwards, nil
+}
+
+// GetRankings returns the qualification rankings of an event, ordered by rank. The qualifying points, ranking
+// points, and highest qualification score are the sort orders, as they were the ranking criteria of the seasons
+// that The Orange Alliance covers.
+func (s *Source) GetRankings(season, eventCode string) ([]*ftc.Ranking, error) {
+	eventKey, err := s.eventKey(season, eventCode)
+	if err != nil {
+		return nil, err
+	}
+	teamRankings, err := s.client.GetEventRankings(eventKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rankings := make([]*ftc.Ranking, 0, len(teamRankings))
+	for _, ranking := range teamRankings {
+		teamNumber, err := strconv.Atoi(ranking.TeamKey)
+		if err != nil || ranking.Rank == 0 {
+			continue
+		}
+		rankings = append(rankings, &ftc.Ranking{
+			Rank:              ranking.Rank,
+			TeamNumber:        teamNumber,
+			DisplayTeamNumber: ranking.TeamKey,
+			SortOrder1:        ranking.QualifyingPoints,
+			SortOrder2:        ranking.RankingPoints,
+			SortOrder3:        ranking.HighestQualScore,
+			Wins:              ranking.Wins,
+			Losses:            ranking.Losses,
+			Ties:              ranking.Ties,
+			DQ:                ranking.Disqualified,
+			MatchesPlayed:     ranking.Played,
+			MatchesCounted:    ranking.Played,
+		})
+	}
+	slices.SortFunc(rankings, func(a, b *ftc.Ranking) int {
+		return a.Rank - b.Rank
+	})
+	return rankings, nil
+}
+
+// GetMatchResults returns the results of an event's matches at the tournament level. Practice matches are not
+// included. Playoff matches are numbered by series, in the order they were played.
+func (s *Source) GetMatchResults(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.Match, error) {
+	matches, err := s.eventMatches(season, eventCode, tournamentLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	ftcMatches := make([]*ftc.Match, 0, len(matches))
+	for _, match := range matches {
+		level := matchLevel(match.TournamentLevel)
+		ftcMatch := &ftc.Match{
+			ActualStartTime: match.MatchStartTime,
+			Description:     match.MatchName,
+			TournamentLevel: level,
+			MatchNumber:     match.number,
+			ScoreRedFinal:   match.RedScore,
+			ScoreRedFoul:    match.RedPenalty,
+			ScoreRedAuto:    match.RedAutoScore,
+			ScoreBlueFinal:  match.BlueScore,
+			ScoreBlueFoul:   match.BluePenalty,
+			ScoreBlueAuto:   match.BlueAutoScore,
+			Teams:           make([]*ftc.MatchTeam, 0, len(match.Participants)),
+		}
+		if level == playoffLevel {
+			ftcMatch.Series = match.number
+		}
+		for _, participant := range match.Participants {
+			teamNumber, err := strconv.Atoi(participant.TeamKey)
+			if err != nil {
+				continue
+			}
+			ftcMatch.Teams = append(ftcMatch.Teams, &ftc.MatchTeam{
+				TeamNumber: teamNumber,
+				Station:    station(participant.Station),
+				OnField:    true,
+			})
+		}
+		ftcMatches = append(ftcMatches, ftcMatch)
+	}
+	return ftcMatches, nil
+}
+
+// GetEventScores returns the scores of an event's matches at the tournament level. The foul points of an alliance
+// are the penalty points it was awarded, which are included in its total, as they are for the FTC Events API.
+func (s *Source) GetEventScores(season, eventCode string, tournamentLevel ftc.MatchType) ([]*ftc.MatchScores, error) {
+	matches, err := s.eventMatches(season, eventCode, tournamentLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]*ftc.MatchScores, 0, len(matches))
+	for _, match := range matches {
+		level := matchLevel(match.TournamentLevel)
+		matchScores := &ftc.MatchScores{
+			MatchLevel:  level,
+			MatchNumber: match.number,
+			Alliances: []*ftc.MatchAlliance{
+				{
+					Alliance:            "Red",
+					AutoPoints:          match.RedAutoScore,
+					TeleopPoints:        match.RedTeleScore + match.RedEndScore,
+					FoulPointsCommitted: match.RedPenalty,
+					PreFoulTotal:        match.RedScore - match.RedPenalty,
+					TotalPoints:         match.RedScore,
+				},
+				{
+					Alliance:            "Blue",
+					AutoPoints:          match.BlueAutoScore,
+					TeleopPoints:        match.BlueTeleScore + match.BlueEndScore,
+					FoulPointsCommitted: match.BluePenalty,
+					PreFoulTotal:        match.BlueScore - match.BluePenalty,
+					TotalPoints:         match.BlueScore,
+				},
+			},
+		}
+		if level == playoffLevel {
+			matchScores.MatchSeries = match.number
+		}
+		scores = append(scores, matchScores)
+	}
+	return scores, nil
+}
+
+// eventMatches returns the matches of an event at the tournament level, numbered in the order they were played.
+// The matches of an event are requested once and reused, since the match results and scores are both built from
+// them.
+func (s *Source) eventMatches(season, eventCode string, tournamentLevel ftc.MatchType) ([]*eventMatch, error) {
+	eventKey, err := s.eventKey(season, eventCode)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	matches, ok := s.matches[eventKey]
+	s.mu.Unlock()
+	if !ok {
+		matches, err = s.client.GetEventMatches(eventKey)
+		if err != nil {
+			return nil, err
+		}
+		matches = slices.Clone(matches)
+		slices.SortFunc(matches, func(a, b *Match) int {
+			return strings.Compare(a.MatchKey, b.MatchKey)
+		})
+		s.mu.Lock()
+		s.matches[eventKey] = matches
+		s.mu.Unlock()
+	}
+
+	var numbered []*eventMatch
+	for _, match := range matches {
+		if match.TournamentLevel == 0 {
+			continue
+		}
+		if !strings.EqualFold(matchLevel(match.TournamentLevel), string(tournamentLevel)) {
+			continue
+		}
+		numbered = append(numbered, &eventMatch{Match: match, number: len(numbered) + 1})
+	}
+	return numbered, nil
+}
+
+// eventKey returns The Orange Alliance event key of the event with the event code, requesting the season's events
+// if the event key isn't yet known.
+func (s *Source) eventKey(season, eventCode string) (string, error) {
+	if key, ok := s.EventKey(eventCode); ok {
+		return key, nil
+	}
+	if _, err := s.GetEvents(season); err != nil {
+		return "", err
+	}
+	if key, ok := s.EventKey(eventCode); ok {
+		return key, nil
+	}
+	return "", fmt.Errorf("event %s not found in The Orange Alliance season %s", eventCode, season)
+}
+
+// eventCode returns the event code of a The Orange Alliance event, and records the event key for the code.
+func (s *Source) eventCode(event *Event) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if code, ok := s.codes[event.EventKey]; ok {
+		return code
+	}
+
+	code := strings.ToUpper(event.FirstEventCode)
+	if code == "" {
+		_, key, _ := strings.Cut(event.EventKey, "-")
+		code = strings.ToUpper(strings.ReplaceAll(key, "-", ""))
+	}
+	s.codes[event.EventKey] = code
+	s.keys[code] = event.EventKey
+	return code
+}
+
+// matchLevel returns the FTC Events API tournament level of a The Orange Alliance tournament level. The Orange
+// Alliance reports each playoff round separately, while the FTC Events API reports them all as playoffs.
+func matchLevel(tournamentLevel int) string {
+	if tournamentLevel == 1 {
+		return qualificationLevel
+	}
+	return playoffLevel
+}
+
+// station returns the FTC Events API station, such as "Red1", of a The Orange Alliance station, such as 11.
+func station(station int) string {
+	alliance := "Red"
+	if station/10 == 2 {
+		alliance = "Blue"
+	}
+	return alliance + strconv.Itoa(station%10)
+}
+
+// otherAwardID returns an award ID for an award that isn't in the awards map. The ID is derived from the award key
+// prefix, so the same award is given the same ID each time it is imported.
+func otherAwardID(prefix string) int {
+	return 1000 + int(crc32.ChecksumIEEE([]byte(prefix))%9000)
+}
+
+// parseDate parses a date reported by The Orange Alliance, which may or may not include the time.
+func parseDate(s string) ftc.Time {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return ftc.Time(t)
+		}
+	}
+	return ftc.Time{}
+}
@@ -0,0 +1,204 @@
+// Package toa is a client for the API of The Orange Alliance (https://theorangealliance.org). The Orange Alliance
+// retains results for seasons that the FTC Events API no longer provides, and is used to backfill them.
+package toa
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultURL    = "https://theorangealliance.org/api"
+	applicationID = "ftcstanding"
+)
+
+// Client requests data from The Orange Alliance API.
+type Client struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// Event is an event returned by The Orange Alliance API.
+type Event struct {
+	EventKey       string `json:"event_key"`
+	SeasonKey      string `json:"season_key"`
+	RegionKey      string `json:"region_key"`
+	LeagueKey      string `json:"league_key"`
+	EventCode      string `json:"event_code"`
+	FirstEventCode string `json:"first_event_code"`
+	EventTypeKey   string `json:"event_type_key"`
+	DivisionName   string `json:"division_name"`
+	EventName      string `json:"event_name"`
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+	City           string `json:"city"`
+	StateProv      string `json:"state_prov"`
+	Country        string `json:"country"`
+	Venue          string `json:"venue"`
+	Website        string `json:"website"`
+	TimeZone       string `json:"time_zone"`
+	FieldCount     int    `json:"field_count"`
+}
+
+// Match is a match played at an event, as returned by The Orange Alliance API.
+type Match struct {
+	MatchKey        string              `json:"match_key"`
+	EventKey        string              `json:"event_key"`
+	TournamentLevel int                 `json:"tournament_level"`
+	MatchName       string              `json:"match_name"`
+	PlayNumber      int                 `json:"play_number"`
+	MatchStartTime  string              `json:"match_start_time"`
+	RedScore        int                 `json:"red_score"`
+	BlueScore       int                 `json:"blue_score"`
+	RedPenalty      int                 `json:"red_penalty"`
+	BluePenalty     int                 `json:"blue_penalty"`
+	RedAutoScore    int                 `json:"red_auto_score"`
+	BlueAutoScore   int                 `json:"blue_auto_score"`
+	RedTeleScore    int                 `json:"red_tele_score"`
+	BlueTeleScore   int                 `json:"blue_tele_score"`
+	RedEndScore     int                 `json:"red_end_score"`
+	BlueEndScore    int                 `json:"blue_end_score"`
+	Participants    []*MatchParticipant `json:"participants"`
+}
+
+// MatchParticipant is a team that played in a match. Stations 11 through 13 are on the red alliance, and 21
+// through 23 are on the blue alliance.
+type MatchParticipant struct {
+	TeamKey       string `json:"team_key"`
+	Station       int    `json:"station"`
+	StationStatus int    `json:"station_status"`
+	RefStatus     int    `json:"ref_status"`
+}
+
+// Ranking is a team's qualification ranking at an event.
+type Ranking struct {
+	TeamKey          string  `json:"team_key"`
+	Rank             int     `json:"rank"`
+	Wins             int     `json:"wins"`
+	Losses           int     `json:"losses"`
+	Ties             int     `json:"ties"`
+	QualifyingPoints float64 `json:"qualifying_points"`
+	RankingPoints    float64 `json:"ranking_points"`
+	HighestQualScore float64 `json:"highest_qual_score"`
+	Disqualified     int     `json:"disqualified"`
+	Played           int     `json:"played"`
+}
+
+// AwardRecipient is an award given to a team, or to a person on the team, at an event.
+type AwardRecipient struct {
+	AwardKey     string `json:"award_key"`
+	TeamKey      string `json:"team_key"`
+	ReceiverName string `json:"receiver_name"`
+	AwardName    string `json:"award_name"`
+}
+
+// EventParticipant is a team that attended an event.
+type EventParticipant struct {
+	TeamKey    string `json:"team_key"`
+	TeamNumber int    `json:"team_number"`
+	Team       *Team  `json:"team"`
+}
+
+// Team is a team returned by The Orange Alliance API.
+type Team struct {
+	TeamNumber    int    `json:"team_number"`
+	TeamNameShort string `json:"team_name_short"`
+	TeamNameLong  string `json:"team_name_long"`
+	RobotName     string `json:"robot_name"`
+	City          string `json:"city"`
+	StateProv     string `json:"state_prov"`
+	Country       string `json:"country"`
+	RookieYear    int    `json:"rookie_year"`
+	Website       string `json:"website"`
+	RegionKey     string `json:"region_key"`
+}
+
+// New returns a client for The Orange Alliance API that authenticates using the API key. If baseURL is empty, the
+// public API is used.
+func New(baseURL, apiKey string) *Client {
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+	return &Client{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SeasonKey returns the key The Orange Alliance uses for the season that starts in the year, such as "1819" for
+// the 2018 season.
+func SeasonKey(year int) string {
+	return fmt.Sprintf("%02d%02d", year%100, (year+1)%100)
+}
+
+// GetEvents returns the events held in the season.
+func (c *Client) GetEvents(year int) ([]*Event, error) {
+	var events []*Event
+	if err := c.get("/event?season_key="+SeasonKey(year), &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetEventMatches returns the matches played at an event.
+func (c *Client) GetEventMatches(eventKey string) ([]*Match, error) {
+	var matches []*Match
+	if err := c.get(fmt.Sprintf("/event/%s/matches", url.PathEscape(eventKey)), &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// GetEventRankings returns the qualification rankings of an event.
+func (c *Client) GetEventRankings(eventKey string) ([]*Ranking, error) {
+	var rankings []*Ranking
+	if err := c.get(fmt.Sprintf("/event/%s/rankings", url.PathEscape(eventKey)), &rankings); err != nil {
+		return nil, err
+	}
+	return rankings, nil
+}
+
+// GetEventAwards returns the awards given at an event.
+func (c *Client) GetEventAwards(eventKey string) ([]*AwardRecipient, error) {
+	var awards []*AwardRecipient
+	if err := c.get(fmt.Sprintf("/event/%s/awards", url.PathEscape(eventKey)), &awards); err != nil {
+		return nil, err
+	}
+	return awards, nil
+}
+
+// GetEventTeams returns the teams that attended an event.
+func (c *Client) GetEventTeams(eventKey string) ([]*EventParticipant, error) {
+	var teams []*EventParticipant
+	if err := c.get(fmt.Sprintf("/event/%s/teams", url.PathEscape(eventKey)), &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// get sends a GET request to The Orange Alliance API and decodes the JSON response into v.
+func (c *Client) get(path string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-TOA-Key", c.apiKey)
+	req.Header.Set("X-Application-Origin", applicationID)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to The Orange Alliance %s failed with status %d", path, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
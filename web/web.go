@@ -0,0 +1,36 @@
+// Package web serves a small browser UI for the standings of an event, built on the API server's endpoints. It is
+// embedded in the binary so no other files are needed to run it.
+package web
+
+import (
+	"embed"
+	"html/template"
+	"log/slog"
+	"net/http"
+)
+
+//go:embed index.html
+var files embed.FS
+
+var index = template.Must(template.ParseFS(files, "index.html"))
+
+// page is the data the UI's page is rendered with.
+type page struct {
+	Season string
+	Events []string // Events shown first in the event list, such as the events being synced
+}
+
+// Handler returns a handler that serves the UI at the root path for the season. The events are listed first when
+// choosing an event, and the first one is shown when the page is opened.
+func Handler(season string, events []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := index.Execute(w, page{Season: season, Events: events}); err != nil {
+			slog.Error("failed to render web UI", "error", err)
+		}
+	})
+}